@@ -169,6 +169,9 @@ type avroMetadata map[string]interface{}
 type avroEnvelopeOpts struct {
 	beforeField, afterField, recordField bool
 	updatedField, resolvedField          bool
+	// debeziumFields adds the op, ts_ms and source fields of the debezium
+	// envelope.
+	debeziumFields bool
 }
 
 // avroEnvelopeRecord is an `avroRecord` that wraps a changed SQL row and some
@@ -178,6 +181,8 @@ type avroEnvelopeRecord struct {
 
 	opts                  avroEnvelopeOpts
 	before, after, record *avroDataRecord
+	// source is the record of the source field of the debezium envelope.
+	source *avroRecord
 }
 
 // typeToAvroSchema converts a database type to an avro field
@@ -593,6 +598,28 @@ func typeToAvroSchema(typ *types.T) (*avroSchemaField, error) {
 				return tree.ParseDJSON(x.(string))
 			},
 		)
+	case types.JsonpathFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return d.(*tree.DJsonpath).Jsonpath.String(), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDJsonpath(x.(string))
+			},
+		)
+	case types.PointFamily, types.BoxFamily, types.PathFamily, types.PolygonFamily,
+		types.CircleFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				d, _, err := tree.ParseAndRequireString(typ, x.(string), nil /* ctx */)
+				return d, err
+			},
+		)
 	case types.TSQueryFamily:
 		setNullable(
 			avroSchemaString,
@@ -955,6 +982,32 @@ func envelopeToAvroSchema(
 		}
 		schema.Fields = append(schema.Fields, recordField)
 	}
+	if opts.debeziumFields {
+		optionalField := func(name string, typ avroSchemaType) *avroSchemaField {
+			return &avroSchemaField{
+				SchemaType: []avroSchemaType{avroSchemaNull, typ},
+				Name:       name,
+				Default:    nil,
+			}
+		}
+		schema.source = &avroRecord{
+			Name:       SQLNameToAvroName(topic) + `_source`,
+			SchemaType: `record`,
+			Namespace:  namespace,
+			Fields: []*avroSchemaField{
+				optionalField(`connector`, avroSchemaString),
+				optionalField(`table`, avroSchemaString),
+				optionalField(`ts_ms`, avroSchemaLong),
+				optionalField(`mvcc_timestamp`, avroSchemaString),
+				optionalField(`snapshot`, avroSchemaString),
+			},
+		}
+		schema.Fields = append(schema.Fields,
+			optionalField(`op`, avroSchemaString),
+			optionalField(`ts_ms`, avroSchemaLong),
+			optionalField(`source`, schema.source),
+		)
+	}
 
 	schemaJSON, err := json.Marshal(schema)
 	if err != nil {
@@ -1033,6 +1086,23 @@ func (r *avroEnvelopeRecord) BinaryFromRow(
 			native[`resolved`] = goavro.Union(avroUnionKey(avroSchemaString), timestampToString(ts))
 		}
 	}
+	if r.opts.debeziumFields {
+		evCtx, ok := meta[`debezium`].(eventContext)
+		if !ok {
+			return nil, changefeedbase.WithTerminalError(
+				errors.AssertionFailedf(`missing debezium metadata: %v`, meta))
+		}
+		delete(meta, `debezium`)
+		native[`op`] = goavro.Union(avroSchemaString, debeziumOp(evCtx, afterRow, beforeRow))
+		native[`ts_ms`] = goavro.Union(avroSchemaLong, timestampToMillis(evCtx.updated))
+		native[`source`] = goavro.Union(avroUnionKey(r.source), map[string]interface{}{
+			`connector`:      goavro.Union(avroSchemaString, debeziumConnectorName),
+			`table`:          goavro.Union(avroSchemaString, afterRow.TableName),
+			`ts_ms`:          goavro.Union(avroSchemaLong, timestampToMillis(evCtx.mvcc)),
+			`mvcc_timestamp`: goavro.Union(avroSchemaString, timestampToString(evCtx.mvcc)),
+			`snapshot`:       goavro.Union(avroSchemaString, debeziumSnapshot(evCtx)),
+		})
+	}
 	for k := range meta {
 		return nil, changefeedbase.WithTerminalError(errors.AssertionFailedf(`unhandled meta key: %s`, k))
 	}
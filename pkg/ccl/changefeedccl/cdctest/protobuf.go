@@ -0,0 +1,200 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package cdctest
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// protobufField is a field of a message in a proto3 schema.
+type protobufField struct {
+	name     string
+	typeName string
+}
+
+// protobufMessage is a message in a proto3 schema.
+type protobufMessage struct {
+	fields map[protowire.Number]protobufField
+	// names lists the names of the fields, so that unset fields can be
+	// reported as null.
+	names []string
+}
+
+// protobufSchema is a parsed proto3 schema, as generated by the changefeed
+// protobuf encoder.
+type protobufSchema struct {
+	// messages lists the messages in the order they are declared, which is
+	// the order the confluent wire format indexes refer to.
+	messages []string
+	byName   map[string]*protobufMessage
+}
+
+// parseProtobufSchema parses the subset of the proto3 syntax generated by the
+// changefeed protobuf encoder: top-level messages made of scalar or message
+// fields.
+func parseProtobufSchema(schema string) (*protobufSchema, error) {
+	s := &protobufSchema{byName: make(map[string]*protobufMessage)}
+	var cur *protobufMessage
+	for _, line := range strings.Split(schema, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "syntax "):
+		case strings.HasPrefix(line, "message ") && strings.HasSuffix(line, "{"):
+			name := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "message "), "{"))
+			cur = &protobufMessage{fields: make(map[protowire.Number]protobufField)}
+			s.messages = append(s.messages, name)
+			s.byName[name] = cur
+		case line == "}":
+			cur = nil
+		default:
+			if cur == nil {
+				return nil, errors.Errorf("unexpected line outside of a message: %q", line)
+			}
+			words := strings.Fields(strings.TrimSuffix(strings.TrimPrefix(line, "optional "), ";"))
+			if len(words) != 4 || words[2] != "=" {
+				return nil, errors.Errorf("unexpected field definition: %q", line)
+			}
+			number, err := strconv.Atoi(words[3])
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing field definition %q", line)
+			}
+			cur.fields[protowire.Number(number)] = protobufField{name: words[1], typeName: words[0]}
+			cur.names = append(cur.names, words[1])
+		}
+	}
+	return s, nil
+}
+
+// decode converts the protobuf encoding of the given message into its Go
+// native representation. Unset fields are nil.
+func (s *protobufSchema) decode(msgName string, b []byte) (map[string]interface{}, error) {
+	msg, ok := s.byName[msgName]
+	if !ok {
+		return nil, errors.Errorf("unknown message %s", msgName)
+	}
+	native := make(map[string]interface{}, len(msg.names))
+	for _, name := range msg.names {
+		native[name] = nil
+	}
+	for len(b) > 0 {
+		number, wireType, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		field, ok := msg.fields[number]
+		if !ok {
+			return nil, errors.Errorf("unknown field %d in message %s", number, msgName)
+		}
+		var v interface{}
+		switch wireType {
+		case protowire.VarintType:
+			var x uint64
+			x, n = protowire.ConsumeVarint(b)
+			if field.typeName == "bool" {
+				v = protowire.DecodeBool(x)
+			} else {
+				v = int64(x)
+			}
+		case protowire.Fixed64Type:
+			var x uint64
+			x, n = protowire.ConsumeFixed64(b)
+			v = math.Float64frombits(x)
+		case protowire.BytesType:
+			var x []byte
+			x, n = protowire.ConsumeBytes(b)
+			switch field.typeName {
+			case "string":
+				v = string(x)
+			case "bytes":
+				v = x
+			default:
+				nested, err := s.decode(field.typeName, x)
+				if err != nil {
+					return nil, err
+				}
+				v = nested
+			}
+		default:
+			return nil, errors.Errorf("unexpected wire type %d for field %s", wireType, field.name)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		native[field.name] = v
+	}
+	return native, nil
+}
+
+// EncodedProtobufToNative decodes bytes that were previously encoded by the
+// confluent protobuf encoder, into GO native representation.
+func (r *SchemaRegistry) EncodedProtobufToNative(b []byte) (interface{}, error) {
+	if len(b) == 0 || b[0] != changefeedbase.ConfluentAvroWireFormatMagic {
+		return nil, errors.Errorf(`bad magic byte`)
+	}
+	b = b[1:]
+	if len(b) < 4 {
+		return nil, errors.Errorf(`missing registry id`)
+	}
+	id := int32(binary.BigEndian.Uint32(b[:4]))
+	b = b[4:]
+
+	// The message indexes are a zigzag-encoded count, followed by as many
+	// indexes. A single 0 is shorthand for the first message.
+	count, n := binary.Varint(b)
+	if n <= 0 {
+		return nil, errors.Errorf(`missing message indexes`)
+	}
+	b = b[n:]
+	msgIdx := int64(0)
+	for i := int64(0); i < count; i++ {
+		if i > 0 {
+			return nil, errors.Errorf(`nested messages are not supported`)
+		}
+		msgIdx, n = binary.Varint(b)
+		if n <= 0 {
+			return nil, errors.Errorf(`malformed message indexes`)
+		}
+		b = b[n:]
+	}
+
+	r.mu.Lock()
+	protoSchema := r.mu.schemas[id]
+	r.mu.Unlock()
+	schema, err := parseProtobufSchema(protoSchema)
+	if err != nil {
+		return nil, err
+	}
+	if msgIdx < 0 || msgIdx >= int64(len(schema.messages)) {
+		return nil, errors.Errorf(`message index %d out of range`, msgIdx)
+	}
+	return schema.decode(schema.messages[msgIdx], b)
+}
+
+// ProtobufToJSON converts protobuf bytes to their JSON representation. Unset
+// fields are rendered as nulls.
+func (r *SchemaRegistry) ProtobufToJSON(protoBytes []byte) ([]byte, error) {
+	if len(protoBytes) == 0 {
+		return nil, nil
+	}
+	native, err := r.EncodedProtobufToNative(protoBytes)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(native)
+}
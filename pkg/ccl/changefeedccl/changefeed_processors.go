@@ -66,6 +66,14 @@ type changeAggregator struct {
 	// sink is the Sink to write rows to. Resolved timestamps are never written
 	// by changeAggregator.
 	sink EventSink
+	// kafkaTxn is the partition of the changefeed whose rows the sink commits
+	// if it is a transactional Kafka sink, in which case rows are held by the
+	// partition until they're committed, and spans are only checkpointed up to
+	// the frontier it committed.
+	kafkaTxn *kafkaTxnPartition
+	// deadLetterQueue, if set, is where rows which permanently fail to be
+	// encoded or emitted are written.
+	deadLetterQueue *deadLetterQueue
 	// changedRowBuf, if non-nil, contains changed rows to be emitted. Anything
 	// queued in `resolvedSpanBuf` is dependent on these having been emitted, so
 	// this one must be empty before moving on to that one.
@@ -283,8 +291,12 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		}
 	}
 
+	ca.kafkaTxn = &kafkaTxnPartition{
+		id:       ca.flowCtx.NodeID.SQLInstanceID().String(),
+		frontier: ca.frontier.SpanFrontier(),
+	}
 	ca.sink, err = getEventSink(ctx, ca.flowCtx.Cfg, ca.spec.Feed, timestampOracle,
-		ca.spec.User(), ca.spec.JobID, recorder)
+		ca.spec.User(), ca.spec.JobID, ca.kafkaTxn, recorder)
 	if err != nil {
 		err = changefeedbase.MarkRetryableError(err)
 		// Early abort in the case that there is an error creating the sink.
@@ -293,6 +305,15 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		return
 	}
 
+	ca.deadLetterQueue, err = makeDeadLetterQueue(ctx, ca.flowCtx.Cfg, ca.spec.Feed,
+		ca.spec.User(), ca.spec.JobID, ca.sliMetrics)
+	if err != nil {
+		err = changefeedbase.MarkRetryableError(err)
+		ca.MoveToDraining(err)
+		ca.cancel()
+		return
+	}
+
 	// This is the correct point to set up certain hooks depending on the sink
 	// type.
 	if b, ok := ca.sink.(*bufferSink); ok {
@@ -320,7 +341,7 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 	ca.sink = &errorWrapperSink{wrapped: ca.sink}
 	ca.eventConsumer, ca.sink, err = newEventConsumer(
 		ctx, ca.flowCtx.Cfg, ca.spec, feed, ca.frontier.SpanFrontier(), kvFeedHighWater,
-		ca.sink, ca.metrics, ca.sliMetrics, ca.knobs)
+		ca.sink, ca.kafkaTxn, ca.deadLetterQueue, ca.metrics, ca.sliMetrics, ca.knobs)
 
 	if err != nil {
 		// Early abort in the case that there is an error setting up the consumption.
@@ -520,6 +541,9 @@ func (ca *changeAggregator) close() {
 		// Best effort: context is often cancel by now, so we expect to see an error
 		_ = ca.sink.Close()
 	}
+	if ca.deadLetterQueue != nil {
+		_ = ca.deadLetterQueue.Close()
+	}
 	ca.memAcc.Close(ca.Ctx())
 	if ca.kvFeedMemMon != nil {
 		ca.kvFeedMemMon.Stop(ca.Ctx())
@@ -546,7 +570,7 @@ func (ca *changeAggregator) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMet
 
 	// helper to iterate frontier and return the list of changefeed frontier spans.
 	getFrontierSpans := func() (spans []execinfrapb.ChangefeedMeta_FrontierSpan) {
-		ca.frontier.Entries(func(r roachpb.Span, ts hlc.Timestamp) (done span.OpResult) {
+		ca.kafkaTxn.checkpointable(ca.frontier.SpanFrontier()).Entries(func(r roachpb.Span, ts hlc.Timestamp) (done span.OpResult) {
 			spans = append(spans,
 				execinfrapb.ChangefeedMeta_FrontierSpan{
 					Span:      r,
@@ -657,7 +681,20 @@ func (ca *changeAggregator) tick() error {
 		}
 		return ca.noteResolvedSpan(resolved)
 	case kvevent.TypeFlush:
-		return ca.flushBufferedEvents()
+		released := ca.kafkaTxn.releasedRows()
+		if err := ca.flushBufferedEvents(); err != nil {
+			return err
+		}
+		// The span frontier only advances once the events the kvfeed is blocked
+		// on are added to the buffer, so if the flush released no held row, the
+		// changefeed can't make progress.
+		if held := ca.kafkaTxn.heldRows(); held > 0 && ca.kafkaTxn.releasedRows() == released {
+			return errors.WithHintf(
+				errors.Newf("the memory budget of the changefeed is used up by %d rows "+
+					"which are held until their spans are resolved", held),
+				"consider increasing %s", changefeedbase.PerChangefeedMemLimit.Key())
+		}
+		return nil
 	}
 
 	return nil
@@ -667,7 +704,22 @@ func (ca *changeAggregator) flushBufferedEvents() error {
 	if err := ca.eventConsumer.Flush(ca.Ctx()); err != nil {
 		return err
 	}
-	return ca.sink.Flush(ca.Ctx())
+	err := ca.sink.Flush(ca.Ctx())
+	if ca.deadLetterQueue == nil {
+		return err
+	}
+	// Rows which could not be encoded and rows which the sink gave up on are
+	// written to the dead letter queue before the flush completes, so that
+	// they're never lost to a checkpoint.
+	var undeliverable *undeliverableRowsError
+	if errors.As(err, &undeliverable) {
+		for _, r := range undeliverable.rows {
+			ca.deadLetterQueue.Add(r)
+		}
+	} else if err != nil {
+		return err
+	}
+	return ca.deadLetterQueue.Flush(ca.Ctx())
 }
 
 // noteResolvedSpan periodically flushes Frontier progress from the current
@@ -727,7 +779,7 @@ func (ca *changeAggregator) flushFrontier() error {
 
 	// Iterate frontier spans and build a list of spans to emit.
 	var batch jobspb.ResolvedSpans
-	ca.frontier.Entries(func(s roachpb.Span, ts hlc.Timestamp) span.OpResult {
+	ca.kafkaTxn.checkpointable(ca.frontier.SpanFrontier()).Entries(func(s roachpb.Span, ts hlc.Timestamp) span.OpResult {
 		boundaryType := jobspb.ResolvedSpan_NONE
 		if ca.frontier.boundaryTime.Equal(ts) {
 			boundaryType = ca.frontier.boundaryType
@@ -1085,7 +1137,7 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 	}
 	cf.sliMetrics = sli
 	cf.sink, err = getResolvedTimestampSink(ctx, cf.flowCtx.Cfg, cf.spec.Feed, nilOracle,
-		cf.spec.User(), cf.spec.JobID, sli)
+		cf.spec.User(), cf.spec.JobID, &kafkaTxnPartition{id: "frontier"}, sli)
 
 	if err != nil {
 		err = changefeedbase.MarkRetryableError(err)
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"net/url"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupresolver"
@@ -41,9 +42,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/asof"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -642,6 +645,11 @@ func createChangefeedJobRecord(
 
 	if details.SinkURI == `` {
 
+		if opts.IsSet(changefeedbase.OptDeadLetterQueue) {
+			return nil, errors.Errorf(`%s is not supported by core changefeeds`,
+				changefeedbase.OptDeadLetterQueue)
+		}
+
 		if details.Select != `` {
 			if err := utilccl.CheckEnterpriseEnabled(
 				p.ExecCfg().Settings, p.ExecCfg().NodeInfo.LogicalClusterID(), "CHANGEFEED",
@@ -686,6 +694,9 @@ func createChangefeedJobRecord(
 	// but are inappropriate for the provided sink.
 	// TODO: Ideally those option validations would happen in validateDetails()
 	// earlier, like the others.
+	if err := validateDeadLetterQueue(ctx, p, jobID, details, opts); err != nil {
+		return nil, err
+	}
 	err = validateSink(ctx, p, jobID, details, opts)
 	if err != nil {
 		return nil, err
@@ -906,7 +917,7 @@ func validateSink(
 	}
 	var nilOracle timestampLowerBoundOracle
 	canarySink, err := getAndDialSink(ctx, &p.ExecCfg().DistSQLSrv.ServerConfig, details,
-		nilOracle, p.User(), jobID, sli)
+		nilOracle, p.User(), jobID, nil /* kafkaTxn */, sli)
 	if err != nil {
 		return err
 	}
@@ -944,6 +955,56 @@ func validateSink(
 	return nil
 }
 
+// validateDeadLetterQueue checks that the dead_letter_queue of the changefeed,
+// if any, can be written to. A sink is checked by dialing a canary sink, like
+// validateSink does. A table must exist, have the deadLetterQueueColumns and be
+// writable by the user; its name is then fully qualified in opts so that the
+// changefeed keeps writing to the same table whatever the current database.
+func validateDeadLetterQueue(
+	ctx context.Context,
+	p sql.PlanHookState,
+	jobID jobspb.JobID,
+	details jobspb.ChangefeedDetails,
+	opts changefeedbase.StatementOptions,
+) error {
+	dest, ok := opts.GetDeadLetterQueue()
+	if !ok {
+		return nil
+	}
+
+	if changefeedbase.IsDeadLetterQueueURI(dest) {
+		var nilOracle timestampLowerBoundOracle
+		canarySink, err := getAndDialSink(ctx, &p.ExecCfg().DistSQLSrv.ServerConfig,
+			deadLetterQueueDetails(details, dest), nilOracle, p.User(), jobID, nil, /* kafkaTxn */
+			(*sliMetrics)(nil))
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s", changefeedbase.OptDeadLetterQueue)
+		}
+		return canarySink.Close()
+	}
+
+	tn, err := parser.ParseQualifiedTableName(dest)
+	if err != nil {
+		return errors.Wrapf(err, "invalid %s", changefeedbase.OptDeadLetterQueue)
+	}
+	_, desc, err := p.ResolveMutableTableDescriptor(ctx, tn, true /* required */, tree.ResolveRequireTableDesc)
+	if err != nil {
+		return errors.Wrapf(err, "invalid %s", changefeedbase.OptDeadLetterQueue)
+	}
+	if err := p.CheckPrivilege(ctx, desc, privilege.INSERT); err != nil {
+		return err
+	}
+	for _, name := range deadLetterQueueColumns {
+		if catalog.FindColumnByName(desc, name) == nil {
+			return pgerror.Newf(pgcode.UndefinedColumn,
+				"%s table %s has no column %q; it needs columns %s",
+				changefeedbase.OptDeadLetterQueue, dest, name, strings.Join(deadLetterQueueColumns, ", "))
+		}
+	}
+	opts.SetDeadLetterQueue(tn.FQString())
+	return nil
+}
+
 func requiresKeyInValue(s Sink) bool {
 	switch s.getConcreteType() {
 	case sinkTypeCloudstorage, sinkTypeWebhook:
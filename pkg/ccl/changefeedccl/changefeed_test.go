@@ -740,6 +740,69 @@ func TestChangefeedEnvelope(t *testing.T) {
 	cdcTest(t, testFn, feedTestRestrictSinks("sinkless", "enterprise", "kafka"))
 }
 
+func TestChangefeedDebeziumEnvelope(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// assertDebeziumPayloads checks the payloads of the debezium envelope,
+	// ignoring the timestamps, which are only checked for presence.
+	assertDebeziumPayloads := func(t *testing.T, f cdctest.TestFeed, expected []string) {
+		t.Helper()
+		msgs, err := readNextMessages(context.Background(), f, len(expected))
+		require.NoError(t, err)
+		var actual []string
+		for _, m := range msgs {
+			var value map[string]interface{}
+			require.NoError(t, json.Unmarshal(m.Value, &value))
+			source, ok := value["source"].(map[string]interface{})
+			require.True(t, ok, "missing source in %s", m.Value)
+			for _, ts := range []struct {
+				obj map[string]interface{}
+				key string
+			}{{value, "ts_ms"}, {source, "ts_ms"}, {source, "mvcc_timestamp"}} {
+				require.Contains(t, ts.obj, ts.key)
+				delete(ts.obj, ts.key)
+			}
+			reformatted, err := reformatJSON(value)
+			require.NoError(t, err)
+			actual = append(actual, fmt.Sprintf(`%s: %s->%s`, m.Topic, m.Key, reformatted))
+		}
+		sort.Strings(expected)
+		sort.Strings(actual)
+		require.Equal(t, expected, actual)
+	}
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'a')`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH envelope='debezium'`)
+		defer closeFeed(t, foo)
+
+		// Rows emitted by the initial scan are snapshot reads.
+		assertDebeziumPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "a"}, "before": null, "op": "r", ` +
+				`"source": {"connector": "cockroachdb", "snapshot": "true", "table": "foo"}}`,
+		})
+
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'b')`)
+		sqlDB.Exec(t, `UPDATE foo SET b = 'c' WHERE a = 1`)
+		sqlDB.Exec(t, `DELETE FROM foo WHERE a = 2`)
+		assertDebeziumPayloads(t, foo, []string{
+			`foo: [2]->{"after": {"a": 2, "b": "b"}, "before": null, "op": "c", ` +
+				`"source": {"connector": "cockroachdb", "snapshot": "false", "table": "foo"}}`,
+			`foo: [1]->{"after": {"a": 1, "b": "c"}, "before": {"a": 1, "b": "a"}, "op": "u", ` +
+				`"source": {"connector": "cockroachdb", "snapshot": "false", "table": "foo"}}`,
+			`foo: [2]->{"after": null, "before": {"a": 2, "b": "b"}, "op": "d", ` +
+				`"source": {"connector": "cockroachdb", "snapshot": "false", "table": "foo"}}`,
+		})
+	}
+
+	// some sinks are incompatible with envelope
+	cdcTest(t, testFn, feedTestRestrictSinks("sinkless", "enterprise", "kafka"))
+}
+
 func TestChangefeedFullTableName(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -956,6 +1019,80 @@ func TestChangefeedMVCCTimestamps(t *testing.T) {
 	cdcTest(t, testFn)
 }
 
+func TestChangefeedTransactionMetadata(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// readTxns reads the next n rows and returns the transaction metadata of
+	// each of them by key.
+	readTxns := func(t *testing.T, f cdctest.TestFeed, n int) map[string]map[string]interface{} {
+		t.Helper()
+		msgs, err := readNextMessages(context.Background(), f, n)
+		require.NoError(t, err)
+		txns := make(map[string]map[string]interface{}, n)
+		for _, m := range msgs {
+			var value map[string]interface{}
+			require.NoError(t, json.Unmarshal(m.Value, &value))
+			require.Contains(t, value, "txn", "missing txn in %s", m.Value)
+			txn, _ := value["txn"].(map[string]interface{})
+			txns[string(m.Key)] = txn
+		}
+		return txns
+	}
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1)`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH transaction_metadata, resolved`)
+		defer closeFeed(t, foo)
+
+		// The transactions of rows emitted by the initial scan are not known.
+		txns := readTxns(t, foo, 1)
+		require.Contains(t, txns, `[1]`)
+		require.Nil(t, txns[`[1]`])
+
+		// Neither are those of rows emitted by the catch-up scan of the
+		// rangefeed, so wait for the rangefeed to checkpoint before writing.
+		first, _ := expectResolvedTimestamp(t, foo)
+		for {
+			if ts, _ := expectResolvedTimestamp(t, foo); first.Less(ts) {
+				break
+			}
+		}
+
+		sqlDB.Exec(t, `BEGIN; INSERT INTO foo VALUES (2), (3); INSERT INTO foo VALUES (4); COMMIT`)
+		txns = readTxns(t, foo, 3)
+		id := txns[`[2]`]["id"]
+		require.NotEmpty(t, id)
+		var counts []float64
+		for _, key := range []string{`[2]`, `[3]`, `[4]`} {
+			require.Equal(t, id, txns[key]["id"], "row %s", key)
+			counts = append(counts, txns[key]["event_count"].(float64))
+		}
+		sort.Float64s(counts)
+		require.Equal(t, []float64{1, 2, 3}, counts)
+
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (5)`)
+		txns = readTxns(t, foo, 1)
+		require.NotEmpty(t, txns[`[5]`]["id"])
+		require.NotEqual(t, id, txns[`[5]`]["id"])
+		require.Equal(t, float64(1), txns[`[5]`]["event_count"])
+
+		// The transactions of the rows emitted by a schema change backfill are
+		// not known either, and the rows are emitted without them.
+		sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN b INT DEFAULT 0`)
+		txns = readTxns(t, foo, 5)
+		for _, key := range []string{`[1]`, `[2]`, `[3]`, `[4]`, `[5]`} {
+			require.Contains(t, txns, key)
+			require.Nil(t, txns[key], "row %s", key)
+		}
+	}
+
+	cdcTest(t, testFn)
+}
+
 func TestChangefeedResolvedFrequency(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -4846,6 +4983,29 @@ func TestChangefeedErrors(t *testing.T) {
 		t, `topic_in_value is only usable with envelope=wrapped`,
 		`CREATE CHANGEFEED FOR foo INTO $1 WITH topic_in_value, envelope='row'`, `kafka://nope`,
 	)
+	sqlDB.ExpectErr(
+		t, `transaction_metadata is only usable with envelope=wrapped`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH transaction_metadata, envelope='row'`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `transaction_metadata is only usable with format=json`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH transaction_metadata, format='avro', confluent_schema_registry=$2`,
+		`kafka://nope`, schemaReg.URL(),
+	)
+
+	// The debezium envelope has its own metadata fields.
+	sqlDB.ExpectErr(
+		t, `updated is not supported with envelope=debezium`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH updated, envelope='debezium'`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `key_in_value is not supported with envelope=debezium`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH key_in_value, envelope='debezium'`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `envelope=debezium is not supported with format=protobuf`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH format='protobuf', envelope='debezium'`, `kafka://nope`,
+	)
 
 	// WITH initial_scan and no_initial_scan disallowed
 	sqlDB.ExpectErr(
@@ -5003,6 +5163,11 @@ func TestChangefeedErrors(t *testing.T) {
 		`CREATE CHANGEFEED FOR foo INTO $1 WITH envelope='row'`,
 		`webhook-https://fake-host`,
 	)
+	sqlDB.ExpectErr(
+		t, `this sink is incompatible with envelope=debezium`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH envelope='debezium'`,
+		`webhook-https://fake-host`,
+	)
 	sqlDB.ExpectErr(
 		t, `invalid sink config, all values must be non-negative`,
 		`CREATE CHANGEFEED FOR foo INTO $1 WITH webhook_sink_config='{"Flush": {"Messages": -100, "Frequency": "1s"}}'`,
@@ -5073,6 +5238,36 @@ func TestChangefeedErrors(t *testing.T) {
 		t, `unknown on_error: not_valid, valid values are 'pause' and 'fail'`,
 		`CREATE CHANGEFEED FOR foo into $1 WITH on_error='not_valid'`,
 		`kafka://nope`)
+
+	// Sanity check dead_letter_queue option
+	sqlDB.ExpectErr(
+		t, `dead_letter_queue is not supported by core changefeeds`,
+		`EXPERIMENTAL CHANGEFEED FOR foo WITH dead_letter_queue='dlq'`,
+	)
+	sqlDB.ExpectErr(
+		t, `invalid dead_letter_queue: relation "dlq" does not exist`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH dead_letter_queue='dlq'`,
+		`kafka://nope`,
+	)
+	sqlDB.Exec(t, `CREATE TABLE dlq (job_id INT, topic STRING, key BYTES, value BYTES)`)
+	sqlDB.ExpectErr(
+		t, `dead_letter_queue table dlq has no column "updated"`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH dead_letter_queue='dlq'`,
+		`kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `invalid dead_letter_queue`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH dead_letter_queue=$2`,
+		`kafka://nope`, `kafka://nope/?tls_enabled=foo`,
+	)
+	// Only Kafka sinks hand back the rows they fail to deliver.
+	for _, sink := range []string{`experimental-nodelocal://1/bar`, `webhook-https://fake-host`} {
+		sqlDB.ExpectErr(
+			t, `this sink is incompatible with option dead_letter_queue`,
+			`CREATE CHANGEFEED FOR foo INTO $1 WITH dead_letter_queue=$2`,
+			sink, `experimental-nodelocal://1/dlq`,
+		)
+	}
 }
 
 func TestChangefeedDescription(t *testing.T) {
@@ -8356,6 +8551,135 @@ func TestChangefeedKafkaMessageTooLarge(t *testing.T) {
 	cdcTest(t, testFn, feedTestForceSink(`kafka`))
 }
 
+func TestChangefeedKafkaTransactional(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		var mu syncutil.Mutex
+		txnIDs := make(map[string]struct{})
+		knobs := mustBeKafkaFeedFactory(f).knobs
+		knobs.kafkaInterceptor = func(m *sarama.ProducerMessage, client kafkaClient) error {
+			mu.Lock()
+			defer mu.Unlock()
+			txnIDs[client.Config().Producer.Transaction.ID] = struct{}{}
+			return nil
+		}
+
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1)`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH resolved, `+
+			`kafka_sink_config='{"Transactional": true}'`)
+		defer closeFeed(t, foo)
+
+		// The rows of the initial scan are committed once their span is scanned.
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1}}`,
+		})
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (3)`)
+		assertPayloads(t, foo, []string{
+			`foo: [2]->{"after": {"a": 2}}`,
+			`foo: [3]->{"after": {"a": 3}}`,
+		})
+		expectResolvedTimestamp(t, foo)
+
+		// So are the rows of schema change backfills.
+		sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN b INT DEFAULT 0`)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "b": 0}}`,
+			`foo: [2]->{"after": {"a": 2, "b": 0}}`,
+			`foo: [3]->{"after": {"a": 3, "b": 0}}`,
+		})
+
+		// The frontier and the aggregator, which is named after its SQL
+		// instance, produce with a transactional ID derived from the job.
+		jobID := foo.(cdctest.EnterpriseTestFeed).JobID()
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, map[string]struct{}{
+			kafkaTransactionalID(jobID, "frontier"):                        {},
+			kafkaTransactionalID(jobID, s.Server.SQLInstanceID().String()): {},
+		}, txnIDs)
+	}
+
+	cdcTest(t, testFn, feedTestForceSink(`kafka`))
+}
+
+func TestChangefeedDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		knobs := mustBeKafkaFeedFactory(f).knobs
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE dlq (
+  job_id INT, topic STRING, key BYTES, value BYTES, updated DECIMAL, mvcc_timestamp DECIMAL, error STRING
+)`)
+		// The changefeeds are created by a user which needs to be able to write to
+		// the dead letter queue.
+		sqlDB.Exec(t, `GRANT INSERT ON dlq TO EnterpriseFeedUser`)
+
+		registry := s.Server.JobRegistry().(*jobs.Registry)
+		sli, err := registry.MetricsStruct().Changefeed.(*Metrics).getSLIMetrics(defaultSLIScope)
+		require.NoError(t, err)
+
+		t.Run(`rows rejected by the sink`, func(t *testing.T) {
+			knobs.kafkaInterceptor = func(m *sarama.ProducerMessage, client kafkaClient) error {
+				if key, ok := m.Key.(sarama.ByteEncoder); ok && string(key) == `[2]` {
+					return sarama.ErrMessageSizeTooLarge
+				}
+				return nil
+			}
+			defer func() { knobs.kafkaInterceptor = nil }()
+
+			sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY)`)
+			sqlDB.Exec(t, `INSERT INTO foo VALUES (1), (2), (3)`)
+			writes := sli.DeadLetterQueueWrites.Value()
+
+			foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH dead_letter_queue = 'dlq'`)
+			defer closeFeed(t, foo)
+			assertPayloads(t, foo, []string{
+				`foo: [1]->{"after": {"a": 1}}`,
+				`foo: [3]->{"after": {"a": 3}}`,
+			})
+
+			jobID := foo.(cdctest.EnterpriseTestFeed).JobID()
+			sqlDB.CheckQueryResultsRetry(t,
+				`SELECT topic, key, value, error LIKE '%Message was too large%', updated IS NOT NULL
+FROM dlq WHERE job_id = `+strconv.Itoa(int(jobID)),
+				[][]string{{`foo`, `[2]`, `{"after": {"a": 2}}`, `true`, `true`}},
+			)
+			require.Equal(t, writes+1, sli.DeadLetterQueueWrites.Value())
+
+			// The changefeed keeps going.
+			sqlDB.Exec(t, `INSERT INTO foo VALUES (4)`)
+			assertPayloads(t, foo, []string{
+				`foo: [4]->{"after": {"a": 4}}`,
+			})
+		})
+
+		t.Run(`rows which cannot be encoded`, func(t *testing.T) {
+			sqlDB.Exec(t, `CREATE TABLE dec (a INT PRIMARY KEY, d DECIMAL)`)
+			sqlDB.Exec(t, `INSERT INTO dec VALUES (1, 1.0)`)
+
+			foo := feed(t, f, `CREATE CHANGEFEED FOR dec WITH format = avro, dead_letter_queue = 'dlq'`)
+			defer closeFeed(t, foo)
+
+			jobID := foo.(cdctest.EnterpriseTestFeed).JobID()
+			sqlDB.CheckQueryResultsRetry(t,
+				`SELECT topic, value IS NULL, error LIKE '%decimal with no precision%'
+FROM dlq WHERE job_id = `+strconv.Itoa(int(jobID)),
+				[][]string{{`dec`, `true`, `true`}},
+			)
+		})
+	}
+
+	cdcTest(t, testFn, feedTestForceSink(`kafka`))
+}
+
 // Regression for #85902.
 func TestRedactedSchemaRegistry(t *testing.T) {
 	defer leaktest.AfterTest(t)()
@@ -102,6 +102,12 @@ func MarkRetryableError(cause error) error {
 	return errors.Mark(cause, &retryableError{})
 }
 
+// IsTerminalError returns true if the error is marked as a terminal changefeed
+// error.
+func IsTerminalError(err error) bool {
+	return errors.Is(err, &terminalError{})
+}
+
 // AsTerminalError determines if the cause error is a terminal changefeed
 // error.  Returns non-nil error if changefeed should terminate with the
 // returned error.
@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/errors"
@@ -87,6 +88,7 @@ const (
 	OptMinCheckpointFrequency  = `min_checkpoint_frequency`
 	OptUpdatedTimestamps       = `updated`
 	OptMVCCTimestamps          = `mvcc_timestamp`
+	OptTransactionMetadata     = `transaction_metadata`
 	OptDiff                    = `diff`
 	OptCompression             = `compression`
 	OptSchemaChangeEvents      = `schema_change_events`
@@ -96,6 +98,7 @@ const (
 	OptWebhookAuthHeader       = `webhook_auth_header`
 	OptWebhookClientTimeout    = `webhook_client_timeout`
 	OptOnError                 = `on_error`
+	OptDeadLetterQueue         = `dead_letter_queue`
 	OptMetricsScope            = `metrics_label`
 	OptUnordered               = `unordered`
 	OptVirtualColumns          = `virtual_columns`
@@ -145,11 +148,13 @@ const (
 	OptEnvelopeDeprecatedRow EnvelopeType = `deprecated_row`
 	OptEnvelopeWrapped       EnvelopeType = `wrapped`
 	OptEnvelopeBare          EnvelopeType = `bare`
+	OptEnvelopeDebezium      EnvelopeType = `debezium`
 
-	OptFormatJSON    FormatType = `json`
-	OptFormatAvro    FormatType = `avro`
-	OptFormatCSV     FormatType = `csv`
-	OptFormatParquet FormatType = `parquet`
+	OptFormatJSON     FormatType = `json`
+	OptFormatAvro     FormatType = `avro`
+	OptFormatCSV      FormatType = `csv`
+	OptFormatParquet  FormatType = `parquet`
+	OptFormatProtobuf FormatType = `protobuf`
 
 	OptOnErrorFail  OnErrorType = `fail`
 	OptOnErrorPause OnErrorType = `pause`
@@ -316,8 +321,8 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptCursor:                             timestampOption,
 	OptCustomKeyColumn:                    stringOption,
 	OptEndTime:                            timestampOption,
-	OptEnvelope:                           enum("row", "key_only", "wrapped", "deprecated_row", "bare"),
-	OptFormat:                             enum("json", "avro", "csv", "experimental_avro", "parquet"),
+	OptEnvelope:                           enum("row", "key_only", "wrapped", "deprecated_row", "bare", "debezium"),
+	OptFormat:                             enum("json", "avro", "csv", "experimental_avro", "parquet", "protobuf"),
 	OptFullTableName:                      flagOption,
 	OptKeyInValue:                         flagOption,
 	OptTopicInValue:                       flagOption,
@@ -325,6 +330,7 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptMinCheckpointFrequency:             durationOption.thatCanBeZero(),
 	OptUpdatedTimestamps:                  flagOption,
 	OptMVCCTimestamps:                     flagOption,
+	OptTransactionMetadata:                flagOption,
 	OptDiff:                               flagOption,
 	OptCompression:                        enum("gzip", "zstd"),
 	OptSchemaChangeEvents:                 enum("column_changes", "default"),
@@ -341,6 +347,7 @@ var ChangefeedOptionExpectValues = map[string]OptionPermittedValues{
 	OptWebhookAuthHeader:                  stringOption,
 	OptWebhookClientTimeout:               durationOption,
 	OptOnError:                            enum("pause", "fail"),
+	OptDeadLetterQueue:                    stringOption,
 	OptMetricsScope:                       stringOption,
 	OptUnordered:                          flagOption,
 	OptVirtualColumns:                     enum("omitted", "null"),
@@ -352,7 +359,7 @@ var CommonOptions = makeStringSet(OptCursor, OptEndTime, OptEnvelope,
 	OptFormat, OptFullTableName,
 	OptKeyInValue, OptTopicInValue,
 	OptResolvedTimestamps, OptUpdatedTimestamps,
-	OptMVCCTimestamps, OptDiff, OptSplitColumnFamilies,
+	OptMVCCTimestamps, OptTransactionMetadata, OptDiff, OptSplitColumnFamilies,
 	OptSchemaChangeEvents, OptSchemaChangePolicy,
 	OptOnError,
 	OptInitialScan, OptNoInitialScan, OptInitialScanOnly, OptUnordered, OptCustomKeyColumn,
@@ -363,8 +370,10 @@ var CommonOptions = makeStringSet(OptCursor, OptEndTime, OptEnvelope,
 // SQLValidOptions is options exclusive to SQL sink
 var SQLValidOptions map[string]struct{} = nil
 
-// KafkaValidOptions is options exclusive to Kafka sink
-var KafkaValidOptions = makeStringSet(OptAvroSchemaPrefix, OptConfluentSchemaRegistry, OptKafkaSinkConfig)
+// KafkaValidOptions is options exclusive to Kafka sink. The dead_letter_queue
+// option is only supported by Kafka sinks, which are the only sinks which hand
+// back the rows they permanently fail to deliver.
+var KafkaValidOptions = makeStringSet(OptAvroSchemaPrefix, OptConfluentSchemaRegistry, OptKafkaSinkConfig, OptDeadLetterQueue)
 
 // CloudStorageValidOptions is options exclusive to cloud storage sink
 var CloudStorageValidOptions = makeStringSet(OptCompression)
@@ -415,6 +424,23 @@ var RedactedOptions = map[string]redactionFunc{
 	OptWebhookAuthHeader:       redactSimple,
 	SinkParamClientKey:         redactSimple,
 	OptConfluentSchemaRegistry: RedactUserFromURI,
+	OptDeadLetterQueue:         redactDeadLetterQueue,
+}
+
+// redactDeadLetterQueue redacts the credentials in a dead_letter_queue sink
+// URI.
+func redactDeadLetterQueue(v string) (string, error) {
+	if !IsDeadLetterQueueURI(v) {
+		return v, nil
+	}
+	v, err := cloud.SanitizeExternalStorageURI(v, []string{
+		SinkParamSASLPassword, SinkParamSASLClientSecret,
+		SinkParamCACert, SinkParamClientCert, SinkParamClientKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	return RedactUserFromURI(v)
 }
 
 // NoLongerExperimental aliases options prefixed with experimental that no longer need to be
@@ -434,7 +460,7 @@ type OptionsSet map[string]struct{}
 // InitialScanOnlyUnsupportedOptions is options that are not supported with the
 // initial scan only option
 var InitialScanOnlyUnsupportedOptions OptionsSet = makeStringSet(OptEndTime, OptResolvedTimestamps, OptDiff,
-	OptMVCCTimestamps, OptUpdatedTimestamps)
+	OptMVCCTimestamps, OptUpdatedTimestamps, OptTransactionMetadata)
 
 // ParquetFormatUnsupportedOptions is options that are not supported with the
 // parquet format.
@@ -747,6 +773,7 @@ type EncodingOptions struct {
 	TopicInValue      bool
 	UpdatedTimestamps bool
 	MVCCTimestamps    bool
+	TxnMetadata       bool
 	Diff              bool
 	AvroSchemaPrefix  string
 	SchemaRegistryURI string
@@ -792,7 +819,10 @@ func (s StatementOptions) GetEncodingOptions() (EncodingOptions, error) {
 	_, o.TopicInValue = s.m[OptTopicInValue]
 	_, o.UpdatedTimestamps = s.m[OptUpdatedTimestamps]
 	_, o.MVCCTimestamps = s.m[OptMVCCTimestamps]
+	_, o.TxnMetadata = s.m[OptTransactionMetadata]
 	_, o.Diff = s.m[OptDiff]
+	// The debezium envelope always includes the previous version of the row.
+	o.Diff = o.Diff || o.Envelope == OptEnvelopeDebezium
 
 	o.SchemaRegistryURI = s.m[OptConfluentSchemaRegistry]
 	o.AvroSchemaPrefix = s.m[OptAvroSchemaPrefix]
@@ -805,11 +835,43 @@ func (s StatementOptions) GetEncodingOptions() (EncodingOptions, error) {
 
 // Validate checks for incompatible encoding options.
 func (e EncodingOptions) Validate() error {
-	if e.Envelope == OptEnvelopeRow && e.Format == OptFormatAvro {
+	if e.Envelope == OptEnvelopeRow && (e.Format == OptFormatAvro || e.Format == OptFormatProtobuf) {
 		return errors.Errorf(`%s=%s is not supported with %s=%s`,
-			OptEnvelope, OptEnvelopeRow, OptFormat, OptFormatAvro,
+			OptEnvelope, OptEnvelopeRow, OptFormat, e.Format,
 		)
 	}
+	if e.TxnMetadata && e.Format != OptFormatJSON {
+		return errors.Errorf(`%s is only usable with %s=%s`,
+			OptTransactionMetadata, OptFormat, OptFormatJSON)
+	}
+	if e.Envelope == OptEnvelopeDebezium {
+		switch e.Format {
+		case OptFormatJSON, OptFormatAvro, DeprecatedOptFormatAvro:
+		default:
+			return errors.Errorf(`%s=%s is not supported with %s=%s`,
+				OptEnvelope, OptEnvelopeDebezium, OptFormat, e.Format,
+			)
+		}
+		// The debezium envelope has its own metadata fields, which replace
+		// these.
+		unsupported := []struct {
+			k string
+			b bool
+		}{
+			{OptKeyInValue, e.KeyInValue},
+			{OptTopicInValue, e.TopicInValue},
+			{OptUpdatedTimestamps, e.UpdatedTimestamps},
+			{OptMVCCTimestamps, e.MVCCTimestamps},
+			{OptTransactionMetadata, e.TxnMetadata},
+		}
+		for _, v := range unsupported {
+			if v.b {
+				return errors.Errorf(`%s is not supported with %s=%s`,
+					v.k, OptEnvelope, OptEnvelopeDebezium)
+			}
+		}
+		return nil
+	}
 	if e.Envelope != OptEnvelopeWrapped && e.Format != OptFormatJSON && e.Format != OptFormatParquet {
 		requiresWrap := []struct {
 			k string
@@ -874,6 +936,8 @@ type Filters struct {
 // GetFilters returns a populated Filters.
 func (s StatementOptions) GetFilters() Filters {
 	_, withDiff := s.m[OptDiff]
+	// The debezium envelope always includes the previous version of the row.
+	withDiff = withDiff || s.m[OptEnvelope] == string(OptEnvelopeDebezium)
 	return Filters{
 		WithDiff: withDiff,
 	}
@@ -1020,6 +1084,25 @@ func (s StatementOptions) GetOnError() (OnErrorType, error) {
 	return OnErrorType(v), nil
 }
 
+// GetDeadLetterQueue returns the destination of rows which could not be
+// encoded or emitted, if one was set. See IsDeadLetterQueueURI.
+func (s StatementOptions) GetDeadLetterQueue() (string, bool) {
+	v, ok := s.m[OptDeadLetterQueue]
+	return v, ok
+}
+
+// SetDeadLetterQueue sets the destination of rows which could not be encoded
+// or emitted.
+func (s StatementOptions) SetDeadLetterQueue(v string) {
+	s.m[OptDeadLetterQueue] = v
+}
+
+// IsDeadLetterQueueURI returns true if the value of the dead_letter_queue
+// option is a sink URI rather than the name of a table.
+func IsDeadLetterQueueURI(v string) bool {
+	return strings.Contains(v, "://")
+}
+
 func describeEnum(strs ...string) string {
 	switch len(strs) {
 	case 1:
@@ -0,0 +1,298 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// deadLetterQueueColumns are the columns a dead_letter_queue table must have.
+// The table may have other columns, as long as they can be left to their
+// default values.
+var deadLetterQueueColumns = []string{
+	"job_id", "topic", "key", "value", "updated", "mvcc_timestamp", "error",
+}
+
+// deadLetterQueueInsertBatchSize is the maximum number of rows written to a
+// dead_letter_queue table by a single statement.
+const deadLetterQueueInsertBatchSize = 128
+
+// deadLetter is a row which permanently failed to be encoded or emitted.
+type deadLetter struct {
+	topic TopicDescriptor
+	// key and value are the encoded key and value of the row. value is nil if
+	// the row failed to be encoded, in which case key is the key of the row
+	// encoded as JSON, or nil if that failed too.
+	key, value    []byte
+	updated, mvcc hlc.Timestamp
+	err           error
+	// alloc is the memory allocation of the row, which is released once the
+	// row is written to the dead letter queue.
+	alloc kvevent.Alloc
+}
+
+// undeliverableRowsError is returned by the Flush of a sink which was unable to
+// deliver some rows for reasons which retrying won't fix, but which delivered
+// all the others. The rows are handed back so that they can be written to the
+// dead letter queue.
+type undeliverableRowsError struct {
+	rows []deadLetter
+}
+
+func (e *undeliverableRowsError) Error() string {
+	return fmt.Sprintf("%d rows could not be delivered: %v", len(e.rows), e.rows[0].err)
+}
+
+// deadLetterQueue is where rows which permanently fail to be encoded or
+// emitted are written when the dead_letter_queue option is set, so that the
+// changefeed can carry on without them. The destination is either a table or a
+// sink; see changefeedbase.IsDeadLetterQueueURI.
+//
+// Rows are buffered by Add, which is safe for concurrent use by the event
+// consumers, and written in batches by Flush, which the change aggregator calls
+// before it flushes its sink. Since buffered rows hold on to their memory
+// allocations until they are written, a changefeed which runs out of memory
+// flushes them like any other buffered row.
+type deadLetterQueue struct {
+	jobID   jobspb.JobID
+	metrics *sliMetrics
+
+	// db, user and table are set if the destination is a table.
+	db    isql.DB
+	user  username.SQLUsername
+	table string
+	// sink is set if the destination is a sink.
+	sink Sink
+
+	// topics names the topics of the rows. Like sink, it is only used by Flush.
+	topics *TopicNamer
+
+	mu struct {
+		syncutil.Mutex
+		// pending are the rows added since the last Flush.
+		pending []deadLetter
+	}
+}
+
+// makeDeadLetterQueue returns the dead letter queue of the changefeed, or nil
+// if the dead_letter_queue option is not set.
+func makeDeadLetterQueue(
+	ctx context.Context,
+	serverCfg *execinfra.ServerConfig,
+	feedCfg jobspb.ChangefeedDetails,
+	user username.SQLUsername,
+	jobID jobspb.JobID,
+	metrics *sliMetrics,
+) (*deadLetterQueue, error) {
+	dest, ok := changefeedbase.MakeStatementOptions(feedCfg.Opts).GetDeadLetterQueue()
+	if !ok {
+		return nil, nil
+	}
+
+	topics, err := MakeTopicNamer(AllTargets(feedCfg))
+	if err != nil {
+		return nil, err
+	}
+	q := &deadLetterQueue{jobID: jobID, metrics: metrics, topics: topics}
+
+	if !changefeedbase.IsDeadLetterQueueURI(dest) {
+		q.db = serverCfg.DB
+		q.user = user
+		q.table = dest
+		return q, nil
+	}
+
+	var nilOracle timestampLowerBoundOracle
+	// The writes to the dead letter queue are not accounted as emitted messages.
+	q.sink, err = getAndDialSink(ctx, serverCfg, deadLetterQueueDetails(feedCfg, dest),
+		nilOracle, user, jobID, nil /* kafkaTxn */, (*sliMetrics)(nil))
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// deadLetterQueueDetails returns the details used to make the sink of a dead
+// letter queue. The sink watches the same targets as the changefeed so that it
+// names topics the same way, but none of the options of the changefeed apply
+// to it.
+func deadLetterQueueDetails(
+	feedCfg jobspb.ChangefeedDetails, sinkURI string,
+) jobspb.ChangefeedDetails {
+	return jobspb.ChangefeedDetails{
+		SinkURI:              sinkURI,
+		Tables:               feedCfg.Tables,
+		TargetSpecifications: feedCfg.TargetSpecifications,
+	}
+}
+
+// Add buffers the row to be written to the dead letter queue by the next
+// Flush.
+func (q *deadLetterQueue) Add(r deadLetter) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.mu.pending = append(q.mu.pending, r)
+}
+
+// Flush durably writes the rows added since the last Flush to the dead letter
+// queue, and releases their allocations. It must not be called concurrently
+// with itself.
+func (q *deadLetterQueue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	rows := q.mu.pending
+	q.mu.pending = nil
+	q.mu.Unlock()
+	if len(rows) == 0 {
+		return nil
+	}
+
+	topics := make([]string, len(rows))
+	for i := range rows {
+		var err error
+		if topics[i], err = q.topics.Name(rows[i].topic); err != nil {
+			return err
+		}
+	}
+	var err error
+	if q.sink != nil {
+		err = q.writeToSink(ctx, topics, rows)
+	} else {
+		err = q.writeToTable(ctx, topics, rows)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "writing %d rows which failed with %q to %s",
+			len(rows), rows[0].err, changefeedbase.OptDeadLetterQueue)
+	}
+
+	for i := range rows {
+		log.VEventf(ctx, 2, "wrote row with key %s to %s: %v",
+			rows[i].key, changefeedbase.OptDeadLetterQueue, rows[i].err)
+		rows[i].alloc.Release(ctx)
+	}
+	if q.metrics != nil {
+		q.metrics.DeadLetterQueueWrites.Inc(int64(len(rows)))
+	}
+	return nil
+}
+
+// writeToSink emits the rows to the sink, keyed by the key of each row, with a
+// JSON value describing the row and its error:
+//
+//	{"topic": ..., "key": ..., "value": ..., "updated": ..., "mvcc_timestamp": ..., "error": ...}
+//
+// The key and value are strings, hex encoded with a \x prefix if they are not
+// valid UTF-8. The sink is flushed once all the rows are emitted.
+func (q *deadLetterQueue) writeToSink(
+	ctx context.Context, topics []string, rows []deadLetter,
+) error {
+	var buf bytes.Buffer
+	for i, r := range rows {
+		b := json.NewObjectBuilder(6)
+		b.Add("topic", json.FromString(topics[i]))
+		b.Add("key", bytesToJSON(r.key))
+		b.Add("value", bytesToJSON(r.value))
+		b.Add("updated", json.FromString(r.updated.AsOfSystemTime()))
+		b.Add("mvcc_timestamp", json.FromString(r.mvcc.AsOfSystemTime()))
+		b.Add("error", json.FromString(r.err.Error()))
+		buf.Reset()
+		b.Build().Format(&buf)
+
+		// The sink may hold on to the value until it is flushed.
+		value := append([]byte(nil), buf.Bytes()...)
+		var zeroAlloc kvevent.Alloc
+		if err := q.sink.EmitRow(
+			ctx, r.topic, r.key, value, r.updated, r.mvcc, zeroAlloc,
+		); err != nil {
+			return err
+		}
+	}
+	return q.sink.Flush(ctx)
+}
+
+// writeToTable inserts the rows into the table as the user who created the
+// changefeed, deadLetterQueueInsertBatchSize rows at a time.
+func (q *deadLetterQueue) writeToTable(
+	ctx context.Context, topics []string, rows []deadLetter,
+) error {
+	for len(rows) > 0 {
+		n := len(rows)
+		if n > deadLetterQueueInsertBatchSize {
+			n = deadLetterQueueInsertBatchSize
+		}
+
+		var stmt strings.Builder
+		fmt.Fprintf(&stmt,
+			`INSERT INTO %s (job_id, topic, key, value, updated, mvcc_timestamp, error) VALUES `, q.table)
+		args := make([]interface{}, 0, n*len(deadLetterQueueColumns))
+		for i, r := range rows[:n] {
+			if i > 0 {
+				stmt.WriteString(", ")
+			}
+			p := len(args)
+			fmt.Fprintf(&stmt, "($%d, $%d, $%d, $%d, $%d, $%d, $%d)", p+1, p+2, p+3, p+4, p+5, p+6, p+7)
+			args = append(args,
+				int64(q.jobID), topics[i], bytesDatum(r.key), bytesDatum(r.value),
+				eval.TimestampToDecimalDatum(r.updated), eval.TimestampToDecimalDatum(r.mvcc),
+				r.err.Error(),
+			)
+		}
+		if _, err := q.db.Executor().ExecEx(ctx, "changefeed-dead-letter-queue", nil, /* txn */
+			sessiondata.InternalExecutorOverride{User: q.user}, stmt.String(), args...,
+		); err != nil {
+			return err
+		}
+		rows, topics = rows[n:], topics[n:]
+	}
+	return nil
+}
+
+// Close closes the sink of the dead letter queue, if any.
+func (q *deadLetterQueue) Close() error {
+	if q.sink != nil {
+		return q.sink.Close()
+	}
+	return nil
+}
+
+func bytesDatum(b []byte) tree.Datum {
+	if b == nil {
+		return tree.DNull
+	}
+	return tree.NewDBytes(tree.DBytes(b))
+}
+
+func bytesToJSON(b []byte) json.JSON {
+	if b == nil {
+		return json.NullJSONValue
+	}
+	if utf8.Valid(b) {
+		return json.FromString(string(b))
+	}
+	return json.FromString(`\x` + hex.EncodeToString(b))
+}
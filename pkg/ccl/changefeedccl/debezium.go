@@ -0,0 +1,74 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+)
+
+// The debezium envelope mirrors the change event values emitted by Debezium
+// connectors, so that existing Debezium consumers can read changefeeds:
+//
+//	{
+//	  "before": <the previous version of the row, or null>,
+//	  "after": <the new version of the row, or null for deletes>,
+//	  "op": <one of the debeziumOp constants>,
+//	  "ts_ms": <the updated timestamp of the event, in milliseconds>,
+//	  "source": {
+//	    "connector": "cockroachdb",
+//	    "table": <the table name>,
+//	    "ts_ms": <the MVCC timestamp of the change, in milliseconds>,
+//	    "mvcc_timestamp": <the MVCC timestamp of the change>,
+//	    "snapshot": <"true" if the row was read by a scan, otherwise "false">
+//	  }
+//	}
+
+// The values of the op field of the debezium envelope.
+const (
+	debeziumOpCreate = `c`
+	debeziumOpUpdate = `u`
+	debeziumOpDelete = `d`
+	debeziumOpRead   = `r`
+)
+
+const debeziumConnectorName = `cockroachdb`
+
+// debeziumOp returns the operation of the given change in the debezium
+// envelope. Rows emitted by a scan, such as the initial scan, are reads, as
+// for debezium snapshots.
+func debeziumOp(evCtx eventContext, updated, prev cdcevent.Row) string {
+	switch {
+	case evCtx.backfill:
+		return debeziumOpRead
+	case updated.IsDeleted():
+		return debeziumOpDelete
+	case prev.IsInitialized() && prev.HasValues() && !prev.IsDeleted():
+		return debeziumOpUpdate
+	default:
+		return debeziumOpCreate
+	}
+}
+
+// debeziumSnapshot returns the value of the snapshot field of the source block
+// of the debezium envelope. Debezium encodes it as a string.
+func debeziumSnapshot(evCtx eventContext) string {
+	if evCtx.backfill {
+		return `true`
+	}
+	return `false`
+}
+
+// timestampToMillis returns the wall time of the timestamp in milliseconds,
+// which is how debezium represents times.
+func timestampToMillis(ts hlc.Timestamp) int64 {
+	return ts.WallTime / int64(time.Millisecond)
+}
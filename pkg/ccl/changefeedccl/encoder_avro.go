@@ -88,7 +88,8 @@ func newConfluentAvroEncoder(
 	}
 
 	e.updatedField = opts.UpdatedTimestamps
-	e.beforeField = opts.Diff
+	// The debezium envelope always includes the previous version of the row.
+	e.beforeField = opts.Diff || opts.Envelope == changefeedbase.OptEnvelopeDebezium
 	e.customKeyColumn = opts.CustomKeyColumn
 
 	// TODO: Implement this.
@@ -245,14 +246,18 @@ func (e *confluentAvroEncoder) EncodeValue(
 
 		var opts avroEnvelopeOpts
 
-		// In the wrapped envelope, row data goes in the "after" field. In the raw envelope,
+		// In the wrapped and debezium envelopes, row data goes in the "after" field. In the raw envelope,
 		// it goes in the "record" field. In the "key_only" envelope it's omitted.
 		// This means metadata can safely go at the top level as there are never arbitrary column names
 		// for it to conflict with.
-		if e.envelopeType == changefeedbase.OptEnvelopeWrapped {
+		switch e.envelopeType {
+		case changefeedbase.OptEnvelopeWrapped:
 			opts = avroEnvelopeOpts{afterField: true, beforeField: e.beforeField, updatedField: e.updatedField}
 			afterDataSchema = currentSchema
-		} else {
+		case changefeedbase.OptEnvelopeDebezium:
+			opts = avroEnvelopeOpts{afterField: true, beforeField: e.beforeField, debeziumFields: true}
+			afterDataSchema = currentSchema
+		default:
 			opts = avroEnvelopeOpts{recordField: true, updatedField: e.updatedField}
 			recordDataSchema = currentSchema
 		}
@@ -283,6 +288,11 @@ func (e *confluentAvroEncoder) EncodeValue(
 			`updated`: evCtx.updated,
 		}
 	}
+	if registered.schema.opts.debeziumFields {
+		meta = map[string]interface{}{
+			`debezium`: evCtx,
+		}
+	}
 
 	// https://docs.confluent.io/current/schema-registry/docs/serializer-formatter.html#wire-format
 	header := []byte{
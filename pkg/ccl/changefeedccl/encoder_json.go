@@ -37,6 +37,7 @@ import (
 // stored in a sub-object under the `__crdb__` key in the top-level JSON object.
 type jsonEncoder struct {
 	updatedField, mvccTimestampField, beforeField, keyInValue, topicInValue bool
+	txnMetadataField                                                        bool
 	envelopeType                                                            changefeedbase.EnvelopeType
 
 	buf             bytes.Buffer
@@ -49,9 +50,10 @@ var _ Encoder = &jsonEncoder{}
 
 func canJSONEncodeMetadata(e changefeedbase.EnvelopeType) bool {
 	// bare envelopes use the _crdb_ key to avoid collisions with column names.
-	// wrapped envelopes can put metadata at the top level because the columns
-	// are nested under the "after:" key.
-	return e == changefeedbase.OptEnvelopeBare || e == changefeedbase.OptEnvelopeWrapped
+	// wrapped and debezium envelopes can put metadata at the top level because
+	// the columns are nested under the "after:" key.
+	return e == changefeedbase.OptEnvelopeBare || e == changefeedbase.OptEnvelopeWrapped ||
+		e == changefeedbase.OptEnvelopeDebezium
 }
 
 // getCachedOrCreate returns cached object, or creates and caches new one.
@@ -81,6 +83,7 @@ func makeJSONEncoder(opts jsonEncoderOptions) (*jsonEncoder, error) {
 		envelopeType:       opts.Envelope,
 		updatedField:       opts.UpdatedTimestamps,
 		mvccTimestampField: opts.MVCCTimestamps,
+		txnMetadataField:   opts.TxnMetadata,
 		customKeyColumn:    opts.CustomKeyColumn,
 		// In the bare envelope we don't output diff directly, it's incorporated into the
 		// projection as desired.
@@ -114,13 +117,22 @@ func makeJSONEncoder(opts jsonEncoderOptions) (*jsonEncoder, error) {
 			return nil, errors.Errorf(`%s is only usable with %s=%s`,
 				changefeedbase.OptTopicInValue, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
 		}
+		if e.txnMetadataField {
+			return nil, errors.Errorf(`%s is only usable with %s=%s`,
+				changefeedbase.OptTransactionMetadata, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+		}
 	}
 
-	if e.envelopeType == changefeedbase.OptEnvelopeWrapped {
+	switch e.envelopeType {
+	case changefeedbase.OptEnvelopeWrapped:
 		if err := e.initWrappedEnvelope(); err != nil {
 			return nil, err
 		}
-	} else {
+	case changefeedbase.OptEnvelopeDebezium:
+		if err := e.initDebeziumEnvelope(); err != nil {
+			return nil, err
+		}
+	default:
 		if err := e.initRawEnvelope(); err != nil {
 			return nil, err
 		}
@@ -242,9 +254,20 @@ func (e *jsonEncoder) initRawEnvelope() error {
 	if e.topicInValue {
 		metaKeys = append(metaKeys, "topic")
 	}
+	if e.txnMetadataField {
+		metaKeys = append(metaKeys, "txn")
+	}
 
 	// Setup builder for crdb meta if needed.
 	var metaBuilder *json.FixedKeysObjectBuilder
+	var txnBuilder *txnMetadataBuilder
+	if e.txnMetadataField {
+		b, err := makeTxnMetadataBuilder()
+		if err != nil {
+			return err
+		}
+		txnBuilder = b
+	}
 	if len(metaKeys) > 0 {
 		b, err := json.NewFixedKeysObjectBuilder(metaKeys)
 		if err != nil {
@@ -284,6 +307,16 @@ func (e *jsonEncoder) initRawEnvelope() error {
 			}
 		}
 
+		if e.txnMetadataField {
+			txn, err := txnBuilder.encode(evCtx)
+			if err != nil {
+				return nil, err
+			}
+			if err := metaBuilder.Set("txn", txn); err != nil {
+				return nil, err
+			}
+		}
+
 		meta, err := metaBuilder.Build()
 		if err != nil {
 			return nil, err
@@ -310,6 +343,15 @@ func (e *jsonEncoder) initWrappedEnvelope() error {
 	if e.mvccTimestampField {
 		keys = append(keys, "mvcc_timestamp")
 	}
+	var txnBuilder *txnMetadataBuilder
+	if e.txnMetadataField {
+		keys = append(keys, "txn")
+		tb, err := makeTxnMetadataBuilder()
+		if err != nil {
+			return err
+		}
+		txnBuilder = tb
+	}
 	b, err := json.NewFixedKeysObjectBuilder(keys)
 	if err != nil {
 		return err
@@ -366,6 +408,83 @@ func (e *jsonEncoder) initWrappedEnvelope() error {
 			}
 		}
 
+		if e.txnMetadataField {
+			txn, err := txnBuilder.encode(evCtx)
+			if err != nil {
+				return nil, err
+			}
+			if err := b.Set("txn", txn); err != nil {
+				return nil, err
+			}
+		}
+
+		return b.Build()
+	}
+	return nil
+}
+
+func (e *jsonEncoder) initDebeziumEnvelope() error {
+	b, err := json.NewFixedKeysObjectBuilder([]string{"after", "before", "op", "ts_ms", "source"})
+	if err != nil {
+		return err
+	}
+	sourceBuilder, err := json.NewFixedKeysObjectBuilder(
+		[]string{"connector", "table", "ts_ms", "mvcc_timestamp", "snapshot"})
+	if err != nil {
+		return err
+	}
+
+	const emitDeletedRowAsNull = true
+	e.envelopeEncoder = func(evCtx eventContext, updated, prev cdcevent.Row) (json.JSON, error) {
+		after, err := e.versionEncoder(updated.EventDescriptor, false).rowAsGoNative(updated, emitDeletedRowAsNull, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.Set("after", after); err != nil {
+			return nil, err
+		}
+
+		var before json.JSON = json.NullJSONValue
+		if prev.IsInitialized() && !prev.IsDeleted() {
+			before, err = e.versionEncoder(prev.EventDescriptor, true).rowAsGoNative(prev, emitDeletedRowAsNull, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := b.Set("before", before); err != nil {
+			return nil, err
+		}
+
+		if err := b.Set("op", json.FromString(debeziumOp(evCtx, updated, prev))); err != nil {
+			return nil, err
+		}
+		if err := b.Set("ts_ms", json.FromInt64(timestampToMillis(evCtx.updated))); err != nil {
+			return nil, err
+		}
+
+		if err := sourceBuilder.Set("connector", json.FromString(debeziumConnectorName)); err != nil {
+			return nil, err
+		}
+		if err := sourceBuilder.Set("table", json.FromString(updated.TableName)); err != nil {
+			return nil, err
+		}
+		if err := sourceBuilder.Set("ts_ms", json.FromInt64(timestampToMillis(evCtx.mvcc))); err != nil {
+			return nil, err
+		}
+		if err := sourceBuilder.Set("mvcc_timestamp", json.FromString(timestampToString(evCtx.mvcc))); err != nil {
+			return nil, err
+		}
+		if err := sourceBuilder.Set("snapshot", json.FromString(debeziumSnapshot(evCtx))); err != nil {
+			return nil, err
+		}
+		source, err := sourceBuilder.Build()
+		if err != nil {
+			return nil, err
+		}
+		if err := b.Set("source", source); err != nil {
+			return nil, err
+		}
+
 		return b.Build()
 	}
 	return nil
@@ -0,0 +1,294 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// confluentProtobufEncoder encodes changefeed entries in the Protocol Buffers
+// binary format, framed as expected by the confluent schema registry
+// serializers. Keys are the primary key columns in a message. Values are all
+// columns in a message, nested in an envelope message.
+type confluentProtobufEncoder struct {
+	schemaRegistry            schemaRegistry
+	schemaPrefix              string
+	updatedField, beforeField bool
+	targets                   changefeedbase.Targets
+	envelopeType              changefeedbase.EnvelopeType
+	customKeyColumn           string
+
+	keyCache   *cache.UnorderedCache // [tableIDAndVersion]confluentRegisteredProtobufKeySchema
+	valueCache *cache.UnorderedCache // [tableIDAndVersionPair]confluentRegisteredProtobufEnvelopeSchema
+
+	// resolvedCache doesn't need to be bounded like the other caches because the number of topics
+	// is fixed per changefeed.
+	resolvedCache map[string]confluentRegisteredProtobufEnvelopeSchema
+}
+
+type confluentRegisteredProtobufKeySchema struct {
+	schema     *protobufDataMessage
+	registryID int32
+}
+
+type confluentRegisteredProtobufEnvelopeSchema struct {
+	schema     *protobufEnvelopeMessage
+	registryID int32
+}
+
+var _ Encoder = &confluentProtobufEncoder{}
+
+func newConfluentProtobufEncoder(
+	opts changefeedbase.EncodingOptions,
+	targets changefeedbase.Targets,
+	p externalConnectionProvider,
+	sliMetrics *sliMetrics,
+) (*confluentProtobufEncoder, error) {
+	e := &confluentProtobufEncoder{
+		schemaPrefix: opts.AvroSchemaPrefix,
+		targets:      targets,
+		envelopeType: opts.Envelope,
+	}
+
+	e.updatedField = opts.UpdatedTimestamps
+	e.beforeField = opts.Diff
+	e.customKeyColumn = opts.CustomKeyColumn
+
+	if opts.KeyInValue {
+		return nil, errors.Errorf(`%s is not supported with %s=%s`,
+			changefeedbase.OptKeyInValue, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+	if opts.TopicInValue {
+		return nil, errors.Errorf(`%s is not supported with %s=%s`,
+			changefeedbase.OptTopicInValue, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+	if len(opts.SchemaRegistryURI) == 0 {
+		return nil, errors.Errorf(`WITH option %s is required for %s=%s`,
+			changefeedbase.OptConfluentSchemaRegistry, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+
+	reg, err := newConfluentSchemaRegistry(opts.SchemaRegistryURI, p, sliMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	e.schemaRegistry = reg
+	e.keyCache = cache.NewUnorderedCache(encoderCacheConfig)
+	e.valueCache = cache.NewUnorderedCache(encoderCacheConfig)
+	e.resolvedCache = make(map[string]confluentRegisteredProtobufEnvelopeSchema)
+	return e, nil
+}
+
+// rawTableName returns the raw SQL-formatted string for a table name, applying
+// the full_table_name and avro_schema_prefix options.
+func (e *confluentProtobufEncoder) rawTableName(eventMeta cdcevent.Metadata) (string, error) {
+	target, found := e.targets.FindByTableIDAndFamilyName(eventMeta.TableID, eventMeta.FamilyName)
+	if !found {
+		return eventMeta.TableName, errors.Newf("Could not find Target for %s", eventMeta)
+	}
+	switch target.Type {
+	case jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY:
+		return e.schemaPrefix + string(target.StatementTimeName), nil
+	case jobspb.ChangefeedTargetSpecification_EACH_FAMILY:
+		return fmt.Sprintf("%s%s.%s", e.schemaPrefix, target.StatementTimeName, eventMeta.FamilyName), nil
+	case jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY:
+		return fmt.Sprintf("%s%s.%s", e.schemaPrefix, target.StatementTimeName, target.FamilyName), nil
+	default:
+		return "", errors.AssertionFailedf("Found a matching target with unimplemented type %s", target.Type)
+	}
+}
+
+// EncodeKey implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeKey(ctx context.Context, row cdcevent.Row) ([]byte, error) {
+	// No familyID in the cache key for keys because it's the same schema for all families
+	cacheKey := tableIDAndVersion{tableID: row.TableID, version: row.Version}
+
+	var registered confluentRegisteredProtobufKeySchema
+	v, ok := e.keyCache.Get(cacheKey)
+	if ok {
+		registered = v.(confluentRegisteredProtobufKeySchema)
+		if err := registered.schema.refreshTypeMetadata(row); err != nil {
+			return nil, err
+		}
+	} else {
+		tableName, err := e.rawTableName(row.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		if e.customKeyColumn == "" {
+			registered.schema, err = primaryIndexToProtobufMessage(row, tableName)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			it, err := row.DatumNamed(e.customKeyColumn)
+			if err != nil {
+				return nil, err
+			}
+			registered.schema, err = newProtobufMessageForRow(it, SQLNameToAvroName(tableName))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := SQLNameToKafkaName(tableName) + confluentSubjectSuffixKey
+		registered.registryID, err = e.schemaRegistry.RegisterProtobufSchemaForSubject(
+			ctx, subject, registered.schema.Schema())
+		if err != nil {
+			return nil, err
+		}
+		e.keyCache.Add(cacheKey, registered)
+	}
+
+	header := confluentProtobufHeader(registered.registryID)
+	if e.customKeyColumn != "" {
+		it, err := row.DatumNamed(e.customKeyColumn)
+		if err != nil {
+			return nil, err
+		}
+		return registered.schema.BinaryFromRow(header, it)
+	}
+	return registered.schema.BinaryFromRow(header, row.ForEachKeyColumn())
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeValue(
+	ctx context.Context, evCtx eventContext, updatedRow cdcevent.Row, prevRow cdcevent.Row,
+) ([]byte, error) {
+	if e.envelopeType == changefeedbase.OptEnvelopeKeyOnly {
+		return nil, nil
+	}
+
+	var cacheKey tableIDAndVersionPair
+	if e.beforeField && prevRow.IsInitialized() {
+		cacheKey[0] = tableIDAndVersion{
+			tableID: prevRow.TableID, version: prevRow.Version, familyID: prevRow.FamilyID,
+		}
+	}
+	cacheKey[1] = tableIDAndVersion{
+		tableID: updatedRow.TableID, version: updatedRow.Version, familyID: updatedRow.FamilyID,
+	}
+
+	var registered confluentRegisteredProtobufEnvelopeSchema
+	v, ok := e.valueCache.Get(cacheKey)
+	if ok {
+		registered = v.(confluentRegisteredProtobufEnvelopeSchema)
+		current := registered.schema.after
+		if current == nil {
+			current = registered.schema.record
+		}
+		if err := current.refreshTypeMetadata(updatedRow); err != nil {
+			return nil, err
+		}
+		if prevRow.IsInitialized() && registered.schema.before != nil {
+			if err := registered.schema.before.refreshTypeMetadata(prevRow); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		var beforeDataMessage, afterDataMessage, recordDataMessage *protobufDataMessage
+		if e.beforeField && prevRow.IsInitialized() {
+			var err error
+			beforeDataMessage, err = tableToProtobufMessage(prevRow, `before`)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		currentMessage, err := tableToProtobufMessage(updatedRow, avroSchemaNoSuffix)
+		if err != nil {
+			return nil, err
+		}
+
+		// As with avro, row data goes in the "after" field in the wrapped
+		// envelope and in the "record" field in the bare envelope.
+		var opts protobufEnvelopeOpts
+		if e.envelopeType == changefeedbase.OptEnvelopeWrapped {
+			opts = protobufEnvelopeOpts{afterField: true, beforeField: e.beforeField, updatedField: e.updatedField}
+			afterDataMessage = currentMessage
+		} else {
+			opts = protobufEnvelopeOpts{recordField: true, updatedField: e.updatedField}
+			recordDataMessage = currentMessage
+		}
+
+		name, err := e.rawTableName(updatedRow.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		registered.schema = envelopeToProtobufMessage(name, opts, beforeDataMessage, afterDataMessage, recordDataMessage)
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := SQLNameToKafkaName(name) + confluentSubjectSuffixValue
+		registered.registryID, err = e.schemaRegistry.RegisterProtobufSchemaForSubject(
+			ctx, subject, registered.schema.Schema())
+		if err != nil {
+			return nil, err
+		}
+		e.valueCache.Add(cacheKey, registered)
+	}
+
+	header := confluentProtobufHeader(registered.registryID)
+	return registered.schema.BinaryFromRow(
+		header, evCtx.updated, hlc.Timestamp{} /* resolved */, prevRow, updatedRow, updatedRow)
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeResolvedTimestamp(
+	ctx context.Context, topic string, resolved hlc.Timestamp,
+) ([]byte, error) {
+	registered, ok := e.resolvedCache[topic]
+	if !ok {
+		opts := protobufEnvelopeOpts{resolvedField: true}
+		registered.schema = envelopeToProtobufMessage(topic, opts, nil /* before */, nil /* after */, nil /* record */)
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := SQLNameToKafkaName(topic) + confluentSubjectSuffixValue
+		var err error
+		registered.registryID, err = e.schemaRegistry.RegisterProtobufSchemaForSubject(
+			ctx, subject, registered.schema.Schema())
+		if err != nil {
+			return nil, err
+		}
+
+		e.resolvedCache[topic] = registered
+	}
+	header := confluentProtobufHeader(registered.registryID)
+	var nilRow cdcevent.Row
+	return registered.schema.BinaryFromRow(
+		header, hlc.Timestamp{} /* updated */, resolved, nilRow, nilRow, nilRow)
+}
+
+// confluentProtobufHeader returns the header of a protobuf message in the
+// confluent wire format. It is the same as for avro, followed by the indexes
+// of the message in the schema. We always encode the first message of the
+// schema, whose indexes are written as a single 0.
+//
+//	https://docs.confluent.io/platform/current/schema-registry/serdes-develop/index.html#wire-format
+func confluentProtobufHeader(registryID int32) []byte {
+	header := []byte{
+		changefeedbase.ConfluentAvroWireFormatMagic,
+		0, 0, 0, 0, // Placeholder for the ID.
+		0, // The message indexes.
+	}
+	binary.BigEndian.PutUint32(header[1:5], uint32(registryID))
+	return header
+}
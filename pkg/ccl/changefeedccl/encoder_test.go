@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/cockroach/pkg/workload/ledger"
 	"github.com/cockroachdb/cockroach/pkg/workload/workloadsql"
 	"github.com/stretchr/testify/require"
@@ -55,9 +56,12 @@ func TestEncoders(t *testing.T) {
 	ts := hlc.Timestamp{WallTime: 1, Logical: 2}
 
 	var opts []changefeedbase.EncodingOptions
-	for _, f := range []changefeedbase.FormatType{changefeedbase.OptFormatJSON, changefeedbase.OptFormatAvro} {
+	for _, f := range []changefeedbase.FormatType{
+		changefeedbase.OptFormatJSON, changefeedbase.OptFormatAvro, changefeedbase.OptFormatProtobuf,
+	} {
 		for _, e := range []changefeedbase.EnvelopeType{
 			changefeedbase.OptEnvelopeKeyOnly, changefeedbase.OptEnvelopeRow, changefeedbase.OptEnvelopeWrapped,
+			changefeedbase.OptEnvelopeDebezium,
 		} {
 			opts = append(opts,
 				changefeedbase.EncodingOptions{Format: f, Envelope: e, UpdatedTimestamps: false, Diff: false},
@@ -68,6 +72,12 @@ func TestEncoders(t *testing.T) {
 		}
 	}
 
+	const jsonDebeziumSource = `"source": {"connector": "cockroachdb", "mvcc_timestamp": "1.0000000002", ` +
+		`"snapshot": "false", "table": "foo", "ts_ms": 0}`
+	const avroDebeziumSource = `"source":{"foo_source":{"connector":{"string":"cockroachdb"},` +
+		`"mvcc_timestamp":{"string":"1.0000000002"},"snapshot":{"string":"false"},` +
+		`"table":{"string":"foo"},"ts_ms":{"long":0}}}`
+
 	expecteds := map[string]struct {
 		// Either err is set or all of insert, delete, and resolved are.
 		err      string
@@ -135,6 +145,26 @@ func TestEncoders(t *testing.T) {
 			delete:   `[1]->{"after": null, "before": {"a": 1, "b": "bar"}, "updated": "1.0000000002"}`,
 			resolved: `{"resolved":"1.0000000002"}`,
 		},
+		`format=json,envelope=debezium`: {
+			insert: `[1]->{"after": {"a": 1, "b": "bar"}, "before": null, "op": "c", ` +
+				jsonDebeziumSource + `, "ts_ms": 0}`,
+			delete: `[1]->{"after": null, "before": {"a": 1, "b": "bar"}, "op": "d", ` +
+				jsonDebeziumSource + `, "ts_ms": 0}`,
+			resolved: `{"__crdb__":{"resolved":"1.0000000002"}}`,
+		},
+		`format=json,envelope=debezium,updated`: {
+			err: `updated is not supported with envelope=debezium`,
+		},
+		`format=json,envelope=debezium,diff`: {
+			insert: `[1]->{"after": {"a": 1, "b": "bar"}, "before": null, "op": "c", ` +
+				jsonDebeziumSource + `, "ts_ms": 0}`,
+			delete: `[1]->{"after": null, "before": {"a": 1, "b": "bar"}, "op": "d", ` +
+				jsonDebeziumSource + `, "ts_ms": 0}`,
+			resolved: `{"__crdb__":{"resolved":"1.0000000002"}}`,
+		},
+		`format=json,envelope=debezium,updated,diff`: {
+			err: `updated is not supported with envelope=debezium`,
+		},
 		`format=avro,envelope=key_only`: {
 			insert:   `{"a":{"long":1}}->`,
 			delete:   `{"a":{"long":1}}->`,
@@ -194,6 +224,90 @@ func TestEncoders(t *testing.T) {
 				`"updated":{"string":"1.0000000002"}}`,
 			resolved: `{"resolved":{"string":"1.0000000002"}}`,
 		},
+		`format=avro,envelope=debezium`: {
+			insert: `{"a":{"long":1}}->` +
+				`{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}},"before":null,` +
+				`"op":{"string":"c"},` + avroDebeziumSource + `,"ts_ms":{"long":0}}`,
+			delete: `{"a":{"long":1}}->` +
+				`{"after":null,"before":{"foo_before":{"a":{"long":1},"b":{"string":"bar"}}},` +
+				`"op":{"string":"d"},` + avroDebeziumSource + `,"ts_ms":{"long":0}}`,
+			resolved: `{"resolved":{"string":"1.0000000002"}}`,
+		},
+		`format=avro,envelope=debezium,updated`: {
+			err: `updated is not supported with envelope=debezium`,
+		},
+		`format=avro,envelope=debezium,diff`: {
+			insert: `{"a":{"long":1}}->` +
+				`{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}},"before":null,` +
+				`"op":{"string":"c"},` + avroDebeziumSource + `,"ts_ms":{"long":0}}`,
+			delete: `{"a":{"long":1}}->` +
+				`{"after":null,"before":{"foo_before":{"a":{"long":1},"b":{"string":"bar"}}},` +
+				`"op":{"string":"d"},` + avroDebeziumSource + `,"ts_ms":{"long":0}}`,
+			resolved: `{"resolved":{"string":"1.0000000002"}}`,
+		},
+		`format=avro,envelope=debezium,updated,diff`: {
+			err: `updated is not supported with envelope=debezium`,
+		},
+		`format=protobuf,envelope=key_only`: {
+			insert:   `{"a":1}->`,
+			delete:   `{"a":1}->`,
+			resolved: `{"resolved":"1.0000000002"}`,
+		},
+		`format=protobuf,envelope=key_only,updated`: {
+			err: `updated is only usable with envelope=wrapped`,
+		},
+		`format=protobuf,envelope=key_only,diff`: {
+			err: `diff is only usable with envelope=wrapped`,
+		},
+		`format=protobuf,envelope=key_only,updated,diff`: {
+			err: `updated is only usable with envelope=wrapped`,
+		},
+		`format=protobuf,envelope=row`: {
+			err: `envelope=row is not supported with format=protobuf`,
+		},
+		`format=protobuf,envelope=row,updated`: {
+			err: `envelope=row is not supported with format=protobuf`,
+		},
+		`format=protobuf,envelope=row,diff`: {
+			err: `envelope=row is not supported with format=protobuf`,
+		},
+		`format=protobuf,envelope=row,updated,diff`: {
+			err: `envelope=row is not supported with format=protobuf`,
+		},
+		`format=protobuf,envelope=wrapped`: {
+			insert:   `{"a":1}->{"after":{"a":1,"b":"bar"}}`,
+			delete:   `{"a":1}->{"after":null}`,
+			resolved: `{"resolved":"1.0000000002"}`,
+		},
+		`format=protobuf,envelope=wrapped,updated`: {
+			insert:   `{"a":1}->{"after":{"a":1,"b":"bar"},"updated":"1.0000000002"}`,
+			delete:   `{"a":1}->{"after":null,"updated":"1.0000000002"}`,
+			resolved: `{"resolved":"1.0000000002"}`,
+		},
+		`format=protobuf,envelope=wrapped,diff`: {
+			insert:   `{"a":1}->{"after":{"a":1,"b":"bar"},"before":null}`,
+			delete:   `{"a":1}->{"after":null,"before":{"a":1,"b":"bar"}}`,
+			resolved: `{"resolved":"1.0000000002"}`,
+		},
+		`format=protobuf,envelope=wrapped,updated,diff`: {
+			insert: `{"a":1}->{"after":{"a":1,"b":"bar"},"before":null,` +
+				`"updated":"1.0000000002"}`,
+			delete: `{"a":1}->{"after":null,"before":{"a":1,"b":"bar"},` +
+				`"updated":"1.0000000002"}`,
+			resolved: `{"resolved":"1.0000000002"}`,
+		},
+		`format=protobuf,envelope=debezium`: {
+			err: `envelope=debezium is not supported with format=protobuf`,
+		},
+		`format=protobuf,envelope=debezium,updated`: {
+			err: `envelope=debezium is not supported with format=protobuf`,
+		},
+		`format=protobuf,envelope=debezium,diff`: {
+			err: `envelope=debezium is not supported with format=protobuf`,
+		},
+		`format=protobuf,envelope=debezium,updated,diff`: {
+			err: `envelope=debezium is not supported with format=protobuf`,
+		},
 	}
 
 	for _, o := range opts {
@@ -224,6 +338,17 @@ func TestEncoders(t *testing.T) {
 				resolvedStringFn = func(r []byte) string {
 					return string(avroToJSON(t, reg, r))
 				}
+			case changefeedbase.OptFormatProtobuf:
+				reg := cdctest.StartTestSchemaRegistry()
+				defer reg.Close()
+				o.SchemaRegistryURI = reg.URL()
+				rowStringFn = func(k, v []byte) string {
+					key, value := protobufToJSON(t, reg, k), protobufToJSON(t, reg, v)
+					return fmt.Sprintf(`%s->%s`, key, value)
+				}
+				resolvedStringFn = func(r []byte) string {
+					return string(protobufToJSON(t, reg, r))
+				}
 			default:
 				t.Fatalf(`unknown format: %s`, o.Format)
 			}
@@ -245,7 +370,7 @@ func TestEncoders(t *testing.T) {
 
 			rowInsert := cdcevent.TestingMakeEventRow(tableDesc, 0, row, false)
 			prevRow := cdcevent.TestingMakeEventRow(tableDesc, 0, nil, false)
-			evCtx := eventContext{updated: ts}
+			evCtx := eventContext{updated: ts, mvcc: ts}
 
 			keyInsert, err := e.EncodeKey(context.Background(), rowInsert)
 			require.NoError(t, err)
@@ -1063,6 +1188,104 @@ func makeTestTypes(rng *rand.Rand, numKeyTypes, numColTypes int) (keyTypes, valT
 	return randTypes(numKeyTypes, true), randTypes(numColTypes, false)
 }
 
+func TestProtobufEncoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (
+			a INT PRIMARY KEY, b STRING, c FLOAT, d BOOL, e BYTES, f DECIMAL, g UUID
+		)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES
+			(1, 'bar', 1.5, true, '\x0102', 1.50, '63616665-6630-3064-6465-616462656566'),
+			(2, NULL, NULL, NULL, NULL, NULL, NULL)`)
+
+		foo := feed(t, f, fmt.Sprintf(`CREATE CHANGEFEED FOR foo `+
+			`WITH format=%s, diff`, changefeedbase.OptFormatProtobuf))
+		defer closeFeed(t, foo)
+		assertPayloads(t, foo, []string{
+			`foo: {"a":1}->{"after":{"a":1,"b":"bar","c":1.5,"d":true,"e":"AQI=","f":"1.50",` +
+				`"g":"63616665-6630-3064-6465-616462656566"},"before":null}`,
+			`foo: {"a":2}->{"after":{"a":2,"b":null,"c":null,"d":null,"e":null,"f":null,"g":null},` +
+				`"before":null}`,
+		})
+
+		sqlDB.Exec(t, `DELETE FROM foo WHERE a = 2`)
+		assertPayloads(t, foo, []string{
+			`foo: {"a":2}->{"after":null,` +
+				`"before":{"a":2,"b":null,"c":null,"d":null,"e":null,"f":null,"g":null}}`,
+		})
+
+		reg := foo.(*kafkaFeed).registry
+		require.Equal(t, `syntax = "proto3";
+
+message foo {
+  optional int64 a = 1;
+}
+`, reg.SchemaForSubject(`foo-key`))
+
+		// Fields are numbered after their column ID, so dropping a column
+		// doesn't renumber the following ones.
+		sqlDB.Exec(t, `ALTER TABLE foo DROP COLUMN b`)
+		sqlDB.Exec(t, `INSERT INTO foo (a, g) VALUES (3, '63616665-6630-3064-6465-616462656566')`)
+		assertPayloads(t, foo, []string{
+			`foo: {"a":1}->{"after":{"a":1,"c":1.5,"d":true,"e":"AQI=","f":"1.50",` +
+				`"g":"63616665-6630-3064-6465-616462656566"},` +
+				`"before":{"a":1,"b":"bar","c":1.5,"d":true,"e":"AQI=","f":"1.50",` +
+				`"g":"63616665-6630-3064-6465-616462656566"}}`,
+			`foo: {"a":3}->{"after":{"a":3,"c":null,"d":null,"e":null,"f":null,` +
+				`"g":"63616665-6630-3064-6465-616462656566"},"before":null}`,
+		})
+		require.Contains(t, reg.SchemaForSubject(`foo-value`), `optional string g = 7;`)
+	}
+
+	cdcTest(t, testFn, feedTestForceSink("kafka"))
+}
+
+// TestProtobufEncoderWithoutPrevRow checks that an insert encoded with the
+// diff option, which may come without a previous row, has no before field.
+func TestProtobufEncoderWithoutPrevRow(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+	require.NoError(t, err)
+	row := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+	}
+	ts := hlc.Timestamp{WallTime: 1, Logical: 2}
+
+	reg := cdctest.StartTestSchemaRegistry()
+	defer reg.Close()
+	opts := changefeedbase.EncodingOptions{
+		Format:            changefeedbase.OptFormatProtobuf,
+		Envelope:          changefeedbase.OptEnvelopeWrapped,
+		Diff:              true,
+		SchemaRegistryURI: reg.URL(),
+	}
+	require.NoError(t, opts.Validate())
+	targets := changefeedbase.Targets{}
+	targets.Add(changefeedbase.Target{
+		Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		TableID:           tableDesc.GetID(),
+		StatementTimeName: changefeedbase.StatementTimeName(tableDesc.GetName()),
+	})
+	e, err := getEncoder(opts, targets, false, nil, nil)
+	require.NoError(t, err)
+
+	rowInsert := cdcevent.TestingMakeEventRow(tableDesc, 0, row, false)
+	key, err := e.EncodeKey(ctx, rowInsert)
+	require.NoError(t, err)
+	key = append([]byte(nil), key...)
+	value, err := e.EncodeValue(ctx, eventContext{updated: ts, mvcc: ts}, rowInsert, cdcevent.Row{})
+	require.NoError(t, err)
+	require.Equal(t, `{"a":1}->{"after":{"a":1,"b":"bar"}}`,
+		fmt.Sprintf(`%s->%s`, protobufToJSON(t, reg, key), protobufToJSON(t, reg, value)))
+}
+
 func TestParquetEncoder(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -1224,3 +1447,69 @@ func TestJsonRountrip(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONEncoderTransactionMetadata(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+	require.NoError(t, err)
+	row := cdcevent.TestingMakeEventRow(tableDesc, 0, rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+	}, false)
+
+	txnID := uuid.MakeV4()
+	ts := hlc.Timestamp{WallTime: 1}
+	withTxn := eventContext{updated: ts, mvcc: ts, txnID: txnID, txnEventCount: 2}
+	withoutTxn := eventContext{updated: ts, mvcc: ts}
+	txn := fmt.Sprintf(`{"id": "%s", "event_count": 2}`, txnID)
+
+	for _, tc := range []struct {
+		envelope changefeedbase.EnvelopeType
+		evCtx    eventContext
+		expected string
+	}{
+		{
+			envelope: changefeedbase.OptEnvelopeWrapped,
+			evCtx:    withTxn,
+			expected: `{"after": {"a": 1, "b": "bar"}, "txn": ` + txn + `}`,
+		},
+		{
+			envelope: changefeedbase.OptEnvelopeWrapped,
+			evCtx:    withoutTxn,
+			expected: `{"after": {"a": 1, "b": "bar"}, "txn": null}`,
+		},
+		{
+			envelope: changefeedbase.OptEnvelopeBare,
+			evCtx:    withTxn,
+			expected: `{"__crdb__": {"txn": ` + txn + `}, "a": 1, "b": "bar"}`,
+		},
+		{
+			envelope: changefeedbase.OptEnvelopeBare,
+			evCtx:    withoutTxn,
+			expected: `{"__crdb__": {"txn": null}, "a": 1, "b": "bar"}`,
+		},
+	} {
+		t.Run(fmt.Sprintf("%s/txn=%t", tc.envelope, tc.evCtx.txnEventCount > 0), func(t *testing.T) {
+			e, err := makeJSONEncoder(jsonEncoderOptions{
+				EncodingOptions: changefeedbase.EncodingOptions{
+					Format:      changefeedbase.OptFormatJSON,
+					Envelope:    tc.envelope,
+					TxnMetadata: true,
+				},
+			})
+			require.NoError(t, err)
+			value, err := e.EncodeValue(context.Background(), tc.evCtx, row, cdcevent.Row{})
+			require.NoError(t, err)
+
+			actual, err := json.ParseJSON(string(value))
+			require.NoError(t, err)
+			expected, err := json.ParseJSON(tc.expected)
+			require.NoError(t, err)
+			cmp, err := actual.Compare(expected)
+			require.NoError(t, err)
+			require.Zero(t, cmp, "expected %s, got %s", expected, actual)
+		})
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/span"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 )
 
@@ -46,6 +47,15 @@ type eventContext struct {
 	updated, mvcc hlc.Timestamp
 	// topic is set to the string to be included if TopicInValue is true
 	topic string
+	// backfill is set if the row was read by a scan of the table, such as the
+	// initial scan or a schema change backfill, rather than by a rangefeed.
+	backfill bool
+	// txnID is the ID of the transaction that wrote the row, if known, and
+	// txnEventCount is the number of events of that transaction emitted so
+	// far, including this one. They are only set if the transaction_metadata
+	// option is set.
+	txnID         uuid.UUID
+	txnEventCount int64
 }
 
 type eventConsumer interface {
@@ -70,6 +80,17 @@ type kvEventToRowConsumer struct {
 
 	topicDescriptorCache map[TopicIdentifier]TopicDescriptor
 	topicNamer           *TopicNamer
+	txnCounter           *txnEventCounter
+
+	// kafkaTxn, if it holds rows, is where rows are emitted instead of the
+	// sink; see kafkaTxnPartition.
+	kafkaTxn *kafkaTxnPartition
+
+	// deadLetterQueue, if set, is where rows which fail to be encoded with a
+	// terminal error are written. deadLetterKeyEncoder encodes the keys of
+	// those rows as JSON if the encoder failed to encode them.
+	deadLetterQueue      *deadLetterQueue
+	deadLetterKeyEncoder Encoder
 
 	metrics *sliMetrics
 
@@ -93,6 +114,8 @@ func newEventConsumer(
 	spanFrontier *span.Frontier,
 	cursor hlc.Timestamp,
 	sink EventSink,
+	kafkaTxn *kafkaTxnPartition,
+	deadLetterQueue *deadLetterQueue,
 	metrics *Metrics,
 	sliMetrics *sliMetrics,
 	knobs TestingKnobs,
@@ -105,6 +128,13 @@ func newEventConsumer(
 	pacerRequestUnit := changefeedbase.EventConsumerPacerRequestSize.Get(&cfg.Settings.SV)
 	enablePacer := changefeedbase.PerEventElasticCPUControlEnabled.Get(&cfg.Settings.SV)
 
+	// The transaction event counts are shared by all consumers, since the
+	// events of a transaction may be spread across them.
+	var txnCounter *txnEventCounter
+	if encodingOpts.TxnMetadata {
+		txnCounter = newTxnEventCounter()
+	}
+
 	makeConsumer := func(s EventSink, frontier frontier) (eventConsumer, error) {
 		var err error
 		encoder, err := getEncoder(encodingOpts, feed.Targets, spec.Select.Expr != "",
@@ -143,7 +173,7 @@ func newEventConsumer(
 
 		execCfg := cfg.ExecutorConfig.(*sql.ExecutorConfig)
 		return newKVEventToRowConsumer(ctx, execCfg, frontier, cursor, s,
-			encoder, feed, spec, knobs, topicNamer, sliMetrics, pacer)
+			encoder, feed, spec, knobs, topicNamer, txnCounter, kafkaTxn, deadLetterQueue, sliMetrics, pacer)
 	}
 
 	numWorkers := changefeedbase.EventConsumerWorkers.Get(&cfg.Settings.SV)
@@ -222,6 +252,9 @@ func newKVEventToRowConsumer(
 	spec execinfrapb.ChangeAggregatorSpec,
 	knobs TestingKnobs,
 	topicNamer *TopicNamer,
+	txnCounter *txnEventCounter,
+	kafkaTxn *kafkaTxnPartition,
+	deadLetterQueue *deadLetterQueue,
 	metrics *sliMetrics,
 	pacer *admission.Pacer,
 ) (_ *kvEventToRowConsumer, err error) {
@@ -245,6 +278,19 @@ func newKVEventToRowConsumer(
 		return nil, err
 	}
 
+	var deadLetterKeyEncoder Encoder
+	if deadLetterQueue != nil {
+		deadLetterKeyEncoder, err = makeJSONEncoder(jsonEncoderOptions{
+			EncodingOptions: changefeedbase.EncodingOptions{
+				Format:   changefeedbase.OptFormatJSON,
+				Envelope: changefeedbase.OptEnvelopeWrapped,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &kvEventToRowConsumer{
 		frontier:             frontier,
 		encoder:              encoder,
@@ -255,6 +301,10 @@ func newKVEventToRowConsumer(
 		knobs:                knobs,
 		topicDescriptorCache: make(map[TopicIdentifier]TopicDescriptor),
 		topicNamer:           topicNamer,
+		txnCounter:           txnCounter,
+		kafkaTxn:             kafkaTxn,
+		deadLetterQueue:      deadLetterQueue,
+		deadLetterKeyEncoder: deadLetterKeyEncoder,
 		evaluator:            evaluator,
 		encodingOpts:         encodingOpts,
 		metrics:              metrics,
@@ -337,7 +387,8 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 	prevSchemaTimestamp := schemaTimestamp
 	keyOnly := c.details.Opts.KeyOnly()
 
-	if backfillTs := ev.BackfillTimestamp(); !backfillTs.IsEmpty() {
+	backfillTs := ev.BackfillTimestamp()
+	if !backfillTs.IsEmpty() {
 		schemaTimestamp = backfillTs
 		prevSchemaTimestamp = schemaTimestamp.Prev()
 	}
@@ -383,14 +434,17 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 		}
 	}
 
-	return c.encodeAndEmit(ctx, updatedRow, prevRow, schemaTimestamp, ev.DetachAlloc())
+	return c.encodeAndEmit(ctx, ev.KV().Key, updatedRow, prevRow, schemaTimestamp, !backfillTs.IsEmpty(), ev.TxnID(), ev.DetachAlloc())
 }
 
 func (c *kvEventToRowConsumer) encodeAndEmit(
 	ctx context.Context,
+	kvKey roachpb.Key,
 	updatedRow cdcevent.Row,
 	prevRow cdcevent.Row,
 	schemaTS hlc.Timestamp,
+	backfill bool,
+	txnID uuid.UUID,
 	alloc kvevent.Alloc,
 ) error {
 	topic, err := c.topicForEvent(updatedRow.Metadata)
@@ -411,8 +465,17 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	}
 
 	evCtx := eventContext{
-		updated: schemaTS,
-		mvcc:    updatedRow.MvccTimestamp,
+		updated:  schemaTS,
+		mvcc:     updatedRow.MvccTimestamp,
+		backfill: backfill,
+	}
+
+	// The transaction of rows read by scans, such as the initial scan, schema
+	// change backfills and the catch-up scans of the rangefeed, is unknown.
+	// They are emitted without it.
+	if c.txnCounter != nil && !txnID.Equal(uuid.Nil) {
+		evCtx.txnID = txnID
+		evCtx.txnEventCount = c.txnCounter.next(txnID, updatedRow.MvccTimestamp, c.frontier.Frontier())
 	}
 
 	if c.topicNamer != nil {
@@ -438,14 +501,14 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	var keyCopy, valueCopy []byte
 	encodedKey, err := c.encoder.EncodeKey(ctx, updatedRow)
 	if err != nil {
-		return err
+		return c.maybeAddToDeadLetterQueue(ctx, updatedRow, topic, nil /* key */, evCtx, alloc, err)
 	}
 	c.scratch, keyCopy = c.scratch.Copy(encodedKey, 0 /* extraCap */)
 	// TODO(yevgeniy): Some refactoring is needed in the encoder: namely, prevRow
 	// might not be available at all when working with changefeed expressions.
 	encodedValue, err := c.encoder.EncodeValue(ctx, evCtx, updatedRow, prevRow)
 	if err != nil {
-		return err
+		return c.maybeAddToDeadLetterQueue(ctx, updatedRow, topic, keyCopy, evCtx, alloc, err)
 	}
 	c.scratch, valueCopy = c.scratch.Copy(encodedValue, 0 /* extraCap */)
 
@@ -453,7 +516,17 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	// than len(key)+len(bytes) worth of resources, adjust allocation to match.
 	alloc.AdjustBytesToTarget(ctx, int64(len(keyCopy)+len(valueCopy)))
 
-	if err := c.sink.EmitRow(
+	if c.kafkaTxn.holdsRows() {
+		c.kafkaTxn.hold(ctx, heldKafkaRow{
+			kvKey:   kvKey,
+			topic:   topic,
+			key:     keyCopy,
+			value:   valueCopy,
+			updated: schemaTS,
+			mvcc:    updatedRow.MvccTimestamp,
+			alloc:   alloc,
+		})
+	} else if err := c.sink.EmitRow(
 		ctx, topic, keyCopy, valueCopy, schemaTS, updatedRow.MvccTimestamp, alloc,
 	); err != nil {
 		return err
@@ -464,6 +537,41 @@ func (c *kvEventToRowConsumer) encodeAndEmit(
 	return nil
 }
 
+// maybeAddToDeadLetterQueue adds a row which failed to be encoded with a
+// terminal error to the dead letter queue, if there is one, so that the
+// changefeed carries on without it. The allocation of the row is released once
+// the dead letter queue is flushed. Otherwise, it returns the encoding error.
+func (c *kvEventToRowConsumer) maybeAddToDeadLetterQueue(
+	ctx context.Context,
+	row cdcevent.Row,
+	topic TopicDescriptor,
+	key []byte,
+	evCtx eventContext,
+	alloc kvevent.Alloc,
+	encodeErr error,
+) error {
+	if c.deadLetterQueue == nil || !changefeedbase.IsTerminalError(encodeErr) {
+		return encodeErr
+	}
+	if key == nil {
+		// The key only helps identify the row, so it's left out if it can't be
+		// encoded as JSON either. The encoder reuses its buffer, so the key is
+		// copied before it is buffered.
+		if jsonKey, err := c.deadLetterKeyEncoder.EncodeKey(ctx, row); err == nil {
+			c.scratch, key = c.scratch.Copy(jsonKey, 0 /* extraCap */)
+		}
+	}
+	c.deadLetterQueue.Add(deadLetter{
+		topic:   topic,
+		key:     key,
+		updated: evCtx.updated,
+		mvcc:    evCtx.mvcc,
+		err:     encodeErr,
+		alloc:   alloc,
+	})
+	return nil
+}
+
 // Close closes this consumer.
 func (c *kvEventToRowConsumer) Close() error {
 	c.pacer.Close()
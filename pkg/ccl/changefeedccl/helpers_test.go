@@ -287,6 +287,12 @@ func avroToJSON(t testing.TB, reg *cdctest.SchemaRegistry, avroBytes []byte) []b
 	return json
 }
 
+func protobufToJSON(t testing.TB, reg *cdctest.SchemaRegistry, protoBytes []byte) []byte {
+	json, err := reg.ProtobufToJSON(protoBytes)
+	require.NoError(t, err)
+	return json
+}
+
 func assertRegisteredSubjects(t testing.TB, reg *cdctest.SchemaRegistry, expected []string) {
 	t.Helper()
 
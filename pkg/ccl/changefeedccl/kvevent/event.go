@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 )
 
@@ -141,6 +142,13 @@ func (e *Event) PrevKeyValue() roachpb.KeyValue {
 	return roachpb.KeyValue{Key: v.Key, Value: v.PrevValue}
 }
 
+// TxnID returns the ID of the transaction that wrote this KV event, if known.
+// It is empty for events emitted by backfills and catch-up scans, and for
+// non-transactional writes.
+func (e *Event) TxnID() uuid.UUID {
+	return e.ev.Val.TxnID
+}
+
 func (e *Event) boundaryType() jobspb.ResolvedSpan_BoundaryType {
 	switch e.et {
 	case resolvedNone:
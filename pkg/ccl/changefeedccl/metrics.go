@@ -68,6 +68,7 @@ type AggMetrics struct {
 	InternalRetryMessageCount *aggmetric.AggGauge
 	SchemaRegistrations       *aggmetric.AggCounter
 	SchemaRegistryRetries     *aggmetric.AggCounter
+	DeadLetterQueueWrites     *aggmetric.AggCounter
 
 	// There is always at least 1 sliMetrics created for defaultSLI scope.
 	mu struct {
@@ -128,6 +129,7 @@ type sliMetrics struct {
 	InternalRetryMessageCount *aggmetric.Gauge
 	SchemaRegistrations       *aggmetric.Counter
 	SchemaRegistryRetries     *aggmetric.Counter
+	DeadLetterQueueWrites     *aggmetric.Counter
 }
 
 // sinkDoesNotCompress is a sentinel value indicating the sink
@@ -539,6 +541,12 @@ func newAggregateMetrics(histogramWindow time.Duration) *AggMetrics {
 		Measurement: "Registrations",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaChangefeedDeadLetterQueueWrites := metric.Metadata{
+		Name:        "changefeed.dead_letter_queue.writes",
+		Help:        "Rows written to the dead letter queue because they could not be encoded or emitted",
+		Measurement: "Messages",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaChangefeedParallelIOQueueNanos := metric.Metadata{
 		Name:        "changefeed.parallel_io_queue_nanos",
 		Help:        "Time spent with outgoing requests to the sink waiting in queue due to inflight requests with conflicting keys",
@@ -613,6 +621,7 @@ func newAggregateMetrics(histogramWindow time.Duration) *AggMetrics {
 		InternalRetryMessageCount: b.Gauge(metaInternalRetryMessageCount),
 		SchemaRegistryRetries:     b.Counter(metaSchemaRegistryRetriesCount),
 		SchemaRegistrations:       b.Counter(metaSchemaRegistryRegistrations),
+		DeadLetterQueueWrites:     b.Counter(metaChangefeedDeadLetterQueueWrites),
 	}
 	a.mu.sliMetrics = make(map[string]*sliMetrics)
 	_, err := a.getOrCreateScope(defaultSLIScope)
@@ -672,6 +681,7 @@ func (a *AggMetrics) getOrCreateScope(scope string) (*sliMetrics, error) {
 		InternalRetryMessageCount: a.InternalRetryMessageCount.AddChild(scope),
 		SchemaRegistryRetries:     a.SchemaRegistryRetries.AddChild(scope),
 		SchemaRegistrations:       a.SchemaRegistrations.AddChild(scope),
+		DeadLetterQueueWrites:     a.DeadLetterQueueWrites.AddChild(scope),
 	}
 
 	a.mu.sliMetrics[scope] = sm
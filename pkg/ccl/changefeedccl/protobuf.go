@@ -0,0 +1,353 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// This file maps SQL table schemas to Protocol Buffers messages.
+//
+// As for Avro, a table maps to a message with one field per column. The
+// message definitions are rendered as a proto3 schema, which is what the
+// Confluent schema registry expects, and rows are encoded directly in the
+// protobuf binary format.
+//
+// Every field is declared optional so that NULLs can be told apart from zero
+// values, and so that adjacent schemas for a table remain compatible with each
+// other. For the same reason, fields are numbered after the ID of their column
+// rather than its position, so that dropping or adding a column doesn't
+// renumber the other fields. Columns that don't belong to a table, such as the
+// ones projected by a changefeed expression, are numbered after their position.
+//
+// Types without a native protobuf equivalent are encoded as strings using their
+// SQL text representation.
+
+// protobufEncodeFn appends the protobuf encoding of a non-NULL datum, without
+// its tag, to b.
+type protobufEncodeFn func(b []byte, d tree.Datum) []byte
+
+// protobufField is our representation of a field of a protobuf message.
+type protobufField struct {
+	name   string
+	number protowire.Number
+	// typeName is the protobuf scalar type or message name of the field.
+	typeName string
+	wireType protowire.Type
+	encodeFn protobufEncodeFn
+}
+
+// protobufDataMessage is a protobuf message that represents the schema of a
+// SQL table or index.
+type protobufDataMessage struct {
+	name           string
+	fields         []*protobufField
+	fieldIdxByName map[string]int
+}
+
+// protobufEnvelopeOpts controls which fields in protobufEnvelopeMessage are
+// set.
+type protobufEnvelopeOpts struct {
+	beforeField, afterField, recordField bool
+	updatedField, resolvedField          bool
+}
+
+// The numbers of the fields of protobufEnvelopeMessage. They are fixed so that
+// the envelopes of a topic are compatible with each other regardless of the
+// changefeed options.
+const (
+	protobufAfterFieldNumber    protowire.Number = 1
+	protobufBeforeFieldNumber   protowire.Number = 2
+	protobufRecordFieldNumber   protowire.Number = 3
+	protobufUpdatedFieldNumber  protowire.Number = 4
+	protobufResolvedFieldNumber protowire.Number = 5
+)
+
+// protobufEnvelopeMessage is a protobuf message that wraps a changed SQL row
+// and some metadata.
+type protobufEnvelopeMessage struct {
+	name                  string
+	opts                  protobufEnvelopeOpts
+	before, after, record *protobufDataMessage
+
+	// scratch is used to encode the nested row messages before appending them
+	// to the envelope, to avoid repeated allocations.
+	scratch []byte
+}
+
+// typeToProtobufField returns the protobuf type, wire type and encoding
+// function of a field holding values of the given SQL type.
+func typeToProtobufField(typ *types.T) (string, protowire.Type, protobufEncodeFn) {
+	switch typ.Family() {
+	case types.BoolFamily:
+		return `bool`, protowire.VarintType, func(b []byte, d tree.Datum) []byte {
+			return protowire.AppendVarint(b, protowire.EncodeBool(bool(*d.(*tree.DBool))))
+		}
+	case types.IntFamily:
+		return `int64`, protowire.VarintType, func(b []byte, d tree.Datum) []byte {
+			return protowire.AppendVarint(b, uint64(*d.(*tree.DInt)))
+		}
+	case types.FloatFamily:
+		return `double`, protowire.Fixed64Type, func(b []byte, d tree.Datum) []byte {
+			return protowire.AppendFixed64(b, math.Float64bits(float64(*d.(*tree.DFloat))))
+		}
+	case types.BytesFamily:
+		return `bytes`, protowire.BytesType, func(b []byte, d tree.Datum) []byte {
+			return protowire.AppendBytes(b, []byte(*d.(*tree.DBytes)))
+		}
+	case types.StringFamily:
+		return `string`, protowire.BytesType, func(b []byte, d tree.Datum) []byte {
+			return protowire.AppendString(b, string(tree.MustBeDString(d)))
+		}
+	case types.CollatedStringFamily:
+		return `string`, protowire.BytesType, func(b []byte, d tree.Datum) []byte {
+			return protowire.AppendString(b, d.(*tree.DCollatedString).Contents)
+		}
+	case types.UuidFamily:
+		return `string`, protowire.BytesType, func(b []byte, d tree.Datum) []byte {
+			return protowire.AppendString(b, d.(*tree.DUuid).UUID.String())
+		}
+	case types.EnumFamily:
+		return `string`, protowire.BytesType, func(b []byte, d tree.Datum) []byte {
+			return protowire.AppendString(b, d.(*tree.DEnum).LogicalRep)
+		}
+	default:
+		return `string`, protowire.BytesType, func(b []byte, d tree.Datum) []byte {
+			return protowire.AppendString(b, tree.AsStringWithFlags(d, tree.FmtBareStrings))
+		}
+	}
+}
+
+// columnToProtobufField converts a column into its corresponding protobuf
+// field.
+func columnToProtobufField(col cdcevent.ResultColumn) (*protobufField, error) {
+	number := protowire.Number(col.PGAttributeNum)
+	if number == 0 {
+		number = protowire.Number(col.Ordinal() + 1)
+	}
+	if !number.IsValid() {
+		return nil, changefeedbase.WithTerminalError(
+			errors.Newf("column %s cannot be numbered as protobuf field %d", col.Name, number))
+	}
+	field := &protobufField{
+		name:   SQLNameToAvroName(col.Name),
+		number: number,
+	}
+	field.typeName, field.wireType, field.encodeFn = typeToProtobufField(col.Typ)
+	return field, nil
+}
+
+// newProtobufMessageForRow constructs the protobuf message for the columns
+// returned by the iterator. Protobuf identifiers have the same restrictions as
+// Avro names, so name is expected to be escaped with SQLNameToAvroName.
+func newProtobufMessageForRow(it cdcevent.Iterator, name string) (*protobufDataMessage, error) {
+	msg := &protobufDataMessage{
+		name:           name,
+		fieldIdxByName: make(map[string]int),
+	}
+	if err := it.Col(func(col cdcevent.ResultColumn) error {
+		field, err := columnToProtobufField(col)
+		if err != nil {
+			return err
+		}
+		msg.fieldIdxByName[col.Name] = len(msg.fields)
+		msg.fields = append(msg.fields, field)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// primaryIndexToProtobufMessage constructs the protobuf message for the primary
+// index.
+func primaryIndexToProtobufMessage(row cdcevent.Row, sqlName string) (*protobufDataMessage, error) {
+	return newProtobufMessageForRow(row.ForEachKeyColumn(), SQLNameToAvroName(sqlName))
+}
+
+// tableToProtobufMessage constructs the protobuf message for the event values.
+// If a name suffix is provided, it is appended to the end of the message name.
+func tableToProtobufMessage(row cdcevent.Row, nameSuffix string) (*protobufDataMessage, error) {
+	var sqlName string
+	if row.HasOtherFamilies {
+		sqlName = SQLNameToAvroName(row.TableName + "." + row.FamilyName)
+	} else {
+		sqlName = SQLNameToAvroName(row.TableName)
+	}
+	if nameSuffix != avroSchemaNoSuffix {
+		sqlName = sqlName + `_` + nameSuffix
+	}
+	return newProtobufMessageForRow(row.ForEachColumn(), sqlName)
+}
+
+// writeProtobufSyntax writes the header of a proto3 schema.
+func writeProtobufSyntax(buf *strings.Builder) {
+	buf.WriteString("syntax = \"proto3\";\n")
+}
+
+// writeDefinition writes the definition of the message in the proto3 syntax.
+func (m *protobufDataMessage) writeDefinition(buf *strings.Builder) {
+	fmt.Fprintf(buf, "\nmessage %s {\n", m.name)
+	for _, f := range m.fields {
+		fmt.Fprintf(buf, "  optional %s %s = %d;\n", f.typeName, f.name, f.number)
+	}
+	buf.WriteString("}\n")
+}
+
+// Schema returns the proto3 schema defining the message.
+func (m *protobufDataMessage) Schema() string {
+	var buf strings.Builder
+	writeProtobufSyntax(&buf)
+	m.writeDefinition(&buf)
+	return buf.String()
+}
+
+// refreshTypeMetadata replaces the encoders of the fields holding user-defined
+// types on a cached message, in case the types have changed.
+func (m *protobufDataMessage) refreshTypeMetadata(row cdcevent.Row) error {
+	return row.ForEachUDTColumn().Col(func(col cdcevent.ResultColumn) error {
+		if fieldIdx, ok := m.fieldIdxByName[col.Name]; ok {
+			field := m.fields[fieldIdx]
+			field.typeName, field.wireType, field.encodeFn = typeToProtobufField(col.Typ)
+		}
+		return nil
+	})
+}
+
+// BinaryFromRow appends the protobuf binary encoding of the given row data to
+// buf. NULL values are omitted.
+func (m *protobufDataMessage) BinaryFromRow(buf []byte, it cdcevent.Iterator) ([]byte, error) {
+	if err := it.Datum(func(d tree.Datum, col cdcevent.ResultColumn) error {
+		fieldIdx, ok := m.fieldIdxByName[col.Name]
+		if !ok {
+			return changefeedbase.WithTerminalError(
+				errors.AssertionFailedf("could not find protobuf field for column %s", col.Name))
+		}
+		if d == tree.DNull {
+			return nil
+		}
+		field := m.fields[fieldIdx]
+		buf = protowire.AppendTag(buf, field.number, field.wireType)
+		buf = field.encodeFn(buf, d)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// envelopeToProtobufMessage creates a protobuf message for an envelope
+// containing before and after versions of a row change and metadata about that
+// row change. before is optional, and after can instead be record.
+func envelopeToProtobufMessage(
+	topic string, opts protobufEnvelopeOpts, before, after, record *protobufDataMessage,
+) *protobufEnvelopeMessage {
+	m := &protobufEnvelopeMessage{
+		name: SQLNameToAvroName(topic) + `_envelope`,
+		opts: opts,
+	}
+	if opts.beforeField {
+		m.before = before
+	}
+	if opts.afterField {
+		m.after = after
+	}
+	if opts.recordField {
+		m.record = record
+	}
+	return m
+}
+
+// Schema returns the proto3 schema defining the envelope message, followed by
+// the messages of the rows it contains. The envelope comes first so that its
+// message index in the Confluent wire format is 0.
+func (m *protobufEnvelopeMessage) Schema() string {
+	var buf strings.Builder
+	writeProtobufSyntax(&buf)
+	fmt.Fprintf(&buf, "\nmessage %s {\n", m.name)
+	if m.opts.afterField {
+		fmt.Fprintf(&buf, "  %s after = %d;\n", m.after.name, protobufAfterFieldNumber)
+	}
+	// There is no before message if the first row encoded with the envelope
+	// had no previous row, as for avro.
+	if m.opts.beforeField && m.before != nil {
+		fmt.Fprintf(&buf, "  %s before = %d;\n", m.before.name, protobufBeforeFieldNumber)
+	}
+	if m.opts.recordField {
+		fmt.Fprintf(&buf, "  %s record = %d;\n", m.record.name, protobufRecordFieldNumber)
+	}
+	if m.opts.updatedField {
+		fmt.Fprintf(&buf, "  optional string updated = %d;\n", protobufUpdatedFieldNumber)
+	}
+	if m.opts.resolvedField {
+		fmt.Fprintf(&buf, "  optional string resolved = %d;\n", protobufResolvedFieldNumber)
+	}
+	buf.WriteString("}\n")
+	for _, data := range []*protobufDataMessage{m.after, m.before, m.record} {
+		if data != nil {
+			data.writeDefinition(&buf)
+		}
+	}
+	return buf.String()
+}
+
+// appendRowField appends the given row as a nested message field to buf.
+func (m *protobufEnvelopeMessage) appendRowField(
+	buf []byte, number protowire.Number, data *protobufDataMessage, row cdcevent.Row,
+) ([]byte, error) {
+	var err error
+	m.scratch, err = data.BinaryFromRow(m.scratch[:0], row.ForEachColumn())
+	if err != nil {
+		return nil, err
+	}
+	buf = protowire.AppendTag(buf, number, protowire.BytesType)
+	return protowire.AppendBytes(buf, m.scratch), nil
+}
+
+// BinaryFromRow appends the protobuf binary encoding of the given metadata and
+// row data to buf.
+func (m *protobufEnvelopeMessage) BinaryFromRow(
+	buf []byte, updated, resolved hlc.Timestamp, beforeRow, afterRow, recordRow cdcevent.Row,
+) ([]byte, error) {
+	var err error
+	if m.opts.afterField && afterRow.HasValues() && !afterRow.IsDeleted() {
+		if buf, err = m.appendRowField(buf, protobufAfterFieldNumber, m.after, afterRow); err != nil {
+			return nil, err
+		}
+	}
+	if m.opts.beforeField && m.before != nil && beforeRow.HasValues() && !beforeRow.IsDeleted() {
+		if buf, err = m.appendRowField(buf, protobufBeforeFieldNumber, m.before, beforeRow); err != nil {
+			return nil, err
+		}
+	}
+	if m.opts.recordField && recordRow.HasValues() {
+		if buf, err = m.appendRowField(buf, protobufRecordFieldNumber, m.record, recordRow); err != nil {
+			return nil, err
+		}
+	}
+	if m.opts.updatedField {
+		buf = protowire.AppendTag(buf, protobufUpdatedFieldNumber, protowire.BytesType)
+		buf = protowire.AppendString(buf, timestampToString(updated))
+	}
+	if m.opts.resolvedField {
+		buf = protowire.AppendTag(buf, protobufResolvedFieldNumber, protowire.BytesType)
+		buf = protowire.AppendString(buf, timestampToString(resolved))
+	}
+	return buf, nil
+}
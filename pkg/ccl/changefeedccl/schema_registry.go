@@ -30,6 +30,13 @@ import (
 
 const confluentSchemaContentType = `application/vnd.schemaregistry.v1+json`
 
+// The schema types understood by the confluent schema registry. AVRO is
+// assumed when no schema type is given.
+const (
+	confluentSchemaTypeAvro     = ``
+	confluentSchemaTypeProtobuf = `PROTOBUF`
+)
+
 type schemaRegistry interface {
 	// Ping tests the connectivity to the schema registry. A nil
 	// error is returned if the schema registry appears to be
@@ -41,10 +48,16 @@ type schemaRegistry interface {
 	// be used in Avro wire messages or in other calls to the
 	// schema registry.
 	RegisterSchemaForSubject(ctx context.Context, subject string, schema string) (int32, error)
+
+	// RegisterProtobufSchemaForSubject is like RegisterSchemaForSubject,
+	// but for a schema in the proto3 syntax. The returned schema ID can
+	// be used in Protobuf wire messages.
+	RegisterProtobufSchemaForSubject(ctx context.Context, subject string, schema string) (int32, error)
 }
 
 type confluentSchemaVersionRequest struct {
-	Schema string `json:"schema"`
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
 }
 
 type confluentSchemaVersionResponse struct {
@@ -225,12 +238,28 @@ func (r *confluentSchemaRegistry) Ping(ctx context.Context) error {
 func (r *confluentSchemaRegistry) RegisterSchemaForSubject(
 	ctx context.Context, subject string, schema string,
 ) (int32, error) {
-	u := r.urlForPath(fmt.Sprintf("subjects/%s/versions", subject))
 	if log.V(1) {
-		log.Infof(ctx, "registering avro schema %s %s", u, schema)
+		log.Infof(ctx, "registering avro schema for %s %s", subject, schema)
+	}
+	return r.registerSchemaForSubject(ctx, subject, confluentSchemaTypeAvro, schema)
+}
+
+// RegisterProtobufSchemaForSubject registers the given protobuf schema for
+// the given subject.
+func (r *confluentSchemaRegistry) RegisterProtobufSchemaForSubject(
+	ctx context.Context, subject string, schema string,
+) (int32, error) {
+	if log.V(1) {
+		log.Infof(ctx, "registering protobuf schema for %s %s", subject, schema)
 	}
+	return r.registerSchemaForSubject(ctx, subject, confluentSchemaTypeProtobuf, schema)
+}
 
-	req := confluentSchemaVersionRequest{Schema: schema}
+func (r *confluentSchemaRegistry) registerSchemaForSubject(
+	ctx context.Context, subject string, schemaType string, schema string,
+) (int32, error) {
+	u := r.urlForPath(fmt.Sprintf("subjects/%s/versions", subject))
+	req := confluentSchemaVersionRequest{Schema: schema, SchemaType: schemaType}
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(req); err != nil {
 		return 0, err
@@ -312,8 +341,9 @@ func (r *confluentSchemaRegistry) urlForPath(relPath string) string {
 }
 
 type schemaRegistryCacheKey struct {
-	subject string
-	schema  string
+	subject    string
+	schemaType string
+	schema     string
 }
 
 type schemaRegistryCache struct {
@@ -350,9 +380,28 @@ func (csr *schemaRegistryWithCache) Ping(ctx context.Context) error {
 // RegisterSchemaForSubject implements the schemaRegistry interface.
 func (csr *schemaRegistryWithCache) RegisterSchemaForSubject(
 	ctx context.Context, subject string, schema string,
+) (int32, error) {
+	return csr.registerWithCache(ctx, subject, confluentSchemaTypeAvro, schema,
+		csr.base.RegisterSchemaForSubject)
+}
+
+// RegisterProtobufSchemaForSubject implements the schemaRegistry interface.
+func (csr *schemaRegistryWithCache) RegisterProtobufSchemaForSubject(
+	ctx context.Context, subject string, schema string,
+) (int32, error) {
+	return csr.registerWithCache(ctx, subject, confluentSchemaTypeProtobuf, schema,
+		csr.base.RegisterProtobufSchemaForSubject)
+}
+
+func (csr *schemaRegistryWithCache) registerWithCache(
+	ctx context.Context,
+	subject string,
+	schemaType string,
+	schema string,
+	register func(ctx context.Context, subject string, schema string) (int32, error),
 ) (int32, error) {
 	cacheKey := schemaRegistryCacheKey{
-		subject: subject, schema: schema,
+		subject: subject, schemaType: schemaType, schema: schema,
 	}
 	csr.cache.mu.Lock()
 	defer csr.cache.mu.Unlock()
@@ -360,7 +409,7 @@ func (csr *schemaRegistryWithCache) RegisterSchemaForSubject(
 	if ok {
 		return id, nil
 	}
-	id, err := csr.base.RegisterSchemaForSubject(ctx, subject, schema)
+	id, err := register(ctx, subject, schema)
 	if err == nil {
 		csr.cache.Add(cacheKey, id)
 	}
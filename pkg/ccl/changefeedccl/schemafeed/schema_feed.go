@@ -209,14 +209,14 @@ func (t *typeDependencyTracker) removeDependency(typeID, tableID descpb.ID) {
 
 func (t *typeDependencyTracker) purgeTable(tbl catalog.TableDescriptor) {
 	for _, col := range tbl.UserDefinedTypeColumns() {
-		id := typedesc.UserDefinedTypeOIDToID(col.GetType().Oid())
+		id := typedesc.UserDefinedTypeOIDToID(col.GetType().UserDefinedOID())
 		t.removeDependency(id, tbl.GetID())
 	}
 }
 
 func (t *typeDependencyTracker) ingestTable(tbl catalog.TableDescriptor) {
 	for _, col := range tbl.UserDefinedTypeColumns() {
-		id := typedesc.UserDefinedTypeOIDToID(col.GetType().Oid())
+		id := typedesc.UserDefinedTypeOIDToID(col.GetType().UserDefinedOID())
 		t.addDependency(id, tbl.GetID())
 	}
 }
@@ -123,9 +123,10 @@ func getEventSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	kafkaTxn *kafkaTxnPartition,
 	m metricsRecorder,
 ) (EventSink, error) {
-	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, kafkaTxn, m)
 }
 
 func getResolvedTimestampSink(
@@ -135,9 +136,10 @@ func getResolvedTimestampSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	kafkaTxn *kafkaTxnPartition,
 	m metricsRecorder,
 ) (ResolvedTimestampSink, error) {
-	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	return getAndDialSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, kafkaTxn, m)
 }
 
 func getAndDialSink(
@@ -147,9 +149,10 @@ func getAndDialSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	kafkaTxn *kafkaTxnPartition,
 	m metricsRecorder,
 ) (Sink, error) {
-	sink, err := getSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	sink, err := getSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, kafkaTxn, m)
 	if err != nil {
 		return nil, err
 	}
@@ -183,6 +186,7 @@ func getSink(
 	timestampOracle timestampLowerBoundOracle,
 	user username.SQLUsername,
 	jobID jobspb.JobID,
+	kafkaTxn *kafkaTxnPartition,
 	m metricsRecorder,
 ) (Sink, error) {
 	u, err := url.Parse(feedCfg.SinkURI)
@@ -230,7 +234,9 @@ func getSink(
 			return makeNullSink(sinkURL{URL: u}, metricsBuilder(nullIsAccounted))
 		case u.Scheme == changefeedbase.SinkSchemeKafka:
 			return validateOptionsAndMakeSink(changefeedbase.KafkaValidOptions, func() (Sink, error) {
-				return makeKafkaSink(ctx, sinkURL{URL: u}, AllTargets(feedCfg), opts.GetKafkaConfigJSON(), serverCfg.Settings, metricsBuilder)
+				return makeKafkaSink(ctx, sinkURL{URL: u}, AllTargets(feedCfg), opts.GetKafkaConfigJSON(),
+					jobID, kafkaTxn, opts.IsSet(changefeedbase.OptDeadLetterQueue),
+					serverCfg.Settings, metricsBuilder)
 			})
 		case isWebhookSink(u):
 			webhookOpts, err := opts.GetWebhookSinkOptions()
@@ -284,7 +290,7 @@ func getSink(
 			return validateOptionsAndMakeSink(changefeedbase.ExternalConnectionValidOptions, func() (Sink, error) {
 				return makeExternalConnectionSink(
 					ctx, sinkURL{URL: u}, user, makeExternalConnectionProvider(ctx, serverCfg.DB),
-					serverCfg, feedCfg, timestampOracle, jobID, m,
+					serverCfg, feedCfg, timestampOracle, jobID, kafkaTxn, m,
 				)
 			})
 		case u.Scheme == "":
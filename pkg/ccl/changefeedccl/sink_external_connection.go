@@ -32,6 +32,7 @@ func makeExternalConnectionSink(
 	feedCfg jobspb.ChangefeedDetails,
 	timestampOracle timestampLowerBoundOracle,
 	jobID jobspb.JobID,
+	kafkaTxn *kafkaTxnPartition,
 	m metricsRecorder,
 ) (Sink, error) {
 	if u.Host == "" {
@@ -48,7 +49,7 @@ func makeExternalConnectionSink(
 	// Replace the external connection URI in the `feedCfg` with the URI of the
 	// underlying resource.
 	feedCfg.SinkURI = uri
-	return getSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, m)
+	return getSink(ctx, serverCfg, feedCfg, timestampOracle, user, jobID, kafkaTxn, m)
 }
 
 func validateExternalConnectionSinkURI(
@@ -78,7 +79,7 @@ func validateExternalConnectionSinkURI(
 	// TODO(adityamaru): When we add `CREATE EXTERNAL CONNECTION ... WITH` support
 	// to accept JSONConfig we should validate that here too.
 	_, err := getSink(ctx, serverCfg, jobspb.ChangefeedDetails{SinkURI: uri}, nil, env.Username,
-		jobspb.JobID(0), nil)
+		jobspb.JobID(0), nil /* kafkaTxn */, nil)
 	if err != nil {
 		return errors.Wrap(err, "invalid changefeed sink URI")
 	}
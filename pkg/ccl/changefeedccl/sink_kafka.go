@@ -26,12 +26,16 @@ import (
 	"github.com/Shopify/sarama"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/util/bufalloc"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
@@ -65,6 +69,9 @@ type kafkaSinkKnobs struct {
 	OverrideClientInit              func(config *sarama.Config) (kafkaClient, error)
 	OverrideAsyncProducerFromClient func(kafkaClient) (sarama.AsyncProducer, error)
 	OverrideSyncProducerFromClient  func(kafkaClient) (sarama.SyncProducer, error)
+	// OverrideReadCommitted returns the committed records of partition 0 of
+	// the given topic, which transactional sinks read their frontier from.
+	OverrideReadCommitted func(topic string) ([]*sarama.ConsumerMessage, error)
 }
 
 var _ sarama.StdLogger = (*kafkaLogAdapter)(nil)
@@ -131,12 +138,183 @@ type kafkaSink struct {
 	// Only synchronized between the client goroutine and the worker goroutine.
 	mu struct {
 		syncutil.Mutex
-		inflight int64
-		flushErr error
-		flushCh  chan struct{}
+		inflight      int64
+		flushErr      error
+		flushCh       chan struct{}
+		undeliverable []deadLetter
+		// txnAlloc holds the memory of the rows acknowledged in the open
+		// transaction of a transactional sink until it is committed.
+		txnAlloc kvevent.Alloc
 	}
 
 	disableInternalRetry bool
+
+	// collectUndeliverable is set if the changefeed has a dead letter queue.
+	// Rows which Kafka rejects for reasons which retrying won't fix are then
+	// collected in mu.undeliverable and handed back by Flush in an
+	// undeliverableRowsError, rather than failing the sink.
+	collectUndeliverable bool
+
+	// txn is the partition of the changefeed whose messages a transactional
+	// sink commits.
+	txn *kafkaTxnPartition
+}
+
+// kafkaTxnFrontierTopic is the topic to which transactional sinks whose
+// partition holds rows commit their frontier, along with the rows it covers.
+// Its records are keyed by transactional ID, so it may be compacted.
+const kafkaTxnFrontierTopic = "crdb_changefeed_frontier"
+
+// kafkaTxnPartition is the part of the work of a changefeed whose messages are
+// committed by the Kafka transactions of a single transactional producer.
+//
+// The partition of a change aggregator holds the rows the aggregator emits
+// until the span frontier of the aggregator reaches the keys they were read
+// from. Rows are then produced and committed in a Kafka transaction along with
+// a record of the frontier, written to kafkaTxnFrontierTopic. The aggregator
+// only checkpoints its spans up to the committed frontier.
+//
+// When the changefeed restarts, each sink reads the records committed by all
+// the partitions of the changefeed, and drops the rows which are emitted again
+// at or below the frontier committed for their key, so that consumers reading
+// with isolation.level=read_committed see every row once, even if the spans
+// were assigned to other partitions. The rows of scans, such as the initial
+// scan and schema change backfills, are emitted at the timestamp which their
+// span is resolved at once scanned, so they're handled the same way.
+type kafkaTxnPartition struct {
+	// id distinguishes the transactional ID of the partition from those of the
+	// other partitions of the changefeed. Creating a producer with the same ID
+	// fences off the previous one, so it must be the same for the same
+	// processor across replans: change aggregators use the ID of their SQL
+	// instance, which runs at most one of them. Producers of instances which
+	// are no longer part of the plan are not fenced off, and their open
+	// transactions are aborted when they time out.
+	id string
+	// frontier, if set, is the span frontier of the change aggregator, which
+	// the partition holds rows until. Otherwise, messages are committed on
+	// every flush.
+	frontier *span.Frontier
+
+	// transactional and jobID are set by the sink of the partition if it is
+	// transactional.
+	transactional bool
+	jobID         jobspb.JobID
+	// previous is the frontier committed to Kafka by all the partitions of the
+	// changefeed before the sink was dialed, and committed the frontier up to
+	// which the spans of the partition may be checkpointed. They're set by the
+	// sink when it is dialed, if the partition holds rows.
+	previous, committed *span.Frontier
+
+	mu struct {
+		syncutil.Mutex
+		// held are the rows held until the frontier reaches them, in the order
+		// in which they were emitted.
+		held []heldKafkaRow
+		// released counts the rows released to the sink so far.
+		released int64
+	}
+}
+
+// heldKafkaRow is a row held by a kafkaTxnPartition. kvKey is the key of the
+// KV the row was read from.
+type heldKafkaRow struct {
+	kvKey         roachpb.Key
+	topic         TopicDescriptor
+	key, value    []byte
+	updated, mvcc hlc.Timestamp
+	alloc         kvevent.Alloc
+}
+
+// holdsRows returns whether the rows emitted to the partition are held until
+// its frontier reaches them, rather than emitted to its sink.
+func (p *kafkaTxnPartition) holdsRows() bool {
+	return p != nil && p.transactional && p.frontier != nil
+}
+
+// hold holds the row until the frontier of the partition reaches it. Rows
+// committed to Kafka before the changefeed restarted are dropped. The memory of
+// held rows is only released once they are committed, which applies
+// backpressure to the aggregator when the memory budget of the changefeed is
+// used up.
+func (p *kafkaTxnPartition) hold(ctx context.Context, row heldKafkaRow) {
+	if row.updated.LessEq(frontierAt(p.previous, row.kvKey)) {
+		row.alloc.Release(ctx)
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mu.held = append(p.mu.held, row)
+}
+
+// release calls emit, in order, for the held rows which the frontier reached.
+func (p *kafkaTxnPartition) release(emit func(heldKafkaRow) error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	remaining := p.mu.held[:0]
+	var err error
+	for _, row := range p.mu.held {
+		if err != nil || frontierAt(p.frontier, row.kvKey).Less(row.updated) {
+			remaining = append(remaining, row)
+			continue
+		}
+		if err = emit(row); err == nil {
+			p.mu.released++
+		}
+	}
+	for i := len(remaining); i < len(p.mu.held); i++ {
+		p.mu.held[i] = heldKafkaRow{}
+	}
+	p.mu.held = remaining
+	return err
+}
+
+// heldRows returns the number of rows held by the partition.
+func (p *kafkaTxnPartition) heldRows() int {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.mu.held)
+}
+
+// releasedRows returns the number of rows released by the partition so far.
+func (p *kafkaTxnPartition) releasedRows() int64 {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.mu.released
+}
+
+// drop releases the memory of the held rows, which are not emitted.
+func (p *kafkaTxnPartition) drop(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.mu.held {
+		p.mu.held[i].alloc.Release(ctx)
+	}
+	p.mu.held = nil
+}
+
+// checkpointable returns the frontier up to which the spans of the partition
+// may be checkpointed, given the frontier f of the change aggregator.
+func (p *kafkaTxnPartition) checkpointable(f *span.Frontier) *span.Frontier {
+	if !p.holdsRows() || p.committed == nil {
+		// Until the sink is dialed, the aggregator emits nothing.
+		return f
+	}
+	return p.committed
+}
+
+// frontierAt returns the timestamp of the given frontier at the given key.
+func frontierAt(f *span.Frontier, key roachpb.Key) (ts hlc.Timestamp) {
+	f.SpanEntries(roachpb.Span{Key: key, EndKey: key.Next()}, func(_ roachpb.Span, entryTS hlc.Timestamp) span.OpResult {
+		ts = entryTS
+		return span.StopMatch
+	})
+	return ts
 }
 
 func (s *kafkaSink) getConcreteType() sinkType {
@@ -189,6 +367,14 @@ type saramaConfig struct {
 	RequiredAcks string `json:",omitempty"`
 
 	Version string `json:",omitempty"`
+
+	// Transactional makes the sink use an idempotent, transactional producer.
+	// Rows are committed in Kafka transactions along with the frontier they
+	// reach, which is written to the crdb_changefeed_frontier topic, so
+	// consumers reading with isolation.level=read_committed don't see the rows
+	// which are emitted again after a changefeed restart. See
+	// kafkaTxnPartition.
+	Transactional bool `json:",omitempty"`
 }
 
 func (c saramaConfig) Validate() error {
@@ -200,6 +386,12 @@ func (c saramaConfig) Validate() error {
 	if (c.Flush.Bytes > 0 || c.Flush.Messages > 1) && c.Flush.Frequency == 0 {
 		return errors.New("Flush.Frequency must be > 0 when Flush.Bytes > 0 or Flush.Messages > 1")
 	}
+	// Idempotent producers require acknowledgement from all in-sync replicas.
+	if c.Transactional && c.RequiredAcks != "" {
+		if acks, err := parseRequiredAcks(c.RequiredAcks); err == nil && acks != sarama.WaitForAll {
+			return errors.New(`RequiredAcks must be "ALL" when Transactional is set`)
+		}
+	}
 	return nil
 }
 
@@ -256,6 +448,15 @@ func (s *kafkaSink) Dial() error {
 	s.client = client
 	s.producer = producer
 
+	// Creating the producer fenced off the previous producer with the same
+	// transactional ID and completed its last transaction, so the frontier
+	// committed by that producer is now final.
+	if s.txn.holdsRows() {
+		if err := s.readCommittedFrontier(); err != nil {
+			return err
+		}
+	}
+
 	// Start the worker
 	s.stopWorkerCh = make(chan struct{})
 	s.worker.Add(1)
@@ -308,6 +509,124 @@ func (s *kafkaSink) newSyncProducer(client kafkaClient) (sarama.SyncProducer, er
 	return producer, nil
 }
 
+// readCommittedFrontier sets the previous frontier of the partition of the sink
+// to the frontier committed by all the partitions of the changefeed, and its
+// committed frontier to the frontier of the partition.
+func (s *kafkaSink) readCommittedFrontier() error {
+	var spans []roachpb.Span
+	s.txn.frontier.Entries(func(sp roachpb.Span, _ hlc.Timestamp) span.OpResult {
+		spans = append(spans, sp)
+		return span.ContinueMatch
+	})
+	previous, err := span.MakeFrontier(spans...)
+	if err != nil {
+		return err
+	}
+	committed, err := span.MakeFrontier(spans...)
+	if err != nil {
+		return err
+	}
+	var forwardErr error
+	s.txn.frontier.Entries(func(sp roachpb.Span, ts hlc.Timestamp) span.OpResult {
+		if _, forwardErr = committed.Forward(sp, ts); forwardErr != nil {
+			return span.StopMatch
+		}
+		return span.ContinueMatch
+	})
+	if forwardErr != nil {
+		return forwardErr
+	}
+
+	var records []*sarama.ConsumerMessage
+	if s.knobs.OverrideReadCommitted != nil {
+		records, err = s.knobs.OverrideReadCommitted(kafkaTxnFrontierTopic)
+	} else {
+		records, err = s.readCommitted(kafkaTxnFrontierTopic)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "reading the committed frontier of kafka transactional ID %s",
+			s.kafkaCfg.Producer.Transaction.ID)
+	}
+	// The frontier of each partition only moves forward, and rows are only
+	// committed by the partition which watches their span, so the records of
+	// all the partitions are merged.
+	prefix := kafkaTransactionalID(s.txn.jobID, "")
+	for _, r := range records {
+		if !strings.HasPrefix(string(r.Key), prefix) || len(r.Value) == 0 {
+			continue
+		}
+		var resolved jobspb.ResolvedSpans
+		if err := protoutil.Unmarshal(r.Value, &resolved); err != nil {
+			return errors.Wrapf(err, "decoding the committed frontier of kafka transactional ID %s", r.Key)
+		}
+		for _, rs := range resolved.ResolvedSpans {
+			if _, err := previous.Forward(rs.Span, rs.Timestamp); err != nil {
+				return err
+			}
+		}
+	}
+	s.txn.previous, s.txn.committed = previous, committed
+	return nil
+}
+
+// readCommitted returns the committed records of partition 0 of the topic. To
+// know where they end, it first commits an empty probe record to the
+// partition; the consumer reads past it once the transactions which were open
+// before it are complete.
+func (s *kafkaSink) readCommitted(topic string) ([]*sarama.ConsumerMessage, error) {
+	if err := s.producer.BeginTxn(); err != nil {
+		return nil, err
+	}
+	probe := &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(s.kafkaCfg.Producer.Transaction.ID + "-probe"),
+		Value: sarama.ByteEncoder{},
+	}
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case s.producer.Input() <- probe:
+	}
+	var probeOffset int64
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case m := <-s.producer.Successes():
+		probeOffset = m.Offset
+	case err := <-s.producer.Errors():
+		_ = s.producer.AbortTxn()
+		return nil, err.Err
+	}
+	if err := s.producer.CommitTxn(); err != nil {
+		return nil, err
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(s.client.(sarama.Client))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = consumer.Close() }()
+	partition, err := consumer.ConsumePartition(topic, 0, sarama.OffsetOldest)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = partition.Close() }()
+	var records []*sarama.ConsumerMessage
+	for {
+		select {
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		case m := <-partition.Messages():
+			if m.Offset >= probeOffset {
+				return records, nil
+			}
+			records = append(records, m)
+		case err := <-partition.Errors():
+			return nil, err
+		}
+	}
+}
+
 // Close implements the Sink interface.
 func (s *kafkaSink) Close() error {
 	if s.stopWorkerCh != nil {
@@ -320,6 +639,12 @@ func (s *kafkaSink) Close() error {
 		// down or beginning to retry regardless
 		_ = s.producer.Close()
 	}
+	s.mu.Lock()
+	s.mu.txnAlloc.Release(s.ctx)
+	s.mu.Unlock()
+	if s.txn.holdsRows() {
+		s.txn.drop(s.ctx)
+	}
 	// s.client is only nil in tests.
 	if s.client != nil {
 		return s.client.Close()
@@ -330,7 +655,8 @@ func (s *kafkaSink) Close() error {
 type messageMetadata struct {
 	alloc         kvevent.Alloc
 	updateMetrics recordOneMessageCallback
-	mvcc          hlc.Timestamp
+	topic         TopicDescriptor
+	updated, mvcc hlc.Timestamp
 }
 
 // EmitRow implements the Sink interface.
@@ -340,6 +666,20 @@ func (s *kafkaSink) EmitRow(
 	key, value []byte,
 	updated, mvcc hlc.Timestamp,
 	alloc kvevent.Alloc,
+) error {
+	if s.txn.holdsRows() {
+		return errors.AssertionFailedf("the rows of kafka transactional ID %s are held by its partition",
+			s.kafkaCfg.Producer.Transaction.ID)
+	}
+	return s.emitRow(ctx, topicDescr, key, value, updated, mvcc, alloc)
+}
+
+func (s *kafkaSink) emitRow(
+	ctx context.Context,
+	topicDescr TopicDescriptor,
+	key, value []byte,
+	updated, mvcc hlc.Timestamp,
+	alloc kvevent.Alloc,
 ) error {
 	topic, err := s.topics.Name(topicDescr)
 	if err != nil {
@@ -347,10 +687,16 @@ func (s *kafkaSink) EmitRow(
 	}
 
 	msg := &sarama.ProducerMessage{
-		Topic:    topic,
-		Key:      sarama.ByteEncoder(key),
-		Value:    sarama.ByteEncoder(value),
-		Metadata: messageMetadata{alloc: alloc, mvcc: mvcc, updateMetrics: s.metrics.recordOneMessage()},
+		Topic: topic,
+		Key:   sarama.ByteEncoder(key),
+		Value: sarama.ByteEncoder(value),
+		Metadata: messageMetadata{
+			alloc:         alloc,
+			topic:         topicDescr,
+			updated:       updated,
+			mvcc:          mvcc,
+			updateMetrics: s.metrics.recordOneMessage(),
+		},
 	}
 	s.stats.startMessage(int64(msg.Key.Length() + msg.Value.Length()))
 	return s.emitMessage(ctx, msg)
@@ -377,7 +723,7 @@ func (s *kafkaSink) EmitResolvedTimestamp(
 		s.lastMetadataRefresh = timeutil.Now()
 	}
 
-	return s.topics.Each(func(topic string) error {
+	if err := s.topics.Each(func(topic string) error {
 		payload, err := encoder.EncodeResolvedTimestamp(ctx, topic, resolved)
 		if err != nil {
 			return err
@@ -404,13 +750,84 @@ func (s *kafkaSink) EmitResolvedTimestamp(
 			}
 		}
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+
+	// Resolved timestamps are not followed by a Flush, so commit them right away
+	// for read_committed consumers to see them.
+	if s.isTransactional() {
+		return s.flush(ctx)
+	}
+	return nil
 }
 
 // Flush implements the Sink interface.
+//
+// If the sink is transactional, Flush also commits the Kafka transaction
+// containing the messages emitted since the previous Flush, or aborts it if any
+// of them failed. If the partition of the sink holds rows, the rows which its
+// frontier reached are produced and committed first, along with the frontier;
+// see kafkaTxnPartition.
 func (s *kafkaSink) Flush(ctx context.Context) error {
 	defer s.metrics.recordFlushRequestCallback()()
+	return s.flush(ctx)
+}
 
+func (s *kafkaSink) flush(ctx context.Context) error {
+	var resolved jobspb.ResolvedSpans
+	if s.txn.holdsRows() {
+		s.txn.frontier.Entries(func(sp roachpb.Span, ts hlc.Timestamp) span.OpResult {
+			resolved.ResolvedSpans = append(resolved.ResolvedSpans, jobspb.ResolvedSpan{Span: sp, Timestamp: ts})
+			return span.ContinueMatch
+		})
+		if err := s.txn.release(func(r heldKafkaRow) error {
+			return s.emitRow(ctx, r.topic, r.key, r.value, r.updated, r.mvcc, r.alloc)
+		}); err != nil {
+			s.abortTxn(ctx)
+			return err
+		}
+		// The frontier is only committed along with rows: without them, no row
+		// emitted again up to it after a restart was committed.
+		if s.inTxn() {
+			if err := s.emitFrontier(ctx, &resolved); err != nil {
+				s.abortTxn(ctx)
+				return err
+			}
+		}
+	}
+
+	if err := s.waitForInflight(ctx); err != nil {
+		s.abortTxn(ctx)
+		return err
+	}
+	if s.isTransactional() && s.inTxn() {
+		if err := s.producer.CommitTxn(); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	s.mu.txnAlloc.Release(ctx)
+	undeliverable := s.mu.undeliverable
+	s.mu.undeliverable = nil
+	s.mu.Unlock()
+
+	if s.txn.holdsRows() {
+		for _, rs := range resolved.ResolvedSpans {
+			if _, err := s.txn.committed.Forward(rs.Span, rs.Timestamp); err != nil {
+				return err
+			}
+		}
+	}
+	if len(undeliverable) > 0 {
+		return &undeliverableRowsError{rows: undeliverable}
+	}
+	return nil
+}
+
+// waitForInflight waits for all inflight messages to be acknowledged and
+// returns the first error encountered since the last time it was called.
+func (s *kafkaSink) waitForInflight(ctx context.Context) error {
 	flushCh := make(chan struct{}, 1)
 
 	s.mu.Lock()
@@ -457,7 +874,50 @@ func (s *kafkaSink) startInflightMessage(ctx context.Context) error {
 	return nil
 }
 
+// emitFrontier emits the record of the frontier of the partition of the sink
+// to the open transaction.
+func (s *kafkaSink) emitFrontier(ctx context.Context, resolved *jobspb.ResolvedSpans) error {
+	value, err := protoutil.Marshal(resolved)
+	if err != nil {
+		return err
+	}
+	return s.emitMessage(ctx, &sarama.ProducerMessage{
+		Topic: kafkaTxnFrontierTopic,
+		Key:   sarama.StringEncoder(s.kafkaCfg.Producer.Transaction.ID),
+		Value: sarama.ByteEncoder(value),
+	})
+}
+
+// abortTxn aborts the open transaction of the sink, if any, and releases the
+// memory of its rows.
+func (s *kafkaSink) abortTxn(ctx context.Context) {
+	if s.isTransactional() && ctx.Err() == nil && s.inTxn() {
+		if err := s.producer.AbortTxn(); err != nil {
+			log.Warningf(ctx, "failed to abort kafka transaction: %v", err)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.txnAlloc.Release(ctx)
+}
+
+func (s *kafkaSink) isTransactional() bool {
+	return s.kafkaCfg.Producer.Transaction.ID != ""
+}
+
+func (s *kafkaSink) inTxn() bool {
+	return s.producer.TxnStatus()&sarama.ProducerTxnFlagInTransaction != 0
+}
+
 func (s *kafkaSink) emitMessage(ctx context.Context, msg *sarama.ProducerMessage) error {
+	// Transactions are begun lazily so that flushes with nothing to commit
+	// don't round-trip to the transaction coordinator.
+	if s.isTransactional() && !s.inTxn() {
+		if err := s.producer.BeginTxn(); err != nil {
+			return err
+		}
+	}
+
 	if err := s.startInflightMessage(ctx); err != nil {
 		return err
 	}
@@ -559,6 +1019,8 @@ func (s *kafkaSink) workerLoop() {
 		isValidMessage := ackMsg != nil && ackMsg.Key != nil && ackMsg.Value != nil
 		if isRetrying() && isValidMessage {
 			retryBuf = append(retryBuf, ackMsg)
+		} else if s.isUndeliverable(ackMsg, ackError) {
+			s.collectUndeliverableMessage(ackMsg, ackError)
 		} else {
 			s.finishProducerMessage(ackMsg, ackError)
 		}
@@ -595,13 +1057,70 @@ func (s *kafkaSink) finishProducerMessage(ackMsg *sarama.ProducerMessage, ackErr
 			s.stats.finishMessage(int64(sz))
 			m.updateMetrics(m.mvcc, sz, sinkDoesNotCompress)
 		}
-		m.alloc.Release(s.ctx)
+		if s.isTransactional() {
+			// The row may only be emitted again once its transaction is
+			// committed or aborted.
+			s.mu.txnAlloc.Merge(&m.alloc)
+		} else {
+			m.alloc.Release(s.ctx)
+		}
 	}
 	if s.mu.flushErr == nil && ackError != nil {
 		s.mu.flushErr = ackError
 	}
 }
 
+// isUndeliverable returns true if the message is a row which should be
+// collected to be written to the dead letter queue because Kafka rejected it
+// for good.
+//
+// Rows which fail the internal retry are not collected, because some of the
+// messages retried alongside them may have been delivered.
+func (s *kafkaSink) isUndeliverable(ackMsg *sarama.ProducerMessage, ackError error) bool {
+	if !s.collectUndeliverable || ackError == nil || ackMsg == nil {
+		return false
+	}
+	if _, ok := ackMsg.Metadata.(messageMetadata); !ok {
+		return false
+	}
+	var kError sarama.KError
+	if !errors.As(ackError, &kError) {
+		return false
+	}
+	switch kError {
+	case sarama.ErrInvalidMessage, sarama.ErrInvalidMessageSize, sarama.ErrMessageSizeTooLarge,
+		sarama.ErrMessageSetSizeTooLarge, sarama.ErrInvalidRecord:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *kafkaSink) collectUndeliverableMessage(ackMsg *sarama.ProducerMessage, ackError error) {
+	s.mu.AssertHeld()
+	m := ackMsg.Metadata.(messageMetadata)
+	s.mu.undeliverable = append(s.mu.undeliverable, deadLetter{
+		topic:   m.topic,
+		key:     encoderBytes(ackMsg.Key),
+		value:   encoderBytes(ackMsg.Value),
+		updated: m.updated,
+		mvcc:    m.mvcc,
+		err:     ackError,
+		// The allocation is released once the row is written to the dead
+		// letter queue.
+		alloc: m.alloc,
+	})
+}
+
+func encoderBytes(e sarama.Encoder) []byte {
+	if e == nil {
+		return nil
+	}
+	// The messages of the sink are ByteEncoders, which never fail to encode.
+	b, _ := e.Encode()
+	return b
+}
+
 func (s *kafkaSink) handleBufferedRetries(msgs []*sarama.ProducerMessage, retryErr error) error {
 	lastSendErr := retryErr
 	activeConfig := s.kafkaCfg
@@ -719,7 +1238,7 @@ func (p *changefeedPartitioner) RequiresConsistency() bool { return true }
 func (p *changefeedPartitioner) Partition(
 	message *sarama.ProducerMessage, numPartitions int32,
 ) (int32, error) {
-	if message.Key == nil {
+	if message.Key == nil || message.Topic == kafkaTxnFrontierTopic {
 		return message.Partition, nil
 	}
 	return p.hash.Partition(message, numPartitions)
@@ -819,6 +1338,18 @@ func (c *saramaConfig) Apply(kafka *sarama.Config) error {
 		kafka.Producer.RequiredAcks = parsedAcks
 	}
 	kafka.Producer.Compression = sarama.CompressionCodec(c.Compression)
+	if c.Transactional {
+		// These are the requirements sarama places on idempotent producers. The
+		// transactional ID is specific to each sink and is set by makeKafkaSink.
+		kafka.Producer.Idempotent = true
+		kafka.Producer.RequiredAcks = sarama.WaitForAll
+		kafka.Net.MaxOpenRequests = 1
+		if kafka.Producer.Retry.Max < 1 {
+			kafka.Producer.Retry.Max = 1
+		}
+		// Sinks read the frontier committed to kafkaTxnFrontierTopic.
+		kafka.Consumer.IsolationLevel = sarama.ReadCommitted
+	}
 	return nil
 }
 
@@ -1035,11 +1566,24 @@ func buildKafkaConfig(
 	return config, nil
 }
 
+// kafkaTransactionalID returns the Kafka transactional ID used by the sink of
+// the given partition of a changefeed when the Transactional option is set.
+func kafkaTransactionalID(jobID jobspb.JobID, partitionID string) string {
+	return fmt.Sprintf("crdb-changefeed-%d-%s", jobID, partitionID)
+}
+
+// makeKafkaSink returns a Kafka sink. If the Transactional option is set and
+// txn is not nil, the sink commits the messages of the partition in Kafka
+// transactions; otherwise, as for the canary sinks dialed to validate the
+// changefeed, the producer is idempotent but not transactional.
 func makeKafkaSink(
 	ctx context.Context,
 	u sinkURL,
 	targets changefeedbase.Targets,
 	jsonStr changefeedbase.SinkSpecificJSONConfig,
+	jobID jobspb.JobID,
+	txn *kafkaTxnPartition,
+	collectUndeliverable bool,
 	settings *cluster.Settings,
 	mb metricsRecorderBuilder,
 ) (Sink, error) {
@@ -1053,6 +1597,23 @@ func makeKafkaSink(
 	if err != nil {
 		return nil, err
 	}
+	if config.Producer.Idempotent {
+		if collectUndeliverable {
+			// Kafka aborts the whole transaction when one of its messages fails.
+			return nil, errors.Errorf(`%s is not supported with transactional kafka sinks`,
+				changefeedbase.OptDeadLetterQueue)
+		}
+		if txn != nil {
+			if jobID == jobspb.InvalidJobID {
+				// Without a job, there is nothing to make the transactional ID of
+				// the changefeed unique.
+				return nil, errors.New(`Transactional kafka sinks are not supported by core changefeeds`)
+			}
+			config.Producer.Transaction.ID = kafkaTransactionalID(jobID, txn.id)
+			txn.transactional = true
+			txn.jobID = jobID
+		}
+	}
 
 	topics, err := MakeTopicNamer(
 		targets,
@@ -1062,7 +1623,10 @@ func makeKafkaSink(
 		return nil, err
 	}
 
-	internalRetryEnabled := settings != nil && changefeedbase.BatchReductionRetryEnabled.Get(&settings.SV)
+	// Internal retries resend messages with a separate producer, outside of the
+	// transaction of the sink's producer.
+	internalRetryEnabled := settings != nil && changefeedbase.BatchReductionRetryEnabled.Get(&settings.SV) &&
+		config.Producer.Transaction.ID == ""
 
 	sink := &kafkaSink{
 		ctx:                  ctx,
@@ -1071,6 +1635,8 @@ func makeKafkaSink(
 		metrics:              mb(requiresResourceAccounting),
 		topics:               topics,
 		disableInternalRetry: !internalRetryEnabled,
+		collectUndeliverable: collectUndeliverable,
+		txn:                  txn,
 	}
 
 	if unknownParams := u.remainingQueryParams(); len(unknownParams) > 0 {
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -32,7 +33,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
@@ -47,6 +50,16 @@ type asyncProducerMock struct {
 	mu          struct {
 		syncutil.Mutex
 		outstanding []*sarama.ProducerMessage
+		// txnStatus, committed and aborted track the transactions of a
+		// transactional sink.
+		txnStatus sarama.ProducerTxnStatusFlag
+		committed int
+		aborted   int
+		// txnMessages are the messages acknowledged in the open transaction
+		// which were passed to record, and committedMessages those of the
+		// committed transactions.
+		txnMessages       []*sarama.ProducerMessage
+		committedMessages []*sarama.ProducerMessage
 	}
 }
 
@@ -72,11 +85,44 @@ func (p *asyncProducerMock) Close() error {
 	close(p.errorsCh)
 	return nil
 }
-func (p *asyncProducerMock) IsTransactional() bool                   { panic(`unimplemented`) }
-func (p *asyncProducerMock) BeginTxn() error                         { panic(`unimplemented`) }
-func (p *asyncProducerMock) CommitTxn() error                        { panic(`unimplemented`) }
-func (p *asyncProducerMock) AbortTxn() error                         { panic(`unimplemented`) }
-func (p *asyncProducerMock) TxnStatus() sarama.ProducerTxnStatusFlag { panic(`unimplemented`) }
+func (p *asyncProducerMock) IsTransactional() bool { panic(`unimplemented`) }
+func (p *asyncProducerMock) BeginTxn() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.mu.txnStatus&sarama.ProducerTxnFlagInTransaction != 0 {
+		return errors.New("transaction already in progress")
+	}
+	p.mu.txnStatus = sarama.ProducerTxnFlagInTransaction
+	return nil
+}
+func (p *asyncProducerMock) CommitTxn() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.mu.txnStatus&sarama.ProducerTxnFlagInTransaction == 0 {
+		return errors.New("no transaction in progress")
+	}
+	p.mu.txnStatus = sarama.ProducerTxnFlagReady
+	p.mu.committed++
+	p.mu.committedMessages = append(p.mu.committedMessages, p.mu.txnMessages...)
+	p.mu.txnMessages = nil
+	return nil
+}
+func (p *asyncProducerMock) AbortTxn() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.mu.txnStatus&sarama.ProducerTxnFlagInTransaction == 0 {
+		return errors.New("no transaction in progress")
+	}
+	p.mu.txnStatus = sarama.ProducerTxnFlagReady
+	p.mu.aborted++
+	p.mu.txnMessages = nil
+	return nil
+}
+func (p *asyncProducerMock) TxnStatus() sarama.ProducerTxnStatusFlag {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.mu.txnStatus
+}
 func (p *asyncProducerMock) AddOffsetsToTxn(
 	_ map[string][]*sarama.PartitionOffsetMetadata, _ string,
 ) error {
@@ -211,6 +257,46 @@ func (p *asyncProducerMock) outstanding() int {
 	return len(p.mu.outstanding)
 }
 
+// txns returns the number of committed and aborted transactions.
+func (p *asyncProducerMock) txns() (committed, aborted int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.mu.committed, p.mu.aborted
+}
+
+// record records an acknowledged message as part of the open transaction.
+func (p *asyncProducerMock) record(m *sarama.ProducerMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mu.txnMessages = append(p.mu.txnMessages, m)
+}
+
+// readCommitted returns the recorded messages of the committed transactions
+// which were produced to the topic, as a consumer reading with
+// isolation.level=read_committed would see them.
+func (p *asyncProducerMock) readCommitted(topic string) ([]*sarama.ConsumerMessage, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var records []*sarama.ConsumerMessage
+	for _, m := range p.mu.committedMessages {
+		if m.Topic != topic {
+			continue
+		}
+		key, err := m.Key.Encode()
+		if err != nil {
+			return nil, err
+		}
+		value, err := m.Value.Encode()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &sarama.ConsumerMessage{
+			Topic: m.Topic, Partition: m.Partition, Offset: int64(len(records)), Key: key, Value: value,
+		})
+	}
+	return records, nil
+}
+
 func topic(name string) *tableDescriptorTopic {
 	tableDesc := tabledesc.NewBuilder(&descpb.TableDescriptor{Name: name}).BuildImmutableTable()
 	spec := changefeedbase.Target{
@@ -362,6 +448,285 @@ func TestKafkaSink(t *testing.T) {
 	require.EqualValues(t, 0, pool.used())
 }
 
+func TestKafkaSinkTransactional(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	p := newAsyncProducerMock(1)
+	sink, cleanup := makeTestKafkaSink(
+		t, noTopicPrefix, defaultTopicName, p, "t")
+	defer cleanup()
+	sink.kafkaCfg.Producer.Transaction.ID = kafkaTransactionalID(1, "frontier")
+	sink.client = &fakeKafkaClient{}
+
+	requireTxns := func(committed, aborted int) {
+		t.Helper()
+		c, a := p.txns()
+		require.Equal(t, committed, c, "committed")
+		require.Equal(t, aborted, a, "aborted")
+	}
+
+	// Nothing to commit.
+	require.NoError(t, sink.Flush(ctx))
+	requireTxns(0, 0)
+
+	// Rows emitted between flushes are committed in one transaction.
+	var pool testAllocPool
+	for _, k := range []string{`1`, `2`} {
+		require.NoError(t, sink.EmitRow(
+			ctx, topic(`t`), []byte(k), nil, zeroTS, zeroTS, pool.alloc()))
+		m := <-p.inputCh
+		go func() { p.successesCh <- m }()
+	}
+	require.NoError(t, sink.Flush(ctx))
+	requireTxns(1, 0)
+
+	// A failed message aborts the transaction.
+	require.NoError(t, sink.EmitRow(
+		ctx, topic(`t`), []byte(`3`), nil, zeroTS, zeroTS, pool.alloc()))
+	m3 := <-p.inputCh
+	go func() { p.errorsCh <- &sarama.ProducerError{Msg: m3, Err: errors.New("m3")} }()
+	require.Regexp(t, "m3", sink.Flush(ctx))
+	requireTxns(1, 1)
+
+	// Resolved timestamps are committed without a flush.
+	e, err := makeJSONEncoder(jsonEncoderOptions{})
+	require.NoError(t, err)
+	go func() { p.successesCh <- <-p.inputCh }()
+	require.NoError(t, sink.EmitResolvedTimestamp(ctx, e, hlc.Timestamp{WallTime: 1}))
+	requireTxns(2, 1)
+
+	require.EqualValues(t, 0, pool.used())
+}
+
+func TestKafkaSinkTransactionalFrontier(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	sp := func(start, end string) roachpb.Span {
+		return roachpb.Span{Key: roachpb.Key(start), EndKey: roachpb.Key(end)}
+	}
+
+	// makeSink returns a sink whose partition watches [a, c), reading the
+	// frontier committed by previous incarnations of the changefeed from
+	// committed.
+	makeSink := func(
+		p *asyncProducerMock, committed func(topic string) ([]*sarama.ConsumerMessage, error),
+	) (*kafkaSink, *span.Frontier, chan string, func()) {
+		topics, err := MakeTopicNamer(makeChangefeedTargets("t"))
+		require.NoError(t, err)
+		frontier, err := span.MakeFrontier(sp("a", "c"))
+		require.NoError(t, err)
+		s := &kafkaSink{
+			ctx:      ctx,
+			topics:   topics,
+			kafkaCfg: &sarama.Config{},
+			metrics:  (*sliMetrics)(nil),
+			txn: &kafkaTxnPartition{
+				id:            "1",
+				frontier:      frontier,
+				transactional: true,
+				jobID:         1,
+			},
+			knobs: kafkaSinkKnobs{
+				OverrideAsyncProducerFromClient: func(client kafkaClient) (sarama.AsyncProducer, error) {
+					return p, nil
+				},
+				OverrideClientInit: func(config *sarama.Config) (kafkaClient, error) {
+					return nil, nil
+				},
+				OverrideReadCommitted: committed,
+			},
+		}
+		s.kafkaCfg.Producer.Transaction.ID = kafkaTransactionalID(1, "1")
+		require.NoError(t, s.Dial())
+
+		// Acknowledge the messages of the sink, sending the keys of the rows to
+		// produced and recording the frontier.
+		produced := make(chan string, 10)
+		go func() {
+			for m := range p.inputCh {
+				if m.Topic == kafkaTxnFrontierTopic {
+					p.record(m)
+					p.successesCh <- m
+					continue
+				}
+				produced <- string(m.Key.(sarama.ByteEncoder))
+				if string(m.Key.(sarama.ByteEncoder)) == `fail` {
+					p.errorsCh <- &sarama.ProducerError{Msg: m, Err: errors.New("fail")}
+				} else {
+					p.successesCh <- m
+				}
+			}
+		}()
+		return s, frontier, produced, func() { require.NoError(t, s.Close()) }
+	}
+	requireProduced := func(produced chan string, expected ...string) {
+		t.Helper()
+		var keys []string
+		for len(produced) > 0 {
+			keys = append(keys, <-produced)
+		}
+		require.Equal(t, expected, keys)
+	}
+	requireFrontier := func(f *span.Frontier, expected ...jobspb.ResolvedSpan) {
+		t.Helper()
+		var entries []jobspb.ResolvedSpan
+		f.Entries(func(sp roachpb.Span, ts hlc.Timestamp) span.OpResult {
+			entries = append(entries, jobspb.ResolvedSpan{Span: sp, Timestamp: ts})
+			return span.ContinueMatch
+		})
+		require.Equal(t, expected, entries)
+	}
+	forward := func(f *span.Frontier, sp roachpb.Span, ts hlc.Timestamp) {
+		_, err := f.Forward(sp, ts)
+		require.NoError(t, err)
+	}
+
+	var pool testAllocPool
+	hold := func(s *kafkaSink, kvKey, key string, updated hlc.Timestamp) {
+		s.txn.hold(ctx, heldKafkaRow{
+			kvKey:   roachpb.Key(kvKey),
+			topic:   topic(`t`),
+			key:     []byte(key),
+			updated: updated,
+			mvcc:    updated,
+			alloc:   pool.alloc(),
+		})
+	}
+
+	noneCommitted := func(string) ([]*sarama.ConsumerMessage, error) { return nil, nil }
+	p1 := newAsyncProducerMock(10)
+	sink, frontier, produced, cleanup := makeSink(p1, noneCommitted)
+	require.Regexp(t, "held by its partition",
+		sink.EmitRow(ctx, topic(`t`), []byte(`a2`), nil, ts(2), ts(2), zeroAlloc))
+
+	// Rows are only produced once the frontier reaches their key, and their
+	// memory is held until they're committed.
+	hold(sink, "a", `a2`, ts(2))
+	hold(sink, "b", `b2`, ts(2))
+	hold(sink, "a", `a3`, ts(3))
+	require.NoError(t, sink.Flush(ctx))
+	requireProduced(produced)
+	require.EqualValues(t, 3, pool.used())
+	forward(frontier, sp("a", "b"), ts(2))
+	require.NoError(t, sink.Flush(ctx))
+	requireProduced(produced, `a2`)
+	c, a := p1.txns()
+	require.Equal(t, []int{1, 0}, []int{c, a})
+	require.EqualValues(t, 2, pool.used())
+	require.Equal(t, 2, sink.txn.heldRows())
+	require.EqualValues(t, 1, sink.txn.releasedRows())
+
+	// The frontier is committed along with the rows, and the spans are only
+	// checkpointed up to it.
+	records, err := p1.readCommitted(kafkaTxnFrontierTopic)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, kafkaTransactionalID(1, "1"), string(records[0].Key))
+	var resolved jobspb.ResolvedSpans
+	require.NoError(t, protoutil.Unmarshal(records[0].Value, &resolved))
+	require.Equal(t, []jobspb.ResolvedSpan{
+		{Span: sp("a", "b"), Timestamp: ts(2)},
+		{Span: sp("b", "c")},
+	}, resolved.ResolvedSpans)
+	forward(frontier, sp("b", "c"), ts(2))
+	requireFrontier(sink.txn.checkpointable(frontier),
+		jobspb.ResolvedSpan{Span: sp("a", "b"), Timestamp: ts(2)},
+		jobspb.ResolvedSpan{Span: sp("b", "c")},
+	)
+	cleanup()
+	require.EqualValues(t, 0, pool.used())
+
+	// After a restart, the rows committed by any partition of the changefeed
+	// are dropped.
+	p2 := newAsyncProducerMock(10)
+	sink, frontier, produced, cleanup = makeSink(p2, p1.readCommitted)
+	defer cleanup()
+	hold(sink, "a", `a2`, ts(2))
+	hold(sink, "b", `b2`, ts(2))
+	hold(sink, "a", `a3`, ts(3))
+	require.EqualValues(t, 2, pool.used())
+	forward(frontier, sp("a", "c"), ts(3))
+	require.NoError(t, sink.Flush(ctx))
+	requireProduced(produced, `b2`, `a3`)
+	requireFrontier(sink.txn.checkpointable(frontier),
+		jobspb.ResolvedSpan{Span: sp("a", "c"), Timestamp: ts(3)},
+	)
+
+	// A failed message aborts the transaction, frontier included.
+	hold(sink, "b", `fail`, ts(4))
+	forward(frontier, sp("a", "c"), ts(4))
+	require.Regexp(t, "fail", sink.Flush(ctx))
+	requireProduced(produced, `fail`)
+	c, a = p2.txns()
+	require.Equal(t, []int{1, 1}, []int{c, a})
+	requireFrontier(sink.txn.checkpointable(frontier),
+		jobspb.ResolvedSpan{Span: sp("a", "c"), Timestamp: ts(3)},
+	)
+	require.EqualValues(t, 0, pool.used())
+}
+
+func TestKafkaSinkCollectsUndeliverableRows(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	p := newAsyncProducerMock(2)
+	sink, cleanup := makeTestKafkaSink(
+		t, noTopicPrefix, defaultTopicName, p, "t")
+	defer cleanup()
+	sink.collectUndeliverable = true
+	// Messages which are too large would be retried with smaller batches first.
+	sink.disableInternalRetry = true
+
+	// A row which Kafka rejects for good is handed back by Flush, and the
+	// others are delivered.
+	var pool testAllocPool
+	ts := hlc.Timestamp{WallTime: 1}
+	require.NoError(t, sink.EmitRow(
+		ctx, topic(`t`), []byte(`1`), []byte(`v1`), ts, ts, pool.alloc()))
+	require.NoError(t, sink.EmitRow(
+		ctx, topic(`t`), []byte(`2`), []byte(`v2`), ts, ts, pool.alloc()))
+	m1, m2 := <-p.inputCh, <-p.inputCh
+	go func() {
+		p.successesCh <- m1
+		p.errorsCh <- &sarama.ProducerError{Msg: m2, Err: sarama.ErrMessageSizeTooLarge}
+	}()
+	err := sink.Flush(ctx)
+	var undeliverable *undeliverableRowsError
+	require.True(t, errors.As(err, &undeliverable), "%v", err)
+	require.Len(t, undeliverable.rows, 1)
+	r := undeliverable.rows[0]
+	name, _ := r.topic.GetNameComponents()
+	require.EqualValues(t, `t`, name)
+	require.Equal(t, []byte(`2`), r.key)
+	require.Equal(t, []byte(`v2`), r.value)
+	require.Equal(t, ts, r.updated)
+	require.True(t, errors.Is(r.err, sarama.ErrMessageSizeTooLarge))
+	// The allocation of the row is held until it is written to the dead letter
+	// queue.
+	require.EqualValues(t, 1, pool.used())
+	r.alloc.Release(ctx)
+	require.EqualValues(t, 0, pool.used())
+
+	// The rows are handed back once.
+	require.NoError(t, sink.Flush(ctx))
+
+	// Other errors still fail the sink.
+	require.NoError(t, sink.EmitRow(
+		ctx, topic(`t`), []byte(`3`), []byte(`v3`), ts, ts, pool.alloc()))
+	m3 := <-p.inputCh
+	go func() { p.errorsCh <- &sarama.ProducerError{Msg: m3, Err: errors.New("m3")} }()
+	err = sink.Flush(ctx)
+	require.Regexp(t, "m3", err)
+	require.False(t, errors.As(err, &undeliverable))
+	require.EqualValues(t, 0, pool.used())
+}
+
 func TestKafkaSinkEscaping(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -709,6 +1074,30 @@ func TestSaramaConfigOptionParsing(t *testing.T) {
 		require.Error(t, err)
 
 	})
+	t.Run("apply configures transactional producer", func(t *testing.T) {
+		opts := changefeedbase.SinkSpecificJSONConfig(`{"Transactional": true}`)
+
+		cfg, err := getSaramaConfig(opts)
+		require.NoError(t, err)
+		require.NoError(t, cfg.Validate())
+
+		saramaCfg := sarama.NewConfig()
+		err = cfg.Apply(saramaCfg)
+		require.NoError(t, err)
+		require.True(t, saramaCfg.Producer.Idempotent)
+		require.Equal(t, sarama.WaitForAll, saramaCfg.Producer.RequiredAcks)
+		require.Equal(t, 1, saramaCfg.Net.MaxOpenRequests)
+
+		saramaCfg.Producer.Transaction.ID = kafkaTransactionalID(1, "frontier")
+		require.NoError(t, saramaCfg.Validate())
+	})
+	t.Run("validate returns error for transactional producer without acks from all", func(t *testing.T) {
+		opts := changefeedbase.SinkSpecificJSONConfig(`{"Transactional": true, "RequiredAcks": "ONE"}`)
+
+		cfg, err := getSaramaConfig(opts)
+		require.NoError(t, err)
+		require.Error(t, cfg.Validate())
+	})
 	t.Run("compression options validation", func(t *testing.T) {
 		testCases := make([]string, 0, len(saramaCompressionCodecOptions)*2)
 		for option := range saramaCompressionCodecOptions {
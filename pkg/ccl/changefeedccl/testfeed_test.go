@@ -1681,6 +1681,15 @@ func (s *fakeKafkaSink) Dial() error {
 					return true
 				}
 			}
+			if m.Topic == kafkaTxnFrontierTopic {
+				// The frontier committed by transactional sinks is not part of the
+				// feed.
+				select {
+				case producer.successesCh <- m:
+				case <-s.tg.done:
+				}
+				return true
+			}
 			return false
 		}
 
@@ -1707,6 +1716,12 @@ func (s *fakeKafkaSink) Dial() error {
 		}}, nil
 	}
 
+	// Each incarnation of the sink produces to a fresh producer, so nothing was
+	// committed by the previous one.
+	kafka.knobs.OverrideReadCommitted = func(topic string) ([]*sarama.ConsumerMessage, error) {
+		return nil, nil
+	}
+
 	return kafka.Dial()
 }
 
@@ -1779,16 +1794,19 @@ func (k *kafkaFeedFactory) Feed(create string, args ...interface{}) (cdctest.Tes
 	}
 
 	var registry *cdctest.SchemaRegistry
+	var registryFormat changefeedbase.FormatType
 	for _, opt := range createStmt.Options {
 		if opt.Key == changefeedbase.OptFormat {
 			format, err := exprAsString(opt.Value)
 			if err != nil {
 				return nil, err
 			}
-			if format == string(changefeedbase.OptFormatAvro) {
+			if format == string(changefeedbase.OptFormatAvro) ||
+				format == string(changefeedbase.OptFormatProtobuf) {
 				// Must use confluent schema registry so that we register our schema
 				// in order to be able to decode kafka messages.
 				registry = cdctest.StartTestSchemaRegistry()
+				registryFormat = changefeedbase.FormatType(format)
 				registryOption := tree.KVOption{
 					Key:   changefeedbase.OptConfluentSchemaRegistry,
 					Value: tree.NewStrVal(registry.URL()),
@@ -1820,6 +1838,7 @@ func (k *kafkaFeedFactory) Feed(create string, args ...interface{}) (cdctest.Tes
 		source:         feedCh,
 		tg:             tg,
 		registry:       registry,
+		registryFormat: registryFormat,
 	}
 
 	if err := k.startFeedJob(c.jobFeed, createStmt.String(), args...); err != nil {
@@ -1840,8 +1859,10 @@ type kafkaFeed struct {
 	source chan *sarama.ProducerMessage
 	tg     *teeGroup
 
-	// Registry is set if we're emitting avro.
-	registry *cdctest.SchemaRegistry
+	// Registry is set if we're emitting avro or protobuf, as indicated by
+	// registryFormat.
+	registry       *cdctest.SchemaRegistry
+	registryFormat changefeedbase.FormatType
 }
 
 var _ cdctest.TestFeed = (*kafkaFeed)(nil)
@@ -1887,9 +1908,17 @@ func (k *kafkaFeed) Next() (*cdctest.TestFeedMessage, error) {
 			if err != nil {
 				return err
 			}
-			if k.registry == nil {
+			switch {
+			case k.registry == nil:
 				*dest = decoded
-			} else {
+			case k.registryFormat == changefeedbase.OptFormatProtobuf:
+				// Convert protobuf message to json.
+				jsonBytes, err := k.registry.ProtobufToJSON(decoded)
+				if err != nil {
+					return err
+				}
+				*dest = jsonBytes
+			default:
 				// Convert avro record to json.
 				jsonBytes, err := k.registry.AvroToJSON(decoded)
 				if err != nil {
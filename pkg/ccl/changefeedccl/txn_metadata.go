@@ -0,0 +1,104 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+)
+
+// txnEventCounter counts the events emitted for each transaction when the
+// transaction_metadata option is set. A single counter is shared by all of the
+// event consumers of a change aggregator, so the count of a transaction is the
+// number of its events emitted by that aggregator. Events of a transaction
+// whose rows are watched by other aggregators are counted by those.
+type txnEventCounter struct {
+	mu struct {
+		syncutil.Mutex
+		counts map[uuid.UUID]txnEventCount
+		// prunedAt is the frontier as of the last time transactions that can no
+		// longer emit events were forgotten.
+		prunedAt hlc.Timestamp
+	}
+}
+
+type txnEventCount struct {
+	// ts is the commit timestamp of the transaction.
+	ts    hlc.Timestamp
+	count int64
+}
+
+func newTxnEventCounter() *txnEventCounter {
+	c := &txnEventCounter{}
+	c.mu.counts = make(map[uuid.UUID]txnEventCount)
+	return c
+}
+
+// next records an event of the transaction committed at ts, and returns the
+// number of events of the transaction emitted so far, including this one.
+//
+// Events are never emitted at or below the frontier, so transactions which
+// committed at or below it are complete and are forgotten.
+func (c *txnEventCounter) next(txnID uuid.UUID, ts hlc.Timestamp, frontier hlc.Timestamp) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mu.prunedAt.Less(frontier) {
+		for id, tc := range c.mu.counts {
+			if tc.ts.LessEq(frontier) {
+				delete(c.mu.counts, id)
+			}
+		}
+		c.mu.prunedAt = frontier
+	}
+
+	tc := c.mu.counts[txnID]
+	tc.ts = ts
+	tc.count++
+	c.mu.counts[txnID] = tc
+	return tc.count
+}
+
+// txnMetadataBuilder builds the object which the transaction_metadata option
+// adds to JSON rows:
+//
+//	{"id": <the ID of the transaction>, "event_count": <see txnEventCounter>}
+//
+// The event count is only the number of events of the transaction emitted by
+// the change aggregator emitting the row, not by the whole changefeed.
+//
+// Committed values do not retain the ID of the transaction which wrote them,
+// so the transaction of rows emitted by scans and by rangefeed catch-up scans
+// is unknown. Their object is null.
+type txnMetadataBuilder struct {
+	b *json.FixedKeysObjectBuilder
+}
+
+func makeTxnMetadataBuilder() (*txnMetadataBuilder, error) {
+	b, err := json.NewFixedKeysObjectBuilder([]string{"id", "event_count"})
+	if err != nil {
+		return nil, err
+	}
+	return &txnMetadataBuilder{b: b}, nil
+}
+
+func (tb *txnMetadataBuilder) encode(evCtx eventContext) (json.JSON, error) {
+	if evCtx.txnID.Equal(uuid.Nil) {
+		return json.NullJSONValue, nil
+	}
+	if err := tb.b.Set("id", json.FromString(evCtx.txnID.String())); err != nil {
+		return nil, err
+	}
+	if err := tb.b.Set("event_count", json.FromInt64(evCtx.txnEventCount)); err != nil {
+		return nil, err
+	}
+	return tb.b.Build()
+}
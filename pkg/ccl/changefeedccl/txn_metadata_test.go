@@ -0,0 +1,34 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxnEventCounter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// The counts of a transaction are forgotten once the frontier passes it.
+	c := newTxnEventCounter()
+	txn1, txn2 := uuid.MakeV4(), uuid.MakeV4()
+	ts1, ts2 := hlc.Timestamp{WallTime: 10}, hlc.Timestamp{WallTime: 20}
+	require.Equal(t, int64(1), c.next(txn1, ts1, hlc.Timestamp{}))
+	require.Equal(t, int64(1), c.next(txn2, ts2, hlc.Timestamp{}))
+	require.Equal(t, int64(2), c.next(txn1, ts1, hlc.Timestamp{}))
+	require.Equal(t, int64(2), c.next(txn2, ts2, ts1))
+	require.Len(t, c.mu.counts, 1)
+}
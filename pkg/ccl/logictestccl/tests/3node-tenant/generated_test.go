@@ -654,6 +654,13 @@ func TestTenantLogic_distsql_tenant(
 	runLogicTest(t, "distsql_tenant")
 }
 
+func TestTenantLogic_domain(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domain")
+}
+
 func TestTenantLogic_drop_database(
 	t *testing.T,
 ) {
@@ -829,6 +836,13 @@ func TestTenantLogic_float(
 	runLogicTest(t, "float")
 }
 
+func TestTenantLogic_foreign_table(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "foreign_table")
+}
+
 func TestTenantLogic_format(
 	t *testing.T,
 ) {
@@ -850,6 +864,13 @@ func TestTenantLogic_fuzzystrmatch(
 	runLogicTest(t, "fuzzystrmatch")
 }
 
+func TestTenantLogic_geometric(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "geometric")
+}
+
 func TestTenantLogic_geospatial(
 	t *testing.T,
 ) {
@@ -990,6 +1011,13 @@ func TestTenantLogic_inflight_trace_spans(
 	runLogicTest(t, "inflight_trace_spans")
 }
 
+func TestTenantLogic_inherits(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "inherits")
+}
+
 func TestTenantLogic_inner_join(
 	t *testing.T,
 ) {
@@ -1130,6 +1158,13 @@ func TestTenantLogic_limit(
 	runLogicTest(t, "limit")
 }
 
+func TestTenantLogic_listen_notify(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "listen_notify")
+}
+
 func TestTenantLogic_lock_timeout(
 	t *testing.T,
 ) {
@@ -1165,6 +1200,13 @@ func TestTenantLogic_materialized_view(
 	runLogicTest(t, "materialized_view")
 }
 
+func TestTenantLogic_merge(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "merge")
+}
+
 func TestTenantLogic_merge_join(
 	t *testing.T,
 ) {
@@ -1410,6 +1452,13 @@ func TestTenantLogic_propagate_input_ordering(
 	runLogicTest(t, "propagate_input_ordering")
 }
 
+func TestTenantLogic_publication(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "publication")
+}
+
 func TestTenantLogic_reassign_owned_by(
 	t *testing.T,
 ) {
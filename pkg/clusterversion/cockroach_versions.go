@@ -553,6 +553,20 @@ const (
 	// DeleteSized operations.
 	V23_2_UseSizedPebblePointTombstones
 
+	// V23_2_NotificationsTable is the version where the system.notifications
+	// table, which is used to deliver LISTEN/NOTIFY notifications, is created.
+	V23_2_NotificationsTable
+
+	// V23_2_ReplicationSlotsTable is the version where the
+	// system.replication_slots table, which stores the logical replication
+	// slots, is created.
+	V23_2_ReplicationSlotsTable
+
+	// V23_2_RangefeedWriteValueTxnID is the version where the writes of
+	// transactions committed in one phase are attributed to the transaction in
+	// the logical op log, and thus carry its ID in rangefeed values.
+	V23_2_RangefeedWriteValueTxnID
+
 	// *************************************************
 	// Step (1) Add new versions here.
 	// Do not add new versions to a patch release.
@@ -962,6 +976,18 @@ var rawVersionsSingleton = keyedVersions{
 		Key:     V23_2_UseSizedPebblePointTombstones,
 		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 14},
 	},
+	{
+		Key:     V23_2_NotificationsTable,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 16},
+	},
+	{
+		Key:     V23_2_ReplicationSlotsTable,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 18},
+	},
+	{
+		Key:     V23_2_RangefeedWriteValueTxnID,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 20},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
@@ -1106,6 +1106,34 @@ var specs = []stmtSpec{
 		name:   "like_table_option_list",
 		inline: []string{"like_table_option"},
 	},
+	{
+		name: "merge_stmt",
+		inline: []string{
+			"opt_with_clause",
+			"with_clause",
+			"cte_list",
+			"table_expr_opt_alias_idx",
+			"table_name_opt_idx",
+			"opt_only",
+			"opt_descendant",
+			"merge_when_list",
+			"merge_when_clause",
+			"opt_merge_when_cond",
+			"merge_matched_action",
+			"merge_not_matched_action",
+			"insert_column_list",
+			"insert_column_item",
+			"expr_list",
+			"returning_clause",
+		},
+		replace: map[string]string{
+			"relation_expr": "table_name",
+		},
+		relink: map[string]string{
+			"table_name": "relation_expr",
+		},
+		nosplit: true,
+	},
 	{
 		name: "on_conflict",
 		inline: []string{"name_list", "set_clause_list", "insert_column_list",
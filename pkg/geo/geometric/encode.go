@@ -0,0 +1,273 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The functions in this file encode and decode the geometric types using the
+// binary format of Postgres, in which every coordinate is a big-endian
+// float64. The same format is used for the value encoding of the types.
+
+func encodeFloat(appendTo []byte, f float64) []byte {
+	return encoding.EncodeUint64Ascending(appendTo, math.Float64bits(f))
+}
+
+func decodeFloat(b []byte) ([]byte, float64, error) {
+	b, u, err := encoding.DecodeUint64Ascending(b)
+	return b, math.Float64frombits(u), err
+}
+
+func encodePoint(appendTo []byte, p Point) []byte {
+	appendTo = encodeFloat(appendTo, p.X)
+	return encodeFloat(appendTo, p.Y)
+}
+
+func decodePoint(b []byte) ([]byte, Point, error) {
+	var p Point
+	var err error
+	if b, p.X, err = decodeFloat(b); err != nil {
+		return nil, Point{}, err
+	}
+	if b, p.Y, err = decodeFloat(b); err != nil {
+		return nil, Point{}, err
+	}
+	return b, p, nil
+}
+
+func encodePoints(appendTo []byte, pts []Point) []byte {
+	appendTo = encoding.EncodeUint32Ascending(appendTo, uint32(len(pts)))
+	for _, p := range pts {
+		appendTo = encodePoint(appendTo, p)
+	}
+	return appendTo
+}
+
+func decodePoints(b []byte) ([]byte, []Point, error) {
+	b, n, err := encoding.DecodeUint32Ascending(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Each point takes 16 bytes, which bounds the number of points that the
+	// input can hold.
+	if n == 0 || uint64(n)*16 > uint64(len(b)) {
+		return nil, nil, pgerror.Newf(pgcode.InvalidBinaryRepresentation,
+			"invalid number of points in external value: %d", n)
+	}
+	pts := make([]Point, n)
+	for i := range pts {
+		if b, pts[i], err = decodePoint(b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return b, pts, nil
+}
+
+func checkEnd(b []byte) error {
+	if len(b) != 0 {
+		return errors.Errorf("%d trailing bytes in encoded geometric value", len(b))
+	}
+	return nil
+}
+
+// EncodePointPGBinary appends the binary representation of a point to
+// appendTo.
+func EncodePointPGBinary(appendTo []byte, p Point) []byte {
+	return encodePoint(appendTo, p)
+}
+
+// DecodePointPGBinary decodes a point from its binary representation.
+func DecodePointPGBinary(b []byte) (Point, error) {
+	b, p, err := decodePoint(b)
+	if err != nil {
+		return Point{}, err
+	}
+	return p, checkEnd(b)
+}
+
+// EncodeBoxPGBinary appends the binary representation of a box to appendTo.
+func EncodeBoxPGBinary(appendTo []byte, box Box) []byte {
+	appendTo = encodePoint(appendTo, box.High)
+	return encodePoint(appendTo, box.Low)
+}
+
+// DecodeBoxPGBinary decodes a box from its binary representation.
+func DecodeBoxPGBinary(b []byte) (Box, error) {
+	b, high, err := decodePoint(b)
+	if err != nil {
+		return Box{}, err
+	}
+	b, low, err := decodePoint(b)
+	if err != nil {
+		return Box{}, err
+	}
+	return MakeBox(high, low), checkEnd(b)
+}
+
+// EncodePathPGBinary appends the binary representation of a path to appendTo.
+func EncodePathPGBinary(appendTo []byte, p Path) []byte {
+	var closed byte
+	if p.Closed {
+		closed = 1
+	}
+	appendTo = append(appendTo, closed)
+	return encodePoints(appendTo, p.Points)
+}
+
+// DecodePathPGBinary decodes a path from its binary representation.
+func DecodePathPGBinary(b []byte) (Path, error) {
+	if len(b) == 0 {
+		return Path{}, errors.New("insufficient bytes to decode path")
+	}
+	closed := b[0] != 0
+	b, pts, err := decodePoints(b[1:])
+	if err != nil {
+		return Path{}, err
+	}
+	return Path{Points: pts, Closed: closed}, checkEnd(b)
+}
+
+// EncodePolygonPGBinary appends the binary representation of a polygon to
+// appendTo.
+func EncodePolygonPGBinary(appendTo []byte, p Polygon) []byte {
+	return encodePoints(appendTo, p.Points)
+}
+
+// DecodePolygonPGBinary decodes a polygon from its binary representation.
+func DecodePolygonPGBinary(b []byte) (Polygon, error) {
+	b, pts, err := decodePoints(b)
+	if err != nil {
+		return Polygon{}, err
+	}
+	return Polygon{Points: pts}, checkEnd(b)
+}
+
+// EncodeCirclePGBinary appends the binary representation of a circle to
+// appendTo.
+func EncodeCirclePGBinary(appendTo []byte, c Circle) []byte {
+	appendTo = encodePoint(appendTo, c.Center)
+	return encodeFloat(appendTo, c.Radius)
+}
+
+// DecodeCirclePGBinary decodes a circle from its binary representation.
+func DecodeCirclePGBinary(b []byte) (Circle, error) {
+	b, center, err := decodePoint(b)
+	if err != nil {
+		return Circle{}, err
+	}
+	b, r, err := decodeFloat(b)
+	if err != nil {
+		return Circle{}, err
+	}
+	if r < 0 {
+		return Circle{}, pgerror.New(pgcode.InvalidBinaryRepresentation,
+			"invalid radius in external \"circle\" value")
+	}
+	return Circle{Center: center, Radius: r}, checkEnd(b)
+}
+
+// The functions below convert the geometric types to and from flat lists of
+// coordinates, which is how they are encoded in keys.
+
+func appendPointCoords(appendTo []float64, pts ...Point) []float64 {
+	for _, p := range pts {
+		appendTo = append(appendTo, p.X, p.Y)
+	}
+	return appendTo
+}
+
+func pointsFromCoords(coords []float64) ([]Point, error) {
+	if len(coords) == 0 || len(coords)%2 != 0 {
+		return nil, errors.Errorf("invalid number of coordinates: %d", len(coords))
+	}
+	pts := make([]Point, len(coords)/2)
+	for i := range pts {
+		pts[i] = Point{X: coords[2*i], Y: coords[2*i+1]}
+	}
+	return pts, nil
+}
+
+// Coords appends the coordinates of the point to appendTo.
+func (p Point) Coords(appendTo []float64) []float64 {
+	return appendPointCoords(appendTo, p)
+}
+
+// Coords appends the coordinates of the corners of the box to appendTo.
+func (b Box) Coords(appendTo []float64) []float64 {
+	return appendPointCoords(appendTo, b.High, b.Low)
+}
+
+// Coords appends the coordinates of the points of the path to appendTo.
+func (p Path) Coords(appendTo []float64) []float64 {
+	return appendPointCoords(appendTo, p.Points...)
+}
+
+// Coords appends the coordinates of the vertices of the polygon to appendTo.
+func (p Polygon) Coords(appendTo []float64) []float64 {
+	return appendPointCoords(appendTo, p.Points...)
+}
+
+// Coords appends the coordinates of the center of the circle, followed by its
+// radius, to appendTo.
+func (c Circle) Coords(appendTo []float64) []float64 {
+	return append(appendPointCoords(appendTo, c.Center), c.Radius)
+}
+
+// PointFromCoords is the inverse of Point.Coords.
+func PointFromCoords(coords []float64) (Point, error) {
+	if len(coords) != 2 {
+		return Point{}, errors.Errorf("invalid number of coordinates for point: %d", len(coords))
+	}
+	return Point{X: coords[0], Y: coords[1]}, nil
+}
+
+// BoxFromCoords is the inverse of Box.Coords.
+func BoxFromCoords(coords []float64) (Box, error) {
+	if len(coords) != 4 {
+		return Box{}, errors.Errorf("invalid number of coordinates for box: %d", len(coords))
+	}
+	return Box{
+		High: Point{X: coords[0], Y: coords[1]},
+		Low:  Point{X: coords[2], Y: coords[3]},
+	}, nil
+}
+
+// PathFromCoords is the inverse of Path.Coords.
+func PathFromCoords(coords []float64, closed bool) (Path, error) {
+	pts, err := pointsFromCoords(coords)
+	if err != nil {
+		return Path{}, err
+	}
+	return Path{Points: pts, Closed: closed}, nil
+}
+
+// PolygonFromCoords is the inverse of Polygon.Coords.
+func PolygonFromCoords(coords []float64) (Polygon, error) {
+	pts, err := pointsFromCoords(coords)
+	if err != nil {
+		return Polygon{}, err
+	}
+	return Polygon{Points: pts}, nil
+}
+
+// CircleFromCoords is the inverse of Circle.Coords.
+func CircleFromCoords(coords []float64) (Circle, error) {
+	if len(coords) != 3 {
+		return Circle{}, errors.Errorf("invalid number of coordinates for circle: %d", len(coords))
+	}
+	return Circle{Center: Point{X: coords[0], Y: coords[1]}, Radius: coords[2]}, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// The functions in this file convert between the geometric types and the
+// GEOMETRY type, as done by the casts between them in PostGIS.
+
+// ToGeometry converts the point to a POINT geometry.
+func (p Point) ToGeometry() (geo.Geometry, error) {
+	return geo.MakeGeometryFromPointCoords(p.X, p.Y)
+}
+
+// ToGeometry converts the path to a LINESTRING geometry. The linestring of a
+// closed path ends with its first point.
+func (p Path) ToGeometry() (geo.Geometry, error) {
+	coords := p.Coords(nil)
+	if p.Closed {
+		coords = p.Points[0].Coords(coords)
+	}
+	return geo.MakeGeometryFromGeomT(geom.NewLineStringFlat(geom.XY, coords))
+}
+
+// ToGeometry converts the polygon to a POLYGON geometry.
+func (p Polygon) ToGeometry() (geo.Geometry, error) {
+	coords := p.Points[0].Coords(p.Coords(nil))
+	return geo.MakeGeometryFromGeomT(geom.NewPolygonFlat(geom.XY, coords, []int{len(coords)}))
+}
+
+func geometryShapeError(shape string) error {
+	return pgerror.Newf(pgcode.InvalidParameterValue, "geometry must be a %s", shape)
+}
+
+// PointFromGeometry converts a non-empty POINT geometry to a point.
+func PointFromGeometry(g geo.Geometry) (Point, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return Point{}, err
+	}
+	pt, ok := t.(*geom.Point)
+	if !ok || pt.Empty() {
+		return Point{}, geometryShapeError("non-empty Point")
+	}
+	return Point{X: pt.X(), Y: pt.Y()}, nil
+}
+
+// flatPoints returns the points of the given flat coordinates, ignoring any
+// dimension beyond X and Y.
+func flatPoints(coords []float64, stride int) []Point {
+	pts := make([]Point, 0, len(coords)/stride)
+	for i := 0; i+1 < len(coords); i += stride {
+		pts = append(pts, Point{X: coords[i], Y: coords[i+1]})
+	}
+	return pts
+}
+
+// PathFromGeometry converts a non-empty LINESTRING geometry to an open path.
+func PathFromGeometry(g geo.Geometry) (Path, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return Path{}, err
+	}
+	ls, ok := t.(*geom.LineString)
+	if !ok || ls.Empty() {
+		return Path{}, geometryShapeError("non-empty LineString")
+	}
+	return Path{Points: flatPoints(ls.FlatCoords(), ls.Stride())}, nil
+}
+
+// PolygonFromGeometry converts a non-empty POLYGON geometry without holes to
+// a polygon.
+func PolygonFromGeometry(g geo.Geometry) (Polygon, error) {
+	t, err := g.AsGeomT()
+	if err != nil {
+		return Polygon{}, err
+	}
+	poly, ok := t.(*geom.Polygon)
+	if !ok || poly.Empty() || poly.NumLinearRings() != 1 {
+		return Polygon{}, geometryShapeError("non-empty Polygon without holes")
+	}
+	pts := flatPoints(poly.LinearRing(0).FlatCoords(), poly.Stride())
+	// The ring of the geometry repeats its first point at the end, which the
+	// polygon does not.
+	if len(pts) > 1 && pts[0] == pts[len(pts)-1] {
+		pts = pts[:len(pts)-1]
+	}
+	return Polygon{Points: pts}, nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package geometric implements the native geometric types of Postgres: point,
+// box, path, polygon and circle. These types describe two-dimensional shapes
+// on a cartesian plane, and are distinct from the spatial types of PostGIS
+// implemented in pkg/geo. See section 8.8 of the Postgres documentation for a
+// description of the types.
+package geometric
+
+import (
+	"math"
+	"strconv"
+)
+
+// Point is a point on a two-dimensional plane.
+type Point struct {
+	X, Y float64
+}
+
+// Box is a rectangular box whose sides are parallel to the axes. High is the
+// upper right corner of the box, and Low its lower left corner.
+type Box struct {
+	High, Low Point
+}
+
+// MakeBox returns the box having the two given points as opposite corners.
+func MakeBox(a, b Point) Box {
+	return Box{
+		High: Point{X: math.Max(a.X, b.X), Y: math.Max(a.Y, b.Y)},
+		Low:  Point{X: math.Min(a.X, b.X), Y: math.Min(a.Y, b.Y)},
+	}
+}
+
+// Path is a list of connected points. A closed path also connects its last
+// point back to its first one.
+type Path struct {
+	Points []Point
+	Closed bool
+}
+
+// Polygon is a list of connected points which, unlike a closed path, also
+// covers the area that they enclose.
+type Polygon struct {
+	Points []Point
+}
+
+// Circle is a circle given by its center and radius.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// compareFloat compares two float64 values. As for the FLOAT type, NaN is
+// less than all other values and -0 is equal to 0, which matches the ordering
+// of the key encoding of floats.
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	case a == b:
+		return 0
+	}
+	// At least one of the values is NaN.
+	aNaN, bNaN := math.IsNaN(a), math.IsNaN(b)
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func comparePoints(a, b []Point) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	for i := range a {
+		if c := a[i].Compare(b[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Compare compares two points, first on X and then on Y. Postgres does not
+// define an ordering of points, so the ordering is arbitrary, but it is total
+// and consistent with the key encoding of points.
+func (p Point) Compare(o Point) int {
+	if c := compareFloat(p.X, o.X); c != 0 {
+		return c
+	}
+	return compareFloat(p.Y, o.Y)
+}
+
+// Compare compares two boxes, first on their upper right corner and then on
+// their lower left corner.
+func (b Box) Compare(o Box) int {
+	if c := b.High.Compare(o.High); c != 0 {
+		return c
+	}
+	return b.Low.Compare(o.Low)
+}
+
+// Compare compares two paths. Open paths sort before closed ones, and paths
+// with fewer points before paths with more points. Paths with the same number
+// of points are compared point by point.
+func (p Path) Compare(o Path) int {
+	if p.Closed != o.Closed {
+		if !p.Closed {
+			return -1
+		}
+		return 1
+	}
+	return comparePoints(p.Points, o.Points)
+}
+
+// Compare compares two polygons. Polygons with fewer points sort before
+// polygons with more points, and polygons with the same number of points are
+// compared point by point.
+func (p Polygon) Compare(o Polygon) int {
+	return comparePoints(p.Points, o.Points)
+}
+
+// Compare compares two circles, first on their center and then on their
+// radius.
+func (c Circle) Compare(o Circle) int {
+	if r := c.Center.Compare(o.Center); r != 0 {
+		return r
+	}
+	return compareFloat(c.Radius, o.Radius)
+}
+
+// appendFloat appends the text representation of a coordinate to buf. As for
+// the FLOAT type, infinite values are written as Infinity and -Infinity.
+func appendFloat(buf []byte, f float64) []byte {
+	switch {
+	case math.IsInf(f, 1):
+		return append(buf, "Infinity"...)
+	case math.IsInf(f, -1):
+		return append(buf, "-Infinity"...)
+	}
+	return strconv.AppendFloat(buf, f, 'g', -1, 64)
+}
+
+func appendPoints(buf []byte, pts []Point) []byte {
+	for i, p := range pts {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = p.AppendFormat(buf)
+	}
+	return buf
+}
+
+// AppendFormat appends the text representation of the point, (x,y), to buf.
+func (p Point) AppendFormat(buf []byte) []byte {
+	buf = append(buf, '(')
+	buf = appendFloat(buf, p.X)
+	buf = append(buf, ',')
+	buf = appendFloat(buf, p.Y)
+	return append(buf, ')')
+}
+
+// String returns the text representation of the point.
+func (p Point) String() string {
+	return string(p.AppendFormat(nil))
+}
+
+// AppendFormat appends the text representation of the box, (x1,y1),(x2,y2),
+// to buf. The upper right corner of the box is written first.
+func (b Box) AppendFormat(buf []byte) []byte {
+	buf = b.High.AppendFormat(buf)
+	buf = append(buf, ',')
+	return b.Low.AppendFormat(buf)
+}
+
+// String returns the text representation of the box.
+func (b Box) String() string {
+	return string(b.AppendFormat(nil))
+}
+
+// AppendFormat appends the text representation of the path to buf. The points
+// of open paths are surrounded by square brackets, [(x1,y1),...], and the
+// points of closed paths by parentheses, ((x1,y1),...).
+func (p Path) AppendFormat(buf []byte) []byte {
+	if p.Closed {
+		buf = append(buf, '(')
+	} else {
+		buf = append(buf, '[')
+	}
+	buf = appendPoints(buf, p.Points)
+	if p.Closed {
+		return append(buf, ')')
+	}
+	return append(buf, ']')
+}
+
+// String returns the text representation of the path.
+func (p Path) String() string {
+	return string(p.AppendFormat(nil))
+}
+
+// AppendFormat appends the text representation of the polygon,
+// ((x1,y1),...), to buf.
+func (p Polygon) AppendFormat(buf []byte) []byte {
+	buf = append(buf, '(')
+	buf = appendPoints(buf, p.Points)
+	return append(buf, ')')
+}
+
+// String returns the text representation of the polygon.
+func (p Polygon) String() string {
+	return string(p.AppendFormat(nil))
+}
+
+// AppendFormat appends the text representation of the circle, <(x,y),r>, to
+// buf.
+func (c Circle) AppendFormat(buf []byte) []byte {
+	buf = append(buf, '<')
+	buf = c.Center.AppendFormat(buf)
+	buf = append(buf, ',')
+	buf = appendFloat(buf, c.Radius)
+	return append(buf, '>')
+}
+
+// String returns the text representation of the circle.
+func (c Circle) String() string {
+	return string(c.AppendFormat(nil))
+}
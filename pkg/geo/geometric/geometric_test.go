@@ -0,0 +1,218 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAndFormat(t *testing.T) {
+	testCases := []struct {
+		typ      string
+		s        string
+		expected string
+	}{
+		{typ: "point", s: "(1,2)", expected: "(1,2)"},
+		{typ: "point", s: " 1.5 , -2 ", expected: "(1.5,-2)"},
+		{typ: "point", s: "(Infinity,NaN)", expected: "(Infinity,NaN)"},
+		{typ: "point", s: "(1,2", expected: "error"},
+		{typ: "point", s: "(1,2,3)", expected: "error"},
+		{typ: "point", s: "", expected: "error"},
+		{typ: "box", s: "((0,0),(1,1))", expected: "(1,1),(0,0)"},
+		{typ: "box", s: "(1,0),(0,1)", expected: "(1,1),(0,0)"},
+		{typ: "box", s: "0,0,1,1", expected: "(1,1),(0,0)"},
+		{typ: "box", s: "(0,0,1,1)", expected: "(1,1),(0,0)"},
+		{typ: "box", s: "[(0,0),(1,1)]", expected: "error"},
+		{typ: "box", s: "(0,0),(1,1),(2,2)", expected: "error"},
+		{typ: "path", s: "[(0,0),(1,1)]", expected: "[(0,0),(1,1)]"},
+		{typ: "path", s: "((0,0),(1,1))", expected: "((0,0),(1,1))"},
+		{typ: "path", s: "(0,0),(1,1)", expected: "((0,0),(1,1))"},
+		{typ: "path", s: "0,0,1,1", expected: "((0,0),(1,1))"},
+		{typ: "path", s: "[(0,0),(1,1))", expected: "error"},
+		{typ: "path", s: "[]", expected: "error"},
+		{typ: "polygon", s: "((0,0),(1,1),(1,0))", expected: "((0,0),(1,1),(1,0))"},
+		{typ: "polygon", s: "0,0,1,1,1,0", expected: "((0,0),(1,1),(1,0))"},
+		{typ: "polygon", s: "[(0,0),(1,1)]", expected: "error"},
+		{typ: "circle", s: "<(1,2),3>", expected: "<(1,2),3>"},
+		{typ: "circle", s: "((1,2),3)", expected: "<(1,2),3>"},
+		{typ: "circle", s: "(1,2),3", expected: "<(1,2),3>"},
+		{typ: "circle", s: "1,2,3", expected: "<(1,2),3>"},
+		{typ: "circle", s: "<(1,2),-3>", expected: "error"},
+		{typ: "circle", s: "<(1,2),3)", expected: "error"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.typ+"/"+tc.s, func(t *testing.T) {
+			var s string
+			var err error
+			switch tc.typ {
+			case "point":
+				var p Point
+				p, err = ParsePoint(tc.s)
+				s = p.String()
+			case "box":
+				var b Box
+				b, err = ParseBox(tc.s)
+				s = b.String()
+			case "path":
+				var p Path
+				p, err = ParsePath(tc.s)
+				s = p.String()
+			case "polygon":
+				var p Polygon
+				p, err = ParsePolygon(tc.s)
+				s = p.String()
+			case "circle":
+				var c Circle
+				c, err = ParseCircle(tc.s)
+				s = c.String()
+			}
+			if tc.expected == "error" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, s)
+		})
+	}
+}
+
+func TestPGBinaryRoundTrip(t *testing.T) {
+	pt := Point{X: 1, Y: -2.5}
+	decodedPoint, err := DecodePointPGBinary(EncodePointPGBinary(nil, pt))
+	require.NoError(t, err)
+	require.Equal(t, pt, decodedPoint)
+
+	box := MakeBox(Point{X: 0, Y: 0}, Point{X: 1, Y: 1})
+	decodedBox, err := DecodeBoxPGBinary(EncodeBoxPGBinary(nil, box))
+	require.NoError(t, err)
+	require.Equal(t, box, decodedBox)
+
+	path := Path{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}, Closed: true}
+	encodedPath := EncodePathPGBinary(nil, path)
+	require.Len(t, encodedPath, 1+4+2*16)
+	decodedPath, err := DecodePathPGBinary(encodedPath)
+	require.NoError(t, err)
+	require.Equal(t, path, decodedPath)
+	_, err = DecodePathPGBinary(encodedPath[:len(encodedPath)-1])
+	require.Error(t, err)
+
+	poly := Polygon{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}}}
+	decodedPoly, err := DecodePolygonPGBinary(EncodePolygonPGBinary(nil, poly))
+	require.NoError(t, err)
+	require.Equal(t, poly, decodedPoly)
+
+	circle := Circle{Center: pt, Radius: 3}
+	decodedCircle, err := DecodeCirclePGBinary(EncodeCirclePGBinary(nil, circle))
+	require.NoError(t, err)
+	require.Equal(t, circle, decodedCircle)
+}
+
+func TestCompare(t *testing.T) {
+	require.Equal(t, 0, Point{X: 0, Y: 1}.Compare(Point{X: math.Copysign(0, -1), Y: 1}))
+	require.Equal(t, -1, Point{X: math.NaN(), Y: 1}.Compare(Point{X: math.Inf(-1), Y: 1}))
+	require.Equal(t, 0, Point{X: math.NaN(), Y: 1}.Compare(Point{X: math.NaN(), Y: 1}))
+	require.Equal(t, 1, Point{X: 1, Y: 2}.Compare(Point{X: 1, Y: 1}))
+
+	open := Path{Points: []Point{{X: 5, Y: 5}, {X: 6, Y: 6}}}
+	closed := Path{Points: []Point{{X: 0, Y: 0}}, Closed: true}
+	require.Equal(t, -1, open.Compare(closed))
+	short := Polygon{Points: []Point{{X: 9, Y: 9}}}
+	long := Polygon{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}
+	require.Equal(t, -1, short.Compare(long))
+}
+
+func TestOperators(t *testing.T) {
+	square := Polygon{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 0}}}
+	require.True(t, square.ContainsPoint(Point{X: 1, Y: 1}))
+	require.True(t, square.ContainsPoint(Point{X: 0, Y: 1}))
+	require.False(t, square.ContainsPoint(Point{X: 3, Y: 1}))
+
+	inner := Polygon{Points: []Point{{X: 0.5, Y: 0.5}, {X: 1.5, Y: 0.5}, {X: 1, Y: 1.5}}}
+	require.True(t, square.Contains(inner))
+	require.False(t, inner.Contains(square))
+	require.True(t, square.Overlaps(inner))
+
+	// The vertices of the concave polygon are all inside the square, but one of
+	// its edges is not.
+	concave := Polygon{Points: []Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 0, Y: 2}}}
+	require.True(t, square.Contains(concave))
+	require.False(t, concave.Contains(square))
+
+	far := Polygon{Points: []Point{{X: 5, Y: 0}, {X: 6, Y: 0}, {X: 6, Y: 2}}}
+	require.False(t, square.Overlaps(far))
+	require.Equal(t, 3.0, square.Distance(far))
+	require.Equal(t, 0.0, square.Distance(inner))
+
+	box := MakeBox(Point{X: 0, Y: 0}, Point{X: 2, Y: 2})
+	require.True(t, box.Contains(MakeBox(Point{X: 1, Y: 1}, Point{X: 2, Y: 2})))
+	require.True(t, box.Overlaps(MakeBox(Point{X: 2, Y: 2}, Point{X: 3, Y: 3})))
+	require.False(t, box.Overlaps(MakeBox(Point{X: 3, Y: 3}, Point{X: 4, Y: 4})))
+	require.Equal(t, 4.0, box.Area())
+	require.Equal(t, Point{X: 1, Y: 1}, box.Center())
+	require.Equal(t, 1.0, box.DistanceToPoint(Point{X: 3, Y: 1}))
+	require.Equal(t, square, box.ToPolygon())
+
+	c := Circle{Center: Point{X: 0, Y: 0}, Radius: 2}
+	require.True(t, c.Contains(Circle{Center: Point{X: 1, Y: 0}, Radius: 1}))
+	require.False(t, c.Contains(Circle{Center: Point{X: 1, Y: 0}, Radius: 1.5}))
+	require.True(t, c.Overlaps(Circle{Center: Point{X: 4, Y: 0}, Radius: 2}))
+	require.Equal(t, 1.0, c.Distance(Circle{Center: Point{X: 4, Y: 0}, Radius: 1}))
+	require.Equal(t, 1.0, c.DistanceToPoint(Point{X: 0, Y: 3}))
+	_, err := c.ToPolygon(1)
+	require.Error(t, err)
+	p, err := c.ToPolygon(4)
+	require.NoError(t, err)
+	require.Len(t, p.Points, 4)
+	for _, pt := range p.Points {
+		require.InDelta(t, 2, pt.Distance(c.Center), epsilon)
+	}
+
+	path := Path{Points: []Point{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 4}}}
+	require.Equal(t, 7.0, path.Length())
+	_, ok := path.Area()
+	require.False(t, ok)
+	_, err = path.ToPolygon()
+	require.Error(t, err)
+	path.Closed = true
+	require.Equal(t, 12.0, path.Length())
+	area, ok := path.Area()
+	require.True(t, ok)
+	require.Equal(t, 6.0, area)
+	require.Equal(t, 1.0, path.DistanceToPoint(Point{X: 4, Y: 2}))
+}
+
+func TestGeometry(t *testing.T) {
+	poly := Polygon{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}}}
+	g, err := poly.ToGeometry()
+	require.NoError(t, err)
+	decodedPoly, err := PolygonFromGeometry(g)
+	require.NoError(t, err)
+	require.Equal(t, poly, decodedPoly)
+	_, err = PointFromGeometry(g)
+	require.Error(t, err)
+
+	path := Path{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}
+	g, err = path.ToGeometry()
+	require.NoError(t, err)
+	decodedPath, err := PathFromGeometry(g)
+	require.NoError(t, err)
+	require.Equal(t, path, decodedPath)
+
+	pt := Point{X: 1, Y: 2}
+	g, err = pt.ToGeometry()
+	require.NoError(t, err)
+	decodedPoint, err := PointFromGeometry(g)
+	require.NoError(t, err)
+	require.Equal(t, pt, decodedPoint)
+}
@@ -0,0 +1,616 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// epsilon is the tolerance used by Postgres when comparing coordinates in the
+// geometric operators, so that the results are not overly sensitive to
+// rounding errors.
+const epsilon = 1.0e-06
+
+func fpZero(a float64) bool  { return math.Abs(a) <= epsilon }
+func fpEq(a, b float64) bool { return a == b || math.Abs(a-b) <= epsilon }
+func fpLt(a, b float64) bool { return a+epsilon < b }
+func fpLe(a, b float64) bool { return a <= b+epsilon }
+func fpGt(a, b float64) bool { return a > b+epsilon }
+func fpGe(a, b float64) bool { return a+epsilon >= b }
+
+// Add translates the point by the given offset.
+func (p Point) Add(o Point) Point {
+	return Point{X: p.X + o.X, Y: p.Y + o.Y}
+}
+
+// Sub translates the point by the negation of the given offset.
+func (p Point) Sub(o Point) Point {
+	return Point{X: p.X - o.X, Y: p.Y - o.Y}
+}
+
+// Distance returns the distance between two points.
+func (p Point) Distance(o Point) float64 {
+	return math.Hypot(p.X-o.X, p.Y-o.Y)
+}
+
+// Same returns whether two points are the same, within a small tolerance.
+func (p Point) Same(o Point) bool {
+	return fpEq(p.X, o.X) && fpEq(p.Y, o.Y)
+}
+
+// BoundBox returns the smallest box containing both boxes.
+func (b Box) BoundBox(o Box) Box {
+	return Box{
+		High: Point{X: math.Max(b.High.X, o.High.X), Y: math.Max(b.High.Y, o.High.Y)},
+		Low:  Point{X: math.Min(b.Low.X, o.Low.X), Y: math.Min(b.Low.Y, o.Low.Y)},
+	}
+}
+
+// Add translates the box by the given offset.
+func (b Box) Add(p Point) Box {
+	return Box{High: b.High.Add(p), Low: b.Low.Add(p)}
+}
+
+// Sub translates the box by the negation of the given offset.
+func (b Box) Sub(p Point) Box {
+	return Box{High: b.High.Sub(p), Low: b.Low.Sub(p)}
+}
+
+// Width returns the horizontal size of the box.
+func (b Box) Width() float64 {
+	return b.High.X - b.Low.X
+}
+
+// Height returns the vertical size of the box.
+func (b Box) Height() float64 {
+	return b.High.Y - b.Low.Y
+}
+
+// Area returns the area of the box.
+func (b Box) Area() float64 {
+	return b.Width() * b.Height()
+}
+
+// Center returns the center of the box.
+func (b Box) Center() Point {
+	return Point{X: (b.High.X + b.Low.X) / 2, Y: (b.High.Y + b.Low.Y) / 2}
+}
+
+// Contains returns whether the box contains the other box.
+func (b Box) Contains(o Box) bool {
+	return fpGe(b.High.X, o.High.X) && fpLe(b.Low.X, o.Low.X) &&
+		fpGe(b.High.Y, o.High.Y) && fpLe(b.Low.Y, o.Low.Y)
+}
+
+// ContainsPoint returns whether the point lies in the box or on its border.
+func (b Box) ContainsPoint(p Point) bool {
+	return p.X <= b.High.X && p.X >= b.Low.X && p.Y <= b.High.Y && p.Y >= b.Low.Y
+}
+
+// Overlaps returns whether the two boxes have at least one point in common.
+func (b Box) Overlaps(o Box) bool {
+	return fpLe(b.Low.X, o.High.X) && fpLe(o.Low.X, b.High.X) &&
+		fpLe(b.Low.Y, o.High.Y) && fpLe(o.Low.Y, b.High.Y)
+}
+
+// Distance returns the distance between the centers of the two boxes.
+func (b Box) Distance(o Box) float64 {
+	return b.Center().Distance(o.Center())
+}
+
+// DistanceToPoint returns the distance between the box and the point, which
+// is zero if the point is inside the box.
+func (b Box) DistanceToPoint(p Point) float64 {
+	dx := math.Max(0, math.Max(b.Low.X-p.X, p.X-b.High.X))
+	dy := math.Max(0, math.Max(b.Low.Y-p.Y, p.Y-b.High.Y))
+	return math.Hypot(dx, dy)
+}
+
+// ToCircle returns the circle circumscribed about the box.
+func (b Box) ToCircle() Circle {
+	center := b.Center()
+	return Circle{Center: center, Radius: center.Distance(b.High)}
+}
+
+// ToPolygon returns the polygon having the corners of the box as vertices.
+func (b Box) ToPolygon() Polygon {
+	return Polygon{Points: []Point{
+		b.Low,
+		{X: b.Low.X, Y: b.High.Y},
+		b.High,
+		{X: b.High.X, Y: b.Low.Y},
+	}}
+}
+
+// Add translates the path by the given offset.
+func (p Path) Add(o Point) Path {
+	pts := make([]Point, len(p.Points))
+	for i := range p.Points {
+		pts[i] = p.Points[i].Add(o)
+	}
+	return Path{Points: pts, Closed: p.Closed}
+}
+
+// Sub translates the path by the negation of the given offset.
+func (p Path) Sub(o Point) Path {
+	return p.Add(Point{X: -o.X, Y: -o.Y})
+}
+
+// Area returns the area enclosed by a closed path, or false for an open path.
+func (p Path) Area() (float64, bool) {
+	if !p.Closed {
+		return 0, false
+	}
+	var area float64
+	for i := range p.Points {
+		j := (i + 1) % len(p.Points)
+		area += p.Points[i].X*p.Points[j].Y - p.Points[i].Y*p.Points[j].X
+	}
+	return math.Abs(area / 2), true
+}
+
+// Length returns the total length of the segments of the path.
+func (p Path) Length() float64 {
+	var l float64
+	p.forEachSegment(func(a, b Point) bool {
+		l += a.Distance(b)
+		return true
+	})
+	return l
+}
+
+// forEachSegment calls fn for each segment of the path, including the one
+// joining the last point to the first if the path is closed, until fn returns
+// false.
+func (p Path) forEachSegment(fn func(a, b Point) bool) {
+	n := len(p.Points)
+	for i := 0; i+1 < n; i++ {
+		if !fn(p.Points[i], p.Points[i+1]) {
+			return
+		}
+	}
+	if p.Closed && n > 1 {
+		fn(p.Points[n-1], p.Points[0])
+	}
+}
+
+// DistanceToPoint returns the distance between the path and the point.
+func (p Path) DistanceToPoint(pt Point) float64 {
+	if len(p.Points) == 1 {
+		return pt.Distance(p.Points[0])
+	}
+	d := math.Inf(1)
+	p.forEachSegment(func(a, b Point) bool {
+		d = math.Min(d, segmentDistanceToPoint(a, b, pt))
+		return true
+	})
+	return d
+}
+
+// Distance returns the distance between the two paths.
+func (p Path) Distance(o Path) float64 {
+	if len(p.Points) == 1 {
+		return o.DistanceToPoint(p.Points[0])
+	}
+	if len(o.Points) == 1 {
+		return p.DistanceToPoint(o.Points[0])
+	}
+	d := math.Inf(1)
+	p.forEachSegment(func(a, b Point) bool {
+		o.forEachSegment(func(c, e Point) bool {
+			d = math.Min(d, segmentDistance(a, b, c, e))
+			return true
+		})
+		return true
+	})
+	return d
+}
+
+// ContainsPoint returns whether the point lies inside the closed path or on
+// its border. As in Postgres, open paths contain no points.
+func (p Path) ContainsPoint(pt Point) bool {
+	return p.Closed && pointInside(pt, p.Points) != 0
+}
+
+// ToPolygon converts a closed path to a polygon.
+func (p Path) ToPolygon() (Polygon, error) {
+	if !p.Closed {
+		return Polygon{}, pgerror.New(pgcode.InvalidParameterValue,
+			"open path cannot be converted to polygon")
+	}
+	return Polygon{Points: append([]Point(nil), p.Points...)}, nil
+}
+
+// BoundingBox returns the smallest box containing all the vertices of the
+// polygon.
+func (p Polygon) BoundingBox() Box {
+	b := Box{High: p.Points[0], Low: p.Points[0]}
+	for _, pt := range p.Points[1:] {
+		b.High.X = math.Max(b.High.X, pt.X)
+		b.High.Y = math.Max(b.High.Y, pt.Y)
+		b.Low.X = math.Min(b.Low.X, pt.X)
+		b.Low.Y = math.Min(b.Low.Y, pt.Y)
+	}
+	return b
+}
+
+// Center returns the average of the vertices of the polygon.
+func (p Polygon) Center() Point {
+	var c Point
+	for _, pt := range p.Points {
+		c.X += pt.X
+		c.Y += pt.Y
+	}
+	n := float64(len(p.Points))
+	return Point{X: c.X / n, Y: c.Y / n}
+}
+
+// ToPath converts the polygon to a closed path.
+func (p Polygon) ToPath() Path {
+	return Path{Points: append([]Point(nil), p.Points...), Closed: true}
+}
+
+// ToCircle returns the circle centered on the average of the vertices of the
+// polygon, whose radius is the average distance of the vertices to that
+// center.
+func (p Polygon) ToCircle() Circle {
+	center := p.Center()
+	var r float64
+	for _, pt := range p.Points {
+		r += pt.Distance(center)
+	}
+	return Circle{Center: center, Radius: r / float64(len(p.Points))}
+}
+
+func (p Polygon) asPath() Path {
+	return Path{Points: p.Points, Closed: true}
+}
+
+// ContainsPoint returns whether the point lies inside the polygon or on its
+// border.
+func (p Polygon) ContainsPoint(pt Point) bool {
+	return pointInside(pt, p.Points) != 0
+}
+
+// Contains returns whether the polygon contains the other polygon.
+func (p Polygon) Contains(o Polygon) bool {
+	if !p.BoundingBox().Contains(o.BoundingBox()) {
+		return false
+	}
+	for _, pt := range o.Points {
+		if !p.ContainsPoint(pt) {
+			return false
+		}
+	}
+	// All the vertices of o are inside p, so o is contained in p unless one of
+	// its edges leaves p. That happens when the edge properly crosses an edge
+	// of p, or when its midpoint lies outside of p.
+	contained := true
+	o.asPath().forEachSegment(func(a, b Point) bool {
+		mid := Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+		if !p.ContainsPoint(mid) {
+			contained = false
+			return false
+		}
+		p.asPath().forEachSegment(func(c, e Point) bool {
+			if segmentsCrossProperly(a, b, c, e) {
+				contained = false
+			}
+			return contained
+		})
+		return contained
+	})
+	return contained
+}
+
+// Overlaps returns whether the two polygons have at least one point in
+// common.
+func (p Polygon) Overlaps(o Polygon) bool {
+	if !p.BoundingBox().Overlaps(o.BoundingBox()) {
+		return false
+	}
+	if p.ContainsPoint(o.Points[0]) || o.ContainsPoint(p.Points[0]) {
+		return true
+	}
+	overlaps := false
+	p.asPath().forEachSegment(func(a, b Point) bool {
+		o.asPath().forEachSegment(func(c, e Point) bool {
+			overlaps = segmentsIntersect(a, b, c, e)
+			return !overlaps
+		})
+		return !overlaps
+	})
+	return overlaps
+}
+
+// DistanceToPoint returns the distance between the polygon and the point,
+// which is zero if the point is inside the polygon.
+func (p Polygon) DistanceToPoint(pt Point) float64 {
+	if p.ContainsPoint(pt) {
+		return 0
+	}
+	return p.asPath().DistanceToPoint(pt)
+}
+
+// Distance returns the distance between the two polygons, which is zero if
+// they overlap.
+func (p Polygon) Distance(o Polygon) float64 {
+	if p.Overlaps(o) {
+		return 0
+	}
+	return p.asPath().Distance(o.asPath())
+}
+
+// Add translates the circle by the given offset.
+func (c Circle) Add(p Point) Circle {
+	return Circle{Center: c.Center.Add(p), Radius: c.Radius}
+}
+
+// Sub translates the circle by the negation of the given offset.
+func (c Circle) Sub(p Point) Circle {
+	return Circle{Center: c.Center.Sub(p), Radius: c.Radius}
+}
+
+// Area returns the area of the circle.
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Diameter returns the diameter of the circle.
+func (c Circle) Diameter() float64 {
+	return 2 * c.Radius
+}
+
+// Contains returns whether the circle contains the other circle.
+func (c Circle) Contains(o Circle) bool {
+	return fpLe(c.Center.Distance(o.Center)+o.Radius, c.Radius)
+}
+
+// ContainsPoint returns whether the point lies inside the circle or on its
+// border.
+func (c Circle) ContainsPoint(p Point) bool {
+	return c.Center.Distance(p) <= c.Radius
+}
+
+// Overlaps returns whether the two circles have at least one point in common.
+func (c Circle) Overlaps(o Circle) bool {
+	return fpLe(c.Center.Distance(o.Center), c.Radius+o.Radius)
+}
+
+// Distance returns the distance between the two circles, which is zero if
+// they overlap.
+func (c Circle) Distance(o Circle) float64 {
+	return math.Max(0, c.Center.Distance(o.Center)-(c.Radius+o.Radius))
+}
+
+// DistanceToPoint returns the distance between the circle and the point,
+// which is zero if the point is inside the circle.
+func (c Circle) DistanceToPoint(p Point) float64 {
+	return math.Max(0, c.Center.Distance(p)-c.Radius)
+}
+
+// DistanceToPolygon returns the distance between the circle and the polygon,
+// which is zero if they overlap.
+func (c Circle) DistanceToPolygon(p Polygon) float64 {
+	return math.Max(0, p.DistanceToPoint(c.Center)-c.Radius)
+}
+
+// ToBox returns the box inscribed in the circle.
+func (c Circle) ToBox() Box {
+	d := c.Radius / math.Sqrt2
+	return Box{
+		High: Point{X: c.Center.X + d, Y: c.Center.Y + d},
+		Low:  Point{X: c.Center.X - d, Y: c.Center.Y - d},
+	}
+}
+
+// maxCirclePolygonPoints bounds the number of vertices of the polygons built
+// from circles, which would otherwise be limited only by memory.
+const maxCirclePolygonPoints = 1 << 20
+
+// ToPolygon returns a polygon with npts vertices approximating the circle.
+func (c Circle) ToPolygon(npts int) (Polygon, error) {
+	if c.Radius == 0 {
+		return Polygon{}, pgerror.New(pgcode.FeatureNotSupported,
+			"cannot convert circle with radius zero to polygon")
+	}
+	if npts < 2 {
+		return Polygon{}, pgerror.New(pgcode.InvalidParameterValue,
+			"must request at least 2 points")
+	}
+	if npts > maxCirclePolygonPoints {
+		return Polygon{}, pgerror.New(pgcode.ProgramLimitExceeded,
+			"too many points requested")
+	}
+	pts := make([]Point, npts)
+	step := -2 * math.Pi / float64(npts)
+	for i := range pts {
+		angle := float64(i) * step
+		pts[i] = Point{
+			X: c.Center.X - c.Radius*math.Cos(angle),
+			Y: c.Center.Y + c.Radius*math.Sin(angle),
+		}
+	}
+	return Polygon{Points: pts}, nil
+}
+
+// cross returns the z component of the cross product of the vectors a->b and
+// a->c, which is positive when c is to the left of the line going through a
+// and b.
+func cross(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// onSegment returns whether p, which is known to be collinear with the
+// segment a-b, lies on it.
+func onSegment(a, b, p Point) bool {
+	return fpLe(math.Min(a.X, b.X), p.X) && fpLe(p.X, math.Max(a.X, b.X)) &&
+		fpLe(math.Min(a.Y, b.Y), p.Y) && fpLe(p.Y, math.Max(a.Y, b.Y))
+}
+
+// segmentsIntersect returns whether the segments a-b and c-d have at least one
+// point in common.
+func segmentsIntersect(a, b, c, d Point) bool {
+	d1, d2 := cross(c, d, a), cross(c, d, b)
+	d3, d4 := cross(a, b, c), cross(a, b, d)
+	if ((fpGt(d1, 0) && fpLt(d2, 0)) || (fpLt(d1, 0) && fpGt(d2, 0))) &&
+		((fpGt(d3, 0) && fpLt(d4, 0)) || (fpLt(d3, 0) && fpGt(d4, 0))) {
+		return true
+	}
+	return (fpZero(d1) && onSegment(c, d, a)) || (fpZero(d2) && onSegment(c, d, b)) ||
+		(fpZero(d3) && onSegment(a, b, c)) || (fpZero(d4) && onSegment(a, b, d))
+}
+
+// segmentsCrossProperly returns whether the segments a-b and c-d intersect in
+// a single point which is not an endpoint of either segment.
+func segmentsCrossProperly(a, b, c, d Point) bool {
+	d1, d2 := cross(c, d, a), cross(c, d, b)
+	d3, d4 := cross(a, b, c), cross(a, b, d)
+	return ((fpGt(d1, 0) && fpLt(d2, 0)) || (fpLt(d1, 0) && fpGt(d2, 0))) &&
+		((fpGt(d3, 0) && fpLt(d4, 0)) || (fpLt(d3, 0) && fpGt(d4, 0)))
+}
+
+// segmentDistanceToPoint returns the distance between the segment a-b and the
+// point p.
+func segmentDistanceToPoint(a, b, p Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	l := dx*dx + dy*dy
+	if l == 0 {
+		return a.Distance(p)
+	}
+	t := math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/l))
+	return p.Distance(Point{X: a.X + t*dx, Y: a.Y + t*dy})
+}
+
+// segmentDistance returns the distance between the segments a-b and c-d.
+func segmentDistance(a, b, c, d Point) float64 {
+	if segmentsIntersect(a, b, c, d) {
+		return 0
+	}
+	return math.Min(
+		math.Min(segmentDistanceToPoint(a, b, c), segmentDistanceToPoint(a, b, d)),
+		math.Min(segmentDistanceToPoint(c, d, a), segmentDistanceToPoint(c, d, b)),
+	)
+}
+
+// pointInside returns 0 if the point is outside of the polygon given by its
+// vertices, 1 if it is inside and 2 if it lies on its border. It is a port of
+// point_inside in the geo_ops.c file of Postgres.
+func pointInside(p Point, pts []Point) int {
+	n := len(pts)
+	if n == 0 {
+		return 0
+	}
+	// Compute the first polygon point relative to the single point.
+	x0, y0 := pts[0].X-p.X, pts[0].Y-p.Y
+	prevX, prevY := x0, y0
+	crossings := 0
+	// Loop over polygon points and aggregate the total crossings.
+	for i := 1; i < n; i++ {
+		// Compute the next polygon point relative to the single point.
+		x, y := pts[i].X-p.X, pts[i].Y-p.Y
+		c := lsegCrossing(x, y, prevX, prevY)
+		if c == onBorder {
+			return 2
+		}
+		crossings += c
+		prevX, prevY = x, y
+	}
+	// Also check the segment joining the last point to the first one.
+	c := lsegCrossing(x0, y0, prevX, prevY)
+	if c == onBorder {
+		return 2
+	}
+	crossings += c
+	if crossings != 0 {
+		return 1
+	}
+	return 0
+}
+
+const onBorder = 1000
+
+// lsegCrossing returns +/-2 if the segment from (prevX,prevY) to (x,y)
+// crosses the positive X axis, +/-1 if one of its endpoints touches the axis,
+// zero if it does not cross the axis at all, and onBorder if it goes through
+// the origin. It is a port of lseg_crossing in the geo_ops.c file of
+// Postgres.
+func lsegCrossing(x, y, prevX, prevY float64) int {
+	if fpZero(y) {
+		// The point is on the X axis.
+		if fpZero(x) {
+			// The point is the origin.
+			return onBorder
+		} else if fpGt(x, 0) {
+			// The point is on the positive X axis.
+			if fpZero(prevY) {
+				// The segment lies on the X axis.
+				if fpGt(prevX, 0) {
+					return 0
+				}
+				return onBorder
+			}
+			if fpLt(prevY, 0) {
+				return 1
+			}
+			return -1
+		}
+		// The point is on the negative X axis.
+		if fpZero(prevY) && fpLt(prevX, 0) {
+			return 0
+		}
+		if fpZero(prevY) {
+			return onBorder
+		}
+		return 0
+	}
+	// The point is not on the X axis.
+	ySign := 1
+	if fpLt(y, 0) {
+		ySign = -1
+	}
+	if fpZero(prevY) {
+		// The previous point is on the X axis.
+		if fpLt(prevX, 0) {
+			return 0
+		}
+		return ySign
+	}
+	prevYSign := 1
+	if fpLt(prevY, 0) {
+		prevYSign = -1
+	}
+	if ySign == prevYSign {
+		// Both points are on the same side of the X axis.
+		return 0
+	}
+	// The segment crosses the X axis.
+	if fpGe(x, 0) && fpGt(prevX, 0) {
+		// The segment crosses the positive X axis.
+		return 2 * ySign
+	}
+	if fpLt(x, 0) && fpLe(prevX, 0) {
+		// The segment crosses the negative X axis.
+		return 0
+	}
+	// Compute the intersection of the segment with the X axis.
+	z := (x-prevX)*y - (y-prevY)*x
+	if fpZero(z) {
+		return onBorder
+	}
+	if (ySign < 0 && fpLt(z, 0)) || (ySign > 0 && fpGt(z, 0)) {
+		return 0
+	}
+	return 2 * ySign
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// parser parses the text representation of the geometric types. The accepted
+// syntax matches that of Postgres, where the delimiters around points and
+// lists of points are mostly optional.
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return pgerror.Newf(pgcode.InvalidTextRepresentation, format, args...)
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && isSpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// peek returns the next non-space character, or 0 at the end of the input.
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// peekAfter returns the first non-space character after the next one, or 0 if
+// there is none.
+func (p *parser) peekAfter() byte {
+	p.skipSpace()
+	for i := p.pos + 1; i < len(p.s); i++ {
+		if !isSpace(p.s[i]) {
+			return p.s[i]
+		}
+	}
+	return 0
+}
+
+// consume consumes the next non-space character if it is c.
+func (p *parser) consume(c byte) bool {
+	if p.peek() != c || c == 0 {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *parser) expect(c byte) error {
+	if !p.consume(c) {
+		return p.errorf("expected %q at position %d", c, p.pos)
+	}
+	return nil
+}
+
+// end checks that only spaces remain in the input.
+func (p *parser) end() error {
+	if p.peek() != 0 {
+		return p.errorf("unexpected characters at position %d", p.pos)
+	}
+	return nil
+}
+
+func (p *parser) float() (float64, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) && !isSpace(p.s[p.pos]) && !strings.ContainsRune(",()[]<>", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		return 0, p.errorf("invalid number at position %d", start)
+	}
+	return f, nil
+}
+
+// point parses a point written as x,y or (x,y).
+func (p *parser) point() (Point, error) {
+	paren := p.consume('(')
+	x, err := p.float()
+	if err != nil {
+		return Point{}, err
+	}
+	if err := p.expect(','); err != nil {
+		return Point{}, err
+	}
+	y, err := p.float()
+	if err != nil {
+		return Point{}, err
+	}
+	if paren {
+		if err := p.expect(')'); err != nil {
+			return Point{}, err
+		}
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+// points parses a non-empty list of points. The list may be surrounded by
+// parentheses or, if allowOpen is set, by square brackets, in which case the
+// returned closed flag is false.
+func (p *parser) points(allowOpen bool) (pts []Point, closed bool, _ error) {
+	var delim byte
+	switch p.peek() {
+	case '[':
+		if !allowOpen {
+			return nil, false, p.errorf("unexpected \"[\" at position %d", p.pos)
+		}
+		delim = ']'
+	case '(':
+		// The list is surrounded by parentheses when the first point is, as in
+		// ((x1,y1),(x2,y2)), or when its points are not, as in (x1,y1,x2,y2).
+		if p.peekAfter() == '(' || strings.Count(p.s, "(") == 1 {
+			delim = ')'
+		}
+	}
+	if delim != 0 {
+		p.pos++
+	}
+	for {
+		pt, err := p.point()
+		if err != nil {
+			return nil, false, err
+		}
+		pts = append(pts, pt)
+		if !p.consume(',') {
+			break
+		}
+	}
+	if delim != 0 {
+		if err := p.expect(delim); err != nil {
+			return nil, false, err
+		}
+	}
+	if err := p.end(); err != nil {
+		return nil, false, err
+	}
+	return pts, delim != ']', nil
+}
+
+// ParsePoint parses a point from its text representation, either x,y or
+// (x,y).
+func ParsePoint(s string) (Point, error) {
+	p := parser{s: s}
+	pt, err := p.point()
+	if err != nil {
+		return Point{}, err
+	}
+	if err := p.end(); err != nil {
+		return Point{}, err
+	}
+	return pt, nil
+}
+
+// ParseBox parses a box from its text representation, which is any two of
+// its opposite corners written as ((x1,y1),(x2,y2)), (x1,y1),(x2,y2) or
+// x1,y1,x2,y2.
+func ParseBox(s string) (Box, error) {
+	p := parser{s: s}
+	pts, _, err := p.points(false /* allowOpen */)
+	if err != nil {
+		return Box{}, err
+	}
+	if len(pts) != 2 {
+		return Box{}, p.errorf("expected 2 points, found %d", len(pts))
+	}
+	return MakeBox(pts[0], pts[1]), nil
+}
+
+// ParsePath parses a path from its text representation. Open paths are
+// written as [(x1,y1),...], and closed paths as ((x1,y1),...), (x1,y1),...
+// or x1,y1,....
+func ParsePath(s string) (Path, error) {
+	p := parser{s: s}
+	pts, closed, err := p.points(true /* allowOpen */)
+	if err != nil {
+		return Path{}, err
+	}
+	return Path{Points: pts, Closed: closed}, nil
+}
+
+// ParsePolygon parses a polygon from its text representation, which is a
+// list of its vertices written as ((x1,y1),...), (x1,y1),... or x1,y1,....
+func ParsePolygon(s string) (Polygon, error) {
+	p := parser{s: s}
+	pts, _, err := p.points(false /* allowOpen */)
+	if err != nil {
+		return Polygon{}, err
+	}
+	return Polygon{Points: pts}, nil
+}
+
+// ParseCircle parses a circle from its text representation, which is written
+// as <(x,y),r>, ((x,y),r), (x,y),r or x,y,r.
+func ParseCircle(s string) (Circle, error) {
+	p := parser{s: s}
+	var delim byte
+	if p.consume('<') {
+		delim = '>'
+	} else if p.peek() == '(' && p.peekAfter() == '(' {
+		p.pos++
+		delim = ')'
+	}
+	center, err := p.point()
+	if err != nil {
+		return Circle{}, err
+	}
+	if err := p.expect(','); err != nil {
+		return Circle{}, err
+	}
+	r, err := p.float()
+	if err != nil {
+		return Circle{}, err
+	}
+	if delim != 0 {
+		if err := p.expect(delim); err != nil {
+			return Circle{}, err
+		}
+	}
+	if err := p.end(); err != nil {
+		return Circle{}, err
+	}
+	if r < 0 {
+		return Circle{}, p.errorf("radius must not be negative")
+	}
+	return Circle{Center: center, Radius: r}, nil
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/raft/v3"
@@ -304,7 +305,9 @@ func TestReplicaRangefeed(t *testing.T) {
 	}
 	// Insert a second key transactionally.
 	ts3 := initTime.Add(0, 3)
+	var txnID3 uuid.UUID
 	if err := store1.DB().Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		txnID3 = txn.ID()
 		if err := txn.SetFixedTimestamp(ctx, ts3); err != nil {
 			return err
 		}
@@ -326,7 +329,9 @@ func TestReplicaRangefeed(t *testing.T) {
 
 	// Update the originally incremented key transactionally.
 	ts5 := initTime.Add(0, 5)
+	var txnID5 uuid.UUID
 	if err := store1.DB().Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		txnID5 = txn.ID()
 		if err := txn.SetFixedTimestamp(ctx, ts5); err != nil {
 			return err
 		}
@@ -417,13 +422,13 @@ func TestReplicaRangefeed(t *testing.T) {
 			Key: roachpb.Key("c"), Value: expVal2,
 		}},
 		{Val: &kvpb.RangeFeedValue{
-			Key: roachpb.Key("m"), Value: expVal3,
+			Key: roachpb.Key("m"), Value: expVal3, TxnID: txnID3,
 		}},
 		{Val: &kvpb.RangeFeedValue{
 			Key: roachpb.Key("b"), Value: expVal4, PrevValue: expVal1NoTS,
 		}},
 		{Val: &kvpb.RangeFeedValue{
-			Key: roachpb.Key("b"), Value: expVal5, PrevValue: expVal4NoTS,
+			Key: roachpb.Key("b"), Value: expVal5, PrevValue: expVal4NoTS, TxnID: txnID5,
 		}},
 		{SST: &kvpb.RangeFeedSSTable{
 			// Binary representation of Data may be modified by SST rewrite, see checkForExpEvents.
@@ -15,6 +15,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval"
@@ -577,6 +578,18 @@ func (r *Replica) evaluate1PC(
 				pErr:    kvpb.NewError(err),
 			}
 		}
+		// The stripped batch was evaluated non-transactionally, so its writes
+		// were logged without a transaction. Attribute them to the transaction
+		// so that rangefeed consumers can group them. The logical op log is
+		// replicated, so this waits until all the nodes know the field.
+		if res.LogicalOpLog != nil &&
+			r.ClusterSettings().Version.IsActive(ctx, clusterversion.V23_2_RangefeedWriteValueTxnID) {
+			for i := range res.LogicalOpLog.Ops {
+				if op := res.LogicalOpLog.Ops[i].WriteValue; op != nil {
+					op.TxnID = ba.Txn.ID
+				}
+			}
+		}
 	}
 
 	// Even though the transaction is 1PC and hasn't written any intents, it may
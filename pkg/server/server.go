@@ -652,6 +652,9 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 			jobsprotectedts.GetMetaType(jobsprotectedts.Schedules): jobsprotectedts.MakeStatusFunc(
 				jobRegistry, jobsprotectedts.Schedules,
 			),
+			sql.ReplicationSlotProtectedTimestampMetaType: sql.MakeReplicationSlotStatusFunc(
+				keys.SystemSQLCodec,
+			),
 		},
 	})
 	if err != nil {
@@ -1132,6 +1132,9 @@ func makeTenantSQLServerArgs(
 			jobsprotectedts.GetMetaType(jobsprotectedts.Schedules): jobsprotectedts.MakeStatusFunc(
 				circularJobRegistry, jobsprotectedts.Schedules,
 			),
+			sql.ReplicationSlotProtectedTimestampMetaType: sql.MakeReplicationSlotStatusFunc(
+				keys.MakeSQLCodec(sqlCfg.TenantID),
+			),
 		},
 	})
 	if err != nil {
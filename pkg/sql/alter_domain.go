@@ -0,0 +1,250 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterDomainNode struct {
+	n    *tree.AlterDomain
+	desc *typedesc.Mutable
+}
+
+// alterDomainNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &alterDomainNode{n: nil}
+
+// AlterDomain alters a domain type.
+// Privileges: ownership of the domain.
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	// Resolve the domain.
+	prefix, desc, err := p.ResolveMutableTypeDescriptor(ctx, n.Domain, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%q is not a domain", tree.AsStringWithFQNames(n.Domain, &p.semaCtx.Annotations))
+	}
+
+	// The user needs ownership privilege to alter the domain.
+	if err := p.canModifyType(ctx, desc); err != nil {
+		return nil, err
+	}
+
+	// Renaming, moving and changing the owner of a domain work like they do
+	// for any other type.
+	var typeCmd tree.AlterTypeCmd
+	switch t := n.Cmd.(type) {
+	case *tree.AlterDomainRename:
+		typeCmd = &tree.AlterTypeRename{NewName: t.NewName}
+	case *tree.AlterDomainSetSchema:
+		typeCmd = &tree.AlterTypeSetSchema{Schema: t.Schema}
+	case *tree.AlterDomainOwner:
+		typeCmd = &tree.AlterTypeOwner{Owner: t.Owner}
+	}
+	if typeCmd != nil {
+		return &alterTypeNode{
+			n:      &tree.AlterType{Type: n.Domain, Cmd: typeCmd},
+			prefix: prefix,
+			desc:   desc,
+		}, nil
+	}
+
+	return &alterDomainNode{
+		n:    n,
+		desc: desc,
+	}, nil
+}
+
+func (n *alterDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("domain", n.n.Cmd.TelemetryName()))
+
+	p := params.p
+	domain := n.desc.Domain
+	domainName := tree.AsStringWithFQNames(n.n.Domain, p.Ann())
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault:
+		if t.Default == nil {
+			domain.DefaultExpr = nil
+			break
+		}
+		defaultExpr, err := p.sanitizeDomainDefault(params.ctx, t.Default, domain.BaseType)
+		if err != nil {
+			return err
+		}
+		domain.DefaultExpr = &defaultExpr
+
+	case *tree.AlterDomainSetNotNull:
+		if t.NotNull && !domain.NotNull {
+			if err := p.validateDomainColumns(params.ctx, n.desc, func(col tree.Expr) (tree.Expr, error) {
+				return &tree.IsNullExpr{Expr: col}, nil
+			}, func(tableName, colName string) error {
+				return pgerror.Newf(pgcode.NotNullViolation,
+					"column %q of table %q contains null values", colName, tableName)
+			}); err != nil {
+				return err
+			}
+		}
+		domain.NotNull = t.NotNull
+
+	case *tree.AlterDomainAddConstraint:
+		check, err := p.makeDomainCheck(params.ctx, domain, n.desc.Name, &t.Constraint)
+		if err != nil {
+			return err
+		}
+		checkExpr, err := parser.ParseExpr(check.Expr)
+		if err != nil {
+			return err
+		}
+		if err := p.validateDomainColumns(params.ctx, n.desc, func(col tree.Expr) (tree.Expr, error) {
+			expr, err := eval.ReplaceDomainValue(checkExpr, col)
+			if err != nil {
+				return nil, err
+			}
+			return &tree.NotExpr{Expr: &tree.ParenExpr{Expr: expr}}, nil
+		}, func(tableName, colName string) error {
+			return pgerror.Newf(pgcode.CheckViolation,
+				"column %q of table %q contains values that violate the new constraint",
+				colName, tableName)
+		}); err != nil {
+			return err
+		}
+		domain.Checks = append(domain.Checks, check)
+
+	case *tree.AlterDomainDropConstraint:
+		idx := findDomainCheck(domain, string(t.Constraint))
+		if idx < 0 {
+			if t.IfExists {
+				p.BufferClientNotice(params.ctx, pgnotice.Newf(
+					"constraint %q of domain %q does not exist, skipping", t.Constraint, n.desc.Name))
+				return nil
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+		}
+		domain.Checks = append(domain.Checks[:idx], domain.Checks[idx+1:]...)
+
+	case *tree.AlterDomainRenameConstraint:
+		idx := findDomainCheck(domain, string(t.Constraint))
+		if idx < 0 {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+		}
+		if t.NewName == t.Constraint {
+			return nil
+		}
+		if findDomainCheck(domain, string(t.NewName)) >= 0 {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"constraint %q for domain %q already exists", t.NewName, n.desc.Name)
+		}
+		domain.Checks[idx].Name = string(t.NewName)
+
+	default:
+		return errors.AssertionFailedf("unknown alter domain cmd %s", t)
+	}
+
+	if err := p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, p.Ann()),
+	); err != nil {
+		return err
+	}
+	return p.logEvent(params.ctx,
+		n.desc.ID,
+		&eventpb.AlterType{
+			TypeName: domainName,
+		})
+}
+
+// findDomainCheck returns the index of the CHECK constraint of the domain with
+// the given name, or -1 if there is no such constraint.
+func findDomainCheck(domain *descpb.TypeDescriptor_Domain, name string) int {
+	for i := range domain.Checks {
+		if domain.Checks[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateDomainColumns verifies that the existing values of all the columns
+// of the given domain type satisfy a new constraint of the domain. The
+// violation function builds the condition under which a value of the given
+// column violates the constraint, and violationErr builds the error that is
+// returned if any such value exists.
+func (p *planner) validateDomainColumns(
+	ctx context.Context,
+	desc *typedesc.Mutable,
+	violation func(col tree.Expr) (tree.Expr, error),
+	violationErr func(tableName, colName string) error,
+) error {
+	for _, id := range desc.ReferencingDescriptorIDs {
+		refDesc, err := p.Descriptors().ByID(p.txn).WithoutNonPublic().Get().Desc(ctx, id)
+		if err != nil {
+			return err
+		}
+		tbl, ok := refDesc.(catalog.TableDescriptor)
+		if !ok || !tbl.IsPhysicalTable() {
+			continue
+		}
+		for _, col := range tbl.PublicColumns() {
+			typ := col.GetType()
+			if !typ.IsDomain() || typedesc.UserDefinedTypeOIDToID(typ.UserDefinedOID()) != desc.ID {
+				continue
+			}
+			cond, err := violation(&tree.ColumnItem{ColumnName: col.ColName()})
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE %s LIMIT 1`,
+				tbl.GetID(), tree.AsStringWithFlags(cond, tree.FmtSerializable))
+			row, err := p.InternalSQLTxn().QueryRowEx(
+				ctx, "validate domain constraint", p.txn, sessiondata.RootUserSessionDataOverride, query,
+			)
+			if err != nil {
+				return err
+			}
+			if row != nil {
+				return violationErr(tbl.GetName(), col.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+func (n *alterDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterDomainNode) Close(ctx context.Context)           {}
+func (n *alterDomainNode) ReadingOwnWrites()                   {}
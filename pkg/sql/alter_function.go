@@ -16,15 +16,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
-	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
-	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 )
@@ -67,7 +66,7 @@ func (p *planner) AlterFunctionOptions(
 func (n *alterFunctionOptionsNode) startExec(params runParams) error {
 	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounter("function"))
 
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, false /* isAggregate */)
 	if err != nil {
 		return err
 	}
@@ -156,7 +155,7 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references.
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -186,7 +185,7 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 
 	scDesc.RemoveFunction(fnDesc.GetName(), fnDesc.GetID())
 	fnDesc.SetName(string(n.n.NewName))
-	scDesc.AddFunction(fnDesc.GetName(), toSchemaOverloadSignature(fnDesc))
+	scDesc.AddFunction(fnDesc.GetName(), fnDesc.ToSchemaFunctionSignature())
 	if err := params.p.writeFuncSchemaChange(params.ctx, fnDesc); err != nil {
 		return err
 	}
@@ -227,7 +226,7 @@ func (p *planner) AlterFunctionSetOwner(
 
 func (n *alterFunctionSetOwnerNode) startExec(params runParams) error {
 	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounter("function"))
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -291,7 +290,7 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references.
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -359,7 +358,7 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 	if err := params.p.writeSchemaDesc(params.ctx, sourceSc); err != nil {
 		return err
 	}
-	targetSc.AddFunction(fnDesc.GetName(), toSchemaOverloadSignature(fnDesc))
+	targetSc.AddFunction(fnDesc.GetName(), fnDesc.ToSchemaFunctionSignature())
 	if err := params.p.writeSchemaDesc(params.ctx, targetSc); err != nil {
 		return err
 	}
@@ -399,8 +398,11 @@ func (n *alterFunctionDepExtensionNode) Next(params runParams) (bool, error) { r
 func (n *alterFunctionDepExtensionNode) Values() tree.Datums                 { return tree.Datums{} }
 func (n *alterFunctionDepExtensionNode) Close(ctx context.Context)           {}
 
+// mustGetMutableFunctionForAlter resolves the function to alter. isAggregate
+// is true for ALTER AGGREGATE statements, which can only target aggregates;
+// ALTER FUNCTION statements cannot target aggregates.
 func (p *planner) mustGetMutableFunctionForAlter(
-	ctx context.Context, funcObj *tree.FuncObj,
+	ctx context.Context, funcObj *tree.FuncObj, isAggregate bool,
 ) (*funcdesc.Mutable, error) {
 	ol, err := p.matchUDF(ctx, funcObj, true /*required*/)
 	if err != nil {
@@ -411,18 +413,8 @@ func (p *planner) mustGetMutableFunctionForAlter(
 	if err != nil {
 		return nil, err
 	}
-	return mut, nil
-}
-
-func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor_FunctionSignature {
-	ret := descpb.SchemaDescriptor_FunctionSignature{
-		ID:         fnDesc.GetID(),
-		ArgTypes:   make([]*types.T, len(fnDesc.GetParams())),
-		ReturnType: fnDesc.ReturnType.Type,
-		ReturnSet:  fnDesc.ReturnType.ReturnSet,
+	if (mut.Aggregate != nil) != isAggregate {
+		return nil, sqlerrors.NewWrongAggregateKindError(&funcObj.FuncName, mut.Aggregate != nil)
 	}
-	for i := range fnDesc.Params {
-		ret.ArgTypes[i] = fnDesc.Params[i].Type
-	}
-	return ret
+	return mut, nil
 }
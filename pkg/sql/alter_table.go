@@ -203,6 +203,10 @@ func (n *alterTableNode) startExec(params runParams) error {
 				return pgerror.Newf(pgcode.InvalidColumnDefinition,
 					"multiple primary keys for table %q are not allowed", tn.Object())
 			}
+			if len(n.tableDesc.InheritedBy) > 0 {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot add column to table %q because other tables inherit from it", tn.Object())
+			}
 			var err error
 			params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
 				err = params.p.addColumnImpl(params, n, tn, n.tableDesc, t)
@@ -260,6 +264,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 				if t.ValidationBehavior == tree.ValidationSkip {
 					return sqlerrors.NewUnsupportedUnvalidatedConstraintError(catconstants.ConstraintTypeUnique)
 				}
+				if d.Deferrability.IsDeferrable() {
+					return sqlerrors.NewDeferrableUniqueIndexError()
+				}
 
 				if err := validateColumnsAreAccessible(n.tableDesc, d.Columns); err != nil {
 					return err
@@ -368,6 +375,85 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+			case *tree.ExcludeConstraintTableDef:
+				if t.ValidationBehavior == tree.ValidationSkip {
+					return pgerror.New(pgcode.FeatureNotSupported,
+						"EXCLUDE constraints cannot be marked NOT VALID")
+				}
+				if n.tableDesc.IsPartitionAllBy() {
+					return unimplemented.NewWithIssue(46657,
+						"EXCLUDE constraints on implicitly partitioned tables are not supported")
+				}
+				indexDef, ops, err := makeExclusionConstraintIndexDef(d)
+				if err != nil {
+					return err
+				}
+				if err := validateColumnsAreAccessible(n.tableDesc, indexDef.Columns); err != nil {
+					return err
+				}
+				if err := checkIndexColumns(
+					n.tableDesc, indexDef.Columns, nil /* storing */, indexDef.Inverted,
+					params.ExecCfg().Settings.Version.ActiveVersion(params.ctx),
+				); err != nil {
+					return err
+				}
+				idx := descpb.IndexDescriptor{
+					Name:               string(indexDef.Name),
+					ExclusionOperators: ops,
+					CreatedAtNanos:     params.EvalContext().GetTxnTimestamp(time.Microsecond).UnixNano(),
+				}
+				if indexDef.Inverted {
+					idx.Type = descpb.IndexDescriptor_INVERTED
+				}
+				if err := idx.FillColumns(indexDef.Columns); err != nil {
+					return err
+				}
+				if indexDef.Inverted {
+					column, err := catalog.MustFindColumnByName(n.tableDesc, idx.InvertedColumnName())
+					if err != nil {
+						return err
+					}
+					if err := populateInvertedIndexDescriptor(
+						params.ctx, params.ExecCfg().Settings, column, &idx,
+						indexDef.Columns[len(indexDef.Columns)-1],
+					); err != nil {
+						return err
+					}
+					if err := checkExclusionOverlapColumn(column); err != nil {
+						return err
+					}
+				}
+				if err := checkExclusionPeriodColumns(n.tableDesc, &idx); err != nil {
+					return err
+				}
+				if indexDef.Predicate != nil {
+					tableName, err := params.p.getQualifiedTableName(params.ctx, n.tableDesc)
+					if err != nil {
+						return err
+					}
+					expr, err := schemaexpr.ValidatePartialIndexPredicate(
+						params.ctx, n.tableDesc, indexDef.Predicate, tableName, params.p.SemaCtx(),
+						params.ExecCfg().Settings.Version.ActiveVersion(params.ctx),
+					)
+					if err != nil {
+						return err
+					}
+					idx.Predicate = expr
+				}
+				foundIndex := catalog.FindIndexByName(n.tableDesc, string(d.Name))
+				if foundIndex != nil && foundIndex.Dropped() {
+					return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+						"index %q being dropped, try again later", d.Name)
+				}
+				if err := n.tableDesc.AddIndexMutationMaybeWithTempIndex(
+					&idx, descpb.DescriptorMutation_ADD,
+				); err != nil {
+					return err
+				}
+				version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+				if err := n.tableDesc.AllocateIDs(params.ctx, version); err != nil {
+					return err
+				}
 			case *tree.CheckConstraintTableDef:
 				var err error
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
@@ -515,6 +601,10 @@ func (n *alterTableNode) startExec(params runParams) error {
 				)
 			}
 
+			if err := params.p.checkColumnNotInherited(params.ctx, tableDesc, t.Column, "drop"); err != nil {
+				return err
+			}
+
 			colDroppedViews, err := dropColumnImpl(params, tn, tableDesc, tableDesc.GetRowLevelTTL(), t)
 			if err != nil {
 				return err
@@ -524,6 +614,25 @@ func (n *alterTableNode) startExec(params runParams) error {
 			name := string(t.Constraint)
 			c := catalog.FindConstraintByName(n.tableDesc, name)
 			if c == nil {
+				// Exclusion constraints are backed by non-unique indexes, which are
+				// not constraints as far as the descriptor is concerned. Dropping one
+				// drops its backing index.
+				if idx := catalog.FindNonDropIndex(n.tableDesc, func(idx catalog.Index) bool {
+					return idx.GetName() == name && idx.IsExclusionConstraint()
+				}); idx != nil {
+					jobDesc := fmt.Sprintf(
+						"removing index %q backing exclusion constraint; full details: %s",
+						name, tree.AsStringWithFQNames(n.n, params.Ann()),
+					)
+					if err := params.p.dropIndexByName(
+						params.ctx, tn, tree.UnrestrictedName(name), n.tableDesc, false, /* ifExists */
+						t.DropBehavior, ignoreIdxConstraint, jobDesc,
+					); err != nil {
+						return err
+					}
+					descriptorChanged = true
+					continue
+				}
 				if t.IfExists {
 					continue
 				}
@@ -705,6 +814,18 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = descriptorChanged || changed
 
+		case *tree.AlterTableInherit:
+			if err := params.p.alterTableInherit(params.ctx, n.tableDesc, &t.Parent); err != nil {
+				return err
+			}
+			descriptorChanged = true
+
+		case *tree.AlterTableNoInherit:
+			if err := params.p.alterTableNoInherit(params.ctx, n.tableDesc, &t.Parent); err != nil {
+				return err
+			}
+			descriptorChanged = true
+
 		case *tree.AlterTableInjectStats:
 			sd, ok := n.statsData[i]
 			if !ok {
@@ -934,6 +1055,9 @@ func applyColumnMutation(
 ) error {
 	switch t := mut.(type) {
 	case *tree.AlterTableAlterColumnType:
+		if err := params.p.checkColumnNotInherited(ctx, tableDesc, col.ColName(), "alter"); err != nil {
+			return err
+		}
 		return AlterColumnType(ctx, tableDesc, col, t, params, cmds, tn)
 
 	case *tree.AlterTableSetDefault:
@@ -1444,6 +1568,18 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExcludeConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
+		if name == "" {
+			return false, nil
+		}
+		if idx := catalog.FindIndexByName(tableDesc, string(name)); idx != nil {
+			if d.IfNotExists {
+				return true, nil
+			}
+			return false, pgerror.Newf(pgcode.DuplicateRelation, "constraint with name %q already exists", name)
+		}
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -84,6 +84,13 @@ func (p *planner) AlterType(ctx context.Context, n *tree.AlterType) (planNode, e
 			"%q is a table's record type and cannot be modified",
 			tree.AsStringWithFQNames(n.Type, &p.semaCtx.Annotations),
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return nil, errors.WithHint(
+			pgerror.Newf(
+				pgcode.WrongObjectType,
+				"%q is a domain",
+				tree.AsStringWithFQNames(n.Type, &p.semaCtx.Annotations)),
+			"use ALTER DOMAIN instead")
 	}
 
 	return &alterTypeNode{
@@ -241,7 +248,10 @@ func (p *planner) renameType(ctx context.Context, n *alterTypeNode, newName stri
 		return err
 	}
 
-	// Now rename the array type.
+	// Now rename the array type, if any. Domains have no array type.
+	if n.desc.ArrayTypeID == descpb.InvalidID {
+		return nil
+	}
 	newArrayName, err := findFreeArrayTypeName(
 		ctx,
 		p.txn,
@@ -373,15 +383,17 @@ func (p *planner) setTypeSchema(ctx context.Context, n *alterTypeNode, schema st
 		return err
 	}
 
-	arrayDesc, err := p.Descriptors().MutableByID(p.txn).Type(ctx, n.desc.ArrayTypeID)
-	if err != nil {
-		return err
-	}
+	if n.desc.ArrayTypeID != descpb.InvalidID {
+		arrayDesc, err := p.Descriptors().MutableByID(p.txn).Type(ctx, n.desc.ArrayTypeID)
+		if err != nil {
+			return err
+		}
 
-	if err := p.performRenameTypeDesc(
-		ctx, arrayDesc, arrayDesc.Name, desiredSchemaID, tree.AsStringWithFQNames(n.n, p.Ann()),
-	); err != nil {
-		return err
+		if err := p.performRenameTypeDesc(
+			ctx, arrayDesc, arrayDesc.Name, desiredSchemaID, tree.AsStringWithFQNames(n.n, p.Ann()),
+		); err != nil {
+			return err
+		}
 	}
 
 	newName, err := p.getQualifiedTypeName(ctx, typeDesc)
@@ -405,9 +417,13 @@ func (p *planner) alterTypeOwner(
 	typeDesc := n.desc
 	oldOwner := typeDesc.GetPrivileges().Owner()
 
-	arrayDesc, err := p.Descriptors().MutableByID(p.txn).Type(ctx, typeDesc.ArrayTypeID)
-	if err != nil {
-		return err
+	// Domains have no array type.
+	var arrayDesc *typedesc.Mutable
+	if typeDesc.ArrayTypeID != descpb.InvalidID {
+		var err error
+		if arrayDesc, err = p.Descriptors().MutableByID(p.txn).Type(ctx, typeDesc.ArrayTypeID); err != nil {
+			return err
+		}
 	}
 
 	if err := p.checkCanAlterToNewOwner(ctx, typeDesc, newOwner); err != nil {
@@ -422,7 +438,10 @@ func (p *planner) alterTypeOwner(
 
 	typeNameWithPrefix := tree.MakeTypeNameWithPrefix(n.prefix.NamePrefix(), typeDesc.GetName())
 
-	arrayTypeNameWithPrefix := tree.MakeTypeNameWithPrefix(n.prefix.NamePrefix(), arrayDesc.GetName())
+	var arrayTypeNameWithPrefix tree.TypeName
+	if arrayDesc != nil {
+		arrayTypeNameWithPrefix = tree.MakeTypeNameWithPrefix(n.prefix.NamePrefix(), arrayDesc.GetName())
+	}
 
 	if err := p.setNewTypeOwner(ctx, typeDesc, arrayDesc, typeNameWithPrefix,
 		arrayTypeNameWithPrefix, newOwner); err != nil {
@@ -440,12 +459,16 @@ func (p *planner) alterTypeOwner(
 		return err
 	}
 
+	if arrayDesc == nil {
+		return nil
+	}
 	return p.writeTypeSchemaChange(
 		ctx, arrayDesc, tree.AsStringWithFQNames(n.n, p.Ann()),
 	)
 }
 
-// setNewTypeOwner handles setting a new type owner.
+// setNewTypeOwner handles setting a new type owner. arrayTypeDesc is nil for
+// types without an array type, like domains.
 // Called in ALTER TYPE and REASSIGN OWNED BY.
 func (p *planner) setNewTypeOwner(
 	ctx context.Context,
@@ -458,9 +481,6 @@ func (p *planner) setNewTypeOwner(
 	privs := typeDesc.GetPrivileges()
 	privs.SetOwner(newOwner)
 
-	// Also have to change the owner of the implicit array type.
-	arrayTypeDesc.Privileges.SetOwner(newOwner)
-
 	if err := p.logEvent(ctx,
 		typeDesc.GetID(),
 		&eventpb.AlterTypeOwner{
@@ -469,6 +489,12 @@ func (p *planner) setNewTypeOwner(
 		}); err != nil {
 		return err
 	}
+	if arrayTypeDesc == nil {
+		return nil
+	}
+
+	// Also have to change the owner of the implicit array type.
+	arrayTypeDesc.Privileges.SetOwner(newOwner)
 	return p.logEvent(ctx,
 		arrayTypeDesc.GetID(),
 		&eventpb.AlterTypeOwner{
@@ -1474,7 +1474,7 @@ func (sc *SchemaChanger) validateIndexes(ctx context.Context) error {
 		return err
 	}
 
-	var forwardIndexes, invertedIndexes []catalog.Index
+	var forwardIndexes, invertedIndexes, exclusionIndexes []catalog.Index
 
 	for _, m := range tableDesc.AllMutations() {
 		if sc.mutationID != m.MutationID() {
@@ -1491,6 +1491,9 @@ func (sc *SchemaChanger) validateIndexes(ctx context.Context) error {
 		case descpb.IndexDescriptor_INVERTED:
 			invertedIndexes = append(invertedIndexes, idx)
 		}
+		if idx.IsExclusionConstraint() {
+			exclusionIndexes = append(exclusionIndexes, idx)
+		}
 	}
 	if len(forwardIndexes) == 0 && len(invertedIndexes) == 0 {
 		return nil
@@ -1533,10 +1536,46 @@ func (sc *SchemaChanger) validateIndexes(ctx context.Context) error {
 	if err := grp.Wait(); err != nil {
 		return err
 	}
+	if len(exclusionIndexes) > 0 {
+		if err := validateExclusionConstraints(
+			ctx, tableDesc, exclusionIndexes, runHistoricalTxn,
+		); err != nil {
+			return err
+		}
+	}
 	log.Info(ctx, "finished validating new indexes")
 	return nil
 }
 
+// validateExclusionConstraints verifies that the existing rows of the table
+// satisfy the EXCLUDE constraints backed by the given new indexes. The
+// constraint columns may have been added in the same mutation, so the
+// validation queries run against a copy of the descriptor in which the first
+// mutation is public.
+func validateExclusionConstraints(
+	ctx context.Context,
+	tableDesc catalog.TableDescriptor,
+	indexes []catalog.Index,
+	runHistoricalTxn descs.HistoricalInternalExecTxnRunner,
+) error {
+	desc, err := tableDesc.MakeFirstMutationPublic(
+		catalog.IgnoreConstraints, catalog.IgnorePKSwaps, catalog.RetainDroppingColumns,
+	)
+	if err != nil {
+		return err
+	}
+	return runHistoricalTxn.Exec(ctx, func(ctx context.Context, txn descs.Txn) error {
+		return txn.WithSyntheticDescriptors([]catalog.Descriptor{desc}, func() error {
+			for _, idx := range indexes {
+				if err := validateExclusionConstraint(ctx, desc, idx, txn); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
 // InvalidIndexesError is used to represent indexes that failed revalidation.
 type InvalidIndexesError struct {
 	Indexes []descpb.IndexID
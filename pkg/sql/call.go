@@ -0,0 +1,76 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// callNode represents a CALL statement. It invokes a procedure and returns a
+// single row with the values of the procedure's OUT and INOUT parameters, or no
+// rows if the procedure has no output parameters.
+type callNode struct {
+	proc    tree.TypedExpr
+	columns colinfo.ResultColumns
+
+	row  tree.Datums
+	done bool
+}
+
+func (n *callNode) startExec(params runParams) error {
+	res, err := eval.Expr(params.ctx, params.EvalContext(), n.proc)
+	if err != nil {
+		return err
+	}
+	switch len(n.columns) {
+	case 0:
+		// The procedure has no output parameters, so its return value is VOID
+		// and is discarded.
+		n.done = true
+	case 1:
+		n.row = tree.Datums{res}
+	default:
+		// A procedure with several output parameters returns a tuple of their
+		// values. A NULL result sets all of the parameters to NULL.
+		n.row = make(tree.Datums, len(n.columns))
+		if t, ok := tree.AsDTuple(res); ok {
+			if len(t.D) != len(n.row) {
+				return errors.AssertionFailedf(
+					"expected %d output parameter values, found %d", len(n.row), len(t.D),
+				)
+			}
+			copy(n.row, t.D)
+		} else if res == tree.DNull {
+			for i := range n.row {
+				n.row[i] = tree.DNull
+			}
+		} else {
+			return errors.AssertionFailedf("expected tuple or NULL, found %T", res)
+		}
+	}
+	return nil
+}
+
+func (n *callNode) Next(runParams) (bool, error) {
+	if n.done {
+		return false, nil
+	}
+	n.done = true
+	return true, nil
+}
+
+func (n *callNode) Values() tree.Datums     { return n.row }
+func (*callNode) Close(ctx context.Context) {}
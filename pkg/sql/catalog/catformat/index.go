@@ -95,6 +95,13 @@ func indexForDisplay(
 	}
 
 	f := tree.NewFmtCtx(formatFlags)
+	if index.IsExclusionConstraint() && displayMode == IndexDisplayDefOnly &&
+		!f.HasFlags(tree.FmtPGCatalog) {
+		if err := formatExclusionConstraint(ctx, table, index, f, semaCtx, sessionData); err != nil {
+			return "", err
+		}
+		return f.CloseAndGetString(), nil
+	}
 	if displayMode == IndexDisplayShowCreate {
 		f.WriteString("CREATE ")
 	}
@@ -193,6 +200,64 @@ func indexForDisplay(
 	return f.CloseAndGetString(), nil
 }
 
+// formatExclusionConstraint formats an index backing an exclusion constraint
+// as an EXCLUDE constraint definition within a CREATE TABLE statement. For
+// example:
+//
+//	CONSTRAINT c EXCLUDE USING GIST (a WITH =, b WITH &&) WHERE a > 0
+//	CONSTRAINT c EXCLUDE (a WITH =, (s, e) WITH OVERLAPS)
+func formatExclusionConstraint(
+	ctx context.Context,
+	table catalog.TableDescriptor,
+	index *descpb.IndexDescriptor,
+	f *tree.FmtCtx,
+	semaCtx *tree.SemaContext,
+	sessionData *sessiondata.SessionData,
+) error {
+	f.WriteString("CONSTRAINT ")
+	f.FormatNameP(&index.Name)
+	f.WriteString(" EXCLUDE ")
+	if index.Type == descpb.IndexDescriptor_INVERTED {
+		f.WriteString("USING GIST ")
+	}
+	f.WriteByte('(')
+	startIdx := index.ExplicitColumnStartIdx()
+	for i := startIdx; i < len(index.KeyColumnIDs); i++ {
+		if i > startIdx {
+			f.WriteString(", ")
+		}
+		if index.ExclusionOperators[i] == "OVERLAPS" {
+			// The last two columns are the start and end of a period.
+			f.WriteByte('(')
+			f.FormatNameP(&index.KeyColumnNames[i])
+			f.WriteString(", ")
+			f.FormatNameP(&index.KeyColumnNames[i+1])
+			f.WriteString(") WITH OVERLAPS")
+			break
+		}
+		f.FormatNameP(&index.KeyColumnNames[i])
+		f.WriteString(" WITH ")
+		f.WriteString(index.ExclusionOperators[i])
+	}
+	f.WriteByte(')')
+	if index.IsPartial() {
+		predFmtFlag := tree.FmtParsable
+		if f.HasFlags(tree.FmtMarkRedactionNode) {
+			predFmtFlag |= tree.FmtMarkRedactionNode
+		}
+		if f.HasFlags(tree.FmtOmitNameRedaction) {
+			predFmtFlag |= tree.FmtOmitNameRedaction
+		}
+		pred, err := schemaexpr.FormatExprForDisplay(ctx, table, index.Predicate, semaCtx, sessionData, predFmtFlag)
+		if err != nil {
+			return err
+		}
+		f.WriteString(" WHERE ")
+		f.WriteString(pred)
+	}
+	return nil
+}
+
 // FormatIndexElements formats the key columns an index. If the column is an
 // inaccessible computed column, the computed column expression is formatted.
 // Otherwise, the column name is formatted. Each column is separated by commas
@@ -132,6 +132,22 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 			)
 		}
 
+	case types.JsonpathFamily:
+		if !version.IsActive(ctx, clusterversion.V23_2) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"jsonpath not supported until version 23.2",
+			)
+		}
+
+	case types.PointFamily, types.BoxFamily, types.PathFamily, types.PolygonFamily, types.CircleFamily:
+		if !version.IsActive(ctx, clusterversion.V23_2) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"%s not supported until version 23.2", t.Name(),
+			)
+		}
+
 	default:
 		return pgerror.Newf(pgcode.InvalidTableDefinition,
 			"value type %s cannot be used for table columns", t.String())
@@ -192,7 +208,7 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		}
 	case types.TupleFamily, types.GeographyFamily, types.GeometryFamily:
 		return true
-	case types.TSVectorFamily, types.TSQueryFamily:
+	case types.TSVectorFamily, types.TSQueryFamily, types.JsonpathFamily:
 		return true
 	}
 	return false
@@ -53,7 +53,12 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 	case types.FloatFamily,
 		types.DecimalFamily,
 		types.JsonFamily,
-		types.CollatedStringFamily:
+		types.CollatedStringFamily,
+		types.PointFamily,
+		types.BoxFamily,
+		types.PathFamily,
+		types.PolygonFamily,
+		types.CircleFamily:
 		return true
 	case types.ArrayFamily:
 		return CanHaveCompositeKeyEncoding(typ.ArrayContents())
@@ -86,7 +91,8 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		types.VoidFamily,
 		types.EncodedKeyFamily,
 		types.TSQueryFamily,
-		types.TSVectorFamily:
+		types.TSVectorFamily,
+		types.JsonpathFamily:
 		return false
 	case types.UnknownFamily,
 		types.AnyFamily:
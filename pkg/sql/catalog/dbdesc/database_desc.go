@@ -253,6 +253,20 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	if desc.IsMultiRegion() {
 		desc.validateMultiRegion(vea)
 	}
+
+	desc.validateReplication(vea)
+}
+
+// validateReplication checks that publications are sorted by name, without
+// duplicates.
+func (desc *immutable) validateReplication(vea catalog.ValidationErrorAccumulator) {
+	for i := 1; i < len(desc.Publications); i++ {
+		if desc.Publications[i-1].Name >= desc.Publications[i].Name {
+			vea.Report(errors.AssertionFailedf(
+				"publications of db %d are not sorted by name or have duplicates", desc.GetID()))
+			break
+		}
+	}
 }
 
 // validateMultiRegion performs checks specific to multi-region DBs.
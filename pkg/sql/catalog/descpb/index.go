@@ -29,6 +29,12 @@ func (desc *IndexDescriptor) IsPartial() bool {
 	return desc.Predicate != ""
 }
 
+// IsExclusionConstraint returns true if the index backs an EXCLUDE
+// constraint.
+func (desc *IndexDescriptor) IsExclusionConstraint() bool {
+	return len(desc.ExclusionOperators) > 0
+}
+
 // ExplicitColumnStartIdx returns the start index of any explicit columns.
 func (desc *IndexDescriptor) ExplicitColumnStartIdx() int {
 	start := int(desc.Partitioning.NumImplicitColumns)
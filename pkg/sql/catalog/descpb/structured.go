@@ -230,6 +230,11 @@ func (desc *TableDescriptor) MaterializedView() bool {
 	return desc.IsMaterializedView
 }
 
+// IsForeignTable implements the TableDescriptor interface.
+func (desc *TableDescriptor) IsForeignTable() bool {
+	return desc.ForeignTable != nil
+}
+
 // IsPhysicalTable implements the TableDescriptor interface.
 func (desc *TableDescriptor) IsPhysicalTable() bool {
 	return desc.IsSequence() || (desc.IsTable() && !desc.IsVirtualTable()) || desc.MaterializedView()
@@ -328,6 +328,9 @@ type TableDescriptor interface {
 	IsPhysicalTable() bool
 	// MaterializedView returns whether this TableDescriptor is a MaterializedView.
 	MaterializedView() bool
+	// IsForeignTable returns whether this TableDescriptor is a foreign table,
+	// i.e. a read-only view over files in external storage.
+	IsForeignTable() bool
 	// IsAs returns true if the TableDescriptor describes a Table that was created
 	// with a CREATE TABLE AS command.
 	IsAs() bool
@@ -625,6 +628,11 @@ type TableDescriptor interface {
 	// GetDependsOnFunctions returns the IDs of all functions that this view
 	// depends on. It's only non-nil if IsView is true.
 	GetDependsOnFunctions() []descpb.ID
+	// GetInherits returns the IDs of the tables this table inherits from.
+	GetInherits() []descpb.ID
+	// GetInheritedBy returns the IDs of the tables which inherit from this
+	// table.
+	GetInheritedBy() []descpb.ID
 
 	// AllConstraints returns all constraints in this table, regardless if
 	// they're enforced yet or not. The ordering of the constraints within this
@@ -811,6 +819,11 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain type,
+	// nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -970,6 +983,13 @@ type FunctionDescriptor interface {
 	// GetLanguage returns the language of this function.
 	GetLanguage() catpb.Function_Language
 
+	// GetIsProcedure returns true if the descriptor represents a procedure.
+	GetIsProcedure() bool
+
+	// GetAggregate returns the support functions of a user-defined aggregate,
+	// or nil if the descriptor does not represent an aggregate.
+	GetAggregate() *descpb.FunctionDescriptor_Aggregate
+
 	// ToCreateExpr converts a function descriptor back to a CREATE FUNCTION
 	// statement. This is mainly used for formatting, e.g. SHOW CREATE FUNCTION.
 	ToCreateExpr() (*tree.CreateFunction, error)
@@ -1085,3 +1105,33 @@ func MaybeRequiresHydration(desc Descriptor) (ret bool) {
 	})
 	return ret
 }
+
+// DomainTypeDescriptor is the TypeDescriptor subtype for domain types, which
+// are base types with additional constraints.
+type DomainTypeDescriptor interface {
+	TypeDescriptor
+
+	// GetBaseType returns the type that the domain is based on.
+	GetBaseType() *types.T
+
+	// IsNotNull returns true if the domain does not allow NULL values.
+	IsNotNull() bool
+
+	// HasDefault returns true if the domain has a default expression.
+	HasDefault() bool
+
+	// GetDefaultExpr returns the serialized default expression of the domain,
+	// if it has one.
+	GetDefaultExpr() string
+
+	// NumChecks returns the number of CHECK constraints of the domain.
+	NumChecks() int
+
+	// GetCheckName returns the name of the CHECK constraint at the given
+	// ordinal.
+	GetCheckName(ordinal int) string
+
+	// GetCheckExpr returns the serialized expression of the CHECK constraint at
+	// the given ordinal.
+	GetCheckExpr(ordinal int) string
+}
@@ -1235,6 +1235,11 @@ func (tc *Collection) GetConstraintComment(
 	return tc.GetComment(catalogkeys.MakeCommentKey(uint32(tableID), uint32(constraintID), catalogkeys.ConstraintCommentType))
 }
 
+// GetFunctionComment implements the scdecomp.CommentGetter interface.
+func (tc *Collection) GetFunctionComment(fnID descpb.ID) (comment string, ok bool) {
+	return tc.GetComment(catalogkeys.MakeCommentKey(uint32(fnID), 0, catalogkeys.FunctionCommentType))
+}
+
 // MakeTestCollection makes a Collection that can be used for tests.
 func MakeTestCollection(
 	ctx context.Context, codec keys.SQLCodec, leaseManager LeaseManager,
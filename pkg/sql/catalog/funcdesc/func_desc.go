@@ -173,10 +173,29 @@ func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, dep := range desc.DependedOnBy {
 		ret.Add(dep.ID)
 	}
+	for _, id := range desc.aggregateSupportFunctionIDs() {
+		ret.Add(id)
+	}
 
 	return ret, nil
 }
 
+// aggregateSupportFunctionIDs returns the IDs of the support functions of a
+// user-defined aggregate, or nil if the descriptor is not an aggregate.
+func (desc *immutable) aggregateSupportFunctionIDs() []descpb.ID {
+	if desc.Aggregate == nil {
+		return nil
+	}
+	ret := []descpb.ID{desc.Aggregate.TransitionFunctionID}
+	if desc.Aggregate.FinalFunctionID != descpb.InvalidID {
+		ret = append(ret, desc.Aggregate.FinalFunctionID)
+	}
+	if desc.Aggregate.CombineFunctionID != descpb.InvalidID {
+		ret = append(ret, desc.Aggregate.CombineFunctionID)
+	}
+	return ret
+}
+
 // ValidateSelf implements the catalog.Descriptor interface.
 func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	vea.Report(catalog.ValidateName(desc))
@@ -226,6 +245,18 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			vea.Report(errors.AssertionFailedf("invalid type id %d in depends-on-types references #%d", typeID, i))
 		}
 	}
+
+	if desc.Aggregate != nil {
+		if desc.Aggregate.TransitionFunctionID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("aggregate transition function not set"))
+		}
+		if desc.Aggregate.StateType == nil {
+			vea.Report(errors.AssertionFailedf("aggregate state type not set"))
+		}
+		if desc.IsProcedure {
+			vea.Report(errors.AssertionFailedf("aggregate cannot be a procedure"))
+		}
+	}
 }
 
 // ValidateForwardReferences implements the catalog.Descriptor interface.
@@ -255,6 +286,17 @@ func (desc *immutable) ValidateForwardReferences(
 	for _, typeID := range desc.DependsOnTypes {
 		vea.Report(catalog.ValidateOutboundTypeRef(typeID, vdg))
 	}
+
+	// Check that the support functions of an aggregate exist.
+	for _, fnID := range desc.aggregateSupportFunctionIDs() {
+		fn, err := vdg.GetFunctionDescriptor(fnID)
+		if err != nil {
+			vea.Report(errors.NewAssertionErrorWithWrappedErrf(err, "invalid aggregate support function reference"))
+		} else if fn.Dropped() {
+			vea.Report(errors.AssertionFailedf("aggregate support function %q (%d) is dropped",
+				fn.GetName(), fn.GetID()))
+		}
+	}
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -276,11 +318,53 @@ func (desc *immutable) ValidateBackReferences(
 		vea.Report(catalog.ValidateOutboundTypeRefBackReference(desc.GetID(), typ))
 	}
 
-	// Currently, we don't support cross function references yet.
-	// So here we assume that all inbound references are from tables.
+	// Functions are only referenced by other functions when they are support
+	// functions of user-defined aggregates. All other inbound references are
+	// from tables.
 	for _, by := range desc.DependedOnBy {
+		if fn, err := vdg.GetFunctionDescriptor(by.ID); err == nil {
+			vea.Report(desc.validateInboundAggregateRef(fn))
+			continue
+		}
 		vea.Report(desc.validateInboundTableRef(by, vdg))
 	}
+
+	// Check that the support functions of an aggregate have back-references to
+	// it.
+	for _, fnID := range desc.aggregateSupportFunctionIDs() {
+		fn, err := vdg.GetFunctionDescriptor(fnID)
+		if err != nil {
+			continue
+		}
+		var found bool
+		for _, by := range fn.GetDependedOnBy() {
+			if by.ID == desc.GetID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			vea.Report(errors.AssertionFailedf(
+				"aggregate support function %q (%d) has no corresponding depended-on-by back reference",
+				fn.GetName(), fn.GetID(),
+			))
+		}
+	}
+}
+
+func (desc *immutable) validateInboundAggregateRef(backRefFn catalog.FunctionDescriptor) error {
+	if backRefFn.Dropped() {
+		return errors.AssertionFailedf("depended-on-by function %q (%d) is dropped",
+			backRefFn.GetName(), backRefFn.GetID())
+	}
+	if agg := backRefFn.GetAggregate(); agg != nil {
+		if agg.TransitionFunctionID == desc.GetID() || agg.FinalFunctionID == desc.GetID() ||
+			agg.CombineFunctionID == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depended-on-by function %q (%d) has no corresponding aggregate support function reference",
+		backRefFn.GetName(), backRefFn.GetID())
 }
 
 func (desc *immutable) validateFuncExistsInSchema(scDesc catalog.SchemaDescriptor) error {
@@ -414,6 +498,11 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 			return iterutil.Map(err)
 		}
 	}
+	if desc.Aggregate != nil && catid.IsOIDUserDefined(desc.Aggregate.StateType.Oid()) {
+		if err := fn(desc.Aggregate.StateType); err != nil {
+			return iterutil.Map(err)
+		}
+	}
 	if !catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return nil
 	}
@@ -527,6 +616,16 @@ func (desc *Mutable) SetFuncBody(v string) {
 	desc.FunctionBody = v
 }
 
+// SetIsProcedure sets whether the descriptor represents a procedure.
+func (desc *Mutable) SetIsProcedure(v bool) {
+	desc.IsProcedure = v
+}
+
+// SetAggregate sets the support functions of a user-defined aggregate.
+func (desc *Mutable) SetAggregate(v *descpb.FunctionDescriptor_Aggregate) {
+	desc.Aggregate = v
+}
+
 // SetName sets the function name.
 func (desc *Mutable) SetName(n string) {
 	desc.Name = n
@@ -639,6 +738,20 @@ func (desc *Mutable) maybeRemoveTableReference(id descpb.ID) {
 	desc.DependedOnBy = ret
 }
 
+// AddAggregateReference adds back reference to a user-defined aggregate that
+// uses the function as one of its support functions.
+func (desc *Mutable) AddAggregateReference(id descpb.ID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			return
+		}
+	}
+	desc.DependedOnBy = append(desc.DependedOnBy, descpb.FunctionDescriptor_Reference{ID: id})
+	sort.Slice(desc.DependedOnBy, func(i, j int) bool {
+		return desc.DependedOnBy[i].ID < desc.DependedOnBy[j].ID
+	})
+}
+
 func (desc *Mutable) RemoveReference(id descpb.ID) {
 	var ret []descpb.FunctionDescriptor_Reference
 	for _, ref := range desc.DependedOnBy {
@@ -657,12 +770,37 @@ func (desc *immutable) ToFuncObj() *tree.FuncObj {
 	}
 	for i := range desc.Params {
 		ret.Params[i] = tree.FuncParam{
-			Type: desc.Params[i].Type,
+			Type:  desc.Params[i].Type,
+			Class: toTreeNodeParamClass(desc.Params[i].Class),
 		}
 	}
 	return ret
 }
 
+// ToSchemaFunctionSignature returns the signature of the function which is
+// stored in its parent schema descriptor. Only the input parameters are part
+// of the signature.
+func (desc *immutable) ToSchemaFunctionSignature() descpb.SchemaDescriptor_FunctionSignature {
+	ret := descpb.SchemaDescriptor_FunctionSignature{
+		ID:         desc.GetID(),
+		ArgTypes:   make([]*types.T, 0, len(desc.Params)),
+		ReturnType: desc.ReturnType.Type,
+		ReturnSet:  desc.ReturnType.ReturnSet,
+	}
+	for i := range desc.Params {
+		class := toTreeNodeParamClass(desc.Params[i].Class)
+		if !class.IsInParam() {
+			continue
+		}
+		ret.ArgTypes = append(ret.ArgTypes, desc.Params[i].Type)
+		if class == tree.FunctionParamVariadic {
+			ret.IsVariadic = true
+		}
+	}
+	ret.IsAggregate = desc.Aggregate != nil
+	return ret
+}
+
 // GetObjectType implements the Object interface.
 func (desc *immutable) GetObjectType() privilege.ObjectType {
 	return privilege.Function
@@ -680,23 +818,51 @@ func (desc *immutable) GetLanguage() catpb.Function_Language {
 
 func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	ret = &tree.Overload{
-		Oid:        catid.FuncIDToOID(desc.ID),
-		ReturnType: tree.FixedReturnType(desc.ReturnType.Type),
-		ReturnSet:  desc.ReturnType.ReturnSet,
-		Body:       desc.FunctionBody,
-		IsUDF:      true,
-		Version:    uint64(desc.Version),
-		Language:   desc.getCreateExprLang(),
+		Oid:         catid.FuncIDToOID(desc.ID),
+		ReturnType:  tree.FixedReturnType(desc.ReturnType.Type),
+		ReturnSet:   desc.ReturnType.ReturnSet,
+		Body:        desc.FunctionBody,
+		IsUDF:       true,
+		IsProcedure: desc.IsProcedure,
+		Version:     uint64(desc.Version),
+		Language:    desc.getCreateExprLang(),
 	}
 
 	argTypes := make(tree.ParamTypes, 0, len(desc.Params))
+	var outParamTypes []*types.T
+	var outParamNames []string
+	variadic := false
 	for _, param := range desc.Params {
-		argTypes = append(
-			argTypes,
-			tree.ParamType{Name: param.Name, Typ: param.Type},
-		)
+		class := toTreeNodeParamClass(param.Class)
+		if class.IsInParam() {
+			argTypes = append(
+				argTypes,
+				tree.ParamType{Name: param.Name, Typ: param.Type},
+			)
+		}
+		if class.IsOutParam() {
+			outParamNames = append(outParamNames, tree.OutParamColumnName(param.Name, len(outParamTypes)))
+			outParamTypes = append(outParamTypes, param.Type)
+			inputOrd := -1
+			if class.IsInParam() {
+				inputOrd = len(argTypes) - 1
+			}
+			ret.OutParams = append(ret.OutParams, tree.RoutineOutParam{
+				ParamType: tree.ParamType{Name: param.Name, Typ: param.Type},
+				InputOrd:  inputOrd,
+			})
+		}
+		if class == tree.FunctionParamVariadic {
+			variadic = true
+		}
+	}
+	ret.SetRoutineParams(argTypes, variadic)
+	// A function with several output parameters returns a tuple of their
+	// types, labeled with their names.
+	if len(outParamTypes) > 1 {
+		ret.ReturnType = tree.FixedReturnType(types.MakeLabeledTuple(outParamTypes, outParamNames))
 	}
-	ret.Types = argTypes
+	ret.ReturnsRecordType = types.IsRecordType(desc.ReturnType.Type) && len(outParamTypes) == 0
 	ret.Volatility, err = desc.getOverloadVolatility()
 	if err != nil {
 		return nil, err
@@ -708,6 +874,20 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if desc.ReturnType.ReturnSet {
 		ret.Class = tree.GeneratorClass
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		// Aggregates decide how to handle NULL inputs using the strictness of
+		// their support functions.
+		ret.CalledOnNullInput = true
+		ret.UserDefinedAggregate = &tree.UserDefinedAggregate{
+			TransitionFunc: catid.FuncIDToOID(agg.TransitionFunctionID),
+			StateType:      agg.StateType,
+			InitCond:       agg.InitialCondition,
+		}
+		if agg.FinalFunctionID != descpb.InvalidID {
+			ret.UserDefinedAggregate.FinalFunc = catid.FuncIDToOID(agg.FinalFunctionID)
+		}
+	}
 
 	return ret, nil
 }
@@ -750,7 +930,8 @@ func (desc *immutable) calledOnNullInput() (bool, error) {
 // ToCreateExpr implements the FunctionDescriptor interface.
 func (desc *immutable) ToCreateExpr() (ret *tree.CreateFunction, err error) {
 	ret = &tree.CreateFunction{
-		FuncName: tree.MakeFunctionNameFromPrefix(tree.ObjectNamePrefix{}, tree.Name(desc.Name)),
+		IsProcedure: desc.IsProcedure,
+		FuncName:    tree.MakeFunctionNameFromPrefix(tree.ObjectNamePrefix{}, tree.Name(desc.Name)),
 		ReturnType: tree.FuncReturnType{
 			Type:  desc.ReturnType.Type,
 			IsSet: desc.ReturnType.ReturnSet,
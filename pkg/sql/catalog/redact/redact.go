@@ -46,6 +46,9 @@ func redactTableDescriptor(d *descpb.TableDescriptor) (errs []error) {
 	if d.CreateQuery != "" {
 		handleErr(errors.Wrap(redactQuery(&d.CreateQuery), "create query"))
 	}
+	if d.ForeignTable != nil {
+		redactForeignTable(d.ForeignTable)
+	}
 	handleErr(redactIndex(&d.PrimaryIndex))
 	for i := range d.Indexes {
 		idx := &d.Indexes[i]
@@ -108,6 +111,16 @@ func redactQuery(sql *string) error {
 	return nil
 }
 
+// redactForeignTable redacts the options of a foreign table, whose location
+// may carry credentials. Only the format of the files is kept.
+func redactForeignTable(ft *descpb.TableDescriptor_ForeignTable) {
+	for i := range ft.Options {
+		if opt := &ft.Options[i]; opt.Key != "format" {
+			opt.Value = "_"
+		}
+	}
+}
+
 func redactIndex(idx *descpb.IndexDescriptor) error {
 	redactPartitioning(&idx.Partitioning)
 	return errors.Wrap(redactExprStr(&idx.Predicate), "partial predicate")
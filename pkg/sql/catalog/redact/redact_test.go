@@ -16,6 +16,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/desctestutils"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/redact"
@@ -39,6 +40,9 @@ func TestRedactQueries(t *testing.T) {
 	tdb.Exec(t, "CREATE VIEW view AS SELECT k, v FROM kv WHERE v <> 'constant literal'")
 	tdb.Exec(t, "CREATE TABLE ctas AS SELECT k, v FROM kv WHERE v <> 'constant literal'")
 	tdb.Exec(t, `
+CREATE FOREIGN TABLE ft (k INT)
+OPTIONS (location 's3://bucket/t.csv?AWS_ACCESS_KEY_ID=id&AWS_SECRET_ACCESS_KEY=secret', format 'csv')`)
+	tdb.Exec(t, `
 CREATE FUNCTION f1() RETURNS INT 
 LANGUAGE SQL 
 AS $$ 
@@ -64,6 +68,19 @@ $$`)
 		require.Equal(t, `SELECT k, v FROM defaultdb.public.kv WHERE v != '_'`, mut.CreateQuery)
 	})
 
+	t.Run("foreign table", func(t *testing.T) {
+		ft := desctestutils.TestingGetTableDescriptor(
+			kvDB, keys.SystemSQLCodec, "defaultdb", "public", "ft",
+		)
+		mut := tabledesc.NewBuilder(ft.TableDesc()).BuildCreatedMutableTable()
+		require.Empty(t, redact.Redact(mut.DescriptorProto()))
+		require.Equal(t, []descpb.TableDescriptor_ForeignTable_Option{
+			{Key: "location", Value: "_"},
+			{Key: "format", Value: "csv"},
+		}, mut.ForeignTable.Options)
+		require.NotContains(t, mut.ViewQuery, "s3://")
+	})
+
 	t.Run("create function", func(t *testing.T) {
 		fn := desctestutils.TestingGetFunctionDescriptor(kvDB, keys.SystemSQLCodec, "defaultdb", "public", "f1")
 		mut := funcdesc.NewBuilder(fn.FuncDesc()).BuildCreatedMutableFunction()
@@ -191,6 +191,11 @@ func TableDescs(
 			}
 		}
 
+		// Inheritance references to tables which are not restored are dropped,
+		// leaving the restored tables standalone.
+		table.Inherits = rewriteIDsInSlice(table.Inherits, descriptorRewrites)
+		table.InheritedBy = rewriteIDsInSlice(table.InheritedBy, descriptorRewrites)
+
 		// Rewrite unique_without_index in both `UniqueWithoutIndexConstraints`
 		// and `Mutations` slice.
 		origUniqueWithoutIndexConstraints := table.UniqueWithoutIndexConstraints
@@ -305,6 +310,18 @@ func makeDBNameReplaceFunc(newDB string) func(ctx *tree.FmtCtx, tn *tree.TableNa
 	}
 }
 
+// rewriteIDsInSlice returns the rewritten IDs of ids, dropping the ones
+// without a rewrite.
+func rewriteIDsInSlice(ids []descpb.ID, descriptorRewrites jobspb.DescRewriteMap) []descpb.ID {
+	var ret []descpb.ID
+	for _, id := range ids {
+		if rewrite, ok := descriptorRewrites[id]; ok {
+			ret = append(ret, rewrite.ID)
+		}
+	}
+	return ret
+}
+
 // rewriteViewQueryDBNames rewrites the passed table's ViewQuery replacing all
 // non-empty db qualifiers with `newDB`.
 func rewriteViewQueryDBNames(table *tabledesc.Mutable, newDB string) error {
@@ -571,6 +588,9 @@ func TypeDescs(types []*typedesc.Mutable, descriptorRewrites jobspb.DescRewriteM
 			if err := rewriteIDsInTypesT(typ.Alias, descriptorRewrites); err != nil {
 				return err
 			}
+		case descpb.TypeDescriptor_DOMAIN:
+			// The base type of a domain is never user-defined, and domains have no
+			// array type, so there is nothing to rewrite.
 		default:
 			return errors.AssertionFailedf("unknown type kind %s", t.String())
 		}
@@ -508,6 +508,9 @@ func (desc *immutable) GetResolvedFuncDefinition(
 		if funcDescPb.Signatures[i].ReturnSet {
 			overload.Class = tree.GeneratorClass
 		}
+		if sig.IsAggregate {
+			overload.Class = tree.AggregateClass
+		}
 		paramTypes := make(tree.ParamTypes, 0, len(sig.ArgTypes))
 		for _, paramType := range sig.ArgTypes {
 			paramTypes = append(
@@ -515,7 +518,7 @@ func (desc *immutable) GetResolvedFuncDefinition(
 				tree.ParamType{Typ: paramType},
 			)
 		}
-		overload.Types = paramTypes
+		overload.SetRoutineParams(paramTypes, sig.IsVariadic)
 		prefixedOverload := tree.MakeQualifiedOverload(desc.GetName(), overload)
 		funcDef.Overloads = append(funcDef.Overloads, prefixedOverload)
 	}
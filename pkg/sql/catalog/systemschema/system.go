@@ -992,6 +992,42 @@ CREATE TABLE system.span_stats_tenant_boundaries (
 	CONSTRAINT "primary" PRIMARY KEY (tenant_id),
 	FAMILY "primary" (tenant_id, boundaries)
 );`
+
+	// NotificationsTableSchema stores the asynchronous notifications sent by
+	// NOTIFY and pg_notify(). A row is written by the sending transaction, so
+	// that the notification becomes visible to the listening sessions, which
+	// watch the table with a rangefeed, if and when the transaction commits.
+	// The primary key folds duplicate notifications sent by a transaction, and
+	// seq orders the notifications of a transaction.
+	NotificationsTableSchema = `
+CREATE TABLE system.notifications (
+	txn_id     UUID NOT NULL,
+	channel    STRING NOT NULL,
+	payload    STRING NOT NULL,
+	seq        INT8 NOT NULL,
+	sender_pid INT4 NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (txn_id, channel, payload),
+	FAMILY "primary" (txn_id, channel, payload, seq, sender_pid)
+);`
+
+	// ReplicationSlotsTableSchema stores the logical replication slots of each
+	// database. The positions are LSNs; see the pgrepl/lsn package.
+	// consistent_lsn is the position at which the slot was created, and
+	// confirmed_flush_lsn the position up to which a client confirmed that it
+	// flushed the changes. pts_record_id is the protected timestamp record
+	// which prevents the changes after the confirmed position from being
+	// garbage collected.
+	ReplicationSlotsTableSchema = `
+CREATE TABLE system.replication_slots (
+	database_id         INT8 NOT NULL,
+	slot_name           STRING NOT NULL,
+	plugin              STRING NOT NULL,
+	consistent_lsn      INT8 NOT NULL,
+	confirmed_flush_lsn INT8 NOT NULL,
+	pts_record_id       UUID NULL,
+	CONSTRAINT "primary" PRIMARY KEY (database_id, slot_name),
+	FAMILY "primary" (database_id, slot_name, plugin, consistent_lsn, confirmed_flush_lsn, pts_record_id)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1221,6 +1257,8 @@ func MakeSystemTables() []SystemTable {
 		SystemTenantTasksTable,
 		StatementActivityTable,
 		TransactionActivityTable,
+		NotificationsTable,
+		ReplicationSlotsTable,
 	}
 }
 
@@ -4054,6 +4092,80 @@ var (
 			},
 		),
 	)
+
+	// NotificationsTable is the descriptor for the asynchronous notifications
+	// table.
+	NotificationsTable = makeSystemTable(
+		NotificationsTableSchema,
+		systemTable(
+			catconstants.NotificationsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "txn_id", ID: 1, Type: types.Uuid},
+				{Name: "channel", ID: 2, Type: types.String},
+				{Name: "payload", ID: 3, Type: types.String},
+				{Name: "seq", ID: 4, Type: types.Int},
+				{Name: "sender_pid", ID: 5, Type: types.Int4},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"txn_id", "channel", "payload", "seq", "sender_pid"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           "primary",
+				ID:             1,
+				Unique:         true,
+				KeyColumnNames: []string{"txn_id", "channel", "payload"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2, 3},
+			},
+		),
+	)
+
+	// ReplicationSlotsTable is the descriptor for the logical replication slots
+	// table.
+	ReplicationSlotsTable = makeSystemTable(
+		ReplicationSlotsTableSchema,
+		systemTable(
+			catconstants.ReplicationSlotsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "database_id", ID: 1, Type: types.Int},
+				{Name: "slot_name", ID: 2, Type: types.String},
+				{Name: "plugin", ID: 3, Type: types.String},
+				{Name: "consistent_lsn", ID: 4, Type: types.Int},
+				{Name: "confirmed_flush_lsn", ID: 5, Type: types.Int},
+				{Name: "pts_record_id", ID: 6, Type: types.Uuid, Nullable: true},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"database_id", "slot_name", "plugin", "consistent_lsn", "confirmed_flush_lsn", "pts_record_id"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           "primary",
+				ID:             1,
+				Unique:         true,
+				KeyColumnNames: []string{"database_id", "slot_name"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC,
+					catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2},
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
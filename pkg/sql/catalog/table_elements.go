@@ -176,6 +176,13 @@ type Index interface {
 	IsCreatedExplicitly() bool
 	GetInvisibility() float64
 	GetPredicate() string
+
+	// IsExclusionConstraint returns true iff the index backs an EXCLUDE
+	// constraint, in which case GetExclusionOperator returns the operator for
+	// each key column.
+	IsExclusionConstraint() bool
+	GetExclusionOperator(columnOrdinal int) string
+
 	GetType() descpb.IndexDescriptor_Type
 	GetGeoConfig() geoindex.Config
 	GetVersion() descpb.IndexDescriptorVersion
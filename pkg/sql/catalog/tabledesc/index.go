@@ -117,6 +117,18 @@ func (w index) GetInvisibility() float64 {
 	return w.desc.Invisibility
 }
 
+// IsExclusionConstraint returns true iff the index backs an EXCLUDE
+// constraint.
+func (w index) IsExclusionConstraint() bool {
+	return w.desc.IsExclusionConstraint()
+}
+
+// GetExclusionOperator returns the exclusion operator of the key column at
+// the given ordinal, which must be in [0, NumKeyColumns()).
+func (w index) GetExclusionOperator(columnOrdinal int) string {
+	return w.desc.ExclusionOperators[columnOrdinal]
+}
+
 // IsCreatedExplicitly returns true iff this index was created explicitly, i.e.
 // via 'CREATE INDEX' statement.
 func (w index) IsCreatedExplicitly() bool {
@@ -118,6 +118,13 @@ func (desc *wrapper) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, ref := range desc.GetDependedOnBy() {
 		ids.Add(ref.ID)
 	}
+	// Add inheritance parents and children.
+	for _, id := range desc.GetInherits() {
+		ids.Add(id)
+	}
+	for _, id := range desc.GetInheritedBy() {
+		ids.Add(id)
+	}
 	// Add sequence dependencies
 	return ids, nil
 }
@@ -285,6 +292,14 @@ func (desc *wrapper) ValidateBackReferences(
 		vea.Report(catalog.ValidateOutboundTableRefBackReference(desc.GetID(), ref))
 	}
 
+	// Check that inheritance parents and children reference this table.
+	for _, id := range desc.Inherits {
+		vea.Report(desc.validateInheritanceReference(id, vdg, true /* parent */))
+	}
+	for _, id := range desc.InheritedBy {
+		vea.Report(desc.validateInheritanceReference(id, vdg, false /* parent */))
+	}
+
 	// Check relation back-references to relations and functions.
 	for _, by := range desc.DependedOnBy {
 		depDesc, err := vdg.GetDescriptor(by.ID)
@@ -398,6 +413,32 @@ func (desc *wrapper) validateInboundFunctionRef(
 		backRefFunc.GetName(), by.ID)
 }
 
+// validateInheritanceReference checks that the table with the given ID, which
+// is a parent of this table if parent is set and a child of it otherwise,
+// references this table in return.
+func (desc *wrapper) validateInheritanceReference(
+	id descpb.ID, vdg catalog.ValidationDescGetter, parent bool,
+) error {
+	kind, backRefs := "inherited-by", (catalog.TableDescriptor).GetInherits
+	if parent {
+		kind, backRefs = "inherits", (catalog.TableDescriptor).GetInheritedBy
+	}
+	ref, err := vdg.GetTableDescriptor(id)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err, "invalid %s table reference", kind)
+	}
+	if ref.Dropped() {
+		return errors.AssertionFailedf("%s table %q (%d) is dropped", kind, ref.GetName(), ref.GetID())
+	}
+	for _, backRef := range backRefs(ref) {
+		if backRef == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("missing back reference to %q (%d) in %s table %q (%d)",
+		desc.GetName(), desc.GetID(), kind, ref.GetName(), ref.GetID())
+}
+
 func (desc *wrapper) validateInboundTableRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -1424,6 +1465,11 @@ func (desc *wrapper) validateTableIndexes(columnsByID map[descpb.ColumnID]catalo
 					idx.GetName(), idx.GetPredicate())
 			}
 		}
+		if idx.IsExclusionConstraint() {
+			if err := validateExclusionOperators(idx.IndexDesc()); err != nil {
+				return err
+			}
+		}
 
 		if !idx.IsMutation() {
 			if idx.IndexDesc().UseDeletePreservingEncoding {
@@ -1556,6 +1602,42 @@ func (desc *wrapper) validateTableIndexes(columnsByID map[descpb.ColumnID]catalo
 	return nil
 }
 
+// validateExclusionOperators checks that the exclusion operators of an index
+// backing an EXCLUDE constraint are consistent with the index itself.
+func validateExclusionOperators(idx *descpb.IndexDescriptor) error {
+	if len(idx.ExclusionOperators) != len(idx.KeyColumnIDs) {
+		return errors.Newf("index %q has mismatched key columns (%d) and exclusion operators (%d)",
+			idx.Name, len(idx.KeyColumnIDs), len(idx.ExclusionOperators))
+	}
+	if idx.Unique {
+		return errors.Newf("exclusion constraint index %q cannot be unique", idx.Name)
+	}
+	last := len(idx.ExclusionOperators) - 1
+	for i, op := range idx.ExclusionOperators {
+		switch op {
+		case "=":
+			if i == last && idx.Type == descpb.IndexDescriptor_INVERTED {
+				return errors.Newf("inverted exclusion constraint index %q must use "+
+					"operator && on its last column", idx.Name)
+			}
+		case "&&":
+			if i != last || idx.Type != descpb.IndexDescriptor_INVERTED {
+				return errors.Newf("operator && of exclusion constraint index %q is only "+
+					"allowed on the last column of an inverted index", idx.Name)
+			}
+		case "OVERLAPS":
+			if last < 1 || i < last-1 || idx.Type == descpb.IndexDescriptor_INVERTED ||
+				idx.ExclusionOperators[last] != "OVERLAPS" || idx.ExclusionOperators[last-1] != "OVERLAPS" {
+				return errors.Newf("operator OVERLAPS of exclusion constraint index %q is only "+
+					"allowed on the last two columns of a forward index", idx.Name)
+			}
+		default:
+			return errors.Newf("index %q has invalid exclusion operator %q", idx.Name, op)
+		}
+	}
+	return nil
+}
+
 // ensureShardedIndexNotComputed ensures that the sharded index is not based on a computed
 // column. This is because the sharded index is based on a hidden computed shard column
 // under the hood and we don't support transitively computed columns (computed column A
@@ -138,6 +138,8 @@ var validationMap = []struct {
 			"HistogramBuckets":              {status: thisFieldReferencesNoObjects},
 			"HistogramSamples":              {status: thisFieldReferencesNoObjects},
 			"SchemaLocked":                  {status: thisFieldReferencesNoObjects},
+			"Inherits":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"InheritedBy":                   {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -3076,6 +3078,66 @@ func TestValidateCrossTableReferences(t *testing.T) {
 				},
 			},
 		},
+		// Inheritance
+		{ // 26
+			err: `invalid inherits table reference: referenced table ID 52: referenced descriptor not found`,
+			desc: descpb.TableDescriptor{
+				Name:                    "foo",
+				ID:                      51,
+				ParentID:                1,
+				UnexposedParentSchemaID: keys.PublicSchemaID,
+				Inherits:                []descpb.ID{52},
+			},
+		},
+		{ // 27
+			err: `missing back reference to "foo" (51) in inherits table "baz" (52)`,
+			desc: descpb.TableDescriptor{
+				Name:                    "foo",
+				ID:                      51,
+				ParentID:                1,
+				UnexposedParentSchemaID: keys.PublicSchemaID,
+				Inherits:                []descpb.ID{52},
+			},
+			otherDescs: []descpb.TableDescriptor{{
+				ID:                      52,
+				Name:                    "baz",
+				ParentID:                1,
+				UnexposedParentSchemaID: keys.PublicSchemaID,
+			}},
+		},
+		{ // 28
+			err: `missing back reference to "foo" (51) in inherited-by table "baz" (52)`,
+			desc: descpb.TableDescriptor{
+				Name:                    "foo",
+				ID:                      51,
+				ParentID:                1,
+				UnexposedParentSchemaID: keys.PublicSchemaID,
+				InheritedBy:             []descpb.ID{52},
+			},
+			otherDescs: []descpb.TableDescriptor{{
+				ID:                      52,
+				Name:                    "baz",
+				ParentID:                1,
+				UnexposedParentSchemaID: keys.PublicSchemaID,
+			}},
+		},
+		{ // 29
+			err: ``,
+			desc: descpb.TableDescriptor{
+				Name:                    "foo",
+				ID:                      51,
+				ParentID:                1,
+				UnexposedParentSchemaID: keys.PublicSchemaID,
+				Inherits:                []descpb.ID{52},
+			},
+			otherDescs: []descpb.TableDescriptor{{
+				ID:                      52,
+				Name:                    "baz",
+				ParentID:                1,
+				UnexposedParentSchemaID: keys.PublicSchemaID,
+				InheritedBy:             []descpb.ID{51},
+			}},
+		},
 	}
 
 	for i, test := range tests {
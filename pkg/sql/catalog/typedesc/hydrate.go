@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
@@ -103,22 +104,24 @@ func ensureTypeIsHydratedRecursive(
 			maybeName = &name
 		}
 	}
-	ensureTypeMetadataIsHydrated(&t.TypeMeta, maybeName, maybeDesc)
-	return nil
+	return ensureTypeMetadataIsHydrated(ctx, &t.TypeMeta, maybeName, maybeDesc)
 }
 
 func ensureTypeMetadataIsHydrated(
-	tm *types.UserDefinedTypeMetadata, maybeName *tree.TypeName, maybeDesc catalog.TypeDescriptor,
-) {
+	ctx context.Context,
+	tm *types.UserDefinedTypeMetadata,
+	maybeName *tree.TypeName,
+	maybeDesc catalog.TypeDescriptor,
+) error {
 	var version uint32
 	if maybeDesc != nil {
 		version = uint32(maybeDesc.GetVersion())
 	} else if maybeName == nil {
 		// Return early because there's nothing to hydrate with.
-		return
+		return nil
 	}
 	if *tm != (types.UserDefinedTypeMetadata{}) && tm.Version == version {
-		return
+		return nil
 	}
 	tm.Version = version
 	if maybeName != nil {
@@ -130,11 +133,32 @@ func ensureTypeMetadataIsHydrated(
 		}
 	}
 	if maybeDesc == nil {
-		return
+		return nil
 	}
 	if maybeDesc.AsTableImplicitRecordTypeDescriptor() != nil {
 		tm.ImplicitRecordType = true
-		return
+		return nil
+	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		tm.DomainData = &types.DomainMetadata{
+			NotNull:     d.IsNotNull(),
+			DefaultExpr: d.GetDefaultExpr(),
+			CheckNames:  make([]string, d.NumChecks()),
+			CheckExprs:  make([]string, d.NumChecks()),
+		}
+		for i := 0; i < d.NumChecks(); i++ {
+			tm.DomainData.CheckNames[i] = d.GetCheckName(i)
+			tm.DomainData.CheckExprs[i] = d.GetCheckExpr(i)
+		}
+		typedExprs, err := eval.TypeCheckDomainChecks(ctx, d.GetBaseType(), tm.DomainData.CheckExprs)
+		if err != nil {
+			return errors.Wrapf(err, "type-checking constraints of domain %q", d.GetName())
+		}
+		tm.DomainData.TypedCheckExprs = make([]interface{}, len(typedExprs))
+		for i, typedExpr := range typedExprs {
+			tm.DomainData.TypedCheckExprs[i] = typedExpr
+		}
+		return nil
 	}
 	if e := maybeDesc.AsEnumTypeDescriptor(); e != nil {
 		n := e.NumEnumMembers()
@@ -149,4 +173,5 @@ func ensureTypeMetadataIsHydrated(
 			tm.EnumData.IsMemberReadOnly[i] = e.IsMemberReadOnly(i)
 		}
 	}
+	return nil
 }
@@ -323,6 +323,11 @@ func (v *tableImplicitRecordType) AsCompositeTypeDescriptor() catalog.CompositeT
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (v *tableImplicitRecordType) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (v *tableImplicitRecordType) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
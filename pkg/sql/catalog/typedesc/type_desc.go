@@ -43,6 +43,7 @@ var _ catalog.EnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.RegionEnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.AliasTypeDescriptor = (*immutable)(nil)
 var _ catalog.CompositeTypeDescriptor = (*immutable)(nil)
+var _ catalog.DomainTypeDescriptor = (*immutable)(nil)
 var _ catalog.TypeDescriptor = (*Mutable)(nil)
 var _ catalog.MutableDescriptor = (*Mutable)(nil)
 
@@ -146,7 +147,7 @@ func UserDefinedTypeOIDToID(oid oid.Oid) descpb.ID {
 
 // GetUserDefinedTypeDescID gets the type descriptor ID from a user defined type.
 func GetUserDefinedTypeDescID(t *types.T) descpb.ID {
-	return UserDefinedTypeOIDToID(t.Oid())
+	return UserDefinedTypeOIDToID(t.UserDefinedOID())
 }
 
 // GetUserDefinedArrayTypeDescID gets the ID of the array type descriptor from a user
@@ -508,6 +509,13 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.Domain == nil || desc.Domain.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+		}
+		if desc.ArrayTypeID != descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has array type ID %d", desc.ArrayTypeID))
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -625,6 +633,13 @@ func (desc *immutable) ValidateForwardReferences(
 			}
 		}
 	}
+
+	if d := desc.AsDomainTypeDescriptor(); d != nil && d.GetBaseType().UserDefined() {
+		// Domains over user-defined types are not supported.
+		vea.Report(errors.AssertionFailedf("invalid reference to user-defined type %q from domain type %q",
+			d.GetBaseType().String(), desc.GetName(),
+		))
+	}
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -768,6 +783,8 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(desc.Domain.BaseType, catid.TypeIDToOID(desc.GetID()))
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -916,6 +933,8 @@ func (desc *immutable) GetIDClosure() (ret catalog.DescriptorIDSet) {
 		for _, e := range desc.Composite.Elements {
 			GetTypeDescriptorClosure(e.ElementType).ForEach(ret.Add)
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		// Domains have no array type, and their base types are not user-defined.
 	default:
 		// Otherwise, take the array type ID.
 		ret.Add(desc.ArrayTypeID)
@@ -936,6 +955,10 @@ func GetTypeDescriptorClosure(typ *types.T) (ret catalog.DescriptorIDSet) {
 	}
 	// Collect the type's descriptor ID.
 	ret.Add(GetUserDefinedTypeDescID(typ))
+	if typ.IsDomain() {
+		// Domains have no array type.
+		return ret
+	}
 	switch typ.Family() {
 	case types.ArrayFamily:
 		// If we have an array type, then collect all types in the contents.
@@ -985,6 +1008,14 @@ func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescript
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (desc *immutable) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
@@ -1011,6 +1042,44 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// GetBaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetBaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// IsNotNull implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) IsNotNull() bool {
+	return desc.Domain.NotNull
+}
+
+// HasDefault implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) HasDefault() bool {
+	return desc.Domain.DefaultExpr != nil
+}
+
+// GetDefaultExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetDefaultExpr() string {
+	if desc.Domain.DefaultExpr == nil {
+		return ""
+	}
+	return *desc.Domain.DefaultExpr
+}
+
+// NumChecks implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) NumChecks() int {
+	return len(desc.Domain.Checks)
+}
+
+// GetCheckName implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckName(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Name
+}
+
+// GetCheckExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckExpr(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Expr
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
@@ -277,7 +277,7 @@ func nonMatchingRowQuery(
 func validateForeignKey(
 	ctx context.Context,
 	txn isql.Txn,
-	srcTable *tabledesc.Mutable,
+	srcTable catalog.TableDescriptor,
 	targetTable catalog.TableDescriptor,
 	fk *descpb.ForeignKeyConstraint,
 	indexIDForValidation descpb.IndexID,
@@ -302,7 +302,7 @@ func validateForeignKey(
 
 		log.Infof(ctx, "validating MATCH FULL FK %q (%q [%v] -> %q [%v]) with query %q",
 			fk.Name,
-			srcTable.Name, colNames,
+			srcTable.GetName(), colNames,
 			targetTable.GetName(), referencedColumnNames,
 			query,
 		)
@@ -327,7 +327,7 @@ func validateForeignKey(
 
 	log.Infof(ctx, "validating FK %q (%q [%v] -> %q [%v]) with query %q",
 		fk.Name,
-		srcTable.Name, colNames, targetTable.GetName(), referencedColumnNames,
+		srcTable.GetName(), colNames, targetTable.GetName(), referencedColumnNames,
 		query,
 	)
 
@@ -339,7 +339,7 @@ func validateForeignKey(
 	if values.Len() > 0 {
 		return pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
 			"foreign key violation: %q row %s has no match in %q",
-			srcTable.Name, formatValues(colNames, values), targetTable.GetName()), fk.Name)
+			srcTable.GetName(), formatValues(colNames, values), targetTable.GetName()), fk.Name)
 	}
 	return nil
 }
@@ -695,6 +695,123 @@ func validateUniqueConstraint(
 	return nil
 }
 
+// exclusionViolationQuery returns a query which finds a pair of distinct rows
+// in srcTbl which conflict on the EXCLUDE constraint backed by idx, that is
+// rows which compare true on all of the exclusion operators. The query returns
+// the key values of both rows. The primary index of the table is used so that
+// the query does not read from idx itself.
+func exclusionViolationQuery(
+	srcTbl catalog.TableDescriptor, idx catalog.Index,
+) (sql string, colNames []string, _ error) {
+	keyCols := make([]descpb.ColumnID, idx.NumKeyColumns())
+	for i := range keyCols {
+		keyCols[i] = idx.GetKeyColumnID(i)
+	}
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, keyCols)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := catalog.ColumnNamesForIDs(srcTbl, srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Project the key columns and primary key columns of each side under
+	// positional aliases, since the same column may appear in both.
+	projections := make([]string, 0, len(colNames)+len(pkColNames))
+	conds := make([]string, 0, len(colNames)+1)
+	outCols := make([]string, 0, 2*len(colNames))
+	for i, n := range colNames {
+		projections = append(projections, fmt.Sprintf("%s AS k%d", tree.NameString(n), i))
+		switch op := idx.GetExclusionOperator(i); op {
+		case "OVERLAPS":
+			// The last two columns are the start and end of a period, which are
+			// compared together.
+			if i == len(colNames)-1 {
+				conds = append(conds, fmt.Sprintf("(a.k%[1]d, a.k%[2]d) OVERLAPS (b.k%[1]d, b.k%[2]d)", i-1, i))
+			}
+		default:
+			conds = append(conds, fmt.Sprintf("a.k%[1]d %[2]s b.k%[1]d", i, op))
+		}
+		outCols = append(outCols, fmt.Sprintf("a.k%d", i))
+	}
+	for i := range colNames {
+		outCols = append(outCols, fmt.Sprintf("b.k%d", i))
+	}
+	aPK := make([]string, len(pkColNames))
+	bPK := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		projections = append(projections, fmt.Sprintf("%s AS p%d", tree.NameString(n), i))
+		aPK[i] = fmt.Sprintf("a.p%d", i)
+		bPK[i] = fmt.Sprintf("b.p%d", i)
+	}
+	conds = append(conds, fmt.Sprintf("(%s) != (%s)", strings.Join(aPK, ", "), strings.Join(bPK, ", ")))
+
+	where := ""
+	if idx.IsPartial() {
+		where = fmt.Sprintf(" WHERE (%s)", idx.GetPredicate())
+	}
+	side := fmt.Sprintf(`SELECT %s FROM [%d AS tbl]@[%d]%s`,
+		strings.Join(projections, ", "), srcTbl.GetID(), srcTbl.GetPrimaryIndexID(), where,
+	)
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM (%[2]s) AS a, (%[2]s) AS b WHERE %[3]s LIMIT 1`,
+		strings.Join(outCols, ", "),  // 1
+		side,                         // 2
+		strings.Join(conds, " AND "), // 3
+	)
+	return query, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows of srcTable conflict
+// on the EXCLUDE constraint backed by idx.
+func validateExclusionConstraint(
+	ctx context.Context, srcTable catalog.TableDescriptor, idx catalog.Index, txn isql.Txn,
+) error {
+	query, colNames, err := exclusionViolationQuery(srcTable, idx)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		idx.GetName(),
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	values, err := txn.QueryRowEx(
+		ctx, "validate exclusion constraint", txn.KV(), sessiondata.NodeUserSessionDataOverride, query,
+	)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		left := make([]string, len(colNames))
+		right := make([]string, len(colNames))
+		for i := range colNames {
+			left[i] = values[i].String()
+			right[i] = values[len(colNames)+i].String()
+		}
+		cols := strings.Join(colNames, ", ")
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting keys.
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "could not create exclusion constraint %q", idx.GetName(),
+				),
+				idx.GetName(),
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) conflicts with key (%s)=(%s).",
+				cols, strings.Join(left, ", "), cols, strings.Join(right, ", "),
+			),
+		)
+	}
+	return nil
+}
+
 // ValidateTTLScheduledJobsInCurrentDB is part of the EvalPlanner interface.
 func (p *planner) ValidateTTLScheduledJobsInCurrentDB(ctx context.Context) error {
 	dbName := p.CurrentDatabase()
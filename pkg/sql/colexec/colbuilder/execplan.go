@@ -192,6 +192,9 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 			if agg.FilterColIdx != nil {
 				return errFilteringAggregation
 			}
+			if agg.Func == execinfrapb.UserDefined && len(agg.ColIdx) == 0 {
+				return errZeroArgUserDefinedAggregate
+			}
 		}
 		return nil
 
@@ -261,6 +264,7 @@ var (
 	errWrappedCast                    = errors.New("mismatched types in NewColOperator and unsupported casts")
 	errLookupJoinUnsupported          = errors.New("lookup join reader is unsupported in vectorized")
 	errFilteringAggregation           = errors.New("filtering aggregation not supported")
+	errZeroArgUserDefinedAggregate    = errors.New("user-defined aggregates without arguments are not supported")
 	errNonInnerHashJoinWithOnExpr     = errors.New("can't plan vectorized non-inner hash joins with ON expressions")
 	errNonInnerMergeJoinWithOnExpr    = errors.New("can't plan vectorized non-inner merge joins with ON expressions")
 	errWindowFunctionFilterClause     = errors.New("window functions with FILTER clause are not supported")
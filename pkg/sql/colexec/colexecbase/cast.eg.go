@@ -93,6 +93,11 @@ func GetCastOperator(
 		outputIdx:                resultIdx,
 		evalCtx:                  evalCtx,
 	}
+	if toType.IsDomain() {
+		// Casts to domains must check the constraints of the domain, which is
+		// not supported by the vectorized engine.
+		return nil, errors.Errorf("unhandled cast to domain %s", toType.SQLStringForError())
+	}
 	if fromType.Family() == types.UnknownFamily {
 		return &castOpNullAny{castOpBase: base}, nil
 	}
@@ -640,6 +645,9 @@ func GetCastOperator(
 }
 
 func IsCastSupported(fromType, toType *types.T) bool {
+	if toType.IsDomain() {
+		return false
+	}
 	if fromType.Family() == types.UnknownFamily {
 		return true
 	}
@@ -61,6 +61,9 @@ func (s *ColBatchDirectScan) Init(ctx context.Context) {
 		s.Ctx, s.flowCtx, "colbatchdirectscan", s.processorID,
 		&s.contentionEventsListener, &s.scanStatsListener, &s.tenantConsumptionListener,
 	)
+	if s.maybeSampleSpans(s.Ctx); s.sampledNothing {
+		return
+	}
 	firstBatchLimit := cFetcherFirstBatchLimit(s.limitHint, s.spec.MaxKeysPerRow)
 	err := s.fetcher.SetupNextFetch(
 		ctx, s.Spans, nil /* spanIDs */, s.batchBytesLimit, firstBatchLimit, false, /* spansCanOverlap */
@@ -72,6 +75,9 @@ func (s *ColBatchDirectScan) Init(ctx context.Context) {
 
 // Next implements the colexecop.Operator interface.
 func (s *ColBatchDirectScan) Next() (ret coldata.Batch) {
+	if s.sampledNothing {
+		return coldata.ZeroBatch
+	}
 	var res row.KVBatchFetcherResponse
 	var err error
 	for {
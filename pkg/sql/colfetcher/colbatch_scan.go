@@ -47,6 +47,13 @@ type colBatchScanBase struct {
 	batchBytesLimit        rowinfra.BytesLimit
 	parallelize            bool
 	ignoreMisplannedRanges bool
+	// See TableReaderSpec.SampleSystem.
+	sampleSystem  bool
+	samplePercent float64
+	sampleSeed    int64
+	// sampledNothing is set if none of the ranges were sampled, in which case
+	// there is nothing to scan.
+	sampledNothing bool
 	// tracingSpan is created when the stats should be collected for the query
 	// execution, and it will be finished when closing the operator.
 	tracingSpan               *tracing.Span
@@ -122,6 +129,27 @@ func (s *colBatchScanBase) Release() {
 	colBatchScanBasePool.Put(s)
 }
 
+// maybeSampleSpans replaces the spans to scan with the sample of them
+// requested by TABLESAMPLE SYSTEM, if any.
+func (s *colBatchScanBase) maybeSampleSpans(ctx context.Context) {
+	if !s.sampleSystem {
+		return
+	}
+	spans, err := row.SampleSpans(
+		ctx, s.flowCtx.Cfg.RangeCache, s.Spans, s.samplePercent, s.sampleSeed,
+	)
+	if err != nil {
+		// Range lookups can fail for reasons unrelated to the scan itself (e.g.
+		// context cancellation), so only assertion failures are internal errors.
+		if errors.HasAssertionFailure(err) {
+			colexecerror.InternalError(err)
+		}
+		colexecerror.ExpectedError(err)
+	}
+	s.Spans = spans
+	s.sampledNothing = len(spans) == 0
+}
+
 func (s *colBatchScanBase) close() error {
 	if s.tracingSpan != nil {
 		s.tracingSpan.Finish()
@@ -210,6 +238,9 @@ func newColBatchScanBase(
 		batchBytesLimit:        batchBytesLimit,
 		parallelize:            spec.Parallelize,
 		ignoreMisplannedRanges: flowCtx.Local || spec.IgnoreMisplannedRanges,
+		sampleSystem:           spec.SampleSystem,
+		samplePercent:          spec.SamplePercent,
+		sampleSeed:             spec.SampleSeed,
 	}
 	return s, bsHeader, tableArgs, nil
 }
@@ -241,6 +272,9 @@ func (s *ColBatchScan) Init(ctx context.Context) {
 		s.Ctx, s.flowCtx, "colbatchscan", s.processorID,
 		&s.contentionEventsListener, &s.scanStatsListener, &s.tenantConsumptionListener,
 	)
+	if s.maybeSampleSpans(s.Ctx); s.sampledNothing {
+		return
+	}
 	limitBatches := !s.parallelize
 	if err := s.cf.StartScan(
 		s.Ctx,
@@ -255,6 +289,9 @@ func (s *ColBatchScan) Init(ctx context.Context) {
 
 // Next is part of the colexecop.Operator interface.
 func (s *ColBatchScan) Next() coldata.Batch {
+	if s.sampledNothing {
+		return coldata.ZeroBatch
+	}
 	bat, err := s.cf.NextBatch(s.Ctx)
 	if err != nil {
 		colexecerror.InternalError(err)
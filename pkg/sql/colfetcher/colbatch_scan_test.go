@@ -0,0 +1,92 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangecache"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/stretchr/testify/require"
+)
+
+// failingRangeDescriptorDB is a rangecache.RangeDescriptorDB whose lookups
+// always fail with the given error.
+type failingRangeDescriptorDB struct {
+	err error
+}
+
+var _ rangecache.RangeDescriptorDB = failingRangeDescriptorDB{}
+
+// RangeLookup is part of the rangecache.RangeDescriptorDB interface.
+func (db failingRangeDescriptorDB) RangeLookup(
+	context.Context, roachpb.RKey, rangecache.RangeLookupConsistency, bool,
+) ([]roachpb.RangeDescriptor, []roachpb.RangeDescriptor, error) {
+	return nil, nil, db.err
+}
+
+// FirstRange is part of the rangecache.RangeDescriptorDB interface.
+func (db failingRangeDescriptorDB) FirstRange() (*roachpb.RangeDescriptor, error) {
+	return nil, db.err
+}
+
+// TestSampleSpansRangeLookupError verifies that a failed range lookup while
+// sampling the spans of a TABLESAMPLE SYSTEM scan keeps its error code instead
+// of being reported as an internal error.
+func TestSampleSpansRangeLookupError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	st := cluster.MakeTestingClusterSettings()
+
+	lookupErr := pgerror.New(pgcode.RangeUnavailable, "range unavailable")
+	s := colBatchScanBase{
+		flowCtx: &execinfra.FlowCtx{
+			Cfg: &execinfra.ServerConfig{
+				Settings: st,
+				RangeCache: rangecache.NewRangeCache(
+					st, failingRangeDescriptorDB{err: lookupErr},
+					func() int64 { return 2 << 10 }, stopper,
+				),
+			},
+		},
+		sampleSystem:  true,
+		samplePercent: 50,
+	}
+	s.Spans = roachpb.Spans{{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}}
+
+	err := colexecerror.CatchVectorizedRuntimeError(func() {
+		s.maybeSampleSpans(ctx)
+	})
+	require.Error(t, err)
+	require.Equal(t, pgcode.RangeUnavailable, pgerror.GetPGCode(err))
+
+	// Without a range cache, sampling is an assertion failure, which is still
+	// reported as an internal error.
+	s.flowCtx.Cfg.RangeCache = nil
+	err = colexecerror.CatchVectorizedRuntimeError(func() {
+		s.maybeSampleSpans(ctx)
+	})
+	require.Error(t, err)
+	require.Equal(t, pgcode.Internal, pgerror.GetPGCode(err))
+}
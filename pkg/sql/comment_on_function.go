@@ -0,0 +1,73 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+)
+
+type commentOnFunctionNode struct {
+	n      *tree.CommentOnFunction
+	fnDesc catalog.FunctionDescriptor
+}
+
+// CommentOnFunction adds a comment on a function or procedure.
+// Privileges: ownership of the function or its schema, like for ALTER FUNCTION.
+//
+//	notes: postgres requires ownership of the function.
+func (p *planner) CommentOnFunction(
+	ctx context.Context, n *tree.CommentOnFunction,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		n.StatementTag(),
+	); err != nil {
+		return nil, err
+	}
+
+	fnDesc, err := p.mustGetMutableFunctionForAlter(ctx, &n.Function, false /* isAggregate */)
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.IsProcedure != n.IsProcedure {
+		expected := "function"
+		if n.IsProcedure {
+			expected = "procedure"
+		}
+		return nil, sqlerrors.NewWrongObjectTypeError(&n.Function.FuncName, expected)
+	}
+
+	return &commentOnFunctionNode{
+		n:      n,
+		fnDesc: fnDesc,
+	}, nil
+}
+
+func (n *commentOnFunctionNode) startExec(params runParams) error {
+	if n.n.Comment == nil {
+		return params.p.deleteComment(
+			params.ctx, n.fnDesc.GetID(), 0 /* subID */, catalogkeys.FunctionCommentType,
+		)
+	}
+	return params.p.updateComment(
+		params.ctx, n.fnDesc.GetID(), 0 /* subID */, catalogkeys.FunctionCommentType, *n.n.Comment,
+	)
+}
+
+func (n *commentOnFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnFunctionNode) Close(context.Context)        {}
@@ -364,6 +364,10 @@ type Server struct {
 
 	idxRecommendationsCache *idxrecommendations.IndexRecCache
 
+	// notifications keeps track of the sessions listening for asynchronous
+	// notifications.
+	notifications notificationRegistry
+
 	mu struct {
 		syncutil.Mutex
 		connectionCount     int64
@@ -456,6 +460,7 @@ func NewServer(cfg *ExecutorConfig, pool *mon.BytesMonitor) *Server {
 			cfg.Settings,
 			&serverMetrics.ContentionSubsystemMetrics),
 		idxRecommendationsCache: idxrecommendations.NewIndexRecommendationsCache(cfg.Settings),
+		notifications:           notificationRegistry{cfg: cfg},
 	}
 
 	telemetryLoggingMetrics := &TelemetryLoggingMetrics{}
@@ -606,6 +611,8 @@ func (s *Server) Start(ctx context.Context, stopper *stop.Stopper) {
 
 	s.schemaTelemetryController.Start(ctx, stopper)
 
+	s.notifications.start(stopper)
+
 	// reportedStats is periodically cleared to prevent too many SQL Stats
 	// accumulated in the reporter when the telemetry server fails.
 	// Usually it is telemetry's reporter's job to clear the reporting SQL Stats.
@@ -1140,7 +1147,14 @@ func (s *Server) newConnExecutor(
 		mode:   ex.sessionData().NewSchemaChangerMode,
 		memAcc: ex.sessionMon.MakeBoundAccount(),
 	}
+	ex.extraTxnState.deferredConstraints.mon = ex.sessionMon
 	ex.queryCancelKey = pgwirecancel.MakeBackendKeyData(ex.rng, ex.server.cfg.NodeInfo.NodeID.SQLInstanceID())
+	ex.notifications.registry = &s.notifications
+	ex.notifications.listener.wakeup = func(ctx context.Context) {
+		// Push a Flush so that an idle session delivers the notification without
+		// waiting for the client to send another command.
+		_ = ex.stmtBuf.Push(ctx, Flush{})
+	}
 	ex.mu.ActiveQueries = make(map[clusterunique.ID]*queryMeta)
 	ex.machine = fsm.MakeMachine(TxnStateTransitions, stateNoTxn{}, &ex.state)
 
@@ -1251,6 +1265,7 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	}
 
 	ex.resetExtraTxnState(ctx, txnEvent{eventType: txnEvType})
+	ex.notifications.unlistenAll()
 	if ex.hasCreatedTemporarySchema && !ex.server.cfg.TestingKnobs.DisableTempObjectsCleanupOnSessionExit {
 		err := cleanupSessionTempObjects(
 			ctx,
@@ -1422,6 +1437,10 @@ type connExecutor struct {
 		// validateDbZoneConfig should the DB zone config on commit.
 		validateDbZoneConfig bool
 
+		// deferredConstraints tracks the checks of DEFERRABLE constraints which
+		// have been postponed until the transaction commits.
+		deferredConstraints deferredConstraints
+
 		// txnCounter keeps track of how many SQL txns have been open since
 		// the start of the session. This is used for logging, to
 		// distinguish statements that belong to separate SQL transactions.
@@ -1646,6 +1665,10 @@ type connExecutor struct {
 	// pgwire cancellation protocol.
 	queryCancelKey pgwirecancel.BackendKeyData
 
+	// notifications holds the state of LISTEN, UNLISTEN and NOTIFY for the
+	// session.
+	notifications sessionNotifications
+
 	// activated determines whether activate() was called already.
 	// When this is set, close() must be called to release resources.
 	activated bool
@@ -1979,6 +2002,8 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) {
 		ex.extraTxnState.descCollection.ReleaseAll(ctx)
 		ex.extraTxnState.jobs.reset()
 		ex.extraTxnState.validateDbZoneConfig = false
+		ex.extraTxnState.deferredConstraints.reset(ctx)
+		ex.notifications.resetTxn()
 		ex.extraTxnState.schemaChangerState.memAcc.Clear(ctx)
 		ex.extraTxnState.schemaChangerState = &SchemaChangerState{
 			mode:   ex.sessionData().NewSchemaChangerMode,
@@ -2422,6 +2447,17 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartParse, tcmd.ParseStart)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndParse, tcmd.ParseEnd)
+		replRes := ex.clientComm.CreateStartReplicationResult(tcmd, pos)
+		res = replRes
+		stmtCtx := withStatement(ctx, tcmd.Stmt)
+		if err := ex.execStartReplication(stmtCtx, tcmd); err != nil {
+			res.SetError(err)
+		}
+		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2521,6 +2557,18 @@ func (ex *connExecutor) execCmd() (retErr error) {
 				}
 			}
 		}
+		// Deliver the asynchronous notifications received by the session, if it
+		// is not in a transaction. Dropped notifications are reported as an
+		// error on Sync, which is always followed by ReadyForQuery.
+		switch cmd.(type) {
+		case Sync, Flush:
+			if _, noTxn := ex.machine.CurState().(stateNoTxn); noTxn {
+				_, isSync := cmd.(Sync)
+				if err := ex.notifications.flushQueued(res.(NotificationResult), isSync); err != nil && res.Err() == nil {
+					res.SetError(err)
+				}
+			}
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2676,6 +2724,8 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				// Can't advance.
 			case DrainRequest:
 				canAdvance = true
 			case Flush:
@@ -3208,8 +3258,12 @@ func (ex *connExecutor) execCopyIn(
 }
 
 // stmtHasNoData returns true if describing a result of the input statement
-// type should return NoData.
-func stmtHasNoData(stmt tree.Statement) bool {
+// type should return NoData. cols are the result columns of the statement.
+func stmtHasNoData(stmt tree.Statement, cols colinfo.ResultColumns) bool {
+	if _, ok := stmt.(*tree.Call); ok && len(cols) == 0 {
+		// CALL only returns a row if the procedure has OUT or INOUT parameters.
+		return true
+	}
 	return stmt == nil || stmt.StatementReturnType() != tree.Rows
 }
 
@@ -3543,6 +3597,10 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		indexUsageStats:      ex.indexUsageStats,
 		statementPreparer:    ex,
 	}
+	if ex.executorType != executorTypeInternal {
+		evalCtx.deferredConstraints = &ex.extraTxnState.deferredConstraints
+		evalCtx.notifications = &ex.notifications
+	}
 	evalCtx.copyFromExecCfg(ex.server.cfg)
 }
 
@@ -3897,7 +3955,7 @@ func (ex *connExecutor) initStatementResult(
 	// If the output mode has been modified by instrumentation (e.g. EXPLAIN
 	// ANALYZE), then the columns will be set later.
 	if ex.planner.instrumentation.outputMode == unmodifiedOutput &&
-		ast.StatementReturnType() == tree.Rows {
+		!stmtHasNoData(ast, cols) {
 		// Note that this call is necessary even if cols is nil.
 		res.SetColumns(ctx, cols)
 	}
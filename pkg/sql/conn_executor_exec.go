@@ -1312,6 +1312,14 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	// Run the checks of any DEFERRABLE constraints which were postponed until
+	// commit.
+	if pending := ex.extraTxnState.deferredConstraints.takePending(); len(pending) > 0 {
+		if err := runDeferredChecks(ctx, ex.planner.InternalSQLTxn(), pending); err != nil {
+			return err
+		}
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -1349,6 +1357,7 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 	if err := ex.state.mu.txn.Commit(ctx); err != nil {
 		return err
 	}
+	ex.applyListenOps()
 
 	// Now that we've committed, if we modified any descriptor we need to make sure
 	// to release the leases for them so that the schema change can proceed and
@@ -2666,8 +2675,13 @@ func (ex *connExecutor) addActiveQuery(
 	cancelQuery context.CancelFunc,
 ) {
 	_, hidden := stmt.AST.(tree.HiddenFromShowQueries)
+	// START_REPLICATION runs outside of a transaction.
+	var txnID uuid.UUID
+	if ex.state.mu.txn != nil {
+		txnID = ex.state.mu.txn.ID()
+	}
 	qm := &queryMeta{
-		txnID:         ex.state.mu.txn.ID(),
+		txnID:         txnID,
 		start:         ex.phaseTimes.GetSessionPhaseTime(sessionphase.SessionQueryReceived),
 		stmt:          stmt,
 		placeholders:  placeholders,
@@ -2711,6 +2725,7 @@ func (ex *connExecutor) handleAutoCommit(
 	txn := ex.state.mu.txn
 	if txn.IsCommitted() {
 		log.Event(ctx, "statement execution committed the txn")
+		ex.applyListenOps()
 		return eventTxnFinishCommitted{}, nil
 	}
 
@@ -2733,6 +2748,13 @@ func (ex *connExecutor) handleAutoCommit(
 	return ev, payload
 }
 
+// applyListenOps applies the LISTEN and UNLISTEN statements of the
+// transaction which just committed. The notifications it sent were written to
+// system.notifications as part of the transaction.
+func (ex *connExecutor) applyListenOps() {
+	ex.notifications.commit(ex.server.cfg.Clock.Now())
+}
+
 // incrementStartedStmtCounter increments the appropriate started
 // statement counter for stmt's type.
 func (ex *connExecutor) incrementStartedStmtCounter(ast tree.Statement) {
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -395,6 +396,40 @@ func (c CopyIn) String() string {
 
 var _ Command = CopyIn{}
 
+// StartReplication is the command for the execution of START_REPLICATION,
+// which streams changes to a client of the streaming replication protocol
+// using the Copy-both pgwire subprotocol.
+type StartReplication struct {
+	ParsedStmt statements.Statement[tree.Statement]
+	Stmt       *pgrepltree.StartReplication
+	// Conn is the network connection. Execution of START_REPLICATION takes
+	// control of the connection.
+	Conn pgwirebase.Conn
+	// Done is decremented once the client stops reading from the stream,
+	// signaling that control of the connection is being handed back to the
+	// network routine.
+	Done *sync.WaitGroup
+	// TimeReceived is the time at which the message was received
+	// from the client. Used to compute the service latency.
+	TimeReceived time.Time
+	// ParseStart/ParseEnd are the timing info for parsing of the query. Used for
+	// stats reporting.
+	ParseStart time.Time
+	ParseEnd   time.Time
+}
+
+// command implements the Command interface.
+func (StartReplication) command() string { return "start replication" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (StartReplication) isExtendedProtocolCmd() bool { return false }
+
+func (s StartReplication) String() string {
+	return fmt.Sprintf("StartReplication: %s", s.Stmt)
+}
+
+var _ Command = StartReplication{}
+
 // CopyOut is the command for execution of the Copy-out pgwire subprotocol.
 type CopyOut struct {
 	ParsedStmt statements.Statement[tree.Statement]
@@ -738,6 +773,9 @@ type ClientComm interface {
 	CreateCopyOutResult(cmd CopyOut, pos CmdPos) CopyOutResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
+	// CreateStartReplicationResult creates a result for a StartReplication
+	// command.
+	CreateStartReplicationResult(cmd StartReplication, pos CmdPos) StartReplicationResult
 
 	// LockCommunication ensures that no further results are delivered to the
 	// client. The returned ClientLock can be queried to see what results have
@@ -920,12 +958,22 @@ type DeleteResult interface {
 // flushed.
 type SyncResult interface {
 	ResultBase
+	NotificationResult
 }
 
 // FlushResult represents the result of a Flush command. When this result is
 // closed, all previously accumulated results are flushed to the client.
 type FlushResult interface {
 	ResultBase
+	NotificationResult
+}
+
+// NotificationResult is implemented by the results which can carry
+// asynchronous notifications, produced by NOTIFY, to the client.
+type NotificationResult interface {
+	// BufferNotification buffers a notification to be sent to the client when
+	// the result is closed.
+	BufferNotification(senderPID int32, channel string, payload string)
 }
 
 // DrainResult represents the result of a Drain command. Closing this result
@@ -949,6 +997,12 @@ type CopyInResult interface {
 	SetRowsAffected(ctx context.Context, n int)
 }
 
+// StartReplicationResult represents the result of a StartReplication command.
+// Closing this result sends a CommandComplete message to the client.
+type StartReplicationResult interface {
+	ResultBase
+}
+
 // CopyOutResult represents the result of a CopyOut command. Closing this result
 // sends a CommandComplete message to the client.
 type CopyOutResult interface {
@@ -1079,6 +1133,11 @@ func (r *streamingCommandResult) BufferNotice(notice pgnotice.Notice) {
 	// Unimplemented: the internal executor does not support notices.
 }
 
+// BufferNotification is part of the NotificationResult interface.
+func (r *streamingCommandResult) BufferNotification(int32, string, string) {
+	// Unimplemented: the internal executor does not support notifications.
+}
+
 // ResetStmtType is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) ResetStmtType(stmt tree.Statement) {
 	panic("unimplemented")
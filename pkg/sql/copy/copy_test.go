@@ -624,6 +624,49 @@ func TestTinyRows(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestCopyEncoding tests that COPY transcodes data in the character set of
+// the ENCODING option.
+func TestCopyEncoding(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	params, _ := tests.CreateTestServerParams()
+	s, _, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(ctx)
+
+	url, cleanup := sqlutils.PGUrl(t, s.ServingSQLAddr(), "copytest", url.User(username.RootUser))
+	defer cleanup()
+	var sqlConnCtx clisqlclient.Context
+	conn := sqlConnCtx.MakeSQLConn(io.Discard, io.Discard, url.String())
+
+	err := conn.Exec(ctx, "CREATE TABLE t (k INT PRIMARY KEY, s STRING)")
+	require.NoError(t, err)
+
+	_, err = conn.GetDriverConn().CopyFrom(ctx, strings.NewReader("1\tcaf\xe9\n"),
+		"COPY t FROM STDIN ENCODING 'LATIN1'")
+	require.NoError(t, err)
+	_, err = conn.GetDriverConn().CopyFrom(ctx, strings.NewReader("2,\x80 \x93quoted\x94\n"),
+		"COPY t FROM STDIN WITH (FORMAT CSV, ENCODING 'WIN1252')")
+	require.NoError(t, err)
+
+	for k, expected := range []string{"café", "€ “quoted”"} {
+		row, err := conn.QueryRow(ctx, "SELECT s FROM t WHERE k = $1", k+1)
+		require.NoError(t, err)
+		require.Equal(t, expected, row[0])
+	}
+
+	var buf bytes.Buffer
+	err = conn.GetDriverConn().CopyTo(ctx, &buf, "COPY t TO STDOUT ENCODING 'WIN1252'")
+	require.NoError(t, err)
+	require.Equal(t, "1\tcaf\xe9\n2\t\x80 \x93quoted\x94\n", buf.String())
+
+	buf.Reset()
+	err = conn.GetDriverConn().CopyTo(ctx, &buf, "COPY t TO STDOUT WITH (FORMAT CSV, ENCODING 'LATIN1')")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `character '€' has no representation in encoding "LATIN1"`)
+}
+
 // TODO(cucaroach): get the rand utilities and ParseAndRequire to be friends
 // STRINGS don't roundtrip well, need to figure out proper escaping
 // INET doesn't round trip: ERROR: could not parse "70e5:112:5114:7da5:1" as inet. invalid IP (SQLSTATE 22P02)
@@ -35,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -51,6 +52,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/errors"
 	"github.com/dustin/go-humanize"
+	"golang.org/x/text/encoding/charmap"
 )
 
 // CopyBatchRowSizeDefault is the number of rows we insert in one insert
@@ -91,6 +93,19 @@ type copyOptions struct {
 	delimiter byte
 	format    tree.CopyFormat
 	null      string
+
+	// forceQuoteColumns, forceNotNullColumns and forceNullColumns are the
+	// arguments of the FORCE_QUOTE, FORCE_NOT_NULL and FORCE_NULL options,
+	// which are resolved against the copied columns by resolveCopyColumns.
+	forceQuoteColumns   tree.NameList
+	forceQuoteAll       bool
+	forceNotNullColumns tree.NameList
+	forceNullColumns    tree.NameList
+
+	// encoding is the character set that the data is transcoded from by COPY
+	// FROM and to by COPY TO, or nil if the data is in UTF8.
+	encoding     *charmap.Charmap
+	encodingName string
 }
 
 // TODO(#sql-sessions): copy all pre-condition checks from the PG code
@@ -177,6 +192,39 @@ func processCopyOptions(
 		c.csvEscape, _ = utf8.DecodeRuneInString(s)
 	}
 
+	if opts.ForceQuote != nil || opts.ForceQuoteAll {
+		if c.format != tree.CopyFormatCSV {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_QUOTE only supported with CSV format")
+		}
+		c.forceQuoteColumns = opts.ForceQuote
+		c.forceQuoteAll = opts.ForceQuoteAll
+	}
+	if opts.ForceNotNull != nil {
+		if c.format != tree.CopyFormatCSV {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NOT_NULL only supported with CSV format")
+		}
+		c.forceNotNullColumns = opts.ForceNotNull
+	}
+	if opts.ForceNull != nil {
+		if c.format != tree.CopyFormatCSV {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NULL only supported with CSV format")
+		}
+		c.forceNullColumns = opts.ForceNull
+	}
+
+	if opts.Encoding != nil {
+		name := opts.Encoding.RawString()
+		cm, err := copyEncoding(name)
+		if err != nil {
+			return c, err
+		}
+		// Like in Postgres, the encoding does not apply to the binary format.
+		if c.format != tree.CopyFormatBinary {
+			c.encoding = cm
+			c.encodingName = name
+		}
+	}
+
 	if opts.Destination != nil {
 		return c, pgerror.Newf(
 			pgcode.FeatureNotSupported,
@@ -187,6 +235,50 @@ func processCopyOptions(
 	return c, nil
 }
 
+// copyEncoding returns the character set for the argument of the ENCODING
+// option, or nil if it is UTF8.
+func copyEncoding(name string) (*charmap.Charmap, error) {
+	switch enc := builtins.CleanEncodingName(name); enc {
+	// All the following are aliases to each other in PostgreSQL.
+	case "utf8", "unicode", "cp65001":
+		return nil, nil
+	case "latin1", "iso88591", "cp28591":
+		return charmap.ISO8859_1, nil
+	case "win1252", "windows1252", "cp1252":
+		return charmap.Windows1252, nil
+	default:
+		return nil, unimplemented.NewWithIssueDetailf(41608,
+			"copy encoding "+enc,
+			"encoding %q is not supported for COPY", name)
+	}
+}
+
+// resolveCopyColumns returns, for each of the copied columns, whether it is
+// listed in names, the argument of the given COPY option. It returns nil if
+// names is nil.
+func resolveCopyColumns(
+	option string, names tree.NameList, cols colinfo.ResultColumns,
+) ([]bool, error) {
+	if names == nil {
+		return nil, nil
+	}
+	ret := make([]bool, len(cols))
+	for _, name := range names {
+		found := false
+		for i := range cols {
+			if cols[i].Name == string(name) {
+				ret[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, pgerror.Newf(pgcode.InvalidColumnReference,
+				"%s column %q not referenced by COPY", option, name)
+		}
+	}
+	return ret, nil
+}
+
 // copyMachine supports the Copy-in pgwire subprotocol (COPY...FROM STDIN). The
 // machine is created by the Executor when that statement is executed; from that
 // moment on, the machine takes control of the pgwire connection until
@@ -214,8 +306,13 @@ type copyMachine struct {
 	// NULL. The spec says this is only supported for CSV, and also must specify
 	// which columns it applies to.
 	forceNotNull bool
-	csvInput     bytes.Buffer
-	csvReader    *csv.Reader
+	// csvForceNotNull and csvForceNull are the columns to which the
+	// FORCE_NOT_NULL and FORCE_NULL options apply, or nil if they are not
+	// specified. See isCSVNull.
+	csvForceNotNull []bool
+	csvForceNull    []bool
+	csvInput        bytes.Buffer
+	csvReader       *csv.Reader
 	// buf is used to parse input data into rows. It also accumulates a partial
 	// row between protocol messages.
 	buf bytes.Buffer
@@ -282,6 +379,11 @@ func newCopyMachine(
 	if err != nil {
 		return nil, err
 	}
+	if cOpts.forceQuoteColumns != nil || cOpts.forceQuoteAll {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "FORCE_QUOTE only supported with COPY TO")
+	}
+	// FREEZE is accepted for compatibility but has no effect, since rows are
+	// not subject to vacuuming.
 	c := &copyMachine{
 		conn:        conn,
 		copyFromAST: n,
@@ -332,6 +434,16 @@ func newCopyMachine(
 		typs[i] = col.GetType()
 	}
 	c.typs = typs
+	if c.csvForceNotNull, err = resolveCopyColumns(
+		"FORCE_NOT_NULL", c.forceNotNullColumns, c.resultColumns,
+	); err != nil {
+		return nil, err
+	}
+	if c.csvForceNull, err = resolveCopyColumns(
+		"FORCE_NULL", c.forceNullColumns, c.resultColumns,
+	); err != nil {
+		return nil, err
+	}
 	// If there are no column specifiers and we expect non-visible columns
 	// to have field data then we have to populate the expectedHiddenColumnIdxs
 	// field with the columns indexes we expect to be hidden.
@@ -629,7 +741,11 @@ func (c *copyMachine) processCopyData(ctx context.Context, data string, final bo
 			return err
 		}
 	}
-	c.buf.WriteString(data)
+	if c.encoding != nil {
+		decodeCopyData(&c.buf, data, c.encoding)
+	} else {
+		c.buf.WriteString(data)
+	}
 	var readFn func(ctx context.Context, final bool) (brk bool, err error)
 	switch c.format {
 	case tree.CopyFormatText:
@@ -684,6 +800,19 @@ func (c *copyMachine) processCopyData(ctx context.Context, data string, final bo
 	return nil
 }
 
+// decodeCopyData transcodes data from the single-byte character set cm to
+// UTF8 and appends it to buf. Since every byte is a whole character, data can
+// be split at any point between CopyData messages.
+func decodeCopyData(buf *bytes.Buffer, data string, cm *charmap.Charmap) {
+	for i := 0; i < len(data); i++ {
+		if b := data[i]; b < utf8.RuneSelf {
+			buf.WriteByte(b)
+		} else {
+			buf.WriteRune(cm.DecodeByte(b))
+		}
+	}
+}
+
 func (c *copyMachine) currentBatchSize() int {
 	if c.vectorized {
 		return c.batch.Length()
@@ -826,9 +955,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	if c.vectorized {
 		vh := c.valueHandlers
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.isCSVNull(i, s) {
 				vh[i].Null()
 				continue
 			}
@@ -840,9 +967,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	} else {
 		datums := c.scratchRow
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.isCSVNull(i, s) {
 				datums[i] = tree.DNull
 				continue
 			}
@@ -859,6 +984,20 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	return nil
 }
 
+// isCSVNull returns whether the CSV field s of the i-th column is NULL. Like
+// in Postgres, a field matching the null string is NULL unless it is quoted,
+// or unless the column is listed in FORCE_NOT_NULL. A quoted field matching
+// the null string is NULL if the column is listed in FORCE_NULL.
+func (c *copyMachine) isCSVNull(i int, s csv.Record) bool {
+	if s.Val != c.null {
+		return false
+	}
+	if !s.Quoted {
+		return c.csvForceNotNull == nil || !c.csvForceNotNull[i]
+	}
+	return c.csvForceNull != nil && c.csvForceNull[i]
+}
+
 func (c *copyMachine) readBinaryData(ctx context.Context, final bool) (brk bool, err error) {
 	if len(c.expectedHiddenColumnIdxs) > 0 {
 		return false, pgerror.Newf(
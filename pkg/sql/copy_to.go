@@ -14,9 +14,12 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"unicode/utf8"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -76,6 +79,9 @@ type csvCopyToTranslater struct {
 	b      bytes.Buffer
 	fmtCtx *tree.FmtCtx
 	w      *csv.Writer
+	// forceQuote is the set of columns whose non-NULL values are always
+	// quoted, or nil if FORCE_QUOTE is not specified.
+	forceQuote []bool
 }
 
 func (c *csvCopyToTranslater) translateRow(
@@ -83,7 +89,7 @@ func (c *csvCopyToTranslater) translateRow(
 ) ([]byte, error) {
 	c.b.Reset()
 	c.fmtCtx.Buffer.Reset()
-	for _, d := range datums {
+	for i, d := range datums {
 		if d == tree.DNull {
 			if err := c.w.WriteField(bytes.NewBufferString(c.null)); err != nil {
 				return nil, err
@@ -97,6 +103,10 @@ func (c *csvCopyToTranslater) translateRow(
 			if err := c.w.ForceEmptyField(); err != nil {
 				return nil, err
 			}
+		} else if c.forceQuote != nil && c.forceQuote[i] {
+			if err := c.w.WriteQuotedField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
+				return nil, err
+			}
 		} else {
 			if err := c.w.WriteField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
 				return nil, err
@@ -135,9 +145,19 @@ func runCopyTo(
 	if err != nil {
 		return 0, err
 	}
+	if copyOptions.forceNotNullColumns != nil {
+		return 0, pgerror.New(pgcode.FeatureNotSupported, "FORCE_NOT_NULL only supported with COPY FROM")
+	}
+	if copyOptions.forceNullColumns != nil {
+		return 0, pgerror.New(pgcode.FeatureNotSupported, "FORCE_NULL only supported with COPY FROM")
+	}
+	if cmd.Stmt.Options.Freeze {
+		return 0, pgerror.New(pgcode.FeatureNotSupported, "FREEZE only supported with COPY FROM")
+	}
 
 	wireFormat := pgwirebase.FormatText
 	var t copyToTranslater
+	var csvTranslater *csvCopyToTranslater
 	switch cmd.Stmt.Options.CopyFormat {
 	case tree.CopyFormatBinary:
 		// wireFormat = pgwirebase.FormatBinary
@@ -146,7 +166,7 @@ func runCopyTo(
 			"binary format for COPY TO not implemented",
 		)
 	case tree.CopyFormatCSV:
-		csvTranslater := &csvCopyToTranslater{
+		csvTranslater = &csvCopyToTranslater{
 			copyOptions: copyOptions,
 			fmtCtx:      p.EvalContext().FmtCtx(tree.FmtPgwireText),
 		}
@@ -202,6 +222,19 @@ func runCopyTo(
 		}
 	}()
 
+	if csvTranslater != nil {
+		if copyOptions.forceQuoteAll {
+			csvTranslater.forceQuote = make([]bool, len(it.Types()))
+			for i := range csvTranslater.forceQuote {
+				csvTranslater.forceQuote[i] = true
+			}
+		} else if csvTranslater.forceQuote, err = resolveCopyColumns(
+			"FORCE_QUOTE", copyOptions.forceQuoteColumns, it.Types(),
+		); err != nil {
+			return 0, err
+		}
+	}
+
 	// Send the message describing the columns to the client.
 	if err := res.SendCopyOut(ctx, it.Types(), wireFormat); err != nil {
 		return 0, err
@@ -213,6 +246,9 @@ func runCopyTo(
 		if row, ok, err := t.headerRow(it.Types()); err != nil {
 			return err
 		} else if ok {
+			if row, err = copyOptions.encodeCopyData(row); err != nil {
+				return err
+			}
 			if err := res.SendCopyData(ctx, row, true /* isHeader */); err != nil {
 				return err
 			}
@@ -231,6 +267,9 @@ func runCopyTo(
 			if err != nil {
 				return err
 			}
+			if row, err = copyOptions.encodeCopyData(row); err != nil {
+				return err
+			}
 			if err := res.SendCopyData(ctx, row, false /* isHeader */); err != nil {
 				return err
 			}
@@ -242,6 +281,31 @@ func runCopyTo(
 	return numOutputRows, res.SendCopyDone(ctx)
 }
 
+// encodeCopyData transcodes row from UTF8 to the character set of the
+// ENCODING option, if any. Like in Postgres, an error is returned if a
+// character cannot be represented in that character set.
+func (c *copyOptions) encodeCopyData(row []byte) ([]byte, error) {
+	if c.encoding == nil {
+		return row, nil
+	}
+	ret := make([]byte, 0, len(row))
+	for len(row) > 0 {
+		r, size := utf8.DecodeRune(row)
+		row = row[size:]
+		if r < utf8.RuneSelf {
+			ret = append(ret, byte(r))
+			continue
+		}
+		b, ok := c.encoding.EncodeRune(r)
+		if !ok {
+			return nil, pgerror.Newf(pgcode.UntranslatableCharacter,
+				"character %q has no representation in encoding %q", r, c.encodingName)
+		}
+		ret = append(ret, b)
+	}
+	return ret, nil
+}
+
 var encodeMap = func() map[byte]byte {
 	ret := make(map[byte]byte, len(decodeMap))
 	for k, v := range decodeMap {
@@ -3167,6 +3167,44 @@ func writeCreateTypeDescRow(
 			tree.DNull,                           // enum_members
 		)
 	}
+	if d := typeDesc.AsDomainTypeDescriptor(); d != nil {
+		name, err := tree.NewUnresolvedObjectName(2, [3]string{d.GetName(), sc.GetName()}, 0)
+		if err != nil {
+			return false, err
+		}
+		node := &tree.CreateDomain{
+			Name: name,
+			Type: d.GetBaseType(),
+		}
+		if d.HasDefault() {
+			if node.Default, err = parser.ParseExpr(d.GetDefaultExpr()); err != nil {
+				return false, err
+			}
+		}
+		if d.IsNotNull() {
+			node.Constraints = append(node.Constraints, tree.DomainConstraint{Nullability: tree.NotNull})
+		}
+		for i := 0; i < d.NumChecks(); i++ {
+			check, err := parser.ParseExpr(d.GetCheckExpr(i))
+			if err != nil {
+				return false, err
+			}
+			node.Constraints = append(node.Constraints, tree.DomainConstraint{
+				Name:        tree.Name(d.GetCheckName(i)),
+				Check:       check,
+				Nullability: tree.SilentNull,
+			})
+		}
+		return true, addRow(
+			tree.NewDInt(tree.DInt(db.GetID())),  // database_id
+			tree.NewDString(db.GetName()),        // database_name
+			tree.NewDString(sc.GetName()),        // schema_name
+			tree.NewDInt(tree.DInt(d.GetID())),   // descriptor_id
+			tree.NewDString(d.GetName()),         // descriptor_name
+			tree.NewDString(tree.AsString(node)), // create_statement
+			tree.DNull,                           // enum_members
+		)
+	}
 	return false, errors.AssertionFailedf("unknown type descriptor kind %s", typeDesc.GetKind())
 }
 
@@ -3409,7 +3447,14 @@ CREATE TABLE crdb_internal.create_statements (
 		namePrefix := tree.ObjectNamePrefix{SchemaName: tree.Name(sc.GetName()), ExplicitSchema: true}
 		name := tree.MakeTableNameFromPrefix(namePrefix, tree.Name(table.GetName()))
 		var err error
-		if table.IsView() {
+		if table.IsForeignTable() {
+			descType = typeTable
+			stmt, err = ShowCreateForeignTable(&name, table, false /* redactableValues */)
+			if err != nil {
+				return err
+			}
+			createRedactable, err = ShowCreateForeignTable(&name, table, true /* redactableValues */)
+		} else if table.IsView() {
 			descType = typeView
 			stmt, err = ShowCreateView(
 				ctx, &p.semaCtx, p.SessionData(), &name, table, false, /* redactableValues */
@@ -5542,6 +5587,10 @@ var crdbInternalCatalogCommentsTable = virtualSchemaTable{
 				}
 				classOid = tree.NewDOid(catconstants.PgCatalogConstraintTableID)
 				objOid = getOIDFromConstraint(c, tableDesc.GetParentID(), tableDesc.GetParentSchemaID(), tableDesc)
+
+			case catalogkeys.FunctionCommentType:
+				classOid = tree.NewDOid(catconstants.PgCatalogProcTableID)
+				objOid = tree.NewDOid(catid.FuncIDToOID(descpb.ID(key.ObjectID)))
 			}
 
 			return addRow(
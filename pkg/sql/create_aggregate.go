@@ -0,0 +1,436 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n      *tree.CreateAggregate
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// CreateAggregate creates a user-defined aggregate function.
+func (p *planner) CreateAggregate(
+	ctx context.Context, n *tree.CreateAggregate,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, scDesc, prefix, err := p.ResolveTargetObject(ctx, n.Name.ToUnresolvedObjectName())
+	if err != nil {
+		return nil, err
+	}
+	n.Name.ObjectNamePrefix = prefix
+
+	return &createAggregateNode{
+		n:      n,
+		dbDesc: dbDesc,
+		scDesc: scDesc,
+	}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *createAggregateNode) ReadingOwnWrites() {}
+
+// aggregateSupportFunctions contains the resolved support functions of a
+// user-defined aggregate.
+type aggregateSupportFunctions struct {
+	transition *funcdesc.Mutable
+	final      *funcdesc.Mutable
+	combine    *funcdesc.Mutable
+}
+
+// all returns the support functions that are set.
+func (s *aggregateSupportFunctions) all() []*funcdesc.Mutable {
+	ret := []*funcdesc.Mutable{s.transition}
+	if s.final != nil {
+		ret = append(ret, s.final)
+	}
+	if s.combine != nil {
+		ret = append(ret, s.combine)
+	}
+	return ret
+}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+
+	if n.scDesc.SchemaKind() == catalog.SchemaTemporary {
+		return unimplemented.NewWithIssue(104687, "cannot create UDFs under a temporary schema")
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("aggregate"))
+
+	mutScDesc, err := params.p.descCollection.MutableByName(params.p.Txn()).Schema(params.ctx, n.dbDesc, n.scDesc.GetName())
+	if err != nil {
+		return err
+	}
+
+	var retErr error
+	params.p.runWithOptions(resolveFlags{contextDatabaseID: n.dbDesc.GetID()}, func() {
+		retErr = func() error {
+			aggDesc, isNew, err := n.getMutableAggregateDesc(mutScDesc, params)
+			if err != nil {
+				return err
+			}
+
+			fnName := tree.MakeQualifiedFunctionName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.Name.String())
+			event := eventpb.CreateFunction{
+				FunctionName: fnName.FQString(),
+				IsReplace:    !isNew,
+			}
+			if isNew {
+				err = n.createNewAggregate(aggDesc, mutScDesc, params)
+			} else {
+				err = n.replaceAggregate(aggDesc, params)
+			}
+			if err != nil {
+				return err
+			}
+			return params.p.logEvent(params.ctx, aggDesc.GetID(), &event)
+		}()
+	})
+
+	return retErr
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
+
+func (n *createAggregateNode) createNewAggregate(
+	aggDesc *funcdesc.Mutable, scDesc *schemadesc.Mutable, params runParams,
+) error {
+	if err := n.setAggregateDefinition(aggDesc, params); err != nil {
+		return err
+	}
+
+	if err := params.p.createDescriptor(
+		params.ctx,
+		aggDesc,
+		tree.AsStringWithFQNames(&n.n.Name, params.Ann()),
+	); err != nil {
+		return err
+	}
+
+	scDesc.AddFunction(aggDesc.GetName(), aggDesc.ToSchemaFunctionSignature())
+	return params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Aggregate")
+}
+
+func (n *createAggregateNode) replaceAggregate(aggDesc *funcdesc.Mutable, params runParams) error {
+	// Remove all existing references before adding the new ones.
+	if agg := aggDesc.Aggregate; agg != nil {
+		supportFnIDs := catalog.MakeDescriptorIDSet(agg.TransitionFunctionID, agg.FinalFunctionID, agg.CombineFunctionID)
+		supportFnIDs.Remove(descpb.InvalidID)
+		for _, id := range supportFnIDs.Ordered() {
+			fnDesc, err := params.p.Descriptors().MutableByID(params.p.txn).Function(params.ctx, id)
+			if err != nil {
+				return err
+			}
+			fnDesc.RemoveReference(aggDesc.GetID())
+			if err := params.p.writeFuncSchemaChange(params.ctx, fnDesc); err != nil {
+				return err
+			}
+		}
+	}
+	jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", aggDesc.DependsOnTypes, aggDesc.ID)
+	if err := params.p.removeTypeBackReferences(params.ctx, aggDesc.DependsOnTypes, aggDesc.ID, jobDesc); err != nil {
+		return err
+	}
+	aggDesc.DependsOnTypes = nil
+
+	prevReturnType := aggDesc.ReturnType.Type
+	if err := n.setAggregateDefinition(aggDesc, params); err != nil {
+		return err
+	}
+	if !aggDesc.ReturnType.Type.Equivalent(prevReturnType) {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
+	}
+
+	return params.p.writeFuncSchemaChange(params.ctx, aggDesc)
+}
+
+// getMutableAggregateDesc returns the descriptor of the existing aggregate to
+// replace, or a new descriptor if there is no such aggregate.
+func (n *createAggregateNode) getMutableAggregateDesc(
+	scDesc catalog.SchemaDescriptor, params runParams,
+) (aggDesc *funcdesc.Mutable, isNew bool, err error) {
+	pbParams := make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	for i, param := range n.n.Params {
+		switch param.Class {
+		case tree.FunctionParamIn:
+		case tree.FunctionParamVariadic:
+			if i != len(n.n.Params)-1 {
+				return nil, false, pgerror.New(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be the last input parameter")
+			}
+		default:
+			return nil, false, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregates cannot have output arguments")
+		}
+		pbParams[i], err = makeFunctionParam(params.ctx, param, params.p)
+		if err != nil {
+			return nil, false, err
+		}
+		if param.Class == tree.FunctionParamVariadic && pbParams[i].Type.Family() != types.ArrayFamily {
+			return nil, false, pgerror.New(pgcode.InvalidFunctionDefinition, "VARIADIC parameter must be an array")
+		}
+	}
+
+	// Try to look up an existing function.
+	fnObj := tree.FuncObj{
+		FuncName: n.n.Name,
+		Params:   n.n.Params,
+	}
+	existing, err := params.p.matchUDF(params.ctx, &fnObj, false /* required */)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		if !n.n.Replace {
+			return nil, false, pgerror.Newf(
+				pgcode.DuplicateFunction,
+				"function %q already exists with same argument types",
+				n.n.Name.Object(),
+			)
+		}
+		aggDesc, err = params.p.checkPrivilegesForDropFunction(params.ctx, funcdesc.UserDefinedFunctionOIDToID(existing.Oid))
+		if err != nil {
+			return nil, false, err
+		}
+		if aggDesc.Aggregate == nil {
+			kind := "function"
+			if aggDesc.IsProcedure {
+				kind = "procedure"
+			}
+			return nil, false, errors.WithDetailf(
+				pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+				"%q is a %s.", n.n.Name.Object(), kind,
+			)
+		}
+		return aggDesc, false, nil
+	}
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	privileges, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		scDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Functions,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The return type is set once the support functions are resolved.
+	newDesc := funcdesc.NewMutableFunctionDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		scDesc.GetID(),
+		string(n.n.Name.ObjectName),
+		pbParams,
+		types.Unknown,
+		false, /* returnSet */
+		privileges,
+	)
+	return &newDesc, true, nil
+}
+
+// setAggregateDefinition resolves the support functions of the aggregate,
+// validates them and records them in the aggregate's descriptor along with
+// the back-references to the support functions and the types it uses.
+func (n *createAggregateNode) setAggregateDefinition(
+	aggDesc *funcdesc.Mutable, params runParams,
+) error {
+	opts := n.n.Options
+	stateType, err := tree.ResolveType(params.ctx, opts.StateType, params.p)
+	if err != nil {
+		return err
+	}
+	argTypes := make([]*types.T, len(aggDesc.Params))
+	for i := range aggDesc.Params {
+		argTypes[i] = aggDesc.Params[i].Type
+	}
+
+	var support aggregateSupportFunctions
+	support.transition, err = n.resolveSupportFunction(
+		params, opts.TransitionFunc, append([]*types.T{stateType}, argTypes...), stateType,
+	)
+	if err != nil {
+		return err
+	}
+	returnType := stateType
+	if opts.FinalFunc != nil {
+		support.final, err = n.resolveSupportFunction(
+			params, opts.FinalFunc, []*types.T{stateType}, nil, /* expectedReturnType */
+		)
+		if err != nil {
+			return err
+		}
+		returnType = support.final.ReturnType.Type
+	}
+	if opts.CombineFunc != nil {
+		support.combine, err = n.resolveSupportFunction(
+			params, opts.CombineFunc, []*types.T{stateType, stateType}, stateType,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.InitCond != nil {
+		if _, _, err := tree.ParseAndRequireString(stateType, *opts.InitCond, params.EvalContext()); err != nil {
+			return pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid initial value for aggregate")
+		}
+	} else if support.transition.NullInputBehavior != catpb.Function_CALLED_ON_NULL_INPUT {
+		// A strict transition function is never called with a NULL state, so the
+		// first non-NULL input becomes the initial state. This is only possible
+		// if the input has the same type as the state.
+		if len(argTypes) == 0 || !argTypes[0].Equivalent(stateType) {
+			return pgerror.New(pgcode.InvalidFunctionDefinition,
+				"must not omit initial value when transition function is strict and transition type is not compatible with input type")
+		}
+	}
+
+	agg := &descpb.FunctionDescriptor_Aggregate{
+		TransitionFunctionID: support.transition.GetID(),
+		StateType:            stateType,
+		InitialCondition:     opts.InitCond,
+	}
+	if support.final != nil {
+		agg.FinalFunctionID = support.final.GetID()
+	}
+	if support.combine != nil {
+		agg.CombineFunctionID = support.combine.GetID()
+	}
+	aggDesc.SetAggregate(agg)
+	aggDesc.ReturnType.Type = returnType
+
+	// The aggregate is as volatile as its most volatile support function.
+	vol := catpb.Function_IMMUTABLE
+	for _, desc := range support.all() {
+		if volatilityRank(desc.Volatility) > volatilityRank(vol) {
+			vol = desc.Volatility
+		}
+	}
+	aggDesc.SetVolatility(vol)
+
+	// Add back-references from the support functions to the aggregate.
+	for _, desc := range support.all() {
+		desc.AddAggregateReference(aggDesc.GetID())
+		if err := params.p.writeFuncSchemaChange(params.ctx, desc); err != nil {
+			return err
+		}
+	}
+
+	// Add back-references from the types used by the aggregate.
+	typeIDs := typedesc.GetTypeDescriptorClosure(stateType)
+	typedesc.GetTypeDescriptorClosure(returnType).ForEach(typeIDs.Add)
+	for _, typ := range argTypes {
+		typedesc.GetTypeDescriptorClosure(typ).ForEach(typeIDs.Add)
+	}
+	for _, id := range typeIDs.Ordered() {
+		jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", id, aggDesc.ID)
+		if err := params.p.addTypeBackReference(params.ctx, id, aggDesc.ID, jobDesc); err != nil {
+			return err
+		}
+	}
+	aggDesc.DependsOnTypes = typeIDs.Ordered()
+	return nil
+}
+
+// resolveSupportFunction resolves a support function of the aggregate with the
+// given parameter types. If expectedReturnType is not nil, the function must
+// return that type.
+func (n *createAggregateNode) resolveSupportFunction(
+	params runParams, name *tree.FunctionName, paramTypes []*types.T, expectedReturnType *types.T,
+) (*funcdesc.Mutable, error) {
+	path := params.p.CurrentSearchPath()
+	fnDef, err := params.p.ResolveFunction(
+		params.ctx, name.ToUnresolvedObjectName().ToUnresolvedName(), &path,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload(paramTypes, name.Schema(), &path)
+	if err != nil {
+		return nil, err
+	}
+	if !ol.IsUDF {
+		return nil, unimplemented.Newf("aggregate builtin support function",
+			"aggregate support function %s must be a user-defined function", fnDef.Name)
+	}
+	fnDesc, err := params.p.Descriptors().MutableByID(params.p.txn).Function(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.IsProcedure || fnDesc.Aggregate != nil || fnDesc.ReturnType.ReturnSet {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%s is not a scalar function", fnDef.Name)
+	}
+	if err := params.p.CheckPrivilege(params.ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	if expectedReturnType != nil && !fnDesc.ReturnType.Type.Equivalent(expectedReturnType) {
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of %s is not %s", fnDef.Name, expectedReturnType.SQLString())
+	}
+	return fnDesc, nil
+}
+
+// volatilityRank orders function volatilities from least to most volatile.
+func volatilityRank(v catpb.Function_Volatility) int {
+	switch v {
+	case catpb.Function_IMMUTABLE:
+		return 0
+	case catpb.Function_STABLE:
+		return 1
+	default:
+		return 2
+	}
+}
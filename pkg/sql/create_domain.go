@@ -0,0 +1,226 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+)
+
+type createDomainNode struct {
+	n        *tree.CreateDomain
+	typeName *tree.TypeName
+	dbDesc   catalog.DatabaseDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createDomainNode{n: nil}
+
+// CreateDomain creates a domain type.
+// Privileges: CREATE on the database and the schema.
+func (p *planner) CreateDomain(ctx context.Context, n *tree.CreateDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	// Resolve the desired new type name.
+	typeName, db, err := resolveNewTypeName(p.RunParams(ctx), n.Name)
+	if err != nil {
+		return nil, err
+	}
+	n.Name.SetAnnotation(&p.semaCtx.Annotations, typeName)
+	return &createDomainNode{
+		n:        n,
+		typeName: typeName,
+		dbDesc:   db,
+	}, nil
+}
+
+func (n *createDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("domain"))
+	p := params.p
+
+	schema, err := getCreateTypeParams(params, n.typeName, n.dbDesc)
+	if err != nil {
+		return err
+	}
+
+	baseType, err := tree.ResolveType(params.ctx, n.n.Type, p.semaCtx.GetTypeResolver())
+	if err != nil {
+		return err
+	}
+	if baseType.UserDefined() {
+		return unimplemented.NewWithIssue(27796, "domains over user-defined types are not supported")
+	}
+	if err := colinfo.ValidateColumnDefType(params.ctx, p.ExecCfg().Settings.Version, baseType); err != nil {
+		return err
+	}
+
+	domain := &descpb.TypeDescriptor_Domain{BaseType: baseType}
+	if n.n.Default != nil {
+		defaultExpr, err := p.sanitizeDomainDefault(params.ctx, n.n.Default, baseType)
+		if err != nil {
+			return err
+		}
+		domain.DefaultExpr = &defaultExpr
+	}
+	for i := range n.n.Constraints {
+		c := &n.n.Constraints[i]
+		if c.Check == nil {
+			domain.NotNull = c.Nullability == tree.NotNull
+			continue
+		}
+		check, err := p.makeDomainCheck(params.ctx, domain, n.typeName.Type(), c)
+		if err != nil {
+			return err
+		}
+		domain.Checks = append(domain.Checks, check)
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	// Unlike the other user-defined types, domains have no implicit array type.
+	typeDesc := typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           n.typeName.Type(),
+		ID:             id,
+		ParentID:       n.dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType()
+	if err := p.createDescriptor(params.ctx, typeDesc, n.typeName.String()); err != nil {
+		return err
+	}
+
+	return p.logEvent(params.ctx,
+		typeDesc.GetID(),
+		&eventpb.CreateType{
+			TypeName: n.typeName.FQString(),
+		})
+}
+
+// sanitizeDomainDefault verifies that the given DEFAULT expression of a domain
+// has the base type of the domain, and returns its serialized form.
+func (p *planner) sanitizeDomainDefault(
+	ctx context.Context, expr tree.Expr, baseType *types.T,
+) (string, error) {
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		ctx, expr, baseType, tree.DomainDefaultExpr, &p.semaCtx, volatility.Volatile, true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// makeDomainCheck verifies the given CHECK constraint of a domain and returns
+// its descriptor representation. Unnamed constraints are given a name that
+// does not conflict with the other constraints of the domain.
+func (p *planner) makeDomainCheck(
+	ctx context.Context,
+	domain *descpb.TypeDescriptor_Domain,
+	domainName string,
+	c *tree.DomainConstraint,
+) (descpb.TypeDescriptor_Domain_Check, error) {
+	name := string(c.Name)
+	if name == "" {
+		name = generateDomainCheckName(domain, domainName)
+	} else {
+		for i := range domain.Checks {
+			if domain.Checks[i].Name == name {
+				return descpb.TypeDescriptor_Domain_Check{}, pgerror.Newf(pgcode.DuplicateObject,
+					"constraint %q for domain %q already exists", name, domainName)
+			}
+		}
+	}
+
+	// The check is type checked with VALUE standing for a NULL of the base type.
+	// The check is evaluated without any schema resolution, so it is validated
+	// with a bare semantic context, which only allows builtin functions and
+	// types.
+	expr, err := eval.ReplaceDomainValue(c.Check, tree.NewTypedCastExpr(tree.DNull, domain.BaseType))
+	if err != nil {
+		return descpb.TypeDescriptor_Domain_Check{}, err
+	}
+	semaCtx := tree.MakeSemaContext()
+	if _, err := schemaexpr.SanitizeVarFreeExpr(
+		ctx, expr, types.Bool, tree.DomainCheckExpr, &semaCtx, volatility.Immutable, false, /* allowAssignmentCast */
+	); err != nil {
+		return descpb.TypeDescriptor_Domain_Check{}, err
+	}
+	return descpb.TypeDescriptor_Domain_Check{
+		Name: name,
+		Expr: tree.Serialize(c.Check),
+	}, nil
+}
+
+// generateDomainCheckName returns a name for an unnamed CHECK constraint of a
+// domain, in the form <domain>_check, followed by a number if necessary to
+// avoid a conflict with an existing constraint.
+func generateDomainCheckName(domain *descpb.TypeDescriptor_Domain, domainName string) string {
+	base := fmt.Sprintf("%s_check", domainName)
+	name := base
+	for i := 1; ; i++ {
+		inUse := false
+		for j := range domain.Checks {
+			if domain.Checks[j].Name == name {
+				inUse = true
+				break
+			}
+		}
+		if !inUse {
+			return name
+		}
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+}
+
+func (n *createDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createDomainNode) Close(ctx context.Context)           {}
+func (n *createDomainNode) ReadingOwnWrites()                   {}
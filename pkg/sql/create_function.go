@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
 )
 
 type createFunctionNode struct {
@@ -134,23 +135,7 @@ func (n *createFunctionNode) createNewFunction(
 		return err
 	}
 
-	returnType, err := tree.ResolveType(params.ctx, n.cf.ReturnType.Type, params.p)
-	if err != nil {
-		return err
-	}
-	paramTypes := make([]*types.T, len(udfDesc.Params))
-	for i, param := range udfDesc.Params {
-		paramTypes[i] = param.Type
-	}
-	scDesc.AddFunction(
-		udfDesc.GetName(),
-		descpb.SchemaDescriptor_FunctionSignature{
-			ID:         udfDesc.GetID(),
-			ArgTypes:   paramTypes,
-			ReturnType: returnType,
-			ReturnSet:  udfDesc.ReturnType.ReturnSet,
-		},
-	)
+	scDesc.AddFunction(udfDesc.GetName(), udfDesc.ToSchemaFunctionSignature())
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
 		return err
 	}
@@ -158,10 +143,41 @@ func (n *createFunctionNode) createNewFunction(
 	return nil
 }
 
+var errCannotChangeOutParams = errors.WithDetail(
+	pgerror.New(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function"),
+	"Row type defined by OUT parameters is different.",
+)
+
 func (n *createFunctionNode) replaceFunction(udfDesc *funcdesc.Mutable, params runParams) error {
 	// TODO(chengxiong): add validation that the function is not referenced. This
 	// is needed when we start allowing function references from other objects.
 
+	// Make sure the output parameters, which determine the return type, are not
+	// changed. The existing function has the same input parameters, so the
+	// parameters are the same if their classes and number are the same.
+	if len(n.cf.Params) != len(udfDesc.Params) {
+		return errCannotChangeOutParams
+	}
+	for i := range n.cf.Params {
+		class, err := funcinfo.ParamClassToProto(n.cf.Params[i].Class)
+		if err != nil {
+			return err
+		}
+		if class != udfDesc.Params[i].Class {
+			return errCannotChangeOutParams
+		}
+		if !n.cf.Params[i].Class.IsOutParam() {
+			continue
+		}
+		typ, err := tree.ResolveType(params.ctx, n.cf.Params[i].Type, params.p)
+		if err != nil {
+			return err
+		}
+		if string(n.cf.Params[i].Name) != udfDesc.Params[i].Name || !typ.Equal(udfDesc.Params[i].Type) {
+			return errCannotChangeOutParams
+		}
+	}
+
 	// Make sure parameter names are not changed.
 	for i := range n.cf.Params {
 		if string(n.cf.Params[i].Name) != udfDesc.Params[i].Name {
@@ -271,6 +287,18 @@ func (n *createFunctionNode) getMutableFuncDesc(
 		if err != nil {
 			return nil, false, err
 		}
+		if fnDesc.IsProcedure != n.cf.IsProcedure || fnDesc.Aggregate != nil {
+			kind := "function"
+			if fnDesc.IsProcedure {
+				kind = "procedure"
+			} else if fnDesc.Aggregate != nil {
+				kind = "aggregate function"
+			}
+			return nil, false, errors.WithDetailf(
+				pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+				"%q is a %s.", n.cf.FuncName.Object(), kind,
+			)
+		}
 		return fnDesc, false, nil
 	}
 
@@ -305,6 +333,7 @@ func (n *createFunctionNode) getMutableFuncDesc(
 		n.cf.ReturnType.IsSet,
 		privileges,
 	)
+	newUdfDesc.SetIsProcedure(n.cf.IsProcedure)
 
 	return &newUdfDesc, true, nil
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/storageparam"
 	"github.com/cockroachdb/cockroach/pkg/sql/storageparam/indexstorageparam"
@@ -447,6 +448,108 @@ func newUndefinedOpclassError(opclass tree.Name) error {
 	return pgerror.Newf(pgcode.UndefinedObject, "operator class %q does not exist", opclass)
 }
 
+// makeExclusionConstraintIndexDef returns the definition of the index backing
+// the given EXCLUDE constraint, along with the exclusion operator of each of
+// its columns. Equality may be used on any column, while the overlap operator
+// is only supported on the last column of a constraint using GIST, for which
+// the backing index is an inverted index. A (start, end) period compared with
+// OVERLAPS is only supported as the last element of a constraint which does
+// not use GIST, and is backed by the last two columns of a forward index.
+func makeExclusionConstraintIndexDef(
+	d *tree.ExcludeConstraintTableDef,
+) (tree.IndexTableDef, []string, error) {
+	ops := make([]string, 0, len(d.Elems)+1)
+	for i := range d.Elems {
+		elem := &d.Elems[i]
+		if elem.Expr != nil {
+			return tree.IndexTableDef{}, nil, unimplemented.NewWithIssue(46657,
+				"expressions in EXCLUDE constraints are not supported")
+		}
+		if elem.IsPeriod() {
+			if d.Inverted {
+				return tree.IndexTableDef{}, nil, pgerror.New(pgcode.FeatureNotSupported,
+					"periods are not supported in EXCLUDE USING GIST constraints")
+			}
+			if i != len(d.Elems)-1 {
+				return tree.IndexTableDef{}, nil, pgerror.New(pgcode.FeatureNotSupported,
+					"a period is only supported as the last element of an EXCLUDE constraint")
+			}
+			ops = append(ops, "OVERLAPS", "OVERLAPS")
+			continue
+		}
+		switch elem.Operator.Symbol {
+		case treecmp.EQ:
+			if d.Inverted && i == len(d.Elems)-1 {
+				return tree.IndexTableDef{}, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"the last element of an EXCLUDE USING GIST constraint must use operator %s",
+					treecmp.MakeComparisonOperator(treecmp.Overlaps))
+			}
+		case treecmp.Overlaps:
+			if !d.Inverted {
+				return tree.IndexTableDef{}, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"operator %s in an EXCLUDE constraint requires USING GIST", elem.Operator)
+			}
+			if i != len(d.Elems)-1 {
+				return tree.IndexTableDef{}, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"operator %s is only supported on the last element of an EXCLUDE constraint",
+					elem.Operator)
+			}
+		default:
+			return tree.IndexTableDef{}, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"operator %s is not supported in EXCLUDE constraints", elem.Operator)
+		}
+		ops = append(ops, elem.Operator.Symbol.String())
+	}
+	return tree.IndexTableDef{
+		Name:      d.Name,
+		Columns:   d.Columns(),
+		Inverted:  d.Inverted,
+		Predicate: d.Predicate,
+	}, ops, nil
+}
+
+// checkExclusionPeriodColumns checks that, if the EXCLUDE constraint backed by
+// idx ends with a period, its start and end columns can be compared with
+// OVERLAPS: they must have the same date or time type.
+func checkExclusionPeriodColumns(
+	tableDesc catalog.TableDescriptor, idx *descpb.IndexDescriptor,
+) error {
+	n := len(idx.ExclusionOperators)
+	if n < 2 || idx.ExclusionOperators[n-1] != "OVERLAPS" {
+		return nil
+	}
+	start, err := catalog.MustFindColumnByName(tableDesc, idx.KeyColumnNames[n-2])
+	if err != nil {
+		return err
+	}
+	end, err := catalog.MustFindColumnByName(tableDesc, idx.KeyColumnNames[n-1])
+	if err != nil {
+		return err
+	}
+	switch start.GetType().Family() {
+	case types.DateFamily, types.TimestampFamily, types.TimestampTZFamily,
+		types.TimeFamily, types.TimeTZFamily:
+		if start.GetType().Equivalent(end.GetType()) {
+			return nil
+		}
+	}
+	return pgerror.Newf(pgcode.DatatypeMismatch,
+		"period columns %q and %q must have the same date or time type, found %s and %s",
+		start.GetName(), end.GetName(), start.GetType().SQLString(), end.GetType().SQLString())
+}
+
+// checkExclusionOverlapColumn checks that the && operator is defined for the
+// type of the given column, which is compared with it in an EXCLUDE
+// constraint.
+func checkExclusionOverlapColumn(column catalog.Column) error {
+	switch column.GetType().Family() {
+	case types.ArrayFamily, types.GeometryFamily, types.GeographyFamily:
+		return nil
+	}
+	return pgerror.Newf(pgcode.UndefinedFunction,
+		"operator does not exist: %s && %s", column.GetType().SQLString(), column.GetType().SQLString())
+}
+
 // validateColumnsAreAccessible validates that the columns for an index are
 // accessible. This check must be performed before creating inaccessible columns
 // for expression indexes with replaceExpressionElemsWithVirtualCols.
@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -68,7 +69,9 @@ const nonIndexColHistogramBuckets = 2
 func StubTableStats(
 	desc catalog.TableDescriptor, name string, multiColEnabled bool, defaultHistogramBuckets uint32,
 ) ([]*stats.TableStatisticProto, error) {
-	colStats, err := createStatsDefaultColumns(desc, multiColEnabled, defaultHistogramBuckets)
+	colStats, err := createStatsDefaultColumns(
+		desc, multiColEnabled, false /* multiColHistEnabled */, defaultHistogramBuckets,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -263,6 +266,10 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 
 	var colStats []jobspb.CreateStatsDetails_ColStat
 	var deleteOtherStats bool
+	// Histograms on multiple columns can only be collected once all nodes know
+	// how to build them.
+	multiColHistEnabled := stats.MultiColumnHistogramsClusterMode.Get(&n.p.ExecCfg().Settings.SV) &&
+		n.p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2)
 	if len(n.ColumnNames) == 0 {
 		// Disable multi-column stats and deleting stats
 		// if partial statistics at the extremes are requested.
@@ -274,7 +281,7 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 		}
 		defaultHistogramBuckets := stats.GetDefaultHistogramBuckets(n.p.ExecCfg().SV(), tableDesc)
 		if colStats, err = createStatsDefaultColumns(
-			tableDesc, multiColEnabled, defaultHistogramBuckets,
+			tableDesc, multiColEnabled, multiColHistEnabled, defaultHistogramBuckets,
 		); err != nil {
 			return nil, err
 		}
@@ -304,11 +311,16 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 		_ = stats.MakeSortedColStatKey(columnIDs)
 		isInvIndex := colinfo.ColumnTypeIsOnlyInvertedIndexable(col.GetType())
 		defaultHistogramBuckets := stats.GetDefaultHistogramBuckets(n.p.ExecCfg().SV(), tableDesc)
+		hasHistogram := len(columnIDs) == 1 && !isInvIndex
+		if len(columnIDs) > 1 && multiColHistEnabled && !n.Options.UsingExtremes {
+			hasHistogram = canHaveMultiColumnHistogram(columns)
+		}
 		colStats = []jobspb.CreateStatsDetails_ColStat{{
 			ColumnIDs: columnIDs,
 			// By default, create histograms on all explicitly requested column stats
-			// with a single column that doesn't use an inverted index.
-			HasHistogram:        len(columnIDs) == 1 && !isInvIndex,
+			// that don't use an inverted index. Histograms on multiple columns are
+			// only created if enabled.
+			HasHistogram:        hasHistogram,
 			HistogramMaxBuckets: defaultHistogramBuckets,
 		}}
 		// Make histograms for inverted index column types.
@@ -384,9 +396,13 @@ const maxNonIndexCols = 100
 //
 // In addition to the index columns, we collect stats on up to maxNonIndexCols
 // other columns from the table. We only collect histograms for index columns,
-// plus any other boolean or enum columns (where the "histogram" is tiny).
+// plus any other boolean or enum columns (where the "histogram" is tiny). If
+// multiColHistEnabled is true, we also collect histograms for the multi-column
+// stats on index prefixes, which capture the correlation between the columns.
 func createStatsDefaultColumns(
-	desc catalog.TableDescriptor, multiColEnabled bool, defaultHistogramBuckets uint32,
+	desc catalog.TableDescriptor,
+	multiColEnabled, multiColHistEnabled bool,
+	defaultHistogramBuckets uint32,
 ) ([]jobspb.CreateStatsDetails_ColStat, error) {
 	colStats := make([]jobspb.CreateStatsDetails_ColStat, 0, len(desc.ActiveIndexes()))
 
@@ -471,11 +487,11 @@ func createStatsDefaultColumns(
 		// Remember the requested stats so we don't request duplicates.
 		_ = sortAndTrackStatsExists(colIDs)
 
-		// Only generate non-histogram multi-column stats.
-		colStats = append(colStats, jobspb.CreateStatsDetails_ColStat{
-			ColumnIDs:    colIDs,
-			HasHistogram: false,
-		})
+		colStat, err := makeMultiColumnStat(desc, colIDs, multiColHistEnabled, defaultHistogramBuckets)
+		if err != nil {
+			return nil, err
+		}
+		colStats = append(colStats, colStat)
 	}
 
 	// Add column stats for each secondary index.
@@ -517,11 +533,11 @@ func createStatsDefaultColumns(
 				continue
 			}
 
-			// Only generate non-histogram multi-column stats.
-			colStats = append(colStats, jobspb.CreateStatsDetails_ColStat{
-				ColumnIDs:    colIDs,
-				HasHistogram: false,
-			})
+			colStat, err := makeMultiColumnStat(desc, colIDs, multiColHistEnabled, defaultHistogramBuckets)
+			if err != nil {
+				return nil, err
+			}
+			colStats = append(colStats, colStat)
 		}
 
 		// Add columns referenced in partial index predicate expressions.
@@ -587,6 +603,49 @@ func createStatsDefaultColumns(
 	return colStats, nil
 }
 
+// makeMultiColumnStat returns the column statistic for the given multi-column
+// stat. It has a histogram if multiColHistEnabled is true and the columns
+// support it.
+func makeMultiColumnStat(
+	desc catalog.TableDescriptor,
+	colIDs []descpb.ColumnID,
+	multiColHistEnabled bool,
+	defaultHistogramBuckets uint32,
+) (jobspb.CreateStatsDetails_ColStat, error) {
+	colStat := jobspb.CreateStatsDetails_ColStat{ColumnIDs: colIDs}
+	if !multiColHistEnabled {
+		return colStat, nil
+	}
+	cols := make([]catalog.Column, len(colIDs))
+	for i, colID := range colIDs {
+		col, err := catalog.MustFindColumnByID(desc, colID)
+		if err != nil {
+			return jobspb.CreateStatsDetails_ColStat{}, err
+		}
+		cols[i] = col
+	}
+	if canHaveMultiColumnHistogram(cols) {
+		colStat.HasHistogram = true
+		colStat.HistogramMaxBuckets = defaultHistogramBuckets
+	}
+	return colStat, nil
+}
+
+// canHaveMultiColumnHistogram returns true if a histogram can be collected on
+// the given columns of a multi-column statistic. The histogram is built on
+// tuples of the column values, so the columns must be key-encodable, and they
+// must not have user-defined types, which would need to be hydrated in the
+// tuple type of the histogram.
+func canHaveMultiColumnHistogram(cols []catalog.Column) bool {
+	for _, col := range cols {
+		typ := col.GetType()
+		if typ.UserDefined() || !colinfo.ColumnTypeIsIndexable(typ) {
+			return false
+		}
+	}
+	return true
+}
+
 // createStatsResumer implements the jobs.Resumer interface for CreateStats
 // jobs. A new instance is created for each job.
 type createStatsResumer struct {
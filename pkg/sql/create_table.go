@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
@@ -351,6 +352,15 @@ func (n *createTableNode) startExec(params runParams) error {
 		return err
 	}
 
+	// LIKE table definitions are replaced when the descriptor is created, so
+	// keep track of them to copy their comments afterwards.
+	var likeDefs []*tree.LikeTableDef
+	for _, def := range n.n.Defs {
+		if d, ok := def.(*tree.LikeTableDef); ok {
+			likeDefs = append(likeDefs, d)
+		}
+	}
+
 	var desc *tabledesc.Mutable
 	var affected map[descpb.ID]*tabledesc.Mutable
 	// creationTime is initialized to a zero value and populated at read time.
@@ -386,8 +396,9 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 
 		// If we have a single statement txn we want to run CTAS async, and
-		// consequently ensure it gets queued as a SchemaChange.
-		if params.extendedEvalCtx.TxnIsSingleStmt {
+		// consequently ensure it gets queued as a SchemaChange. CTAS WITH NO
+		// DATA has nothing to backfill, so the table is public right away.
+		if params.extendedEvalCtx.TxnIsSingleStmt && !n.n.AsWithNoData {
 			desc.State = descpb.DescriptorState_ADD
 		}
 	} else {
@@ -460,6 +471,14 @@ func (n *createTableNode) startExec(params runParams) error {
 		return err
 	}
 
+	if err := params.p.copyLikeTableComments(params.ctx, likeDefs, desc); err != nil {
+		return err
+	}
+
+	if err := params.p.addInheritanceBackReferences(params.ctx, desc); err != nil {
+		return err
+	}
+
 	if err := validateDescriptor(params.ctx, params.p, desc); err != nil {
 		return err
 	}
@@ -518,7 +537,7 @@ func (n *createTableNode) startExec(params runParams) error {
 
 	// If we are in a multi-statement txn or the source has placeholders, we
 	// execute the CTAS query synchronously.
-	if n.n.As() && !params.extendedEvalCtx.TxnIsSingleStmt {
+	if n.n.As() && !n.n.AsWithNoData && !params.extendedEvalCtx.TxnIsSingleStmt {
 		err = func() error {
 			// The data fill portion of CREATE AS must operate on a read snapshot,
 			// so that it doesn't end up observing its own writes.
@@ -670,6 +689,7 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		d.Unique.Deferrability,
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -733,7 +753,7 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrability, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
@@ -753,6 +773,7 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrability tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -801,12 +822,14 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:              constraintName,
+		TableID:           tbl.ID,
+		ColumnIDs:         columnIDs,
+		Predicate:         predicate,
+		Validity:          validity,
+		ConstraintID:      tbl.NextConstraintID,
+		Deferrable:        deferrability.IsDeferrable(),
+		InitiallyDeferred: deferrability == tree.ConstraintInitiallyDeferred,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1051,6 +1074,8 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrable:          d.Deferrability.IsDeferrable(),
+		InitiallyDeferred:   d.Deferrability == tree.ConstraintInitiallyDeferred,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1233,7 +1258,7 @@ func newTableDescIfAs(
 			// resolving it again.
 			typ := d.Type.(*types.T)
 			if typ.UserDefined() {
-				tn, typDesc, err := params.p.GetTypeDescriptor(params.ctx, typedesc.UserDefinedTypeOIDToID(typ.Oid()))
+				tn, typDesc, err := params.p.GetTypeDescriptor(params.ctx, typedesc.UserDefinedTypeOIDToID(typ.UserDefinedOID()))
 				if err != nil {
 					return nil, err
 				}
@@ -1256,6 +1281,11 @@ func newTableDescIfAs(
 	if err != nil {
 		return nil, err
 	}
+	// The create query is only needed to backfill the table, which is skipped
+	// for CTAS WITH NO DATA.
+	if p.AsWithNoData {
+		return desc, nil
+	}
 	createQuery, err := getFinalSourceQuery(params, p.AsSource, evalContext)
 	if err != nil {
 		return nil, err
@@ -1794,6 +1824,20 @@ func NewTableDesc(
 	}
 
 	for _, def := range n.Defs {
+		// EXCLUDE constraints are backed by an index which records the
+		// operators of the constraint.
+		var exclusionOperators []string
+		if d, ok := def.(*tree.ExcludeConstraintTableDef); ok {
+			if desc.PartitionAllBy {
+				return nil, unimplemented.NewWithIssue(46657,
+					"EXCLUDE constraints on implicitly partitioned tables are not supported")
+			}
+			indexDef, ops, err := makeExclusionConstraintIndexDef(d)
+			if err != nil {
+				return nil, err
+			}
+			def, exclusionOperators = &indexDef, ops
+		}
 		switch d := def.(type) {
 		case *tree.ColumnTableDef, *tree.LikeTableDef:
 			// pass, handled above.
@@ -1830,11 +1874,12 @@ func NewTableDesc(
 				return nil, unimplemented.New("partially visible indexes", "partially visible indexes are not yet supported")
 			}
 			idx := descpb.IndexDescriptor{
-				Name:             string(d.Name),
-				StoreColumnNames: d.Storing.ToStrings(),
-				Version:          indexEncodingVersion,
-				NotVisible:       d.Invisibility != 0.0,
-				Invisibility:     d.Invisibility,
+				Name:               string(d.Name),
+				StoreColumnNames:   d.Storing.ToStrings(),
+				Version:            indexEncodingVersion,
+				NotVisible:         d.Invisibility != 0.0,
+				Invisibility:       d.Invisibility,
+				ExclusionOperators: exclusionOperators,
 			}
 			if d.Inverted {
 				idx.Type = descpb.IndexDescriptor_INVERTED
@@ -1859,6 +1904,14 @@ func NewTableDesc(
 					ctx, evalCtx.Settings, column, &idx, columns[len(columns)-1]); err != nil {
 					return nil, err
 				}
+				if idx.IsExclusionConstraint() {
+					if err := checkExclusionOverlapColumn(column); err != nil {
+						return nil, err
+					}
+				}
+			}
+			if err := checkExclusionPeriodColumns(&desc, &idx); err != nil {
+				return nil, err
 			}
 
 			var idxPartitionBy *tree.PartitionBy
@@ -1918,6 +1971,9 @@ func NewTableDesc(
 				// We will add the unique constraint below.
 				break
 			}
+			if d.Deferrability.IsDeferrable() {
+				return nil, sqlerrors.NewDeferrableUniqueIndexError()
+			}
 			// If the index is named, ensure that the name is unique. Unnamed
 			// indexes will be given a unique auto-generated name later on when
 			// AllocateIDs is called.
@@ -2201,7 +2257,7 @@ func NewTableDesc(
 				}
 			}
 
-		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
+		case *tree.IndexTableDef, *tree.ExcludeConstraintTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
 			// Pass, handled above.
 
 		case *tree.CheckConstraintTableDef:
@@ -2331,7 +2387,7 @@ func newTableDesc(
 		return nil, err
 	}
 
-	newDefs, err := replaceLikeTableOpts(n, params)
+	newDefs, likeParams, err := replaceLikeTableOpts(n, params)
 	if err != nil {
 		return nil, err
 	}
@@ -2342,6 +2398,28 @@ func newTableDesc(
 		// n.Defs.
 		n.Defs = newDefs
 	}
+	if likeParams != nil {
+		// Storage parameters given explicitly take precedence over the ones
+		// copied by INCLUDING STATISTICS.
+		defer func(originalParams tree.StorageParams) { n.StorageParams = originalParams }(n.StorageParams)
+		params := append(tree.StorageParams(nil), n.StorageParams...)
+		for _, param := range likeParams {
+			if params.GetVal(string(param.Key)) == nil {
+				params = append(params, param)
+			}
+		}
+		n.StorageParams = params
+	}
+
+	var parents []*tabledesc.Mutable
+	if len(n.Inherits) > 0 {
+		var inheritedDefs tree.TableDefs
+		parents, inheritedDefs, err = inheritTableDefs(params, n, db.GetID())
+		if err != nil {
+			return nil, err
+		}
+		n.Defs = inheritedDefs
+	}
 
 	// Process any SERIAL columns to remove the SERIAL type, as required by
 	// NewTableDesc.
@@ -2387,6 +2465,9 @@ func newTableDesc(
 	if err != nil {
 		return nil, err
 	}
+	for _, parent := range parents {
+		ret.Inherits = append(ret.Inherits, parent.ID)
+	}
 
 	// We need to ensure sequence ownerships so that column owned sequences are
 	// correctly dropped when a column/table is dropped.
@@ -2503,14 +2584,33 @@ func rowLevelTTLAutomaticColumnExpr(intervalExpr tree.Expr) tree.Expr {
 	}
 }
 
+// likeTableOpts returns the options of a LIKE table definition, after
+// applying its INCLUDING and EXCLUDING clauses in order.
+func likeTableOpts(d *tree.LikeTableDef) tree.LikeTableOpt {
+	opts := tree.LikeTableOpt(0)
+	for _, opt := range d.Options {
+		if opt.Excluded {
+			opts &^= opt.Opt
+		} else {
+			opts |= opt.Opt
+		}
+	}
+	return opts
+}
+
 // replaceLikeTableOps processes the TableDefs in the input CreateTableNode,
 // searching for LikeTableDefs. If any are found, each LikeTableDef will be
 // replaced in the output tree.TableDefs (which will be a copy of the input
 // node's TableDefs) by an equivalent set of TableDefs pulled from the
 // LikeTableDef's target table.
 // If no LikeTableDefs are found, the output tree.TableDefs will be nil.
-func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs, error) {
+// The storage parameters copied by INCLUDING STATISTICS are returned
+// separately.
+func replaceLikeTableOpts(
+	n *tree.CreateTable, params runParams,
+) (tree.TableDefs, tree.StorageParams, error) {
 	var newDefs tree.TableDefs
+	var storageParams tree.StorageParams
 	for i, def := range n.Defs {
 		d, ok := def.(*tree.LikeTableDef)
 		if !ok {
@@ -2526,17 +2626,9 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 		}
 		_, td, err := params.p.ResolveMutableTableDescriptor(params.ctx, &d.Name, true, tree.ResolveRequireTableDesc)
 		if err != nil {
-			return nil, err
-		}
-		opts := tree.LikeTableOpt(0)
-		// Process ons / offs.
-		for _, opt := range d.Options {
-			if opt.Excluded {
-				opts &^= opt.Opt
-			} else {
-				opts |= opt.Opt
-			}
+			return nil, nil, err
 		}
+		opts := likeTableOpts(d)
 
 		// Copy defaults of implicitly created columns if they are needed by indexes.
 		// This is required to ensure the newly created table still works as expected
@@ -2556,17 +2648,19 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 		}
 
 		defs := make(tree.TableDefs, 0)
+		copiedColumns := make(map[string]struct{}, len(td.Columns))
 		// Add user-defined columns.
 		for i := range td.Columns {
 			c := &td.Columns[i]
 			implicit, err := isImplicitlyCreatedBySystem(td, c)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			if implicit {
 				// Don't add system-created implicit columns.
 				continue
 			}
+			copiedColumns[c.Name] = struct{}{}
 			def := tree.ColumnTableDef{
 				Name:   tree.Name(c.Name),
 				Type:   c.Type,
@@ -2577,12 +2671,31 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 			} else {
 				def.Nullable.Nullability = tree.NotNull
 			}
-			if c.DefaultExpr != nil {
+			// The default expression of an identity column uses the sequence of
+			// the source table, so it is never copied. INCLUDING IDENTITY creates
+			// a new sequence for the column instead.
+			isIdentity := c.GeneratedAsIdentityType != catpb.GeneratedAsIdentityType_NOT_IDENTITY_COLUMN
+			if isIdentity && opts.Has(tree.LikeTableOptIdentity) {
+				def.GeneratedIdentity.IsGeneratedAsIdentity = true
+				def.GeneratedIdentity.GeneratedAsIdentityType = tree.GeneratedAlways
+				if c.GeneratedAsIdentityType == catpb.GeneratedAsIdentityType_GENERATED_BY_DEFAULT {
+					def.GeneratedIdentity.GeneratedAsIdentityType = tree.GeneratedByDefault
+				}
+				if c.GeneratedAsIdentitySequenceOption != nil {
+					def.GeneratedIdentity.SeqOptions, err = parseIdentitySequenceOptions(
+						*c.GeneratedAsIdentitySequenceOption,
+					)
+					if err != nil {
+						return nil, nil, err
+					}
+				}
+			}
+			if c.DefaultExpr != nil && !isIdentity {
 				_, shouldCopyColumnDefault := shouldCopyColumnDefaultSet[c.Name]
 				if opts.Has(tree.LikeTableOptDefaults) || shouldCopyColumnDefault {
 					def.DefaultExpr.Expr, err = parser.ParseExpr(*c.DefaultExpr)
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			}
@@ -2592,7 +2705,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 					def.Computed.Virtual = c.Virtual
 					def.Computed.Expr, err = parser.ParseExpr(*c.ComputeExpr)
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			}
@@ -2600,7 +2713,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				if opts.Has(tree.LikeTableOptDefaults) {
 					def.OnUpdateExpr.Expr, err = parser.ParseExpr(*c.OnUpdateExpr)
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			}
@@ -2614,7 +2727,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				}
 				def.Expr, err = parser.ParseExpr(c.Expr)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				defs = append(defs, &def)
 			}
@@ -2628,7 +2741,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				}
 				colNames, err := catalog.ColumnNamesForIDs(td, c.ColumnIDs)
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				for i := range colNames {
 					def.Columns = append(def.Columns, tree.IndexElem{Column: tree.Name(colNames[i])})
@@ -2637,7 +2750,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				if c.IsPartial() {
 					def.Predicate, err = parser.ParseExpr(c.Predicate)
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			}
@@ -2674,13 +2787,13 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 					}
 					col, err := catalog.MustFindColumnByID(td, idx.GetKeyColumnID(j))
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 					if col.IsExpressionIndexColumn() {
 						elem.Column = ""
 						elem.Expr, err = parser.ParseExpr(col.GetComputeExpr())
 						if err != nil {
-							return nil, err
+							return nil, nil, err
 						}
 					}
 					if idx.GetKeyColumnDirection(j) == catenumpb.IndexColumn_DESC {
@@ -2706,15 +2819,139 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				if idx.IsPartial() {
 					indexDef.Predicate, err = parser.ParseExpr(idx.GetPredicate())
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 				defs = append(defs, def)
 			}
 		}
+		if opts.Has(tree.LikeTableOptStorage) {
+			// A table which only has the default family does not need its
+			// families to be copied.
+			if len(td.Families) > 1 || (len(td.Families) == 1 && td.Families[0].Name != tabledesc.FamilyPrimaryName) {
+				for i := range td.Families {
+					f := &td.Families[i]
+					def := tree.FamilyTableDef{Name: tree.Name(f.Name)}
+					for _, name := range f.ColumnNames {
+						if _, ok := copiedColumns[name]; ok {
+							def.Columns = append(def.Columns, tree.Name(name))
+						}
+					}
+					if len(def.Columns) > 0 {
+						defs = append(defs, &def)
+					}
+				}
+			}
+		}
+		if opts.Has(tree.LikeTableOptStatistics) {
+			storageParams = append(storageParams, likeTableStatisticsParams(td)...)
+		}
 		newDefs = append(newDefs, defs...)
 	}
-	return newDefs, nil
+	return newDefs, storageParams, nil
+}
+
+// copyLikeTableComments copies the comments on the columns, indexes and
+// constraints of the source tables of the LIKE table definitions which include
+// COMMENTS to the elements of desc with the same names.
+func (p *planner) copyLikeTableComments(
+	ctx context.Context, likeDefs []*tree.LikeTableDef, desc *tabledesc.Mutable,
+) error {
+	for _, d := range likeDefs {
+		if !likeTableOpts(d).Has(tree.LikeTableOptComments) {
+			continue
+		}
+		// Resolve the source table from storage, so that its comments are
+		// cached in the descriptor collection.
+		_, td, err := p.ResolveMutableTableDescriptor(ctx, &d.Name, true, tree.ResolveRequireTableDesc)
+		if err != nil {
+			return err
+		}
+		for _, col := range td.PublicColumns() {
+			cmt, ok := p.Descriptors().GetColumnComment(td.GetID(), col.GetPGAttributeNum())
+			if !ok {
+				continue
+			}
+			if newCol := catalog.FindColumnByName(desc, col.GetName()); newCol != nil {
+				if err := p.updateComment(
+					ctx, desc.GetID(), uint32(newCol.GetPGAttributeNum()), catalogkeys.ColumnCommentType, cmt,
+				); err != nil {
+					return err
+				}
+			}
+		}
+		for _, idx := range td.ActiveIndexes() {
+			cmt, ok := p.Descriptors().GetIndexComment(td.GetID(), idx.GetID())
+			if !ok {
+				continue
+			}
+			if newIdx := catalog.FindIndexByName(desc, idx.GetName()); newIdx != nil {
+				if err := p.updateComment(
+					ctx, desc.GetID(), uint32(newIdx.GetID()), catalogkeys.IndexCommentType, cmt,
+				); err != nil {
+					return err
+				}
+			}
+		}
+		for _, c := range td.AllConstraints() {
+			cmt, ok := p.Descriptors().GetConstraintComment(td.GetID(), c.GetConstraintID())
+			if !ok {
+				continue
+			}
+			if newC := catalog.FindConstraintByName(desc, c.GetName()); newC != nil {
+				if err := p.updateComment(
+					ctx, desc.GetID(), uint32(newC.GetConstraintID()), catalogkeys.ConstraintCommentType, cmt,
+				); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseIdentitySequenceOptions parses the serialized sequence options of an
+// identity column.
+func parseIdentitySequenceOptions(s string) (tree.SequenceOptions, error) {
+	stmt, err := parser.ParseOne("CREATE SEQUENCE fake_seq " + s)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse sequence option")
+	}
+	createSeq, ok := stmt.AST.(*tree.CreateSequence)
+	if !ok {
+		return nil, errors.AssertionFailedf("cannot convert parsed result to tree.CreateSequence")
+	}
+	return createSeq.Options, nil
+}
+
+// likeTableStatisticsParams returns the storage parameters controlling the
+// collection and use of statistics which are set on the given table.
+func likeTableStatisticsParams(td catalog.TableDescriptor) tree.StorageParams {
+	var params tree.StorageParams
+	add := func(key string, value tree.Expr) {
+		params = append(params, tree.StorageParam{Key: tree.Name(key), Value: value})
+	}
+	if settings := td.GetAutoStatsSettings(); settings != nil {
+		if settings.Enabled != nil {
+			add(catpb.AutoStatsEnabledTableSettingName, tree.MakeDBool(tree.DBool(*settings.Enabled)))
+		}
+		if settings.MinStaleRows != nil {
+			add(catpb.AutoStatsMinStaleTableSettingName, tree.NewDInt(tree.DInt(*settings.MinStaleRows)))
+		}
+		if settings.FractionStaleRows != nil {
+			add(catpb.AutoStatsFractionStaleTableSettingName, tree.NewDFloat(tree.DFloat(*settings.FractionStaleRows)))
+		}
+	}
+	if enabled, ok := td.ForecastStatsEnabled(); ok {
+		add(`sql_stats_forecasts_enabled`, tree.MakeDBool(tree.DBool(enabled)))
+	}
+	if count, ok := td.HistogramSamplesCount(); ok {
+		add(`sql_stats_histogram_samples_count`, tree.NewDInt(tree.DInt(count)))
+	}
+	if count, ok := td.HistogramBucketsCount(); ok {
+		add(`sql_stats_histogram_buckets_count`, tree.NewDInt(tree.DInt(count)))
+	}
+	return params
 }
 
 // makeShardColumnDesc returns a new column descriptor for a hidden computed shard column
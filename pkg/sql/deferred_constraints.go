@@ -0,0 +1,586 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// constraintDeferrability returns the deferrability of the given constraint.
+// Only foreign keys and UNIQUE WITHOUT INDEX constraints can be deferrable.
+func constraintDeferrability(c catalog.Constraint) tree.ConstraintDeferrability {
+	if fk := c.AsForeignKey(); fk != nil {
+		desc := fk.ForeignKeyDesc()
+		return tree.MakeConstraintDeferrability(desc.Deferrable, desc.InitiallyDeferred)
+	}
+	if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+		desc := uwi.UniqueWithoutIndexDesc()
+		return tree.MakeConstraintDeferrability(desc.Deferrable, desc.InitiallyDeferred)
+	}
+	return tree.ConstraintNotDeferrable
+}
+
+// deferredConstraintKey identifies a deferrable constraint by the ID of the
+// table on which it is defined and its constraint ID.
+type deferredConstraintKey struct {
+	tableID      descpb.ID
+	constraintID descpb.ConstraintID
+}
+
+func makeDeferredConstraintKey(c *exec.DeferrableConstraint) deferredConstraintKey {
+	return deferredConstraintKey{
+		tableID:      descpb.ID(c.Table),
+		constraintID: descpb.ConstraintID(c.Constraint),
+	}
+}
+
+// deferredCheckBatchSize is the maximum number of keys re-checked by a single
+// query when deferred checks are run.
+const deferredCheckBatchSize = 256
+
+// deferredCheck holds the rows that violated a deferred constraint when a
+// statement checked it. Only the keys of these rows need to be re-checked
+// before the transaction commits.
+type deferredCheck struct {
+	key deferredConstraintKey
+
+	// keyCols are the ordinals of the columns in rows which hold the values of
+	// the constraint's columns.
+	keyCols []exec.NodeColumnOrdinal
+
+	// mkErr creates the violation error for one of the rows.
+	mkErr exec.MkErrFn
+
+	// rows are the violating rows, accounted for by the session's memory
+	// monitor. The container is closed once the rows are re-checked or the
+	// transaction finishes.
+	rows *rowcontainer.RowContainer
+}
+
+// namedConstraintMode is the mode set by SET CONSTRAINTS for the constraints
+// with the given name in the schemas of the search path at the time.
+type namedConstraintMode struct {
+	name      string
+	schemaIDs catalog.DescriptorIDSet
+	deferred  bool
+}
+
+// deferredConstraints tracks the SET CONSTRAINTS modes of a transaction along
+// with the checks of deferrable constraints which have been postponed. Checks
+// may run concurrently, so all accesses are protected by a mutex.
+//
+// The constraints named by SET CONSTRAINTS are only resolved against the
+// tables whose deferrable constraints the transaction checks: against those
+// already checked when SET CONSTRAINTS runs, and against the others when a
+// statement which checks them is planned. This avoids looking up every table
+// in the search path.
+type deferredConstraints struct {
+	// mon accounts for the memory of the violating rows. It is the monitor of
+	// the session, since the rows are kept across statements.
+	mon *mon.BytesMonitor
+
+	mu struct {
+		syncutil.Mutex
+
+		// allMode is the mode set by SET CONSTRAINTS ALL, if any.
+		allMode *bool
+
+		// modes maps constraints to the mode set for them by SET CONSTRAINTS,
+		// overriding allMode.
+		modes map[deferredConstraintKey]bool
+
+		// named are the modes set by SET CONSTRAINTS for named constraints since
+		// the last SET CONSTRAINTS ALL, in order. They are applied to modes when
+		// a table's constraints are resolved.
+		named []namedConstraintMode
+
+		// tables are the tables whose deferrable constraints have been checked
+		// by the transaction, and which named has been resolved against.
+		tables catalog.DescriptorIDSet
+
+		// pending contains the checks which must be re-run before the
+		// transaction commits, in the order in which they were deferred.
+		pending []*deferredCheck
+	}
+}
+
+// isDeferred returns true if the check for the given constraint should be
+// postponed until commit.
+func (d *deferredConstraints) isDeferred(c *exec.DeferrableConstraint) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if mode, ok := d.mu.modes[makeDeferredConstraintKey(c)]; ok {
+		return mode
+	}
+	if d.mu.allMode != nil {
+		return *d.mu.allMode
+	}
+	return c.InitiallyDeferred
+}
+
+// noteTable records that the transaction checks the deferrable constraints of
+// the given table, and applies the modes set for named constraints to them.
+// It is called when the checks are planned.
+func (d *deferredConstraints) noteTable(
+	ctx context.Context, col *descs.Collection, txn *kv.Txn, tableID descpb.ID,
+) error {
+	d.mu.Lock()
+	if d.mu.tables.Contains(tableID) {
+		d.mu.Unlock()
+		return nil
+	}
+	named := d.mu.named
+	d.mu.Unlock()
+
+	var modes map[deferredConstraintKey]bool
+	if len(named) > 0 {
+		tableDesc, err := col.ByIDWithLeased(txn).WithoutNonPublic().Get().Table(ctx, tableID)
+		if err != nil {
+			return err
+		}
+		modes = make(map[deferredConstraintKey]bool)
+		for _, m := range named {
+			if !m.schemaIDs.Contains(tableDesc.GetParentSchemaID()) {
+				continue
+			}
+			c := catalog.FindConstraintByName(tableDesc, m.name)
+			if c == nil || c.IsMutation() || !constraintDeferrability(c).IsDeferrable() {
+				continue
+			}
+			modes[deferredConstraintKey{tableID: tableID, constraintID: c.GetConstraintID()}] = m.deferred
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.tables.Add(tableID)
+	if len(modes) > 0 && d.mu.modes == nil {
+		d.mu.modes = make(map[deferredConstraintKey]bool, len(modes))
+	}
+	for key, mode := range modes {
+		d.mu.modes[key] = mode
+	}
+	return nil
+}
+
+// newViolations returns a row container for the rows with the given columns
+// which violate a deferred constraint.
+func (d *deferredConstraints) newViolations(cols colinfo.ResultColumns) *rowcontainer.RowContainer {
+	return rowcontainer.NewRowContainer(d.mon.MakeBoundAccount(), colinfo.ColTypeInfoFromResCols(cols))
+}
+
+// addViolations records the rows which violated the given deferred
+// constraint, so that their keys are re-checked before the transaction
+// commits. The container is owned by deferredConstraints from then on.
+func (d *deferredConstraints) addViolations(
+	ctx context.Context,
+	c *exec.DeferrableConstraint,
+	mkErr exec.MkErrFn,
+	rows *rowcontainer.RowContainer,
+) {
+	if rows.Len() == 0 {
+		rows.Close(ctx)
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.pending = append(d.mu.pending, &deferredCheck{
+		key:     makeDeferredConstraintKey(c),
+		keyCols: c.KeyCols,
+		mkErr:   mkErr,
+		rows:    rows,
+	})
+}
+
+// setMode records the mode for the given constraints, or for all deferrable
+// constraints if named is nil. keys are the constraints which named resolves
+// to on the tables in d.mu.tables. The pending checks of constraints which
+// become IMMEDIATE are removed and returned so that the caller can run them
+// right away.
+func (d *deferredConstraints) setMode(
+	named []namedConstraintMode, keys []deferredConstraintKey, deferred bool,
+) []*deferredCheck {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if named == nil {
+		d.mu.allMode = &deferred
+		d.mu.modes = nil
+		d.mu.named = nil
+	} else {
+		if d.mu.modes == nil {
+			d.mu.modes = make(map[deferredConstraintKey]bool, len(keys))
+		}
+		for _, key := range keys {
+			d.mu.modes[key] = deferred
+		}
+		d.mu.named = append(d.mu.named, named...)
+	}
+	if deferred {
+		return nil
+	}
+	var immediate []*deferredCheck
+	remaining := d.mu.pending[:0]
+	for _, c := range d.mu.pending {
+		if mode, ok := d.mu.modes[c.key]; named == nil || (ok && !mode) {
+			immediate = append(immediate, c)
+		} else {
+			remaining = append(remaining, c)
+		}
+	}
+	d.mu.pending = remaining
+	return immediate
+}
+
+// resolvedTables returns the tables which the constraints named by SET
+// CONSTRAINTS are resolved against when it runs.
+func (d *deferredConstraints) resolvedTables() []descpb.ID {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.mu.tables.Ordered()
+}
+
+// takePending removes and returns all pending checks.
+func (d *deferredConstraints) takePending() []*deferredCheck {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	pending := d.mu.pending
+	d.mu.pending = nil
+	return pending
+}
+
+// reset clears the state at the end of a transaction.
+func (d *deferredConstraints) reset(ctx context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	closeDeferredChecks(ctx, d.mu.pending)
+	d.mu.allMode = nil
+	d.mu.modes = nil
+	d.mu.named = nil
+	d.mu.tables = catalog.DescriptorIDSet{}
+	d.mu.pending = nil
+}
+
+func closeDeferredChecks(ctx context.Context, checks []*deferredCheck) {
+	for _, dc := range checks {
+		dc.rows.Close(ctx)
+	}
+}
+
+// runDeferredChecks re-checks the keys recorded by the given deferred checks
+// against the data visible to the transaction, and returns the violation error
+// of the first key which still violates its constraint. The keys of each
+// constraint are re-checked together, deferredCheckBatchSize keys per query.
+// The checks are closed once they have run.
+func runDeferredChecks(ctx context.Context, txn descs.Txn, checks []*deferredCheck) error {
+	defer closeDeferredChecks(ctx, checks)
+	var order []deferredConstraintKey
+	byConstraint := make(map[deferredConstraintKey][]*deferredCheck)
+	for _, dc := range checks {
+		if _, ok := byConstraint[dc.key]; !ok {
+			order = append(order, dc.key)
+		}
+		byConstraint[dc.key] = append(byConstraint[dc.key], dc)
+	}
+	for _, key := range order {
+		if err := runDeferredCheck(ctx, txn, key, byConstraint[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deferredKey identifies a violating row of a deferred check.
+type deferredKey struct {
+	check *deferredCheck
+	row   int
+}
+
+// runDeferredCheck re-checks the keys recorded by the deferred checks of the
+// given constraint.
+func runDeferredCheck(
+	ctx context.Context, txn descs.Txn, key deferredConstraintKey, checks []*deferredCheck,
+) error {
+	tableDesc, err := txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(
+		ctx, key.tableID,
+	)
+	if err != nil {
+		return err
+	}
+	c := catalog.FindConstraintByID(tableDesc, key.constraintID)
+	if c == nil {
+		// The constraint was dropped after its check was deferred.
+		return nil
+	}
+	mkQuery, err := deferredCheckQuery(ctx, txn, tableDesc, c)
+	if err != nil {
+		return err
+	}
+
+	var batch []deferredKey
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		numCols := len(batch[0].check.keyCols)
+		args := make([]interface{}, 0, len(batch)*(numCols+1))
+		for i, k := range batch {
+			row := k.check.rows.At(k.row)
+			args = append(args, tree.NewDInt(tree.DInt(i)))
+			for _, ord := range k.check.keyCols {
+				args = append(args, row[ord])
+			}
+		}
+		violation, err := txn.QueryRowEx(
+			ctx, "validate deferred constraint", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride, mkQuery(len(batch)), args...,
+		)
+		if err != nil {
+			return err
+		}
+		if violation != nil {
+			k := batch[int(tree.MustBeDInt(violation[0]))]
+			return k.check.mkErr(k.check.rows.At(k.row))
+		}
+		batch = batch[:0]
+		return nil
+	}
+	for _, dc := range checks {
+		for i := 0; i < dc.rows.Len(); i++ {
+			batch = append(batch, deferredKey{check: dc, row: i})
+			if len(batch) == deferredCheckBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return flush()
+}
+
+// deferredCheckQuery returns a function which makes a query re-checking the
+// given number of keys. The keys are passed as placeholders, each key as its
+// position in the batch followed by its values in the order of the
+// constraint's columns. The query returns the position of the first key which
+// still violates the constraint, if any.
+func deferredCheckQuery(
+	ctx context.Context, txn descs.Txn, tableDesc catalog.TableDescriptor, c catalog.Constraint,
+) (func(numKeys int) string, error) {
+	var numCols int
+	var where string
+	if fk := c.AsForeignKey(); fk != nil {
+		refTable, err := txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(
+			ctx, fk.GetReferencedTableID(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		originCols, err := catalog.ColumnNamesForIDs(tableDesc, fk.ForeignKeyDesc().OriginColumnIDs)
+		if err != nil {
+			return nil, err
+		}
+		refCols, err := catalog.ColumnNamesForIDs(refTable, fk.ForeignKeyDesc().ReferencedColumnIDs)
+		if err != nil {
+			return nil, err
+		}
+		// A key is still violated if a referencing row with the key exists and
+		// no referenced row does. IS NOT DISTINCT FROM is used for the
+		// referencing row so that MATCH FULL keys with some NULL values are
+		// found.
+		numCols = len(originCols)
+		originWhere := make([]string, numCols)
+		refWhere := make([]string, numCols)
+		for i := range originCols {
+			originWhere[i] = fmt.Sprintf("o.%s IS NOT DISTINCT FROM k.k%d", tree.NameString(originCols[i]), i+1)
+			refWhere[i] = fmt.Sprintf("r.%s = k.k%d", tree.NameString(refCols[i]), i+1)
+		}
+		where = fmt.Sprintf(
+			`EXISTS (SELECT 1 FROM [%d AS o] WHERE %s) AND NOT EXISTS (SELECT 1 FROM [%d AS r] WHERE %s)`,
+			tableDesc.GetID(), strings.Join(originWhere, " AND "),
+			refTable.GetID(), strings.Join(refWhere, " AND "),
+		)
+	} else if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+		cols, err := catalog.ColumnNamesForIDs(tableDesc, uwi.CollectKeyColumnIDs().Ordered())
+		if err != nil {
+			return nil, err
+		}
+		numCols = len(cols)
+		conds := make([]string, 0, numCols+1)
+		for i := range cols {
+			conds = append(conds, fmt.Sprintf("t.%s = k.k%d", tree.NameString(cols[i]), i+1))
+		}
+		if uwi.IsPartial() {
+			conds = append(conds, fmt.Sprintf("(%s)", uwi.GetPredicate()))
+		}
+		// A key is still violated if more than one row has it.
+		where = fmt.Sprintf(
+			`(SELECT count(*) FROM (SELECT 1 FROM [%d AS t] WHERE %s LIMIT 2)) > 1`,
+			tableDesc.GetID(), strings.Join(conds, " AND "),
+		)
+	} else {
+		return nil, errors.AssertionFailedf("constraint %q cannot be deferred", c.GetName())
+	}
+
+	colNames := make([]string, numCols)
+	for i := range colNames {
+		colNames[i] = fmt.Sprintf("k%d", i+1)
+	}
+	return func(numKeys int) string {
+		var values strings.Builder
+		for i := 0; i < numKeys; i++ {
+			if i > 0 {
+				values.WriteString(", ")
+			}
+			values.WriteString("(")
+			for j := 0; j <= numCols; j++ {
+				if j > 0 {
+					values.WriteString(", ")
+				}
+				fmt.Fprintf(&values, "$%d", i*(numCols+1)+j+1)
+			}
+			values.WriteString(")")
+		}
+		return fmt.Sprintf(
+			`SELECT k.i FROM (VALUES %s) AS k (i, %s) WHERE %s ORDER BY k.i LIMIT 1`,
+			values.String(), strings.Join(colNames, ", "), where,
+		)
+	}, nil
+}
+
+// searchPathSchemaIDs returns the IDs of the schemas of the current database
+// in the search path.
+func (p *planner) searchPathSchemaIDs(ctx context.Context) (catalog.DescriptorIDSet, error) {
+	var schemaIDs catalog.DescriptorIDSet
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return schemaIDs, err
+	}
+	iter := p.CurrentSearchPath().Iter()
+	for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+		sc, err := p.Descriptors().ByNameWithLeased(p.txn).MaybeGet().Schema(ctx, db, scName)
+		if err != nil {
+			return schemaIDs, err
+		}
+		if sc != nil {
+			schemaIDs.Add(sc.GetID())
+		}
+	}
+	return schemaIDs, nil
+}
+
+// resolveDeferrableConstraints returns the keys of the constraints with the
+// given names on the given tables in the given schemas. It is an error if a
+// name matches a constraint which is not deferrable. Names which match no
+// constraint of these tables are resolved against the other tables when the
+// transaction checks their constraints.
+func (p *planner) resolveDeferrableConstraints(
+	ctx context.Context,
+	names tree.NameList,
+	schemaIDs catalog.DescriptorIDSet,
+	tables []descpb.ID,
+) ([]deferredConstraintKey, error) {
+	var keys []deferredConstraintKey
+	for _, id := range tables {
+		tableDesc, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Table(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !schemaIDs.Contains(tableDesc.GetParentSchemaID()) {
+			continue
+		}
+		for _, name := range names {
+			c := catalog.FindConstraintByName(tableDesc, string(name))
+			if c == nil || c.IsMutation() {
+				continue
+			}
+			if !constraintDeferrability(c).IsDeferrable() {
+				return nil, pgerror.Newf(pgcode.WrongObjectType, "constraint %q is not deferrable", name)
+			}
+			keys = append(keys, deferredConstraintKey{
+				tableID:      tableDesc.GetID(),
+				constraintID: c.GetConstraintID(),
+			})
+		}
+	}
+	return keys, nil
+}
+
+// setConstraintsNode represents a SET CONSTRAINTS statement.
+type setConstraintsNode struct {
+	n *tree.SetConstraints
+}
+
+// SetConstraints sets the checking mode of deferrable constraints for the
+// current transaction.
+// Privileges: None.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	return &setConstraintsNode{n: n}, nil
+}
+
+func (n *setConstraintsNode) startExec(params runParams) error {
+	p := params.p
+	if p.extendedEvalCtx.TxnImplicit {
+		// Postgres accepts this with a warning, since the statement has no
+		// effect outside of a transaction block.
+		p.BufferClientNotice(
+			params.ctx,
+			pgnotice.NewWithSeverityf(
+				"WARNING",
+				"SET CONSTRAINTS can only be used in transaction blocks",
+			),
+		)
+		return nil
+	}
+	if p.extendedEvalCtx.deferredConstraints == nil {
+		return nil
+	}
+	d := p.extendedEvalCtx.deferredConstraints
+	var named []namedConstraintMode
+	var keys []deferredConstraintKey
+	if len(n.n.Names) > 0 {
+		schemaIDs, err := p.searchPathSchemaIDs(params.ctx)
+		if err != nil {
+			return err
+		}
+		if keys, err = p.resolveDeferrableConstraints(
+			params.ctx, n.n.Names, schemaIDs, d.resolvedTables(),
+		); err != nil {
+			return err
+		}
+		named = make([]namedConstraintMode, len(n.n.Names))
+		for i, name := range n.n.Names {
+			named[i] = namedConstraintMode{name: string(name), schemaIDs: schemaIDs, deferred: n.n.Deferred}
+		}
+	}
+	immediate := d.setMode(named, keys, n.n.Deferred)
+	return runDeferredChecks(params.ctx, p.InternalSQLTxn(), immediate)
+}
+
+func (n *setConstraintsNode) Next(runParams) (bool, error) { return false, nil }
+func (n *setConstraintsNode) Values() tree.Datums          { return nil }
+func (n *setConstraintsNode) Close(context.Context)        {}
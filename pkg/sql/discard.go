@@ -61,6 +61,11 @@ func (n *discardNode) startExec(params runParams) error {
 			m.initSequenceCache()
 		})
 
+		// UNLISTEN *
+		if notifications := params.p.extendedEvalCtx.notifications; notifications != nil {
+			notifications.addListenOp(listenOp{})
+		}
+
 		// DISCARD TEMP
 		err := deleteTempTables(params.ctx, params.p)
 		if err != nil {
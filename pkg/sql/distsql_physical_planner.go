@@ -40,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -379,6 +380,19 @@ func checkExpr(expr tree.Expr) error {
 	return v.err
 }
 
+// checkAggregateSupportExprs checks if the support expressions of a
+// user-defined aggregate are supported by distSQL. Since they are calls to
+// routines, they currently never are. support may be nil.
+func checkAggregateSupportExprs(support *tree.AggregateSupportExprs) error {
+	if support == nil {
+		return nil
+	}
+	if err := checkExpr(support.Transition); err != nil {
+		return err
+	}
+	return checkExpr(support.Final)
+}
+
 type distRecommendation int
 
 const (
@@ -510,6 +524,11 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		return checkSupportForPlanNode(n.source.plan)
 
 	case *groupNode:
+		for _, f := range n.funcs {
+			if err := checkAggregateSupportExprs(f.userDefined); err != nil {
+				return cannotDistribute, err
+			}
+		}
 		rec, err := checkSupportForPlanNode(n.plan)
 		if err != nil {
 			return cannotDistribute, err
@@ -701,6 +720,11 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		return canDistribute, nil
 
 	case *windowNode:
+		for _, f := range n.funcs {
+			if err := checkAggregateSupportExprs(f.userDefined); err != nil {
+				return cannotDistribute, err
+			}
+		}
 		rec, err := checkSupportForPlanNode(n.plan)
 		if err != nil {
 			return cannotDistribute, err
@@ -1619,6 +1643,8 @@ func initTableReaderSpecTemplate(
 		return nil, execinfrapb.PostProcessSpec{}, err
 	}
 
+	initTableReaderSample(s, n.sample)
+
 	var post execinfrapb.PostProcessSpec
 	if n.hardLimit != 0 {
 		post.Limit = uint64(n.hardLimit)
@@ -1628,6 +1654,21 @@ func initTableReaderSpecTemplate(
 	return s, post, nil
 }
 
+// initTableReaderSample sets up the TableReaderSpec to only read a sample of
+// the ranges overlapping its spans, if requested by TABLESAMPLE SYSTEM. Without
+// a REPEATABLE clause, a new seed is chosen every time the query is planned.
+func initTableReaderSample(spec *execinfrapb.TableReaderSpec, sample opt.TableSample) {
+	if !sample.System {
+		return
+	}
+	spec.SampleSystem = true
+	spec.SamplePercent = sample.Percent
+	spec.SampleSeed = sample.Seed
+	if !sample.Repeatable {
+		spec.SampleSeed = randutil.FastInt63()
+	}
+}
+
 // createTableReaders generates a plan consisting of table reader processors,
 // one for each node that has spans that we are reading.
 func (dsp *DistSQLPlanner) createTableReaders(
@@ -1972,11 +2013,22 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			aggregations[i].Func = execinfrapb.UserDefined
+			var err error
+			aggregations[i].UserDefined, err = makeUserDefinedAggregateSpec(
+				ctx, planCtx, fholder.userDefined, n.columns[i].Typ,
+			)
+			if err != nil {
+				return err
+			}
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2008,6 +2060,33 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// makeUserDefinedAggregateSpec creates the specification of a user-defined
+// aggregate with the given support expressions and result type.
+func makeUserDefinedAggregateSpec(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	support *tree.AggregateSupportExprs,
+	resultType *types.T,
+) (*execinfrapb.AggregatorSpec_UserDefinedAggregate, error) {
+	spec := &execinfrapb.AggregatorSpec_UserDefinedAggregate{
+		TransitionStrict: support.TransitionStrict,
+		FinalStrict:      support.FinalStrict,
+		StateType:        support.StateType,
+		ResultType:       resultType,
+	}
+	var err error
+	if spec.Transition, err = physicalplan.MakeExpression(ctx, support.Transition, planCtx, nil); err != nil {
+		return nil, err
+	}
+	if spec.Final, err = physicalplan.MakeExpression(ctx, support.Final, planCtx, nil); err != nil {
+		return nil, err
+	}
+	if spec.InitialState, err = physicalplan.MakeExpression(ctx, support.InitialState, planCtx, nil); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2555,8 +2634,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 			argTypes[j] = inputTypes[c]
 		}
 		copy(argTypes[len(agg.ColIdx):], info.argumentsColumnTypes[i])
-		var err error
-		_, returnTyp, err := execagg.GetAggregateInfo(agg.Func, argTypes...)
+		returnTyp, err := execagg.GetAggregateOutputType(&info.aggregations[i], argTypes...)
 		if err != nil {
 			return err
 		}
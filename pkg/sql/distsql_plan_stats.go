@@ -323,7 +323,7 @@ func (dsp *DistSQLPlanner) createPartialStatsPlan(
 		// currently have a way of using more than one or deciding which one
 		// is better.
 		//
-		// We do not generate multi-column stats with histograms, so there
+		// We do not generate inverted histograms on multi-column stats, so there
 		// is no need to find an index for multi-column stats here.
 		//
 		// TODO(mjibson): allow multiple inverted indexes on the same column
@@ -437,7 +437,7 @@ func (dsp *DistSQLPlanner) createStatsPlan(
 			// currently have a way of using more than one or deciding which one
 			// is better.
 			//
-			// We do not generate multi-column stats with histograms, so there
+			// We do not generate inverted histograms on multi-column stats, so there
 			// is no need to find an index for multi-column stats here.
 			//
 			// TODO(mjibson): allow multiple inverted indexes on the same column
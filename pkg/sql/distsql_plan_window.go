@@ -28,18 +28,33 @@ func createWindowFnSpec(
 			return execinfrapb.WindowerSpec_WindowFn{}, nil, errors.Errorf("ColIdx out of range (%d)", argIdx)
 		}
 	}
-	// Figure out which built-in to compute.
-	funcSpec, err := rowexec.CreateWindowerSpecFunc(funcInProgress.expr.Func.String())
-	if err != nil {
-		return execinfrapb.WindowerSpec_WindowFn{}, nil, err
-	}
-	argTypes := make([]*types.T, len(funcInProgress.argsIdxs))
-	for i, argIdx := range funcInProgress.argsIdxs {
-		argTypes[i] = plan.GetResultTypes()[argIdx]
-	}
-	_, outputType, err := execagg.GetWindowFunctionInfo(funcSpec, argTypes...)
-	if err != nil {
-		return execinfrapb.WindowerSpec_WindowFn{}, outputType, err
+	var funcSpec execinfrapb.WindowerSpec_Func
+	var userDefined *execinfrapb.AggregatorSpec_UserDefinedAggregate
+	var outputType *types.T
+	if funcInProgress.userDefined != nil {
+		aggSpec := execinfrapb.UserDefined
+		funcSpec.AggregateFunc = &aggSpec
+		outputType = funcInProgress.expr.ResolvedType()
+		var err error
+		userDefined, err = makeUserDefinedAggregateSpec(ctx, planCtx, funcInProgress.userDefined, outputType)
+		if err != nil {
+			return execinfrapb.WindowerSpec_WindowFn{}, nil, err
+		}
+	} else {
+		// Figure out which built-in to compute.
+		var err error
+		funcSpec, err = rowexec.CreateWindowerSpecFunc(funcInProgress.expr.Func.String())
+		if err != nil {
+			return execinfrapb.WindowerSpec_WindowFn{}, nil, err
+		}
+		argTypes := make([]*types.T, len(funcInProgress.argsIdxs))
+		for i, argIdx := range funcInProgress.argsIdxs {
+			argTypes[i] = plan.GetResultTypes()[argIdx]
+		}
+		_, outputType, err = execagg.GetWindowFunctionInfo(funcSpec, argTypes...)
+		if err != nil {
+			return execinfrapb.WindowerSpec_WindowFn{}, outputType, err
+		}
 	}
 	// Populating column ordering from ORDER BY clause of funcInProgress.
 	ordCols := make([]execinfrapb.Ordering_Column, 0, len(funcInProgress.columnOrdering))
@@ -57,6 +72,7 @@ func createWindowFnSpec(
 		Ordering:     execinfrapb.Ordering{Columns: ordCols},
 		FilterColIdx: int32(funcInProgress.filterColIdx),
 		OutputColIdx: uint32(funcInProgress.outputColIdx),
+		UserDefined:  userDefined,
 	}
 	if funcInProgress.frame != nil {
 		// funcInProgress has a custom window frame.
@@ -256,6 +256,7 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 	}
 	trSpec.LockingStrength = descpb.ToScanLockingStrength(params.Locking.Strength)
 	trSpec.LockingWaitPolicy = descpb.ToScanLockingWaitPolicy(params.Locking.WaitPolicy)
+	initTableReaderSample(trSpec, params.Sample)
 	if trSpec.LockingStrength != descpb.ScanLockingStrength_FOR_NONE {
 		// Scans that are performing row-level locking cannot currently be
 		// distributed because their locks would not be propagated back to
@@ -460,14 +461,24 @@ func populateAggFuncSpec(
 	argCols []exec.NodeColumnOrdinal,
 	constArgs []tree.Datum,
 	filter exec.NodeColumnOrdinal,
+	userDefined *tree.AggregateSupportExprs,
+	resultType *types.T,
 	planCtx *PlanningCtx,
 	physPlan *PhysicalPlan,
 ) (argumentsColumnTypes []*types.T, err error) {
-	funcIdx, err := execinfrapb.GetAggregateFuncIdx(funcName)
-	if err != nil {
-		return nil, err
+	if userDefined != nil {
+		spec.Func = execinfrapb.UserDefined
+		spec.UserDefined, err = makeUserDefinedAggregateSpec(ctx, planCtx, userDefined, resultType)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		funcIdx, err := execinfrapb.GetAggregateFuncIdx(funcName)
+		if err != nil {
+			return nil, err
+		}
+		spec.Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 	}
-	spec.Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 	spec.Distinct = distinct
 	spec.ColIdx = make([]uint32, len(argCols))
 	for i, col := range argCols {
@@ -514,7 +525,7 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 			argColsScratch[0] = col
 			_, err = populateAggFuncSpec(
 				e.ctx, spec, builtins.AnyNotNull, false /* distinct*/, argColsScratch,
-				nil /* constArgs */, noFilter, planCtx, physPlan,
+				nil /* constArgs */, noFilter, nil /* userDefined */, nil /* resultType */, planCtx, physPlan,
 			)
 			if err != nil {
 				return nil, err
@@ -527,7 +538,7 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 		agg := &aggregations[j]
 		argumentsColumnTypes[i], err = populateAggFuncSpec(
 			e.ctx, spec, agg.FuncName, agg.Distinct, agg.ArgCols,
-			agg.ConstArgs, agg.Filter, planCtx, physPlan,
+			agg.ConstArgs, agg.Filter, agg.UserDefined, agg.ResultType, planCtx, physPlan,
 		)
 		if err != nil {
 			return nil, err
@@ -999,6 +1010,7 @@ func (e *distSQLSpecExecFactory) ConstructUpsert(
 	arbiterIndexes cat.IndexOrdinals,
 	arbiterConstraints cat.UniqueOrdinals,
 	canaryCol exec.NodeColumnOrdinal,
+	deleteCol exec.NodeColumnOrdinal,
 	insertCols exec.TableColumnOrdinalSet,
 	fetchCols exec.TableColumnOrdinalSet,
 	updateCols exec.TableColumnOrdinalSet,
@@ -1063,6 +1075,12 @@ func (e *distSQLSpecExecFactory) ConstructCreateFunction(
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: create function")
 }
 
+func (e *distSQLSpecExecFactory) ConstructCall(
+	proc tree.TypedExpr, columns colinfo.ResultColumns,
+) (exec.Node, error) {
+	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: call")
+}
+
 func (e *distSQLSpecExecFactory) ConstructSequenceSelect(sequence cat.Sequence) (exec.Node, error) {
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: sequence select")
 }
@@ -1074,7 +1092,7 @@ func (e *distSQLSpecExecFactory) ConstructSaveTable(
 }
 
 func (e *distSQLSpecExecFactory) ConstructErrorIfRows(
-	input exec.Node, mkErr exec.MkErrFn,
+	input exec.Node, mkErr exec.MkErrFn, deferrable *exec.DeferrableConstraint,
 ) (exec.Node, error) {
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: error if rows")
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -25,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -75,6 +77,12 @@ func (p *planner) DropFunction(
 		if err != nil {
 			return nil, err
 		}
+		if mut.IsProcedure != n.IsProcedure {
+			return nil, sqlerrors.NewWrongRoutineKindError(&fn.FuncName, mut.IsProcedure)
+		}
+		if isAggregate := mut.Aggregate != nil; isAggregate != n.IsAggregate {
+			return nil, sqlerrors.NewWrongAggregateKindError(&fn.FuncName, isAggregate)
+		}
 		if n.DropBehavior != tree.DropCascade && len(mut.DependedOnBy) > 0 {
 			dependedOnByIDs := make([]descpb.ID, 0, len(mut.DependedOnBy))
 			for _, ref := range mut.DependedOnBy {
@@ -217,6 +225,22 @@ func (p *planner) dropFunctionImpl(ctx context.Context, fnMutable *funcdesc.Muta
 		}
 	}
 
+	// Remove backreference from the support functions of an aggregate.
+	if agg := fnMutable.Aggregate; agg != nil {
+		supportFnIDs := catalog.MakeDescriptorIDSet(agg.TransitionFunctionID, agg.FinalFunctionID, agg.CombineFunctionID)
+		supportFnIDs.Remove(descpb.InvalidID)
+		for _, id := range supportFnIDs.Ordered() {
+			refMutable, err := p.Descriptors().MutableByID(p.txn).Function(ctx, id)
+			if err != nil {
+				return err
+			}
+			refMutable.RemoveReference(fnMutable.GetID())
+			if err := p.writeFuncSchemaChange(ctx, refMutable); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Remove backreference from types referenced by this UDF.
 	jobDesc := fmt.Sprintf(
 		"updating type backreference %v for function %s(%d)",
@@ -241,6 +265,13 @@ func (p *planner) dropFunctionImpl(ctx context.Context, fnMutable *funcdesc.Muta
 		return err
 	}
 
+	// Remove the comment on the function, if any.
+	if err := p.deleteComment(
+		ctx, fnMutable.GetID(), 0 /* subID */, catalogkeys.FunctionCommentType,
+	); err != nil {
+		return err
+	}
+
 	// Mark the UDF as dropped.
 	fnMutable.SetDropped()
 	if err := p.writeDropFuncSchemaChange(ctx, fnMutable); err != nil {
@@ -74,6 +74,10 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 		td[droppedDesc.ID] = toDelete{tn, droppedDesc}
 	}
 
+	if err := p.addInheritingTablesToDrop(ctx, td, n.DropBehavior); err != nil {
+		return nil, err
+	}
+
 	for _, toDel := range td {
 		droppedDesc := toDel.desc
 		for _, fk := range droppedDesc.InboundForeignKeys() {
@@ -257,6 +261,11 @@ func (p *planner) dropTableImpl(
 	}
 	tableDesc.InboundFKs = nil
 
+	// Remove the references from the parents and children of the table.
+	if err := p.removeInheritanceReferences(ctx, tableDesc); err != nil {
+		return droppedViews, err
+	}
+
 	// Remove sequence dependencies.
 	for _, col := range tableDesc.PublicColumns() {
 		if err := p.removeSequenceDependencies(ctx, tableDesc, col); err != nil {
@@ -38,10 +38,32 @@ type dropTypeNode struct {
 var _ planNode = &dropTypeNode{n: nil}
 
 func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, error) {
+	return p.dropTypes(ctx, n, false /* isDomain */)
+}
+
+// DropDomain drops the given domains. Domains are type descriptors, so
+// dropping them works just like DROP TYPE.
+func (p *planner) DropDomain(ctx context.Context, n *tree.DropDomain) (planNode, error) {
+	return p.dropTypes(ctx, &tree.DropType{
+		Names:        n.Names,
+		IfExists:     n.IfExists,
+		DropBehavior: n.DropBehavior,
+	}, true /* isDomain */)
+}
+
+// dropTypes plans a DROP TYPE statement, or a DROP DOMAIN statement if isDomain
+// is set.
+func (p *planner) dropTypes(
+	ctx context.Context, n *tree.DropType, isDomain bool,
+) (planNode, error) {
+	stmtName := "DROP TYPE"
+	if isDomain {
+		stmtName = "DROP DOMAIN"
+	}
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"DROP TYPE",
+		stmtName,
 	); err != nil {
 		return nil, err
 	}
@@ -51,7 +73,7 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		toDrop: make(map[descpb.ID]*typedesc.Mutable),
 	}
 	if n.DropBehavior == tree.DropCascade {
-		return nil, unimplemented.NewWithIssue(51480, "DROP TYPE CASCADE is not yet supported")
+		return nil, unimplemented.NewWithIssuef(51480, "%s CASCADE is not yet supported", stmtName)
 	}
 	for _, name := range n.Names {
 		// Resolve the desired type descriptor.
@@ -66,6 +88,9 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		if _, ok := node.toDrop[typeDesc.ID]; ok {
 			continue
 		}
+		if isDomain && typeDesc.Kind != descpb.TypeDescriptor_DOMAIN {
+			return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name)
+		}
 		switch typeDesc.Kind {
 		case descpb.TypeDescriptor_ALIAS:
 			// The implicit array types are not directly droppable.
@@ -91,6 +116,12 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 				"cannot drop type %q because table %q requires it",
 				name, name,
 			)
+		case descpb.TypeDescriptor_DOMAIN:
+			if !isDomain {
+				return nil, errors.WithHint(
+					pgerror.Newf(pgcode.WrongObjectType, "%q is a domain", name),
+					"use DROP DOMAIN instead")
+			}
 		}
 
 		// Check if we can drop the type.
@@ -98,6 +129,12 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 			return nil, err
 		}
 
+		// Record the descriptor for deletion.
+		node.toDrop[typeDesc.ID] = typeDesc
+		// Domains have no array type.
+		if typeDesc.ArrayTypeID == descpb.InvalidID {
+			continue
+		}
 		// Get the array type that needs to be dropped as well.
 		mutArrayDesc, err := p.Descriptors().MutableByID(p.txn).Type(ctx, typeDesc.ArrayTypeID)
 		if err != nil {
@@ -107,8 +144,6 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		if err := p.canDropTypeDesc(ctx, mutArrayDesc, n.DropBehavior); err != nil {
 			return nil, err
 		}
-		// Record these descriptors for deletion.
-		node.toDrop[typeDesc.ID] = typeDesc
 		node.toDrop[mutArrayDesc.ID] = mutArrayDesc
 	}
 	return node, nil
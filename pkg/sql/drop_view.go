@@ -43,7 +43,7 @@ func (p *planner) DropView(ctx context.Context, n *tree.DropView) (planNode, err
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"DROP VIEW",
+		n.StatementTag(),
 	); err != nil {
 		return nil, err
 	}
@@ -62,6 +62,9 @@ func (p *planner) DropView(ctx context.Context, n *tree.DropView) (planNode, err
 		if err := checkViewMatchesMaterialized(droppedDesc, true /* requireView */, n.IsMaterialized); err != nil {
 			return nil, err
 		}
+		if err := checkViewMatchesForeignTable(droppedDesc, n.IsForeignTable); err != nil {
+			return nil, err
+		}
 
 		td = append(td, toDelete{tn, droppedDesc})
 	}
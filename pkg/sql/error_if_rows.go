@@ -26,6 +26,12 @@ type errorIfRowsNode struct {
 	// produced.
 	mkErr exec.MkErrFn
 
+	// deferrable is set if the node checks a DEFERRABLE constraint. If the
+	// constraint is deferred in the current transaction, the rows produced are
+	// recorded instead of returning an error, and their keys are re-checked
+	// when the transaction commits.
+	deferrable *exec.DeferrableConstraint
+
 	nexted bool
 }
 
@@ -39,6 +45,27 @@ func (n *errorIfRowsNode) Next(params runParams) (bool, error) {
 	}
 	n.nexted = true
 
+	if n.deferrable != nil {
+		if d := params.extendedEvalCtx.deferredConstraints; d != nil && d.isDeferred(n.deferrable) {
+			rows := d.newViolations(planColumns(n.plan))
+			for {
+				ok, err := n.plan.Next(params)
+				if err == nil && ok {
+					_, err = rows.AddRow(params.ctx, n.plan.Values())
+				}
+				if err != nil {
+					rows.Close(params.ctx)
+					return false, err
+				}
+				if !ok {
+					break
+				}
+			}
+			d.addViolations(params.ctx, n.deferrable, n.mkErr, rows)
+			return false, nil
+		}
+	}
+
 	ok, err := n.plan.Next(params)
 	if err != nil {
 		return false, err
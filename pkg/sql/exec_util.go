@@ -1976,6 +1976,12 @@ func checkResultType(typ *types.T) error {
 	case types.TSVectorFamily:
 	case types.IntervalFamily:
 	case types.JsonFamily:
+	case types.JsonpathFamily:
+	case types.PointFamily:
+	case types.BoxFamily:
+	case types.PathFamily:
+	case types.PolygonFamily:
+	case types.CircleFamily:
 	case types.UuidFamily:
 	case types.INetFamily:
 	case types.OidFamily:
@@ -2164,6 +2170,10 @@ type SessionArgs struct {
 	// JWTAuthEnabled indicates if the customer is passing a JWT token in the
 	// password field.
 	JWTAuthEnabled bool
+	// ReplicationMode is the value of the "replication" connection parameter.
+	// Connections in a replication mode accept the commands of the streaming
+	// replication protocol in addition to SQL.
+	ReplicationMode sessiondatapb.ReplicationMode
 }
 
 // SessionRegistry stores a set of all sessions on this node.
@@ -106,10 +106,94 @@ func GetAggregateConstructor(
 		argTypes[len(aggInfo.ColIdx)+j] = d.ResolvedType()
 		arguments[j] = d
 	}
+	if aggInfo.UserDefined != nil {
+		constructor, err = getUserDefinedAggregateConstructor(
+			ctx, evalCtx, semaCtx, aggInfo.UserDefined, argTypes,
+		)
+		outputType = aggInfo.UserDefined.ResultType
+		return
+	}
 	constructor, outputType, err = GetAggregateInfo(aggInfo.Func, argTypes...)
 	return
 }
 
+// GetAggregateOutputType returns the output type of the given aggregation when
+// applied on the given types.
+func GetAggregateOutputType(
+	aggInfo *execinfrapb.AggregatorSpec_Aggregation, inputTypes ...*types.T,
+) (*types.T, error) {
+	if aggInfo.UserDefined != nil {
+		return aggInfo.UserDefined.ResultType, nil
+	}
+	_, outputType, err := GetAggregateInfo(aggInfo.Func, inputTypes...)
+	return outputType, err
+}
+
+// getUserDefinedAggregateConstructor deserializes the support expressions of a
+// user-defined aggregate and returns a constructor that evaluates them.
+func getUserDefinedAggregateConstructor(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+	argTypes []*types.T,
+) (AggregateConstructor, error) {
+	deserialize := func(expr execinfrapb.Expression, typs ...*types.T) (tree.TypedExpr, error) {
+		var h execinfrapb.ExprHelper
+		if err := h.Init(ctx, expr, typs, semaCtx, evalCtx); err != nil {
+			return nil, errors.Wrapf(err, "%s", expr)
+		}
+		return h.Expr, nil
+	}
+	support := &tree.AggregateSupportExprs{
+		TransitionStrict: spec.TransitionStrict,
+		FinalStrict:      spec.FinalStrict,
+		StateType:        spec.StateType,
+		InitialState:     tree.DNull,
+	}
+	transitionTypes := make([]*types.T, 0, len(argTypes)+1)
+	transitionTypes = append(transitionTypes, spec.StateType)
+	transitionTypes = append(transitionTypes, argTypes...)
+	var err error
+	if support.Transition, err = deserialize(spec.Transition, transitionTypes...); err != nil {
+		return nil, err
+	}
+	if support.Final, err = deserialize(spec.Final, spec.StateType); err != nil {
+		return nil, err
+	}
+	if !spec.InitialState.Empty() {
+		var h execinfrapb.ExprHelper
+		// Pass nil types and row - there are no variables in the expression.
+		if err = h.Init(ctx, spec.InitialState, nil /* types */, semaCtx, evalCtx); err != nil {
+			return nil, err
+		}
+		if support.InitialState, err = h.Eval(ctx, nil /* row */); err != nil {
+			return nil, err
+		}
+	}
+	numArgs := len(argTypes)
+	return func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return builtins.NewUserDefinedAggregate(evalCtx, support, numArgs)
+	}, nil
+}
+
+// GetUserDefinedWindowFunctionInfo returns the windowFunc constructor and the
+// return type of the given user-defined aggregate when it is computed as a
+// window function over arguments of the given types.
+func GetUserDefinedWindowFunctionInfo(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+	inputTypes ...*types.T,
+) (windowConstructor func(*eval.Context) eval.WindowFunc, returnType *types.T, err error) {
+	constructor, err := getUserDefinedAggregateConstructor(ctx, evalCtx, semaCtx, spec, inputTypes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return builtins.NewFramableAggregateWindowFunc(constructor), spec.ResultType, nil
+}
+
 // GetWindowFunctionInfo returns windowFunc constructor and the return type
 // when given fn is applied to given inputTypes.
 func GetWindowFunctionInfo(
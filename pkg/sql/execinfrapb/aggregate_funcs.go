@@ -74,4 +74,5 @@ const (
 	FinalCorr               = AggregatorSpec_FINAL_CORR
 	FinalSqrdiff            = AggregatorSpec_FINAL_SQRDIFF
 	ArrayCatAgg             = AggregatorSpec_ARRAY_CAT_AGG
+	UserDefined             = AggregatorSpec_USER_DEFINED
 )
@@ -42,6 +42,11 @@ func (a AggregatorSpec_Aggregation) Equals(b AggregatorSpec_Aggregation) bool {
 	if a.Func != b.Func || a.Distinct != b.Distinct {
 		return false
 	}
+	if a.UserDefined != nil || b.UserDefined != nil {
+		// User-defined aggregations are never considered equal so that they
+		// are not de-duplicated.
+		return false
+	}
 	if a.FilterColIdx == nil {
 		if b.FilterColIdx != nil {
 			return false
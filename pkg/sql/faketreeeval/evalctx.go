@@ -242,6 +242,13 @@ func (*DummyEvalPlanner) ExternalReadFile(ctx context.Context, uri string) ([]by
 	return nil, errors.WithStack(errEvalPlanner)
 }
 
+// ReadForeignTableRows is part of the Planner interface.
+func (*DummyEvalPlanner) ReadForeignTableRows(
+	ctx context.Context, tableOID oid.Oid, typs []*types.T, colNames []string,
+) (eval.InternalRows, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
 // ExternalWriteFile is part of the Planner interface.
 func (*DummyEvalPlanner) ExternalWriteFile(ctx context.Context, uri string, content []byte) error {
 	return errors.WithStack(errEvalPlanner)
@@ -571,6 +578,16 @@ func (ep *DummySessionAccessor) HasViewActivityOrViewActivityRedactedRole(
 	return false, errors.WithStack(errEvalSessionVar)
 }
 
+// SendNotification is part of the eval.SessionAccessor interface.
+func (ep *DummySessionAccessor) SendNotification(_ context.Context, _, _ string) error {
+	return errors.WithStack(errEvalSessionVar)
+}
+
+// ListeningChannels is part of the eval.SessionAccessor interface.
+func (ep *DummySessionAccessor) ListeningChannels() ([]string, error) {
+	return nil, errors.WithStack(errEvalSessionVar)
+}
+
 // DummyClientNoticeSender implements the eval.ClientNoticeSender interface.
 type DummyClientNoticeSender struct{}
 
@@ -0,0 +1,403 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"go/constant"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// Foreign tables are read-only tables whose rows come from a file in
+// external storage. They are stored as views over the
+// crdb_internal.read_foreign_table generator, so that they can be planned,
+// joined and dropped like any other view. The view only refers to the table
+// by ID: the location of the file, which may carry credentials, is only kept
+// in the ForeignTable field of the descriptor and is resolved when the table
+// is read, with the privileges of the user running the query.
+
+const (
+	foreignTableLocationOption = "location"
+	foreignTableFormatOption   = "format"
+)
+
+// foreignTableFormatOptions lists the supported formats of foreign tables,
+// and the options each format accepts besides location and format.
+var foreignTableFormatOptions = map[string]map[string]struct{}{
+	"csv":     {"delimiter": {}, "nullif": {}, "skip": {}},
+	"parquet": {},
+	"avro":    {},
+}
+
+// ReadForeignTableRowsHook is the hook point for the readers of the files
+// backing foreign tables, which live in the importer package.
+var ReadForeignTableRowsHook = func(
+	ctx context.Context,
+	p PlanHookState,
+	uri, format string,
+	options map[string]string,
+	typs []*types.T,
+	colNames []string,
+) (eval.InternalRows, error) {
+	return nil, errors.AssertionFailedf("foreign table readers are not linked into this binary")
+}
+
+// ReadForeignTableRows is part of the eval.Planner interface.
+func (p *planner) ReadForeignTableRows(
+	ctx context.Context, tableOID oid.Oid, typs []*types.T, colNames []string,
+) (eval.InternalRows, error) {
+	desc, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Table(ctx, descpb.ID(tableOID))
+	if err != nil {
+		return nil, err
+	}
+	ft := desc.TableDesc().ForeignTable
+	if ft == nil {
+		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a foreign table", desc.GetName())
+	}
+	if err := p.CheckPrivilege(ctx, desc, privilege.SELECT); err != nil {
+		return nil, err
+	}
+	uri, format, options := foreignTableSource(ft)
+	return ReadForeignTableRowsHook(ctx, p, uri, format, options, typs, colNames)
+}
+
+type createForeignTableNode struct {
+	n      *tree.CreateForeignTable
+	dbDesc catalog.DatabaseDescriptor
+}
+
+// CreateForeignTable creates a foreign table.
+// Privileges: CREATE on the database and the schema.
+func (p *planner) CreateForeignTable(
+	ctx context.Context, n *tree.CreateForeignTable,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+
+	un := n.Table.ToUnresolvedObjectName()
+	dbDesc, _, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	n.Table.ObjectNamePrefix = prefix
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	return &createForeignTableNode{
+		n:      n,
+		dbDesc: dbDesc,
+	}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE FOREIGN TABLE performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *createForeignTableNode) ReadingOwnWrites() {}
+
+func (n *createForeignTableNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("foreign_table"))
+	p := params.p
+
+	columns, err := n.resultColumns(params)
+	if err != nil {
+		return err
+	}
+	foreignTable, err := makeForeignTable(n.n, p.User())
+	if err != nil {
+		return err
+	}
+
+	schema, err := getSchemaForCreateTable(params, n.dbDesc, tree.PersistencePermanent, &n.n.Table,
+		tree.ResolveRequireViewDesc, n.n.IfNotExists)
+	if err != nil {
+		if sqlerrors.IsRelationAlreadyExistsError(err) && n.n.IfNotExists {
+			return nil
+		}
+		return err
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Tables,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	// creationTime is initialized to a zero value and populated at read time.
+	// See the comment in desc.MaybeIncrementVersion.
+	var creationTime hlc.Timestamp
+	desc, err := makeViewTableDesc(
+		params.ctx,
+		n.n.Table.Table(),
+		makeForeignTableViewQuery(id, columns),
+		n.dbDesc.GetID(),
+		schema.GetID(),
+		id,
+		columns,
+		creationTime,
+		privs,
+		&p.semaCtx,
+		p.EvalContext(),
+		p.EvalContext().Settings,
+		tree.PersistencePermanent,
+		n.dbDesc.IsMultiRegion(),
+		nil, /* sc */
+	)
+	if err != nil {
+		return err
+	}
+	desc.ForeignTable = foreignTable
+
+	if err := p.createDescriptor(
+		params.ctx,
+		&desc,
+		fmt.Sprintf("CREATE FOREIGN TABLE %q", n.n.Table.FQString()),
+	); err != nil {
+		return err
+	}
+	if err := validateDescriptor(params.ctx, p, &desc); err != nil {
+		return err
+	}
+
+	return p.logEvent(params.ctx,
+		desc.ID,
+		&eventpb.CreateTable{
+			TableName: n.n.Table.FQString(),
+		})
+}
+
+// resultColumns returns the columns of the foreign table. Foreign tables only
+// support plain columns of builtin types.
+func (n *createForeignTableNode) resultColumns(params runParams) (colinfo.ResultColumns, error) {
+	columns := make(colinfo.ResultColumns, 0, len(n.n.Defs))
+	for _, def := range n.n.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"constraints and indexes are not supported on foreign tables")
+		}
+		if d.HasDefaultExpr() || d.IsComputed() || d.IsSerial || d.HasColumnFamily() ||
+			d.PrimaryKey.IsPrimaryKey || d.Unique.IsUnique || len(d.CheckExprs) > 0 ||
+			d.References.Table != nil || d.Nullable.Nullability == tree.NotNull {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q: column constraints and defaults are not supported on foreign tables", d.Name)
+		}
+		typ, err := tree.ResolveType(params.ctx, d.Type, params.p.semaCtx.GetTypeResolver())
+		if err != nil {
+			return nil, err
+		}
+		if typ.UserDefined() {
+			return nil, unimplemented.New("foreign table user-defined types",
+				"user-defined types are not supported in foreign tables")
+		}
+		if err := colinfo.ValidateColumnDefType(params.ctx, params.p.ExecCfg().Settings.Version, typ); err != nil {
+			return nil, err
+		}
+		columns = append(columns, colinfo.ResultColumn{Name: string(d.Name), Typ: typ})
+	}
+	return columns, nil
+}
+
+// makeForeignTable validates the options of a CREATE FOREIGN TABLE statement.
+// It returns the foreign table metadata to store in the descriptor.
+func makeForeignTable(
+	n *tree.CreateForeignTable, user username.SQLUsername,
+) (*descpb.TableDescriptor_ForeignTable, error) {
+	res := &descpb.TableDescriptor_ForeignTable{Server: string(n.Server)}
+	seen := make(map[string]struct{}, len(n.Options))
+	for _, opt := range n.Options {
+		key := string(opt.Key)
+		value, ok := opt.Value.(*tree.StrVal)
+		if !ok {
+			return nil, errors.AssertionFailedf("unexpected option value %T", opt.Value)
+		}
+		if _, ok := seen[key]; ok {
+			return nil, pgerror.Newf(pgcode.DuplicateObject, "option %q provided more than once", key)
+		}
+		seen[key] = struct{}{}
+		res.Options = append(res.Options, descpb.TableDescriptor_ForeignTable_Option{
+			Key: key, Value: value.RawString(),
+		})
+	}
+
+	uri, format, options := foreignTableSource(res)
+	if format == "" {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, `option "format" is required`)
+	}
+	allowed, ok := foreignTableFormatOptions[format]
+	if !ok {
+		return nil, errors.WithHint(
+			pgerror.Newf(pgcode.InvalidParameterValue, "unsupported format %q", format),
+			"supported formats are csv, parquet and avro",
+		)
+	}
+	for key := range options {
+		if _, ok := allowed[key]; !ok {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"option %q is not supported for format %s", key, format)
+		}
+	}
+	if _, hasLocation := seen[foreignTableLocationOption]; n.Server == "" && !hasLocation {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			`option "location" is required unless a SERVER is given`)
+	}
+	if _, err := cloud.ExternalStorageConfFromURI(uri, user); err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid location")
+	}
+	return res, nil
+}
+
+// foreignTableSource returns the URI and format of the file backing a foreign
+// table, together with the reader options.
+func foreignTableSource(
+	ft *descpb.TableDescriptor_ForeignTable,
+) (uri, format string, options map[string]string) {
+	var location string
+	options = make(map[string]string)
+	for _, opt := range ft.Options {
+		switch opt.Key {
+		case foreignTableLocationOption:
+			location = opt.Value
+		case foreignTableFormatOption:
+			format = strings.ToLower(opt.Value)
+		default:
+			options[opt.Key] = opt.Value
+		}
+	}
+	if ft.Server == "" {
+		return location, format, options
+	}
+	uri = "external://" + ft.Server
+	if location != "" {
+		uri += "/" + strings.TrimPrefix(location, "/")
+	}
+	return uri, format, options
+}
+
+// makeForeignTableViewQuery returns the query of the view that stores the
+// foreign table with the given ID, of the form:
+//
+//	SELECT * FROM crdb_internal.read_foreign_table(id:::REGCLASS)
+//	  AS t (col type, ...)
+//
+// The table is referenced by ID so that the view query doesn't need to change
+// when the table is renamed, and is rewritten like sequence references when
+// the table is restored.
+func makeForeignTableViewQuery(id descpb.ID, columns colinfo.ResultColumns) string {
+	cols := make(tree.ColumnDefList, len(columns))
+	for i := range columns {
+		cols[i] = tree.ColumnDef{Name: tree.Name(columns[i].Name), Type: columns[i].Typ}
+	}
+	sel := &tree.Select{Select: &tree.SelectClause{
+		Exprs: tree.SelectExprs{tree.StarSelectExpr()},
+		From: tree.From{Tables: tree.TableExprs{&tree.AliasedTableExpr{
+			Expr: &tree.RowsFromExpr{Items: tree.Exprs{&tree.FuncExpr{
+				Func: tree.ResolvableFunctionReference{
+					FunctionReference: tree.NewUnresolvedName("crdb_internal", "read_foreign_table"),
+				},
+				Exprs: tree.Exprs{&tree.AnnotateTypeExpr{
+					Type:       types.RegClass,
+					SyntaxMode: tree.AnnotateShort,
+					Expr:       tree.NewNumVal(constant.MakeInt64(int64(id)), "", false),
+				}},
+			}}},
+			As: tree.AliasClause{Alias: "t", Cols: cols},
+		}}},
+	}}
+	return tree.AsStringWithFlags(sel, tree.FmtParsable)
+}
+
+// ShowCreateForeignTable returns a valid SQL representation of the CREATE
+// FOREIGN TABLE statement used to create the given foreign table. The
+// credentials in its location are redacted.
+func ShowCreateForeignTable(
+	tn *tree.TableName, desc catalog.TableDescriptor, redactableValues bool,
+) (string, error) {
+	ft := desc.TableDesc().ForeignTable
+	n := &tree.CreateForeignTable{Table: *tn, Server: tree.Name(ft.Server)}
+	for _, col := range desc.PublicColumns() {
+		def := &tree.ColumnTableDef{Name: tree.Name(col.GetName()), Type: col.GetType()}
+		def.Nullable.Nullability = tree.SilentNull
+		n.Defs = append(n.Defs, def)
+	}
+	opts, err := sanitizedForeignTableOptions(ft)
+	if err != nil {
+		return "", err
+	}
+	for _, opt := range opts {
+		n.Options = append(n.Options, tree.KVOption{Key: tree.Name(opt.Key), Value: tree.NewStrVal(opt.Value)})
+	}
+	fmtFlags := tree.FmtSimple
+	if redactableValues {
+		fmtFlags |= tree.FmtMarkRedactionNode | tree.FmtOmitNameRedaction
+	}
+	return tree.AsStringWithFlags(n, fmtFlags), nil
+}
+
+// sanitizedForeignTableOptions returns the options of a foreign table with
+// the credentials in its location redacted.
+func sanitizedForeignTableOptions(
+	ft *descpb.TableDescriptor_ForeignTable,
+) ([]descpb.TableDescriptor_ForeignTable_Option, error) {
+	res := make([]descpb.TableDescriptor_ForeignTable_Option, len(ft.Options))
+	for i, opt := range ft.Options {
+		res[i] = opt
+		// Locations relative to an external connection carry no credentials.
+		if opt.Key == foreignTableLocationOption && ft.Server == "" {
+			var err error
+			if res[i].Value, err = cloud.SanitizeExternalStorageURI(opt.Value, nil /* extraParams */); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+func (*createForeignTableNode) Next(runParams) (bool, error) { return false, nil }
+func (*createForeignTableNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createForeignTableNode) Close(context.Context)        {}
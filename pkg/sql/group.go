@@ -74,6 +74,9 @@ type aggregateFuncHolder struct {
 	arguments tree.Datums
 	// isDistinct indicates whether only distinct values are aggregated.
 	isDistinct bool
+	// userDefined contains the support expressions of a user-defined
+	// aggregate. It is nil for builtin aggregates.
+	userDefined *tree.AggregateSupportExprs
 }
 
 // newAggregateFuncHolder creates an aggregateFuncHolder.
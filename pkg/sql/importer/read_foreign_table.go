@@ -0,0 +1,268 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/cloudprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/parquet"
+	"github.com/cockroachdb/errors"
+	"github.com/linkedin/goavro/v2"
+)
+
+func init() {
+	sql.ReadForeignTableRowsHook = readForeignTableRows
+}
+
+// readForeignTableRows reads the rows of the file backing a foreign table. The
+// file is read with the privileges of the current user, as for IMPORT.
+func readForeignTableRows(
+	ctx context.Context,
+	p sql.PlanHookState,
+	uri, format string,
+	options map[string]string,
+	typs []*types.T,
+	colNames []string,
+) (eval.InternalRows, error) {
+	if err := cloudprivilege.CheckDestinationPrivileges(ctx, p, []string{uri}); err != nil {
+		return nil, err
+	}
+	store, err := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI(ctx, uri, p.User())
+	if err != nil {
+		return nil, err
+	}
+	file, _, err := store.ReadFile(ctx, "", cloud.ReadOptions{NoFileSize: true})
+	if err != nil {
+		return nil, errors.CombineErrors(err, store.Close())
+	}
+	rows := &foreignTableRows{store: store, file: file}
+	evalCtx := &p.ExtendedEvalContext().Context
+	input := ioctx.ReaderCtxAdapter(ctx, file)
+	switch format {
+	case "csv":
+		rows.next, err = newForeignCSVReader(input, options, typs, evalCtx)
+	case "avro":
+		rows.next, err = newForeignAvroReader(input, typs, colNames, evalCtx)
+	case "parquet":
+		rows.next, rows.closeFn, err = newForeignParquetReader(ctx, file, typs, colNames)
+	default:
+		err = pgerror.Newf(pgcode.InvalidParameterValue, "unsupported foreign table format %q", format)
+	}
+	if err != nil {
+		return nil, errors.CombineErrors(err, rows.Close())
+	}
+	return rows, nil
+}
+
+// foreignTableRows implements eval.InternalRows over a file in external
+// storage.
+type foreignTableRows struct {
+	store cloud.ExternalStorage
+	file  ioctx.ReadCloserCtx
+	// next returns the next row of the file, or nil if there are no more rows.
+	next func(ctx context.Context) (tree.Datums, error)
+	// closeFn, if set, releases the resources held by next.
+	closeFn func() error
+
+	cur  tree.Datums
+	done bool
+}
+
+var _ eval.InternalRows = &foreignTableRows{}
+
+// Next implements the eval.InternalRows interface.
+func (r *foreignTableRows) Next(ctx context.Context) (bool, error) {
+	if r.done {
+		return false, nil
+	}
+	row, err := r.next(ctx)
+	if err != nil || row == nil {
+		r.done = true
+		return false, errors.CombineErrors(err, r.Close())
+	}
+	r.cur = row
+	return true, nil
+}
+
+// Cur implements the eval.InternalRows interface.
+func (r *foreignTableRows) Cur() tree.Datums {
+	return r.cur
+}
+
+// Close implements the eval.InternalRows interface.
+func (r *foreignTableRows) Close() error {
+	var err error
+	if r.closeFn != nil {
+		err = r.closeFn()
+		r.closeFn = nil
+	}
+	if r.file != nil {
+		err = errors.CombineErrors(err, r.file.Close(context.Background()))
+		r.file = nil
+	}
+	if r.store != nil {
+		err = errors.CombineErrors(err, r.store.Close())
+		r.store = nil
+	}
+	return err
+}
+
+// newForeignCSVReader returns a function which reads the rows of a CSV file.
+// The fields of each record are parsed as the types of the columns, in order.
+func newForeignCSVReader(
+	input io.Reader, options map[string]string, typs []*types.T, evalCtx *eval.Context,
+) (func(ctx context.Context) (tree.Datums, error), error) {
+	cr := csv.NewReader(input)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	// As for IMPORT, fields are only NULL if the nullif option is given.
+	var nullIf *string
+	var skip int
+	for k, v := range options {
+		switch k {
+		case "delimiter":
+			r, size := utf8.DecodeRuneInString(v)
+			if size == 0 || size != len(v) {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "delimiter must be a single character")
+			}
+			cr.Comma = r
+		case "nullif":
+			v := v
+			nullIf = &v
+		case "skip":
+			var err error
+			if skip, err = strconv.Atoi(v); err != nil || skip < 0 {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue, "skip must be a non-negative integer")
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unsupported CSV option %q", k)
+		}
+	}
+
+	rowNum := 0
+	return func(ctx context.Context) (tree.Datums, error) {
+		for {
+			record, err := cr.Read()
+			if err == io.EOF {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			rowNum++
+			if rowNum <= skip {
+				continue
+			}
+			// Ignore the optional trailing delimiter, as IMPORT does.
+			if len(record) == len(typs)+1 && record[len(typs)].Val == "" && !record[len(typs)].Quoted {
+				record = record[:len(typs)]
+			}
+			if len(record) != len(typs) {
+				return nil, pgerror.Newf(pgcode.InvalidTextRepresentation,
+					"row %d: expected %d fields, got %d", rowNum, len(typs), len(record))
+			}
+			row := make(tree.Datums, len(typs))
+			for i, field := range record {
+				if nullIf != nil && !field.Quoted && field.Val == *nullIf {
+					row[i] = tree.DNull
+					continue
+				}
+				if row[i], err = rowenc.ParseDatumStringAs(ctx, typs[i], field.Val, evalCtx); err != nil {
+					return nil, errors.Wrapf(err, "row %d: parse field %d as %s", rowNum, i+1, typs[i].SQLString())
+				}
+			}
+			return row, nil
+		}
+	}, nil
+}
+
+// newForeignAvroReader returns a function which reads the records of an Avro
+// object container file. Record fields are matched to columns by name.
+func newForeignAvroReader(
+	input io.Reader, typs []*types.T, colNames []string, evalCtx *eval.Context,
+) (func(ctx context.Context) (tree.Datums, error), error) {
+	ocf, err := goavro.NewOCFReader(bufio.NewReaderSize(input, 64<<10))
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "reading avro file")
+	}
+	colIdx := make(map[string]int, len(colNames))
+	for i, name := range colNames {
+		colIdx[name] = i
+	}
+	return func(ctx context.Context) (tree.Datums, error) {
+		if !ocf.Scan() {
+			return nil, ocf.Err()
+		}
+		native, err := ocf.Read()
+		if err != nil {
+			return nil, err
+		}
+		record, ok := native.(map[string]interface{})
+		if !ok {
+			return nil, errors.Newf("unexpected avro record type %T", native)
+		}
+		row := make(tree.Datums, len(typs))
+		for i := range row {
+			row[i] = tree.DNull
+		}
+		for f, v := range record {
+			i, ok := colIdx[lexbase.NormalizeName(f)]
+			if !ok {
+				continue
+			}
+			avroT, ok := familyToAvroT[typs[i].Family()]
+			if !ok {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"column %q: cannot read avro values as %s", colNames[i], typs[i].SQLString())
+			}
+			if row[i], err = nativeToDatum(ctx, v, typs[i], avroT, evalCtx); err != nil {
+				return nil, errors.Wrapf(err, "column %q", colNames[i])
+			}
+		}
+		return row, nil
+	}, nil
+}
+
+// newForeignParquetReader returns a function which reads the rows of a
+// parquet file. Parquet files need random access, so the whole file is read
+// into memory first.
+func newForeignParquetReader(
+	ctx context.Context, file ioctx.ReaderCtx, typs []*types.T, colNames []string,
+) (func(ctx context.Context) (tree.Datums, error), func() error, error) {
+	content, err := ioctx.ReadAll(ctx, file)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := parquet.NewReader(bytes.NewReader(content), colNames, typs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return func(ctx context.Context) (tree.Datums, error) {
+		return reader.Next()
+	}, reader.Close, nil
+}
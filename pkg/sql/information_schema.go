@@ -1300,6 +1300,7 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = catconstants.ConstraintTypePK
 					}
+					deferrability := constraintDeferrability(c)
 					if err := addRow(
 						dbNameStr,                     // constraint_catalog
 						scNameStr,                     // constraint_schema
@@ -1308,8 +1309,8 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 						scNameStr,                     // table_schema
 						tbNameStr,                     // table_name
 						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						yesOrNoDatum(deferrability.IsDeferrable()),                      // is_deferrable
+						yesOrNoDatum(deferrability == tree.ConstraintInitiallyDeferred), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -1449,6 +1450,7 @@ var (
 	tableTypeBaseTable  = tree.NewDString("BASE TABLE")
 	tableTypeView       = tree.NewDString("VIEW")
 	tableTypeTemporary  = tree.NewDString("LOCAL TEMPORARY")
+	tableTypeForeign    = tree.NewDString("FOREIGN")
 )
 
 var informationSchemaTablesTable = virtualSchemaTable{
@@ -1477,6 +1479,9 @@ func addTablesTableRow(
 		if table.IsVirtualTable() {
 			tableType = tableTypeSystemView
 			insertable = noString
+		} else if table.IsForeignTable() {
+			tableType = tableTypeForeign
+			insertable = noString
 		} else if table.IsView() {
 			tableType = tableTypeView
 			insertable = noString
@@ -1507,7 +1512,7 @@ https://www.postgresql.org/docs/9.5/infoschema-views.html`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual schemas have no views */
 			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
-				if !table.IsView() {
+				if !table.IsView() || table.IsForeignTable() {
 					return nil
 				}
 				// Note that the view query printed will not include any column aliases
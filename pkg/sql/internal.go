@@ -1441,6 +1441,13 @@ func (icc *internalClientComm) CreateDrainResult(pos CmdPos) DrainResult {
 	panic("unimplemented")
 }
 
+// CreateStartReplicationResult is part of the ClientComm interface.
+func (icc *internalClientComm) CreateStartReplicationResult(
+	cmd StartReplication, pos CmdPos,
+) StartReplicationResult {
+	panic("unimplemented")
+}
+
 // Close is part of the ClientLock interface.
 func (icc *internalClientComm) Close() {}
 
@@ -807,6 +807,13 @@ func TestLogic_gc_job_mixed(
 	runLogicTest(t, "gc_job_mixed")
 }
 
+func TestLogic_geometric_mixed(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "geometric_mixed")
+}
+
 func TestLogic_geospatial(
 	t *testing.T,
 ) {
@@ -0,0 +1,701 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed/rangefeedbuffer"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/multitenant"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+)
+
+const (
+	// maxNotifyChannelLength is the maximum length of a channel name. It
+	// matches NAMEDATALEN-1 in Postgres.
+	maxNotifyChannelLength = 63
+
+	// maxNotifyPayloadLength is the maximum length of a notification payload.
+	// It matches the default configuration of Postgres.
+	maxNotifyPayloadLength = 7999
+
+	// maxQueuedNotifications bounds the number of notifications which can be
+	// queued for a session before they are delivered to its client. Any
+	// further notifications are dropped, and the session reports an error to
+	// its client.
+	maxQueuedNotifications = 10000
+
+	// maxBufferedNotifications bounds the number of notifications which a node
+	// buffers until the rangefeed on system.notifications has seen every
+	// transaction which committed before them. Any further notifications are
+	// dropped, and the sessions listening on their channels report an error to
+	// their clients.
+	maxBufferedNotifications = 100000
+
+	// notificationRetention is how long notifications are kept in
+	// system.notifications. It only needs to cover the time it takes for the
+	// rangefeeds of the listening nodes to see them, since rangefeeds which
+	// fall behind catch up from the MVCC history of the table.
+	notificationRetention = 10 * time.Minute
+
+	// notificationCleanupInterval is how often a node which sent or listened
+	// for notifications deletes the expired rows of system.notifications.
+	notificationCleanupInterval = time.Minute
+)
+
+// notification is an asynchronous notification sent by NOTIFY or pg_notify().
+type notification struct {
+	channel   string
+	payload   string
+	senderPID int32
+}
+
+// notificationEvent is a notification read from system.notifications.
+type notificationEvent struct {
+	notification
+
+	// ts is the commit timestamp of the transaction which sent the
+	// notification.
+	ts hlc.Timestamp
+	// txnID and seq order the notifications sent by the same transaction.
+	txnID uuid.UUID
+	seq   int64
+}
+
+// Timestamp is part of the rangefeedbuffer.Event interface.
+func (e *notificationEvent) Timestamp() hlc.Timestamp { return e.ts }
+
+// notificationRegistry keeps track of the sessions on this node which are
+// listening for asynchronous notifications, indexed by channel.
+//
+// Notifications are written to system.notifications by the transaction which
+// sends them, so they are only visible once it commits and are never lost if
+// it does. The first LISTEN executed on a node starts a rangefeed on the
+// table, which buffers the notifications it sees until its frontier has
+// passed them, and then delivers them to the listening sessions in commit
+// order.
+type notificationRegistry struct {
+	cfg     *ExecutorConfig
+	stopper *stop.Stopper
+
+	mu struct {
+		syncutil.Mutex
+
+		// listeners maps each channel to the listeners on it and the time at
+		// which they started listening. A listener only receives the
+		// notifications which committed after that time.
+		listeners map[string]map[*notificationListener]hlc.Timestamp
+
+		// watcher is the rangefeed on system.notifications. It is nil until a
+		// session on this node executes LISTEN.
+		watcher *rangefeed.RangeFeed
+
+		// cleanupStarted is set once the task which deletes expired
+		// notifications is running.
+		cleanupStarted bool
+	}
+}
+
+// start records the stopper used to run the background tasks of the
+// registry.
+func (r *notificationRegistry) start(stopper *stop.Stopper) {
+	r.stopper = stopper
+}
+
+func (r *notificationRegistry) register(
+	l *notificationListener, channel string, since hlc.Timestamp,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mu.listeners == nil {
+		r.mu.listeners = make(map[string]map[*notificationListener]hlc.Timestamp)
+	}
+	listeners, ok := r.mu.listeners[channel]
+	if !ok {
+		listeners = make(map[*notificationListener]hlc.Timestamp)
+		r.mu.listeners[channel] = listeners
+	}
+	listeners[l] = since
+}
+
+func (r *notificationRegistry) unregister(l *notificationListener, channel string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners := r.mu.listeners[channel]
+	delete(listeners, l)
+	if len(listeners) == 0 {
+		delete(r.mu.listeners, channel)
+	}
+}
+
+// deliver queues the given notifications, which must be sorted in commit
+// order, for all of the sessions listening on their channels.
+func (r *notificationRegistry) deliver(ctx context.Context, evs []*notificationEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ev := range evs {
+		for l, since := range r.mu.listeners[ev.channel] {
+			if since.Less(ev.ts) {
+				l.enqueue(ctx, &ev.notification)
+			}
+		}
+	}
+}
+
+// drop records that a notification could not be buffered for the sessions
+// listening on its channel.
+func (r *notificationRegistry) drop(ev *notificationEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for l, since := range r.mu.listeners[ev.channel] {
+		if since.Less(ev.ts) {
+			l.drop()
+		}
+	}
+}
+
+// backgroundCtx returns the context used for the background tasks of the
+// registry, which outlive the statements which start them.
+func (r *notificationRegistry) backgroundCtx() context.Context {
+	return multitenant.WithTenantCostControlExemption(
+		r.cfg.AmbientCtx.AnnotateCtx(context.Background()),
+	)
+}
+
+// startWatcher starts the rangefeed on system.notifications, if it is not
+// running already.
+func (r *notificationRegistry) startWatcher(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mu.watcher != nil {
+		return nil
+	}
+	if r.stopper == nil || r.cfg.RangeFeedFactory == nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "LISTEN cannot be used in this context")
+	}
+	tableID, err := r.cfg.SystemTableIDResolver.LookupSystemTableID(
+		ctx, systemschema.NotificationsTable.GetName(),
+	)
+	if err != nil {
+		return err
+	}
+	prefix := r.cfg.Codec.TablePrefix(uint32(tableID))
+	span := roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()}
+
+	columns := systemschema.NotificationsTable.PublicColumns()
+	keyTypes := make([]*types.T, systemschema.NotificationsTable.GetPrimaryIndex().NumKeyColumns())
+	for i := range keyTypes {
+		keyTypes[i] = columns[i].GetType()
+	}
+	decoder := valueside.MakeDecoder(columns)
+	var alloc tree.DatumAlloc
+	buf := rangefeedbuffer.New(maxBufferedNotifications)
+
+	onValue := func(ctx context.Context, kv *kvpb.RangeFeedValue) {
+		if !kv.Value.IsPresent() {
+			// The row was deleted because it expired.
+			return
+		}
+		ev, err := decodeNotification(r.cfg.Codec, keyTypes, &decoder, &alloc, kv)
+		if err != nil {
+			log.Warningf(ctx, "failed to decode notification: %v", err)
+			return
+		}
+		if err := buf.Add(ev); err != nil {
+			log.Warningf(ctx, "dropping notification on channel %q: %v", ev.channel, err)
+			r.drop(ev)
+		}
+	}
+	onFrontierAdvance := func(ctx context.Context, frontier hlc.Timestamp) {
+		buffered := buf.Flush(ctx, frontier)
+		if len(buffered) == 0 {
+			return
+		}
+		evs := make([]*notificationEvent, len(buffered))
+		for i, ev := range buffered {
+			evs[i] = ev.(*notificationEvent)
+		}
+		// The buffer sorts the notifications by commit timestamp. Order the
+		// notifications of each transaction in the order in which they were
+		// sent.
+		sort.SliceStable(evs, func(i, j int) bool {
+			a, b := evs[i], evs[j]
+			if a.ts != b.ts {
+				return a.ts.Less(b.ts)
+			}
+			if a.txnID != b.txnID {
+				return bytes.Compare(a.txnID.GetBytes(), b.txnID.GetBytes()) < 0
+			}
+			return a.seq < b.seq
+		})
+		r.deliver(ctx, evs)
+	}
+
+	watcher, err := r.cfg.RangeFeedFactory.RangeFeed(
+		r.backgroundCtx(),
+		"notifications-watcher",
+		[]roachpb.Span{span},
+		r.cfg.Clock.Now(),
+		onValue,
+		rangefeed.WithOnFrontierAdvance(onFrontierAdvance),
+	)
+	if err != nil {
+		return err
+	}
+	r.mu.watcher = watcher
+	r.maybeStartCleanupLocked()
+	return nil
+}
+
+// decodeNotification decodes a row of system.notifications.
+func decodeNotification(
+	codec keys.SQLCodec,
+	keyTypes []*types.T,
+	decoder *valueside.Decoder,
+	alloc *tree.DatumAlloc,
+	kv *kvpb.RangeFeedValue,
+) (*notificationEvent, error) {
+	key, err := rowenc.DecodeIndexKeyToDatums(codec, keyTypes, nil /* colDirs */, kv.Key, alloc)
+	if err != nil {
+		return nil, err
+	}
+	tuple, err := kv.Value.GetTuple()
+	if err != nil {
+		return nil, err
+	}
+	row, err := decoder.Decode(alloc, tuple)
+	if err != nil {
+		return nil, err
+	}
+	return &notificationEvent{
+		notification: notification{
+			channel:   string(tree.MustBeDString(key[1])),
+			payload:   string(tree.MustBeDString(key[2])),
+			senderPID: int32(tree.MustBeDInt(row[4])),
+		},
+		ts:    kv.Value.Timestamp,
+		txnID: tree.MustBeDUuid(key[0]).UUID,
+		seq:   int64(tree.MustBeDInt(row[3])),
+	}, nil
+}
+
+// maybeStartCleanup starts the task which deletes the expired rows of
+// system.notifications, if it is not running already.
+func (r *notificationRegistry) maybeStartCleanup() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maybeStartCleanupLocked()
+}
+
+func (r *notificationRegistry) maybeStartCleanupLocked() {
+	if r.mu.cleanupStarted || r.stopper == nil {
+		return
+	}
+	r.mu.cleanupStarted = true
+	_ = r.stopper.RunAsyncTask(r.backgroundCtx(), "notifications-cleanup", func(ctx context.Context) {
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(notificationCleanupInterval)
+			select {
+			case <-timer.C:
+				timer.Read = true
+				if err := r.deleteExpiredNotifications(ctx); err != nil {
+					log.Warningf(ctx, "failed to delete expired notifications: %v", err)
+				}
+			case <-r.stopper.ShouldQuiesce():
+				return
+			}
+		}
+	})
+}
+
+// deleteExpiredNotifications deletes the notifications which were sent more
+// than notificationRetention ago.
+func (r *notificationRegistry) deleteExpiredNotifications(ctx context.Context) error {
+	cutoff := r.cfg.Clock.Now().Add(-notificationRetention.Nanoseconds(), 0)
+	_, err := r.cfg.InternalDB.Executor().ExecEx(
+		ctx, "delete-expired-notifications", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.notifications WHERE crdb_internal_mvcc_timestamp < $1`,
+		eval.TimestampToDecimalDatum(cutoff),
+	)
+	return err
+}
+
+// notificationListener receives the notifications for a session.
+type notificationListener struct {
+	// wakeup is called when a notification is queued for the session, so that
+	// the session delivers it to its client as soon as it is idle.
+	wakeup func(ctx context.Context)
+
+	// channels contains the channels on which the session listens, in the order
+	// in which it started listening. It is only accessed by the session's
+	// goroutine.
+	channels []string
+
+	mu struct {
+		syncutil.Mutex
+
+		// queue contains the notifications which have not been delivered to the
+		// client yet.
+		queue []*notification
+
+		// dropped is the number of notifications which were dropped because the
+		// queue or the buffer of the node was full, since the client was last
+		// told about it.
+		dropped int
+
+		// wakeupPending is set if wakeup was called and the queue has not been
+		// drained since.
+		wakeupPending bool
+	}
+}
+
+func (l *notificationListener) enqueue(ctx context.Context, n *notification) {
+	l.mu.Lock()
+	if len(l.mu.queue) >= maxQueuedNotifications {
+		l.mu.dropped++
+		l.mu.Unlock()
+		log.Warningf(ctx, "too many queued notifications, dropping notification on channel %q", n.channel)
+		return
+	}
+	l.mu.queue = append(l.mu.queue, n)
+	wakeup := !l.mu.wakeupPending
+	l.mu.wakeupPending = true
+	l.mu.Unlock()
+	if wakeup && l.wakeup != nil {
+		l.wakeup(ctx)
+	}
+}
+
+// drop records that a notification for the session was dropped.
+func (l *notificationListener) drop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mu.dropped++
+}
+
+// drain removes and returns all of the queued notifications. If
+// resetDropped is set, it also returns and resets the number of dropped
+// notifications.
+func (l *notificationListener) drain(resetDropped bool) (queue []*notification, dropped int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	queue = l.mu.queue
+	l.mu.queue = nil
+	l.mu.wakeupPending = false
+	if resetDropped {
+		dropped = l.mu.dropped
+		l.mu.dropped = 0
+	}
+	return queue, dropped
+}
+
+func (l *notificationListener) listening(channel string) bool {
+	for _, c := range l.channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// listenOp is a LISTEN or UNLISTEN operation which takes effect when the
+// transaction which executed it commits.
+type listenOp struct {
+	// channel is empty for UNLISTEN *.
+	channel string
+	listen  bool
+}
+
+// sessionNotifications holds the LISTEN/NOTIFY state of a session.
+type sessionNotifications struct {
+	registry *notificationRegistry
+	listener notificationListener
+
+	// seq orders the notifications sent by the session.
+	seq int64
+
+	// txn contains the LISTEN and UNLISTEN operations of the current
+	// transaction, which only take effect if it commits.
+	txn struct {
+		syncutil.Mutex
+		ops []listenOp
+	}
+}
+
+func (s *sessionNotifications) addListenOp(op listenOp) {
+	s.txn.Lock()
+	defer s.txn.Unlock()
+	s.txn.ops = append(s.txn.ops, op)
+}
+
+// commit applies the LISTEN and UNLISTEN operations of the transaction which
+// just committed. The session receives the notifications committed after the
+// given time on the channels on which it started listening.
+func (s *sessionNotifications) commit(now hlc.Timestamp) {
+	s.txn.Lock()
+	ops := s.txn.ops
+	s.txn.ops = nil
+	s.txn.Unlock()
+
+	for _, op := range ops {
+		switch {
+		case op.listen:
+			if !s.listener.listening(op.channel) {
+				s.listener.channels = append(s.listener.channels, op.channel)
+				s.registry.register(&s.listener, op.channel, now)
+			}
+		case op.channel == "":
+			s.unlistenAll()
+		default:
+			for i, c := range s.listener.channels {
+				if c == op.channel {
+					s.listener.channels = append(s.listener.channels[:i], s.listener.channels[i+1:]...)
+					s.registry.unregister(&s.listener, op.channel)
+					break
+				}
+			}
+		}
+	}
+}
+
+// resetTxn discards the LISTEN and UNLISTEN operations of the current
+// transaction. The notifications it sent are discarded along with its writes.
+func (s *sessionNotifications) resetTxn() {
+	s.txn.Lock()
+	defer s.txn.Unlock()
+	s.txn.ops = nil
+}
+
+// unlistenAll stops listening on all channels.
+func (s *sessionNotifications) unlistenAll() {
+	for _, c := range s.listener.channels {
+		s.registry.unregister(&s.listener, c)
+	}
+	s.listener.channels = nil
+}
+
+// flushQueued buffers the notifications queued for the session into the
+// given result. If reportDropped is set, it returns an error if notifications
+// for the session were dropped since the last time it was reported, so that
+// the client learns that it missed notifications instead of silently losing
+// them.
+func (s *sessionNotifications) flushQueued(res NotificationResult, reportDropped bool) error {
+	queue, dropped := s.listener.drain(reportDropped)
+	for _, n := range queue {
+		res.BufferNotification(n.senderPID, n.channel, n.payload)
+	}
+	if dropped > 0 {
+		return pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"too many notifications queued for the session: %d notifications were dropped", dropped)
+	}
+	return nil
+}
+
+func validateNotification(channel, payload string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxNotifyChannelLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	if len(payload) > maxNotifyPayloadLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	return nil
+}
+
+// channelName returns the name of the channel referenced by a LISTEN, NOTIFY
+// or UNLISTEN statement.
+func channelName(n *tree.UnresolvedObjectName) (string, error) {
+	if n.NumParts > 1 {
+		return "", pgerror.Newf(pgcode.Syntax, "invalid channel name: %s", tree.ErrString(n))
+	}
+	return n.Object(), nil
+}
+
+// listenNode represents a LISTEN or UNLISTEN statement.
+type listenNode struct {
+	op listenOp
+}
+
+// Listen registers the session as a listener on a notification channel once
+// the current transaction commits.
+// Privileges: None.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	channel, err := channelName(n.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateNotification(channel, "" /* payload */); err != nil {
+		return nil, err
+	}
+	if p.extendedEvalCtx.notifications == nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "LISTEN cannot be used in this context")
+	}
+	if err := checkNotificationsVersion(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	return &listenNode{op: listenOp{channel: channel, listen: true}}, nil
+}
+
+// Unlisten stops listening on one or all notification channels once the
+// current transaction commits.
+// Privileges: None.
+func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
+	var channel string
+	if !n.Star {
+		var err error
+		if channel, err = channelName(n.ChannelName); err != nil {
+			return nil, err
+		}
+		if channel == "" {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+		}
+	}
+	return &listenNode{op: listenOp{channel: channel}}, nil
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	notifications := params.p.extendedEvalCtx.notifications
+	if notifications == nil {
+		return nil
+	}
+	if n.op.listen {
+		// Start watching for notifications before the transaction commits, so
+		// that the session sees every notification committed after it starts
+		// listening.
+		if err := notifications.registry.startWatcher(params.ctx); err != nil {
+			return err
+		}
+	}
+	notifications.addListenOp(n.op)
+	return nil
+}
+
+func (n *listenNode) Next(runParams) (bool, error) { return false, nil }
+func (n *listenNode) Values() tree.Datums          { return nil }
+func (n *listenNode) Close(context.Context)        {}
+
+// notifyNode represents a NOTIFY statement.
+type notifyNode struct {
+	channel string
+	payload string
+}
+
+// Notify sends a notification on a channel. The notification is delivered to
+// the listening sessions once the current transaction commits.
+// Privileges: None.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	channel, err := channelName(n.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	var payload string
+	if n.Payload != nil {
+		payload = n.Payload.RawString()
+	}
+	if err := validateNotification(channel, payload); err != nil {
+		return nil, err
+	}
+	if err := checkNotificationsVersion(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	return &notifyNode{channel: channel, payload: payload}, nil
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return params.p.notify(params.ctx, n.channel, n.payload)
+}
+
+func (n *notifyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *notifyNode) Values() tree.Datums          { return nil }
+func (n *notifyNode) Close(context.Context)        {}
+
+// notify writes a notification to system.notifications in the current
+// transaction, so that it is delivered if and only if the transaction commits.
+// Identical notifications sent by the same transaction are folded into one, as
+// in Postgres.
+func (p *planner) notify(ctx context.Context, channel, payload string) error {
+	notifications := p.extendedEvalCtx.notifications
+	if notifications == nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "NOTIFY cannot be used in this context")
+	}
+	if err := checkNotificationsVersion(ctx, p.ExecCfg().Settings); err != nil {
+		return err
+	}
+	if p.EvalContext().TxnReadOnly {
+		return pgerror.New(pgcode.ReadOnlySQLTransaction,
+			"cannot execute NOTIFY in a read-only transaction")
+	}
+	seq := atomic.AddInt64(&notifications.seq, 1)
+	pid := int32(p.EvalContext().QueryCancelKey.GetPGBackendPID())
+	if _, err := p.InternalSQLTxn().ExecEx(
+		ctx, "notify", p.txn, sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.notifications (txn_id, channel, payload, seq, sender_pid)
+VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`,
+		tree.NewDUuid(tree.DUuid{UUID: p.txn.ID()}), channel, payload, seq, tree.NewDInt(tree.DInt(pid)),
+	); err != nil {
+		return err
+	}
+	notifications.registry.maybeStartCleanup()
+	return nil
+}
+
+// checkNotificationsVersion returns an error if system.notifications may not
+// exist yet.
+func checkNotificationsVersion(ctx context.Context, st *cluster.Settings) error {
+	if !st.Version.IsActive(ctx, clusterversion.V23_2_NotificationsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"LISTEN and NOTIFY are not supported until upgrade to version %v is finalized",
+			clusterversion.ByKey(clusterversion.V23_2_NotificationsTable))
+	}
+	return nil
+}
+
+// SendNotification is part of the eval.SessionAccessor interface.
+func (p *planner) SendNotification(ctx context.Context, channel, payload string) error {
+	return p.notify(ctx, channel, payload)
+}
+
+// ListeningChannels is part of the eval.SessionAccessor interface.
+func (p *planner) ListeningChannels() ([]string, error) {
+	if p.extendedEvalCtx.notifications == nil {
+		return nil, nil
+	}
+	return append([]string(nil), p.extendedEvalCtx.notifications.listener.channels...), nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+type bufferedNotifications []string
+
+// BufferNotification is part of the NotificationResult interface.
+func (b *bufferedNotifications) BufferNotification(_ int32, channel string, payload string) {
+	*b = append(*b, channel+":"+payload)
+}
+
+// TestNotificationOverflow checks that the notifications which are dropped
+// because a session's queue or the node's buffer is full are reported to the
+// listening session as an error.
+func TestNotificationOverflow(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	var registry notificationRegistry
+	s := sessionNotifications{registry: &registry}
+	var wakeups int
+	s.listener.wakeup = func(context.Context) { wakeups++ }
+	registry.register(&s.listener, "c", hlc.Timestamp{WallTime: 1})
+
+	// Fill the queue of the session. The notifications past its capacity are
+	// dropped.
+	for i := 0; i < maxQueuedNotifications+2; i++ {
+		registry.deliver(ctx, []*notificationEvent{{
+			notification: notification{channel: "c", payload: fmt.Sprint(i)},
+			ts:           hlc.Timestamp{WallTime: 2},
+		}})
+	}
+	require.Equal(t, 1, wakeups)
+
+	// A notification dropped by the node for another channel, or which was
+	// committed before the session started listening, doesn't concern the
+	// session; one on its channel does.
+	registry.drop(&notificationEvent{
+		notification: notification{channel: "d"}, ts: hlc.Timestamp{WallTime: 2},
+	})
+	registry.drop(&notificationEvent{
+		notification: notification{channel: "c"}, ts: hlc.Timestamp{WallTime: 1},
+	})
+	registry.drop(&notificationEvent{
+		notification: notification{channel: "c"}, ts: hlc.Timestamp{WallTime: 2},
+	})
+
+	// Flushing the queue without reporting drops delivers the queued
+	// notifications without an error.
+	var res bufferedNotifications
+	require.NoError(t, s.flushQueued(&res, false /* reportDropped */))
+	require.Len(t, res, maxQueuedNotifications)
+	require.Equal(t, "c:0", res[0])
+
+	// The dropped notifications are reported by the next flush which can
+	// report them, and only once.
+	res = nil
+	err := s.flushQueued(&res, true /* reportDropped */)
+	require.Error(t, err)
+	require.Equal(t, pgcode.ProgramLimitExceeded, pgerror.GetPGCode(err))
+	require.Contains(t, err.Error(), "3 notifications were dropped")
+	require.Empty(t, res)
+	require.NoError(t, s.flushQueued(&res, true /* reportDropped */))
+
+	// The session receives notifications again once its queue was drained.
+	registry.deliver(ctx, []*notificationEvent{{
+		notification: notification{channel: "c", payload: "again"},
+		ts:           hlc.Timestamp{WallTime: 3},
+	}})
+	require.Equal(t, 2, wakeups)
+	require.NoError(t, s.flushQueued(&res, true /* reportDropped */))
+	require.Equal(t, bufferedNotifications{"c:again"}, res)
+}
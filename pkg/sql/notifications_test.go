@@ -0,0 +1,84 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNotifications checks that notifications are delivered to the sessions
+// listening on their channel, on the node which sent them as well as on the
+// other nodes of the cluster, and only if the transaction which sent them
+// commits.
+func TestNotifications(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := testcluster.StartTestCluster(t, 3, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	sender := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+
+	for _, tt := range []struct {
+		name         string
+		listenerNode int
+	}{
+		{name: "single node", listenerNode: 0},
+		{name: "multi node", listenerNode: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pgURL, cleanup := sqlutils.PGUrl(
+				t, tc.Server(tt.listenerNode).ServingSQLAddr(), "TestNotifications", url.User("root"),
+			)
+			defer cleanup()
+			conn, err := pgx.Connect(ctx, pgURL.String())
+			require.NoError(t, err)
+			defer func() { _ = conn.Close(ctx) }()
+
+			_, err = conn.Exec(ctx, "LISTEN c")
+			require.NoError(t, err)
+
+			// Notifications sent by a transaction which rolls back, or on another
+			// channel, are not delivered.
+			sender.Exec(t, "BEGIN; NOTIFY c, 'rolled back'; ROLLBACK")
+			sender.Exec(t, "NOTIFY d, 'other channel'")
+
+			// Identical notifications sent by a transaction are folded into one,
+			// and notifications are delivered in the order in which they were
+			// sent.
+			sender.Exec(t, "BEGIN; NOTIFY c, 'a'; NOTIFY c, 'b'; NOTIFY c, 'a'; COMMIT")
+			sender.Exec(t, "SELECT pg_notify('c', 'c')")
+
+			var payloads []string
+			waitCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
+			defer cancel()
+			for len(payloads) < 3 {
+				n, err := conn.WaitForNotification(waitCtx)
+				require.NoError(t, err)
+				require.Equal(t, "c", n.Channel)
+				payloads = append(payloads, n.Payload)
+			}
+			require.Equal(t, []string{"a", "b", "c"}, payloads)
+		})
+	}
+}
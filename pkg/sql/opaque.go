@@ -17,10 +17,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -48,7 +50,10 @@ func buildOpaque(
 	scalarProps.Require(stmt.StatementTag(), tree.RejectSubqueries)
 
 	var plan planNode
-	if tree.CanModifySchema(stmt) {
+	// Replication commands are not parsed by the SQL parser, so they cannot be
+	// planned by the declarative schema changer.
+	_, isReplication := stmt.(pgrepltree.ReplicationStatement)
+	if tree.CanModifySchema(stmt) && !isReplication {
 		if err := p.checkNoConflictingCursors(stmt); err != nil {
 			return nil, err
 		}
@@ -136,6 +141,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.alterRenameTenant(ctx, n)
 	case *tree.AlterTenantService:
 		return p.alterTenantService(ctx, n)
+	case *tree.AlterDomain:
+		return p.AlterDomain(ctx, n)
 	case *tree.AlterType:
 		return p.AlterType(ctx, n)
 	case *tree.AlterRole:
@@ -152,6 +159,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.CommentOnConstraint(ctx, n)
 	case *tree.CommentOnDatabase:
 		return p.CommentOnDatabase(ctx, n)
+	case *tree.CommentOnFunction:
+		return p.CommentOnFunction(ctx, n)
 	case *tree.CommentOnSchema:
 		return p.CommentOnSchema(ctx, n)
 	case *tree.CommentOnIndex:
@@ -163,10 +172,18 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		// it can't have placeholder arguments, and the execution can use the same
 		// logic as if it were a simple query. This matches the Postgres behavior.
 		return &zeroNode{}, nil
+	case *tree.CreateAggregate:
+		return p.CreateAggregate(ctx, n)
 	case *tree.CreateDatabase:
 		return p.CreateDatabase(ctx, n)
+	case *tree.CreateDomain:
+		return p.CreateDomain(ctx, n)
+	case *tree.CreateForeignTable:
+		return p.CreateForeignTable(ctx, n)
 	case *tree.CreateIndex:
 		return p.CreateIndex(ctx, n)
+	case *tree.CreatePublication:
+		return p.CreatePublication(ctx, n)
 	case *tree.CreateSchema:
 		return p.CreateSchema(ctx, n)
 	case *tree.CreateType:
@@ -191,6 +208,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.Discard(ctx, n)
 	case *tree.DropDatabase:
 		return p.DropDatabase(ctx, n)
+	case *tree.DropDomain:
+		return p.DropDomain(ctx, n)
 	case *tree.DropFunction:
 		return p.DropFunction(ctx, n)
 	case *tree.DropIndex:
@@ -203,6 +222,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.DropSchema(ctx, n)
 	case *tree.DropSequence:
 		return p.DropSequence(ctx, n)
+	case *tree.DropPublication:
+		return p.DropPublication(ctx, n)
 	case *tree.DropTable:
 		return p.DropTable(ctx, n)
 	case *tree.DropTenant:
@@ -217,8 +238,12 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.Grant(ctx, n)
 	case *tree.GrantRole:
 		return p.GrantRole(ctx, n)
+	case *tree.Listen:
+		return p.Listen(ctx, n)
 	case *tree.MoveCursor:
 		return p.FetchCursor(ctx, &n.CursorStmt, true /* isMove */)
+	case *tree.Notify:
+		return p.Notify(ctx, n)
 	case *tree.ReassignOwnedBy:
 		return p.ReassignOwnedBy(ctx, n)
 	case *tree.RefreshMaterializedView:
@@ -243,6 +268,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.Scrub(ctx, n)
 	case *tree.SetClusterSetting:
 		return p.SetClusterSetting(ctx, n)
+	case *tree.SetConstraints:
+		return p.SetConstraints(ctx, n)
 	case *tree.SetZoneConfig:
 		return p.SetZoneConfig(ctx, n)
 	case *tree.SetVar:
@@ -281,6 +308,15 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.Truncate(ctx, n)
 	case *tree.Unlisten:
 		return p.Unlisten(ctx, n)
+	case *pgrepltree.IdentifySystem:
+		return p.IdentifySystem(ctx, n)
+	case *pgrepltree.CreateReplicationSlot:
+		return p.CreateReplicationSlot(ctx, n)
+	case *pgrepltree.DropReplicationSlot:
+		return p.DropReplicationSlot(ctx, n)
+	case *pgrepltree.BaseBackup, *pgrepltree.ReadReplicationSlot, *pgrepltree.TimelineHistory:
+		return nil, unimplemented.Newf("physical replication",
+			"%s is not supported", stmt.StatementTag())
 	case tree.CCLOnlyStatement:
 		plan, err := p.maybePlanHook(ctx, stmt)
 		if plan == nil && err == nil {
@@ -325,6 +361,7 @@ func init() {
 		&tree.AlterTenantRename{},
 		&tree.AlterTenantSetClusterSetting{},
 		&tree.AlterTenantService{},
+		&tree.AlterDomain{},
 		&tree.AlterType{},
 		&tree.AlterSequence{},
 		&tree.AlterRole{},
@@ -335,13 +372,18 @@ func init() {
 		&tree.CommentOnSchema{},
 		&tree.CommentOnIndex{},
 		&tree.CommentOnConstraint{},
+		&tree.CommentOnFunction{},
 		&tree.CommentOnTable{},
 		&tree.CopyTo{},
+		&tree.CreateAggregate{},
 		&tree.CreateDatabase{},
+		&tree.CreateDomain{},
 		&tree.CreateExtension{},
 		&tree.CreateExternalConnection{},
+		&tree.CreateForeignTable{},
 		&tree.CreateTenant{},
 		&tree.CreateIndex{},
+		&tree.CreatePublication{},
 		&tree.CreateSchema{},
 		&tree.CreateSequence{},
 		&tree.CreateType{},
@@ -350,10 +392,12 @@ func init() {
 		&tree.DeclareCursor{},
 		&tree.Discard{},
 		&tree.DropDatabase{},
+		&tree.DropDomain{},
 		&tree.DropExternalConnection{},
 		&tree.DropFunction{},
 		&tree.DropIndex{},
 		&tree.DropOwnedBy{},
+		&tree.DropPublication{},
 		&tree.DropRole{},
 		&tree.DropSchema{},
 		&tree.DropSequence{},
@@ -364,7 +408,9 @@ func init() {
 		&tree.FetchCursor{},
 		&tree.Grant{},
 		&tree.GrantRole{},
+		&tree.Listen{},
 		&tree.MoveCursor{},
+		&tree.Notify{},
 		&tree.ReassignOwnedBy{},
 		&tree.RefreshMaterializedView{},
 		&tree.RenameColumn{},
@@ -377,6 +423,7 @@ func init() {
 		&tree.Scatter{},
 		&tree.Scrub{},
 		&tree.SetClusterSetting{},
+		&tree.SetConstraints{},
 		&tree.SetZoneConfig{},
 		&tree.SetVar{},
 		&tree.SetTransaction{},
@@ -397,6 +444,15 @@ func init() {
 		&tree.Truncate{},
 		&tree.Unlisten{},
 
+		// Commands of the streaming replication protocol (without
+		// START_REPLICATION which takes control of the connection).
+		&pgrepltree.BaseBackup{},
+		&pgrepltree.CreateReplicationSlot{},
+		&pgrepltree.DropReplicationSlot{},
+		&pgrepltree.IdentifySystem{},
+		&pgrepltree.ReadReplicationSlot{},
+		&pgrepltree.TimelineHistory{},
+
 		// CCL statements (without Export which has an optimizer operator).
 		&tree.AlterBackup{},
 		&tree.AlterBackupSchedule{},
@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -139,6 +140,24 @@ type Table interface {
 	// i < UniqueCount.
 	Unique(i UniqueOrdinal) UniqueConstraint
 
+	// ExclusionConstraintCount returns the number of EXCLUDE constraints that
+	// must be enforced by mutations of this table. This includes constraints
+	// which are still being added to the table.
+	ExclusionConstraintCount() int
+
+	// ExclusionConstraint returns the ith EXCLUDE constraint of this table,
+	// where i < ExclusionConstraintCount.
+	ExclusionConstraint(i int) ExclusionConstraint
+
+	// InheritedByCount returns the number of tables which directly inherit
+	// from this table. Queries on this table also return the rows of these
+	// tables, unless ONLY is specified.
+	InheritedByCount() int
+
+	// InheritedBy returns the ID of the ith table which directly inherits from
+	// this table, where i < InheritedByCount.
+	InheritedBy(i int) StableID
+
 	// Zone returns a table's zone.
 	Zone() Zone
 
@@ -278,6 +297,10 @@ type ForeignKeyConstraint interface {
 	// Name of the foreign key constraint.
 	Name() string
 
+	// ConstraintID returns the identifier of the constraint, which is unique
+	// among the constraints of the origin table.
+	ConstraintID() StableID
+
 	// OriginTableID returns the referencing table's stable identifier.
 	OriginTableID() StableID
 
@@ -314,6 +337,12 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrability returns whether the checking of the constraint may be
+	// deferred until the end of the transaction. Deferrable constraints are
+	// never reported as validated, since existing data may violate them until
+	// the transaction commits.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -329,6 +358,10 @@ type UniqueConstraint interface {
 	// Name of the unique constraint.
 	Name() string
 
+	// ConstraintID returns the identifier of the constraint, which is unique
+	// among the constraints of the table on which it is defined.
+	ConstraintID() StableID
+
 	// TableID returns the stable identifier of the table on which this unique
 	// constraint is defined.
 	TableID() StableID
@@ -361,6 +394,51 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// Deferrability returns whether the checking of the constraint may be
+	// deferred until the end of the transaction. Only unique constraints without
+	// an index can be deferrable, and they are never reported as validated.
+	Deferrability() tree.ConstraintDeferrability
+}
+
+// ExclusionConstraint represents an EXCLUDE constraint, which ensures that no
+// two rows of a table compare true on all of the constraint's operators. For
+// example:
+//
+//	CREATE TABLE t (k INT PRIMARY KEY, a INT, b INT[],
+//	  EXCLUDE USING GIST (a WITH =, b WITH &&))
+//
+// forbids two distinct rows with equal values of a and overlapping arrays b.
+// The optimizer enforces the constraint with a check that is added as a
+// postquery to any query that inserts into or updates its columns.
+type ExclusionConstraint interface {
+	// Name of the exclusion constraint.
+	Name() string
+
+	// ColumnCount returns the number of columns in this constraint.
+	ColumnCount() int
+
+	// ColumnOrdinal returns the table column ordinal of the ith column in this
+	// constraint.
+	ColumnOrdinal(tab Table, i int) int
+
+	// Operator returns the operator with which the ith column of two rows is
+	// compared. It is either treecmp.EQ or treecmp.Overlaps.
+	Operator(i int) treecmp.ComparisonOperatorSymbol
+
+	// HasPeriod returns true if the last two columns of the constraint are the
+	// start and end of a period, like in:
+	//
+	//	EXCLUDE (room WITH =, (start_at, end_at) WITH OVERLAPS)
+	//
+	// The periods of two rows are compared with the OVERLAPS function, rather
+	// than by comparing each column with its Operator.
+	HasPeriod() bool
+
+	// Predicate returns the partial predicate expression and true if the
+	// constraint only applies to rows which satisfy a predicate. If it does not,
+	// the empty string and false are returned.
+	Predicate() (string, bool)
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
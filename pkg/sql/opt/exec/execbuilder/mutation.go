@@ -13,6 +13,7 @@ package execbuilder
 import (
 	"bytes"
 	"fmt"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
@@ -176,6 +177,10 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 			return execPlan{}, false, nil
 		}
 		fk := tab.OutboundForeignKey(c.FKOrdinal)
+		if fk.Deferrability().IsDeferrable() {
+			// Checks for deferrable FKs may need to be postponed until commit.
+			return execPlan{}, false, nil
+		}
 		lookupJoin, isLookupJoin := c.Check.(*memo.LookupJoinExpr)
 		if !isLookupJoin || lookupJoin.JoinType != opt.AntiJoinOp {
 			// Not a lookup anti-join.
@@ -417,7 +422,7 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 	// TODO(andyk): Using ensureColumns here can result in an extra Render.
 	// Upgrade execution engine to not require this.
 	cnt := len(ups.InsertCols) + len(ups.FetchCols) + len(ups.UpdateCols) + len(ups.CheckCols) +
-		len(ups.PartialIndexPutCols) + len(ups.PartialIndexDelCols) + 1
+		len(ups.PartialIndexPutCols) + len(ups.PartialIndexDelCols) + 2
 	colList := make(opt.ColList, 0, cnt)
 	colList = appendColsWhenPresent(colList, ups.InsertCols)
 	colList = appendColsWhenPresent(colList, ups.FetchCols)
@@ -425,6 +430,9 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 	if ups.CanaryCol != 0 {
 		colList = append(colList, ups.CanaryCol)
 	}
+	if ups.DeleteCol != 0 {
+		colList = append(colList, ups.DeleteCol)
+	}
 	colList = appendColsWhenPresent(colList, ups.CheckCols)
 	colList = appendColsWhenPresent(colList, ups.PartialIndexPutCols)
 	colList = appendColsWhenPresent(colList, ups.PartialIndexDelCols)
@@ -444,6 +452,13 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 			return execPlan{}, err
 		}
 	}
+	deleteCol := exec.NodeColumnOrdinal(-1)
+	if ups.DeleteCol != 0 {
+		deleteCol, err = input.getNodeColumnOrdinal(ups.DeleteCol)
+		if err != nil {
+			return execPlan{}, err
+		}
+	}
 	insertColOrds := ordinalSetFromColList(ups.InsertCols)
 	fetchColOrds := ordinalSetFromColList(ups.FetchCols)
 	updateColOrds := ordinalSetFromColList(ups.UpdateCols)
@@ -455,6 +470,7 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 		ups.ArbiterIndexes,
 		ups.ArbiterConstraints,
 		canaryCol,
+		deleteCol,
 		insertColOrds,
 		fetchColOrds,
 		updateColOrds,
@@ -728,9 +744,39 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 				}
 				keyVals[i] = row[ord]
 			}
+			if c.Exclusion {
+				return mkExclusionCheckErr(md, c, keyVals)
+			}
 			return mkUniqueCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		var deferrable *exec.DeferrableConstraint
+		tab := md.Table(c.Table)
+		// EXCLUDE constraints cannot be deferred.
+		if !c.Exclusion {
+			if uc := tab.Unique(c.CheckOrdinal); uc.Deferrability().IsDeferrable() {
+				// The key columns of the check are ordered by table column ordinal,
+				// so map them back to the order of the constraint's columns.
+				var ordinals intsets.Fast
+				for i := 0; i < uc.ColumnCount(); i++ {
+					ordinals.Add(uc.ColumnOrdinal(tab, i))
+				}
+				sorted := ordinals.Ordered()
+				keyCols := make([]exec.NodeColumnOrdinal, uc.ColumnCount())
+				for i := range keyCols {
+					pos := sort.SearchInts(sorted, uc.ColumnOrdinal(tab, i))
+					if keyCols[i], err = query.getNodeColumnOrdinal(c.KeyCols[pos]); err != nil {
+						return err
+					}
+				}
+				deferrable = &exec.DeferrableConstraint{
+					Table:             tab.ID(),
+					Constraint:        uc.ConstraintID(),
+					InitiallyDeferred: uc.Deferrability() == tree.ConstraintInitiallyDeferred,
+					KeyCols:           keyCols,
+				}
+			}
+		}
+		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr, deferrable)
 		if err != nil {
 			return err
 		}
@@ -760,7 +806,29 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 			}
 			return mkFKCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		var fk cat.ForeignKeyConstraint
+		if c.FKOutbound {
+			fk = md.TableMeta(c.OriginTable).Table.OutboundForeignKey(c.FKOrdinal)
+		} else {
+			fk = md.TableMeta(c.ReferencedTable).Table.InboundForeignKey(c.FKOrdinal)
+		}
+		var deferrable *exec.DeferrableConstraint
+		if fk.Deferrability().IsDeferrable() {
+			// The key columns of FK checks are in the order of the FK's columns.
+			keyCols := make([]exec.NodeColumnOrdinal, len(c.KeyCols))
+			for i, col := range c.KeyCols {
+				if keyCols[i], err = query.getNodeColumnOrdinal(col); err != nil {
+					return err
+				}
+			}
+			deferrable = &exec.DeferrableConstraint{
+				Table:             fk.OriginTableID(),
+				Constraint:        fk.ConstraintID(),
+				InitiallyDeferred: fk.Deferrability() == tree.ConstraintInitiallyDeferred,
+				KeyCols:           keyCols,
+			}
+		}
+		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr, deferrable)
 		if err != nil {
 			return err
 		}
@@ -811,6 +879,49 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	)
 }
 
+// mkExclusionCheckErr generates a user-friendly error describing a violation
+// of an EXCLUDE constraint. The keyVals are the values that correspond to the
+// cat.ExclusionConstraint columns.
+func mkExclusionCheckErr(
+	md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums,
+) error {
+	tabMeta := md.TableMeta(c.Table)
+	ec := tabMeta.Table.ExclusionConstraint(c.CheckOrdinal)
+	constraintName := ec.Name()
+	var msg, details bytes.Buffer
+
+	// Generate an error of the form:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (a, b)=(1, {1,2}) conflicts with existing key.
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
+
+	details.WriteString("Key (")
+	for i := 0; i < ec.ColumnCount(); i++ {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		col := tabMeta.Table.Column(ec.ColumnOrdinal(tabMeta.Table, i))
+		details.WriteString(string(col.ColName()))
+	}
+	details.WriteString(")=(")
+	for i, d := range keyVals {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(d.String())
+	}
+	details.WriteString(") conflicts with existing key.")
+
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()),
+			constraintName,
+		),
+		details.String(),
+	)
+}
+
 // mkFKCheckErr generates a user-friendly error describing a foreign key
 // violation. The keyVals are the values that correspond to the
 // cat.ForeignKeyConstraint columns.
@@ -300,6 +300,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.CreateFunctionExpr:
 		ep, err = b.buildCreateFunction(t)
 
+	case *memo.CallExpr:
+		ep, err = b.buildCall(t)
+
 	case *memo.WithExpr:
 		ep, err = b.buildWith(t)
 
@@ -722,6 +725,7 @@ func (b *Builder) scanParams(
 		Locking:            locking,
 		EstimatedRowCount:  rowCount,
 		LocalityOptimized:  scan.LocalityOptimized,
+		Sample:             scan.Sample,
 	}, outputMap, nil
 }
 
@@ -1572,14 +1576,26 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 			agg = aggDistinct.Input
 		}
 
-		name, _ := memo.FindAggregateOverload(agg)
+		var name string
+		var userDefined *tree.AggregateSupportExprs
+		args := opt.Expr(agg)
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			// The arguments of a user-defined aggregate are stored in a list.
+			name, args = uda.Name, &uda.Args
+			var err error
+			if userDefined, err = b.buildAggregateSupport(uda.Support); err != nil {
+				return execPlan{}, err
+			}
+		} else {
+			name, _ = memo.FindAggregateOverload(agg)
+		}
 
 		// Accumulate variable arguments in argCols and constant arguments in
 		// constArgs. Constant arguments must follow variable arguments.
 		var argCols []exec.NodeColumnOrdinal
 		var constArgs tree.Datums
-		for j, n := 0, agg.ChildCount(); j < n; j++ {
-			child := agg.Child(j)
+		for j, n := 0, args.ChildCount(); j < n; j++ {
+			child := args.Child(j)
 			if variable, ok := child.(*memo.VariableExpr); ok {
 				if len(constArgs) != 0 {
 					return execPlan{}, errors.Errorf("constant args must come after variable args")
@@ -1598,12 +1614,13 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 		}
 
 		aggInfos[i] = exec.AggInfo{
-			FuncName:   name,
-			Distinct:   distinct,
-			ResultType: item.Agg.DataType(),
-			ArgCols:    argCols,
-			ConstArgs:  constArgs,
-			Filter:     filterOrd,
+			FuncName:    name,
+			Distinct:    distinct,
+			ResultType:  item.Agg.DataType(),
+			ArgCols:     argCols,
+			ConstArgs:   constArgs,
+			Filter:      filterOrd,
+			UserDefined: userDefined,
 		}
 		ep.outputCols.Set(int(item.Col), len(groupingColIdx)+i)
 	}
@@ -1635,6 +1652,38 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 	return ep, nil
 }
 
+// buildAggregateSupport builds the support functions of a user-defined
+// aggregate as routines. In each of them, IndexedVar i refers to the i-th
+// parameter of the support function.
+func (b *Builder) buildAggregateSupport(
+	support *memo.AggSupportFuncs,
+) (*tree.AggregateSupportExprs, error) {
+	build := func(fn *memo.AggSupportFunc) (tree.TypedExpr, error) {
+		if fn.Call == nil {
+			return nil, nil
+		}
+		ctx := buildScalarCtx{ivh: tree.MakeIndexedVarHelper(nil /* container */, len(fn.Params))}
+		for i, col := range fn.Params {
+			ctx.ivarMap.Set(int(col), i)
+		}
+		return b.buildScalar(&ctx, fn.Call)
+	}
+	res := &tree.AggregateSupportExprs{
+		TransitionStrict: support.Transition.Strict,
+		FinalStrict:      support.Final.Strict,
+		StateType:        support.StateType,
+		InitialState:     support.InitialState,
+	}
+	var err error
+	if res.Transition, err = build(&support.Transition); err != nil {
+		return nil, err
+	}
+	if res.Final, err = build(&support.Final); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (b *Builder) buildDistinct(distinct memo.RelExpr) (execPlan, error) {
 	private := distinct.Private().(*memo.GroupingPrivate)
 
@@ -3267,20 +3316,44 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 	filterIdxs := make([]int, len(w.Windows))
 	exprs := make([]*tree.FuncExpr, len(w.Windows))
 	windowVals := make([]tree.WindowDef, len(w.Windows))
+	userDefined := make([]*tree.AggregateSupportExprs, len(w.Windows))
 
 	for i := range w.Windows {
 		item := &w.Windows[i]
 		fn := b.extractWindowFunction(item.Function)
-		name, overload := memo.FindWindowOverload(fn)
-		if !b.disableTelemetry {
-			telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+		var name string
+		var overload *tree.Overload
+		var props *tree.FunctionProperties
+		var fnRef tree.ResolvableFunctionReference
+		var typ *types.T
+		fnArgs := opt.Expr(fn)
+		if uda, ok := fn.(*memo.UserDefinedAggExpr); ok {
+			// The arguments of a user-defined aggregate are stored in a list.
+			name, overload, props = uda.Name, uda.Overload, &uda.Overload.FunctionProperties
+			typ = uda.Typ
+			fnRef = tree.ResolvableFunctionReference{FunctionReference: tree.NewUnresolvedName(name)}
+			fnArgs = &uda.Args
+			var err error
+			if userDefined[i], err = b.buildAggregateSupport(uda.Support); err != nil {
+				return execPlan{}, err
+			}
+		} else {
+			name, overload = memo.FindWindowOverload(fn)
+			if !b.disableTelemetry {
+				telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+			}
+			props, _ = builtinsregistry.GetBuiltinProperties(name)
+			typ = overload.FixedReturnType()
+			var err error
+			if fnRef, err = b.wrapFunction(name); err != nil {
+				return execPlan{}, err
+			}
 		}
-		props, _ := builtinsregistry.GetBuiltinProperties(name)
 
-		args := make([]tree.TypedExpr, fn.ChildCount())
-		argIdxs[i] = make([]exec.NodeColumnOrdinal, fn.ChildCount())
-		for j, n := 0, fn.ChildCount(); j < n; j++ {
-			col := fn.Child(j).(*memo.VariableExpr).Col
+		args := make([]tree.TypedExpr, fnArgs.ChildCount())
+		argIdxs[i] = make([]exec.NodeColumnOrdinal, fnArgs.ChildCount())
+		for j, n := 0, fnArgs.ChildCount(); j < n; j++ {
+			col := fnArgs.Child(j).(*memo.VariableExpr).Col
 			indexedVar, err := b.indexedVar(&ctx, b.mem.Metadata(), col)
 			if err != nil {
 				return execPlan{}, err
@@ -3318,17 +3391,13 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 			OrderBy:    orderingExprs,
 			Frame:      frame,
 		}
-		wrappedFn, err := b.wrapFunction(name)
-		if err != nil {
-			return execPlan{}, err
-		}
 		exprs[i] = tree.NewTypedFuncExpr(
-			wrappedFn,
+			fnRef,
 			0,
 			args,
 			builtFilter,
 			&windowVals[i],
-			overload.FixedReturnType(),
+			typ,
 			props,
 			overload,
 		)
@@ -3366,13 +3435,14 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 	node, err := b.factory.ConstructWindow(input.root, exec.WindowInfo{
-		Cols:       resultCols,
-		Exprs:      exprs,
-		OutputIdxs: outputIdxs,
-		ArgIdxs:    argIdxs,
-		FilterIdxs: filterIdxs,
-		Partition:  partitionIdxs,
-		Ordering:   sqlOrdering,
+		Cols:        resultCols,
+		Exprs:       exprs,
+		OutputIdxs:  outputIdxs,
+		ArgIdxs:     argIdxs,
+		FilterIdxs:  filterIdxs,
+		UserDefined: userDefined,
+		Partition:   partitionIdxs,
+		Ordering:    sqlOrdering,
 	})
 	if err != nil {
 		return execPlan{}, err
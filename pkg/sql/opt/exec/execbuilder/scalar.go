@@ -700,6 +700,7 @@ func (b *Builder) buildExistsSubquery(
 				true,  /* calledOnNullInput */
 				false, /* multiColOutput */
 				false, /* generator */
+				nil,   /* exceptionHandler */
 			),
 			tree.DBoolFalse,
 		}, types.Bool), nil
@@ -815,6 +816,7 @@ func (b *Builder) buildSubquery(
 			true,  /* calledOnNullInput */
 			false, /* multiColOutput */
 			false, /* generator */
+			nil,   /* exceptionHandler */
 		), nil
 	}
 
@@ -869,6 +871,7 @@ func (b *Builder) buildSubquery(
 			true,  /* calledOnNullInput */
 			false, /* multiColOutput */
 			false, /* generator */
+			nil,   /* exceptionHandler */
 		), nil
 	}
 
@@ -955,6 +958,38 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	// statements.
 	enableStepping := udf.Def.Volatility == volatility.Volatile
 
+	// Build each exception handler as a routine that is invoked with the same
+	// arguments as the UDF if its error code matches an error thrown by the
+	// UDF body.
+	var exceptionHandler *tree.RoutineExceptionHandler
+	if udf.Def.ExceptionBlock != nil {
+		block := udf.Def.ExceptionBlock
+		exceptionHandler = &tree.RoutineExceptionHandler{
+			Codes:   block.Codes,
+			Actions: make([]*tree.RoutineExpr, len(block.Actions)),
+		}
+		for i, action := range block.Actions {
+			actionPlanGen := b.buildRoutinePlanGenerator(
+				action.Params,
+				action.Body,
+				action.BodyProps,
+				false, /* allowOuterWithRefs */
+				nil,   /* wrapRootExpr */
+			)
+			exceptionHandler.Actions[i] = tree.NewTypedRoutineExpr(
+				action.Name,
+				args,
+				actionPlanGen,
+				action.Typ,
+				action.Volatility == volatility.Volatile,
+				action.CalledOnNullInput,
+				action.MultiColDataSource,
+				action.SetReturning,
+				nil, /* exceptionHandler */
+			)
+		}
+	}
+
 	return tree.NewTypedRoutineExpr(
 		udf.Def.Name,
 		args,
@@ -964,6 +999,7 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Def.CalledOnNullInput,
 		udf.Def.MultiColDataSource,
 		udf.Def.SetReturning,
+		exceptionHandler,
 	), nil
 }
 
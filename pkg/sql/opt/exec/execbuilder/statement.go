@@ -87,6 +87,25 @@ func (b *Builder) buildCreateFunction(cf *memo.CreateFunctionExpr) (execPlan, er
 	return execPlan{root: root}, err
 }
 
+func (b *Builder) buildCall(c *memo.CallExpr) (execPlan, error) {
+	scalarCtx := buildScalarCtx{}
+	proc, err := b.buildScalar(&scalarCtx, c.Proc)
+	if err != nil {
+		return execPlan{}, err
+	}
+	md := b.mem.Metadata()
+	cols := make(colinfo.ResultColumns, len(c.Columns))
+	for i, col := range c.Columns {
+		colMeta := md.ColumnMeta(col)
+		cols[i] = colinfo.ResultColumn{Name: colMeta.Alias, Typ: colMeta.Type}
+	}
+	node, err := b.factory.ConstructCall(proc, cols)
+	if err != nil {
+		return execPlan{}, err
+	}
+	return planWithColumns(node, c.Columns), nil
+}
+
 func (b *Builder) buildExplainOpt(explain *memo.ExplainExpr) (execPlan, error) {
 	fmtFlags := memo.ExprFmtHideAll
 	switch {
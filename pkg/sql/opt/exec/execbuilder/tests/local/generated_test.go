@@ -392,6 +392,13 @@ func TestExecBuild_materialized_view(
 	runExecBuildLogicTest(t, "materialized_view")
 }
 
+func TestExecBuild_merge(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runExecBuildLogicTest(t, "merge")
+}
+
 func TestExecBuild_mvcc(
 	t *testing.T,
 ) {
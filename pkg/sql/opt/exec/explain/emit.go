@@ -294,6 +294,7 @@ var nodeNames = [...]string{
 	alterTableUnsplitOp:    "unsplit",
 	applyJoinOp:            "", // This node does not have a fixed name.
 	bufferOp:               "buffer",
+	callOp:                 "call",
 	cancelQueriesOp:        "cancel queries",
 	cancelSessionsOp:       "cancel sessions",
 	controlJobsOp:          "control jobs",
@@ -600,6 +601,13 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		if a.Params.Parallelize {
 			ob.VAttr("parallel", "")
 		}
+		if s := a.Params.Sample; s.System {
+			if s.Repeatable {
+				ob.Attrf("sample", "system (%g) repeatable (%d)", s.Percent, s.Seed)
+			} else {
+				ob.Attrf("sample", "system (%g)", s.Percent)
+			}
+		}
 		e.emitLockingPolicy(a.Params.Locking)
 
 	case valuesOp:
@@ -969,6 +977,10 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			ob.Expr("from", a.fromStoreID, nil /* columns */)
 		}
 
+	case callOp:
+		a := n.args.(*callArgs)
+		ob.Expr("procedure", a.Proc, nil /* columns */)
+
 	case simpleProjectOp,
 		serializingProjectOp,
 		ordinalityOp,
@@ -36,7 +36,7 @@ import (
 )
 
 func init() {
-	if numOperators != 61 {
+	if numOperators != 62 {
 		// This error occurs when an operator has been added or removed in
 		// pkg/sql/opt/exec/explain/factory.opt. If an operator is added at the
 		// end of factory.opt, simply adjust the hardcoded value above. If an
@@ -575,6 +575,22 @@ func (u *unknownTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	panic(errors.AssertionFailedf("not implemented"))
 }
 
+func (u *unknownTable) ExclusionConstraintCount() int {
+	return 0
+}
+
+func (u *unknownTable) ExclusionConstraint(i int) cat.ExclusionConstraint {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+func (u *unknownTable) InheritedByCount() int {
+	return 0
+}
+
+func (u *unknownTable) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 func (u *unknownTable) Zone() cat.Zone {
 	return cat.EmptyZone()
 }
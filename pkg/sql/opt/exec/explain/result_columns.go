@@ -188,6 +188,9 @@ func getResultColumns(
 	case explainOptOp:
 		return colinfo.ExplainPlanColumns, nil
 
+	case callOp:
+		return args.(*callArgs).Columns, nil
+
 	case showTraceOp:
 		if args.(*showTraceArgs).Compact {
 			return colinfo.ShowCompactTraceColumns, nil
@@ -72,6 +72,10 @@ type ScanParams struct {
 	// to work correctly, the execution engine must create a local DistSQL plan
 	// for the main query (subqueries and postqueries need not be local).
 	LocalityOptimized bool
+
+	// If Sample.System is set, the scan only reads a sample of the ranges that
+	// overlap its spans.
+	Sample opt.TableSample
 }
 
 // OutputOrdering indicates the required output ordering on a Node that is being
@@ -154,6 +158,10 @@ type AggInfo struct {
 	// Filter is the index of the column, if any, which should be used as the
 	// FILTER condition for the aggregate. If there is no filter, Filter is -1.
 	Filter NodeColumnOrdinal
+
+	// UserDefined contains the support expressions of a user-defined
+	// aggregate. It is nil for builtin aggregates.
+	UserDefined *tree.AggregateSupportExprs
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -178,6 +186,10 @@ type WindowInfo struct {
 	// FilterIdxs is the list of column indices to use as filters.
 	FilterIdxs []int
 
+	// UserDefined contains the support expressions of each function that is a
+	// user-defined aggregate, and nil for the builtin functions.
+	UserDefined []*tree.AggregateSupportExprs
+
 	// Partition is the set of input columns to partition on.
 	Partition []NodeColumnOrdinal
 
@@ -273,6 +285,27 @@ type InsertFastPathFKCheck struct {
 // relevant row.
 type MkErrFn func(tree.Datums) error
 
+// DeferrableConstraint identifies a DEFERRABLE constraint enforced by an
+// ErrorIfRows check.
+type DeferrableConstraint struct {
+	// Table is the table on which the constraint is defined. For foreign keys,
+	// this is the referencing (origin) table.
+	Table cat.StableID
+
+	// Constraint is the ID of the constraint within Table.
+	Constraint cat.StableID
+
+	// InitiallyDeferred is true if the constraint was declared INITIALLY
+	// DEFERRED.
+	InitiallyDeferred bool
+
+	// KeyCols are the ordinals of the columns of the check's input which hold
+	// the values of the constraint's columns, in the order of the constraint's
+	// columns. If the check is deferred, these values identify the keys which
+	// must be re-checked when the transaction commits.
+	KeyCols []NodeColumnOrdinal
+}
+
 // ExplainFactory is an extension of Factory used when constructing a plan that
 // can be explained. It allows annotation of nodes with extra information.
 type ExplainFactory interface {
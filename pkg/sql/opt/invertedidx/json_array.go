@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/errors"
 )
 
@@ -400,6 +401,10 @@ func (j *jsonOrArrayFilterPlanner) extractInvertedFilterConditionFromLeaf(
 		}
 	case *memo.OverlapsExpr:
 		invertedExpr = j.extractArrayOverlapsCondition(ctx, evalCtx, t.Left, t.Right)
+	case *memo.JsonPathExistsExpr:
+		invertedExpr = j.extractJSONPathCondition(ctx, evalCtx, t.Left, t.Right, false /* match */)
+	case *memo.TSMatchesExpr:
+		invertedExpr = j.extractJSONPathCondition(ctx, evalCtx, t.Left, t.Right, true /* match */)
 	}
 
 	if invertedExpr == nil {
@@ -559,6 +564,157 @@ func (j *jsonOrArrayFilterPlanner) extractJSONExistsCondition(
 	return inverted.NonInvertedColExpression{}
 }
 
+// maxJSONPathKeys is the maximum number of keys in a jsonpath predicate for
+// which extractJSONPathCondition generates an InvertedExpression. In lax mode,
+// the number of JSON objects that are scanned grows exponentially with the
+// number of keys.
+const maxJSONPathKeys = 4
+
+// extractJSONPathCondition extracts an InvertedExpression representing an
+// inverted filter over the planner's inverted index, based on a jsonpath
+// operator. If match is true, the operator is @@, otherwise it is @?. If an
+// InvertedExpression cannot be generated from the expression, an
+// inverted.NonInvertedColExpression is returned.
+//
+// In order to generate an InvertedExpression, left must be a variable or
+// expression referencing the inverted column in the inverted index, and right
+// must be a constant jsonpath that compares a chain of keys to a scalar, such
+// as '$.a.b == 1' for @@, or '$.a ? (@.b == 1)' for @?.
+func (j *jsonOrArrayFilterPlanner) extractJSONPathCondition(
+	ctx context.Context, evalCtx *eval.Context, left, right opt.ScalarExpr, match bool,
+) inverted.Expression {
+	if !isIndexColumn(j.tabID, j.index, left, j.computedColumns) ||
+		!memo.CanExtractConstDatum(right) {
+		return inverted.NonInvertedColExpression{}
+	}
+	path, ok := memo.ExtractConstDatum(right).(*tree.DJsonpath)
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	var keys []string
+	var val json.JSON
+	if match {
+		keys, val, ok = jsonpathKeyEquality(path.Expr, jsonpath.Root{})
+	} else {
+		keys, val, ok = jsonpathFilterKeyEquality(path.Expr)
+	}
+	if !ok || len(keys) > maxJSONPathKeys {
+		return inverted.NonInvertedColExpression{}
+	}
+
+	// In lax mode, arrays are unwrapped by the member accessors and by the
+	// comparison, so any of the objects and the value may be wrapped in an
+	// array. For example, '$.a == 1' matches '{"a": 1}', '{"a": [1]}',
+	// '[{"a": 1}]' and '[{"a": [1]}]'.
+	objs := []json.JSON{val}
+	if !path.Strict {
+		objs = append(objs, wrapInArray(val))
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		next := make([]json.JSON, 0, 2*len(objs))
+		for _, v := range objs {
+			b := json.NewObjectBuilder(1)
+			b.Add(keys[i], v)
+			obj := b.Build()
+			next = append(next, obj)
+			if !path.Strict {
+				next = append(next, wrapInArray(obj))
+			}
+		}
+		objs = next
+	}
+
+	var invertedExpr inverted.Expression
+	for _, obj := range objs {
+		expr := getInvertedExprForJSONOrArrayIndexForContaining(ctx, evalCtx, tree.NewDJSON(obj))
+		if invertedExpr == nil {
+			invertedExpr = expr
+		} else {
+			invertedExpr = inverted.Or(invertedExpr, expr)
+		}
+	}
+
+	// The jsonpath comparison is not equivalent to containment, for example
+	// because the JSON values may have other elements or keys, so the original
+	// filter must be applied after the inverted index scan.
+	invertedExpr.SetNotTight()
+	return invertedExpr
+}
+
+// jsonpathFilterKeyEquality returns the keys and the value of a jsonpath of
+// the form $.key0.key1 ? (@.key2 == val), where each part of the chain of keys
+// is optional.
+func jsonpathFilterKeyEquality(e jsonpath.Expr) (keys []string, val json.JSON, ok bool) {
+	p, ok := e.(*jsonpath.Path)
+	if !ok || len(p.Accessors) == 0 {
+		return nil, nil, false
+	}
+	n := len(p.Accessors) - 1
+	filter, ok := p.Accessors[n].(*jsonpath.Filter)
+	if !ok {
+		return nil, nil, false
+	}
+	keys, ok = jsonpathKeys(&jsonpath.Path{Start: p.Start, Accessors: p.Accessors[:n]}, jsonpath.Root{})
+	if !ok {
+		return nil, nil, false
+	}
+	filterKeys, val, ok := jsonpathKeyEquality(filter.Pred, jsonpath.Current{})
+	if !ok {
+		return nil, nil, false
+	}
+	return append(keys, filterKeys...), val, true
+}
+
+// jsonpathKeyEquality returns the keys and the value of a jsonpath predicate of
+// the form start.key0.key1 == val, where start is $ or @, and val is a scalar.
+func jsonpathKeyEquality(
+	e jsonpath.Expr, start jsonpath.Expr,
+) (keys []string, val json.JSON, ok bool) {
+	b, ok := e.(*jsonpath.Binary)
+	if !ok || b.Op != jsonpath.OpEq {
+		return nil, nil, false
+	}
+	path, lit := b.Left, b.Right
+	if _, ok := path.(*jsonpath.Literal); ok {
+		path, lit = lit, path
+	}
+	l, ok := lit.(*jsonpath.Literal)
+	if !ok {
+		return nil, nil, false
+	}
+	if keys, ok = jsonpathKeys(path, start); !ok {
+		return nil, nil, false
+	}
+	return keys, l.Value, true
+}
+
+// jsonpathKeys returns the keys of a jsonpath of the form start.key0.key1.
+func jsonpathKeys(e jsonpath.Expr, start jsonpath.Expr) ([]string, bool) {
+	if e == start {
+		return nil, true
+	}
+	p, ok := e.(*jsonpath.Path)
+	if !ok || p.Start != start {
+		return nil, false
+	}
+	keys := make([]string, 0, len(p.Accessors))
+	for _, a := range p.Accessors {
+		k, ok := a.(*jsonpath.Key)
+		if !ok {
+			return nil, false
+		}
+		keys = append(keys, k.Name)
+	}
+	return keys, true
+}
+
+// wrapInArray returns a single-element JSON array containing j.
+func wrapInArray(j json.JSON) json.JSON {
+	b := json.NewArrayBuilder(1)
+	b.Add(j)
+	return b.Build()
+}
+
 // extractJSONEqCondition extracts an InvertedExpression representing an
 // inverted filter over the planner's inverted index, based on equality between
 // two scalar expressions. If an InvertedExpression cannot be generated from the
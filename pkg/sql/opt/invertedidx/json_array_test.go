@@ -1129,6 +1129,52 @@ func TestTryFilterJsonOrArrayIndex(t *testing.T) {
 			unique:           false,
 			remainingFilters: `j IN ('[1, 2, 3]', '{"a": "b"}', '1', '"a"')`,
 		},
+		{
+			// A strict jsonpath equality predicate is supported. The spans are
+			// not tight, since the predicate is not equivalent to containment.
+			filters:          `j @@ 'strict $.a.b == 1'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: `j @@ 'strict $.a.b == 1'`,
+		},
+		{
+			// In lax mode, the objects and the value may be wrapped in arrays, so
+			// the span expression is not unique.
+			filters:          `j @@ '$.a == "b"'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: `j @@ '$.a == "b"'`,
+		},
+		{
+			filters:          `j @? 'strict $.a ? (@.b == 1)'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: `j @? 'strict $.a ? (@.b == 1)'`,
+		},
+		{
+			// Only equality predicates are supported.
+			filters:  `j @@ '$.a > 1'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			// Wildcards are not supported.
+			filters:  `j @? '$.a[*] ? (@ == 1)'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			// @? with a predicate is not supported, since it is always true.
+			filters:  `j @? '$.a == 1'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
 	}
 
 	for _, tc := range testCases {
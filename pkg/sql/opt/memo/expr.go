@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
@@ -720,6 +721,53 @@ type UDFDefinition struct {
 	// During execution of the UDF, these columns are replaced with the arguments
 	// of the function invocation.
 	Params opt.ColList
+
+	// ExceptionBlock contains information needed for exception-handling when
+	// the body of this routine returns an error. It is only set for routines
+	// built from a PL/pgSQL block with an EXCEPTION section.
+	ExceptionBlock *ExceptionBlock
+}
+
+// AggSupportFuncs contains the support functions of a user-defined aggregate,
+// built as calls to the functions.
+type AggSupportFuncs struct {
+	// Transition computes the next state of the aggregate from the current
+	// state and the arguments of the aggregate.
+	Transition AggSupportFunc
+	// Final computes the result of the aggregate from its final state. Its Call
+	// is nil if the aggregate has no final function.
+	Final AggSupportFunc
+	// StateType is the type of the aggregate's state.
+	StateType *types.T
+	// InitialState is the initial state of the aggregate.
+	InitialState tree.Datum
+}
+
+// AggSupportFunc is a call to a support function of a user-defined aggregate.
+type AggSupportFunc struct {
+	// Call is the call to the support function. Its arguments reference the
+	// columns in Params.
+	Call opt.ScalarExpr
+	// Params are the columns which are bound to the arguments of the support
+	// function when it is called: the state of the aggregate followed by the
+	// arguments of the aggregate for the transition function, and the state
+	// for the final function.
+	Params opt.ColList
+	// Strict is true if the function is not called on NULL inputs.
+	Strict bool
+}
+
+// ExceptionBlock contains the information needed to match and handle errors in
+// the EXCEPTION section of a PL/pgSQL routine.
+type ExceptionBlock struct {
+	// Codes is a list of pgcodes that the exception handlers match against. The
+	// special code "OTHERS" matches all errors except query cancellation and
+	// assertion failures.
+	Codes []pgcode.Code
+
+	// Actions contains a routine to handle each error code. The i-th routine
+	// handles errors that match the i-th code in Codes.
+	Actions []*UDFDefinition
 }
 
 // WindowFrame denotes the definition of a window frame for an individual
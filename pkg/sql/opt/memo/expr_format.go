@@ -532,6 +532,13 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			tp.Child(b.String())
 		}
 		f.formatLocking(tp, private.Locking)
+		if private.Sample.System {
+			if private.Sample.Repeatable {
+				tp.Childf("sample: system (%g) repeatable (%d)", private.Sample.Percent, private.Sample.Seed)
+			} else {
+				tp.Childf("sample: system (%g)", private.Sample.Percent)
+			}
+		}
 
 	case *InvertedFilterExpr:
 		var b strings.Builder
@@ -665,6 +672,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			}
 			if t.CanaryCol != 0 {
 				f.formatRelColList(e, tp, "canary column:", opt.ColList{t.CanaryCol})
+				if t.DeleteCol != 0 {
+					f.formatRelColList(e, tp, "delete column:", opt.ColList{t.DeleteCol})
+				}
 				f.formatOptionalColList(e, tp, "fetch columns:", t.FetchCols)
 				f.formatMutationCols(e, tp, "insert-mapping:", t.InsertCols, t.Table)
 				f.formatMutationCols(e, tp, "update-mapping:", t.UpdateCols, t.Table)
@@ -946,6 +956,16 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 			for i := range udf.Def.Body {
 				f.formatExpr(udf.Def.Body[i], n)
 			}
+			if udf.Def.ExceptionBlock != nil {
+				n = tp.Child("exception-handler")
+				for i, code := range udf.Def.ExceptionBlock.Codes {
+					handler := n.Childf("SQLSTATE '%s'", code.String())
+					action := udf.Def.ExceptionBlock.Actions[i]
+					for j := range action.Body {
+						f.formatExpr(action.Body[j], handler)
+					}
+				}
+			}
 			delete(f.seenUDFs, udf.Def)
 		} else {
 			tp.Child("recursive-call")
@@ -1206,14 +1226,24 @@ func (f *ExprFmtCtx) formatScalarPrivate(scalar opt.ScalarExpr) {
 
 	case *UniqueChecksItem:
 		tab := f.Memo.metadata.TableMeta(t.Table)
-		constraint := tab.Table.Unique(t.CheckOrdinal)
+		var colOrds []int
+		if t.Exclusion {
+			constraint := tab.Table.ExclusionConstraint(t.CheckOrdinal)
+			for i := 0; i < constraint.ColumnCount(); i++ {
+				colOrds = append(colOrds, constraint.ColumnOrdinal(tab.Table, i))
+			}
+		} else {
+			constraint := tab.Table.Unique(t.CheckOrdinal)
+			for i := 0; i < constraint.ColumnCount(); i++ {
+				colOrds = append(colOrds, constraint.ColumnOrdinal(tab.Table, i))
+			}
+		}
 		fmt.Fprintf(f.Buffer, ": %s(", tab.Alias.ObjectName)
-		for i := 0; i < constraint.ColumnCount(); i++ {
+		for i, ord := range colOrds {
 			if i > 0 {
 				f.Buffer.WriteByte(',')
 			}
-			col := tab.Table.Column(constraint.ColumnOrdinal(tab.Table, i))
-			f.Buffer.WriteString(string(col.ColName()))
+			f.Buffer.WriteString(string(tab.Table.Column(ord).ColName()))
 		}
 		f.Buffer.WriteByte(')')
 
@@ -1588,7 +1618,7 @@ func (f *ExprFmtCtx) formatDependencies(
 		n.Child(f.Buffer.String())
 	}
 	for _, typ := range f.Memo.Metadata().AllUserDefinedTypes() {
-		typeID := catid.UserDefinedOIDToID(typ.Oid())
+		typeID := catid.UserDefinedOIDToID(typ.UserDefinedOID())
 		if typeDeps.Contains(int(typeID)) {
 			n.Child(typ.Name())
 		}
@@ -1672,6 +1702,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UserDefinedAggPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *WindowsItemPrivate:
 		fmt.Fprintf(f.Buffer, " frame=%q", &t.Frame)
 
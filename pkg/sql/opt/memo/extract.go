@@ -139,6 +139,15 @@ func ExtractAggInputColumns(e opt.ScalarExpr) opt.ColSet {
 		}
 	}
 
+	// The arguments of a user-defined aggregate are stored in a list.
+	if uda, ok := e.(*UserDefinedAggExpr); ok {
+		for i := range uda.Args {
+			if variable, ok := uda.Args[i].(*VariableExpr); ok {
+				res.Add(variable.Col)
+			}
+		}
+	}
+
 	return res
 }
 
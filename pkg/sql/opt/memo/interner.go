@@ -645,6 +645,13 @@ func (h *hasher) HashLocking(val opt.Locking) {
 	h.HashByte(byte(val.WaitPolicy))
 }
 
+func (h *hasher) HashTableSample(val opt.TableSample) {
+	h.HashBool(val.System)
+	h.HashFloat64(val.Percent)
+	h.HashBool(val.Repeatable)
+	h.HashInt64(val.Seed)
+}
+
 func (h *hasher) HashInvertedSpans(val inverted.Spans) {
 	for i := range val {
 		span := &val[i]
@@ -762,6 +769,10 @@ func (h *hasher) HashUDFDefinition(val *UDFDefinition) {
 	h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
 }
 
+func (h *hasher) HashAggSupportFuncs(val *AggSupportFuncs) {
+	h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
+}
+
 // ----------------------------------------------------------------------
 //
 // Equality functions
@@ -1061,6 +1072,10 @@ func (h *hasher) IsLockingEqual(l, r opt.Locking) bool {
 	return l == r
 }
 
+func (h *hasher) IsTableSampleEqual(l, r opt.TableSample) bool {
+	return l == r
+}
+
 func (h *hasher) IsInvertedSpansEqual(l, r inverted.Spans) bool {
 	return l.Equals(r)
 }
@@ -1219,6 +1234,10 @@ func (h *hasher) IsLiteralRowsEqual(l, r *opt.LiteralRows) bool {
 	return l == r
 }
 
+func (h *hasher) IsAggSupportFuncsEqual(l, r *AggSupportFuncs) bool {
+	return l == r
+}
+
 func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 	if len(l.Body) != len(r.Body) {
 		return false
@@ -1231,7 +1250,8 @@ func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 			return false
 		}
 	}
-	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive
+	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive &&
+		l.ExceptionBlock == r.ExceptionBlock
 }
 
 // encodeDatum turns the given datum into an encoded string of bytes. If two
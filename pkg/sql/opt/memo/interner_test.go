@@ -521,6 +521,30 @@ func TestInterner(t *testing.T) {
 			},
 		}},
 
+		{hashFn: in.hasher.HashTableSample, eqFn: in.hasher.IsTableSampleEqual, variations: []testVariation{
+			{val1: opt.TableSample{}, val2: opt.TableSample{}, equal: true},
+			{
+				val1:  opt.TableSample{},
+				val2:  opt.TableSample{System: true},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{System: true, Percent: 10},
+				val2:  opt.TableSample{System: true, Percent: 20},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{System: true, Percent: 10, Repeatable: true, Seed: 1},
+				val2:  opt.TableSample{System: true, Percent: 10, Repeatable: true, Seed: 2},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{System: true, Percent: 10, Repeatable: true, Seed: 1},
+				val2:  opt.TableSample{System: true, Percent: 10, Repeatable: true, Seed: 1},
+				equal: true,
+			},
+		}},
+
 		{hashFn: in.hasher.HashRelExpr, eqFn: in.hasher.IsRelExprEqual, variations: []testVariation{
 			{val1: (*ScanExpr)(nil), val2: (*ScanExpr)(nil), equal: true},
 			{val1: scanNode, val2: scanNode, equal: true},
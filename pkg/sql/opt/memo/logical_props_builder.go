@@ -1526,6 +1526,10 @@ func (b *logicalPropsBuilder) buildCreateFunctionProps(
 	BuildSharedProps(cf, &rel.Shared, b.evalCtx)
 }
 
+func (b *logicalPropsBuilder) buildCallProps(call *CallExpr, rel *props.Relational) {
+	b.buildBasicProps(call, call.Columns, rel)
+}
+
 func (b *logicalPropsBuilder) buildFiltersItemProps(item *FiltersItem, scalar *props.Scalar) {
 	BuildSharedProps(item.Condition, &scalar.Shared, b.evalCtx)
 
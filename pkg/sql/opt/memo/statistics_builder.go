@@ -14,6 +14,7 @@ import (
 	"context"
 	"math"
 	"reflect"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
@@ -27,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
@@ -651,9 +653,11 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 
 			var cols opt.ColSet
 			var colOrd int
+			colList := make(opt.ColList, stat.ColumnCount())
 			for i := 0; i < stat.ColumnCount(); i++ {
 				colOrd = stat.ColumnOrdinal(i)
-				cols.Add(tabID.ColumnID(colOrd))
+				colList[i] = tabID.ColumnID(colOrd)
+				cols.Add(colList[i])
 			}
 
 			// We currently only use average column sizes of single column
@@ -694,6 +698,16 @@ func (sb *statisticsBuilder) makeTableStatistics(tabID opt.TableID) *props.Stati
 				//    non-inverted histogram that we should be using instead.
 				colStat.DistinctCount = float64(stat.DistinctCount())
 				colStat.NullCount = float64(stat.NullCount())
+				if cols.Len() > 1 && stat.Histogram() != nil &&
+					sb.evalCtx.SessionData().OptimizerUseHistograms &&
+					stat.HistogramType().Family() == types.TupleFamily {
+					// The histogram of a multi-column statistic is kept aside, and is
+					// only used to calculate the selectivity of filters on the table.
+					// See selectivityFromMultiColHistograms.
+					colStat.MultiColHistogram = &props.Histogram{}
+					colStat.MultiColHistogram.Init(sb.evalCtx, colList[0], stat.Histogram())
+					colStat.MultiColHistogramCols = colList
+				}
 				if needHistogram && !invertedStatistic {
 					// A statistic is inverted if the column is invertible and its
 					// histogram contains buckets of types BYTES.
@@ -794,6 +808,11 @@ func (sb *statisticsBuilder) buildScan(scan *ScanExpr, relProps *props.Relationa
 
 	inputStats := sb.makeTableStatistics(scan.Table)
 	s.RowCount = inputStats.RowCount
+	if scan.Sample.System {
+		// A sampled scan reads the given percentage of the ranges, which we
+		// assume contain the same percentage of the rows.
+		s.ApplySelectivity(props.MakeSelectivity(scan.Sample.Percent / 100))
+	}
 	pred := scan.PartialIndexPredicate(sb.md)
 
 	// If the constraints and pred are nil, then this scan is an unconstrained
@@ -978,8 +997,18 @@ func (sb *statisticsBuilder) constrainScan(
 
 	// Calculate row count and selectivity
 	// -----------------------------------
-	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, scan, s)
-	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, scan, s, corr))
+	multiColHistSelectivity, multiColHistCols := props.OneSelectivity, opt.ColSet{}
+	if constraint != nil && scan.InvertedConstraint == nil && pred == nil {
+		multiColHistSelectivity, multiColHistCols = sb.selectivityFromMultiColHistograms(
+			scan.Table, constraint,
+		)
+	}
+	s.ApplySelectivity(multiColHistSelectivity)
+	remainingCols := constrainedCols.Difference(multiColHistCols)
+	corr := sb.correlationFromMultiColDistinctCounts(remainingCols, scan, s)
+	s.ApplySelectivity(sb.selectivityFromConstrainedCols(
+		remainingCols, histCols.Difference(multiColHistCols), scan, s, corr,
+	))
 	s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(numUnappliedConjuncts))
 	s.ApplySelectivity(sb.selectivityFromNullsRemoved(scan, notNullCols, constrainedCols))
 }
@@ -3071,8 +3100,32 @@ func (sb *statisticsBuilder) filterRelExpr(
 
 	// Calculate row count and selectivity
 	// -----------------------------------
-	corr := sb.correlationFromMultiColDistinctCounts(constrainedCols, e, s)
-	s.ApplySelectivity(sb.selectivityFromConstrainedCols(constrainedCols, histCols, e, s, corr))
+	multiColHistSelectivity, multiColHistCols := props.OneSelectivity, opt.ColSet{}
+	if sel, ok := e.(*SelectExpr); ok {
+		// Multi-column histograms describe the base table, so they can only be
+		// used when filtering an unfiltered scan.
+		if scan, ok := sel.Input.(*ScanExpr); ok && scan.IsUnfiltered(sb.md) {
+			var tightConstraints []*constraint.Constraint
+			for i := range filters {
+				scalarProps := filters[i].ScalarProps()
+				if !scalarProps.TightConstraints || scalarProps.Constraints == nil {
+					continue
+				}
+				for j, n := 0, scalarProps.Constraints.Length(); j < n; j++ {
+					tightConstraints = append(tightConstraints, scalarProps.Constraints.Constraint(j))
+				}
+			}
+			multiColHistSelectivity, multiColHistCols = sb.selectivityFromMultiColHistograms(
+				scan.Table, tightConstraints...,
+			)
+		}
+	}
+	s.ApplySelectivity(multiColHistSelectivity)
+	remainingCols := constrainedCols.Difference(multiColHistCols)
+	corr := sb.correlationFromMultiColDistinctCounts(remainingCols, e, s)
+	s.ApplySelectivity(sb.selectivityFromConstrainedCols(
+		remainingCols, histCols.Difference(multiColHistCols), e, s, corr,
+	))
 	s.ApplySelectivity(sb.selectivityFromEquivalencies(equivReps, &relProps.FuncDeps, e, s))
 	s.ApplySelectivity(sb.selectivityFromUnappliedConjuncts(numUnappliedConjuncts))
 	s.ApplySelectivity(sb.selectivityFromNullsRemoved(e, notNullCols, constrainedCols))
@@ -4158,6 +4211,176 @@ func (sb *statisticsBuilder) selectivityFromConstrainedCols(
 	return selectivity
 }
 
+// maxMultiColHistogramPoints is the maximum number of tuples that are looked up
+// in a multi-column histogram to calculate the selectivity of constraints.
+const maxMultiColHistogramPoints = 100
+
+// multiColHistogramPoints is a list of constant values for a list of columns,
+// deduced from a constraint with only single-key spans.
+type multiColHistogramPoints struct {
+	cols   opt.ColList
+	points []tree.Datums
+}
+
+// makeMultiColHistogramPoints returns the constant values of the constrained
+// columns of c. ok is false if c has spans that are not single-key, has keys
+// of different lengths, constrains a column to NULL, or has too many spans.
+func makeMultiColHistogramPoints(
+	c *constraint.Constraint, evalCtx *eval.Context,
+) (p multiColHistogramPoints, ok bool) {
+	if c.IsUnconstrained() || c.IsContradiction() || c.Spans.Count() > maxMultiColHistogramPoints {
+		return p, false
+	}
+	keyLen := c.Spans.Get(0).StartKey().Length()
+	if keyLen == 0 {
+		return p, false
+	}
+	p.points = make([]tree.Datums, c.Spans.Count())
+	for i := range p.points {
+		sp := c.Spans.Get(i)
+		if !sp.HasSingleKey(evalCtx) || sp.StartKey().Length() != keyLen {
+			return p, false
+		}
+		p.points[i] = make(tree.Datums, keyLen)
+		for j := range p.points[i] {
+			p.points[i][j] = sp.StartKey().Value(j)
+			if p.points[i][j] == tree.DNull {
+				return p, false
+			}
+		}
+	}
+	p.cols = make(opt.ColList, keyLen)
+	for j := range p.cols {
+		p.cols[j] = c.Columns.Get(j).ID()
+	}
+	return p, true
+}
+
+// selectivityFromMultiColHistograms calculates the selectivity of the given
+// constraints using the histograms of multi-column statistics on the table.
+// A histogram is only used if every one of its columns is constrained to a
+// small set of constant values. Returns the columns of the histograms that
+// were used, so that the selectivity of their constraints is not applied a
+// second time.
+func (sb *statisticsBuilder) selectivityFromMultiColHistograms(
+	tabID opt.TableID, constraints ...*constraint.Constraint,
+) (selectivity props.Selectivity, cols opt.ColSet) {
+	selectivity = props.OneSelectivity
+	var pointSets []multiColHistogramPoints
+	for _, c := range constraints {
+		if p, ok := makeMultiColHistogramPoints(c, sb.evalCtx); ok {
+			pointSets = append(pointSets, p)
+		}
+	}
+	if len(pointSets) == 0 {
+		return selectivity, cols
+	}
+
+	tableStats := sb.makeTableStatistics(tabID)
+	for i, n := 0, tableStats.ColStats.Count(); i < n; i++ {
+		colStat := tableStats.ColStats.Get(i)
+		if colStat.MultiColHistogram == nil || colStat.Cols.Intersects(cols) {
+			continue
+		}
+		tuples, ok := sb.multiColHistogramTuples(colStat.MultiColHistogramCols, pointSets)
+		if !ok {
+			continue
+		}
+
+		// Build a constraint on the first column of the histogram with one
+		// single-key span for each tuple, and use it to filter the histogram.
+		var keyCols constraint.Columns
+		keyCols.InitSingle(opt.MakeOrderingColumn(colStat.MultiColHistogramCols[0], false /* descending */))
+		keyCtx := constraint.MakeKeyContext(&keyCols, sb.evalCtx)
+		var spans constraint.Spans
+		spans.Alloc(len(tuples))
+		for _, t := range tuples {
+			var sp constraint.Span
+			key := constraint.MakeKey(t)
+			sp.Init(key, constraint.IncludeBoundary, key, constraint.IncludeBoundary)
+			spans.Append(&sp)
+		}
+		var c constraint.Constraint
+		c.Init(&keyCtx, &spans)
+
+		filtered := colStat.MultiColHistogram.Filter(&c)
+		selectivity.Multiply(props.MakeSelectivityFromFraction(
+			filtered.ValuesCount(), tableStats.RowCount,
+		))
+		cols.UnionWith(colStat.Cols)
+	}
+	return selectivity, cols
+}
+
+// multiColHistogramTuples returns the sorted, de-duplicated tuples of constant
+// values of histCols, built from the cross product of the point sets that
+// constrain them. ok is false if some column is not constrained by any of the
+// point sets, or if there would be too many tuples.
+func (sb *statisticsBuilder) multiColHistogramTuples(
+	histCols opt.ColList, pointSets []multiColHistogramPoints,
+) (tuples tree.Datums, ok bool) {
+	// For each histogram column, find the first point set (and its column
+	// offset) that constrains it.
+	setIdxs := make([]int, len(histCols))
+	colIdxs := make([]int, len(histCols))
+	var usedSets intsets.Fast
+	for i, col := range histCols {
+		found := false
+		for j := range pointSets {
+			if k, ok := pointSets[j].cols.Find(col); ok {
+				setIdxs[i], colIdxs[i], found = j, k, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+		usedSets.Add(setIdxs[i])
+	}
+	numTuples := 1
+	for j, ok := usedSets.Next(0); ok; j, ok = usedSets.Next(j + 1) {
+		numTuples *= len(pointSets[j].points)
+		if numTuples > maxMultiColHistogramPoints {
+			return nil, false
+		}
+	}
+
+	contents := make([]*types.T, len(histCols))
+	for i, col := range histCols {
+		contents[i] = sb.md.ColumnMeta(col).Type
+	}
+	typ := types.MakeTuple(contents)
+
+	// Enumerate the cross product of the used point sets.
+	choices := make([]int, len(pointSets))
+	tuples = make(tree.Datums, 0, numTuples)
+	for t := 0; t < numTuples; t++ {
+		elems := make(tree.Datums, len(histCols))
+		for i := range histCols {
+			elems[i] = pointSets[setIdxs[i]].points[choices[setIdxs[i]]][colIdxs[i]]
+		}
+		tuples = append(tuples, tree.NewDTuple(typ, elems...))
+		for j, ok := usedSets.Next(0); ok; j, ok = usedSets.Next(j + 1) {
+			choices[j]++
+			if choices[j] < len(pointSets[j].points) {
+				break
+			}
+			choices[j] = 0
+		}
+	}
+
+	sort.Slice(tuples, func(i, j int) bool {
+		return tuples[i].Compare(sb.evalCtx, tuples[j]) < 0
+	})
+	res := tuples[:1]
+	for i := 1; i < len(tuples); i++ {
+		if tuples[i].Compare(sb.evalCtx, res[len(res)-1]) != 0 {
+			res = append(res, tuples[i])
+		}
+	}
+	return res, true
+}
+
 // selectivityFromNullsRemoved calculates the selectivity from null-rejecting
 // filters that were not already accounted for in selectivityFromMultiColDistinctCounts
 // or selectivityFromHistograms. The columns for filters already accounted for
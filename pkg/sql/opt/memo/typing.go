@@ -192,6 +192,7 @@ func init() {
 	typingFuncMap[opt.ArrayFlattenOp] = typeArrayFlatten
 	typingFuncMap[opt.IfErrOp] = typeIfErr
 	typingFuncMap[opt.UDFCallOp] = typeUDFCall
+	typingFuncMap[opt.UserDefinedAggOp] = typeUserDefinedAgg
 
 	// Override default typeAsAggregate behavior for aggregate functions with
 	// a large number of possible overloads or where ReturnType depends on
@@ -387,6 +388,11 @@ func typeUDFCall(e opt.ScalarExpr) *types.T {
 	return e.(*UDFCallExpr).Def.Typ
 }
 
+// typeUserDefinedAgg returns the return type of a user-defined aggregate.
+func typeUserDefinedAgg(e opt.ScalarExpr) *types.T {
+	return e.(*UserDefinedAggExpr).Typ
+}
+
 // typeSubquery returns the type of a subquery, which is equal to the type of
 // its first (and only) column.
 func typeSubquery(e opt.ScalarExpr) *types.T {
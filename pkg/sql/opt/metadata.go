@@ -250,7 +250,7 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		}
 		for i := range from.userDefinedTypesSlice {
 			typ := from.userDefinedTypesSlice[i]
-			md.userDefinedTypes[typ.Oid()] = struct{}{}
+			md.userDefinedTypes[typ.UserDefinedOID()] = struct{}{}
 			md.userDefinedTypesSlice = append(md.userDefinedTypesSlice, typ)
 		}
 	}
@@ -402,17 +402,17 @@ func (md *Metadata) CheckDependencies(
 
 	// Check that no referenced user defined types have changed.
 	for _, typ := range md.AllUserDefinedTypes() {
-		id := cat.StableID(catid.UserDefinedOIDToID(typ.Oid()))
+		id := cat.StableID(catid.UserDefinedOIDToID(typ.UserDefinedOID()))
 		if names, ok := md.objectRefsByName[id]; ok {
 			for _, name := range names {
 				toCheck, err := optCatalog.ResolveType(ctx, name)
-				if err != nil || typ.Oid() != toCheck.Oid() ||
+				if err != nil || typ.UserDefinedOID() != toCheck.UserDefinedOID() ||
 					typ.TypeMeta.Version != toCheck.TypeMeta.Version {
 					return false, maybeSwallowMetadataResolveErr(err)
 				}
 			}
 		} else {
-			toCheck, err := optCatalog.ResolveTypeByOID(ctx, typ.Oid())
+			toCheck, err := optCatalog.ResolveTypeByOID(ctx, typ.UserDefinedOID())
 			if err != nil || typ.TypeMeta.Version != toCheck.TypeMeta.Version {
 				return false, maybeSwallowMetadataResolveErr(err)
 			}
@@ -525,12 +525,12 @@ func (md *Metadata) AddUserDefinedType(typ *types.T, name *tree.UnresolvedObject
 	if md.userDefinedTypes == nil {
 		md.userDefinedTypes = make(map[oid.Oid]struct{})
 	}
-	if _, ok := md.userDefinedTypes[typ.Oid()]; !ok {
-		md.userDefinedTypes[typ.Oid()] = struct{}{}
+	if _, ok := md.userDefinedTypes[typ.UserDefinedOID()]; !ok {
+		md.userDefinedTypes[typ.UserDefinedOID()] = struct{}{}
 		md.userDefinedTypesSlice = append(md.userDefinedTypesSlice, typ)
 	}
 	if name != nil {
-		id := cat.StableID(catid.UserDefinedOIDToID(typ.Oid()))
+		id := cat.StableID(catid.UserDefinedOIDToID(typ.UserDefinedOID()))
 		md.objectRefsByName[id] = append(md.objectRefsByName[id], name)
 	}
 }
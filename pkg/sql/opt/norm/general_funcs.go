@@ -132,6 +132,12 @@ func (c *CustomFuncs) IsAdditiveType(typ *types.T) bool {
 	return types.IsAdditiveType(typ)
 }
 
+// IsDomainType returns true if the given type is a domain. Values of a domain
+// type are subject to its constraints, which may reject NULL.
+func (c *CustomFuncs) IsDomainType(typ *types.T) bool {
+	return typ.IsDomain()
+}
+
 // IsConstJSON returns true if the given ScalarExpr is a ConstExpr that wraps a
 // DJSON datum.
 func (c *CustomFuncs) IsConstJSON(expr opt.ScalarExpr) bool {
@@ -1495,7 +1501,7 @@ func (c *CustomFuncs) DatumsEqual(first, second tree.Datum) bool {
 // ----------------------------------------------------------------------
 
 // DuplicateScanPrivate constructs a new ScanPrivate with new table and column
-// IDs. Only the Index, Flags and Locking fields are copied from the old
+// IDs. Only the Index, Flags, Locking and Sample fields are copied from the old
 // ScanPrivate, so the new ScanPrivate will not have constraints even if the old
 // one did.
 func (c *CustomFuncs) DuplicateScanPrivate(sp *memo.ScanPrivate) *memo.ScanPrivate {
@@ -1506,6 +1512,7 @@ func (c *CustomFuncs) DuplicateScanPrivate(sp *memo.ScanPrivate) *memo.ScanPriva
 		Cols:    cols,
 		Flags:   sp.Flags,
 		Locking: sp.Locking,
+		Sample:  sp.Sample,
 	}
 }
 
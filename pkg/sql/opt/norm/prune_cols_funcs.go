@@ -78,6 +78,13 @@ func (c *CustomFuncs) neededMutationCols(
 	if private.CanaryCol != 0 {
 		cols.Add(private.CanaryCol)
 	}
+	if private.DeleteCol != 0 {
+		cols.Add(private.DeleteCol)
+	}
+	for i := range private.FKCascades {
+		cols.UnionWith(private.FKCascades[i].OldValues.ToSet())
+		cols.UnionWith(private.FKCascades[i].NewValues.ToSet())
+	}
 
 	if private.WithID != 0 {
 		for i := range uniqueChecks {
@@ -223,6 +230,14 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 			}
 		}
 
+		// An Upsert built for a MERGE statement may delete existing rows, which
+		// requires the strict key columns from all indexes, as for Delete.
+		if op == opt.UpsertOp && private.DeleteCol != 0 {
+			for i, n := 0, tabMeta.Table.DeletableIndexCount(); i < n; i++ {
+				cols.UnionWith(tabMeta.IndexKeyColumnsMapInverted(i))
+			}
+		}
+
 	case opt.DeleteOp:
 		// Add in all strict key columns from all indexes, since these are needed
 		// to compose the keys of rows to delete. Include mutation indexes, since
@@ -142,6 +142,7 @@ var ComparisonOpReverseMap = map[Operator]treecmp.ComparisonOperatorSymbol{
 	JsonExistsOp:     treecmp.JSONExists,
 	JsonSomeExistsOp: treecmp.JSONSomeExists,
 	JsonAllExistsOp:  treecmp.JSONAllExists,
+	JsonPathExistsOp: treecmp.JSONPathExists,
 	OverlapsOp:       treecmp.Overlaps,
 	BBoxCoversOp:     treecmp.RegMatch,
 	BBoxIntersectsOp: treecmp.Overlaps,
@@ -168,6 +169,7 @@ var BinaryOpReverseMap = map[Operator]treebin.BinaryOperatorSymbol{
 	FetchTextOp:     treebin.JSONFetchText,
 	FetchValPathOp:  treebin.JSONFetchValPath,
 	FetchTextPathOp: treebin.JSONFetchTextPath,
+	DistanceOp:      treebin.Distance,
 }
 
 // UnaryOpReverseMap maps from an optimizer operator type to a semantic tree
@@ -331,7 +333,8 @@ func AggregateIgnoresNulls(op Operator) bool {
 		return true
 
 	case ArrayAggOp, ArrayCatAggOp, ConcatAggOp, ConstAggOp, CountRowsOp,
-		FirstAggOp, JsonAggOp, JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp:
+		FirstAggOp, JsonAggOp, JsonbAggOp, JsonObjectAggOp, JsonbObjectAggOp,
+		UserDefinedAggOp:
 		return false
 
 	default:
@@ -356,7 +359,7 @@ func AggregateIsNullOnEmpty(op Operator) bool {
 		RegressionSXYOp, RegressionSYYOp:
 		return true
 
-	case CountOp, CountRowsOp, RegressionCountOp:
+	case CountOp, CountRowsOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -385,7 +388,7 @@ func AggregateIsNeverNullOnNonNullInput(op Operator) bool {
 		return true
 
 	case VarianceOp, StdDevOp, CorrOp, CovarSampOp, RegressionInterceptOp,
-		RegressionR2Op, RegressionSlopeOp, STExtentOp, STMakeLineOp:
+		RegressionR2Op, RegressionSlopeOp, STExtentOp, STMakeLineOp, UserDefinedAggOp:
 		// These aggregations can return NULL even with non-null input values.
 		return false
 
@@ -436,7 +439,7 @@ func AggregatesCanMerge(inner, outer Operator) bool {
 		SqrDiffOp, STCollectOp, StdDevOp, StringAggOp, VarianceOp, StdDevPopOp,
 		VarPopOp, CovarPopOp, CovarSampOp, RegressionAvgXOp, RegressionAvgYOp,
 		RegressionInterceptOp, RegressionR2Op, RegressionSlopeOp, RegressionSXXOp,
-		RegressionSXYOp, RegressionSYYOp, RegressionCountOp:
+		RegressionSXYOp, RegressionSYYOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -458,7 +461,7 @@ func AggregateIgnoresDuplicates(op Operator) bool {
 		VarPopOp, JsonObjectAggOp, JsonbObjectAggOp, STCollectOp, CovarPopOp,
 		CovarSampOp, RegressionAvgXOp, RegressionAvgYOp, RegressionInterceptOp,
 		RegressionR2Op, RegressionSlopeOp, RegressionSXXOp, RegressionSXYOp,
-		RegressionSYYOp, RegressionCountOp:
+		RegressionSYYOp, RegressionCountOp, UserDefinedAggOp:
 		return false
 
 	default:
@@ -109,6 +109,11 @@ type Builder struct {
 	// be used with care.
 	skipSelectPrivilegeChecks bool
 
+	// If set, the table data source being built is scanned without the tables
+	// inheriting from it, as requested by FROM ONLY. It is reset as soon as the
+	// table name is resolved.
+	onlyTable bool
+
 	// views contains a cache of views that have already been parsed, in case they
 	// are referenced multiple times in the same query.
 	views map[cat.View]*tree.Select
@@ -301,10 +306,10 @@ func (b *Builder) buildStmt(
 	if b.insideViewDef {
 		// A blocklist of statements that can't be used from inside a view.
 		switch stmt := stmt.(type) {
-		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
-			*tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
+		case *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge, *tree.CreateTable,
+			*tree.CreateView, *tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
 			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions,
-			*tree.CreateFunction:
+			*tree.CreateFunction, *tree.Call:
 			panic(pgerror.Newf(
 				pgcode.Syntax, "%s cannot be used inside a view definition", stmt.StatementTag(),
 			))
@@ -342,6 +347,11 @@ func (b *Builder) buildStmt(
 			return b.buildUpdate(stmt, inScope)
 		})
 
+	case *tree.Merge:
+		return b.processWiths(stmt.With, inScope, func(inScope *scope) *scope {
+			return b.buildMerge(stmt, inScope)
+		})
+
 	case *tree.CreateTable:
 		return b.buildCreateTable(stmt, inScope)
 
@@ -379,6 +389,9 @@ func (b *Builder) buildStmt(
 	case *tree.ControlSchedules:
 		return b.buildControlSchedules(stmt, inScope)
 
+	case *tree.Call:
+		return b.buildCall(stmt, inScope)
+
 	case *tree.ShowCompletions:
 		return b.buildShowCompletions(stmt, inScope)
 
@@ -0,0 +1,59 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// buildCall builds a CALL statement, which invokes a procedure.
+func (b *Builder) buildCall(c *tree.Call, inScope *scope) (outScope *scope) {
+	// The arguments of the procedure cannot reference outer columns, so we
+	// type-check the call with a "blank" scope rather than inScope.
+	emptyScope := b.allocScope()
+	texpr := emptyScope.resolveType(c.Proc, types.Any)
+	f, ok := texpr.(*tree.FuncExpr)
+	if !ok {
+		panic(errors.AssertionFailedf("expected FuncExpr, found %T", texpr))
+	}
+	def, err := f.Func.Resolve(b.ctx, b.semaCtx.SearchPath, b.semaCtx.FunctionResolver)
+	if err != nil {
+		panic(err)
+	}
+	o := f.ResolvedOverload()
+	if !o.IsProcedure {
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is not a procedure", def.Name),
+			"To call a function, use SELECT.",
+		))
+	}
+
+	proc := b.buildUDF(f, def, emptyScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	// A procedure with OUT or INOUT parameters returns a single row with their
+	// values. The arguments of CALL only include the input parameters.
+	outScope = inScope.push()
+	cols := make(colinfo.ResultColumns, len(o.OutParams))
+	for i := range o.OutParams {
+		cols[i] = colinfo.ResultColumn{
+			Name: tree.OutParamColumnName(o.OutParams[i].Name, i),
+			Typ:  o.OutParams[i].Typ,
+		}
+	}
+	b.synthesizeResultColumns(outScope, cols)
+	outScope.expr = b.factory.ConstructCall(proc, &memo.CallPrivate{Columns: colsToColList(outScope.cols)})
+	return outScope
+}
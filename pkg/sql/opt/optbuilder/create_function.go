@@ -136,15 +136,43 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	}
 
 	// bodyScope is the base scope for each statement in the body. We add the
-	// named parameters to the scope so that references to them in the body can
-	// be resolved.
+	// named input parameters to the scope so that references to them in the
+	// body can be resolved.
 	bodyScope := b.allocScope()
+	var outParamTypes []*types.T
+	var outParamNames []string
+	paramOrd := 0
+	sawVariadic := false
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
 		if err != nil {
 			panic(err)
 		}
+
+		// Collect the user defined type dependencies.
+		typedesc.GetTypeDescriptorClosure(typ).ForEach(func(id descpb.ID) {
+			typeDeps.Add(int(id))
+		})
+
+		if param.Class.IsOutParam() {
+			outParamNames = append(outParamNames, tree.OutParamColumnName(string(param.Name), len(outParamTypes)))
+			outParamTypes = append(outParamTypes, typ)
+		}
+		if !param.Class.IsInParam() {
+			// Output parameters cannot be referenced in the body.
+			continue
+		}
+		if sawVariadic {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"VARIADIC parameter must be the last input parameter"))
+		}
+		if param.Class == tree.FunctionParamVariadic {
+			sawVariadic = true
+			if typ.Family() != types.ArrayFamily {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition, "VARIADIC parameter must be an array"))
+			}
+		}
 		if types.IsRecordType(typ) {
 			if language == tree.FunctionLangSQL {
 				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
@@ -158,14 +186,10 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 		}
 
 		// Add the parameter to the base scope of the body.
-		paramColName := funcParamColName(param.Name, i)
+		paramColName := funcParamColName(param.Name, paramOrd)
 		col := b.synthesizeColumn(bodyScope, paramColName, typ, nil /* expr */, nil /* scalar */)
-		col.setParamOrd(i)
-
-		// Collect the user defined type dependencies.
-		typedesc.GetTypeDescriptorClosure(typ).ForEach(func(id descpb.ID) {
-			typeDeps.Add(int(id))
-		})
+		col.setParamOrd(paramOrd)
+		paramOrd++
 	}
 
 	// Collect the user defined type dependency of the return type.
@@ -177,6 +201,24 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 		typeDeps.Add(int(id))
 	})
 
+	// The return type is determined by the output parameters, if there are
+	// any. A function with several output parameters returns a tuple of their
+	// types.
+	switch len(outParamTypes) {
+	case 0:
+	case 1:
+		if !funcReturnType.Equivalent(outParamTypes[0]) {
+			panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"function result type must be %s because of OUT parameters", outParamTypes[0].SQLString()))
+		}
+	default:
+		if !types.IsRecordType(funcReturnType) {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"function result type must be record because of OUT parameters"))
+		}
+		funcReturnType = types.MakeLabeledTuple(outParamTypes, outParamNames)
+	}
+
 	targetVolatility := tree.GetFuncVolatility(cf.Options)
 	fmtCtx := tree.NewFmtCtx(tree.FmtSerializable)
 
@@ -213,13 +255,6 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 			afterBuildStmt()
 		}
 	case tree.FunctionLangPLpgSQL:
-		if cf.ReturnType.IsSet {
-			panic(unimplemented.NewWithIssueDetail(105240,
-				"set-returning PL/pgSQL functions",
-				"set-returning PL/pgSQL functions are not yet supported",
-			))
-		}
-
 		// Parse the function body.
 		stmt, err := plpgsql.Parse(funcBodyStr)
 		if err != nil {
@@ -300,8 +335,9 @@ func validateReturnType(expected *types.T, cols []scopeColumn) error {
 		)
 	}
 
-	// If return type is RECORD, any column types are valid.
-	if types.IsRecordType(expected) {
+	// If return type is RECORD, any column types are valid. This is not the
+	// case for the tuple returned by a function with OUT parameters.
+	if expected.Identical(types.AnyTuple) {
 		return nil
 	}
 
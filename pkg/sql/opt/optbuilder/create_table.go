@@ -93,6 +93,13 @@ func (b *Builder) buildCreateTable(ct *tree.CreateTable, inScope *scope) (outSco
 			scopeCol := b.synthesizeColumn(outScope, scopeColName("rowid"), types.Int, nil /* expr */, fn)
 			input = b.factory.CustomFuncs().ProjectExtraCol(outScope.expr, fn, scopeCol.id)
 		}
+		if ct.AsWithNoData {
+			// WITH NO DATA only needs the columns of the query to create the
+			// table, so the query is never run.
+			input = b.factory.ConstructSelect(
+				input, memo.FiltersExpr{b.factory.ConstructFiltersItem(memo.FalseSingleton)},
+			)
+		}
 		inputCols = outScope.makePhysicalProps().Presentation
 	} else {
 		// Create dummy empty input.
@@ -100,8 +100,40 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets is non-nil if the GROUP BY clause contains ROLLUP, CUBE or
+	// GROUPING SETS. Each element contains the grouping columns of one grouping
+	// set, in the order in which the grouping sets are listed by the query. For
+	// example:
+	//
+	//   SELECT a, b, sum(c) FROM t GROUP BY ROLLUP (a, b)
+	//
+	// has the grouping sets (a, b), (a) and (). The aggregation produces the
+	// groups of every grouping set, with the grouping columns that are not part
+	// of the grouping set replaced by NULL.
+	groupingSets []opt.ColSet
+
+	// groupingFuncs contains information about GROUPING() expressions
+	// encountered.
+	groupingFuncs []*groupingFuncInfo
+
+	// groupingSetsExpansion is set once the input of an aggregation over more
+	// than one grouping set has been constructed.
+	groupingSetsExpansion *groupingSetsExpansion
 }
 
+// maxGroupingSets is the maximum number of grouping sets that a single GROUP
+// BY clause can expand to. This matches the limit in Postgres.
+const maxGroupingSets = 4096
+
+// maxCubeElements is the maximum number of elements in a CUBE. This matches
+// the limit in Postgres.
+const maxCubeElements = 12
+
+// maxGroupingFuncArgs is the maximum number of arguments to GROUPING(), which
+// is bounded by the number of bits in the INT4 result in Postgres.
+const maxGroupingFuncArgs = 31
+
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
 // grouping column in an aggOutScope scope that projects that expression. It
 // is used to enforce scoping rules, since any non-aggregate, variable
@@ -250,6 +282,11 @@ func (a aggregateInfo) isOrderingSensitive() bool {
 	if a.isOrderedSetAggregate() {
 		return true
 	}
+	if a.def.Overload != nil && a.def.Overload.UserDefinedAggregate != nil {
+		// The result of a user-defined aggregate may depend on the order in
+		// which its transition function is applied.
+		return true
+	}
 	switch a.def.Name {
 	case "array_agg", "array_cat_agg", "concat_agg", "string_agg", "json_agg",
 		"jsonb_agg", "json_object_agg", "jsonb_object_agg", "st_makeline",
@@ -279,14 +316,74 @@ func (a *aggregateInfo) ResolvedType() *types.T {
 var _ tree.Expr = &aggregateInfo{}
 var _ tree.TypedExpr = &aggregateInfo{}
 
+// groupingFuncInfo stores information about a GROUPING() expression. The
+// result of GROUPING() is constant within each grouping set, so it is computed
+// by the aggregation as an extra output column.
+type groupingFuncInfo struct {
+	*tree.GroupingFuncExpr
+
+	// args contains the resolved arguments of GROUPING(). Each argument must
+	// match a GROUP BY expression.
+	args []tree.TypedExpr
+
+	// argCols contains the grouping column corresponding to each argument. It
+	// is populated once the grouping columns have been built.
+	argCols opt.ColList
+
+	// col is the output column of the GROUPING() expression.
+	col *scopeColumn
+}
+
+// Walk is part of the tree.Expr interface.
+func (g *groupingFuncInfo) Walk(v tree.Visitor) tree.Expr {
+	return g
+}
+
+// TypeCheck is part of the tree.Expr interface.
+func (g *groupingFuncInfo) TypeCheck(
+	ctx context.Context, semaCtx *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return g, nil
+}
+
+// Eval is part of the tree.TypedExpr interface.
+func (g *groupingFuncInfo) Eval(_ context.Context, _ tree.ExprEvaluator) (tree.Datum, error) {
+	panic(errors.AssertionFailedf("groupingFuncInfo must be replaced before evaluation"))
+}
+
+// ResolvedType is part of the tree.TypedExpr interface.
+func (g *groupingFuncInfo) ResolvedType() *types.T {
+	return types.Int
+}
+
+// value returns the result of the GROUPING() expression for rows produced by
+// the given grouping set: a bitmask in which the rightmost argument maps to
+// the least significant bit, and a bit is set if the corresponding argument is
+// not part of the grouping set.
+func (g *groupingFuncInfo) value(groupingSet opt.ColSet) int64 {
+	var res int64
+	for _, col := range g.argCols {
+		res <<= 1
+		if !groupingSet.Contains(col) {
+			res |= 1
+		}
+	}
+	return res
+}
+
+var _ tree.Expr = &groupingFuncInfo{}
+var _ tree.TypedExpr = &groupingFuncInfo{}
+
 func (b *Builder) needsAggregation(sel *tree.SelectClause, scope *scope) bool {
 	// We have an aggregation if:
 	//  - we have a GROUP BY, or
 	//  - we have a HAVING clause, or
-	//  - we have aggregate functions in the SELECT, DISTINCT ON and/or ORDER BY expressions.
+	//  - we have aggregate functions in the SELECT, DISTINCT ON and/or ORDER BY expressions, or
+	//  - we have GROUPING() expressions (which are only valid in a grouping query).
 	return len(sel.GroupBy) > 0 ||
 		sel.Having != nil ||
-		(scope.groupby != nil && scope.groupby.hasAggregates())
+		(scope.groupby != nil && scope.groupby.hasAggregates()) ||
+		(scope.groupby != nil && len(scope.groupby.groupingFuncs) > 0)
 }
 
 func (b *Builder) constructGroupBy(
@@ -336,6 +433,22 @@ func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope,
 
 	// Copy the grouping columns to the aggOutScope.
 	g.aggOutScope.appendColumns(g.groupingCols())
+
+	// Resolve the arguments of any GROUPING() expressions and add their output
+	// columns to the aggOutScope.
+	for _, info := range g.groupingFuncs {
+		info.argCols = make(opt.ColList, len(info.args))
+		for i, arg := range info.args {
+			col, ok := g.groupStrs[symbolicExprStr(arg)]
+			if !ok {
+				panic(pgerror.Newf(pgcode.Grouping,
+					"arguments to GROUPING must be grouping expressions of the associated query level",
+				))
+			}
+			info.argCols[i] = col.id
+		}
+		g.aggOutScope.appendColumn(info.col)
+	}
 }
 
 // buildAggregation builds the aggregation operators and constructs the
@@ -385,7 +498,11 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		if agg.def.Overload.UserDefinedAggregate != nil {
+			aggCols[i].scalar = b.constructUserDefinedAggregate(&agg.def, agg.FuncExpr, args)
+		} else {
+			aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		}
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -426,16 +543,44 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 		g.aggInScope.copyOrdering(fromScope)
 	}
 
-	// Construct the pre-projection, which renders the grouping columns and the
-	// aggregate arguments, as well as any additional order by columns.
-	b.constructProjectForScope(fromScope, g.aggInScope)
-
-	g.aggOutScope.expr = b.constructGroupBy(
-		g.aggInScope.expr,
-		groupingColSet,
-		aggCols,
-		g.aggInScope.ordering,
-	)
+	if len(g.groupingSets) > 1 {
+		// Grouping sets construct their own pre-projection; see
+		// constructGroupingSetsInput.
+		input, exp := b.constructGroupingSetsInput(g, fromScope)
+		setAggCols := make([]scopeColumn, len(aggCols), len(aggCols)+1)
+		for i := range aggCols {
+			setAggCols[i] = aggCols[i]
+			setAggCols[i].scalar = exp.remapAggregate(b, aggCols[i].scalar)
+		}
+		if exp.presentCol != 0 {
+			setAggCols = append(setAggCols, scopeColumn{
+				id:     exp.presentAggCol,
+				scalar: b.factory.ConstructAnyNotNullAgg(b.factory.ConstructVariable(exp.presentCol)),
+			})
+		}
+		ordering := make(opt.Ordering, len(g.aggInScope.ordering))
+		for i, o := range g.aggInScope.ordering {
+			ordering[i] = opt.MakeOrderingColumn(exp.remapCol(o.ID()), o.Descending())
+		}
+		g.aggOutScope.expr = b.constructGroupBy(
+			exp.projectFilters(b, input),
+			groupingColSet.Union(opt.MakeColSet(exp.setCol)),
+			setAggCols,
+			ordering,
+		)
+	} else {
+		// Construct the pre-projection, which renders the grouping columns and
+		// the aggregate arguments, as well as any additional order by columns.
+		b.constructProjectForScope(fromScope, g.aggInScope)
+
+		g.aggOutScope.expr = b.constructGroupBy(
+			g.aggInScope.expr,
+			groupingColSet,
+			aggCols,
+			g.aggInScope.ordering,
+		)
+	}
+	g.aggOutScope.expr = b.constructGroupingFuncs(g, g.aggOutScope.expr)
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -447,6 +592,288 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	return g.aggOutScope
 }
 
+// groupingSetsExpansion describes the input of an aggregation over more than
+// one grouping set. See constructGroupingSetsInput.
+type groupingSetsExpansion struct {
+	// colMap maps each grouping column to the column of the pre-projection
+	// which holds its value before it is replaced by NULL for the grouping sets
+	// which don't include it. Aggregate arguments, filters and orderings which
+	// refer to grouping columns are remapped to those columns.
+	colMap opt.ColMap
+
+	// setCol is the column which holds the index of the grouping set (in
+	// groupby.groupingSets) of each expanded row. It is a grouping column of the
+	// aggregation, so that the groups of different grouping sets are never
+	// merged, even when they have the same grouping column values.
+	setCol opt.ColumnID
+
+	// emptySets contains the indexes of the grouping sets which have no grouping
+	// columns. Like a scalar aggregation, those produce a row even if the input
+	// is empty.
+	emptySets []int
+
+	// presentCol is only set if there are empty grouping sets. It is true for
+	// the expanded input rows, and NULL for the row which is produced for each
+	// grouping set when the input is empty. presentAggCol is the aggregation of
+	// presentCol, which is used to discard the groups of non-empty grouping sets
+	// for empty input.
+	presentCol, presentAggCol opt.ColumnID
+
+	// filterProjections projects the filters of the aggregates combined with
+	// presentCol. See remapAggregate.
+	filterProjections memo.ProjectionsExpr
+}
+
+// constructGroupingSetsInput constructs the input of an aggregation over more
+// than one grouping set. Every row of the input is expanded into one row per
+// grouping set, in which the grouping columns that are not part of the
+// grouping set are NULL. The input is therefore only computed and read once,
+// and all grouping sets are computed by a single aggregation that also groups
+// on the index of the grouping set of each row:
+//
+//	SELECT a, b, sum(c) FROM t GROUP BY GROUPING SETS ((a, b), (a))
+//	=>
+//	SELECT a, b, sum(c) FROM (
+//	  SELECT
+//	    c,
+//	    a' AS a,
+//	    CASE WHEN grouping_set IN (0) THEN b' END AS b,
+//	    grouping_set
+//	  FROM (SELECT c, a AS a', b AS b' FROM t)
+//	  CROSS JOIN (VALUES (0), (1)) AS v(grouping_set)
+//	) GROUP BY a, b, grouping_set
+//
+// If any of the grouping sets is empty, the VALUES are instead left joined to
+// the input, so that they produce a row for each grouping set when the input
+// is empty. See groupingSetsExpansion.presentCol.
+//
+// The columns of aggInScope are projected from fromScope, with the grouping
+// columns renamed. Aggregates must be remapped by remapAggregate.
+func (b *Builder) constructGroupingSetsInput(
+	g *groupby, fromScope *scope,
+) (memo.RelExpr, *groupingSetsExpansion) {
+	md := b.factory.Metadata()
+	exp := &groupingSetsExpansion{}
+
+	var groupingColSet opt.ColSet
+	for _, col := range g.groupingCols() {
+		groupingColSet.Add(col.id)
+	}
+
+	// Construct the pre-projection, giving the grouping columns new IDs.
+	aggInCols := append(g.aggInScope.cols, g.aggInScope.extraCols...)
+	preCols := make([]scopeColumn, 0, len(aggInCols)+1)
+	var seen opt.ColSet
+	for _, col := range aggInCols {
+		if seen.Contains(col.id) {
+			continue
+		}
+		seen.Add(col.id)
+		if groupingColSet.Contains(col.id) {
+			if col.scalar == nil {
+				col.scalar = b.factory.ConstructVariable(col.id)
+			}
+			newID := md.AddColumn(col.name.MetadataName(), col.typ)
+			exp.colMap.Set(int(col.id), int(newID))
+			col.id = newID
+		}
+		preCols = append(preCols, col)
+	}
+	for i, set := range g.groupingSets {
+		if set.Empty() {
+			exp.emptySets = append(exp.emptySets, i)
+		}
+	}
+	if len(exp.emptySets) > 0 {
+		exp.presentCol = md.AddColumn("grouping_set_present", types.Bool)
+		exp.presentAggCol = md.AddColumn("grouping_set_present", types.Bool)
+		preCols = append(preCols, scopeColumn{
+			id: exp.presentCol, typ: types.Bool, scalar: memo.TrueSingleton,
+		})
+	}
+	pre := b.constructProject(fromScope.expr, preCols)
+
+	// Expand each row into one row per grouping set.
+	exp.setCol = md.AddColumn("grouping_set", types.Int)
+	rows := make(memo.ScalarListExpr, len(g.groupingSets))
+	rowType := types.MakeTuple([]*types.T{types.Int})
+	for i := range g.groupingSets {
+		rows[i] = b.factory.ConstructTuple(
+			memo.ScalarListExpr{b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int)},
+			rowType,
+		)
+	}
+	sets := b.factory.ConstructValues(rows, &memo.ValuesPrivate{
+		Cols: opt.ColList{exp.setCol},
+		ID:   md.NextUniqueID(),
+	})
+	var expanded memo.RelExpr
+	if exp.presentCol != 0 {
+		expanded = b.factory.ConstructLeftJoin(sets, pre, memo.TrueFilter, memo.EmptyJoinPrivate)
+	} else {
+		expanded = b.factory.ConstructInnerJoin(pre, sets, memo.TrueFilter, memo.EmptyJoinPrivate)
+	}
+
+	// Replace each grouping column by NULL in the grouping sets that don't
+	// include it.
+	var projections memo.ProjectionsExpr
+	for _, col := range g.groupingCols() {
+		var setIdxs []int
+		for i, set := range g.groupingSets {
+			if set.Contains(col.id) {
+				setIdxs = append(setIdxs, i)
+			}
+		}
+		preCol := b.factory.ConstructVariable(exp.remapCol(col.id))
+		scalar := opt.ScalarExpr(preCol)
+		if len(setIdxs) < len(g.groupingSets) {
+			scalar = b.factory.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{b.factory.ConstructWhen(exp.inSets(b, setIdxs), preCol)},
+				b.factory.ConstructNull(col.typ),
+			)
+		}
+		projections = append(projections, b.factory.ConstructProjectionsItem(scalar, col.id))
+	}
+	g.groupingSetsExpansion = exp
+	return b.factory.ConstructProject(
+		expanded, projections, expanded.Relational().OutputCols,
+	), exp
+}
+
+// remapCol returns the column of the pre-projection which holds the value of
+// the given column, which differs from it if it is a grouping column.
+func (exp *groupingSetsExpansion) remapCol(col opt.ColumnID) opt.ColumnID {
+	return opt.ColumnID(exp.colMap.GetDefault(int(col)))
+}
+
+// inSets returns an expression which is true for the rows of the given
+// grouping sets.
+func (exp *groupingSetsExpansion) inSets(b *Builder, setIdxs []int) opt.ScalarExpr {
+	elems := make(memo.ScalarListExpr, len(setIdxs))
+	typs := make([]*types.T, len(setIdxs))
+	for i, idx := range setIdxs {
+		elems[i] = b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(idx)), types.Int)
+		typs[i] = types.Int
+	}
+	return b.factory.ConstructIn(
+		b.factory.ConstructVariable(exp.setCol),
+		b.factory.ConstructTuple(elems, types.MakeTuple(typs)),
+	)
+}
+
+// remapAggregate remaps the columns referenced by an aggregate to the columns
+// of the expanded input. If there are empty grouping sets, the aggregate is
+// also filtered on presentCol, so that it ignores the row which is produced
+// for each grouping set when the input is empty.
+func (exp *groupingSetsExpansion) remapAggregate(b *Builder, agg opt.ScalarExpr) opt.ScalarExpr {
+	agg = b.factory.RemapCols(agg, exp.colMap)
+	if exp.presentCol == 0 {
+		return agg
+	}
+	if filter, ok := agg.(*memo.AggFilterExpr); ok {
+		return b.factory.ConstructAggFilter(
+			filter.Input, b.factory.ConstructVariable(exp.filterCol(b, filter.Filter)),
+		)
+	}
+	return b.factory.ConstructAggFilter(agg, b.factory.ConstructVariable(exp.presentCol))
+}
+
+// filterCol returns a column which is true for the rows of the expanded input
+// that an aggregate with the given FILTER expression aggregates, which must
+// refer to the columns of the expanded input. If there are empty grouping
+// sets, the column is projected by projectFilters.
+func (exp *groupingSetsExpansion) filterCol(b *Builder, filter opt.ScalarExpr) opt.ColumnID {
+	if v, ok := filter.(*memo.VariableExpr); ok && exp.presentCol == 0 {
+		return v.Col
+	}
+	filterCol := b.factory.Metadata().AddColumn("grouping_set_filter", types.Bool)
+	if exp.presentCol != 0 {
+		filter = b.factory.ConstructAnd(filter, b.factory.ConstructVariable(exp.presentCol))
+	}
+	exp.filterProjections = append(exp.filterProjections,
+		b.factory.ConstructProjectionsItem(filter, filterCol),
+	)
+	return filterCol
+}
+
+// projectFilters projects the columns returned by filterCol.
+func (exp *groupingSetsExpansion) projectFilters(b *Builder, input memo.RelExpr) memo.RelExpr {
+	if len(exp.filterProjections) == 0 {
+		return input
+	}
+	return b.factory.ConstructProject(input, exp.filterProjections, input.Relational().OutputCols)
+}
+
+// constructGroupingFuncs projects the results of the GROUPING() expressions of
+// the query over the aggregation, which is constructed over the expansion exp
+// if there is more than one grouping set, and removes the columns of the
+// expansion. It also discards the groups that the expansion produces for
+// non-empty grouping sets when the input is empty.
+func (b *Builder) constructGroupingFuncs(g *groupby, aggregation memo.RelExpr) memo.RelExpr {
+	exp := g.groupingSetsExpansion
+	if exp == nil {
+		// With a single grouping set, every grouping column is part of every
+		// group, so all GROUPING() expressions evaluate to zero.
+		if len(g.groupingFuncs) == 0 {
+			return aggregation
+		}
+		projections := make(memo.ProjectionsExpr, len(g.groupingFuncs))
+		for i, info := range g.groupingFuncs {
+			projections[i] = b.factory.ConstructProjectionsItem(
+				b.factory.ConstructConstVal(tree.NewDInt(0), types.Int), info.col.id,
+			)
+		}
+		return b.factory.ConstructProject(
+			aggregation, projections, aggregation.Relational().OutputCols,
+		)
+	}
+
+	if exp.presentCol != 0 {
+		aggregation = b.factory.ConstructSelect(aggregation, memo.FiltersExpr{
+			b.factory.ConstructFiltersItem(b.factory.ConstructOr(
+				b.factory.ConstructIsNot(
+					b.factory.ConstructVariable(exp.presentAggCol), memo.NullSingleton,
+				),
+				exp.inSets(b, exp.emptySets),
+			)),
+		})
+	}
+
+	// The result of each GROUPING() expression is constant within a grouping
+	// set.
+	projections := make(memo.ProjectionsExpr, len(g.groupingFuncs))
+	for i, info := range g.groupingFuncs {
+		var values []int64
+		setIdxs := make(map[int64][]int)
+		for j, set := range g.groupingSets {
+			v := info.value(set)
+			if _, ok := setIdxs[v]; !ok {
+				values = append(values, v)
+			}
+			setIdxs[v] = append(setIdxs[v], j)
+		}
+		scalar := b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(values[0])), types.Int)
+		if len(values) > 1 {
+			whens := make(memo.ScalarListExpr, 0, len(values)-1)
+			for _, v := range values[1:] {
+				whens = append(whens, b.factory.ConstructWhen(
+					exp.inSets(b, setIdxs[v]),
+					b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(v)), types.Int),
+				))
+			}
+			scalar = b.factory.ConstructCase(memo.TrueSingleton, whens, scalar)
+		}
+		projections[i] = b.factory.ConstructProjectionsItem(scalar, info.col.id)
+	}
+	passthrough := aggregation.Relational().OutputCols.Copy()
+	passthrough.Remove(exp.setCol)
+	if exp.presentAggCol != 0 {
+		passthrough.Remove(exp.presentAggCol)
+	}
+	return b.factory.ConstructProject(aggregation, projections, passthrough)
+}
+
 // analyzeHaving analyzes the having clause and returns it as a typed
 // expression. fromScope contains the name bindings that are visible for this
 // HAVING clause (e.g., passed in from an enclosing statement).
@@ -509,12 +936,118 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
-	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+	if !hasGroupingSets(groupBy) {
+		for _, e := range groupBy {
+			b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		}
+	} else {
+		// The grouping sets of the GROUP BY clause are the cross product of the
+		// grouping sets of each item. For example:
+		//   GROUP BY a, ROLLUP (b, c)
+		// has the grouping sets (a, b, c), (a, b) and (a).
+		g.groupingSets = []opt.ColSet{{}}
+		for _, e := range groupBy {
+			itemSets := b.buildGroupingSetItem(e, selects, projectionsScope, fromScope)
+			if len(g.groupingSets)*len(itemSets) > maxGroupingSets {
+				panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+					"too many grouping sets present (maximum %d)", maxGroupingSets,
+				))
+			}
+			sets := make([]opt.ColSet, 0, len(g.groupingSets)*len(itemSets))
+			for _, prefix := range g.groupingSets {
+				for _, set := range itemSets {
+					sets = append(sets, prefix.Union(set))
+				}
+			}
+			g.groupingSets = sets
+		}
 	}
 	g.buildingGroupingCols = false
 }
 
+// hasGroupingSets returns true if any of the given GROUP BY expressions is a
+// ROLLUP, CUBE or GROUPING SETS expression.
+func hasGroupingSets(groupBy tree.GroupBy) bool {
+	for _, e := range groupBy {
+		if _, ok := tree.StripParens(e).(*tree.GroupingSet); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// buildGroupingSetItem builds the grouping columns for a single item of a GROUP
+// BY clause that contains grouping sets, and returns the grouping sets that the
+// item expands to. A plain GROUP BY expression expands to a single grouping
+// set, ROLLUP (e1, ..., en) expands to the n+1 prefixes of its elements, CUBE
+// (e1, ..., en) expands to all 2^n subsets of its elements, and GROUPING SETS
+// expands to the concatenation of the grouping sets of its elements.
+func (b *Builder) buildGroupingSetItem(
+	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) []opt.ColSet {
+	aggInScope := fromScope.groupby.aggInScope
+	gs, ok := tree.StripParens(groupBy).(*tree.GroupingSet)
+	if !ok {
+		return []opt.ColSet{b.buildGrouping(groupBy, selects, projectionsScope, fromScope, aggInScope)}
+	}
+
+	switch gs.Type {
+	case tree.RollupGroupingSet:
+		sets := make([]opt.ColSet, len(gs.Exprs)+1)
+		for i, e := range gs.Exprs {
+			sets[i+1] = sets[i].Union(
+				b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope),
+			)
+		}
+		// List the grouping sets from the most to the least specific, as
+		// Postgres does.
+		for i, j := 0, len(sets)-1; i < j; i, j = i+1, j-1 {
+			sets[i], sets[j] = sets[j], sets[i]
+		}
+		return sets
+
+	case tree.CubeGroupingSet:
+		if len(gs.Exprs) > maxCubeElements {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"CUBE is limited to %d elements", maxCubeElements,
+			))
+		}
+		elems := make([]opt.ColSet, len(gs.Exprs))
+		for i, e := range gs.Exprs {
+			elems[i] = b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope)
+		}
+		// Enumerate the subsets from the most to the least specific. The first
+		// element corresponds to the most significant bit of the mask.
+		n := len(elems)
+		sets := make([]opt.ColSet, 0, 1<<n)
+		for mask := (1 << n) - 1; mask >= 0; mask-- {
+			var set opt.ColSet
+			for i := range elems {
+				if mask&(1<<(n-1-i)) != 0 {
+					set.UnionWith(elems[i])
+				}
+			}
+			sets = append(sets, set)
+		}
+		return sets
+
+	case tree.SetsGroupingSet:
+		var sets []opt.ColSet
+		for _, e := range gs.Exprs {
+			sets = append(sets, b.buildGroupingSetItem(e, selects, projectionsScope, fromScope)...)
+			if len(sets) > maxGroupingSets {
+				panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+					"too many grouping sets present (maximum %d)", maxGroupingSets,
+				))
+			}
+		}
+		return sets
+
+	default:
+		panic(errors.AssertionFailedf("unknown grouping set type %s", gs.Type))
+	}
+}
+
 // buildGrouping builds a set of memo groups that represent a GROUP BY
 // expression. The expression (or expressions, if we have a star) is added to
 // groupStrs and to the aggInScope.
@@ -535,9 +1068,11 @@ func (b *Builder) buildGroupingList(
 // aggInScope       The scope that will contain the grouping expressions as well
 //
 //	as the aggregate function arguments.
+//
+// buildGrouping returns the set of grouping columns for the expression.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -635,7 +1170,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -644,8 +1180,16 @@ func (b *Builder) buildGrouping(
 		//   SELECT x+y FROM t GROUP BY x+y
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
+		if col.scalar == nil && fromScope.groupby.groupingSets != nil {
+			// With grouping sets, a grouping column is NULL in the groups of the
+			// grouping sets that do not include it, so it cannot be a pass-through
+			// column of the input.
+			b.populateSynthesizedColumn(col, b.factory.ConstructVariable(col.id))
+		}
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
 	}
+	return cols
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -896,10 +1440,42 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 	panic(errors.AssertionFailedf("unhandled aggregate: %s", name))
 }
 
+// constructUserDefinedAggregate constructs a UserDefinedAgg expression for a
+// call to an aggregate created with CREATE AGGREGATE, either as an aggregate
+// or as a window function.
+func (b *Builder) constructUserDefinedAggregate(
+	def *memo.FunctionPrivate, f *tree.FuncExpr, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	argTypes := make([]*types.T, len(args))
+	for i := range args {
+		argTypes[i] = args[i].DataType()
+	}
+	b.factory.Metadata().AddUserDefinedFunction(def.Overload, f.Func.ReferenceByName)
+	return b.factory.ConstructUserDefinedAgg(
+		memo.ScalarListExpr(args),
+		&memo.UserDefinedAggPrivate{
+			Name:     def.Name,
+			Typ:      f.ResolvedType(),
+			Overload: def.Overload,
+			Support:  b.buildAggregateSupport(def.Overload.UserDefinedAggregate, argTypes),
+		},
+	)
+}
+
 func isAggregate(def *tree.ResolvedFunctionDefinition) bool {
 	return isClass(def, tree.AggregateClass)
 }
 
+// isUDFOnly returns true if all overloads of the function are user-defined.
+func isUDFOnly(def *tree.ResolvedFunctionDefinition) bool {
+	for i := range def.Overloads {
+		if !def.Overloads[i].IsUDF {
+			return false
+		}
+	}
+	return true
+}
+
 func isGenerator(def *tree.ResolvedFunctionDefinition) bool {
 	return isClass(def, tree.GeneratorClass)
 }
@@ -931,6 +1507,11 @@ func newGroupingError(name tree.Name) error {
 // In the unique index or unique without index cases, all key columns must be
 // marked as NOT NULL to allow the implicit grouping.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		// The key columns are not grouped on in every grouping set, so they do
+		// not determine the value of col.
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
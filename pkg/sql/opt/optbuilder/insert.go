@@ -682,6 +682,8 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 
 	mb.buildUniqueChecksForInsert()
 
+	mb.buildExclusionChecksForInsert()
+
 	mb.buildFKChecksForInsert()
 
 	private := mb.makeMutationPrivate(returning != nil)
@@ -905,6 +907,8 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 
 	mb.buildUniqueChecksForUpsert()
 
+	mb.buildExclusionChecksForUpsert()
+
 	mb.buildFKChecksForUpsert()
 
 	private := mb.makeMutationPrivate(returning != nil)
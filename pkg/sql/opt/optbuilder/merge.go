@@ -0,0 +1,725 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// duplicateMergeErrText is the error text used when a target row is matched by
+// more than one source row of a MERGE statement.
+const duplicateMergeErrText = "MERGE command cannot affect row a second time"
+
+// buildMerge builds a memo group for a MERGE statement. MERGE is compiled into
+// an Upsert operator whose input is a left join of the source rows with the
+// target table. For example:
+//
+//	MERGE INTO abc USING xyz ON a = x
+//	WHEN MATCHED AND z > 0 THEN UPDATE SET b = y
+//	WHEN MATCHED THEN DELETE
+//	WHEN NOT MATCHED THEN INSERT VALUES (x, y, z)
+//
+// is built as an Upsert with this input:
+//
+//	SELECT
+//	  <fetch-cols>,
+//	  CASE WHEN action = 3 THEN x ELSE fetch_a END AS a_ins,
+//	  CASE WHEN action = 3 THEN y ELSE fetch_b END AS b_ins,
+//	  CASE WHEN action = 3 THEN z ELSE fetch_c END AS c_ins,
+//	  CASE WHEN action = 1 THEN y ELSE fetch_b END AS b_upd,
+//	  action = 2 AS merge_delete
+//	FROM (
+//	  SELECT *, CASE
+//	    WHEN fetch_a IS NOT NULL AND z > 0 THEN 1
+//	    WHEN fetch_a IS NOT NULL THEN 2
+//	    WHEN fetch_a IS NULL THEN 3
+//	    ELSE 0
+//	  END AS action
+//	  FROM xyz LEFT JOIN abc AS fetch ON a = x
+//	)
+//	WHERE action != 0
+//
+// The action column holds the 1-based position of the first WHEN clause that
+// applies to the row, or zero if no clause applies or the clause is DO NOTHING.
+// As for UPSERT, a not-null "canary" column of the target table distinguishes
+// the source rows that matched a target row from those that did not. Matched
+// target rows for which a DELETE clause applies are deleted by the Upsert
+// operator rather than updated, and their old values are passed to the checks
+// and cascades of the foreign keys which reference the target table (see
+// buildFKChecksAndCascadesForMergeDelete).
+//
+// The values of each WHEN clause are only evaluated for the rows to which the
+// clause applies, so that volatile expressions, such as the DEFAULT values of
+// an INSERT clause, have no effect on the other rows.
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	// Find which table we're working on, check the permissions. Existing rows
+	// are always read in order to find matches.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Table, privilege.SELECT)
+
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+
+	var hasInsert, hasUpdate, hasDelete bool
+	for _, when := range merge.Whens {
+		switch when.Action {
+		case tree.MergeInsert:
+			hasInsert = true
+		case tree.MergeUpdate:
+			hasUpdate = true
+		case tree.MergeDelete:
+			hasDelete = true
+		}
+	}
+	if hasInsert {
+		b.checkPrivilege(depName, tab, privilege.INSERT)
+	}
+	if hasUpdate {
+		b.checkPrivilege(depName, tab, privilege.UPDATE)
+	}
+	if hasDelete {
+		b.checkPrivilege(depName, tab, privilege.DELETE)
+	}
+
+	// Check if this table has already been mutated in another subquery.
+	b.checkMultipleMutations(tab, false /* simpleInsert */)
+
+	var returning *tree.ReturningExprs
+	if resultsNeeded(merge.Returning) {
+		returning = merge.Returning.(*tree.ReturningExprs)
+	}
+
+	var mb mutationBuilder
+	mb.init(b, "merge", tab, alias)
+
+	// Left-join the source rows to the target table using the ON condition.
+	mb.buildInputForMerge(inScope, merge.Table, merge.Source, merge.On)
+
+	// Determine the WHEN clause that applies to each joined row, and filter out
+	// rows that are not affected.
+	actionColID := mb.addMergeActionCol(merge.Whens)
+
+	// Build the values inserted by the INSERT clauses.
+	mb.addMergeInsertCols(merge.Whens, actionColID)
+
+	// Build the values set by the UPDATE clauses.
+	mb.addMergeUpdateCols(merge.Whens, actionColID)
+
+	// Mark the rows deleted by the DELETE clauses.
+	if hasDelete {
+		mb.addMergeDeleteCol(merge.Whens, actionColID)
+	}
+
+	// Build the final upsert statement.
+	mb.buildUpsert(returning)
+
+	return mb.outScope
+}
+
+// buildInputForMerge constructs a left join of the source table of a MERGE
+// statement with the target table, using the ON condition as the join
+// condition. The canary column is set to a not-null column of the target table,
+// which is null for the source rows that do not match any target row.
+func (mb *mutationBuilder) buildInputForMerge(
+	inScope *scope, texpr tree.TableExpr, source tree.TableExpr, on tree.Expr,
+) {
+	var indexFlags *tree.IndexFlags
+	if t, ok := texpr.(*tree.AliasedTableExpr); ok && t.IndexFlags != nil {
+		indexFlags = t.IndexFlags
+		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
+	}
+
+	// Build the source rows.
+	sourceScope := mb.b.buildFromTables(tree.TableExprs{source}, noRowLocking, inScope)
+
+	// Fetch existing rows from the target table.
+	//
+	// NOTE: Include mutation columns, but be careful to never use them for any
+	//       reason other than as "fetch columns". See buildScan comment.
+	mb.fetchScope = mb.b.buildScan(
+		mb.b.addTable(mb.tab, &mb.alias),
+		tableOrdinals(mb.tab, columnKinds{
+			includeMutations: true,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags,
+		noRowLocking,
+		inScope,
+		false, /* disableNotVisibleIndex */
+	)
+
+	// Set list of columns that will be fetched by the input expression.
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	// Check that the same table name is not used multiple times.
+	mb.b.validateJoinTableNames(sourceScope, mb.fetchScope)
+
+	// Both the source and the target columns can be referenced by the ON
+	// condition, the WHEN conditions, and the values of the actions. Create a
+	// new scope so that fetchScope is not modified.
+	mb.outScope = mb.fetchScope.replace()
+	mb.outScope.appendColumnsFromScope(sourceScope)
+	mb.outScope.appendColumnsFromScope(mb.fetchScope)
+	mb.mergeInputCols = mb.outScope.colSet()
+
+	onExpr := mb.b.resolveAndBuildScalar(
+		on, types.Bool, exprKindOn, tree.RejectGenerators|tree.RejectWindowApplications, mb.outScope,
+	)
+	mb.outScope.expr = mb.b.factory.ConstructLeftJoin(
+		sourceScope.expr,
+		mb.fetchScope.expr,
+		memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(onExpr)},
+		memo.EmptyJoinPrivate,
+	)
+
+	// Record a not-null "canary" column. After the left-join, this will be null
+	// if the source row did not match any target row, or not null otherwise.
+	canaryOrd := findNotNullIndexCol(mb.tab.Index(cat.PrimaryIndex))
+	mb.canaryColID = mb.fetchColIDs[canaryOrd]
+}
+
+// addMergeActionCol projects a column that holds the 1-based position of the
+// first WHEN clause that applies to each joined row, or zero if no clause
+// applies or the clause is DO NOTHING. Rows with a zero action are filtered out,
+// and an error is raised if a target row is matched by multiple source rows.
+// The ID of the new column is returned.
+func (mb *mutationBuilder) addMergeActionCol(whens tree.MergeWhens) opt.ColumnID {
+	f := mb.b.factory
+	canary := f.ConstructVariable(mb.canaryColID)
+	zero := f.ConstructConstVal(tree.NewDInt(0), types.Int)
+
+	whenExprs := make(memo.ScalarListExpr, 0, len(whens))
+	for i, when := range whens {
+		var cond opt.ScalarExpr
+		if when.Matched {
+			cond = f.ConstructIsNot(canary, memo.NullSingleton)
+		} else {
+			cond = f.ConstructIs(canary, memo.NullSingleton)
+		}
+		if when.Cond != nil {
+			cond = f.ConstructAnd(cond, mb.b.resolveAndBuildScalar(
+				when.Cond, types.Bool, exprKindMergeWhen, tree.RejectSpecial, mb.outScope,
+			))
+		}
+		action := zero
+		if when.Action != tree.MergeDoNothing {
+			action = f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int)
+		}
+		whenExprs = append(whenExprs, f.ConstructWhen(cond, action))
+	}
+	caseExpr := f.ConstructCase(memo.TrueSingleton, whenExprs, zero)
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	name := scopeColName("").WithMetadataName("merge_action")
+	actionColID := mb.b.synthesizeColumn(projectionsScope, name, types.Int, nil /* expr */, caseExpr).id
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Filter out rows that are not affected by any WHEN clause.
+	mb.outScope.expr = f.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{f.ConstructFiltersItem(
+			f.ConstructNe(f.ConstructVariable(actionColID), zero),
+		)},
+	)
+
+	// Ensure that each target row is affected at most once. The fetched primary
+	// key columns are null for the source rows that did not match, so nulls are
+	// treated as distinct.
+	var pkCols opt.ColSet
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+		pkCols.Add(mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+	}
+	mb.outScope = mb.b.buildDistinctOn(
+		pkCols, mb.outScope, true /* nullsAreDistinct */, duplicateMergeErrText,
+	)
+
+	return actionColID
+}
+
+// addMergeInsertCols builds the values inserted by the INSERT clauses of a
+// MERGE statement. The values of each clause are projected, and combined into a
+// single insert column per target column using a CASE expression on the action
+// column. Columns that are not named by an INSERT clause are set to their
+// default values by that clause.
+//
+// The insert columns of matched rows are set to the existing values, since the
+// insert values of all rows are checked against NOT NULL constraints.
+func (mb *mutationBuilder) addMergeInsertCols(whens tree.MergeWhens, actionColID opt.ColumnID) {
+	// Determine the target columns of each INSERT clause.
+	var targets []opt.ColList
+	for _, when := range whens {
+		if when.Action != tree.MergeInsert {
+			continue
+		}
+		mb.targetColList = make(opt.ColList, 0, mb.tab.ColumnCount())
+		mb.targetColSet = opt.ColSet{}
+		if len(when.Columns) != 0 {
+			mb.addTargetNamedColsForInsert(when.Columns)
+			if when.Values != nil {
+				mb.checkNumCols(len(mb.targetColList), len(when.Values))
+			}
+		} else if when.Values != nil {
+			mb.addTargetTableColsForInsert(len(when.Values))
+		}
+		targets = append(targets, mb.targetColList)
+	}
+	mb.targetColList = make(opt.ColList, 0, mb.tab.ColumnCount())
+	mb.targetColSet = opt.ColSet{}
+
+	if len(targets) == 0 {
+		// No source row is inserted, so use the existing values as the insert
+		// values of all the (matched) rows.
+		for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+			col := mb.tab.Column(i)
+			if kind := col.Kind(); (kind == cat.Ordinary || kind == cat.WriteOnly) && !col.IsComputed() {
+				mb.insertColIDs[i] = mb.fetchColIDs[i]
+			}
+		}
+	} else {
+		codes, vals := mb.buildMergeValues(whens, tree.MergeInsert, "ins", actionColID,
+			func(k int, when *tree.MergeWhen, v *mergeValuesBuilder) {
+				for ord, n := 0, mb.tab.ColumnCount(); ord < n; ord++ {
+					col := mb.tab.Column(ord)
+					if col.Kind() != cat.Ordinary || col.IsComputed() {
+						continue
+					}
+					var expr tree.Expr = tree.DefaultVal{}
+					for j, colID := range targets[k] {
+						if colID == mb.tabID.ColumnID(ord) && when.Values != nil {
+							expr = when.Values[j]
+						}
+					}
+					// GENERATED ALWAYS AS IDENTITY columns are not allowed to be
+					// explicitly written to.
+					if _, ok := expr.(tree.DefaultVal); !ok && col.IsGeneratedAlwaysAsIdentity() {
+						panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnOverrideError(string(col.ColName())))
+					}
+					v.addCol(ord, expr)
+				}
+			},
+		)
+		mb.projectMergeCaseCols(mb.insertColIDs, actionColID, codes, vals)
+	}
+
+	// Add write-only columns with default values, and computed columns.
+	mb.addSynthesizedColsForInsert()
+}
+
+// addMergeUpdateCols builds the values set by the UPDATE clauses of a MERGE
+// statement. The values of each clause are projected, and combined into a
+// single update column per target column using a CASE expression on the action
+// column. Target rows to which no UPDATE clause applies keep their existing
+// values.
+func (mb *mutationBuilder) addMergeUpdateCols(whens tree.MergeWhens, actionColID opt.ColumnID) {
+	codes, vals := mb.buildMergeValues(whens, tree.MergeUpdate, "upd", actionColID,
+		func(_ int, when *tree.MergeWhen, v *mergeValuesBuilder) {
+			mb.targetColList = make(opt.ColList, 0, mb.tab.ColumnCount())
+			mb.targetColSet = opt.ColSet{}
+			for _, set := range when.Exprs {
+				mb.addTargetColsByName(set.Names)
+				ords := make([]int, len(set.Names))
+				for j, name := range set.Names {
+					ords[j] = findPublicTableColumnByName(mb.tab, name)
+				}
+				exprs := tree.Exprs{set.Expr}
+				if set.Tuple {
+					switch t := set.Expr.(type) {
+					case *tree.Subquery:
+						v.addSubqueryCols(ords, t)
+						continue
+					case *tree.Tuple:
+						if len(set.Names) != len(t.Exprs) {
+							panic(pgerror.Newf(pgcode.Syntax,
+								"number of columns (%d) does not match number of values (%d)",
+								len(set.Names), len(t.Exprs)))
+						}
+						exprs = t.Exprs
+					default:
+						panic(unimplementedWithIssueDetailf(35713, fmt.Sprintf("%T", set.Expr),
+							"source for a multiple-column UPDATE item must be a sub-SELECT or ROW() expression; not supported: %T", set.Expr))
+					}
+				}
+				for j, ord := range ords {
+					// GENERATED ALWAYS AS IDENTITY columns are not allowed to be
+					// explicitly written to.
+					if _, ok := exprs[j].(tree.DefaultVal); !ok {
+						if col := mb.tab.Column(ord); col.IsGeneratedAlwaysAsIdentity() {
+							panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnUpdateError(string(col.ColName())))
+						}
+					}
+					v.addCol(ord, exprs[j])
+				}
+			}
+		},
+	)
+	mb.targetColList = make(opt.ColList, 0, mb.tab.ColumnCount())
+	mb.targetColSet = opt.ColSet{}
+
+	if len(codes) == 0 {
+		return
+	}
+	mb.projectMergeCaseCols(mb.updateColIDs, actionColID, codes, vals)
+
+	// Add additional columns for computed expressions that may depend on the
+	// updated columns, as well as mutation columns with default values.
+	mb.addSynthesizedColsForUpdate()
+}
+
+// addMergeDeleteCol projects a boolean column that is true for the target rows
+// to which a DELETE clause of a MERGE statement applies.
+func (mb *mutationBuilder) addMergeDeleteCol(whens tree.MergeWhens, actionColID opt.ColumnID) {
+	f := mb.b.factory
+	var deleteExpr opt.ScalarExpr
+	for i, when := range whens {
+		if when.Action != tree.MergeDelete {
+			continue
+		}
+		eq := f.ConstructEq(
+			f.ConstructVariable(actionColID),
+			f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int),
+		)
+		if deleteExpr == nil {
+			deleteExpr = eq
+		} else {
+			deleteExpr = f.ConstructOr(deleteExpr, eq)
+		}
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	name := scopeColName("").WithMetadataName("merge_delete")
+	mb.deleteColID = mb.b.synthesizeColumn(
+		projectionsScope, name, types.Bool, nil /* expr */, deleteExpr,
+	).id
+
+	// Project the existing values of the deleted rows for the columns which are
+	// referenced by foreign keys. They must be projected before the input of
+	// the mutation is buffered for the checks. They are projected alongside the
+	// delete column, so they cannot reference it and use its expression.
+	for i, n := 0, mb.tab.InboundForeignKeyCount(); i < n; i++ {
+		fk := mb.tab.InboundForeignKey(i)
+		for j, m := 0, fk.ColumnCount(); j < m; j++ {
+			ord := fk.ReferencedColumnOrdinal(mb.tab, j)
+			if mb.mergeDeleteColIDs == nil {
+				mb.mergeDeleteColIDs = make(opt.OptionalColList, mb.tab.ColumnCount())
+			}
+			if mb.mergeDeleteColIDs[ord] != 0 {
+				continue
+			}
+			col := mb.tab.Column(ord)
+			caseExpr := f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(
+					deleteExpr, f.ConstructVariable(mb.fetchColIDs[ord]),
+				)},
+				f.ConstructNull(col.DatumType()),
+			)
+			name := scopeColName("").WithMetadataName(fmt.Sprintf("merge_delete_%s", col.ColName()))
+			mb.mergeDeleteColIDs[ord] = mb.b.synthesizeColumn(
+				projectionsScope, name, col.DatumType(), nil /* expr */, caseExpr,
+			).id
+		}
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// buildFKChecksAndCascadesForMergeDelete builds the check or cascade of the
+// given inbound foreign key for the rows deleted by a MERGE statement. They are
+// built as for a DELETE statement (see buildFKChecksAndCascadesForDelete), from
+// the existing values of the deleted rows, which are null for the rows that are
+// inserted or updated and thus never match a row of the referencing table.
+func (mb *mutationBuilder) buildFKChecksAndCascadesForMergeDelete(fkOrdinal int) {
+	h := &mb.fkCheckHelper
+	if !h.initWithInboundFK(mb, fkOrdinal) {
+		return
+	}
+	mb.ensureWithID()
+
+	inCols := make(opt.ColList, len(h.tabOrdinals))
+	for i, tabOrd := range h.tabOrdinals {
+		inCols[i] = mb.mergeDeleteColIDs[tabOrd]
+	}
+
+	if a := h.fk.DeleteReferenceAction(); a != tree.Restrict && a != tree.NoAction {
+		telemetry.Inc(sqltelemetry.ForeignKeyCascadesUseCounter)
+		var builder memo.CascadeBuilder
+		switch a {
+		case tree.Cascade:
+			builder = newOnDeleteCascadeBuilder(mb.tab, fkOrdinal, h.otherTab)
+		case tree.SetNull, tree.SetDefault:
+			builder = newOnDeleteSetBuilder(mb.tab, fkOrdinal, h.otherTab, a)
+		default:
+			panic(errors.AssertionFailedf("unhandled action type %s", a))
+		}
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			FKName:    h.fk.Name(),
+			Builder:   builder,
+			WithID:    mb.withID,
+			OldValues: inCols,
+			NewValues: nil,
+		})
+		return
+	}
+
+	outCols := make(opt.ColList, len(inCols))
+	for i, tabOrd := range h.tabOrdinals {
+		tableCol := mb.md.Table(mb.tabID).Column(tabOrd)
+		outCols[i] = mb.md.AddColumn(string(tableCol.ColName()), tableCol.DatumType())
+	}
+	withScan := mb.b.factory.ConstructWithScan(&memo.WithScanPrivate{
+		With:    mb.withID,
+		InCols:  inCols,
+		OutCols: outCols,
+		ID:      mb.md.NextUniqueID(),
+	})
+	mb.fkChecks = append(mb.fkChecks, h.buildDeletionCheck(withScan, outCols))
+}
+
+// mergeValuesBuilder builds the values of a single WHEN clause of a MERGE
+// statement. See buildMergeValues.
+type mergeValuesBuilder struct {
+	mb *mutationBuilder
+
+	// inScope is the scope of the joined source and target rows, which can be
+	// referenced by the values.
+	inScope *scope
+
+	// projectionsScope holds the projected values.
+	projectionsScope *scope
+
+	// isArm is true for the rows to which the clause applies.
+	isArm opt.ScalarExpr
+
+	// colNameSuffix is appended to the names of the target columns to name the
+	// projected values, e.g. "ins1" for the first clause of the statement.
+	colNameSuffix string
+
+	// vals holds the IDs of the projected values, indexed by target column
+	// ordinal.
+	vals opt.OptionalColList
+}
+
+// addCol projects the given value of the target column with the given ordinal.
+// The value is only evaluated for the rows to which the clause applies, and is
+// null for the other rows:
+//
+//	CASE WHEN action = <code> THEN <expr> ELSE NULL END
+func (v *mergeValuesBuilder) addCol(ord int, expr tree.Expr) {
+	mb := v.mb
+	f := mb.b.factory
+	targetCol := mb.tab.Column(ord)
+
+	// Allow values to be DEFAULT.
+	if _, ok := expr.(tree.DefaultVal); ok {
+		expr = mb.parseDefaultExpr(mb.tabID.ColumnID(ord))
+	}
+
+	// Add new column to the projections scope. It is important to use the real
+	// column reference name, as it is used to find the column when adding
+	// assignment casts.
+	texpr := v.inScope.resolveType(expr, targetCol.DatumType())
+	scalar := mb.b.buildScalar(texpr, v.inScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	typ := texpr.ResolvedType()
+	caseExpr := f.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{f.ConstructWhen(v.isArm, scalar)},
+		f.ConstructNull(typ),
+	)
+	targetColName := targetCol.ColName()
+	colName := scopeColName(targetColName).WithMetadataName(
+		fmt.Sprintf("%s_%s", targetColName, v.colNameSuffix),
+	)
+	v.vals[ord] = mb.b.synthesizeColumn(v.projectionsScope, colName, typ, texpr, caseExpr).id
+}
+
+// addSubqueryCols projects the values of the target columns with the given
+// ordinals from a subquery, as in SET (a, b) = (SELECT ...). The input is
+// wrapped in a LeftJoinApply with the subquery, which is only evaluated for
+// the rows to which the clause applies:
+//
+//	SELECT * FROM <input> LEFT JOIN LATERAL (
+//	  SELECT * FROM (<subquery>) WHERE action = <code>
+//	) ON True
+func (v *mergeValuesBuilder) addSubqueryCols(ords []int, sub *tree.Subquery) {
+	mb := v.mb
+	f := mb.b.factory
+
+	// Use the data types of the target columns to resolve expressions with
+	// ambiguous types.
+	desiredTypes := make([]*types.T, len(ords))
+	for i, ord := range ords {
+		desiredTypes[i] = mb.tab.Column(ord).DatumType()
+	}
+	subqueryScope := mb.b.buildSelectStmt(sub.Select, noRowLocking, desiredTypes, v.inScope)
+	if len(subqueryScope.cols) != len(ords) {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"number of columns (%d) does not match number of values (%d)",
+			len(ords), len(subqueryScope.cols)))
+	}
+	for i, ord := range ords {
+		subqueryScope.cols[i].name = scopeColName(mb.tab.Column(ord).ColName())
+		v.vals[ord] = subqueryScope.cols[i].id
+	}
+
+	// Wrap the input with Max1Row + LOJ. The input scope is not modified, so
+	// that the subquery columns cannot be referenced by the values of the
+	// clause.
+	mb.outScope.appendColumnsFromScope(subqueryScope)
+	mb.outScope.expr = f.ConstructLeftJoinApply(
+		mb.outScope.expr,
+		f.ConstructMax1Row(
+			f.ConstructSelect(subqueryScope.expr, memo.FiltersExpr{f.ConstructFiltersItem(v.isArm)}),
+			multiRowSubqueryErrText,
+		),
+		memo.TrueFilter,
+		memo.EmptyJoinPrivate,
+	)
+
+	// Project all subquery output columns.
+	v.projectionsScope.appendColumnsFromScope(subqueryScope)
+}
+
+// buildMergeValues projects the values of the WHEN clauses of a MERGE statement
+// with the given action. The build function is called for each such clause
+// with its position among those clauses, and adds the values of the clause to
+// the given mergeValuesBuilder. The 1-based positions of the clauses in the
+// statement are returned, together with the IDs of the projected values of
+// each clause, indexed by target column ordinal. Assignment casts are added to
+// the values.
+func (mb *mutationBuilder) buildMergeValues(
+	whens tree.MergeWhens,
+	action tree.MergeActionType,
+	suffix string,
+	actionColID opt.ColumnID,
+	build func(k int, when *tree.MergeWhen, v *mergeValuesBuilder),
+) (codes []int, vals []opt.OptionalColList) {
+	f := mb.b.factory
+
+	// Values should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("MERGE", tree.RejectSpecial)
+
+	// Only the joined source and target columns are accessible to the values.
+	// The names of the columns projected for previous actions, which are those
+	// of the target columns, are cleared so that they are not ambiguous.
+	inScope := mb.outScope.replace()
+	inScope.appendColumnsFromScope(mb.outScope)
+	inScope.expr = mb.outScope.expr
+	for i := range inScope.cols {
+		if !mb.mergeInputCols.Contains(inScope.cols[i].id) {
+			inScope.cols[i].clearName()
+		}
+	}
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+
+	for i, when := range whens {
+		if when.Action != action {
+			continue
+		}
+		v := mergeValuesBuilder{
+			mb:               mb,
+			inScope:          inScope,
+			projectionsScope: projectionsScope,
+			isArm: f.ConstructEq(
+				f.ConstructVariable(actionColID),
+				f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int),
+			),
+			colNameSuffix: fmt.Sprintf("%s%d", suffix, i+1),
+			vals:          make(opt.OptionalColList, mb.tab.ColumnCount()),
+		}
+		build(len(vals), when, &v)
+		codes = append(codes, i+1)
+		vals = append(vals, v.vals)
+	}
+
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Add assignment casts for the values of each clause separately, since
+	// multiple clauses can target the same column.
+	for _, armVals := range vals {
+		mb.addAssignmentCasts(armVals)
+	}
+	return codes, vals
+}
+
+// projectMergeCaseCols combines the values of multiple WHEN clauses of a MERGE
+// statement into a single column per target column, using CASE expressions that
+// select the value of the clause identified by the action column:
+//
+//	CASE
+//	  WHEN action = <code1> THEN <val1>
+//	  WHEN action = <code2> THEN <val2>
+//	  ELSE <fetch-col>
+//	END
+//
+// The existing value is used if no clause provides a value for the column. The
+// IDs of the combined columns are stored in dst.
+func (mb *mutationBuilder) projectMergeCaseCols(
+	dst opt.OptionalColList, actionColID opt.ColumnID, codes []int, vals []opt.OptionalColList,
+) {
+	f := mb.b.factory
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+
+	for ord := range dst {
+		var whenExprs memo.ScalarListExpr
+		for k := range vals {
+			if colID := vals[k][ord]; colID != 0 {
+				whenExprs = append(whenExprs, f.ConstructWhen(
+					f.ConstructEq(
+						f.ConstructVariable(actionColID),
+						f.ConstructConstVal(tree.NewDInt(tree.DInt(codes[k])), types.Int),
+					),
+					f.ConstructVariable(colID),
+				))
+			}
+		}
+		if len(whenExprs) == 0 {
+			continue
+		}
+
+		col := mb.tab.Column(ord)
+		caseExpr := f.ConstructCase(
+			memo.TrueSingleton, whenExprs, f.ConstructVariable(mb.fetchColIDs[ord]),
+		)
+		name := scopeColName(col.ColName()).WithMetadataName(
+			fmt.Sprintf("merge_%s", col.ColName()),
+		)
+		dst[ord] = mb.b.synthesizeColumn(
+			projectionsScope, name, col.DatumType(), nil /* expr */, caseExpr,
+		).id
+	}
+
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
@@ -138,6 +138,23 @@ type mutationBuilder struct {
 	// an insert; otherwise it's an update.
 	canaryColID opt.ColumnID
 
+	// deleteColID is the ID of the boolean column that is used to decide whether
+	// to delete an existing row rather than update it. It is only set when
+	// building a MERGE statement with a DELETE action.
+	deleteColID opt.ColumnID
+
+	// mergeDeleteColIDs lists the input column IDs storing the existing values
+	// of the rows deleted by a MERGE statement, which are null for the other
+	// rows. They are only set for the columns referenced by inbound foreign
+	// keys, and are used to build the checks and cascades of those foreign
+	// keys. See buildFKChecksAndCascadesForMergeDelete.
+	mergeDeleteColIDs opt.OptionalColList
+
+	// mergeInputCols is the set of joined source and target columns of a MERGE
+	// statement, which are the only columns that can be referenced by the values
+	// of its WHEN clauses.
+	mergeInputCols opt.ColSet
+
 	// arbiters is the set of indexes and unique constraints that are used to
 	// detect conflicts for UPSERT and INSERT ON CONFLICT statements.
 	arbiters arbiterSet
@@ -169,6 +186,11 @@ type mutationBuilder struct {
 	// once and cached for reuse.
 	parsedUniqueConstraintExprs []tree.Expr
 
+	// parsedExclusionConstraintExprs is a cached set of parsed partial
+	// exclusion constraint predicate expressions from the table schema. These
+	// are parsed once and cached for reuse.
+	parsedExclusionConstraintExprs []tree.Expr
+
 	// uniqueChecks contains unique check queries; see buildUnique* methods.
 	uniqueChecks memo.UniqueChecksExpr
 
@@ -195,6 +217,9 @@ type mutationBuilder struct {
 	// uniqueCheckHelper is used to prevent allocating the helper separately.
 	uniqueCheckHelper uniqueCheckHelper
 
+	// exclusionCheckHelper is used to prevent allocating the helper separately.
+	exclusionCheckHelper exclusionCheckHelper
+
 	// arbiterPredicateHelper is used to prevent allocating the helper
 	// separately.
 	arbiterPredicateHelper arbiterPredicateHelper
@@ -993,6 +1018,7 @@ func (mb *mutationBuilder) makeMutationPrivate(needResults bool) *memo.MutationP
 		FetchCols:           checkEmptyList(mb.fetchColIDs),
 		UpdateCols:          checkEmptyList(mb.updateColIDs),
 		CanaryCol:           mb.canaryColID,
+		DeleteCol:           mb.deleteColID,
 		ArbiterIndexes:      mb.arbiters.IndexOrdinals(),
 		ArbiterConstraints:  mb.arbiters.UniqueConstraintOrdinals(),
 		CheckCols:           checkEmptyList(mb.checkColIDs),
@@ -1140,6 +1166,12 @@ func (mb *mutationBuilder) parseDefaultExpr(colID opt.ColumnID) tree.Expr {
 	col := mb.tab.Column(ord)
 	exprStr := col.DefaultExprStr()
 
+	// A column of a domain type without a default of its own uses the default
+	// of the domain, if any.
+	if typ := col.DatumType(); exprStr == "" && typ.IsDomain() && typ.TypeMeta.DomainData != nil {
+		exprStr = typ.TypeMeta.DomainData.DefaultExpr
+	}
+
 	// If no default expression, return NULL or a default value.
 	if exprStr == "" {
 		if col.IsMutation() && !col.IsNullable() {
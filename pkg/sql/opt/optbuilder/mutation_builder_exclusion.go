@@ -0,0 +1,361 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+)
+
+// buildExclusionChecksForInsert builds check queries for an insert. These check
+// queries are used to enforce EXCLUDE constraints.
+func (mb *mutationBuilder) buildExclusionChecksForInsert() {
+	h := &mb.exclusionCheckHelper
+	for i, n := 0, mb.tab.ExclusionConstraintCount(); i < n; i++ {
+		if h.init(mb, i) {
+			mb.uniqueChecks = append(mb.uniqueChecks, h.buildInsertionCheck())
+		}
+	}
+}
+
+// buildExclusionChecksForUpdate builds check queries for an update. These
+// check queries are used to enforce EXCLUDE constraints.
+func (mb *mutationBuilder) buildExclusionChecksForUpdate() {
+	if mb.tab.ExclusionConstraintCount() == 0 {
+		return
+	}
+
+	mb.ensureWithID()
+	h := &mb.exclusionCheckHelper
+
+	for i, n := 0, mb.tab.ExclusionConstraintCount(); i < n; i++ {
+		// If this constraint doesn't include the updated columns we don't need to
+		// plan a check.
+		if !mb.exclusionColsUpdated(i) {
+			continue
+		}
+		if h.init(mb, i) {
+			// The insertion check works for updates too since it simply checks that
+			// the newly inserted or updated rows do not conflict with any other
+			// rows.
+			mb.uniqueChecks = append(mb.uniqueChecks, h.buildInsertionCheck())
+		}
+	}
+}
+
+// buildExclusionChecksForUpsert builds check queries for an upsert. These
+// check queries are used to enforce EXCLUDE constraints.
+func (mb *mutationBuilder) buildExclusionChecksForUpsert() {
+	if mb.tab.ExclusionConstraintCount() == 0 {
+		return
+	}
+
+	mb.ensureWithID()
+	h := &mb.exclusionCheckHelper
+
+	// Upserted rows may be either inserted or updated, so all the constraints
+	// must be checked.
+	for i, n := 0, mb.tab.ExclusionConstraintCount(); i < n; i++ {
+		if h.init(mb, i) {
+			mb.uniqueChecks = append(mb.uniqueChecks, h.buildInsertionCheck())
+		}
+	}
+}
+
+// exclusionColsUpdated returns true if any of the columns of an exclusion
+// constraint are being updated (according to updateColIDs). When the
+// constraint has a partial predicate, it also returns true if the predicate
+// references any of the columns being updated.
+func (mb *mutationBuilder) exclusionColsUpdated(ord int) bool {
+	ec := mb.tab.ExclusionConstraint(ord)
+
+	for i, n := 0, ec.ColumnCount(); i < n; i++ {
+		if colOrd := ec.ColumnOrdinal(mb.tab, i); mb.updateColIDs[colOrd] != 0 {
+			return true
+		}
+	}
+
+	if _, isPartial := ec.Predicate(); isPartial {
+		pred := mb.parseExclusionConstraintPredicateExpr(ord)
+		typedPred := mb.fetchScope.resolveAndRequireType(pred, types.Bool)
+
+		var predCols opt.ColSet
+		mb.b.buildScalar(typedPred, mb.fetchScope, nil, nil, &predCols)
+		for colID, ok := predCols.Next(0); ok; colID, ok = predCols.Next(colID + 1) {
+			colOrd := mb.md.ColumnMeta(colID).Table.ColumnOrdinal(colID)
+			if mb.updateColIDs[colOrd] != 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// parseExclusionConstraintPredicateExpr parses the predicate of the given
+// partial exclusion constraint and caches it for reuse. This function panics
+// if the exclusion constraint at the given ordinal is not partial.
+func (mb *mutationBuilder) parseExclusionConstraintPredicateExpr(ord int) tree.Expr {
+	predStr, isPartial := mb.tab.ExclusionConstraint(ord).Predicate()
+	if !isPartial {
+		panic(errors.AssertionFailedf("exclusion constraint at ordinal %d is not partial", ord))
+	}
+
+	if mb.parsedExclusionConstraintExprs == nil {
+		mb.parsedExclusionConstraintExprs = make([]tree.Expr, mb.tab.ExclusionConstraintCount())
+	}
+
+	// Return expression from the cache, if it was already parsed previously.
+	if mb.parsedExclusionConstraintExprs[ord] != nil {
+		return mb.parsedExclusionConstraintExprs[ord]
+	}
+
+	expr, err := parser.ParseExpr(predStr)
+	if err != nil {
+		panic(err)
+	}
+
+	mb.parsedExclusionConstraintExprs[ord] = expr
+	return expr
+}
+
+// exclusionCheckHelper is a type associated with a single exclusion constraint
+// and is used to build the "leaves" of an exclusion check expression, namely
+// the WithScan of the mutation input and the Scan of the table.
+type exclusionCheckHelper struct {
+	mb *mutationBuilder
+
+	exclusion    cat.ExclusionConstraint
+	exclusionOrd int
+
+	// colOrdinals are the table ordinals of the constraint columns. They
+	// correspond 1-to-1 to the columns in the ExclusionConstraint.
+	colOrdinals []int
+
+	// primaryKeyOrdinals includes the ordinals from any primary key columns
+	// that are not compared with equality by the constraint. They are used to
+	// prevent rows from conflicting with themselves.
+	primaryKeyOrdinals intsets.Fast
+
+	// The scope and column ordinals of the scan that will serve as the right
+	// side of the semi join for the exclusion checks.
+	scanScope    *scope
+	scanOrdinals []int
+}
+
+// init initializes the helper with an exclusion constraint.
+//
+// Returns false if the constraint should be ignored (e.g. because the new
+// values for the constraint columns are known to be always NULL).
+func (h *exclusionCheckHelper) init(mb *mutationBuilder, exclusionOrd int) bool {
+	// This initialization pattern ensures that fields are not unwittingly
+	// reused. Field reuse must be explicit.
+	*h = exclusionCheckHelper{
+		mb:           mb,
+		exclusion:    mb.tab.ExclusionConstraint(exclusionOrd),
+		exclusionOrd: exclusionOrd,
+	}
+
+	var eqOrds intsets.Fast
+	h.colOrdinals = make([]int, h.exclusion.ColumnCount())
+	for i := range h.colOrdinals {
+		ord := h.exclusion.ColumnOrdinal(mb.tab, i)
+		// Columns which are still being added cannot be scanned. Rows are
+		// validated against the constraint once the columns are backfilled.
+		if mb.tab.Column(ord).IsMutation() {
+			return false
+		}
+		h.colOrdinals[i] = ord
+		if h.exclusion.Operator(i) == treecmp.EQ {
+			eqOrds.Add(ord)
+		}
+	}
+
+	// Find the primary key columns that are not compared with equality. If
+	// there aren't any, two conflicting rows must be the same row, so no check
+	// is needed.
+	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
+	primaryOrds.DifferenceWith(eqOrds)
+	if primaryOrds.Empty() {
+		return false
+	}
+	h.primaryKeyOrdinals = primaryOrds
+
+	for _, ord := range h.colOrdinals {
+		// Check if we are setting NULL values for the constraint columns, like
+		// when this mutation is the result of a SET NULL cascade action. NULL
+		// values never conflict, so if at least one column is getting a NULL
+		// value, the check is not needed.
+		if memo.OutputColumnIsAlwaysNull(mb.outScope.expr, mb.mapToReturnColID(ord)) {
+			return false
+		}
+	}
+
+	h.scanScope, h.scanOrdinals = h.buildTableScan()
+	return true
+}
+
+// buildInsertionCheck creates an exclusion check for rows which are added to
+// a table. The input to the insertion check will be produced from the input to
+// the mutation operator.
+func (h *exclusionCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
+	f := h.mb.b.factory
+
+	// Build a self semi-join, with the new values on the left and the
+	// existing values on the right.
+
+	withScanScope, _ := h.mb.buildCheckInputScan(
+		checkInputScanNewVals, h.scanOrdinals, false, /* isFK */
+	)
+
+	// Build the join filters, comparing each column with the operator of the
+	// constraint:
+	//   (new_a = existing_a) AND (new_b && existing_b) AND ...
+	//
+	// If the constraint ends with a period, its start and end columns are
+	// compared together:
+	//   ... AND ((new_s, new_e) OVERLAPS (existing_s, existing_e))
+	//
+	// Set the capacity to len(h.colOrdinals)+1 since we'll have a condition for
+	// each column in the constraint, plus one additional condition to prevent
+	// rows from matching themselves (see below). If the constraint is partial,
+	// add 2 to account for filtering both the WithScan and the Scan by the
+	// predicate.
+	numFilters := len(h.colOrdinals) + 1
+	_, isPartial := h.exclusion.Predicate()
+	if isPartial {
+		numFilters += 2
+	}
+	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
+	cmpOrdinals := h.colOrdinals
+	if h.exclusion.HasPeriod() {
+		cmpOrdinals = cmpOrdinals[:len(cmpOrdinals)-2]
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
+			h.buildPeriodOverlaps(withScanScope),
+		))
+	}
+	for i, ord := range cmpOrdinals {
+		newVal := f.ConstructVariable(withScanScope.cols[ord].id)
+		existingVal := f.ConstructVariable(h.scanScope.cols[ord].id)
+		var cmp opt.ScalarExpr
+		switch h.exclusion.Operator(i) {
+		case treecmp.EQ:
+			cmp = f.ConstructEq(newVal, existingVal)
+		case treecmp.Overlaps:
+			cmp = f.ConstructOverlaps(newVal, existingVal)
+		default:
+			panic(errors.AssertionFailedf(
+				"unexpected exclusion operator %s", h.exclusion.Operator(i),
+			))
+		}
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(cmp))
+	}
+
+	// If the constraint is partial, only rows that satisfy the predicate can
+	// conflict, so we add the predicate as a filter on both the WithScan columns
+	// and the Scan columns.
+	if isPartial {
+		pred := h.mb.parseExclusionConstraintPredicateExpr(h.exclusionOrd)
+
+		typedPred := withScanScope.resolveAndRequireType(pred, types.Bool)
+		withScanPred := h.mb.b.buildScalar(typedPred, withScanScope, nil, nil, nil)
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(withScanPred))
+
+		typedPred = h.scanScope.resolveAndRequireType(pred, types.Bool)
+		scanPred := h.mb.b.buildScalar(typedPred, h.scanScope, nil, nil, nil)
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(scanPred))
+	}
+
+	// We need to prevent rows from matching themselves in the semi join. We can
+	// do this by adding another filter that uses the primary keys to check if
+	// two rows are identical:
+	//    (new_pk1 != existing_pk1) OR (new_pk2 != existing_pk2) OR ...
+	var pkFilter opt.ScalarExpr
+	for i, ok := h.primaryKeyOrdinals.Next(0); ok; i, ok = h.primaryKeyOrdinals.Next(i + 1) {
+		pkFilterLocal := f.ConstructNe(
+			f.ConstructVariable(withScanScope.cols[i].id),
+			f.ConstructVariable(h.scanScope.cols[i].id),
+		)
+		if pkFilter == nil {
+			pkFilter = pkFilterLocal
+		} else {
+			pkFilter = f.ConstructOr(pkFilter, pkFilterLocal)
+		}
+	}
+	semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(pkFilter))
+
+	semiJoin := f.ConstructSemiJoin(withScanScope.expr, h.scanScope.expr, semiJoinFilters, memo.EmptyJoinPrivate)
+
+	// Collect the key columns that will be shown in the error message if there
+	// is a conflict resulting from this check.
+	keyCols := make(opt.ColList, len(h.colOrdinals))
+	for i, ord := range h.colOrdinals {
+		keyCols[i] = withScanScope.cols[ord].id
+	}
+
+	// Create a Project that passes-through only the key columns. This allows
+	// normalization rules to prune any unnecessary columns from the expression.
+	// The key columns are always needed in order to display the constraint
+	// violation error.
+	project := f.ConstructProject(semiJoin, nil /* projections */, keyCols.ToSet())
+
+	return f.ConstructUniqueChecksItem(project, &memo.UniqueChecksItemPrivate{
+		Table:        h.mb.tabID,
+		CheckOrdinal: h.exclusionOrd,
+		Exclusion:    true,
+		KeyCols:      keyCols,
+		OpName:       h.mb.opName,
+	})
+}
+
+// buildPeriodOverlaps builds the comparison of the period of the constraint,
+// which is formed by its last two columns, between the new rows in
+// withScanScope and the existing rows:
+//
+//	overlaps(new_s, new_e, existing_s, existing_e)
+func (h *exclusionCheckHelper) buildPeriodOverlaps(withScanScope *scope) opt.ScalarExpr {
+	start, end := h.colOrdinals[len(h.colOrdinals)-2], h.colOrdinals[len(h.colOrdinals)-1]
+	overlaps := &tree.FuncExpr{
+		Func: tree.WrapFunction("overlaps"),
+		Exprs: tree.Exprs{
+			&withScanScope.cols[start], &withScanScope.cols[end],
+			&h.scanScope.cols[start], &h.scanScope.cols[end],
+		},
+	}
+	typedOverlaps := withScanScope.resolveAndRequireType(overlaps, types.Bool)
+	return h.mb.b.buildScalar(typedOverlaps, withScanScope, nil, nil, nil)
+}
+
+// buildTableScan builds a Scan of the table. The ordinals of the columns
+// scanned are also returned.
+func (h *exclusionCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
+	tabMeta := h.mb.b.addTable(h.mb.tab, tree.NewUnqualifiedTableName(h.mb.tab.Name()))
+	ordinals = tableOrdinals(tabMeta.Table, columnKinds{
+		includeMutations: false,
+		includeSystem:    false,
+		includeInverted:  false,
+	})
+	return h.mb.b.buildScan(
+		tabMeta,
+		ordinals,
+		nil, /* indexFlags */
+		noRowLocking,
+		h.mb.b.allocScope(),
+		true, /* disableNotVisibleIndex */
+	), ordinals
+}
@@ -384,6 +384,11 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 	}
 
 	for i := 0; i < numInbound; i++ {
+		// The rows deleted by a MERGE statement are checked as for a DELETE.
+		if mb.deleteColID != 0 {
+			mb.buildFKChecksAndCascadesForMergeDelete(i)
+		}
+
 		// Verify that at least one FK column is updated by the Upsert; columns that
 		// are not updated can get new values (through the insert path) but existing
 		// values are never removed.
@@ -297,6 +297,9 @@ func ensureColumnOrderable(e tree.TypedExpr) {
 		typ = typ.ArrayContents()
 	}
 	switch typ.Family() {
+	case types.JsonpathFamily:
+		panic(pgerror.Newf(pgcode.UndefinedFunction,
+			"could not identify an ordering operator for type %s", typ.Name()))
 	case types.TSQueryFamily, types.TSVectorFamily:
 		panic(unimplementedWithIssueDetailf(92165, "", "can't order by column type %s", typ.SQLString()))
 	}
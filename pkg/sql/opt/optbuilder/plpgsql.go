@@ -12,17 +12,24 @@ package optbuilder
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
 )
 
@@ -111,17 +118,39 @@ type plpgsqlBuilder struct {
 	colRefs *opt.ColSet
 
 	// params tracks the names and types for the original function parameters.
+	// INOUT parameters are excluded, since they are modeled as variables.
 	params []tree.ParamType
 
+	// outParams holds the names of the variables that model the OUT and INOUT
+	// parameters of the function, which are returned by RETURN statements
+	// without an expression.
+	outParams []tree.Name
+
 	// decls is the set of variable declarations for a PL/pgSQL function.
 	decls []plpgsqltree.PLpgSQLDecl
 
 	// varTypes maps from the name of each variable to its type.
 	varTypes map[tree.Name]*types.T
 
-	// returnType is the return type of the PL/pgSQL function.
+	// returnType is the return type of the PL/pgSQL function. For a
+	// set-returning function, it is the type of each returned row.
 	returnType *types.T
 
+	// setReturning is true if the function returns a set of rows.
+	setReturning bool
+
+	// routineType is the type of the value that is returned by RETURN
+	// statements. It is returnType, unless the function is set-returning, in
+	// which case it is an array of returnType; the rows are accumulated in the
+	// hidden retVar variable and expanded into a set once the function body
+	// returns. See buildSetResult.
+	routineType *types.T
+
+	// retVar is the hidden variable that accumulates the rows returned by a
+	// set-returning function. It is empty if the function is not
+	// set-returning.
+	retVar tree.Name
+
 	// continuations is used to model the control flow of a PL/pgSQL function.
 	// The head of the continuations stack is used upon reaching the end of a
 	// statement block to call a function that models the statements that come
@@ -129,57 +158,180 @@ type plpgsqlBuilder struct {
 	// call back into the loop body.
 	continuations []continuation
 
-	// exitContinuations is similar to continuations, but is used upon reaching an
-	// EXIT statement within a loop. It is used to resume execution with the
-	// statements that follow the loop.
-	exitContinuations []continuation
+	// frames tracks the loops and blocks that enclose the statements that are
+	// being built. It is used to resolve the targets of EXIT and CONTINUE
+	// statements, and to leave blocks that have an EXCEPTION section.
+	frames []*blockFrame
+
+	// forLoops maps each integer FOR loop to the hidden variables that are used
+	// to build it. See desugarForIntLoop for details.
+	forLoops map[*plpgsqltree.PLpgSQLStmtForIntLoop]forLoopVars
+
+	// blocks maps each nested block to the statements that are used to enter
+	// and leave it. See desugarBlock for details.
+	blocks map[*plpgsqltree.PLpgSQLStmtBlock]*blockVars
+
+	// cursors maps the name of each cursor to the hidden variables that are used
+	// to model it. See declareCursor for details.
+	cursors map[tree.Name]*cursorVars
+
+	// foundDeclared is true if the implicit FOUND variable has been declared.
+	// It is only declared if the function has a statement that sets it.
+	foundDeclared bool
+
+	// handlerDepth is the number of exception handlers that enclose the
+	// statements that are being built.
+	handlerDepth int
 
 	identCounter int
 }
 
+// foundVar is the name of the implicit variable that indicates whether the
+// last FOR loop, FETCH, MOVE or RETURN QUERY statement processed any rows.
+const foundVar = tree.Name("found")
+
 func (b *plpgsqlBuilder) init(
 	ob *Builder,
 	colRefs *opt.ColSet,
 	params []tree.ParamType,
+	outParams []tree.RoutineOutParam,
 	block *plpgsqltree.PLpgSQLStmtBlock,
 	returnType *types.T,
+	setReturning bool,
 ) {
 	b.ob = ob
 	b.colRefs = colRefs
 	b.params = params
-	b.decls = block.Decls
 	b.returnType = returnType
+	b.setReturning = setReturning
+	b.routineType = returnType
 	b.varTypes = make(map[tree.Name]*types.T)
-	for _, dec := range b.decls {
-		typ, err := tree.ResolveType(b.ob.ctx, dec.Typ, b.ob.semaCtx.TypeResolver)
-		if err != nil {
-			panic(err)
-		}
-		b.varTypes[dec.Var] = typ
-		if dec.NotNull {
-			panic(unimplemented.NewWithIssueDetail(105243,
-				"not null variable",
-				"not-null PL/pgSQL variables are not yet supported",
-			))
+	b.forLoops = make(map[*plpgsqltree.PLpgSQLStmtForIntLoop]forLoopVars)
+	b.blocks = make(map[*plpgsqltree.PLpgSQLStmtBlock]*blockVars)
+	b.cursors = make(map[tree.Name]*cursorVars)
+	b.decls = make([]plpgsqltree.PLpgSQLDecl, 0, len(block.Decls)+len(outParams))
+	b.declareOutParams(outParams)
+	for i := range block.Decls {
+		dec := &block.Decls[i]
+		if isCursorDecl(dec) {
+			continue
 		}
-		if dec.Constant {
-			panic(unimplemented.NewWithIssueDetail(105241,
-				"constant variable",
-				"constant PL/pgSQL variables are not yet supported",
+		b.varTypes[dec.Var] = b.resolveDeclType(dec)
+		b.decls = append(b.decls, *dec)
+	}
+	if setReturning {
+		if returnType.Family() == types.ArrayFamily || returnType.Identical(types.AnyTuple) {
+			panic(unimplemented.NewWithIssueDetailf(105240,
+				"set-returning PL/pgSQL function",
+				"set-returning PL/pgSQL functions returning %s are not yet supported", returnType.SQLString(),
 			))
 		}
-		if dec.Collate != "" {
-			panic(unimplemented.NewWithIssueDetail(105245,
-				"variable collation",
-				"collation for PL/pgSQL variables is not yet supported",
-			))
+		b.routineType = types.MakeArray(returnType)
+		b.retVar = tree.Name(b.makeIdentifier("return_rows"))
+		b.varTypes[b.retVar] = b.routineType
+		b.decls = append(b.decls, plpgsqltree.PLpgSQLDecl{
+			Var: b.retVar, Typ: b.routineType, Expr: tree.NewDArray(returnType),
+		})
+	}
+	if _, ok := b.varTypes[foundVar]; !ok {
+		var v foundVisitor
+		plpgsqltree.Walk(&v, block)
+		if v.setsFound {
+			b.foundDeclared = true
+			b.varTypes[foundVar] = types.Bool
+			b.decls = append(b.decls, plpgsqltree.PLpgSQLDecl{
+				Var: foundVar, Typ: types.Bool, Expr: tree.DBoolFalse,
+			})
+		}
+	}
+	// Cursors are declared after the other variables, since their queries may
+	// reference them.
+	for i := range block.Decls {
+		if dec := &block.Decls[i]; isCursorDecl(dec) {
+			b.declareCursor(dec)
+		}
+	}
+}
+
+// declareOutParams declares a variable for each OUT and INOUT parameter. OUT
+// parameters are initialized to NULL, and INOUT parameters are initialized to
+// the value of the corresponding input parameter, which is then excluded from
+// the parameters passed to continuations.
+func (b *plpgsqlBuilder) declareOutParams(outParams []tree.RoutineOutParam) {
+	if len(outParams) == 0 {
+		return
+	}
+	var inOutOrds intsets.Fast
+	b.outParams = make([]tree.Name, len(outParams))
+	for i, param := range outParams {
+		name := tree.Name(param.Name)
+		var init tree.Expr
+		if param.InputOrd >= 0 {
+			if name == "" {
+				panic(unimplemented.New(
+					"unnamed INOUT parameter",
+					"unnamed INOUT parameters of PL/pgSQL routines are not yet supported",
+				))
+			}
+			inOutOrds.Add(param.InputOrd)
+			init = makeVarRef(name)
+		} else if name == "" {
+			// Unnamed OUT parameters cannot be referenced, so they are always NULL.
+			name = tree.Name(b.makeIdentifier("out_param"))
 		}
+		b.outParams[i] = name
+		b.varTypes[name] = param.Typ
+		b.decls = append(b.decls, plpgsqltree.PLpgSQLDecl{Var: name, Typ: param.Typ, Expr: init})
+	}
+	params := make([]tree.ParamType, 0, len(b.params))
+	for i := range b.params {
+		if !inOutOrds.Contains(i) {
+			params = append(params, b.params[i])
+		}
+	}
+	b.params = params
+}
+
+// resolveDeclType returns the type of the given variable declaration. It
+// panics if the declaration uses an option that is not yet supported.
+func (b *plpgsqlBuilder) resolveDeclType(dec *plpgsqltree.PLpgSQLDecl) *types.T {
+	typ, err := tree.ResolveType(b.ob.ctx, dec.Typ, b.ob.semaCtx.TypeResolver)
+	if err != nil {
+		panic(err)
 	}
+	if dec.NotNull {
+		panic(unimplemented.NewWithIssueDetail(105243,
+			"not null variable",
+			"not-null PL/pgSQL variables are not yet supported",
+		))
+	}
+	if dec.Constant {
+		panic(unimplemented.NewWithIssueDetail(105241,
+			"constant variable",
+			"constant PL/pgSQL variables are not yet supported",
+		))
+	}
+	if dec.Collate != "" {
+		panic(unimplemented.NewWithIssueDetail(105245,
+			"variable collation",
+			"collation for PL/pgSQL variables is not yet supported",
+		))
+	}
+	return typ
 }
 
 // build constructs an expression that returns the result of executing a
 // PL/pgSQL function. See buildPLpgSQLStatements for more details.
 func (b *plpgsqlBuilder) build(block *plpgsqltree.PLpgSQLStmtBlock, s *scope) *scope {
+	// Rewrite the statements before building anything, since desugaring may
+	// add implicit variables, and every continuation takes all variables as
+	// parameters.
+	body := b.desugarStatements(b.addImplicitReturn(block.Body))
+	var exceptions []*plpgsqltree.PLpgSQLException
+	if block.Exceptions != nil {
+		exceptions = b.desugarExceptions(block.Exceptions, true /* implicitReturn */)
+	}
+
 	s = s.push()
 	b.ensureScopeHasExpr(s)
 
@@ -192,7 +344,25 @@ func (b *plpgsqlBuilder) build(block *plpgsqltree.PLpgSQLStmtBlock, s *scope) *s
 			s = b.addPLpgSQLAssign(s, dec.Var, &tree.CastExpr{Expr: tree.DNull, Type: dec.Typ})
 		}
 	}
-	if s = b.buildPLpgSQLStatements(block.Body, s); s != nil {
+	if block.Label != "" {
+		// A labeled EXIT statement can target the root block, in which case
+		// control reaches the end of the function.
+		exitCon := b.makeContinuation("block_exit")
+		b.finishContinuation(b.addImplicitReturn(nil), &exitCon, false /* recursive */)
+		b.pushFrame(&blockFrame{label: block.Label, exitCon: &exitCon})
+	}
+	if exceptions != nil {
+		s = b.buildExceptionBlock(body, exceptions, s)
+	} else {
+		s = b.buildPLpgSQLStatements(body, s)
+	}
+	if block.Label != "" {
+		b.popFrame()
+	}
+	if s != nil {
+		if b.setReturning {
+			return b.buildSetResult(s)
+		}
 		return s
 	}
 	// At least one path in the control flow does not terminate with a RETURN
@@ -202,14 +372,63 @@ func (b *plpgsqlBuilder) build(block *plpgsqltree.PLpgSQLStmtBlock, s *scope) *s
 	// function that runs correctly for some inputs, but returns this error for
 	// others. We are compiling rather than interpreting, so it seems better to
 	// eagerly return the error if there is an execution path with no RETURN.
-	// TODO(drewk): consider using RAISE (when it is implemented) to throw the
-	// error at runtime instead.
+	// TODO(drewk): consider using RAISE to throw the error at runtime instead.
 	panic(pgerror.New(
 		pgcode.RoutineExceptionFunctionExecutedNoReturnStatement,
 		"control reached end of function without RETURN",
 	))
 }
 
+// addImplicitReturn adds a RETURN statement to the end of the given statements
+// if the function returns VOID or a set, or has OUT parameters. Routines that
+// return VOID, including procedures, do not require an explicit RETURN
+// statement, so control flow that reaches the end of the routine returns NULL.
+// Set-returning functions return the rows produced by RETURN NEXT and RETURN
+// QUERY statements once control flow reaches the end of the routine, and
+// functions with OUT parameters return their values.
+func (b *plpgsqlBuilder) addImplicitReturn(
+	stmts []plpgsqltree.PLpgSQLStatement,
+) []plpgsqltree.PLpgSQLStatement {
+	if b.setReturning || len(b.outParams) > 0 {
+		return append(stmts[:len(stmts):len(stmts)], &plpgsqltree.PLpgSQLStmtReturn{})
+	}
+	if b.returnType.Family() != types.VoidFamily {
+		return stmts
+	}
+	return append(stmts[:len(stmts):len(stmts)], &plpgsqltree.PLpgSQLStmtReturn{
+		Expr: &tree.CastExpr{Expr: tree.DNull, Type: types.Void},
+	})
+}
+
+// buildSetResult expands the array of rows that is returned by the body of a
+// set-returning function into a set with one row for each element.
+func (b *plpgsqlBuilder) buildSetResult(s *scope) *scope {
+	rowsScope := s.push()
+	rowsCol := b.ob.synthesizeColumn(
+		rowsScope, scopeColName(b.retVar), b.routineType, nil /* expr */, b.ob.factory.ConstructVariable(s.cols[0].id),
+	)
+	rowsID := rowsCol.id
+	b.ob.constructProjectForScope(s, rowsScope)
+
+	// Build generate_series(1, array_length(rows, 1)) as a ProjectSet over the
+	// array, and project the element at each index.
+	series := &tree.FuncExpr{
+		Func:  tree.WrapFunction("generate_series"),
+		Exprs: tree.Exprs{tree.NewDInt(1), makeArrayLen(makeVarRef(b.retVar))},
+	}
+	zipScope := b.ob.buildZip(tree.Exprs{series}, rowsScope)
+	zipScope.expr = b.ob.factory.ConstructProjectSet(
+		rowsScope.expr, zipScope.expr.(*memo.ProjectSetExpr).Zip,
+	)
+	elem := b.ob.factory.ConstructIndirection(
+		b.ob.factory.ConstructVariable(rowsID), b.ob.factory.ConstructVariable(zipScope.cols[0].id),
+	)
+	resultScope := zipScope.push()
+	b.ob.synthesizeColumn(resultScope, scopeColName(""), b.returnType, nil /* expr */, elem)
+	b.ob.constructProjectForScope(zipScope, resultScope)
+	return resultScope
+}
+
 // buildPLpgSQLStatements performs the majority of the work building a PL/pgSQL
 // function definition into a form that can be handled by the SQL execution
 // engine. It models control flow statements by defining (possibly recursive)
@@ -227,26 +446,49 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(
 		case *plpgsqltree.PLpgSQLStmtReturn:
 			// RETURN is handled by projecting a single column with the expression
 			// that is being returned.
-			returnScalar := b.buildPLpgSQLExpr(t.Expr, b.returnType, s)
-			returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return"))
-			returnScope := s.push()
-			b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, returnScalar)
-			b.ob.constructProjectForScope(s, returnScope)
-			return returnScope
+			var returnScalar opt.ScalarExpr
+			switch {
+			case b.setReturning:
+				// A set-returning function returns the rows that have been
+				// accumulated by RETURN NEXT and RETURN QUERY statements.
+				if t.Expr != nil {
+					panic(errors.WithHint(
+						pgerror.New(pgcode.DatatypeMismatch,
+							"RETURN cannot have a parameter in function returning set"),
+						"Use RETURN NEXT or RETURN QUERY.",
+					))
+				}
+				returnScalar = b.varScalar(s, b.retVar, b.routineType)
+			case len(b.outParams) > 0:
+				// A function with OUT parameters returns their values.
+				if t.Expr != nil {
+					panic(pgerror.New(pgcode.DatatypeMismatch,
+						"RETURN cannot have a parameter in function with OUT parameters",
+					))
+				}
+				returnScalar = b.outParamsScalar(s)
+			case t.Expr == nil:
+				if b.returnType.Family() != types.VoidFamily {
+					panic(pgerror.New(pgcode.Syntax, "missing expression at or near \";\""))
+				}
+				returnScalar = b.buildPLpgSQLExpr(
+					&tree.CastExpr{Expr: tree.DNull, Type: types.Void}, b.returnType, s,
+				)
+			default:
+				returnScalar = b.buildPLpgSQLExpr(t.Expr, b.returnType, s)
+			}
+			return b.returnValue(s, returnScalar)
 		case *plpgsqltree.PLpgSQLStmtAssign:
 			// Assignment (:=) is handled by projecting a new column with the same
 			// name as the variable being assigned.
 			s = b.addPLpgSQLAssign(s, t.Var, t.Value)
+		case *plpgsqlStmtQueryArray:
+			s = b.assignScalar(s, t.target, b.buildQueryArray(t.query, t.elemType, s))
 		case *plpgsqltree.PLpgSQLStmtIf:
-			if len(t.ElseIfList) != 0 {
-				panic(unimplemented.New(
-					"ELSIF statements",
-					"PL/pgSQL ELSIF branches are not yet supported",
-				))
-			}
 			// IF statement control flow is handled by calling a "continuation"
 			// function in each branch that executes all the statements that logically
-			// follow the IF statement block.
+			// follow the IF statement block. ELSIF branches have already been
+			// rewritten as nested IF statements by desugarStatements.
 			//
 			// Create a function that models executing the statements that follow the
 			// IF statement. If the IF statement is the last statement in its own
@@ -284,67 +526,33 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(
 			// Return a single column that projects the result of the CASE statement.
 			returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_if"))
 			returnScope := s.push()
-			b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, scalar)
+			b.ob.synthesizeColumn(returnScope, returnColName, b.resultType(), nil /* expr */, scalar)
 			b.ob.constructProjectForScope(s, returnScope)
 			return returnScope
 		case *plpgsqltree.PLpgSQLStmtSimpleLoop:
-			if t.Label != "" {
-				panic(unimplemented.New(
-					"LOOP label",
-					"LOOP statement labels are not yet supported",
-				))
-			}
-			// LOOP control flow is handled similarly to IF statements, but two
-			// continuation functions are used - one that executes the loop body, and
-			// one that executes the statements following the LOOP statement. These
-			// are used while building the loop body, which means that its definition
-			// is recursive.
-			//
-			// Upon reaching the end of the loop body statements or a CONTINUE
-			// statement, the loop body function is called. Upon reaching an EXIT
-			// statement, the exit continuation is called to model returning control
-			// flow to the statements outside the loop.
-			exitCon := b.makeContinuation("loop_exit")
-			b.finishContinuation(stmts[i+1:], &exitCon, false /* recursive */)
-			b.pushExitContinuation(exitCon)
-			loopContinuation := b.makeContinuation("stmt_loop")
-			loopContinuation.isLoopContinuation = true
-			b.pushContinuation(loopContinuation)
-			b.finishContinuation(t.Body, &loopContinuation, true /* recursive */)
-			b.popContinuation()
-			b.popExitContinuation()
-			return b.callContinuation(&loopContinuation, s)
+			return b.buildLoop(t.Label, t.Body, stmts[i+1:], nil /* increment */, nil /* restore */, s)
+		case *plpgsqltree.PLpgSQLStmtForIntLoop:
+			return b.buildForIntLoop(t, stmts[i+1:], s)
+		case *plpgsqltree.PLpgSQLStmtBlock:
+			return b.buildBlock(t, stmts[i+1:], s)
+		case *plpgsqltree.PLpgSQLStmtRaise:
+			return b.buildRaise(t, stmts[i+1:], s)
+		case *plpgsqltree.PLpgSQLStmtForDynamicLoop:
+			panic(unimplemented.New(
+				"FOR loop over dynamic query",
+				"PL/pgSQL FOR loops over EXECUTE queries are not yet supported",
+			))
 		case *plpgsqltree.PLpgSQLStmtExit:
-			if t.Label != "" {
-				panic(unimplemented.New(
-					"EXIT label",
-					"EXIT statement labels are not yet supported",
-				))
-			}
 			// EXIT statements are handled by calling the function that executes the
-			// statements after a loop. Errors if used outside a loop.
-			if con := b.getExitContinuation(); con != nil {
-				return b.callContinuation(con, s)
-			} else {
-				panic(pgerror.New(
-					pgcode.Syntax,
-					"EXIT cannot be used outside a loop, unless it has a label",
-				))
-			}
+			// statements after the target loop or block. Errors if there is no
+			// target.
+			target := b.findFrame(t.Label, false /* isContinue */)
+			return b.jumpTo(s, target, b.frames[target].exitCon)
 		case *plpgsqltree.PLpgSQLStmtContinue:
-			if t.Label != "" {
-				panic(unimplemented.New(
-					"CONTINUE label",
-					"CONTINUE statement labels are not yet supported",
-				))
-			}
 			// CONTINUE statements are handled by calling the function that executes
-			// the loop body. Errors if used outside a loop.
-			if con := b.getLoopContinuation(); con != nil {
-				return b.callContinuation(con, s)
-			} else {
-				panic(pgerror.New(pgcode.Syntax, "CONTINUE cannot be used outside a loop"))
-			}
+			// the next iteration of the target loop. Errors if there is no target.
+			target := b.findFrame(t.Label, true /* isContinue */)
+			return b.jumpTo(s, target, b.frames[target].continueCon)
 		default:
 			panic(unimplemented.New(
 				"unimplemented PL/pgSQL statement",
@@ -356,6 +564,598 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(
 	return b.callContinuation(b.getContinuation(), s)
 }
 
+// outParamsScalar returns the value that is returned by a function with OUT
+// parameters: the value of the OUT parameter, or a tuple with the value of each
+// OUT parameter if there are several.
+func (b *plpgsqlBuilder) outParamsScalar(s *scope) opt.ScalarExpr {
+	if len(b.outParams) == 1 {
+		return b.varScalar(s, b.outParams[0], b.returnType)
+	}
+	elems := make(memo.ScalarListExpr, len(b.outParams))
+	for i, name := range b.outParams {
+		elems[i] = b.varScalar(s, name, b.varTypes[name])
+	}
+	return b.ob.factory.ConstructTuple(elems, b.returnType)
+}
+
+// returnValue builds a RETURN statement that returns the given value. Within
+// the body of a block with an EXCEPTION section, the value is instead returned
+// from the routine that executes the body, and the caller of that routine
+// returns it. See buildBlock for details.
+func (b *plpgsqlBuilder) returnValue(s *scope, returnScalar opt.ScalarExpr) *scope {
+	if f := b.exceptionFrame(); f != nil {
+		f.hasReturn = true
+		return b.exitFrame(s, f, blockResultReturn, returnScalar)
+	}
+	returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return"))
+	returnScope := s.push()
+	b.ob.synthesizeColumn(returnScope, returnColName, b.routineType, nil /* expr */, returnScalar)
+	b.ob.constructProjectForScope(s, returnScope)
+	return returnScope
+}
+
+// buildLoop builds a loop with the given body statements. The exitStmts are
+// executed after the loop exits. If increment is non-nil, its statements are
+// executed at the end of each iteration, including iterations that are ended
+// early by a CONTINUE statement. The restore statements restore variables that
+// are scoped to the loop when control leaves the loop by an EXIT or CONTINUE
+// statement that targets an enclosing loop or block. They must also be
+// included in exitStmts by the caller.
+//
+// LOOP control flow is handled similarly to IF statements, but two
+// continuation functions are used - one that executes the loop body, and one
+// that executes the statements following the LOOP statement. These are used
+// while building the loop body, which means that its definition is recursive.
+//
+// Upon reaching the end of the loop body statements or a CONTINUE statement,
+// the loop body function is called. Upon reaching an EXIT statement, the exit
+// continuation is called to model returning control flow to the statements
+// outside the loop.
+func (b *plpgsqlBuilder) buildLoop(
+	label string, body, exitStmts, increment, restore []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	exitCon := b.makeContinuation("loop_exit")
+	b.finishContinuation(exitStmts, &exitCon, false /* recursive */)
+	loopContinuation := b.makeContinuation("stmt_loop")
+	frame := &blockFrame{
+		label:       label,
+		isLoop:      true,
+		exitCon:     &exitCon,
+		continueCon: &loopContinuation,
+		restore:     restore,
+	}
+	b.pushContinuation(loopContinuation)
+	var incrementCon continuation
+	if increment != nil {
+		// The increment continuation calls back into the loop body, so it is
+		// also recursive. It is pushed after the loop continuation so that the
+		// end of the loop body and CONTINUE statements call it instead of the
+		// loop body.
+		incrementCon = b.makeContinuation("loop_increment")
+		b.finishContinuation(increment, &incrementCon, true /* recursive */)
+		b.pushContinuation(incrementCon)
+		frame.continueCon = &incrementCon
+	}
+	b.pushFrame(frame)
+	b.finishContinuation(body, &loopContinuation, true /* recursive */)
+	b.popFrame()
+	if increment != nil {
+		b.popContinuation()
+	}
+	b.popContinuation()
+	return b.callContinuation(&loopContinuation, s)
+}
+
+// buildForIntLoop builds an integer FOR loop. It is modeled as a LOOP that
+// exits once the loop variable passes the upper bound, and that increments the
+// loop variable at the end of each iteration. The bounds and step of the loop
+// are evaluated once, before the loop begins.
+func (b *plpgsqlBuilder) buildForIntLoop(
+	loop *plpgsqltree.PLpgSQLStmtForIntLoop, exitStmts []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	vars, ok := b.forLoops[loop]
+	if !ok {
+		panic(errors.AssertionFailedf("failed to find hidden variables for FOR loop"))
+	}
+	// The restore statements are executed however control leaves the loop.
+	var restore []plpgsqltree.PLpgSQLStatement
+	if vars.iterated != "" {
+		// FOUND is set to true once the loop exits if it iterated at least once.
+		restore = append(restore, &plpgsqltree.PLpgSQLStmtAssign{
+			Var: foundVar, Value: makeVarRef(vars.iterated),
+		})
+	}
+	restore = append(restore, vars.cleanup...)
+	if vars.saved != "" {
+		// The loop variable is scoped to the loop, so restore its original
+		// value once the loop exits.
+		s = b.addPLpgSQLAssign(s, vars.saved, makeVarRef(loop.Var))
+		restore = append(restore, &plpgsqltree.PLpgSQLStmtAssign{
+			Var: loop.Var, Value: makeVarRef(vars.saved),
+		})
+	}
+	if len(restore) > 0 {
+		exitStmts = append(restore[:len(restore):len(restore)], exitStmts...)
+	}
+	s = b.addPLpgSQLAssign(s, vars.upper, loop.Upper)
+	step := func() tree.Expr { return tree.NewDInt(1) }
+	if vars.step != "" {
+		step = func() tree.Expr { return makeVarRef(vars.step) }
+		s = b.addPLpgSQLAssign(s, vars.step, loop.Step)
+		s = b.addPLpgSQLAssign(s, vars.step, &tree.CaseExpr{
+			Whens: []*tree.When{{
+				Cond: &tree.ComparisonExpr{
+					Operator: treecmp.MakeComparisonOperator(treecmp.GT),
+					Left:     step(),
+					Right:    tree.NewDInt(0),
+				},
+				Val: step(),
+			}},
+			Else: makeRaiseCall(
+				"EXCEPTION",
+				tree.NewStrVal("BY value of FOR loop must be greater than zero"),
+				nil, /* detail */
+				nil, /* hint */
+				tree.NewStrVal(pgcode.InvalidParameterValue.String()),
+			),
+		})
+	}
+	s = b.addPLpgSQLAssign(s, loop.Var, loop.Lower)
+
+	cmp, inc := treecmp.LE, treebin.Plus
+	if loop.Reverse {
+		cmp, inc = treecmp.GE, treebin.Minus
+	}
+	inBounds := func() tree.Expr {
+		return &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(cmp),
+			Left:     makeVarRef(loop.Var),
+			Right:    makeVarRef(vars.upper),
+		}
+	}
+	if vars.iterated != "" {
+		// The loop iterates at least once if the lower bound is within the
+		// upper bound.
+		s = b.addPLpgSQLAssign(s, vars.iterated, &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.IsNotDistinctFrom),
+			Left:     &tree.ParenExpr{Expr: inBounds()},
+			Right:    tree.DBoolTrue,
+		})
+	}
+	// Exit the loop once the loop variable passes the upper bound, or if
+	// either is NULL.
+	exitCheck := &plpgsqltree.PLpgSQLStmtIf{
+		Condition: makeIsNotTrue(inBounds()),
+		ThenBody:  []plpgsqltree.PLpgSQLStatement{&plpgsqltree.PLpgSQLStmtExit{}},
+	}
+	body := append([]plpgsqltree.PLpgSQLStatement{exitCheck}, loop.Body...)
+	increment := []plpgsqltree.PLpgSQLStatement{&plpgsqltree.PLpgSQLStmtAssign{
+		Var: loop.Var,
+		Value: &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(inc),
+			Left:     makeVarRef(loop.Var),
+			Right:    step(),
+		},
+	}}
+	return b.buildLoop(loop.Label, body, exitStmts, increment, restore, s)
+}
+
+// buildBlock builds a block that is nested within another block. The
+// variables declared by the block have already been added to the set of
+// function variables by desugarBlock, which also produced the statements that
+// initialize them when the block is entered, and that restore any variables
+// they shadow when the block is exited.
+//
+// A block without an EXCEPTION section is built inline, with a continuation
+// that executes the statements following the block. A block with an EXCEPTION
+// section is executed by a separate routine with an exception handler. The
+// routine cannot call the continuation for the statements following the block,
+// since they must not be executed within the scope of the handler. Instead, the
+// routine returns a tuple with the following fields:
+//
+//  1. The reason that control left the block body: it reached the end of the
+//     block, it executed a RETURN statement, or it executed an EXIT or CONTINUE
+//     statement that targets an enclosing loop or block. See blockResultExit
+//     and blockResultReturn.
+//  2. The value to return from the function, if a RETURN statement was
+//     executed.
+//  3. The values of each variable when control left the block body.
+//
+// The caller of the routine then continues execution accordingly.
+func (b *plpgsqlBuilder) buildBlock(
+	block *plpgsqltree.PLpgSQLStmtBlock, stmts []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	vars, ok := b.blocks[block]
+	if !ok {
+		panic(errors.AssertionFailedf("failed to find variables for nested block"))
+	}
+	s = b.applyAssigns(s, vars.entry)
+	exitCon := b.makeContinuation("block_exit")
+	b.finishContinuation(
+		append(vars.restore[:len(vars.restore):len(vars.restore)], stmts...), &exitCon, false, /* recursive */
+	)
+	frame := &blockFrame{label: block.Label, exitCon: &exitCon, restore: vars.restore}
+	if block.Exceptions == nil {
+		b.pushFrame(frame)
+		b.pushContinuation(exitCon)
+		s = b.buildPLpgSQLStatements(block.Body, s)
+		b.popContinuation()
+		b.popFrame()
+		return s
+	}
+
+	// Reaching the end of the block body, or an EXIT statement that targets the
+	// block, returns from the routine that executes the body.
+	frame.exception = true
+	frame.typ = b.makeBlockResultType()
+	endCon := continuation{frame: frame}
+	frame.exitCon = &endCon
+	b.pushFrame(frame)
+	b.pushContinuation(endCon)
+	s = b.buildExceptionBlock(block.Body, block.Exceptions.ExecList, s)
+	b.popContinuation()
+	b.popFrame()
+	if s == nil {
+		return nil
+	}
+
+	// Unpack the variables from the result of the routine.
+	f := b.ob.factory
+	result := f.ConstructVariable(s.cols[0].id)
+	unpackScope := s.push()
+	unpackScope.appendColumn(&s.cols[0])
+	for i, dec := range b.decls {
+		b.ob.synthesizeColumn(
+			unpackScope, scopeColName(dec.Var), b.varTypes[dec.Var], nil, /* expr */
+			f.ConstructColumnAccess(result, memo.TupleOrdinal(i+blockResultVarsOffset)),
+		)
+	}
+	b.ob.constructProjectForScope(s, unpackScope)
+	if !frame.hasReturn && len(frame.jumps) == 0 {
+		// Control always continues with the statements after the block.
+		return b.callContinuation(&exitCon, unpackScope)
+	}
+
+	// Build a CASE statement that continues execution according to the reason
+	// that control left the block body. Each branch is built as a subquery.
+	buildBranch := func(build func(s *scope) *scope) opt.ScalarExpr {
+		branchScope := unpackScope.push()
+		b.ensureScopeHasExpr(branchScope)
+		if branchScope = build(branchScope); branchScope == nil {
+			return nil
+		}
+		return f.ConstructSubquery(branchScope.expr, &memo.SubqueryPrivate{})
+	}
+	makeKind := func(kind int) opt.ScalarExpr {
+		return f.ConstructConstVal(tree.NewDInt(tree.DInt(kind)), types.Int)
+	}
+	var whens memo.ScalarListExpr
+	if frame.hasReturn {
+		returnBranch := buildBranch(func(s *scope) *scope {
+			return b.returnValue(s, f.ConstructColumnAccess(result, blockResultValueOrd))
+		})
+		whens = append(whens, f.ConstructWhen(makeKind(blockResultReturn), returnBranch))
+	}
+	for i, jump := range frame.jumps {
+		jumpBranch := buildBranch(jump)
+		if jumpBranch == nil {
+			return nil
+		}
+		whens = append(whens, f.ConstructWhen(makeKind(blockResultJumpOffset+i), jumpBranch))
+	}
+	exitBranch := buildBranch(func(s *scope) *scope { return b.callContinuation(&exitCon, s) })
+	if exitBranch == nil {
+		return nil
+	}
+	kind := f.ConstructColumnAccess(result, blockResultKindOrd)
+	scalar := f.ConstructCase(kind, whens, exitBranch)
+	returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_block"))
+	returnScope := unpackScope.push()
+	b.ob.synthesizeColumn(returnScope, returnColName, b.resultType(), nil /* expr */, scalar)
+	b.ob.constructProjectForScope(unpackScope, returnScope)
+	return returnScope
+}
+
+// The result of the routine that executes the body of a nested block with an
+// EXCEPTION section is a tuple. blockResultKindOrd is the ordinal of the field
+// that holds the reason that control left the body, blockResultValueOrd is the
+// ordinal of the field that holds the value to return from the function, and
+// the values of the variables start at blockResultVarsOffset.
+const (
+	blockResultKindOrd    = 0
+	blockResultValueOrd   = 1
+	blockResultVarsOffset = 2
+)
+
+// These are the values of the first field in the result of the routine that
+// executes the body of a nested block with an EXCEPTION section.
+const (
+	// blockResultExit indicates that control reached the end of the block.
+	blockResultExit = 0
+	// blockResultReturn indicates that a RETURN statement was executed.
+	blockResultReturn = 1
+	// blockResultJumpOffset is added to the index of the blockFrame.jumps entry
+	// for an EXIT or CONTINUE statement that targets an enclosing loop or block.
+	blockResultJumpOffset = 2
+)
+
+// makeBlockResultType returns the type of the result of the routine that
+// executes the body of a nested block with an EXCEPTION section.
+func (b *plpgsqlBuilder) makeBlockResultType() *types.T {
+	contents := make([]*types.T, 0, len(b.decls)+blockResultVarsOffset)
+	contents = append(contents, types.Int, b.routineType)
+	for _, dec := range b.decls {
+		contents = append(contents, b.varTypes[dec.Var])
+	}
+	return types.MakeTuple(contents)
+}
+
+// exitFrame returns from the routine that executes the body of the given
+// block. See buildBlock for details.
+func (b *plpgsqlBuilder) exitFrame(
+	s *scope, frame *blockFrame, kind int, returnScalar opt.ScalarExpr,
+) *scope {
+	f := b.ob.factory
+	if returnScalar == nil {
+		returnScalar = f.ConstructNull(b.routineType)
+	}
+	elems := make(memo.ScalarListExpr, 0, len(b.decls)+blockResultVarsOffset)
+	elems = append(elems, f.ConstructConstVal(tree.NewDInt(tree.DInt(kind)), types.Int), returnScalar)
+	for _, dec := range b.decls {
+		elems = append(elems, b.varScalar(s, dec.Var, b.varTypes[dec.Var]))
+	}
+	returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("exit_block"))
+	returnScope := s.push()
+	b.ob.synthesizeColumn(
+		returnScope, returnColName, frame.typ, nil /* expr */, f.ConstructTuple(elems, frame.typ),
+	)
+	b.ob.constructProjectForScope(s, returnScope)
+	return returnScope
+}
+
+// findFrame returns the index of the loop or block that is the target of an
+// EXIT or CONTINUE statement with the given label. An EXIT or CONTINUE
+// statement without a label targets the innermost loop.
+func (b *plpgsqlBuilder) findFrame(label string, isContinue bool) int {
+	for i := len(b.frames) - 1; i >= 0; i-- {
+		frame := b.frames[i]
+		if label == "" {
+			if frame.isLoop {
+				return i
+			}
+			continue
+		}
+		if frame.label == label {
+			if isContinue && !frame.isLoop {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"block label \"%s\" cannot be used in CONTINUE", label,
+				))
+			}
+			return i
+		}
+	}
+	if label != "" {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"there is no label \"%s\" attached to any block or loop enclosing this statement", label,
+		))
+	}
+	if isContinue {
+		panic(pgerror.New(pgcode.Syntax, "CONTINUE cannot be used outside a loop"))
+	}
+	panic(pgerror.New(pgcode.Syntax, "EXIT cannot be used outside a loop, unless it has a label"))
+}
+
+// jumpTo transfers control to the given continuation of the loop or block at
+// the given index in the frames stack, on behalf of an EXIT or CONTINUE
+// statement. The variables that are scoped to the loops and blocks that
+// control leaves are restored first.
+func (b *plpgsqlBuilder) jumpTo(s *scope, target int, con *continuation) *scope {
+	for i := len(b.frames) - 1; i > target; i-- {
+		frame := b.frames[i]
+		if frame.exception {
+			// Control leaves the routine that executes the body of a block with an
+			// EXCEPTION section. The caller of the routine completes the jump.
+			frame.jumps = append(frame.jumps, func(s *scope) *scope {
+				return b.jumpTo(b.applyAssigns(s, frame.restore), target, con)
+			})
+			return b.exitFrame(s, frame, blockResultJumpOffset+len(frame.jumps)-1, nil /* returnScalar */)
+		}
+		s = b.applyAssigns(s, frame.restore)
+	}
+	return b.callContinuation(con, s)
+}
+
+// applyAssigns builds the given assignment statements.
+func (b *plpgsqlBuilder) applyAssigns(s *scope, stmts []plpgsqltree.PLpgSQLStatement) *scope {
+	for _, stmt := range stmts {
+		assign, ok := stmt.(*plpgsqltree.PLpgSQLStmtAssign)
+		if !ok {
+			panic(errors.AssertionFailedf("expected assignment, found %T", stmt))
+		}
+		s = b.addPLpgSQLAssign(s, assign.Var, assign.Value)
+	}
+	return s
+}
+
+// buildRaise builds a RAISE statement, which is modeled as a continuation with
+// two body statements. The first calls a builtin function that sends the
+// notice or throws the error, and the second executes the statements that
+// follow the RAISE. Using a separate body statement ensures that the RAISE is
+// executed before the statements that follow it.
+func (b *plpgsqlBuilder) buildRaise(
+	raise *plpgsqltree.PLpgSQLStmtRaise, stmts []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	con := b.makeContinuation("stmt_raise")
+	paramScope, params := b.makeContinuationParamScope()
+	raiseScope := paramScope.push()
+	b.ensureScopeHasExpr(raiseScope)
+	raiseCall := b.buildPLpgSQLExpr(b.makeRaiseStmtCall(raise), types.Int, raiseScope)
+	raiseColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_raise"))
+	projectScope := raiseScope.push()
+	b.ob.synthesizeColumn(projectScope, raiseColName, types.Int, nil /* expr */, raiseCall)
+	b.ob.constructProjectForScope(raiseScope, projectScope)
+
+	continuationScope := b.buildPLpgSQLStatements(stmts, paramScope.push())
+	if continuationScope == nil {
+		// One or more branches did not terminate with a RETURN statement.
+		con.reachedEndOfFunction = true
+	} else {
+		b.setContinuationBody(&con, params, projectScope, continuationScope)
+	}
+	return b.callContinuation(&con, s)
+}
+
+// makeRaiseStmtCall returns a call to the builtin function that implements the
+// given RAISE statement.
+func (b *plpgsqlBuilder) makeRaiseStmtCall(raise *plpgsqltree.PLpgSQLStmtRaise) tree.Expr {
+	if raise.LogLevel == "" {
+		// A RAISE statement without parameters re-raises the error that is being
+		// handled by the enclosing exception handler. The builtin function
+		// returns a sentinel error that the handler replaces with the original
+		// error.
+		if b.handlerDepth == 0 {
+			panic(pgerror.New(
+				pgcode.StackedDiagnosticsAccessedWithoutActiveHandler,
+				"RAISE without parameters cannot be used outside an exception handler",
+			))
+		}
+		return makeRaiseCall("" /* severity */, nil, nil, nil, nil)
+	}
+	var message, detail, hint, code tree.Expr
+	if raise.Message != "" {
+		message = b.makeRaiseFormatMessage(raise)
+	}
+	if raise.CodeName != "" {
+		code = tree.NewStrVal(raise.CodeName)
+	} else if raise.Code != "" {
+		code = tree.NewStrVal(raise.Code)
+	}
+	for _, option := range raise.Options {
+		optionExpr := &tree.CastExpr{Expr: option.Expr, Type: types.String, SyntaxMode: tree.CastShort}
+		switch option.OptType {
+		case plpgsqltree.PLpgSQLRaiseOptionMessage:
+			message = optionExpr
+		case plpgsqltree.PLpgSQLRaiseOptionDetail:
+			detail = optionExpr
+		case plpgsqltree.PLpgSQLRaiseOptionHint:
+			hint = optionExpr
+		case plpgsqltree.PLpgSQLRaiseOptionErrCode:
+			code = optionExpr
+		default:
+			panic(unimplemented.Newf(
+				"RAISE option",
+				"the %s option of RAISE statements is not yet supported", option.OptType,
+			))
+		}
+	}
+	return makeRaiseCall(raise.LogLevel, message, detail, hint, code)
+}
+
+// makeRaiseFormatMessage returns an expression that substitutes the parameters
+// of a RAISE statement into its format string. Each % in the format string is
+// replaced by the string representation of the next parameter, or <NULL> if
+// the parameter is NULL. %% is replaced by a literal %.
+func (b *plpgsqlBuilder) makeRaiseFormatMessage(raise *plpgsqltree.PLpgSQLStmtRaise) tree.Expr {
+	var message tree.Expr
+	addToMessage := func(expr tree.Expr) {
+		if message == nil {
+			message = expr
+			return
+		}
+		message = &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(treebin.Concat),
+			Left:     message,
+			Right:    expr,
+		}
+	}
+	var sb strings.Builder
+	paramIdx := 0
+	for i := 0; i < len(raise.Message); i++ {
+		if raise.Message[i] != '%' {
+			sb.WriteByte(raise.Message[i])
+			continue
+		}
+		if i+1 < len(raise.Message) && raise.Message[i+1] == '%' {
+			sb.WriteByte('%')
+			i++
+			continue
+		}
+		if paramIdx >= len(raise.Params) {
+			panic(errors.AssertionFailedf("too few parameters specified for RAISE"))
+		}
+		if sb.Len() > 0 {
+			addToMessage(tree.NewStrVal(sb.String()))
+			sb.Reset()
+		}
+		addToMessage(&tree.CoalesceExpr{
+			Name: "COALESCE",
+			Exprs: tree.Exprs{
+				&tree.CastExpr{Expr: raise.Params[paramIdx], Type: types.String, SyntaxMode: tree.CastShort},
+				tree.NewStrVal("<NULL>"),
+			},
+		})
+		paramIdx++
+	}
+	if sb.Len() > 0 || message == nil {
+		addToMessage(tree.NewStrVal(sb.String()))
+	}
+	return message
+}
+
+// buildExceptionBlock builds the body of a block that has an EXCEPTION
+// section. The body is built as a continuation with an exception handler,
+// which executes the body within a savepoint. If the body throws an error that
+// matches one of the handler's conditions, the changes made by the body are
+// rolled back, and the continuation for the matching action is executed
+// instead.
+//
+// Note that the actions observe the values that the variables had when the
+// block was entered. In Postgres, they observe the values that the variables
+// had when the error was thrown.
+func (b *plpgsqlBuilder) buildExceptionBlock(
+	body []plpgsqltree.PLpgSQLStatement, exceptions []*plpgsqltree.PLpgSQLException, s *scope,
+) *scope {
+	var exceptionBlock memo.ExceptionBlock
+	b.handlerDepth++
+	for _, e := range exceptions {
+		action := b.makeContinuation("exception_handler")
+		b.finishContinuation(e.Action, &action, false /* recursive */)
+		if action.reachedEndOfFunction {
+			// Return nil to signify "control reached end of function without
+			// RETURN".
+			b.handlerDepth--
+			return nil
+		}
+		for _, cond := range e.Conditions {
+			exceptionBlock.Codes = append(exceptionBlock.Codes, conditionCode(cond))
+			exceptionBlock.Actions = append(exceptionBlock.Actions, action.def)
+		}
+	}
+	b.handlerDepth--
+	con := b.makeContinuation("exception_block")
+	b.finishContinuation(body, &con, false /* recursive */)
+	con.def.ExceptionBlock = &exceptionBlock
+	return b.callContinuation(&con, s)
+}
+
+// conditionCode returns the error code that is matched by the given condition
+// of an exception handler.
+func conditionCode(cond plpgsqltree.PLpgSQLCondition) pgcode.Code {
+	if cond.SqlErrState != "" {
+		return pgcode.MakeCode(cond.SqlErrState)
+	}
+	if cond.Name == "others" {
+		return pgcode.PLpgSQLOthers
+	}
+	code, ok := pgcode.PLpgSQLConditionNameToCode[cond.Name]
+	if !ok {
+		panic(pgerror.Newf(
+			pgcode.UndefinedObject, "unrecognized exception condition \"%s\"", cond.Name,
+		))
+	}
+	return code
+}
+
 // addPLpgSQLAssign adds a PL/pgSQL assignment to the current scope as a
 // new column with the variable name that projects the assigned expression.
 // If there is a column with the same name in the previous scope, it will be
@@ -365,7 +1165,23 @@ func (b *plpgsqlBuilder) addPLpgSQLAssign(
 ) *scope {
 	typ, ok := b.varTypes[ident]
 	if !ok {
-		panic(errors.AssertionFailedf("failed to find type for variable %s", ident))
+		panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", ident))
+	}
+	return b.assignScalar(inScope, ident, b.buildPLpgSQLExpr(val, typ, inScope))
+}
+
+// assignScalar is like addPLpgSQLAssign, but it assigns an expression that has
+// already been built. The expression is cast to the type of the variable if
+// necessary.
+func (b *plpgsqlBuilder) assignScalar(
+	inScope *scope, ident plpgsqltree.PLpgSQLVariable, scalar opt.ScalarExpr,
+) *scope {
+	typ := b.varTypes[ident]
+	if srcTyp := scalar.DataType(); srcTyp.Family() != types.UnknownFamily && !srcTyp.Equivalent(typ) {
+		if !cast.ValidCast(srcTyp, typ, cast.ContextAssignment) {
+			panic(sqlerrors.NewInvalidAssignmentCastError(srcTyp, typ, string(ident)))
+		}
+		scalar = b.ob.factory.ConstructAssignmentCast(scalar, typ)
 	}
 	assignScope := inScope.push()
 	for i := range inScope.cols {
@@ -380,7 +1196,6 @@ func (b *plpgsqlBuilder) addPLpgSQLAssign(
 	}
 	// Project the assignment as a new column.
 	colName := scopeColName(ident)
-	scalar := b.buildPLpgSQLExpr(val, typ, inScope)
 	b.ob.synthesizeColumn(assignScope, colName, typ, nil, scalar)
 	b.ob.constructProjectForScope(inScope, assignScope)
 	return assignScope
@@ -392,12 +1207,23 @@ func (b *plpgsqlBuilder) makeContinuation(name string) continuation {
 	return continuation{
 		def: &memo.UDFDefinition{
 			Name:              b.makeIdentifier(name),
-			Typ:               b.returnType,
+			Typ:               b.resultType(),
 			CalledOnNullInput: true,
 		},
 	}
 }
 
+// resultType returns the type of the result of the continuations that are
+// being built. It is the type of the value returned by RETURN statements,
+// unless the continuations are built within the body of a block with an
+// EXCEPTION section. See buildBlock for details.
+func (b *plpgsqlBuilder) resultType() *types.T {
+	if f := b.exceptionFrame(); f != nil {
+		return f.typ
+	}
+	return b.routineType
+}
+
 // finishContinuation initializes the definition of a continuation function with
 // the function body. It is separate from makeContinuation to allow recursive
 // function definitions, which need to push the continuation before it is
@@ -405,6 +1231,23 @@ func (b *plpgsqlBuilder) makeContinuation(name string) continuation {
 func (b *plpgsqlBuilder) finishContinuation(
 	stmts []plpgsqltree.PLpgSQLStatement, con *continuation, recursive bool,
 ) {
+	s, params := b.makeContinuationParamScope()
+	// Make sure to push s before constructing the continuation scope to ensure
+	// that the parameter columns are not projected.
+	continuationScope := b.buildPLpgSQLStatements(stmts, s.push())
+	if continuationScope == nil {
+		// One or more branches did not terminate with a RETURN statement.
+		con.reachedEndOfFunction = true
+		return
+	}
+	con.def.IsRecursive = recursive
+	b.setContinuationBody(con, params, continuationScope)
+}
+
+// makeContinuationParamScope returns a scope with a parameter column for each
+// variable and function parameter, which are passed to every continuation. It
+// also returns the IDs of the parameter columns.
+func (b *plpgsqlBuilder) makeContinuationParamScope() (*scope, opt.ColList) {
 	s := b.ob.allocScope()
 	b.ensureScopeHasExpr(s)
 	params := make(opt.ColList, 0, len(b.decls)+len(b.params))
@@ -422,21 +1265,26 @@ func (b *plpgsqlBuilder) finishContinuation(
 	for _, param := range b.params {
 		addParam(tree.Name(param.Name), param.Typ)
 	}
-	// Make sure to push s before constructing the continuation scope to ensure
-	// that the parameter columns are not projected.
-	continuationScope := b.buildPLpgSQLStatements(stmts, s.push())
-	if continuationScope == nil {
-		// One or more branches did not terminate with a RETURN statement.
-		con.reachedEndOfFunction = true
-		return
+	return s, params
+}
+
+// setContinuationBody sets the parameters of a continuation function and its
+// body, which has one statement for each of the given scopes. The result of
+// the continuation is the result of the last statement.
+func (b *plpgsqlBuilder) setContinuationBody(
+	con *continuation, params opt.ColList, stmtScopes ...*scope,
+) {
+	con.def.Body = make([]memo.RelExpr, len(stmtScopes))
+	con.def.BodyProps = make([]*physical.Required, len(stmtScopes))
+	var vol props.VolatilitySet
+	for i, stmtScope := range stmtScopes {
+		con.def.Body[i] = stmtScope.expr
+		con.def.BodyProps[i] = stmtScope.makePhysicalProps()
+		vol.UnionWith(stmtScope.expr.Relational().VolatilitySet)
 	}
-	con.def.IsRecursive = recursive
-	con.def.Body = []memo.RelExpr{continuationScope.expr}
-	con.def.BodyProps = []*physical.Required{continuationScope.makePhysicalProps()}
 	con.def.Params = params
 	// Set the volatility of the continuation routine to the least restrictive
-	// volatility level in the expression's Relational properties.
-	vol := continuationScope.expr.Relational().VolatilitySet
+	// volatility level in the Relational properties of its statements.
 	if vol.HasVolatile() {
 		con.def.Volatility = volatility.Volatile
 	} else if vol.HasStable() {
@@ -455,35 +1303,42 @@ func (b *plpgsqlBuilder) callContinuation(con *continuation, s *scope) *scope {
 		// Return nil to signify "control reached end of function without RETURN".
 		return nil
 	}
+	if con.frame != nil {
+		// Control leaves the body of a block with an EXCEPTION section.
+		return b.exitFrame(s, con.frame, blockResultExit, nil /* returnScalar */)
+	}
 	args := make(memo.ScalarListExpr, 0, len(b.decls)+len(b.params))
-	addArg := func(name tree.Name, typ *types.T) {
-		_, source, _, _ := s.FindSourceProvidingColumn(b.ob.ctx, name)
-		if source != nil {
-			args = append(args, b.ob.factory.ConstructVariable(source.(*scopeColumn).id))
-		} else {
-			args = append(args, b.ob.factory.ConstructNull(typ))
-		}
-	}
 	for _, dec := range b.decls {
-		addArg(dec.Var, b.varTypes[dec.Var])
+		args = append(args, b.varScalar(s, dec.Var, b.varTypes[dec.Var]))
 	}
 	for _, param := range b.params {
-		addArg(tree.Name(param.Name), param.Typ)
+		args = append(args, b.varScalar(s, tree.Name(param.Name), param.Typ))
 	}
 	call := b.ob.factory.ConstructUDFCall(args, &memo.UDFCallPrivate{Def: con.def})
 
 	returnColName := scopeColName("").WithMetadataName(con.def.Name)
 	returnScope := s.push()
-	b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, call)
+	b.ob.synthesizeColumn(returnScope, returnColName, con.def.Typ, nil /* expr */, call)
 	b.ob.constructProjectForScope(s, returnScope)
 	return returnScope
 }
 
+// varScalar returns a reference to the current value of the given variable or
+// parameter, or NULL if it is not in scope.
+func (b *plpgsqlBuilder) varScalar(s *scope, name tree.Name, typ *types.T) opt.ScalarExpr {
+	_, source, _, _ := s.FindSourceProvidingColumn(b.ob.ctx, name)
+	if source != nil {
+		return b.ob.factory.ConstructVariable(source.(*scopeColumn).id)
+	}
+	return b.ob.factory.ConstructNull(typ)
+}
+
 // buildPLpgSQLExpr parses and builds the given SQL expression into a ScalarExpr
 // within the given scope.
 func (b *plpgsqlBuilder) buildPLpgSQLExpr(
 	expr plpgsqltree.PLpgSQLExpr, typ *types.T, s *scope,
 ) opt.ScalarExpr {
+	expr = b.replaceRecordRefs(expr)
 	expr, _ = tree.WalkExpr(s, expr)
 	typedExpr, err := expr.TypeCheck(b.ob.ctx, b.ob.semaCtx, typ)
 	if err != nil {
@@ -492,6 +1347,131 @@ func (b *plpgsqlBuilder) buildPLpgSQLExpr(
 	return b.ob.buildScalar(typedExpr, s, nil, nil, b.colRefs)
 }
 
+// replaceRecordRefs rewrites references of the form rec.field, where rec is a
+// variable with a composite or RECORD type, as accesses of the field of the
+// variable. They would otherwise be resolved as a column of a table named rec.
+func (b *plpgsqlBuilder) replaceRecordRefs(expr tree.Expr) tree.Expr {
+	expr, err := tree.SimpleVisit(expr, b.replaceRecordRef)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
+// replaceRecordRef is a tree.SimpleVisitFn that implements replaceRecordRefs.
+func (b *plpgsqlBuilder) replaceRecordRef(expr tree.Expr) (bool, tree.Expr, error) {
+	name, ok := expr.(*tree.UnresolvedName)
+	if !ok || name.NumParts != 2 || name.Star {
+		return true, expr, nil
+	}
+	rec := tree.Name(name.Parts[1])
+	if typ, ok := b.varTypes[rec]; !ok || typ.Family() != types.TupleFamily {
+		return true, expr, nil
+	}
+	return false, &tree.ColumnAccessExpr{
+		Expr:    &tree.ParenExpr{Expr: makeVarRef(rec)},
+		ColName: tree.Name(name.Parts[0]),
+	}, nil
+}
+
+// buildQuery builds the given query as a subquery within the given scope.
+func (b *plpgsqlBuilder) buildQuery(query tree.Statement, s *scope) *subquery {
+	sel, ok := query.(*tree.Select)
+	if !ok {
+		panic(unimplemented.Newf(
+			"PL/pgSQL query",
+			"%s statements are not yet supported as PL/pgSQL queries", query.StatementTag(),
+		))
+	}
+	rewritten, err := tree.SimpleStmtVisit(sel, b.replaceRecordRef)
+	if err != nil {
+		panic(err)
+	}
+	sub := s.replaceSubquery(
+		&tree.Subquery{Select: &tree.ParenSelect{Select: rewritten.(*tree.Select)}},
+		false, /* wrapInTuple */
+		-1,    /* desiredNumColumns */
+		extraColsAllowed,
+	)
+	sub.buildSubquery(nil /* desiredTypes */)
+	return sub
+}
+
+// queryRowType returns a tuple type with the types and names of the columns
+// returned by the given query.
+func (b *plpgsqlBuilder) queryRowType(query tree.Statement) *types.T {
+	s, _ := b.makeContinuationParamScope()
+	sub := b.buildQuery(query, s)
+	contents := make([]*types.T, len(sub.cols))
+	labels := make([]string, len(sub.cols))
+	for i := range sub.cols {
+		contents[i] = sub.cols[i].typ
+		labels[i] = string(sub.cols[i].name.ReferenceName())
+	}
+	return types.MakeLabeledTuple(contents, labels)
+}
+
+// buildQueryArray builds an expression that returns an array with one element
+// for each row returned by the given query, in the order of the query. If the
+// element type is a tuple type with a field for each column of the query, the
+// elements are tuples of the columns. Otherwise, the query must return a
+// single column of the element type.
+func (b *plpgsqlBuilder) buildQueryArray(
+	query tree.Statement, elemType *types.T, s *scope,
+) opt.ScalarExpr {
+	f := b.ob.factory
+	sub := b.buildQuery(query, s)
+	var elem opt.ScalarExpr
+	if elemType.Family() == types.TupleFamily && len(elemType.TupleContents()) == len(sub.cols) {
+		elems := make(memo.ScalarListExpr, len(sub.cols))
+		for i := range sub.cols {
+			if !sub.cols[i].typ.Equivalent(elemType.TupleContents()[i]) {
+				elems = nil
+				break
+			}
+			elems[i] = f.ConstructVariable(sub.cols[i].id)
+		}
+		if elems != nil {
+			elem = f.ConstructTuple(elems, elemType)
+		}
+	}
+	if elem == nil && len(sub.cols) == 1 && sub.cols[0].typ.Equivalent(elemType) {
+		elem = f.ConstructVariable(sub.cols[0].id)
+	}
+	if elem == nil {
+		panic(pgerror.New(pgcode.DatatypeMismatch,
+			"structure of query does not match function result type",
+		))
+	}
+
+	// This mirrors the construction of ARRAY(...) subqueries in buildScalar.
+	if !sub.outerCols.Empty() && !memo.AggregateOverloadExists(opt.ArrayAggOp, elemType) {
+		panic(unimplementedWithIssueDetailf(35710, "",
+			"can't execute a correlated ARRAY(...) over %s", elemType,
+		))
+	}
+	if err := types.CheckArrayElementType(elemType); err != nil {
+		panic(err)
+	}
+	b.ob.checkSubqueryOuterCols(sub.outerCols, false /* inGroupingContext */, s, b.colRefs)
+
+	// Project the element, passing through the columns of the ordering.
+	elemCol := f.Metadata().AddColumn("query_row", elemType)
+	var passthrough opt.ColSet
+	for _, c := range sub.ordering {
+		passthrough.Add(c.ID())
+	}
+	input := f.ConstructProject(
+		sub.node, memo.ProjectionsExpr{f.ConstructProjectionsItem(elem, elemCol)}, passthrough,
+	)
+	return f.ConstructArrayFlatten(input, &memo.SubqueryPrivate{
+		OriginalExpr: sub.Subquery,
+		Ordering:     sub.ordering,
+		RequestedCol: elemCol,
+		WithinUDF:    b.ob.insideUDF,
+	})
+}
+
 func (b *plpgsqlBuilder) ensureScopeHasExpr(s *scope) {
 	if s.expr == nil {
 		s.expr = b.ob.factory.ConstructValues(memo.ScalarListWithEmptyTuple, &memo.ValuesPrivate{
@@ -506,6 +1486,921 @@ func (b *plpgsqlBuilder) makeIdentifier(id string) string {
 	return fmt.Sprintf("%s_%d", id, b.identCounter)
 }
 
+// desugarStatements rewrites WHILE loops, FOREACH loops, FOR loops over
+// queries, ELSIF branches, nested blocks, cursor statements, RETURN NEXT and
+// RETURN QUERY statements, and EXIT and CONTINUE statements with WHEN
+// conditions in terms of the simpler statements that are built directly by
+// buildPLpgSQLStatements. It also declares the implicit and hidden variables
+// used by these statements, so it must be called before any continuations are
+// built.
+func (b *plpgsqlBuilder) desugarStatements(
+	stmts []plpgsqltree.PLpgSQLStatement,
+) []plpgsqltree.PLpgSQLStatement {
+	res := make([]plpgsqltree.PLpgSQLStatement, 0, len(stmts))
+	for _, stmt := range stmts {
+		switch t := stmt.(type) {
+		case *plpgsqltree.PLpgSQLStmtIf:
+			newIf := *t
+			newIf.ThenBody = b.desugarStatements(t.ThenBody)
+			// Each ELSIF branch is equivalent to an IF statement within the ELSE
+			// branch of the preceding branch.
+			elseBody := t.ElseBody
+			for i := len(t.ElseIfList) - 1; i >= 0; i-- {
+				elseIf := t.ElseIfList[i]
+				elseBody = []plpgsqltree.PLpgSQLStatement{&plpgsqltree.PLpgSQLStmtIf{
+					Condition: elseIf.Condition,
+					ThenBody:  elseIf.Stmts,
+					ElseBody:  elseBody,
+				}}
+			}
+			newIf.ElseIfList = nil
+			newIf.ElseBody = b.desugarStatements(elseBody)
+			res = append(res, &newIf)
+		case *plpgsqltree.PLpgSQLStmtSimpleLoop:
+			newLoop := *t
+			newLoop.Body = b.desugarStatements(t.Body)
+			res = append(res, &newLoop)
+		case *plpgsqltree.PLpgSQLStmtWhileLoop:
+			// A WHILE loop is a LOOP that exits at the start of each iteration if
+			// the condition is not true.
+			exit := &plpgsqltree.PLpgSQLStmtExit{Condition: makeIsNotTrue(t.Condition)}
+			res = append(res, &plpgsqltree.PLpgSQLStmtSimpleLoop{
+				Label: t.Label,
+				Body:  b.desugarStatements(append([]plpgsqltree.PLpgSQLStatement{exit}, t.Body...)),
+			})
+		case *plpgsqltree.PLpgSQLStmtForIntLoop:
+			// The loop variable is implicitly declared as an integer.
+			b.addImplicitDecl(t.Var, types.Int)
+			res = append(res, b.desugarForIntLoop(t, true /* scoped */, nil /* cleanup */))
+		case *plpgsqltree.PLpgSQLStmtForEachALoop:
+			res = append(res, b.desugarForEachLoop(t)...)
+		case *plpgsqltree.PLpgSQLStmtForQuerySelectLoop:
+			res = append(res, b.desugarForQueryLoop(t)...)
+		case *plpgsqltree.PLpgSQLStmtForQueryCursorLoop:
+			res = append(res, b.desugarForCursorLoop(t)...)
+		case *plpgsqltree.PLpgSQLStmtBlock:
+			res = append(res, b.desugarBlock(t))
+		case *plpgsqltree.PLpgSQLStmtOpen:
+			res = append(res, b.desugarOpen(t)...)
+		case *plpgsqltree.PLpgSQLStmtFetch:
+			res = append(res, b.desugarFetch(t)...)
+		case *plpgsqltree.PLpgSQLStmtClose:
+			name := tree.Name(t.CursorName)
+			cur := b.getCursor(name)
+			if cur.rows == "" {
+				panic(cursorNotOpenedErr(name))
+			}
+			// A cursor is closed by discarding its rows.
+			res = append(res,
+				b.makeCursorCheck(name, cur),
+				makeAssign(cur.rows, makeNull(types.MakeArray(cur.rowType))),
+			)
+		case *plpgsqltree.PLpgSQLStmtReturnNext:
+			res = append(res, b.desugarReturnNext(t))
+		case *plpgsqltree.PLpgSQLStmtReturnQuery:
+			res = append(res, b.desugarReturnQuery(t)...)
+		case *plpgsqltree.PLpgSQLStmtNull:
+			// NULL is a no-op.
+		case *plpgsqltree.PLpgSQLStmtExit:
+			if t.Condition == nil {
+				res = append(res, t)
+				break
+			}
+			// EXIT WHEN cond is equivalent to IF cond THEN EXIT; END IF.
+			res = append(res, &plpgsqltree.PLpgSQLStmtIf{
+				Condition: t.Condition,
+				ThenBody:  []plpgsqltree.PLpgSQLStatement{&plpgsqltree.PLpgSQLStmtExit{Label: t.Label}},
+			})
+		case *plpgsqltree.PLpgSQLStmtContinue:
+			if t.Condition == nil {
+				res = append(res, t)
+				break
+			}
+			// CONTINUE WHEN cond is equivalent to IF cond THEN CONTINUE; END IF.
+			res = append(res, &plpgsqltree.PLpgSQLStmtIf{
+				Condition: t.Condition,
+				ThenBody:  []plpgsqltree.PLpgSQLStatement{&plpgsqltree.PLpgSQLStmtContinue{Label: t.Label}},
+			})
+		default:
+			res = append(res, stmt)
+		}
+	}
+	return res
+}
+
+// desugarExceptions returns a copy of the handlers of the given EXCEPTION
+// section with desugared actions. If implicitReturn is true, the actions end
+// the function, so they may need an implicit RETURN statement.
+func (b *plpgsqlBuilder) desugarExceptions(
+	block *plpgsqltree.PLpgSQLExceptionBlock, implicitReturn bool,
+) []*plpgsqltree.PLpgSQLException {
+	exceptions := make([]*plpgsqltree.PLpgSQLException, len(block.ExecList))
+	for i, e := range block.ExecList {
+		action := e.Action
+		if implicitReturn {
+			action = b.addImplicitReturn(action)
+		}
+		exceptions[i] = &plpgsqltree.PLpgSQLException{
+			LineNo:     e.LineNo,
+			Conditions: e.Conditions,
+			Action:     b.desugarStatements(action),
+		}
+	}
+	return exceptions
+}
+
+// desugarBlock declares the variables of the given nested block, and returns
+// a copy of the block with a desugared body and no declarations. The
+// statements that initialize the variables when the block is entered, and that
+// restore the variables they shadow when the block is exited, are recorded
+// for buildBlock.
+func (b *plpgsqlBuilder) desugarBlock(
+	block *plpgsqltree.PLpgSQLStmtBlock,
+) *plpgsqltree.PLpgSQLStmtBlock {
+	vars := &blockVars{}
+	for i := range block.Decls {
+		dec := &block.Decls[i]
+		if isCursorDecl(dec) {
+			// A cursor is closed when the block is entered.
+			if cur := b.declareCursor(dec); cur.rows != "" {
+				vars.entry = append(vars.entry,
+					makeAssign(cur.rows, makeNull(types.MakeArray(cur.rowType))),
+				)
+			}
+			continue
+		}
+		typ := b.resolveDeclType(dec)
+		for _, param := range b.params {
+			if tree.Name(param.Name) == dec.Var {
+				panic(unimplemented.New(
+					"variable shadowing",
+					"PL/pgSQL variables that shadow a function parameter are not yet supported",
+				))
+			}
+		}
+		if prevTyp, ok := b.varTypes[dec.Var]; ok {
+			if !prevTyp.Identical(typ) {
+				panic(unimplemented.New(
+					"variable shadowing",
+					"PL/pgSQL variables that shadow a variable of a different type are not yet supported",
+				))
+			}
+			// The variable shadows a variable of an enclosing block, so its value
+			// is saved when the block is entered and restored when it is exited.
+			saved := b.addHiddenDecl("block_saved", typ)
+			vars.entry = append(vars.entry, makeAssign(saved, makeVarRef(dec.Var)))
+			vars.restore = append(vars.restore, makeAssign(dec.Var, makeVarRef(saved)))
+		} else {
+			b.addImplicitDecl(dec.Var, typ)
+		}
+		init := dec.Expr
+		if init == nil {
+			init = makeNull(typ)
+		}
+		vars.entry = append(vars.entry, makeAssign(dec.Var, init))
+	}
+	newBlock := *block
+	newBlock.Decls = nil
+	newBlock.Body = b.desugarStatements(block.Body)
+	if block.Exceptions != nil {
+		newBlock.Exceptions = &plpgsqltree.PLpgSQLExceptionBlock{
+			ExecList: b.desugarExceptions(block.Exceptions, false /* implicitReturn */),
+		}
+	}
+	b.blocks[&newBlock] = vars
+	return &newBlock
+}
+
+// desugarForIntLoop declares the hidden variables for the given integer FOR
+// loop, and returns a copy of the loop with a desugared body. If scoped is
+// true, the loop variable is only visible within the loop, so its original
+// value is restored once the loop exits. The cleanup statements are executed
+// however control leaves the loop.
+func (b *plpgsqlBuilder) desugarForIntLoop(
+	loop *plpgsqltree.PLpgSQLStmtForIntLoop, scoped bool, cleanup []plpgsqltree.PLpgSQLStatement,
+) *plpgsqltree.PLpgSQLStmtForIntLoop {
+	var vars forLoopVars
+	if scoped {
+		vars.saved = b.addHiddenDecl("for_saved", b.varTypes[loop.Var])
+	}
+	vars.upper = b.addHiddenDecl("for_upper", types.Int)
+	if loop.Step != nil {
+		vars.step = b.addHiddenDecl("for_step", types.Int)
+	}
+	if b.foundDeclared {
+		vars.iterated = b.addHiddenDecl("for_iterated", types.Bool)
+	}
+	vars.cleanup = cleanup
+	newLoop := *loop
+	newLoop.Body = b.desugarStatements(loop.Body)
+	b.forLoops[&newLoop] = vars
+	return &newLoop
+}
+
+// desugarForEachLoop rewrites a FOREACH loop as an integer FOR loop over the
+// indexes of the array, which assigns the current element to the loop
+// variable at the start of each iteration.
+func (b *plpgsqlBuilder) desugarForEachLoop(
+	loop *plpgsqltree.PLpgSQLStmtForEachALoop,
+) []plpgsqltree.PLpgSQLStatement {
+	if loop.Slice != 0 {
+		panic(unimplemented.New(
+			"FOREACH SLICE",
+			"slicing arrays in PL/pgSQL FOREACH loops is not yet supported",
+		))
+	}
+	typ, ok := b.varTypes[loop.Var]
+	if !ok {
+		panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", loop.Var))
+	}
+	arr := b.addHiddenDecl("foreach_array", types.MakeArray(typ))
+	idx := b.addHiddenDecl("foreach_index", types.Int)
+	assignElem := &plpgsqltree.PLpgSQLStmtAssign{
+		Var: loop.Var,
+		Value: &tree.IndirectionExpr{
+			Expr:        makeVarRef(arr),
+			Indirection: tree.ArraySubscripts{{Begin: makeVarRef(idx)}},
+		},
+	}
+	forLoop := &plpgsqltree.PLpgSQLStmtForIntLoop{
+		Label: loop.Label,
+		Var:   idx,
+		Lower: tree.NewDInt(1),
+		Upper: makeArrayLen(makeVarRef(arr)),
+		Body:  append([]plpgsqltree.PLpgSQLStatement{assignElem}, loop.Body...),
+	}
+	return []plpgsqltree.PLpgSQLStatement{
+		&plpgsqltree.PLpgSQLStmtAssign{Var: arr, Value: loop.Expr},
+		b.desugarForIntLoop(forLoop, false /* scoped */, nil /* cleanup */),
+	}
+}
+
+// desugarForQueryLoop rewrites a FOR loop over the rows of a query as an
+// assignment of the rows to a hidden array variable, followed by a loop over
+// the array. See desugarRowLoop.
+func (b *plpgsqlBuilder) desugarForQueryLoop(
+	loop *plpgsqltree.PLpgSQLStmtForQuerySelectLoop,
+) []plpgsqltree.PLpgSQLStatement {
+	rowType := b.queryRowType(loop.Query)
+	rows := b.addHiddenDecl("for_rows", types.MakeArray(rowType))
+	return []plpgsqltree.PLpgSQLStatement{
+		&plpgsqlStmtQueryArray{target: rows, query: loop.Query, elemType: rowType},
+		b.desugarRowLoop(loop.Label, loop.Var, rows, rowType, loop.Body, nil /* cleanup */),
+	}
+}
+
+// desugarForCursorLoop rewrites a FOR loop over the rows of a bound cursor as
+// an OPEN statement, followed by a loop over the rows of the cursor that
+// closes the cursor once the loop exits.
+func (b *plpgsqlBuilder) desugarForCursorLoop(
+	loop *plpgsqltree.PLpgSQLStmtForQueryCursorLoop,
+) []plpgsqltree.PLpgSQLStatement {
+	name := tree.Name(loop.CursorName)
+	cur := b.getCursor(name)
+	if cur.query == nil {
+		panic(pgerror.New(pgcode.Syntax, "cursor FOR loop must use a bound cursor variable"))
+	}
+	if loop.ArgQuery != nil {
+		panic(unimplemented.New(
+			"cursor arguments",
+			"PL/pgSQL cursors with arguments are not yet supported",
+		))
+	}
+	// The loop variable is implicitly declared as a record.
+	b.addImplicitDecl(loop.Var, types.AnyTuple)
+	closeCursor := []plpgsqltree.PLpgSQLStatement{
+		makeAssign(cur.rows, makeNull(types.MakeArray(cur.rowType))),
+	}
+	return append(
+		b.openCursor(name, cur, cur.query),
+		b.desugarRowLoop(loop.Label, loop.Var, cur.rows, cur.rowType, loop.Body, closeCursor),
+	)
+}
+
+// desugarRowLoop returns an integer FOR loop over the indexes of the given
+// array of rows, which assigns the current row to the target variable at the
+// start of each iteration.
+func (b *plpgsqlBuilder) desugarRowLoop(
+	label string,
+	target tree.Name,
+	rows tree.Name,
+	rowType *types.T,
+	body []plpgsqltree.PLpgSQLStatement,
+	cleanup []plpgsqltree.PLpgSQLStatement,
+) *plpgsqltree.PLpgSQLStmtForIntLoop {
+	if _, ok := b.varTypes[target]; !ok {
+		panic(pgerror.New(pgcode.Syntax,
+			"loop variable of loop over rows must be a record variable or list of scalar variables",
+		))
+	}
+	idx := b.addHiddenDecl("for_index", types.Int)
+	row := &tree.IndirectionExpr{
+		Expr:        makeVarRef(rows),
+		Indirection: tree.ArraySubscripts{{Begin: makeVarRef(idx)}},
+	}
+	loop := &plpgsqltree.PLpgSQLStmtForIntLoop{
+		Label: label,
+		Var:   idx,
+		Lower: tree.NewDInt(1),
+		Upper: makeArrayLen(makeVarRef(rows)),
+		Body:  append(b.makeRowAssign([]tree.Name{target}, row, rowType), body...),
+	}
+	return b.desugarForIntLoop(loop, false /* scoped */, cleanup)
+}
+
+// makeRowAssign returns statements that assign the given row, which has the
+// given tuple type, to the target variables. A single target variable with a
+// composite or RECORD type is assigned the entire row. Otherwise, each target
+// variable is assigned the corresponding column of the row, or NULL if the row
+// has too few columns.
+func (b *plpgsqlBuilder) makeRowAssign(
+	targets []tree.Name, row tree.Expr, rowType *types.T,
+) []plpgsqltree.PLpgSQLStatement {
+	stmts := make([]plpgsqltree.PLpgSQLStatement, 0, len(targets))
+	for i, target := range targets {
+		typ, ok := b.varTypes[target]
+		if !ok {
+			panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", target))
+		}
+		if typ.Family() == types.TupleFamily {
+			if len(targets) > 1 {
+				panic(pgerror.New(pgcode.Syntax,
+					"record variable cannot be part of multiple-item INTO list",
+				))
+			}
+			if typ.Identical(types.AnyTuple) {
+				// A RECORD variable takes on the type of the rows assigned to it.
+				b.setVarType(target, rowType)
+			} else if len(typ.TupleContents()) != len(rowType.TupleContents()) {
+				panic(pgerror.Newf(pgcode.DatatypeMismatch,
+					"cannot assign a row with %d columns to variable \"%s\" of type %s",
+					len(rowType.TupleContents()), target, typ.SQLString(),
+				))
+			}
+			stmts = append(stmts, makeAssign(target, row))
+			continue
+		}
+		var val tree.Expr = makeNull(typ)
+		if i < len(rowType.TupleContents()) {
+			val = &tree.ColumnAccessExpr{Expr: &tree.ParenExpr{Expr: row}, ByIndex: true, ColIndex: i}
+		}
+		stmts = append(stmts, makeAssign(target, val))
+	}
+	return stmts
+}
+
+// setVarType changes the type of the given variable. It is used to resolve
+// the type of RECORD variables.
+func (b *plpgsqlBuilder) setVarType(name tree.Name, typ *types.T) {
+	b.varTypes[name] = typ
+	for i := range b.decls {
+		if b.decls[i].Var == name {
+			b.decls[i].Typ = typ
+		}
+	}
+}
+
+// isCursorDecl returns true if the given declaration declares a cursor, either
+// bound to a query or with the REFCURSOR type.
+func isCursorDecl(dec *plpgsqltree.PLpgSQLDecl) bool {
+	if dec.CursorQuery != nil {
+		return true
+	}
+	name, ok := dec.Typ.(*tree.UnresolvedObjectName)
+	return ok && name.NumParts == 1 && strings.ToLower(name.Object()) == "refcursor"
+}
+
+// declareCursor declares the given cursor. A cursor is modeled by two hidden
+// variables: an array that holds the rows of the query, which is NULL if the
+// cursor is not open, and the position of the cursor within the array. The
+// variables of a bound cursor are declared immediately. The variables of an
+// unbound cursor are declared by its first OPEN statement, since the type of
+// the rows is not known until then.
+func (b *plpgsqlBuilder) declareCursor(dec *plpgsqltree.PLpgSQLDecl) *cursorVars {
+	if dec.Expr != nil {
+		panic(unimplemented.New(
+			"cursor initialization",
+			"initializing PL/pgSQL cursor variables is not yet supported",
+		))
+	}
+	if _, ok := b.varTypes[dec.Var]; ok || b.cursors[dec.Var] != nil {
+		panic(unimplemented.New(
+			"cursor shadowing",
+			"PL/pgSQL cursors that shadow another variable are not yet supported",
+		))
+	}
+	cur := &cursorVars{query: dec.CursorQuery}
+	if dec.CursorQuery != nil {
+		checkCursorQuery(dec.CursorQuery)
+		b.ensureCursorVars(cur, b.queryRowType(dec.CursorQuery))
+	}
+	b.cursors[dec.Var] = cur
+	return cur
+}
+
+// getCursor returns the cursor with the given name.
+func (b *plpgsqlBuilder) getCursor(name tree.Name) *cursorVars {
+	cur, ok := b.cursors[name]
+	if !ok {
+		if _, ok := b.varTypes[name]; ok {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"variable \"%s\" must be of type cursor or refcursor", name,
+			))
+		}
+		panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", name))
+	}
+	return cur
+}
+
+// ensureCursorVars declares the hidden variables of the given cursor, unless
+// they have already been declared.
+func (b *plpgsqlBuilder) ensureCursorVars(cur *cursorVars, rowType *types.T) {
+	if cur.rows != "" {
+		if !cur.rowType.Equivalent(rowType) {
+			panic(unimplemented.New(
+				"cursor result types",
+				"opening a PL/pgSQL cursor for queries with different result types is not yet supported",
+			))
+		}
+		return
+	}
+	cur.rowType = rowType
+	cur.rows = b.addHiddenDecl("cursor_rows", types.MakeArray(rowType))
+	cur.pos = b.addHiddenDecl("cursor_pos", types.Int)
+}
+
+// checkCursorQuery returns an error if the given query cannot be used to open
+// a cursor.
+func checkCursorQuery(query tree.Statement) {
+	if _, ok := query.(*tree.Select); !ok {
+		panic(pgerror.Newf(pgcode.InvalidCursorDefinition,
+			"cannot open %s query as cursor", query.StatementTag(),
+		))
+	}
+}
+
+// cursorNotOpenedErr returns the error for a FETCH, MOVE or CLOSE statement
+// for an unbound cursor that is not opened by a preceding OPEN statement.
+func cursorNotOpenedErr(name tree.Name) error {
+	return unimplemented.Newf(
+		"cursor use before OPEN",
+		"using unbound PL/pgSQL cursor \"%s\" before an OPEN statement for it is not yet supported", name,
+	)
+}
+
+// desugarOpen rewrites an OPEN statement as statements that check that the
+// cursor is not already open, assign the rows of the query to the cursor,
+// and move the cursor before the first row.
+func (b *plpgsqlBuilder) desugarOpen(
+	open *plpgsqltree.PLpgSQLStmtOpen,
+) []plpgsqltree.PLpgSQLStatement {
+	name := tree.Name(open.CursorName)
+	cur := b.getCursor(name)
+	if open.DynamicQuery != "" || open.WithExplicitExpr || open.ArgQuery != "" || len(open.Params) > 0 {
+		panic(unimplemented.New(
+			"OPEN with dynamic query or arguments",
+			"opening PL/pgSQL cursors with dynamic queries or arguments is not yet supported",
+		))
+	}
+	query := open.Query
+	if cur.query != nil {
+		if query != nil {
+			panic(pgerror.Newf(pgcode.Syntax, "cursor \"%s\" is already bound to a query", name))
+		}
+		query = cur.query
+	} else {
+		if query == nil {
+			panic(pgerror.New(pgcode.Syntax,
+				"expected FOR to open a cursor for an unbound cursor variable",
+			))
+		}
+		checkCursorQuery(query)
+		b.ensureCursorVars(cur, b.queryRowType(query))
+	}
+	return b.openCursor(name, cur, query)
+}
+
+// openCursor returns the statements that open the given cursor for the given
+// query.
+func (b *plpgsqlBuilder) openCursor(
+	name tree.Name, cur *cursorVars, query tree.Statement,
+) []plpgsqltree.PLpgSQLStatement {
+	return []plpgsqltree.PLpgSQLStatement{
+		&plpgsqltree.PLpgSQLStmtIf{
+			Condition: &tree.IsNotNullExpr{Expr: makeVarRef(cur.rows)},
+			ThenBody: []plpgsqltree.PLpgSQLStatement{makeRaiseError(
+				pgcode.DuplicateCursor, fmt.Sprintf("cursor \"%s\" already in use", name),
+			)},
+		},
+		&plpgsqlStmtQueryArray{target: cur.rows, query: query, elemType: cur.rowType},
+		makeAssign(cur.pos, tree.NewDInt(0)),
+	}
+}
+
+// makeCursorCheck returns a statement that throws an error if the given
+// cursor is not open.
+func (b *plpgsqlBuilder) makeCursorCheck(
+	name tree.Name, cur *cursorVars,
+) plpgsqltree.PLpgSQLStatement {
+	return &plpgsqltree.PLpgSQLStmtIf{
+		Condition: &tree.IsNullExpr{Expr: makeVarRef(cur.rows)},
+		ThenBody: []plpgsqltree.PLpgSQLStatement{makeRaiseError(
+			pgcode.InvalidCursorName, fmt.Sprintf("cursor \"%s\" does not exist", name),
+		)},
+	}
+}
+
+// desugarFetch rewrites a FETCH or MOVE statement as statements that move the
+// position of the cursor, set FOUND, and, for FETCH, assign the row at the new
+// position to the target variables. The position is clamped to the range
+// [0, n+1], where n is the number of rows; positions outside of [1, n] do not
+// have a row, so the target variables are set to NULL.
+func (b *plpgsqlBuilder) desugarFetch(
+	fetch *plpgsqltree.PLpgSQLStmtFetch,
+) []plpgsqltree.PLpgSQLStatement {
+	name := fetch.Cursor.Name
+	cur := b.getCursor(name)
+	if cur.rows == "" {
+		panic(cursorNotOpenedErr(name))
+	}
+	count := fetch.Cursor.Count
+	if !fetch.IsMove {
+		switch fetch.Cursor.FetchType {
+		case tree.FetchAll, tree.FetchBackwardAll:
+			count = 2
+		case tree.FetchRelative, tree.FetchAbsolute, tree.FetchFirst, tree.FetchLast:
+			count = 1
+		}
+		if count > 1 || count < -1 {
+			panic(pgerror.New(pgcode.Syntax, "FETCH statement cannot return multiple rows"))
+		}
+	}
+	plus := func(left, right tree.Expr) tree.Expr {
+		return &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(treebin.Plus),
+			Left:     left,
+			Right:    right,
+		}
+	}
+	numRows := func() tree.Expr { return makeArrayLen(makeVarRef(cur.rows)) }
+	var pos tree.Expr
+	switch fetch.Cursor.FetchType {
+	case tree.FetchNormal, tree.FetchRelative:
+		pos = plus(makeVarRef(cur.pos), tree.NewDInt(tree.DInt(fetch.Cursor.Count)))
+	case tree.FetchAbsolute:
+		if fetch.Cursor.Count >= 0 {
+			pos = tree.NewDInt(tree.DInt(fetch.Cursor.Count))
+		} else {
+			// Negative positions count backward from the end of the rows.
+			pos = plus(numRows(), tree.NewDInt(tree.DInt(fetch.Cursor.Count+1)))
+		}
+	case tree.FetchFirst:
+		pos = tree.NewDInt(1)
+	case tree.FetchLast:
+		pos = numRows()
+	case tree.FetchAll:
+		pos = plus(numRows(), tree.NewDInt(1))
+	case tree.FetchBackwardAll:
+		pos = tree.NewDInt(0)
+	default:
+		panic(errors.AssertionFailedf("unexpected fetch type %v", fetch.Cursor.FetchType))
+	}
+	pos = &tree.FuncExpr{
+		Func: tree.WrapFunction("greatest"),
+		Exprs: tree.Exprs{tree.NewDInt(0), &tree.FuncExpr{
+			Func:  tree.WrapFunction("least"),
+			Exprs: tree.Exprs{pos, plus(numRows(), tree.NewDInt(1))},
+		}},
+	}
+	stmts := []plpgsqltree.PLpgSQLStatement{
+		b.makeCursorCheck(name, cur),
+		makeAssign(cur.pos, pos),
+	}
+	stmts = append(stmts, b.makeSetFound(&tree.AndExpr{
+		Left: &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.GE),
+			Left:     makeVarRef(cur.pos),
+			Right:    tree.NewDInt(1),
+		},
+		Right: &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.LE),
+			Left:     makeVarRef(cur.pos),
+			Right:    numRows(),
+		},
+	})...)
+	if !fetch.IsMove {
+		row := &tree.IndirectionExpr{
+			Expr:        makeVarRef(cur.rows),
+			Indirection: tree.ArraySubscripts{{Begin: makeVarRef(cur.pos)}},
+		}
+		stmts = append(stmts, b.makeRowAssign(fetch.Target, row, cur.rowType)...)
+	}
+	return stmts
+}
+
+// desugarReturnNext rewrites a RETURN NEXT statement as an assignment that
+// appends the returned row to the hidden variable that accumulates the rows
+// returned by a set-returning function.
+func (b *plpgsqlBuilder) desugarReturnNext(
+	ret *plpgsqltree.PLpgSQLStmtReturnNext,
+) plpgsqltree.PLpgSQLStatement {
+	if !b.setReturning {
+		panic(pgerror.New(pgcode.DatatypeMismatch, "cannot use RETURN NEXT in a non-SETOF function"))
+	}
+	row := ret.Expr
+	if len(b.outParams) > 0 {
+		// A function with OUT parameters returns a row with their values.
+		if row != nil {
+			panic(pgerror.New(pgcode.DatatypeMismatch,
+				"RETURN NEXT cannot have a parameter in function with OUT parameters",
+			))
+		}
+		if len(b.outParams) == 1 {
+			row = makeVarRef(b.outParams[0])
+		} else {
+			tuple := &tree.Tuple{
+				Exprs:  make(tree.Exprs, len(b.outParams)),
+				Labels: b.returnType.TupleLabels(),
+			}
+			for i, name := range b.outParams {
+				tuple.Exprs[i] = makeVarRef(name)
+			}
+			row = tuple
+		}
+	} else if row == nil {
+		panic(pgerror.New(pgcode.Syntax, "RETURN NEXT must have a parameter"))
+	}
+	if b.returnType.Family() != types.TupleFamily {
+		row = &tree.CastExpr{Expr: row, Type: b.returnType, SyntaxMode: tree.CastShort}
+	}
+	return makeAssign(b.retVar, &tree.FuncExpr{
+		Func:  tree.WrapFunction("array_append"),
+		Exprs: tree.Exprs{makeVarRef(b.retVar), row},
+	})
+}
+
+// desugarReturnQuery rewrites a RETURN QUERY statement as statements that
+// append the rows of the query to the hidden variable that accumulates the
+// rows returned by a set-returning function, and set FOUND.
+func (b *plpgsqlBuilder) desugarReturnQuery(
+	ret *plpgsqltree.PLpgSQLStmtReturnQuery,
+) []plpgsqltree.PLpgSQLStatement {
+	if !b.setReturning {
+		panic(pgerror.New(pgcode.DatatypeMismatch, "cannot use RETURN QUERY in a non-SETOF function"))
+	}
+	if ret.DynamicQuery != nil {
+		panic(unimplemented.New(
+			"RETURN QUERY EXECUTE",
+			"RETURN QUERY EXECUTE statements are not yet supported",
+		))
+	}
+	rows := b.addHiddenDecl("return_query_rows", b.routineType)
+	stmts := []plpgsqltree.PLpgSQLStatement{
+		&plpgsqlStmtQueryArray{target: rows, query: ret.Query, elemType: b.returnType},
+		makeAssign(b.retVar, &tree.FuncExpr{
+			Func:  tree.WrapFunction("array_cat"),
+			Exprs: tree.Exprs{makeVarRef(b.retVar), makeVarRef(rows)},
+		}),
+	}
+	return append(stmts, b.makeSetFound(&tree.ComparisonExpr{
+		Operator: treecmp.MakeComparisonOperator(treecmp.GT),
+		Left:     makeArrayLen(makeVarRef(rows)),
+		Right:    tree.NewDInt(0),
+	})...)
+}
+
+// makeSetFound returns a statement that assigns the given condition to the
+// implicit FOUND variable, if it has been declared.
+func (b *plpgsqlBuilder) makeSetFound(cond tree.Expr) []plpgsqltree.PLpgSQLStatement {
+	if !b.foundDeclared {
+		return nil
+	}
+	return []plpgsqltree.PLpgSQLStatement{makeAssign(foundVar, cond)}
+}
+
+// addImplicitDecl declares a variable with the given name and type, unless a
+// variable with the same name has already been declared.
+func (b *plpgsqlBuilder) addImplicitDecl(name tree.Name, typ *types.T) {
+	if _, ok := b.varTypes[name]; ok {
+		return
+	}
+	b.decls = append(b.decls, plpgsqltree.PLpgSQLDecl{Var: name, Typ: typ})
+	b.varTypes[name] = typ
+}
+
+// addHiddenDecl declares a variable with a unique name that starts with the
+// given prefix, and returns the name.
+func (b *plpgsqlBuilder) addHiddenDecl(prefix string, typ *types.T) tree.Name {
+	name := tree.Name(b.makeIdentifier(prefix))
+	b.addImplicitDecl(name, typ)
+	return name
+}
+
+// forLoopVars holds the names of the hidden variables that are used to build
+// an integer FOR loop.
+type forLoopVars struct {
+	// upper holds the upper bound of the loop.
+	upper tree.Name
+	// step holds the amount by which the loop variable is incremented. It is
+	// empty if the loop has no BY clause, in which case the step is 1.
+	step tree.Name
+	// saved holds the value that the loop variable had before the loop began.
+	// It is empty if the loop variable is not scoped to the loop.
+	saved tree.Name
+	// iterated is true if the loop iterates at least once, which is the value
+	// of FOUND once the loop exits. It is empty if FOUND is not declared.
+	iterated tree.Name
+	// cleanup holds assignments that are executed however control leaves the
+	// loop.
+	cleanup []plpgsqltree.PLpgSQLStatement
+}
+
+// blockVars holds the assignments that are used to build a nested block.
+type blockVars struct {
+	// entry holds the assignments that initialize the variables of the block,
+	// and save the values of the variables that they shadow.
+	entry []plpgsqltree.PLpgSQLStatement
+	// restore holds the assignments that restore the values of the shadowed
+	// variables.
+	restore []plpgsqltree.PLpgSQLStatement
+}
+
+// cursorVars holds the information needed to build the statements that use a
+// cursor. See declareCursor for details.
+type cursorVars struct {
+	// query is the query that the cursor is bound to. It is nil for unbound
+	// cursors.
+	query tree.Statement
+	// rows holds the rows of the query, or NULL if the cursor is closed. It is
+	// empty until the hidden variables of the cursor are declared.
+	rows tree.Name
+	// pos holds the position of the cursor. The first row is at position 1.
+	pos tree.Name
+	// rowType is the type of each row.
+	rowType *types.T
+}
+
+// blockFrame tracks a loop or block that encloses the statements that are
+// being built.
+type blockFrame struct {
+	// label is the label of the loop or block, if any.
+	label string
+	// isLoop is true if the frame is for a loop, which can be the target of a
+	// CONTINUE statement, or the target of an EXIT statement without a label.
+	isLoop bool
+	// exitCon executes the statements that follow the loop or block.
+	exitCon *continuation
+	// continueCon executes the next iteration of a loop. It is nil for blocks.
+	continueCon *continuation
+	// restore holds the assignments that restore variables scoped to the loop
+	// or block. They are applied when an EXIT or CONTINUE statement leaves the
+	// loop or block. exitCon already includes them.
+	restore []plpgsqltree.PLpgSQLStatement
+
+	// The following fields are only used for blocks with an EXCEPTION section.
+	// See buildBlock.
+
+	// exception is true if the block has an EXCEPTION section.
+	exception bool
+	// typ is the type of the result of the routine that executes the body of
+	// the block.
+	typ *types.T
+	// hasReturn is true if the block body has a RETURN statement.
+	hasReturn bool
+	// jumps builds the remainder of each EXIT or CONTINUE statement in the
+	// block body that targets an enclosing loop or block, once control has
+	// left the routine that executes the body.
+	jumps []func(s *scope) *scope
+}
+
+func (b *plpgsqlBuilder) pushFrame(frame *blockFrame) {
+	b.frames = append(b.frames, frame)
+}
+
+func (b *plpgsqlBuilder) popFrame() {
+	if len(b.frames) > 0 {
+		b.frames = b.frames[:len(b.frames)-1]
+	}
+}
+
+// exceptionFrame returns the innermost enclosing block that has an EXCEPTION
+// section, or nil if there is none.
+func (b *plpgsqlBuilder) exceptionFrame() *blockFrame {
+	for i := len(b.frames) - 1; i >= 0; i-- {
+		if b.frames[i].exception {
+			return b.frames[i]
+		}
+	}
+	return nil
+}
+
+// foundVisitor determines whether a PL/pgSQL function has a statement that
+// sets the implicit FOUND variable.
+type foundVisitor struct {
+	setsFound bool
+}
+
+var _ plpgsqltree.PLpgSQLStmtVisitor = &foundVisitor{}
+
+// Visit is part of the plpgsqltree.PLpgSQLStmtVisitor interface.
+func (v *foundVisitor) Visit(stmt plpgsqltree.PLpgSQLStatement) {
+	switch stmt.(type) {
+	case *plpgsqltree.PLpgSQLStmtForIntLoop, *plpgsqltree.PLpgSQLStmtForEachALoop,
+		*plpgsqltree.PLpgSQLStmtForQuerySelectLoop, *plpgsqltree.PLpgSQLStmtForQueryCursorLoop,
+		*plpgsqltree.PLpgSQLStmtFetch, *plpgsqltree.PLpgSQLStmtReturnQuery:
+		v.setsFound = true
+	}
+}
+
+// plpgsqlStmtQueryArray is a statement produced by desugarStatements that
+// assigns an array with the rows of a query to a variable. See
+// buildQueryArray.
+type plpgsqlStmtQueryArray struct {
+	plpgsqltree.PLpgSQLStatementImpl
+	target   tree.Name
+	query    tree.Statement
+	elemType *types.T
+}
+
+// Format is part of the plpgsqltree.PLpgSQLStatement interface.
+func (s *plpgsqlStmtQueryArray) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&s.target)
+	ctx.WriteString(" := ARRAY(")
+	ctx.FormatNode(s.query)
+	ctx.WriteString(");\n")
+}
+
+// WalkStmt is part of the plpgsqltree.PLpgSQLStatement interface.
+func (s *plpgsqlStmtQueryArray) WalkStmt(visitor plpgsqltree.PLpgSQLStmtVisitor) {
+	visitor.Visit(s)
+}
+
+// makeRaiseCall returns a call to the builtin function that sends a notice or
+// throws an error with the given severity. Any of the expressions except
+// severity may be nil, in which case an empty string is used.
+func makeRaiseCall(severity string, message, detail, hint, code tree.Expr) tree.Expr {
+	args := tree.Exprs{tree.NewStrVal(severity), message, detail, hint, code}
+	for i := range args {
+		if args[i] == nil {
+			args[i] = tree.NewStrVal("")
+		}
+	}
+	return &tree.FuncExpr{Func: tree.WrapFunction("crdb_internal.plpgsql_raise"), Exprs: args}
+}
+
+// makeRaiseError returns a RAISE statement that throws an error with the
+// given code and message.
+func makeRaiseError(code pgcode.Code, message string) *plpgsqltree.PLpgSQLStmtRaise {
+	return &plpgsqltree.PLpgSQLStmtRaise{
+		LogLevel: "EXCEPTION",
+		Options: []plpgsqltree.PLpgSQLStmtRaiseOption{
+			{OptType: plpgsqltree.PLpgSQLRaiseOptionMessage, Expr: tree.NewStrVal(message)},
+			{OptType: plpgsqltree.PLpgSQLRaiseOptionErrCode, Expr: tree.NewStrVal(code.String())},
+		},
+	}
+}
+
+// makeAssign returns a statement that assigns the given value to the given
+// variable.
+func makeAssign(name tree.Name, value tree.Expr) *plpgsqltree.PLpgSQLStmtAssign {
+	return &plpgsqltree.PLpgSQLStmtAssign{Var: name, Value: value}
+}
+
+// makeVarRef returns a reference to the PL/pgSQL variable with the given name.
+func makeVarRef(name tree.Name) tree.Expr {
+	return &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{string(name)}}
+}
+
+// makeNull returns a NULL value of the given type.
+func makeNull(typ *types.T) tree.Expr {
+	return &tree.CastExpr{Expr: tree.DNull, Type: typ}
+}
+
+// makeArrayLen returns an expression for the length of the given array, which
+// is zero if the array is empty or NULL.
+func makeArrayLen(arr tree.Expr) tree.Expr {
+	return &tree.CoalesceExpr{
+		Name: "COALESCE",
+		Exprs: tree.Exprs{
+			&tree.FuncExpr{
+				Func:  tree.WrapFunction("array_length"),
+				Exprs: tree.Exprs{arr, tree.NewDInt(1)},
+			},
+			tree.NewDInt(0),
+		},
+	}
+}
+
+// makeIsNotTrue returns an expression that is true if the given condition is
+// false or NULL.
+func makeIsNotTrue(cond tree.Expr) tree.Expr {
+	return &tree.ComparisonExpr{
+		Operator: treecmp.MakeComparisonOperator(treecmp.IsDistinctFrom),
+		Left:     &tree.ParenExpr{Expr: cond},
+		Right:    tree.DBoolTrue,
+	}
+}
+
 // continuation holds the information necessary to pick up execution from some
 // branching point in the control flow.
 type continuation struct {
@@ -513,9 +2408,10 @@ type continuation struct {
 	// from a branch in the control flow.
 	def *memo.UDFDefinition
 
-	// isLoopContinuation indicates that this continuation was constructed for the
-	// body statements of a loop.
-	isLoopContinuation bool
+	// frame is set for the continuation that ends the body of a block with an
+	// EXCEPTION section. Rather than calling a routine, it returns from the
+	// routine that executes the body. In this case def is nil.
+	frame *blockFrame
 
 	// reachedEndOfFunction indicates that the statements used to define this
 	// continuation did not return from at least one path in the control flow.
@@ -540,29 +2436,3 @@ func (b *plpgsqlBuilder) getContinuation() *continuation {
 	}
 	return &b.continuations[len(b.continuations)-1]
 }
-
-func (b *plpgsqlBuilder) pushExitContinuation(con continuation) {
-	b.exitContinuations = append(b.exitContinuations, con)
-}
-
-func (b *plpgsqlBuilder) popExitContinuation() {
-	if len(b.exitContinuations) > 0 {
-		b.exitContinuations = b.exitContinuations[:len(b.exitContinuations)-1]
-	}
-}
-
-func (b *plpgsqlBuilder) getExitContinuation() *continuation {
-	if len(b.exitContinuations) == 0 {
-		return nil
-	}
-	return &b.exitContinuations[len(b.exitContinuations)-1]
-}
-
-func (b *plpgsqlBuilder) getLoopContinuation() *continuation {
-	for i := len(b.continuations) - 1; i >= 0; i-- {
-		if b.continuations[i].isLoopContinuation {
-			return &b.continuations[i]
-		}
-	}
-	return nil
-}
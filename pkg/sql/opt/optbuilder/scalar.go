@@ -121,6 +121,13 @@ func (b *Builder) buildScalar(
 		}
 		return b.finishBuildScalarRef(t.col, aggOutScope, outScope, outCol, colRefs)
 
+	case *groupingFuncInfo:
+		var aggOutScope *scope
+		if inScope.groupby != nil {
+			aggOutScope = inScope.groupby.aggOutScope
+		}
+		return b.finishBuildScalarRef(t.col, aggOutScope, outScope, outCol, colRefs)
+
 	case *windowInfo:
 		return b.finishBuildScalarRef(t.col, inScope, outScope, outCol, colRefs)
 
@@ -551,6 +558,12 @@ func (b *Builder) buildFunction(
 	}
 
 	overload := f.ResolvedOverload()
+	if overload.IsProcedure {
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is a procedure", def.Name),
+			"To call a procedure, use CALL.",
+		))
+	}
 	if overload.HasSQLBody() {
 		return b.buildUDF(f, def, inScope, outScope, outCol, colRefs)
 	}
@@ -648,7 +661,7 @@ func (b *Builder) buildUDF(
 	// determine the types from the result columns or tuple of the last
 	// statement.
 	finishResolveType := func(lastStmtScope *scope) *types.T {
-		if types.IsRecordType(rtyp) {
+		if o.ReturnsRecordType {
 			if len(lastStmtScope.cols) == 1 &&
 				lastStmtScope.cols[0].typ.Family() == types.TupleFamily {
 				// When the final statement returns a single tuple, we can use the
@@ -683,6 +696,17 @@ func (b *Builder) buildUDF(
 			)
 		}
 	}
+	paramTypes := o.RoutineParams()
+	if o.VariadicParams != nil && !f.Variadic {
+		// Collect the trailing arguments into an array which is bound to the
+		// VARIADIC parameter. If the last argument is marked VARIADIC, it is
+		// already an array, which is bound to the parameter directly.
+		numFixed := len(paramTypes) - 1
+		elems := make(memo.ScalarListExpr, len(args)-numFixed)
+		copy(elems, args[numFixed:])
+		args = append(args[:numFixed:numFixed],
+			b.factory.ConstructArray(elems, paramTypes[numFixed].Typ))
+	}
 
 	// Create a new scope for building the statements in the function body. We
 	// start with an empty scope because a statement in the function body cannot
@@ -694,12 +718,7 @@ func (b *Builder) buildUDF(
 	// CTEs that mutate and are not at the top-level.
 	bodyScope := b.allocScope()
 	var params opt.ColList
-	if o.Types.Length() > 0 {
-		paramTypes, ok := o.Types.(tree.ParamTypes)
-		if !ok {
-			panic(unimplemented.NewWithIssue(88947,
-				"variadiac user-defined functions are not yet supported"))
-		}
+	if len(paramTypes) > 0 {
 		params = make(opt.ColList, len(paramTypes))
 		for i := range paramTypes {
 			paramType := &paramTypes[i]
@@ -751,11 +770,14 @@ func (b *Builder) buildUDF(
 			panic(err)
 		}
 		var plBuilder plpgsqlBuilder
-		plBuilder.init(b, colRefs, o.Types.(tree.ParamTypes), stmt.AST, rtyp)
+		plBuilder.init(b, colRefs, paramTypes, o.OutParams, stmt.AST, rtyp, isSetReturning)
 		stmtScope := plBuilder.build(stmt.AST, bodyScope)
-		b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
-		body = []memo.RelExpr{stmtScope.expr}
-		bodyProps = []*physical.Required{stmtScope.makePhysicalProps()}
+		var expr memo.RelExpr
+		var physProps *physical.Required
+		expr, physProps, isMultiColDataSource =
+			b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
+		body = []memo.RelExpr{expr}
+		bodyProps = []*physical.Required{physProps}
 	default:
 		panic(errors.AssertionFailedf("unexpected language: %v", o.Language))
 	}
@@ -782,8 +804,6 @@ func (b *Builder) buildUDF(
 	// Synthesize an output columns if necessary.
 	if outCol == nil {
 		if isMultiColDataSource {
-			// TODO(harding): Add the returns record property during create function.
-			f.ResolvedOverload().ReturnsRecordType = types.IsRecordType(rtyp)
 			return b.finishBuildGeneratorFunction(f, f.ResolvedOverload(), out, inScope, outScope, outCol)
 		}
 		if outScope != nil {
@@ -817,6 +837,18 @@ func (b *Builder) finishBuildLastStmt(
 		physProps.Ordering = props.OrderingChoice{}
 	}
 
+	// The result of the last statement is discarded by routines that return
+	// VOID, such as procedures without output parameters.
+	if rtyp.Family() == types.VoidFamily && (len(physProps.Presentation) != 1 ||
+		b.factory.Metadata().ColumnMeta(physProps.Presentation[0].ID).Type.Family() != types.VoidFamily) {
+		stmtScope = bodyScope.push()
+		col := b.synthesizeColumn(
+			stmtScope, scopeColName(""), rtyp, nil /* expr */, b.factory.ConstructNull(rtyp),
+		)
+		expr = b.constructProject(expr, []scopeColumn{*col})
+		return expr, stmtScope.makePhysicalProps(), false /* isMultiColDataSource */
+	}
+
 	// Only a single column can be returned from a UDF, unless it is used as a
 	// data source. Data sources may output multiple columns, and if the
 	// statement body produces a tuple it needs to be expanded into columns.
@@ -1052,6 +1084,8 @@ func (b *Builder) constructComparison(
 		return b.factory.ConstructJsonAllExists(left, right)
 	case treecmp.JSONSomeExists:
 		return b.factory.ConstructJsonSomeExists(left, right)
+	case treecmp.JSONPathExists:
+		return b.factory.ConstructJsonPathExists(left, right)
 	case treecmp.Overlaps:
 		leftFam, rightFam := cmp.Op.LeftType.Family(), cmp.Op.RightType.Family()
 		if (leftFam == types.GeometryFamily || leftFam == types.Box2DFamily) &&
@@ -1105,6 +1139,8 @@ func (b *Builder) constructBinary(
 		return b.factory.ConstructFetchValPath(left, right)
 	case treebin.JSONFetchTextPath:
 		return b.factory.ConstructFetchTextPath(left, right)
+	case treebin.Distance:
+		return b.factory.ConstructDistance(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled binary operator: %s", redact.Safe(bin)))
 }
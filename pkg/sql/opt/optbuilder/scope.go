@@ -129,12 +129,14 @@ const (
 	exprKindHaving
 	exprKindLateralJoin
 	exprKindLimit
+	exprKindMergeWhen
 	exprKindOffset
 	exprKindOn
 	exprKindOrderBy
 	exprKindReturning
 	exprKindSelect
 	exprKindStoreID
+	exprKindTableSample
 	exprKindValues
 	exprKindWhere
 	exprKindWindowFrameStart
@@ -150,12 +152,14 @@ var exprKindName = [...]string{
 	exprKindHaving:            "HAVING",
 	exprKindLateralJoin:       "LATERAL JOIN",
 	exprKindLimit:             "LIMIT",
+	exprKindMergeWhen:         "MERGE WHEN",
 	exprKindOffset:            "OFFSET",
 	exprKindOn:                "ON",
 	exprKindOrderBy:           "ORDER BY",
 	exprKindReturning:         "RETURNING",
 	exprKindSelect:            "SELECT",
 	exprKindStoreID:           "RELOCATE STORE ID",
+	exprKindTableSample:       "TABLESAMPLE",
 	exprKindValues:            "VALUES",
 	exprKindWhere:             "WHERE",
 	exprKindWindowFrameStart:  "WINDOW FRAME START",
@@ -1080,6 +1084,9 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 			break
 		}
 
+	case *tree.GroupingFuncExpr:
+		expr = s.replaceGroupingFunc(t)
+
 	case *tree.ArrayFlatten:
 		if sub, ok := t.Subquery.(*tree.Subquery); ok {
 			// Copy the ArrayFlatten expression so that the tree isn't mutated.
@@ -1168,6 +1175,58 @@ func (s *scope) replaceSRF(f *tree.FuncExpr, def *tree.ResolvedFunctionDefinitio
 	return srf
 }
 
+// replaceGroupingFunc returns a groupingFuncInfo that can be used to replace a
+// GROUPING() expression. When a groupingFuncInfo is encountered during the
+// build process, it is replaced with a reference to the column that the
+// aggregation computes for it.
+//
+// replaceGroupingFunc also stores the groupingFuncInfo in the groupby of this
+// scope. The arguments are matched against the GROUP BY expressions once the
+// grouping columns have been built (see buildGroupingColumns).
+func (s *scope) replaceGroupingFunc(f *tree.GroupingFuncExpr) tree.Expr {
+	if s.builder.semaCtx.Properties.IsSet(tree.RejectNestedAggregates) {
+		panic(pgerror.Newf(pgcode.Grouping, "aggregate function calls cannot contain GROUPING()"))
+	}
+	if s.builder.semaCtx.Properties.IsSet(tree.RejectAggregates) {
+		panic(pgerror.Newf(pgcode.Grouping, "GROUPING() is not allowed in %s", s.context))
+	}
+	switch s.context {
+	case exprKindWhere, exprKindOn, exprKindLateralJoin:
+		panic(pgerror.Newf(pgcode.Grouping, "GROUPING() is not allowed in %s", s.context))
+	}
+	if len(f.Exprs) > maxGroupingFuncArgs {
+		panic(pgerror.Newf(pgcode.TooManyArguments,
+			"GROUPING must have fewer than %d arguments", maxGroupingFuncArgs+1,
+		))
+	}
+
+	// We need to save and restore the previous value of the field in
+	// semaCtx in case we are recursively called within a subquery
+	// context.
+	defer s.builder.semaCtx.Properties.Restore(s.builder.semaCtx.Properties)
+	s.builder.semaCtx.Properties.Require("GROUPING", tree.RejectSpecial)
+
+	info := &groupingFuncInfo{
+		GroupingFuncExpr: f,
+		args:             make([]tree.TypedExpr, len(f.Exprs)),
+	}
+	for i, e := range f.Exprs {
+		info.args[i] = s.resolveType(e, types.Any)
+	}
+
+	if s.groupby == nil {
+		s.initGrouping()
+	}
+	info.col = &scopeColumn{
+		name: scopeColName("grouping"),
+		typ:  types.Int,
+		id:   s.builder.factory.Metadata().AddColumn("grouping", types.Int),
+		expr: info,
+	}
+	s.groupby.groupingFuncs = append(s.groupby.groupingFuncs, info)
+	return info
+}
+
 // isOrderedSetAggregate returns if the input function definition is an
 // ordered-set aggregate, and the overridden function definition if so.
 func isOrderedSetAggregate(
@@ -1224,6 +1283,13 @@ func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.ResolvedFunctionDef
 
 	// Make a copy of f so we can modify it if needed.
 	fCopy := *f
+	// A call of the form agg(*) invokes a user-defined aggregate that takes no
+	// arguments.
+	if len(fCopy.Exprs) == 1 && isUDFOnly(def) {
+		if _, ok := fCopy.Exprs[0].(tree.UnqualifiedStar); ok {
+			fCopy.Exprs = nil
+		}
+	}
 	// Override ordered-set aggregates to use their impl counterparts.
 	if orderedSetDef, found := isOrderedSetAggregate(def); found {
 		// Ensure that the aggregation is well formed.
@@ -1285,6 +1351,10 @@ func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.ResolvedFunctionDef
 		Overload:   f.ResolvedOverload(),
 	}
 
+	if private.Overload.UserDefinedAggregate != nil {
+		packVariadicAggregateArgs(f)
+	}
+
 	return s.builder.buildAggregateFunction(f, &private, tempScope, s)
 }
 
@@ -1338,6 +1408,13 @@ func (s *scope) replaceWindowFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefi
 	fCopy := *f
 	newWindowDef := s.constructWindowDef(*f.WindowDef)
 	fCopy.WindowDef = &newWindowDef
+	// A call of the form agg(*) invokes a user-defined aggregate that takes no
+	// arguments.
+	if len(fCopy.Exprs) == 1 && isUDFOnly(def) {
+		if _, ok := fCopy.Exprs[0].(tree.UnqualifiedStar); ok {
+			fCopy.Exprs = nil
+		}
+	}
 
 	expr := fCopy.Walk(s)
 
@@ -1351,6 +1428,10 @@ func (s *scope) replaceWindowFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefi
 
 	f = typedFunc.(*tree.FuncExpr)
 
+	if f.ResolvedOverload().UserDefinedAggregate != nil {
+		packVariadicAggregateArgs(f)
+	}
+
 	// We will be performing type checking on expressions from PARTITION BY and
 	// ORDER BY clauses below, and we need the semantic context to know that we
 	// are in a window function. InWindowFunc is updated when type checking
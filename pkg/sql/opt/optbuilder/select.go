@@ -89,7 +89,12 @@ func (b *Builder) buildDataSource(
 			locking = locking.filter(source.As.Alias)
 		}
 
-		outScope = b.buildDataSource(source.Expr, indexFlags, locking, inScope)
+		if source.Sample != nil {
+			outScope = b.buildTableSample(source, indexFlags, locking, inScope)
+		} else {
+			b.onlyTable = source.Only
+			outScope = b.buildDataSource(source.Expr, indexFlags, locking, inScope)
+		}
 
 		if source.Ordinality {
 			outScope = b.buildWithOrdinality(outScope)
@@ -105,6 +110,8 @@ func (b *Builder) buildDataSource(
 
 	case *tree.TableName:
 		tn := source
+		only := b.onlyTable
+		b.onlyTable = false
 
 		// CTEs take precedence over other data sources.
 		if cte := inScope.resolveCTE(tn); cte != nil {
@@ -143,6 +150,21 @@ func (b *Builder) buildDataSource(
 
 		switch t := ds.(type) {
 		case cat.Table:
+			if t.InheritedByCount() > 0 && !only {
+				// The tables inheriting from the table would not be recorded as
+				// dependencies of views and functions.
+				if b.insideViewDef || b.insideFuncDef {
+					panic(errors.WithHint(
+						unimplementedWithIssueDetailf(
+							22456, "inheriting table in view or function",
+							"referencing table %q, which has inheriting tables, in a view or "+
+								"function definition is not supported", tn.ObjectName,
+						),
+						"Use ONLY to only reference the rows of the table itself.",
+					))
+				}
+				return b.buildInheritanceScan(t, &resName, indexFlags, locking, inScope)
+			}
 			tabMeta := b.addTable(t, &resName)
 			return b.buildScan(
 				tabMeta,
@@ -368,6 +390,87 @@ func (b *Builder) buildView(
 	return outScope
 }
 
+// buildInheritanceScan builds a scan of the given table which also returns the
+// rows of the tables inheriting from it, directly or not, by combining the
+// scans of all these tables with UNION ALL. Only the visible columns of the
+// table are returned.
+func (b *Builder) buildInheritanceScan(
+	tab cat.Table,
+	tabName *tree.TableName,
+	indexFlags *tree.IndexFlags,
+	locking lockingSpec,
+	inScope *scope,
+) (outScope *scope) {
+	outScope = b.buildScan(
+		b.addTable(tab, tabName),
+		tableOrdinals(tab, columnKinds{}),
+		indexFlags, locking, inScope,
+		false, /* disableNotVisibleIndex */
+	)
+	outScope.removeHiddenCols()
+
+	seen := map[cat.StableID]struct{}{tab.ID(): {}}
+	for queue := []cat.Table{tab}; len(queue) > 0; queue = queue[1:] {
+		parent := queue[0]
+		for i, n := 0, parent.InheritedByCount(); i < n; i++ {
+			id := parent.InheritedBy(i)
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			ds, _, err := b.catalog.ResolveDataSourceByID(b.ctx, cat.Flags{}, id)
+			if err != nil {
+				panic(err)
+			}
+			child, ok := ds.(cat.Table)
+			if !ok {
+				panic(errors.AssertionFailedf("inheriting data source %q is not a table", ds.Name()))
+			}
+			// The rows of the tables inheriting from the table are read with the
+			// same privileges as the rows of the table itself.
+			b.checkPrivilege(opt.DepByID(id), child, privilege.SELECT)
+			if locking.isSet() {
+				b.checkPrivilege(opt.DepByID(id), child, privilege.UPDATE)
+			}
+			queue = append(queue, child)
+
+			childName := tree.MakeUnqualifiedTableName(child.Name())
+			childScope := b.buildScan(
+				b.addTable(child, &childName),
+				tableOrdinals(child, columnKinds{}),
+				nil /* indexFlags */, locking, inScope,
+				false, /* disableNotVisibleIndex */
+			)
+			// Project the columns of the child in the order of the columns of
+			// the table.
+			projectionsScope := childScope.push()
+			for j := range outScope.cols {
+				name := outScope.cols[j].name.ReferenceName()
+				var col *scopeColumn
+				for k := range childScope.cols {
+					if c := &childScope.cols[k]; c.visibility == visible && c.name.MatchesReferenceName(name) {
+						col = c
+						break
+					}
+				}
+				if col == nil {
+					panic(pgerror.Newf(pgcode.UndefinedColumn,
+						"column %q does not exist in table %q inheriting from %q",
+						name, child.Name(), tab.Name()))
+				}
+				projectionsScope.cols = append(projectionsScope.cols, *col)
+			}
+			b.constructProjectForScope(childScope, projectionsScope)
+			outScope = b.buildSetOp(tree.UnionOp, true /* all */, inScope, outScope, projectionsScope)
+		}
+	}
+
+	for i := range outScope.cols {
+		outScope.cols[i].table = *tabName
+	}
+	return outScope
+}
+
 // renameSource applies an AS clause to the columns in scope.
 func (b *Builder) renameSource(as tree.AliasClause, scope *scope) {
 	if as.Alias != "" {
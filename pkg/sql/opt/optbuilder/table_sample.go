@@ -0,0 +1,173 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+const tableSampleBernoulliFn = "crdb_internal.tablesample_bernoulli"
+
+// buildTableSample builds a scan of the table named by the given table
+// expression, and applies its TABLESAMPLE clause to the scan:
+//
+//   - BERNOULLI sampling is built as a filter that selects each row with the
+//     given probability. With a REPEATABLE clause, the choice is a function of
+//     the seed and the primary key of the row.
+//   - SYSTEM sampling is pushed into the scan, which only reads the sampled
+//     ranges of the table and skips the others entirely.
+//
+// See Builder.buildStmt for a description of the remaining input and return
+// values.
+func (b *Builder) buildTableSample(
+	source *tree.AliasedTableExpr,
+	indexFlags *tree.IndexFlags,
+	locking lockingSpec,
+	inScope *scope,
+) (outScope *scope) {
+	sample := source.Sample
+	tn, ok := source.Expr.(*tree.TableName)
+	if !ok {
+		panic(errors.AssertionFailedf("unexpected TABLESAMPLE source %T", source.Expr))
+	}
+	errNotTable := pgerror.New(pgcode.WrongObjectType,
+		"TABLESAMPLE clause can only be applied to tables and materialized views")
+	if cte := inScope.resolveCTE(tn); cte != nil {
+		panic(errNotTable)
+	}
+
+	ds, depName, resName := b.resolveDataSource(tn, privilege.SELECT)
+	locking = locking.filter(tn.ObjectName)
+	if locking.isSet() {
+		// SELECT ... FOR [KEY] UPDATE/SHARE also requires UPDATE privileges.
+		b.checkPrivilege(depName, ds, privilege.UPDATE)
+	}
+	tab, ok := ds.(cat.Table)
+	if !ok || tab.IsVirtualTable() {
+		panic(errNotTable)
+	}
+
+	percentDatum := b.buildTableSampleArg(sample.Percent, inScope)
+	if percentDatum == tree.DNull {
+		panic(pgerror.New(pgcode.InvalidTablesampleArgument, "TABLESAMPLE parameter cannot be null"))
+	}
+	percent := float64(tree.MustBeDFloat(percentDatum))
+	if math.IsNaN(percent) || percent < 0 || percent > 100 {
+		panic(pgerror.New(pgcode.InvalidTablesampleArgument, "sample percentage must be between 0 and 100"))
+	}
+	var seed int64
+	repeatable := sample.Seed != nil
+	if repeatable {
+		seedDatum := b.buildTableSampleArg(sample.Seed, inScope)
+		if seedDatum == tree.DNull {
+			panic(pgerror.New(pgcode.InvalidTablesampleRepeat, "TABLESAMPLE REPEATABLE parameter cannot be null"))
+		}
+		// Like Postgres, we accept any float as a seed. Seeds with a fractional
+		// part are mapped onto an integer using their bit representation.
+		if f := float64(tree.MustBeDFloat(seedDatum)); f == math.Trunc(f) && math.Abs(f) < math.MaxInt64 {
+			seed = int64(f)
+		} else {
+			seed = int64(math.Float64bits(f))
+		}
+	}
+
+	tabMeta := b.addTable(tab, &resName)
+	outScope = b.buildScan(
+		tabMeta,
+		tableOrdinals(tab, columnKinds{
+			includeMutations: false,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags, locking, inScope,
+		false, /* disableNotVisibleIndex */
+	)
+
+	switch sample.Method {
+	case tree.TableSampleSystem:
+		// Rebuild the scan with the sample, preserving the projection of virtual
+		// computed columns (if any) on top of it.
+		var proj *memo.ProjectExpr
+		input := outScope.expr
+		if p, ok := input.(*memo.ProjectExpr); ok {
+			proj = p
+			input = p.Input
+		}
+		scan, ok := input.(*memo.ScanExpr)
+		if !ok {
+			panic(errors.AssertionFailedf("expected a scan, found %T", input))
+		}
+		private := scan.ScanPrivate
+		private.Sample = opt.TableSample{
+			System:     true,
+			Percent:    percent,
+			Repeatable: repeatable,
+			Seed:       seed,
+		}
+		outScope.expr = b.factory.ConstructScan(&private)
+		if proj != nil {
+			outScope.expr = b.factory.ConstructProject(outScope.expr, proj.Projections, proj.Passthrough)
+		}
+
+	case tree.TableSampleBernoulli:
+		args := memo.ScalarListExpr{
+			b.factory.ConstructConstVal(tree.NewDFloat(tree.DFloat(percent)), types.Float),
+		}
+		if repeatable {
+			args = append(args, b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(seed)), types.Int))
+			primary := tab.Index(cat.PrimaryIndex)
+			for i, n := 0, primary.KeyColumnCount(); i < n; i++ {
+				colID := tabMeta.MetaID.ColumnID(primary.Column(i).Ordinal())
+				args = append(args, b.factory.ConstructVariable(colID))
+			}
+		} else {
+			args = append(args, b.factory.ConstructNull(types.Int))
+		}
+		props, overloads := builtinsregistry.GetBuiltinProperties(tableSampleBernoulliFn)
+		fn := b.factory.ConstructFunction(args, &memo.FunctionPrivate{
+			Name:       tableSampleBernoulliFn,
+			Typ:        types.Bool,
+			Properties: props,
+			Overload:   &overloads[0],
+		})
+		outScope.expr = b.factory.ConstructSelect(
+			outScope.expr, memo.FiltersExpr{b.factory.ConstructFiltersItem(fn)},
+		)
+
+	default:
+		panic(errors.AssertionFailedf("unexpected TABLESAMPLE method %s", sample.Method))
+	}
+	return outScope
+}
+
+// buildTableSampleArg builds an argument of a TABLESAMPLE clause, which must
+// evaluate to a constant float.
+func (b *Builder) buildTableSampleArg(expr tree.Expr, inScope *scope) tree.Datum {
+	scalar := b.resolveAndBuildScalar(
+		expr, types.Float, exprKindTableSample, tree.RejectSpecial, inScope,
+	)
+	if !memo.CanExtractConstDatum(scalar) {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"TABLESAMPLE arguments must be constant expressions"))
+	}
+	return memo.ExtractConstDatum(scalar)
+}
@@ -344,6 +344,8 @@ func (mb *mutationBuilder) buildUpdate(returning *tree.ReturningExprs) {
 
 	mb.buildUniqueChecksForUpdate()
 
+	mb.buildExclusionChecksForUpdate()
+
 	mb.buildFKChecksForUpdate()
 
 	private := mb.makeMutationPrivate(returning != nil)
@@ -0,0 +1,111 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/lib/pq/oid"
+)
+
+// buildAggregateSupport builds calls to the support functions of a
+// user-defined aggregate, which are invoked as routines by the execution
+// engine. argTypes are the types of the aggregate's arguments.
+func (b *Builder) buildAggregateSupport(
+	def *tree.UserDefinedAggregate, argTypes []*types.T,
+) *memo.AggSupportFuncs {
+	support := &memo.AggSupportFuncs{
+		StateType:    def.StateType,
+		InitialState: tree.DNull,
+	}
+
+	transitionParams := make([]*types.T, 0, len(argTypes)+1)
+	transitionParams = append(transitionParams, def.StateType)
+	transitionParams = append(transitionParams, argTypes...)
+	support.Transition = b.buildAggregateSupportFunc(def.TransitionFunc, transitionParams)
+	if def.FinalFunc != 0 {
+		support.Final = b.buildAggregateSupportFunc(def.FinalFunc, []*types.T{def.StateType})
+	}
+
+	if def.InitCond != nil {
+		d, _, err := tree.ParseAndRequireString(def.StateType, *def.InitCond, b.evalCtx)
+		if err != nil {
+			panic(err)
+		}
+		support.InitialState = d
+	}
+	return support
+}
+
+// buildAggregateSupportFunc builds a call to the support function with the
+// given OID. A column is synthesized for each of its parameters, which is
+// bound to the corresponding argument when the function is invoked.
+func (b *Builder) buildAggregateSupportFunc(
+	fnOID oid.Oid, paramTypes []*types.T,
+) memo.AggSupportFunc {
+	paramScope := b.allocScope()
+	for _, typ := range paramTypes {
+		b.synthesizeColumn(paramScope, scopeColName(""), typ, nil /* expr */, nil /* scalar */)
+	}
+	params := make(opt.ColList, len(paramScope.cols))
+	exprs := make(tree.Exprs, len(paramScope.cols))
+	for i := range paramScope.cols {
+		params[i] = paramScope.cols[i].id
+		exprs[i] = &paramScope.cols[i]
+	}
+
+	defer b.semaCtx.Properties.Restore(b.semaCtx.Properties)
+	b.semaCtx.Properties.Require("aggregate support function", tree.RejectSpecial)
+	call := &tree.FuncExpr{
+		Func:  tree.ResolvableFunctionReference{FunctionReference: &tree.FunctionOID{OID: fnOID}},
+		Exprs: exprs,
+	}
+	typed, err := tree.TypeCheck(b.ctx, call, b.semaCtx, types.Any)
+	if err != nil {
+		panic(err)
+	}
+
+	// Build the call without inlining the function body, so that it is always
+	// invoked as a routine.
+	var out opt.ScalarExpr
+	b.factory.DisableOptimizationRulesTemporarily(intsets.MakeFast(int(opt.InlineUDF)), func() {
+		out = b.buildScalar(typed, paramScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	})
+	return memo.AggSupportFunc{
+		Call:   out,
+		Params: params,
+		Strict: !typed.(*tree.FuncExpr).ResolvedOverload().CalledOnNullInput,
+	}
+}
+
+// packVariadicAggregateArgs collects the trailing arguments of a call to a
+// user-defined aggregate with a VARIADIC parameter into an array, which is
+// passed to the transition function like any other argument. If the last
+// argument is marked VARIADIC, it is already an array and is left as is.
+func packVariadicAggregateArgs(f *tree.FuncExpr) {
+	params := f.ResolvedOverload().VariadicParams
+	if params == nil || f.Variadic {
+		return
+	}
+	numFixed := len(params) - 1
+	elems := make(tree.TypedExprs, len(f.Exprs)-numFixed)
+	for i := range elems {
+		elems[i] = f.Exprs[numFixed+i].(tree.TypedExpr)
+	}
+	exprs := make(tree.Exprs, numFixed, numFixed+1)
+	copy(exprs, f.Exprs[:numFixed])
+	f.Exprs = append(exprs, tree.NewTypedArray(elems, params[numFixed].Typ))
+	// Mark the call as VARIADIC so that it type-checks again if necessary.
+	f.Variadic = true
+}
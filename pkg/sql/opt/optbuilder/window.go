@@ -143,7 +143,12 @@ func (b *Builder) buildWindow(outScope *scope, inScope *scope) {
 
 		frameIdx := b.findMatchingFrameIndex(&frames, partitions[i], orderings[i])
 
-		fn := b.constructWindowFn(w.def.Name, argLists[i])
+		var fn opt.ScalarExpr
+		if w.def.Overload.UserDefinedAggregate != nil {
+			fn = b.constructUserDefinedAggregate(&w.def, w.FuncExpr, argLists[i])
+		} else {
+			fn = b.constructWindowFn(w.def.Name, argLists[i])
+		}
 
 		if windowFrames[i].Bounds.StartBound.OffsetExpr != nil {
 			fn = b.factory.ConstructWindowFromOffset(
@@ -255,9 +260,15 @@ func (b *Builder) buildAggregationAsWindow(
 	filterCols := make([]opt.ColumnID, len(g.aggs))
 
 	// Construct the pre-projection, which renders the grouping columns and the
-	// aggregate arguments, as well as any additional order by columns.
+	// aggregate arguments, as well as any additional order by columns. With
+	// grouping sets, the pre-projection is constructed by
+	// constructGroupingSetsInput once the arguments have been built.
 	g.aggInScope.appendColumnsFromScope(fromScope)
-	b.constructProjectForScope(fromScope, g.aggInScope)
+	groupingSets := len(g.groupingSets) > 1
+	if !groupingSets {
+		b.constructProjectForScope(fromScope, g.aggInScope)
+	}
+	ords := make([]opt.Ordering, len(g.aggs))
 
 	// Build the arguments, partitions and orderings for each aggregate.
 	for i, agg := range g.aggs {
@@ -271,8 +282,7 @@ func (b *Builder) buildAggregationAsWindow(
 
 		// Build appropriate orderings.
 		if !agg.isCommutative() {
-			ord := b.buildWindowOrdering(agg.OrderBy, i, agg.def.Name, fromScope, g.aggInScope, false /* isRangeModeWithOffsets */)
-			orderings[i].FromOrdering(ord)
+			ords[i] = b.buildWindowOrdering(agg.OrderBy, i, agg.def.Name, fromScope, g.aggInScope, false /* isRangeModeWithOffsets */)
 		}
 
 		if agg.Filter != nil {
@@ -283,12 +293,47 @@ func (b *Builder) buildAggregationAsWindow(
 
 	// Initialize the aggregate expression.
 	aggregateExpr := g.aggInScope.expr
+	var exp *groupingSetsExpansion
+	if groupingSets {
+		// Aggregate each grouping set over the expanded input. The arguments,
+		// orderings and filters of the aggregates are remapped to the columns of
+		// the expansion, and the aggregates are partitioned by grouping set.
+		aggregateExpr, exp = b.constructGroupingSetsInput(g, fromScope)
+		for i := range g.aggs {
+			for j := range argLists[i] {
+				argLists[i][j] = b.factory.RemapCols(argLists[i][j], exp.colMap)
+			}
+			partitions[i].Add(exp.setCol)
+			for j, o := range ords[i] {
+				ords[i][j] = opt.MakeOrderingColumn(exp.remapCol(o.ID()), o.Descending())
+			}
+			if filterCols[i] != 0 {
+				filterCols[i] = exp.filterCol(
+					b, b.factory.ConstructVariable(exp.remapCol(filterCols[i])),
+				)
+			} else if exp.presentCol != 0 {
+				filterCols[i] = exp.presentCol
+			}
+		}
+		aggregateExpr = exp.projectFilters(b, aggregateExpr)
+		groupingColSet = groupingColSet.Union(opt.MakeColSet(exp.setCol))
+	}
+	for i := range g.aggs {
+		if ords[i] != nil {
+			orderings[i].FromOrdering(ords[i])
+		}
+	}
 
 	// frames accumulates the set of distinct window frames we're computing over
 	// so that we can group functions over the same partition and ordering.
 	frames := make([]memo.WindowExpr, 0, len(g.aggs))
 	for i, agg := range g.aggs {
-		fn := b.constructAggregate(agg.def.Name, argLists[i])
+		var fn opt.ScalarExpr
+		if agg.def.Overload.UserDefinedAggregate != nil {
+			fn = b.constructUserDefinedAggregate(&agg.def, agg.FuncExpr, argLists[i])
+		} else {
+			fn = b.constructAggregate(agg.def.Name, argLists[i])
+		}
 		if filterCols[i] != 0 {
 			fn = b.factory.ConstructAggFilter(
 				fn,
@@ -317,7 +362,8 @@ func (b *Builder) buildAggregationAsWindow(
 	// aggregations built as window functions emit an aggregated value for each row
 	// instead of each group. To rectify this, we must 'squash' the values down by
 	// wrapping it with a GroupBy or ScalarGroupBy.
-	g.aggOutScope.expr = b.constructWindowGroup(aggregateExpr, groupingColSet, g.aggs, g.aggOutScope)
+	g.aggOutScope.expr = b.constructWindowGroup(aggregateExpr, groupingColSet, g.aggs, exp, g.aggOutScope)
+	g.aggOutScope.expr = b.constructGroupingFuncs(g, g.aggOutScope.expr)
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -544,7 +590,11 @@ func (b *Builder) findMatchingFrameIndex(
 // The expression may be wrapped with a projection so ensure the default NULL
 // values of the aggregates are respected when no rows are returned.
 func (b *Builder) constructWindowGroup(
-	input memo.RelExpr, groupingColSet opt.ColSet, aggInfos []aggregateInfo, outScope *scope,
+	input memo.RelExpr,
+	groupingColSet opt.ColSet,
+	aggInfos []aggregateInfo,
+	exp *groupingSetsExpansion,
+	outScope *scope,
 ) memo.RelExpr {
 	if groupingColSet.Empty() {
 		// Construct a scalar GroupBy wrapped around the appropriate projections.
@@ -562,6 +612,12 @@ func (b *Builder) constructWindowGroup(
 			aggInfos[i].col.id,
 		))
 	}
+	if exp != nil && exp.presentCol != 0 {
+		aggs = append(aggs, b.factory.ConstructAggregationsItem(
+			b.factory.ConstructAnyNotNullAgg(b.factory.ConstructVariable(exp.presentCol)),
+			exp.presentAggCol,
+		))
+	}
 	return b.factory.ConstructGroupBy(input, aggs, &private)
 }
 
@@ -196,6 +196,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"UniqueID":             {fullName: "opt.UniqueID", passByVal: true},
 		"WithID":               {fullName: "opt.WithID", passByVal: true},
 		"UDFDefinition":        {fullName: "memo.UDFDefinition", isPointer: true},
+		"AggSupportFuncs":      {fullName: "memo.AggSupportFuncs", isPointer: true},
 		"Ordering":             {fullName: "opt.Ordering", passByVal: true},
 		"OrderingChoice":       {fullName: "props.OrderingChoice", passByVal: true},
 		"GroupingOrder":        {fullName: "memo.GroupingOrder", passByVal: true},
@@ -243,6 +244,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"SchemaDeps":           {fullName: "opt.SchemaDeps", passByVal: true},
 		"SchemaTypeDeps":       {fullName: "opt.SchemaTypeDeps", passByVal: true},
 		"Locking":              {fullName: "opt.Locking", passByVal: true},
+		"TableSample":          {fullName: "opt.TableSample", passByVal: true},
 		"CTEMaterializeClause": {fullName: "tree.CTEMaterializeClause", passByVal: true},
 		"SpanExpression":       {fullName: "inverted.SpanExpression", isPointer: true, usePointerIntern: true},
 		"InvertedSpans":        {fullName: "inverted.Spans", passByVal: true},
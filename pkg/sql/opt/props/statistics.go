@@ -195,6 +195,15 @@ type ColumnStatistic struct {
 	// the approximate distribution of values for that column, represented
 	// by a slice of histogram buckets.
 	Histogram *Histogram
+
+	// MultiColHistogram is only set for multi-column statistics of a base
+	// table. The upper bounds of its buckets are tuples with one element for
+	// each column in MultiColHistogramCols, in that order. It is not
+	// propagated to other expressions.
+	MultiColHistogram *Histogram
+
+	// MultiColHistogramCols lists the columns of MultiColHistogram in order.
+	MultiColHistogramCols opt.ColList
 }
 
 // ApplySelectivity updates the distinct count, null count, and histogram
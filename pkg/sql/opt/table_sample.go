@@ -0,0 +1,30 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opt
+
+// TableSample represents a TABLESAMPLE SYSTEM clause (see tree.TableSample)
+// applied to a scan. A sampled scan only reads a random subset of the ranges
+// that overlap its spans, skipping the others entirely. TABLESAMPLE BERNOULLI
+// is not represented here, since it is planned as an ordinary filter on top of
+// the scan.
+type TableSample struct {
+	// System is true if the scan only reads a sample of its spans.
+	System bool
+
+	// Percent is the percentage of ranges that are read, between 0 and 100.
+	Percent float64
+
+	// Repeatable is true if the sample was given a seed with the REPEATABLE
+	// clause, in which case Seed determines which ranges are read. Otherwise,
+	// a different sample is read on every execution.
+	Repeatable bool
+	Seed       int64
+}
@@ -361,7 +361,9 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		switch def := def.(type) {
 		case *tree.UniqueConstraintTableDef:
 			if def.WithoutIndex {
-				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex)
+				tab.addUniqueConstraint(
+					def.Name, def.Columns, def.Predicate, def.WithoutIndex, def.Deferrability,
+				)
 			} else if !def.PrimaryKey {
 				tab.addIndex(&def.IndexTableDef, uniqueIndex)
 			}
@@ -369,6 +371,9 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
+		case *tree.ExcludeConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.FamilyTableDef:
 			tab.addFamily(def)
 
@@ -380,6 +385,7 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 						tree.IndexElemList{{Column: def.Name}},
 						nil, /* predicate */
 						def.Unique.WithoutIndex,
+						def.Unique.Deferrability,
 					)
 				} else {
 					tab.addIndex(
@@ -677,17 +683,22 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		referencedTableID:        targetTable.ID(),
 		originColumnOrdinals:     fromCols,
 		referencedColumnOrdinals: toCols,
-		validated:                true,
+		validated:                !d.Deferrability.IsDeferrable(),
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrability:            d.Deferrability,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
 }
 
 func (tt *Table) addUniqueConstraint(
-	name tree.Name, columns tree.IndexElemList, predicate tree.Expr, withoutIndex bool,
+	name tree.Name,
+	columns tree.IndexElemList,
+	predicate tree.Expr,
+	withoutIndex bool,
+	deferrability tree.ConstraintDeferrability,
 ) {
 	// We don't currently use unique constraints with an index (those are already
 	// tracked with unique indexes), so don't bother adding them.
@@ -707,7 +718,8 @@ func (tt *Table) addUniqueConstraint(
 		tabID:          tt.TabID,
 		columnOrdinals: cols,
 		withoutIndex:   withoutIndex,
-		validated:      true,
+		validated:      !deferrability.IsDeferrable(),
+		deferrability:  deferrability,
 	}
 	// Add partial unique constraint predicate.
 	if predicate != nil {
@@ -726,6 +738,35 @@ func (tt *Table) addUniqueConstraint(
 	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
 }
 
+// addExclusionConstraint adds the index backing an EXCLUDE constraint along
+// with the constraint itself.
+func (tt *Table) addExclusionConstraint(def *tree.ExcludeConstraintTableDef) {
+	idx := tt.addIndex(&tree.IndexTableDef{
+		Name:      def.Name,
+		Columns:   def.Columns(),
+		Inverted:  def.Inverted,
+		Predicate: def.Predicate,
+	}, nonUniqueIndex)
+	c := ExclusionConstraint{
+		name:  idx.IdxName,
+		tabID: tt.TabID,
+	}
+	for i := range def.Elems {
+		elem := &def.Elems[i]
+		c.columnOrdinals = append(c.columnOrdinals, tt.FindOrdinal(string(elem.Column)))
+		c.operators = append(c.operators, elem.Operator.Symbol)
+		if elem.IsPeriod() {
+			c.columnOrdinals = append(c.columnOrdinals, tt.FindOrdinal(string(elem.PeriodEnd)))
+			c.operators = append(c.operators, elem.Operator.Symbol)
+			c.period = true
+		}
+	}
+	if def.Predicate != nil {
+		c.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.exclusionConstraints = append(tt.exclusionConstraints, c)
+}
+
 func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	ordinal := len(tt.Columns)
 	nullable := !def.PrimaryKey.IsPrimaryKey && def.Nullable.Nullability != tree.NotNull
@@ -848,7 +889,9 @@ func (tt *Table) addIndexWithVersion(
 ) *Index {
 	// Add a unique constraint if this is a primary or unique index.
 	if typ != nonUniqueIndex {
-		tt.addUniqueConstraint(def.Name, def.Columns, def.Predicate, false /* withoutIndex */)
+		tt.addUniqueConstraint(
+			def.Name, def.Columns, def.Predicate, false /* withoutIndex */, tree.ConstraintNotDeferrable,
+		)
 	}
 
 	// The test catalog does not support the hash-sharded index syntactic sugar.
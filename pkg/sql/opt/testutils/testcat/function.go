@@ -17,6 +17,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/treeprinter"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
@@ -74,15 +75,37 @@ func (tc *Catalog) CreateFunction(c *tree.CreateFunction) {
 		panic(fmt.Errorf("routine body of BEGIN ATOMIC is not supported"))
 	}
 
-	// Resolve the parameter names and types.
-	paramTypes := make(tree.ParamTypes, len(c.Params))
+	// Resolve the parameter names and types. Only input parameters are part
+	// of the signature, and output parameters determine the return type.
+	paramTypes := make(tree.ParamTypes, 0, len(c.Params))
+	var outParamTypes []*types.T
+	var outParamNames []string
+	var outParams []tree.RoutineOutParam
+	variadic := false
 	for i := range c.Params {
 		param := &c.Params[i]
 		typ, err := tree.ResolveType(context.Background(), param.Type, tc)
 		if err != nil {
 			panic(err)
 		}
-		paramTypes.SetAt(i, string(param.Name), typ)
+		if param.Class.IsInParam() {
+			paramTypes = append(paramTypes, tree.ParamType{Name: string(param.Name), Typ: typ})
+		}
+		if param.Class.IsOutParam() {
+			outParamNames = append(outParamNames, tree.OutParamColumnName(string(param.Name), len(outParamTypes)))
+			outParamTypes = append(outParamTypes, typ)
+			inputOrd := -1
+			if param.Class.IsInParam() {
+				inputOrd = len(paramTypes) - 1
+			}
+			outParams = append(outParams, tree.RoutineOutParam{
+				ParamType: tree.ParamType{Name: string(param.Name), Typ: typ},
+				InputOrd:  inputOrd,
+			})
+		}
+		if param.Class == tree.FunctionParamVariadic {
+			variadic = true
+		}
 	}
 
 	// Resolve the return type.
@@ -98,15 +121,22 @@ func (tc *Catalog) CreateFunction(c *tree.CreateFunction) {
 		tc.udfs = make(map[string]*tree.ResolvedFunctionDefinition)
 	}
 
+	if len(outParamTypes) > 1 {
+		retType = types.MakeLabeledTuple(outParamTypes, outParamNames)
+	}
+
 	overload := &tree.Overload{
-		Types:             paramTypes,
 		ReturnType:        tree.FixedReturnType(retType),
 		IsUDF:             true,
+		IsProcedure:       c.IsProcedure,
 		Body:              body,
 		Volatility:        v,
 		CalledOnNullInput: calledOnNullInput,
 		Language:          language,
+		OutParams:         outParams,
 	}
+	overload.SetRoutineParams(paramTypes, variadic)
+	overload.ReturnsRecordType = types.IsRecordType(retType) && len(outParamTypes) == 0
 	if c.ReturnType.IsSet {
 		overload.Class = tree.GeneratorClass
 	}
@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -719,6 +720,8 @@ type Table struct {
 
 	uniqueConstraints []UniqueConstraint
 
+	exclusionConstraints []ExclusionConstraint
+
 	// partitionBy is the partitioning clause that corresponds to the primary
 	// index. Used to initialize the partitioning for the primary index.
 	partitionBy *tree.PartitionBy
@@ -879,6 +882,26 @@ func (tt *Table) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	return &tt.uniqueConstraints[i]
 }
 
+// ExclusionConstraintCount is part of the cat.Table interface.
+func (tt *Table) ExclusionConstraintCount() int {
+	return len(tt.exclusionConstraints)
+}
+
+// ExclusionConstraint is part of the cat.Table interface.
+func (tt *Table) ExclusionConstraint(i int) cat.ExclusionConstraint {
+	return &tt.exclusionConstraints[i]
+}
+
+// InheritedByCount is part of the cat.Table interface.
+func (tt *Table) InheritedByCount() int {
+	return 0
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (tt *Table) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("no inheriting tables"))
+}
+
 // Zone is part of the cat.Table interface.
 func (tt *Table) Zone() cat.Zone {
 	zone := zonepb.DefaultZoneConfig()
@@ -1273,11 +1296,9 @@ func (ts *TableStat) Histogram() []cat.HistogramBucket {
 	if ts.js.HistogramColumnType == "" || ts.js.HistogramBuckets == nil {
 		return nil
 	}
-	colTypeRef, err := parser.GetTypeFromValidSQLSyntax(ts.js.HistogramColumnType)
-	if err != nil {
-		panic(err)
-	}
-	colType, err := tree.ResolveType(context.Background(), colTypeRef, ts.tc)
+	colType, err := ts.histogramColumnType(func(ref tree.ResolvableTypeReference) (*types.T, error) {
+		return tree.ResolveType(context.Background(), ref, ts.tc)
+	})
 	if err != nil {
 		return nil
 	}
@@ -1322,14 +1343,37 @@ func (ts *TableStat) HistogramType() *types.T {
 	if ts.histogramType != nil {
 		return ts.histogramType
 	}
-	colTypeRef, err := parser.GetTypeFromValidSQLSyntax(ts.js.HistogramColumnType)
-	if err != nil {
-		panic(err)
-	}
-	ts.histogramType = tree.MustBeStaticallyKnownType(colTypeRef)
+	ts.histogramType, _ = ts.histogramColumnType(func(ref tree.ResolvableTypeReference) (*types.T, error) {
+		return tree.MustBeStaticallyKnownType(ref), nil
+	})
 	return ts.histogramType
 }
 
+// histogramColumnType parses the type of the histogram, and resolves it with
+// the given function. A histogram on multiple columns has a tuple type.
+func (ts *TableStat) histogramColumnType(
+	resolve func(tree.ResolvableTypeReference) (*types.T, error),
+) (*types.T, error) {
+	parseAndResolve := func(typ string) (*types.T, error) {
+		colTypeRef, err := parser.GetTypeFromValidSQLSyntax(typ)
+		if err != nil {
+			panic(err)
+		}
+		return resolve(colTypeRef)
+	}
+	if len(ts.js.HistogramColumnTypes) == 0 {
+		return parseAndResolve(ts.js.HistogramColumnType)
+	}
+	contents := make([]*types.T, len(ts.js.HistogramColumnTypes))
+	for i, typ := range ts.js.HistogramColumnTypes {
+		var err error
+		if contents[i], err = parseAndResolve(typ); err != nil {
+			return nil, err
+		}
+	}
+	return types.MakeTuple(contents), nil
+}
+
 // IsPartial is part of the cat.TableStatistic interface.
 func (ts *TableStat) IsPartial() bool {
 	return ts.js.IsPartial()
@@ -1377,10 +1421,11 @@ type ForeignKeyConstraint struct {
 	originColumnOrdinals     []int
 	referencedColumnOrdinals []int
 
-	validated    bool
-	matchMethod  tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validated     bool
+	matchMethod   tree.CompositeKeyMatchMethod
+	deleteAction  tree.ReferenceAction
+	updateAction  tree.ReferenceAction
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &ForeignKeyConstraint{}
@@ -1390,6 +1435,12 @@ func (fk *ForeignKeyConstraint) Name() string {
 	return fk.name
 }
 
+// ConstraintID is part of the cat.ForeignKeyConstraint interface. The test
+// catalog does not assign constraint IDs.
+func (fk *ForeignKeyConstraint) ConstraintID() cat.StableID {
+	return 0
+}
+
 // OriginTableID is part of the cat.ForeignKeyConstraint interface.
 func (fk *ForeignKeyConstraint) OriginTableID() cat.StableID {
 	return fk.originTableID
@@ -1448,6 +1499,11 @@ func (fk *ForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction {
 	return fk.updateAction
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
 // UniqueConstraint implements cat.UniqueConstraint. See that interface
 // for more information on the fields.
 type UniqueConstraint struct {
@@ -1457,6 +1513,7 @@ type UniqueConstraint struct {
 	predicate      string
 	withoutIndex   bool
 	validated      bool
+	deferrability  tree.ConstraintDeferrability
 }
 
 var _ cat.UniqueConstraint = &UniqueConstraint{}
@@ -1466,6 +1523,12 @@ func (u *UniqueConstraint) Name() string {
 	return u.name
 }
 
+// ConstraintID is part of the cat.UniqueConstraint interface. The test catalog
+// does not assign constraint IDs.
+func (u *UniqueConstraint) ConstraintID() cat.StableID {
+	return 0
+}
+
 // TableID is part of the cat.UniqueConstraint interface.
 func (u *UniqueConstraint) TableID() cat.StableID {
 	return u.tabID
@@ -1508,6 +1571,60 @@ func (u *UniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return false
 }
 
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return u.deferrability
+}
+
+// ExclusionConstraint implements cat.ExclusionConstraint. See that interface
+// for more information on the fields.
+type ExclusionConstraint struct {
+	name           string
+	tabID          cat.StableID
+	columnOrdinals []int
+	operators      []treecmp.ComparisonOperatorSymbol
+	period         bool
+	predicate      string
+}
+
+var _ cat.ExclusionConstraint = &ExclusionConstraint{}
+
+// Name is part of the cat.ExclusionConstraint interface.
+func (c *ExclusionConstraint) Name() string {
+	return c.name
+}
+
+// ColumnCount is part of the cat.ExclusionConstraint interface.
+func (c *ExclusionConstraint) ColumnCount() int {
+	return len(c.columnOrdinals)
+}
+
+// ColumnOrdinal is part of the cat.ExclusionConstraint interface.
+func (c *ExclusionConstraint) ColumnOrdinal(tab cat.Table, i int) int {
+	if tab.ID() != c.tabID {
+		panic(errors.AssertionFailedf(
+			"invalid table %d passed to ColumnOrdinal (expected %d)",
+			tab.ID(), c.tabID,
+		))
+	}
+	return c.columnOrdinals[i]
+}
+
+// Operator is part of the cat.ExclusionConstraint interface.
+func (c *ExclusionConstraint) Operator(i int) treecmp.ComparisonOperatorSymbol {
+	return c.operators[i]
+}
+
+// HasPeriod is part of the cat.ExclusionConstraint interface.
+func (c *ExclusionConstraint) HasPeriod() bool {
+	return c.period
+}
+
+// Predicate is part of the cat.ExclusionConstraint interface.
+func (c *ExclusionConstraint) Predicate() (string, bool) {
+	return c.predicate, c.predicate != ""
+}
+
 // Sequence implements the cat.Sequence interface for testing purposes.
 type Sequence struct {
 	SeqID      cat.StableID
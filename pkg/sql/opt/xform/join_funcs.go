@@ -367,6 +367,10 @@ func (c *CustomFuncs) generateLookupJoinsImpl(
 	if !c.canGenerateLookupJoins(input, joinPrivate.Flags, inputProps.OutputCols, rightCols, on) {
 		return
 	}
+	if scanPrivate.Sample.System {
+		// Lookups would read rows from ranges that were not sampled.
+		return
+	}
 
 	// Initialize the constraint builder.
 	c.cb.Init(
@@ -795,6 +799,10 @@ func (c *CustomFuncs) GenerateInvertedJoins(
 	if joinPrivate.Flags.Has(memo.DisallowInvertedJoinIntoRight) {
 		return
 	}
+	if scanPrivate.Sample.System {
+		// Lookups would read rows from ranges that were not sampled.
+		return
+	}
 
 	inputCols := input.Relational().OutputCols
 	var pkCols opt.ColList
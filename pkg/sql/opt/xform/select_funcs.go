@@ -1024,6 +1024,10 @@ func (c *CustomFuncs) GenerateZigzagJoins(
 	if !c.e.evalCtx.SessionData().ZigzagJoinEnabled || scanPrivate.Flags.NoZigzagJoin {
 		return
 	}
+	// Zigzag joins do not support sampling the indexes they read.
+	if scanPrivate.Sample.System {
+		return
+	}
 
 	fixedCols := memo.ExtractConstColumns(filters, c.e.evalCtx)
 	if fixedCols.Len() < 2 {
@@ -1407,6 +1411,10 @@ func (c *CustomFuncs) GenerateInvertedIndexZigzagJoins(
 	if !c.e.evalCtx.SessionData().ZigzagJoinEnabled || scanPrivate.Flags.NoZigzagJoin {
 		return
 	}
+	// Zigzag joins do not support sampling the indexes they read.
+	if scanPrivate.Sample.System {
+		return
+	}
 
 	var sb indexScanBuilder
 	sb.Init(c, scanPrivate.Table)
@@ -1838,6 +1846,7 @@ func (c *CustomFuncs) AddPrimaryKeyColsToScanPrivate(sp *memo.ScanPrivate) *memo
 		Cols:    sp.Cols.Union(keyCols),
 		Flags:   sp.Flags,
 		Locking: sp.Locking,
+		Sample:  sp.Sample,
 	}
 }
 
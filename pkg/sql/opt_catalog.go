@@ -745,6 +745,10 @@ type optTable struct {
 
 	uniqueConstraints []optUniqueConstraint
 
+	// exclusionConstraints are the EXCLUDE constraints backed by public or
+	// write-only indexes, which must be enforced by mutations.
+	exclusionConstraints []optExclusionConstraint
+
 	outboundFKs []optForeignKeyConstraint
 	inboundFKs  []optForeignKeyConstraint
 
@@ -887,11 +891,15 @@ func newOptTable(
 	for i, u := range ot.desc.EnforcedUniqueConstraintsWithoutIndex() {
 		ot.uniqueConstraints[i] = optUniqueConstraint{
 			name:         u.GetName(),
+			id:           u.GetConstraintID(),
 			table:        ot.ID(),
 			columns:      u.CollectKeyColumnIDs().Ordered(),
 			predicate:    u.GetPredicate(),
 			withoutIndex: true,
 			validity:     u.GetConstraintValidity(),
+			deferrability: tree.MakeConstraintDeferrability(
+				u.UniqueWithoutIndexDesc().Deferrable, u.UniqueWithoutIndexDesc().InitiallyDeferred,
+			),
 		}
 	}
 
@@ -962,6 +970,7 @@ func newOptTable(
 				// Add unique constraints for implicitly partitioned unique indexes.
 				ot.uniqueConstraints = append(ot.uniqueConstraints, optUniqueConstraint{
 					name:         idx.GetName(),
+					id:           idx.GetConstraintID(),
 					table:        ot.ID(),
 					columns:      idx.IndexDesc().KeyColumnIDs[idx.IndexDesc().ExplicitColumnStartIdx():],
 					withoutIndex: true,
@@ -974,6 +983,7 @@ func newOptTable(
 				// Add unique constraint for hash sharded indexes.
 				ot.uniqueConstraints = append(ot.uniqueConstraints, optUniqueConstraint{
 					name:                               idx.GetName(),
+					id:                                 idx.GetConstraintID(),
 					table:                              ot.ID(),
 					columns:                            idx.IndexDesc().KeyColumnIDs[idx.IndexDesc().ExplicitColumnStartIdx():],
 					withoutIndex:                       true,
@@ -983,11 +993,35 @@ func newOptTable(
 				})
 			}
 		}
+
+		if idx.IsExclusionConstraint() && (idx.Public() || idx.WriteAndDeleteOnly()) {
+			ops := make([]treecmp.ComparisonOperatorSymbol, idx.NumKeyColumns())
+			for j := range ops {
+				switch idx.GetExclusionOperator(j) {
+				case "=":
+					ops[j] = treecmp.EQ
+				case "&&", "OVERLAPS":
+					ops[j] = treecmp.Overlaps
+				default:
+					return nil, errors.AssertionFailedf(
+						"unexpected exclusion operator %q", idx.GetExclusionOperator(j))
+				}
+			}
+			ot.exclusionConstraints = append(ot.exclusionConstraints, optExclusionConstraint{
+				name:      idx.GetName(),
+				table:     ot.ID(),
+				columns:   idx.IndexDesc().KeyColumnIDs,
+				operators: ops,
+				period:    idx.GetExclusionOperator(len(ops)-1) == "OVERLAPS",
+				predicate: idx.GetPredicate(),
+			})
+		}
 	}
 
 	for _, fk := range ot.desc.OutboundForeignKeys() {
 		ot.outboundFKs = append(ot.outboundFKs, optForeignKeyConstraint{
 			name:              fk.GetName(),
+			id:                fk.GetConstraintID(),
 			originTable:       ot.ID(),
 			originColumns:     fk.ForeignKeyDesc().OriginColumnIDs,
 			referencedTable:   cat.StableID(fk.GetReferencedTableID()),
@@ -996,11 +1030,15 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability: tree.MakeConstraintDeferrability(
+				fk.ForeignKeyDesc().Deferrable, fk.ForeignKeyDesc().InitiallyDeferred,
+			),
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
 		ot.inboundFKs = append(ot.inboundFKs, optForeignKeyConstraint{
 			name:              fk.GetName(),
+			id:                fk.GetConstraintID(),
 			originTable:       cat.StableID(fk.GetOriginTableID()),
 			originColumns:     fk.ForeignKeyDesc().OriginColumnIDs,
 			referencedTable:   ot.ID(),
@@ -1009,6 +1047,9 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability: tree.MakeConstraintDeferrability(
+				fk.ForeignKeyDesc().Deferrable, fk.ForeignKeyDesc().InitiallyDeferred,
+			),
 		})
 	}
 
@@ -1279,6 +1320,26 @@ func (ot *optTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	return &ot.uniqueConstraints[i]
 }
 
+// ExclusionConstraintCount is part of the cat.Table interface.
+func (ot *optTable) ExclusionConstraintCount() int {
+	return len(ot.exclusionConstraints)
+}
+
+// ExclusionConstraint is part of the cat.Table interface.
+func (ot *optTable) ExclusionConstraint(i int) cat.ExclusionConstraint {
+	return &ot.exclusionConstraints[i]
+}
+
+// InheritedByCount is part of the cat.Table interface.
+func (ot *optTable) InheritedByCount() int {
+	return len(ot.desc.GetInheritedBy())
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (ot *optTable) InheritedBy(i int) cat.StableID {
+	return cat.StableID(ot.desc.GetInheritedBy()[i])
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optTable) Zone() cat.Zone {
 	return ot.zone
@@ -1858,13 +1919,15 @@ func (oi *optFamily) Table() cat.Table {
 // unique constraint.
 type optUniqueConstraint struct {
 	name string
+	id   descpb.ConstraintID
 
 	table     cat.StableID
 	columns   []descpb.ColumnID
 	predicate string
 
-	withoutIndex bool
-	validity     descpb.ConstraintValidity
+	withoutIndex  bool
+	validity      descpb.ConstraintValidity
+	deferrability tree.ConstraintDeferrability
 
 	uniquenessGuaranteedByAnotherIndex bool
 }
@@ -1876,6 +1939,11 @@ func (u *optUniqueConstraint) Name() string {
 	return u.name
 }
 
+// ConstraintID is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ConstraintID() cat.StableID {
+	return cat.StableID(u.id)
+}
+
 // TableID is part of the cat.UniqueConstraint interface.
 func (u *optUniqueConstraint) TableID() cat.StableID {
 	return u.table
@@ -1911,7 +1979,7 @@ func (u *optUniqueConstraint) WithoutIndex() bool {
 
 // Validated is part of the cat.UniqueConstraint interface.
 func (u *optUniqueConstraint) Validated() bool {
-	return u.validity == descpb.ConstraintValidity_Validated
+	return u.validity == descpb.ConstraintValidity_Validated && !u.deferrability.IsDeferrable()
 }
 
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
@@ -1922,12 +1990,70 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return u.deferrability
+}
+
+// optExclusionConstraint implements cat.ExclusionConstraint and represents an
+// EXCLUDE constraint backed by an index.
+type optExclusionConstraint struct {
+	name string
+
+	table     cat.StableID
+	columns   []descpb.ColumnID
+	operators []treecmp.ComparisonOperatorSymbol
+	period    bool
+	predicate string
+}
+
+var _ cat.ExclusionConstraint = &optExclusionConstraint{}
+
+// Name is part of the cat.ExclusionConstraint interface.
+func (c *optExclusionConstraint) Name() string {
+	return c.name
+}
+
+// ColumnCount is part of the cat.ExclusionConstraint interface.
+func (c *optExclusionConstraint) ColumnCount() int {
+	return len(c.columns)
+}
+
+// ColumnOrdinal is part of the cat.ExclusionConstraint interface.
+func (c *optExclusionConstraint) ColumnOrdinal(tab cat.Table, i int) int {
+	if tab.ID() != c.table {
+		panic(errors.AssertionFailedf(
+			"invalid table %d passed to ColumnOrdinal (expected %d)",
+			tab.ID(), c.table,
+		))
+	}
+	optTab := convertTableToOptTable(tab)
+	ord, _ := optTab.lookupColumnOrdinal(c.columns[i])
+	return ord
+}
+
+// Operator is part of the cat.ExclusionConstraint interface.
+func (c *optExclusionConstraint) Operator(i int) treecmp.ComparisonOperatorSymbol {
+	return c.operators[i]
+}
+
+// HasPeriod is part of the cat.ExclusionConstraint interface.
+func (c *optExclusionConstraint) HasPeriod() bool {
+	return c.period
+}
+
+// Predicate is part of the cat.ExclusionConstraint interface.
+func (c *optExclusionConstraint) Predicate() (string, bool) {
+	return c.predicate, c.predicate != ""
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
 // respectively).
 type optForeignKeyConstraint struct {
 	name string
+	id   descpb.ConstraintID
 
 	originTable   cat.StableID
 	originColumns []descpb.ColumnID
@@ -1935,10 +2061,11 @@ type optForeignKeyConstraint struct {
 	referencedTable   cat.StableID
 	referencedColumns []descpb.ColumnID
 
-	validity     descpb.ConstraintValidity
-	match        tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validity      descpb.ConstraintValidity
+	match         tree.CompositeKeyMatchMethod
+	deleteAction  tree.ReferenceAction
+	updateAction  tree.ReferenceAction
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -1948,6 +2075,11 @@ func (fk *optForeignKeyConstraint) Name() string {
 	return fk.name
 }
 
+// ConstraintID is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) ConstraintID() cat.StableID {
+	return cat.StableID(fk.id)
+}
+
 // OriginTableID is part of the cat.ForeignKeyConstraint interface.
 func (fk *optForeignKeyConstraint) OriginTableID() cat.StableID {
 	return fk.originTable
@@ -1992,7 +2124,7 @@ func (fk *optForeignKeyConstraint) ReferencedColumnOrdinal(referencedTable cat.T
 
 // Validated is part of the cat.ForeignKeyConstraint interface.
 func (fk *optForeignKeyConstraint) Validated() bool {
-	return fk.validity == descpb.ConstraintValidity_Validated
+	return fk.validity == descpb.ConstraintValidity_Validated && !fk.deferrability.IsDeferrable()
 }
 
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
@@ -2010,6 +2142,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2308,6 +2445,26 @@ func (ot *optVirtualTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	panic(errors.AssertionFailedf("no unique constraints"))
 }
 
+// ExclusionConstraintCount is part of the cat.Table interface.
+func (ot *optVirtualTable) ExclusionConstraintCount() int {
+	return 0
+}
+
+// ExclusionConstraint is part of the cat.Table interface.
+func (ot *optVirtualTable) ExclusionConstraint(i int) cat.ExclusionConstraint {
+	panic(errors.AssertionFailedf("no exclusion constraints"))
+}
+
+// InheritedByCount is part of the cat.Table interface.
+func (ot *optVirtualTable) InheritedByCount() int {
+	return 0
+}
+
+// InheritedBy is part of the cat.Table interface.
+func (ot *optVirtualTable) InheritedBy(i int) cat.StableID {
+	panic(errors.AssertionFailedf("no inheriting tables"))
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optVirtualTable) Zone() cat.Zone {
 	panic(errors.AssertionFailedf("no zone"))
@@ -2616,7 +2773,7 @@ func collectTypes(col catalog.Column) (descpb.IDs, error) {
 		}
 	}
 	if typ := col.GetType(); typ != nil && typ.UserDefined() {
-		visitor.OIDs[typ.Oid()] = struct{}{}
+		visitor.OIDs[typ.UserDefinedOID()] = struct{}{}
 	}
 
 	ids := make(descpb.IDs, 0, len(visitor.OIDs))
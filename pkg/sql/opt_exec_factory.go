@@ -170,6 +170,7 @@ func (ef *execFactory) ConstructScan(
 	scan.lockingStrength = descpb.ToScanLockingStrength(params.Locking.Strength)
 	scan.lockingWaitPolicy = descpb.ToScanLockingWaitPolicy(params.Locking.WaitPolicy)
 	scan.localityOptimized = params.LocalityOptimized
+	scan.sample = params.Sample
 	if !ef.isExplain && !(ef.planner.isInternalPlanner || ef.planner.SessionData().Internal) {
 		idxUsageKey := roachpb.IndexUsageKey{
 			TableID: roachpb.TableID(tabDesc.GetID()),
@@ -543,6 +544,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.Distinct,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -1141,6 +1143,7 @@ func (ef *execFactory) ConstructWindow(root exec.Node, wi exec.WindowInfo) (exec
 		p.funcs[i] = &windowFuncHolder{
 			expr:           wi.Exprs[i],
 			args:           wi.Exprs[i].Exprs,
+			userDefined:    wi.UserDefined[i],
 			argsIdxs:       argsIdxs,
 			filterColIdx:   wi.FilterIdxs[i],
 			outputColIdx:   wi.OutputIdxs[i],
@@ -1606,6 +1609,7 @@ func (ef *execFactory) ConstructUpsert(
 	arbiterIndexes cat.IndexOrdinals,
 	arbiterConstraints cat.UniqueOrdinals,
 	canaryCol exec.NodeColumnOrdinal,
+	deleteCol exec.NodeColumnOrdinal,
 	insertColOrdSet exec.TableColumnOrdinalSet,
 	fetchColOrdSet exec.TableColumnOrdinalSet,
 	updateColOrdSet exec.TableColumnOrdinalSet,
@@ -1655,6 +1659,20 @@ func (ef *execFactory) ConstructUpsert(
 		return nil, err
 	}
 
+	// Create the table deleter if existing rows may be deleted instead of
+	// updated (which is the case for MERGE statements with a DELETE action).
+	var rd row.Deleter
+	if deleteCol != -1 {
+		rd = row.MakeDeleter(
+			ef.planner.ExecCfg().Codec,
+			tabDesc,
+			fetchCols,
+			&ef.planner.ExecCfg().Settings.SV,
+			internal,
+			ef.planner.ExecCfg().GetRowMetrics(internal),
+		)
+	}
+
 	// Instantiate the upsert node.
 	ups := upsertNodePool.Get().(*upsertNode)
 	*ups = upsertNode{
@@ -1665,9 +1683,11 @@ func (ef *execFactory) ConstructUpsert(
 			tw: optTableUpserter{
 				ri:            ri,
 				canaryOrdinal: int(canaryCol),
+				deleteOrdinal: int(deleteCol),
 				fetchCols:     fetchCols,
 				updateCols:    updateCols,
 				ru:            ru,
+				rd:            rd,
 			},
 		},
 	}
@@ -1909,6 +1929,13 @@ func (ef *execFactory) ConstructCreateFunction(
 	}, nil
 }
 
+// ConstructCall is part of the exec.Factory interface.
+func (ef *execFactory) ConstructCall(
+	proc tree.TypedExpr, columns colinfo.ResultColumns,
+) (exec.Node, error) {
+	return &callNode{proc: proc, columns: columns}, nil
+}
+
 func toPlanDependencies(
 	deps opt.SchemaDeps, typeDeps opt.SchemaTypeDeps,
 ) (planDependencies, typeDependencies, error) {
@@ -1957,11 +1984,21 @@ func (ef *execFactory) ConstructSaveTable(
 
 // ConstructErrorIfRows is part of the exec.Factory interface.
 func (ef *execFactory) ConstructErrorIfRows(
-	input exec.Node, mkErr exec.MkErrFn,
+	input exec.Node, mkErr exec.MkErrFn, deferrable *exec.DeferrableConstraint,
 ) (exec.Node, error) {
+	if d := ef.planner.extendedEvalCtx.deferredConstraints; deferrable != nil && d != nil {
+		// Resolve the constraints named by SET CONSTRAINTS against the table now,
+		// since the checks may run concurrently.
+		if err := d.noteTable(
+			ef.ctx, ef.planner.Descriptors(), ef.planner.Txn(), descpb.ID(deferrable.Table),
+		); err != nil {
+			return nil, err
+		}
+	}
 	return &errorIfRowsNode{
-		plan:  input.(planNode),
-		mkErr: mkErr,
+		plan:       input.(planNode),
+		mkErr:      mkErr,
+		deferrable: deferrable,
 	}, nil
 }
 
@@ -103,6 +103,11 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER TYPE t RENAME ??`, `ALTER TYPE`},
 		{`ALTER TYPE t DROP VALUE ??`, `ALTER TYPE`},
 
+		{`ALTER DOMAIN ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d SET ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d ADD CONSTRAINT ??`, `ALTER DOMAIN`},
+
 		{`ALTER INDEX foo@bar RENAME ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar RENAME TO blih ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar SPLIT ??`, `ALTER INDEX`},
@@ -191,6 +196,12 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE VIEW blah AS SELECT c FROM x ??`, `SELECT`},
 		{`CREATE VIEW blah AS (??`, `<SELECTCLAUSE>`},
 
+		{`CREATE FOREIGN TABLE ??`, `CREATE FOREIGN TABLE`},
+		{`CREATE FOREIGN TABLE blah (a INT) ??`, `CREATE FOREIGN TABLE`},
+
+		{`CREATE PUBLICATION ??`, `CREATE PUBLICATION`},
+		{`CREATE PUBLICATION p FOR ??`, `CREATE PUBLICATION`},
+
 		{`CREATE SEQUENCE ??`, `CREATE SEQUENCE`},
 
 		{`CREATE STATISTICS ??`, `CREATE STATISTICS`},
@@ -206,6 +217,10 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE TYPE blah AS ENUM ??`, `CREATE TYPE`},
 		{`DROP TYPE ??`, `DROP TYPE`},
 
+		{`CREATE DOMAIN ??`, `CREATE DOMAIN`},
+		{`CREATE DOMAIN d AS ??`, `CREATE DOMAIN`},
+		{`DROP DOMAIN ??`, `DROP DOMAIN`},
+
 		{`CREATE SCHEMA IF ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA IF NOT ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA bli ??`, `CREATE SCHEMA`},
@@ -248,6 +263,12 @@ func TestContextualHelp(t *testing.T) {
 		{`DROP VIEW IF ??`, `DROP VIEW`},
 		{`DROP VIEW IF EXISTS blih, bloh ??`, `DROP VIEW`},
 
+		{`DROP FOREIGN TABLE ??`, `DROP FOREIGN TABLE`},
+		{`DROP FOREIGN TABLE IF EXISTS blih, bloh ??`, `DROP FOREIGN TABLE`},
+
+		{`DROP PUBLICATION ??`, `DROP PUBLICATION`},
+		{`DROP PUBLICATION IF EXISTS p, q ??`, `DROP PUBLICATION`},
+
 		{`DROP SCHEDULE ???`, `DROP SCHEDULES`},
 		{`DROP SCHEDULES ???`, `DROP SCHEDULES`},
 
@@ -302,6 +323,10 @@ func TestContextualHelp(t *testing.T) {
 		{`INSERT INTO blah VALUES (1) ??`, `VALUES`},
 		{`INSERT INTO blah TABLE foo ??`, `TABLE`},
 
+		{`MERGE ??`, `MERGE`},
+		{`MERGE INTO blah USING foo ON true ??`, `MERGE`},
+		{`MERGE INTO blah USING foo ON true WHEN MATCHED THEN ??`, `MERGE`},
+
 		{`UPSERT INTO ??`, `UPSERT`},
 		{`UPSERT INTO blah (??`, `<SELECTCLAUSE>`},
 		{`UPSERT INTO blah VALUES (1) RETURNING ??`, `UPSERT`},
@@ -512,6 +537,9 @@ func TestContextualHelp(t *testing.T) {
 		{`SET LOCAL TIME ??`, `SET LOCAL`},
 		{`SET LOCAL TIME ZONE 'UTC' ??`, `SET LOCAL`},
 
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
+		{`SET CONSTRAINTS ALL ??`, `SET CONSTRAINTS`},
+
 		{`SET TRANSACTION ??`, `SET TRANSACTION`},
 		{`SET TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
 		{`SET TIME ??`, `SET SESSION`},
@@ -573,6 +601,16 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE FUNCTION ??`, `CREATE FUNCTION`},
 		{`ALTER FUNCTION ??`, `ALTER FUNCTION`},
 		{`DROP FUNCTION ??`, `DROP FUNCTION`},
+
+		{`CREATE PROCEDURE ??`, `CREATE PROCEDURE`},
+		{`CREATE OR REPLACE PROCEDURE ??`, `CREATE PROCEDURE`},
+		{`DROP PROCEDURE ??`, `DROP PROCEDURE`},
+		{`CALL ??`, `CALL`},
+
+		{`CREATE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`CREATE OR REPLACE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`ALTER AGGREGATE ??`, `ALTER AGGREGATE`},
+		{`DROP AGGREGATE ??`, `DROP AGGREGATE`},
 	}
 
 	// The following checks that the test definition above exercises all
@@ -39,6 +39,8 @@ func TestScanner(t *testing.T) {
 		{`<=`, []int{LESS_EQUALS}},
 		{`<<`, []int{LSHIFT}},
 		{`<<=`, []int{INET_CONTAINED_BY_OR_EQUALS}},
+		{`<->`, []int{DISTANCE}},
+		{`<-1`, []int{'<', '-', ICONST}},
 		{`>`, []int{'>'}},
 		{`>=`, []int{GREATER_EQUALS}},
 		{`>>`, []int{RSHIFT}},
@@ -77,6 +77,54 @@ func unimplementedWithIssueDetail(sqllex sqlLexer, issue int, detail string) int
     return 1
 }
 
+// validateAggregateOptions checks that the options required by CREATE
+// AGGREGATE were specified.
+func validateAggregateOptions(options *tree.AggregateOptions) error {
+    if options.TransitionFunc == nil {
+        return pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+    }
+    if options.StateType == nil {
+        return pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+    }
+    return nil
+}
+
+// makeRecursiveViewSelect desugars the query of CREATE RECURSIVE VIEW into a
+// recursive CTE named after the view, in the same way as Postgres:
+//
+//	CREATE RECURSIVE VIEW v (cols) AS <query>
+//
+// is equivalent to:
+//
+//	CREATE VIEW v AS WITH RECURSIVE v (cols) AS (<query>) SELECT cols FROM v
+func makeRecursiveViewSelect(
+    viewName tree.Name, columns tree.NameList, query *tree.Select,
+) (*tree.Select, error) {
+    if len(columns) == 0 {
+        return nil, pgerror.New(pgcode.Syntax, "recursive views must specify a column list")
+    }
+    cteCols := make(tree.ColumnDefList, len(columns))
+    exprs := make(tree.SelectExprs, len(columns))
+    for i, col := range columns {
+        cteCols[i] = tree.ColumnDef{Name: col}
+        exprs[i] = tree.SelectExpr{Expr: tree.NewUnresolvedName(string(col))}
+    }
+    cteName := tree.MakeUnqualifiedTableName(viewName)
+    return &tree.Select{
+        With: &tree.With{
+            Recursive: true,
+            CTEList: []*tree.CTE{{
+                Name: tree.AliasClause{Alias: viewName, Cols: cteCols},
+                Stmt: query,
+            }},
+        },
+        Select: &tree.SelectClause{
+            Exprs: exprs,
+            From: tree.From{Tables: tree.TableExprs{&tree.AliasedTableExpr{Expr: &cteName}}},
+        },
+    }, nil
+}
+
 func processBinaryQualOp(
   sqllex sqlLexer,
   op tree.Operator,
@@ -326,6 +374,9 @@ func (u *sqlSymUnion) tableNames() tree.TableNames {
 func (u *sqlSymUnion) indexFlags() *tree.IndexFlags {
     return u.val.(*tree.IndexFlags)
 }
+func (u *sqlSymUnion) tableSample() *tree.TableSample {
+    return u.val.(*tree.TableSample)
+}
 func (u *sqlSymUnion) arraySubscript() *tree.ArraySubscript {
     return u.val.(*tree.ArraySubscript)
 }
@@ -527,6 +578,12 @@ func (u *sqlSymUnion) updateExpr() *tree.UpdateExpr {
 func (u *sqlSymUnion) updateExprs() tree.UpdateExprs {
     return u.val.(tree.UpdateExprs)
 }
+func (u *sqlSymUnion) mergeWhen() *tree.MergeWhen {
+    return u.val.(*tree.MergeWhen)
+}
+func (u *sqlSymUnion) mergeWhens() tree.MergeWhens {
+    return u.val.(tree.MergeWhens)
+}
 func (u *sqlSymUnion) limit() *tree.Limit {
     return u.val.(*tree.Limit)
 }
@@ -641,6 +698,12 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) excludeElem() tree.ExcludeElem {
+    return u.val.(tree.ExcludeElem)
+}
+func (u *sqlSymUnion) excludeElems() tree.ExcludeElemList {
+    return u.val.(tree.ExcludeElemList)
+}
 func (u *sqlSymUnion) dropBehavior() tree.DropBehavior {
     return u.val.(tree.DropBehavior)
 }
@@ -710,6 +773,9 @@ func (u *sqlSymUnion) kvOptions() []tree.KVOption {
 func (u *sqlSymUnion) backupOptions() *tree.BackupOptions {
   return u.val.(*tree.BackupOptions)
 }
+func (u *sqlSymUnion) aggregateOptions() *tree.AggregateOptions {
+  return u.val.(*tree.AggregateOptions)
+}
 func (u *sqlSymUnion) copyOptions() *tree.CopyOptions {
   return u.val.(*tree.CopyOptions)
 }
@@ -731,6 +797,12 @@ func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
 func (u *sqlSymUnion) compositeKeyMatchMethod() tree.CompositeKeyMatchMethod {
   return u.val.(tree.CompositeKeyMatchMethod)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+  return u.val.(tree.ConstraintDeferrability)
+}
+func (u *sqlSymUnion) constraintAttribute() tree.ConstraintAttribute {
+  return u.val.(tree.ConstraintAttribute)
+}
 func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
     return u.val.(tree.ReferenceAction)
 }
@@ -902,7 +974,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 
 // Ordinary key words in alphabetical order.
 %token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
-%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
+%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT AT_QUESTION
 %token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
@@ -911,7 +983,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 
 %token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
-%token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
+%token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMBINEFUNC COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONNECTIONS CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
 %token <str> CONVERSION CONVERT COPY COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
@@ -921,7 +993,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEBUG_PAUSE_ON DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DROP
 
 %token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
@@ -930,7 +1002,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> EXPIRATION EXPLAIN EXPORT EXTENSION EXTERNAL EXTRACT EXTRACT_DURATION EXTREMES
 
 %token <str> FAILURE FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
-%token <str> FILES FILTER
+%token <str> FILES FILTER FINALFUNC
 %token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE FORCE_INDEX
 %token <str> FORCE_NOT_NULL FORCE_NULL FORCE_QUOTE FORCE_ZIGZAG
 %token <str> FOREIGN FORMAT FORWARD FREEZE FROM FULL FUNCTION FUNCTIONS
@@ -945,7 +1017,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMUTABLE IMPORT IN INCLUDE
 %token <str> INCLUDING INCLUDE_ALL_SECONDARY_TENANTS INCLUDE_ALL_VIRTUAL_CLUSTERS INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
-%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
+%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERIT INHERITS INITCOND INJECT INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
 %token <str> INNER INOUT INPUT INSENSITIVE INSERT INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
@@ -957,9 +1029,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
+%token <str> MATCH MATCHED MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
@@ -967,7 +1039,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NOLOGIN NOMODIFYCLUSTERSETTING
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
-%token <str> NOTHING NOTHING_AFTER_RETURNING
+%token <str> NOTHING NOTHING_AFTER_RETURNING NOTIFY
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
@@ -989,14 +1061,14 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
 %token <str> SEARCH SECOND SECONDARY SECURITY SELECT SEQUENCE SEQUENCES
-%token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS
+%token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS SFUNC
 %token <str> SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
 %token <str> SQLLOGIN
-%token <str> STABLE START STATE STATISTICS STATUS STDIN STDOUT STOP STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
+%token <str> STABLE START STATE STATISTICS STATUS STDIN STDOUT STOP STREAM STRICT STRING STYPE STORAGE STORE STORED STORING SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
-%token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
+%token <str> TABLE TABLES TABLESAMPLE TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
 %token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TRAILING TRACE
 %token <str> TRANSACTION TRANSACTIONS TRANSFER TRANSFORM TREAT TRIGGER TRIM TRUE
 %token <str> TRUNCATE TRUSTED TYPE TYPES
@@ -1062,8 +1134,8 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> alter_role_stmt
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
+%type <tree.Statement> alter_domain_stmt
 %type <tree.Statement> alter_schema_stmt
-%type <tree.Statement> alter_unsupported_stmt
 %type <tree.Statement> alter_func_stmt
 
 // ALTER RANGE
@@ -1144,6 +1216,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 // ALTER DEFAULT PRIVILEGES
 %type <tree.Statement> alter_default_privileges_stmt
 
+// ALTER AGGREGATE
+%type <tree.Statement> alter_aggregate_stmt
+
 // ALTER FUNCTION
 %type <tree.Statement> alter_func_options_stmt
 %type <tree.Statement> alter_func_rename_stmt
@@ -1190,8 +1265,12 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> create_table_as_stmt
 %type <tree.Statement> create_virtual_cluster_stmt
 %type <tree.Statement> create_view_stmt
+%type <tree.Statement> create_foreign_table_stmt
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
+%type <tree.Statement> create_proc_stmt
+%type <tree.Statement> create_aggregate_stmt
+%type <tree.Statement> create_publication_stmt
 
 %type <*tree.LikeTenantSpec> opt_like_virtual_cluster
 
@@ -1201,6 +1280,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <*tree.CreateStatsOptions> create_stats_option
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_domain_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -1213,9 +1293,14 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
 %type <tree.Statement> drop_view_stmt
+%type <tree.Statement> drop_foreign_table_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
+%type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_aggregate_stmt
+%type <tree.Statement> drop_publication_stmt
 %type <tree.Statement> drop_virtual_cluster_stmt
 %type <bool>           opt_immediate
 
@@ -1255,6 +1340,10 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
+%type <bool> constraints_set_mode
+%type <tree.ConstraintDeferrability> opt_deferrable
+%type <tree.ConstraintAttribute> constraint_attr
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1317,6 +1406,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
 %type <tree.Statement> unlisten_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> merge_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
 %type <tree.Statement> use_stmt
@@ -1336,6 +1428,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 
 %type <[]string> opt_incremental
 %type <tree.KVOption> kv_option
+%type <tree.KVOption> foreign_table_option
+%type <[]tree.KVOption> foreign_table_option_list
+%type <str> opt_foreign_server
 %type <[]tree.KVOption> kv_option_list opt_with_options var_set_list opt_with_schedule_options
 %type <*tree.BackupOptions> opt_with_backup_options backup_options backup_options_list
 %type <*tree.RestoreOptions> opt_with_restore_options restore_options restore_options_list
@@ -1434,14 +1529,14 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <*tree.PartitionByTable> opt_partition_by_table partition_by_table
 %type <*tree.PartitionByIndex> opt_partition_by_index partition_by_index
 %type <str> partition opt_partition
-%type <str> opt_create_table_inherits
+%type <tree.TableNames> opt_create_table_inherits
 %type <tree.ListPartition> list_partition
 %type <[]tree.ListPartition> list_partitions
 %type <tree.RangePartition> range_partition
 %type <[]tree.RangePartition> range_partitions
 %type <empty> opt_all_clause
 %type <empty> opt_privileges_clause
-%type <bool> distinct_clause opt_with_data
+%type <bool> distinct_clause opt_with_data opt_create_as_data opt_view_recursive
 %type <tree.DistinctOn> distinct_on_clause
 %type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
 %type <tree.OrderBy> sort_clause single_sort_clause opt_sort_clause
@@ -1460,6 +1555,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <[]string> session_var_parts
 %type <tree.SelectExprs> target_list
 %type <tree.UpdateExprs> set_clause_list
+%type <tree.MergeWhens> merge_when_list
+%type <*tree.MergeWhen> merge_when_clause merge_matched_action merge_not_matched_action
+%type <tree.Expr> opt_merge_when_cond
 %type <*tree.UpdateExpr> set_clause multiple_set_clause
 %type <tree.ArraySubscripts> array_subscripts
 %type <tree.GroupBy> group_clause
@@ -1519,6 +1617,8 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <*tree.ArraySubscript> array_subscript
 %type <tree.Expr> opt_slice_bound
 %type <*tree.IndexFlags> opt_index_flags
+%type <*tree.TableSample> opt_tablesample_clause
+%type <tree.Expr> opt_repeatable_clause
 %type <*tree.IndexFlags> index_flags_param
 %type <*tree.IndexFlags> index_flags_param_list
 %type <tree.Expr> a_expr b_expr c_expr d_expr typed_literal
@@ -1539,6 +1639,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <bool> opt_ordinality opt_compact
 %type <*tree.Order> sortby
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
+%type <tree.ExcludeElemList> exclude_elem_list
+%type <tree.ExcludeElem> exclude_elem
+%type <treecmp.ComparisonOperator> exclude_op
 %type <tree.TableExpr> table_ref numeric_table_ref func_table
 %type <tree.Exprs> rowsfrom_list
 %type <tree.Expr> rowsfrom_item
@@ -1665,10 +1768,10 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <privilege.TargetObjectType> target_object_type
 
 // User defined function relevant components.
-%type <bool> opt_or_replace opt_return_table opt_return_set opt_no
+%type <bool> opt_or_replace opt_return_set opt_no
 %type <str> param_name func_as
-%type <tree.FuncParams> opt_func_param_with_default_list func_param_with_default_list func_params func_params_list
-%type <tree.FuncParam> func_param_with_default func_param
+%type <tree.FuncParams> opt_func_param_with_default_list func_param_with_default_list func_params func_params_list table_func_column_list
+%type <tree.FuncParam> func_param_with_default func_param table_func_column
 %type <tree.ResolvableTypeReference> func_return_type func_param_type
 %type <tree.FunctionOptions> opt_create_func_opt_list create_func_opt_list alter_func_opt_list
 %type <tree.FunctionOption> create_func_opt_item common_func_opt_item
@@ -1679,6 +1782,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <*tree.RoutineBody> opt_routine_body
 %type <tree.FuncObj> function_with_paramtypes
 %type <tree.FuncObjs> function_with_paramtypes_list
+%type <tree.FuncObj> aggregate_with_argtypes
+%type <tree.FuncObjs> aggregate_with_argtypes_list
+%type <*tree.AggregateOptions> aggregate_option aggregate_option_list
 %type <empty> opt_link_sym
 
 %type <*tree.LabelSpec> label_spec
@@ -1725,7 +1831,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 // funny behavior of UNBOUNDED on the SQL standard, though.
 %nonassoc  UNBOUNDED         // ideally should have same precedence as IDENT
 %nonassoc  IDENT NULL PARTITION RANGE ROWS GROUPS PRECEDING FOLLOWING CUBE ROLLUP
-%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT  // multi-character ops
+%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT AT_QUESTION DISTANCE  // multi-character ops
 %left      '|'
 %left      '#'
 %left      '&'
@@ -1773,7 +1879,7 @@ stmt:
 stmt_without_legacy_transaction:
   preparable_stmt            // help texts in sub-rule
 | analyze_stmt               // EXTEND WITH HELP: ANALYZE
-| call_stmt
+| call_stmt                  // EXTEND WITH HELP: CALL
 | copy_stmt
 | comment_stmt
 | execute_stmt               // EXTEND WITH HELP: EXECUTE
@@ -1793,6 +1899,8 @@ stmt_without_legacy_transaction:
 | declare_cursor_stmt        // EXTEND WITH HELP: DECLARE
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
+| listen_stmt
+| notify_stmt
 | reindex_stmt
 | unlisten_stmt
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
@@ -1804,7 +1912,6 @@ alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
 | alter_virtual_cluster_stmt   /* SKIP DOC */
-| alter_unsupported_stmt
 | ALTER error         // SHOW HELP: ALTER
 
 alter_ddl_stmt:
@@ -1817,10 +1924,12 @@ alter_ddl_stmt:
 | alter_partition_stmt          // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt             // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt               // EXTEND WITH HELP: ALTER TYPE
+| alter_domain_stmt             // EXTEND WITH HELP: ALTER DOMAIN
 | alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
+| alter_aggregate_stmt          // EXTEND WITH HELP: ALTER AGGREGATE
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 
 // %Help: ALTER TABLE - change the definition of a table
@@ -1842,6 +1951,7 @@ alter_ddl_stmt:
 //   ALTER TABLE ... RENAME TO <newname>
 //   ALTER TABLE ... RENAME [COLUMN] <colname> TO <newname>
 //   ALTER TABLE ... VALIDATE CONSTRAINT <constraintname>
+//   ALTER TABLE ... [NO] INHERIT <parenttablename>
 //   ALTER TABLE ... SET (storage_param = value, ...)
 //   ALTER TABLE ... SPLIT AT <selectclause> [WITH EXPIRATION <expr>]
 //   ALTER TABLE ... UNSPLIT AT <selectclause>
@@ -2778,17 +2888,15 @@ alter_table_cmd:
   }
   // ALTER TABLE <name> ALTER CONSTRAINT ...
 | ALTER CONSTRAINT constraint_name error { return unimplementedWithIssueDetail(sqllex, 31632, "alter constraint") }
-  // ALTER TABLE <name> INHERITS ....
-| INHERITS error
+  // ALTER TABLE <name> INHERIT <parent>
+| INHERIT table_name
   {
-    /* SKIP DOC */
-    return unimplementedWithIssueDetail(sqllex, 22456, "alter table inherits")
+    $$.val = &tree.AlterTableInherit{Parent: $2.unresolvedObjectName().ToTableName()}
   }
-  // ALTER TABLE <name> NO INHERITS ....
-| NO INHERITS error
+  // ALTER TABLE <name> NO INHERIT <parent>
+| NO INHERIT table_name
   {
-    /* SKIP DOC */
-    return unimplementedWithIssueDetail(sqllex, 22456, "alter table no inherits")
+    $$.val = &tree.AlterTableNoInherit{Parent: $3.unresolvedObjectName().ToTableName()}
   }
   // ALTER TABLE <name> ALTER PRIMARY KEY USING COLUMNS ( <colnames...> )
 | ALTER PRIMARY KEY USING COLUMNS '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -3042,6 +3150,128 @@ alter_type_stmt:
   }
 | ALTER TYPE error // SHOW HELP: ALTER TYPE
 
+// %Help: ALTER DOMAIN - change the definition of a domain
+// %Category: DDL
+// %Text: ALTER DOMAIN <domain_name> <command>
+//
+// Commands:
+//   ALTER DOMAIN ... { SET DEFAULT <expr> | DROP DEFAULT }
+//   ALTER DOMAIN ... { SET | DROP } NOT NULL
+//   ALTER DOMAIN ... ADD [ CONSTRAINT <constraint_name> ] CHECK (<expr>)
+//   ALTER DOMAIN ... DROP CONSTRAINT [ IF EXISTS ] <constraint_name> [ CASCADE | RESTRICT ]
+//   ALTER DOMAIN ... RENAME CONSTRAINT <constraint_name> TO <newname>
+//   ALTER DOMAIN ... RENAME TO <newname>
+//   ALTER DOMAIN ... SET SCHEMA <newschemaname>
+//   ALTER DOMAIN ... OWNER TO {<newowner> | CURRENT_USER | SESSION_USER }
+// %SeeAlso: CREATE DOMAIN, DROP DOMAIN
+alter_domain_stmt:
+  ALTER DOMAIN type_name SET DEFAULT a_expr
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{Default: $6.expr()},
+    }
+  }
+| ALTER DOMAIN type_name DROP DEFAULT
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{},
+    }
+  }
+| ALTER DOMAIN type_name SET NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: true},
+    }
+  }
+| ALTER DOMAIN type_name DROP NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: false},
+    }
+  }
+| ALTER DOMAIN type_name ADD CONSTRAINT constraint_name CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Constraint: tree.DomainConstraint{
+          Name: tree.Name($6),
+          Check: $9.expr(),
+          Nullability: tree.SilentNull,
+        },
+      },
+    }
+  }
+| ALTER DOMAIN type_name ADD CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Constraint: tree.DomainConstraint{
+          Check: $7.expr(),
+          Nullability: tree.SilentNull,
+        },
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($6),
+        DropBehavior: $7.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT IF EXISTS constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($8),
+        IfExists: true,
+        DropBehavior: $9.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name RENAME CONSTRAINT constraint_name TO constraint_name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainRenameConstraint{
+        Constraint: tree.Name($6),
+        NewName: tree.Name($8),
+      },
+    }
+  }
+| ALTER DOMAIN type_name RENAME TO name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainRename{NewName: tree.Name($6)},
+    }
+  }
+| ALTER DOMAIN type_name SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetSchema{Schema: tree.Name($6)},
+    }
+  }
+| ALTER DOMAIN type_name OWNER TO role_spec
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainOwner{Owner: $6.roleSpec()},
+    }
+  }
+| ALTER DOMAIN error // SHOW HELP: ALTER DOMAIN
+
 opt_add_val_placement:
   BEFORE SCONST
   {
@@ -3833,16 +4063,6 @@ import_format:
     $$ = strings.ToUpper($1)
   }
 
-alter_unsupported_stmt:
-  ALTER DOMAIN error
-  {
-    return unimplemented(sqllex, "alter domain")
-  }
-| ALTER AGGREGATE error
-  {
-    return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
-  }
-
 // %Help: IMPORT - load data from file in a distributed manner
 // %Category: CCL
 // %Text:
@@ -3991,9 +4211,16 @@ opt_with_options:
     $$.val = nil
   }
 
-// CALL invokes a stored procedure. It is not currently supported in CRDB.
+// %Help: CALL - invoke a procedure
+// %Category: Misc
+// %Text: CALL <name> ( [ <expr> [, ...] ] )
+// %SeeAlso: CREATE PROCEDURE
 call_stmt:
-  CALL error { return unimplementedWithIssueDetail(sqllex, 17511, "call procedure") }
+  CALL func_application
+  {
+    $$.val = &tree.Call{Proc: $2.expr().(*tree.FuncExpr)}
+  }
+| CALL error // SHOW HELP: CALL
 
 // The COPY grammar in postgres has 3 different versions, all of which are supported by postgres:
 // 1) The "really old" syntax from v7.2 and prior
@@ -4110,9 +4337,9 @@ copy_options:
   {
     return unimplementedWithIssueDetail(sqllex, 41608, "oids")
   }
-| FREEZE error
+| FREEZE
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "freeze")
+    $$.val = &tree.CopyOptions{Freeze: true, HasFreeze: true}
   }
 | HEADER
   {
@@ -4126,21 +4353,25 @@ copy_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE QUOTE error
+| FORCE QUOTE '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuoteAll: true}
   }
-| FORCE NOT NULL error
+| FORCE QUOTE name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceQuote: $3.nameList()}
   }
-| FORCE NULL error
+| FORCE NOT NULL name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: $4.nameList()}
   }
-| ENCODING SCONST error
+| FORCE NULL name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "encoding")
+    $$.val = &tree.CopyOptions{ForceNull: $3.nameList()}
+  }
+| ENCODING SCONST
+  {
+    $$.val = &tree.CopyOptions{Encoding: tree.NewStrVal($2)}
   }
 
 copy_generic_options:
@@ -4187,9 +4418,17 @@ copy_generic_options:
   {
     return unimplementedWithIssueDetail(sqllex, 41608, "oids")
   }
-| FREEZE error
+| FREEZE
+  {
+    $$.val = &tree.CopyOptions{Freeze: true, HasFreeze: true}
+  }
+| FREEZE TRUE
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "freeze")
+    $$.val = &tree.CopyOptions{Freeze: true, HasFreeze: true}
+  }
+| FREEZE FALSE
+  {
+    $$.val = &tree.CopyOptions{Freeze: false, HasFreeze: true}
   }
 | HEADER
   {
@@ -4211,21 +4450,25 @@ copy_generic_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE_QUOTE error
+| FORCE_QUOTE '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuoteAll: true}
   }
-| FORCE_NOT_NULL error
+| FORCE_QUOTE '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceQuote: $3.nameList()}
   }
-| FORCE_NULL error
+| FORCE_NOT_NULL '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: $3.nameList()}
   }
-| ENCODING SCONST error
+| FORCE_NULL '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "encoding")
+    $$.val = &tree.CopyOptions{ForceNull: $3.nameList()}
+  }
+| ENCODING SCONST
+  {
+    $$.val = &tree.CopyOptions{Encoding: tree.NewStrVal($2)}
   }
 
 // %Help: CANCEL
@@ -4384,7 +4627,14 @@ comment_stmt:
     $$.val = &tree.CommentOnConstraint{Constraint:tree.Name($4), Table: $6.unresolvedObjectName(), Comment: $8.strPtr()}
   }
 | COMMENT ON EXTENSION error { return unimplementedWithIssueDetail(sqllex, 74777, "comment on extension") }
-| COMMENT ON FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "comment on function") }
+| COMMENT ON FUNCTION function_with_paramtypes IS comment_text
+  {
+    $$.val = &tree.CommentOnFunction{Function: $4.functionObj(), Comment: $6.strPtr()}
+  }
+| COMMENT ON PROCEDURE function_with_paramtypes IS comment_text
+  {
+    $$.val = &tree.CommentOnFunction{Function: $4.functionObj(), IsProcedure: true, Comment: $6.strPtr()}
+  }
 
 comment_text:
   SCONST
@@ -4553,7 +4803,8 @@ create_extension_stmt:
 // %Text:
 // CREATE [ OR REPLACE ] FUNCTION
 //    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
-//    [ RETURNS rettype ]
+//    [ RETURNS rettype
+//      | RETURNS TABLE ( column_name column_type [, ...] ) ]
 //  { LANGUAGE lang_name
 //    | { IMMUTABLE | STABLE | VOLATILE }
 //    | [ NOT ] LEAKPROOF
@@ -4563,7 +4814,7 @@ create_extension_stmt:
 // %SeeAlso: WEBDOCS/create-function.html
 create_func_stmt:
   CREATE opt_or_replace FUNCTION func_create_name '(' opt_func_param_with_default_list ')'
-  RETURNS opt_return_table opt_return_set func_return_type
+  RETURNS opt_return_set func_return_type
   opt_create_func_opt_list opt_routine_body
   {
     name := $4.unresolvedObjectName().ToFunctionName()
@@ -4573,23 +4824,198 @@ create_func_stmt:
       FuncName: name,
       Params: $6.functionParams(),
       ReturnType: tree.FuncReturnType{
-        Type: $11.typeReference(),
-        IsSet: $10.bool(),
+        Type: $10.typeReference(),
+        IsSet: $9.bool(),
+      },
+      Options: $11.functionOptions(),
+      RoutineBody: $12.routineBody(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION func_create_name '(' opt_func_param_with_default_list ')'
+  RETURNS TABLE '(' table_func_column_list ')'
+  opt_create_func_opt_list opt_routine_body
+  {
+    // RETURNS TABLE is shorthand for OUT parameters along with RETURNS SETOF.
+    params := $6.functionParams()
+    for _, param := range params {
+      if param.Class.IsOutParam() {
+        return setErr(sqllex, pgerror.New(pgcode.InvalidFunctionDefinition,
+          "OUT and INOUT arguments aren't allowed in TABLE functions"))
+      }
+    }
+    params = append(params, $11.functionParams()...)
+    returnType, err := tree.ReturnTypeFromOutParams(params)
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    name := $4.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.CreateFunction{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      FuncName: name,
+      Params: params,
+      ReturnType: tree.FuncReturnType{
+        Type: returnType,
+        IsSet: true,
       },
-      Options: $12.functionOptions(),
-      RoutineBody: $13.routineBody(),
+      Options: $13.functionOptions(),
+      RoutineBody: $14.routineBody(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION func_create_name '(' opt_func_param_with_default_list ')'
+  opt_create_func_opt_list opt_routine_body
+  {
+    // The return type of a function without a RETURNS clause is determined by
+    // its OUT parameters.
+    params := $6.functionParams()
+    returnType, err := tree.ReturnTypeFromOutParams(params)
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    name := $4.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.CreateFunction{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      FuncName: name,
+      Params: params,
+      ReturnType: tree.FuncReturnType{
+        Type: returnType,
+      },
+      Options: $8.functionOptions(),
+      RoutineBody: $9.routineBody(),
     }
   }
 | CREATE opt_or_replace FUNCTION error // SHOW HELP: CREATE FUNCTION
 
+// %Help: CREATE PROCEDURE - define a new procedure
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] PROCEDURE
+//    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//  { LANGUAGE lang_name
+//    | AS 'definition'
+//  } ...
+// %SeeAlso: CALL, DROP PROCEDURE
+create_proc_stmt:
+  CREATE opt_or_replace PROCEDURE func_create_name '(' opt_func_param_with_default_list ')'
+  opt_create_func_opt_list opt_routine_body
+  {
+    // A procedure with output parameters returns their values as a single
+    // row. Otherwise, it returns nothing.
+    params := $6.functionParams()
+    var returnType tree.ResolvableTypeReference = types.Void
+    for _, param := range params {
+      if param.Class.IsOutParam() {
+        var err error
+        if returnType, err = tree.ReturnTypeFromOutParams(params); err != nil {
+          return setErr(sqllex, err)
+        }
+        break
+      }
+    }
+    name := $4.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.CreateFunction{
+      IsProcedure: true,
+      Replace: $2.bool(),
+      FuncName: name,
+      Params: params,
+      ReturnType: tree.FuncReturnType{
+        Type: returnType,
+      },
+      Options: $8.functionOptions(),
+      RoutineBody: $9.routineBody(),
+    }
+  }
+| CREATE opt_or_replace PROCEDURE error // SHOW HELP: CREATE PROCEDURE
+
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE
+//    name ( [ [ argmode ] [ argname ] argtype [, ...] ] | * ) (
+//    SFUNC = sfunc,
+//    STYPE = state_data_type
+//    [ , FINALFUNC = ffunc ]
+//    [ , COMBINEFUNC = combinefunc ]
+//    [ , INITCOND = initial_condition ]
+// )
+// %SeeAlso: DROP AGGREGATE, ALTER AGGREGATE
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE func_create_name '(' '*' ')' '(' aggregate_option_list ')'
+  {
+    options := $9.aggregateOptions()
+    if err := validateAggregateOptions(options); err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = &tree.CreateAggregate{
+      Replace: $2.bool(),
+      Name: $4.unresolvedObjectName().ToFunctionName(),
+      Params: tree.FuncParams{},
+      Options: options,
+    }
+  }
+| CREATE opt_or_replace AGGREGATE func_create_name '(' func_params_list ')' '(' aggregate_option_list ')'
+  {
+    options := $9.aggregateOptions()
+    if err := validateAggregateOptions(options); err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = &tree.CreateAggregate{
+      Replace: $2.bool(),
+      Name: $4.unresolvedObjectName().ToFunctionName(),
+      Params: $6.functionParams(),
+      Options: options,
+    }
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_option_list:
+  aggregate_option
+  {
+    $$.val = $1.aggregateOptions()
+  }
+| aggregate_option_list ',' aggregate_option
+  {
+    if err := $1.aggregateOptions().CombineWith($3.aggregateOptions()); err != nil {
+      return setErr(sqllex, err)
+    }
+  }
+
+aggregate_option:
+  SFUNC '=' db_object_name
+  {
+    name := $3.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.AggregateOptions{TransitionFunc: &name}
+  }
+| STYPE '=' typename
+  {
+    $$.val = &tree.AggregateOptions{StateType: $3.typeReference()}
+  }
+| FINALFUNC '=' db_object_name
+  {
+    name := $3.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.AggregateOptions{FinalFunc: &name}
+  }
+| COMBINEFUNC '=' db_object_name
+  {
+    name := $3.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.AggregateOptions{CombineFunc: &name}
+  }
+| INITCOND '=' SCONST
+  {
+    initCond := $3
+    $$.val = &tree.AggregateOptions{InitCond: &initCond}
+  }
+| INITCOND '=' numeric_only
+  {
+    initCond := tree.AsString($3.expr())
+    $$.val = &tree.AggregateOptions{InitCond: &initCond}
+  }
+
 opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
 
-opt_return_table:
-  TABLE { return unimplementedWithIssueDetail(sqllex, 100226, "UDF returning TABLE") }
-| /* EMPTY */ { $$.val = false }
-
 opt_return_set:
   SETOF { $$.val = true}
 | /* EMPTY */ { $$.val = false }
@@ -4665,14 +5091,31 @@ func_param:
 
 func_param_class:
   IN { $$.val = tree.FunctionParamIn }
-| OUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'OUT' argument class") }
-| INOUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'INOUT' argument class") }
-| IN OUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'IN OUT' argument class") }
-| VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
+| OUT { $$.val = tree.FunctionParamOut }
+| INOUT { $$.val = tree.FunctionParamInOut }
+| IN OUT { $$.val = tree.FunctionParamInOut }
+| VARIADIC { $$.val = tree.FunctionParamVariadic }
 
 func_param_type:
   typename
 
+table_func_column_list:
+  table_func_column { $$.val = tree.FuncParams{$1.functionParam()} }
+| table_func_column_list ',' table_func_column
+  {
+    $$.val = append($1.functionParams(), $3.functionParam())
+  }
+
+table_func_column:
+  param_name func_param_type
+  {
+    $$.val = tree.FuncParam{
+      Name: tree.Name($1),
+      Type: $2.typeReference(),
+      Class: tree.FunctionParamOut,
+    }
+  }
+
 func_return_type:
   func_param_type
 
@@ -4850,6 +5293,84 @@ drop_func_stmt:
   }
 | DROP FUNCTION error // SHOW HELP: DROP FUNCTION
 
+// %Help: DROP PROCEDURE - remove a procedure
+// %Category: DDL
+// %Text:
+// DROP PROCEDURE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE PROCEDURE
+drop_proc_stmt:
+  DROP PROCEDURE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsProcedure: true,
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsProcedure: true,
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
+
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name ( { [ argmode ] [ argname ] argtype [, ...] | * } ) [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE aggregate_with_argtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsAggregate: true,
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS aggregate_with_argtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsAggregate: true,
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
+aggregate_with_argtypes_list:
+  aggregate_with_argtypes
+  {
+    $$.val = tree.FuncObjs{$1.functionObj()}
+  }
+| aggregate_with_argtypes_list ',' aggregate_with_argtypes
+  {
+    $$.val = append($1.functionObjs(), $3.functionObj())
+  }
+
+aggregate_with_argtypes:
+  db_object_name '(' '*' ')'
+  {
+    $$.val = tree.FuncObj{
+      FuncName: $1.unresolvedObjectName().ToFunctionName(),
+      Params: tree.FuncParams{},
+    }
+  }
+| db_object_name func_params
+  {
+    $$.val = tree.FuncObj{
+      FuncName: $1.unresolvedObjectName().ToFunctionName(),
+      Params: $2.functionParams(),
+    }
+  }
+
 function_with_paramtypes_list:
   function_with_paramtypes
   {
@@ -4945,6 +5466,40 @@ alter_func_owner_stmt:
     }
   }
 
+// %Help: ALTER AGGREGATE - change the definition of an aggregate function
+// %Category: DDL
+// %Text:
+// ALTER AGGREGATE name ( { [ argmode ] [ argname ] argtype [, ...] | * } ) RENAME TO new_name
+// ALTER AGGREGATE name ( { [ argmode ] [ argname ] argtype [, ...] | * } ) OWNER TO new_owner
+// ALTER AGGREGATE name ( { [ argmode ] [ argname ] argtype [, ...] | * } ) SET SCHEMA new_schema
+// %SeeAlso: CREATE AGGREGATE, DROP AGGREGATE
+alter_aggregate_stmt:
+  ALTER AGGREGATE aggregate_with_argtypes RENAME TO name
+  {
+    $$.val = &tree.AlterFunctionRename{
+      IsAggregate: true,
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+    }
+  }
+| ALTER AGGREGATE aggregate_with_argtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterFunctionSetOwner{
+      IsAggregate: true,
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+    }
+  }
+| ALTER AGGREGATE aggregate_with_argtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterFunctionSetSchema{
+      IsAggregate: true,
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+    }
+  }
+| ALTER AGGREGATE error // SHOW HELP: ALTER AGGREGATE
+
 alter_func_dep_extension_stmt:
   ALTER FUNCTION function_with_paramtypes opt_no DEPENDS ON EXTENSION name
   {
@@ -4965,19 +5520,52 @@ opt_no:
     $$.val = false
   }
 
+// %Help: CREATE PUBLICATION - create a publication for logical replication
+// %Category: DDL
+// %Text:
+// CREATE PUBLICATION <name>
+// CREATE PUBLICATION <name> FOR ALL TABLES
+// CREATE PUBLICATION <name> FOR TABLE <tablename> [, ...]
+// %SeeAlso: DROP PUBLICATION
+create_publication_stmt:
+  CREATE PUBLICATION name
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3)}
+  }
+| CREATE PUBLICATION name FOR ALL TABLES
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3), AllTables: true}
+  }
+| CREATE PUBLICATION name FOR TABLE table_name_list
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3), Tables: $6.tableNames()}
+  }
+| CREATE PUBLICATION error // SHOW HELP: CREATE PUBLICATION
+
+// %Help: DROP PUBLICATION - remove a publication
+// %Category: DDL
+// %Text: DROP PUBLICATION [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE PUBLICATION
+drop_publication_stmt:
+  DROP PUBLICATION name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{Names: $3.nameList(), DropBehavior: $4.dropBehavior()}
+  }
+| DROP PUBLICATION IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{Names: $5.nameList(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+| DROP PUBLICATION error // SHOW HELP: DROP PUBLICATION
+
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
-| CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
 | CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
-| CREATE opt_or_replace PROCEDURE error { return unimplementedWithIssueDetail(sqllex, 17511, "create procedure") }
-| CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
 | CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
@@ -4995,18 +5583,14 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
-| DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
-| DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
 | DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
@@ -5022,9 +5606,14 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
+| create_foreign_table_stmt // EXTEND WITH HELP: CREATE FOREIGN TABLE
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
+| create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
+| create_publication_stmt // EXTEND WITH HELP: CREATE PUBLICATION
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -5406,10 +5995,15 @@ drop_ddl_stmt:
 | drop_index_stmt    // EXTEND WITH HELP: DROP INDEX
 | drop_table_stmt    // EXTEND WITH HELP: DROP TABLE
 | drop_view_stmt     // EXTEND WITH HELP: DROP VIEW
+| drop_foreign_table_stmt // EXTEND WITH HELP: DROP FOREIGN TABLE
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_proc_stmt     // EXTEND WITH HELP: DROP PROCEDURE
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
+| drop_publication_stmt // EXTEND WITH HELP: DROP PUBLICATION
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5444,6 +6038,31 @@ drop_view_stmt:
   }
 | DROP VIEW error // SHOW HELP: DROP VIEW
 
+// %Help: DROP FOREIGN TABLE - remove a foreign table
+// %Category: DDL
+// %Text: DROP FOREIGN TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE FOREIGN TABLE
+drop_foreign_table_stmt:
+  DROP FOREIGN TABLE table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropView{
+      Names: $4.tableNames(),
+      IfExists: false,
+      DropBehavior: $5.dropBehavior(),
+      IsForeignTable: true,
+    }
+  }
+| DROP FOREIGN TABLE IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropView{
+      Names: $6.tableNames(),
+      IfExists: true,
+      DropBehavior: $7.dropBehavior(),
+      IsForeignTable: true,
+    }
+  }
+| DROP FOREIGN TABLE error // SHOW HELP: DROP FOREIGN TABLE
+
 // %Help: DROP SEQUENCE - remove a sequence
 // %Category: DDL
 // %Text: DROP SEQUENCE [IF EXISTS] <sequenceName> [, ...] [CASCADE | RESTRICT]
@@ -5520,29 +6139,52 @@ drop_database_stmt:
       DropBehavior: $6.dropBehavior(),
     }
   }
-| DROP DATABASE error // SHOW HELP: DROP DATABASE
+| DROP DATABASE error // SHOW HELP: DROP DATABASE
+
+// %Help: DROP TYPE - remove a type
+// %Category: DDL
+// %Text: DROP TYPE [IF EXISTS] <type_name> [, ...] [CASCASE | RESTRICT]
+drop_type_stmt:
+  DROP TYPE type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP TYPE IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TYPE error // SHOW HELP: DROP TYPE
 
-// %Help: DROP TYPE - remove a type
+// %Help: DROP DOMAIN - remove a domain
 // %Category: DDL
-// %Text: DROP TYPE [IF EXISTS] <type_name> [, ...] [CASCASE | RESTRICT]
-drop_type_stmt:
-  DROP TYPE type_name_list opt_drop_behavior
+// %Text: DROP DOMAIN [IF EXISTS] <domain_name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, ALTER DOMAIN
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
   {
-    $$.val = &tree.DropType{
+    $$.val = &tree.DropDomain{
       Names: $3.unresolvedObjectNames(),
       IfExists: false,
       DropBehavior: $4.dropBehavior(),
     }
   }
-| DROP TYPE IF EXISTS type_name_list opt_drop_behavior
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
   {
-    $$.val = &tree.DropType{
+    $$.val = &tree.DropDomain{
       Names: $5.unresolvedObjectNames(),
       IfExists: true,
       DropBehavior: $6.dropBehavior(),
     }
   }
-| DROP TYPE error // SHOW HELP: DROP TYPE
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
 
 // %Help: DROP VIRTUAL CLUSTER - remove a virtual cluster
 // %Category: Experimental
@@ -5764,6 +6406,7 @@ preparable_stmt:
 | explain_stmt   // EXTEND WITH HELP: EXPLAIN
 | import_stmt    // EXTEND WITH HELP: IMPORT
 | insert_stmt    // EXTEND WITH HELP: INSERT
+| merge_stmt     // EXTEND WITH HELP: MERGE
 | pause_stmt     // help texts in sub-rule
 | reset_stmt     // help texts in sub-rule
 | restore_stmt   // EXTEND WITH HELP: RESTORE
@@ -6442,7 +7085,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -6896,6 +7539,37 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set the checking mode of deferrable constraints
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// Deferred constraints are not checked until the transaction commits.
+// Switching a constraint to IMMEDIATE checks any pending violations
+// right away.
+//
+// %SeeAlso: SET TRANSACTION, CREATE TABLE
+set_constraints_stmt:
+  SET CONSTRAINTS ALL constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS name_list constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+constraints_set_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
+  }
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -9270,8 +9944,8 @@ alter_schema_stmt:
 // %Help: CREATE TABLE - create a new table
 // %Category: DDL
 // %Text:
-// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> ( <elements...> ) [<on_commit>]
-// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source> [<on commit>]
+// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> ( <elements...> ) [INHERITS ( <tablenames...> )] [<on_commit>]
+// CREATE [[GLOBAL | LOCAL] {TEMPORARY | TEMP}] TABLE [IF NOT EXISTS] <tablename> [( <colnames...> )] AS <source> [WITH [NO] DATA] [<on commit>]
 //
 // Table elements:
 //    <name> <type> [<qualifiers...>]
@@ -9279,6 +9953,7 @@ alter_schema_stmt:
 //                            [USING HASH] [{STORING | INCLUDE | COVERING} ( <colnames...> )]
 //    FAMILY [<name>] ( <colnames...> )
 //    [CONSTRAINT <name>] <constraint>
+//    LIKE <tablename> [{INCLUDING | EXCLUDING} {ALL | COMMENTS | CONSTRAINTS | DEFAULTS | GENERATED | IDENTITY | INDEXES | STATISTICS | STORAGE} ...]
 //
 // Table constraints:
 //    PRIMARY KEY ( <colnames...> ) [USING HASH]
@@ -9313,6 +9988,7 @@ create_table_stmt:
       StorageParams: $10.storageParams(),
       OnCommit: $11.createTableOnCommitSetting(),
       Locality: $12.locality(),
+      Inherits: $8.tableNames(),
     }
   }
 | CREATE opt_persistence_temp_table TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' opt_create_table_inherits opt_partition_by_table opt_table_with opt_create_table_on_commit opt_locality
@@ -9328,6 +10004,7 @@ create_table_stmt:
       StorageParams: $13.storageParams(),
       OnCommit: $14.createTableOnCommitSetting(),
       Locality: $15.locality(),
+      Inherits: $11.tableNames(),
     }
   }
 
@@ -9357,12 +10034,11 @@ opt_table_with:
 opt_create_table_inherits:
   /* EMPTY */
   {
-    $$ = ""
+    $$.val = tree.TableNames(nil)
   }
-| INHERITS error
+| INHERITS '(' table_name_list ')'
   {
-    /* SKIP DOC */
-    return unimplementedWithIssueDetail(sqllex, 22456, "create table inherits")
+    $$.val = $3.tableNames()
   }
 
 opt_with_storage_parameter_list:
@@ -9430,6 +10106,7 @@ create_table_as_stmt:
       IfNotExists: false,
       Defs: $5.tblDefs(),
       AsSource: $8.slct(),
+      AsWithNoData: $9.bool(),
       StorageParams: $6.storageParams(),
       OnCommit: $10.createTableOnCommitSetting(),
       Persistence: $2.persistence(),
@@ -9443,6 +10120,7 @@ create_table_as_stmt:
       IfNotExists: true,
       Defs: $8.tblDefs(),
       AsSource: $11.slct(),
+      AsWithNoData: $12.bool(),
       StorageParams: $9.storageParams(),
       OnCommit: $13.createTableOnCommitSetting(),
       Persistence: $2.persistence(),
@@ -9450,9 +10128,9 @@ create_table_as_stmt:
   }
 
 opt_create_as_data:
-  /* EMPTY */  { /* no error */ }
-| WITH DATA    { /* SKIP DOC */ /* This is the default */ }
-| WITH NO DATA { return unimplemented(sqllex, "create table as with no data") }
+  /* EMPTY */  { $$.val = false }
+| WITH DATA    { /* SKIP DOC */ /* This is the default */ $$.val = false }
+| WITH NO DATA { $$.val = true }
 
 /*
  * Redundancy here is needed to avoid shift/reduce conflicts,
@@ -9544,14 +10222,14 @@ like_table_option_list:
   }
 
 like_table_option:
-  COMMENTS			{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding comments") }
+  COMMENTS			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptComments} }
 | CONSTRAINTS		{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptConstraints} }
 | DEFAULTS			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptDefaults} }
-| IDENTITY	  	{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding identity") }
+| IDENTITY	  	{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptIdentity} }
 | GENERATED			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptGenerated} }
 | INDEXES			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptIndexes} }
-| STATISTICS		{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding statistics") }
-| STORAGE			{ return unimplementedWithIssueDetail(sqllex, 47071, "like table in/excluding storage") }
+| STATISTICS		{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptStatistics} }
+| STORAGE			{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptStorage} }
 | ALL				{ $$.val = tree.LikeTableOption{Opt: tree.LikeTableOptAll} }
 
 
@@ -9717,6 +10395,10 @@ col_qualification:
   {
     $$.val = tree.NamedColumnQualification{Qualification: $1.colQualElem()}
   }
+| constraint_attr
+  {
+    $$.val = tree.NamedColumnQualification{Qualification: $1.constraintAttribute()}
+  }
 | COLLATE collation_name
   {
     $$.val = tree.NamedColumnQualification{Qualification: tree.ColumnCollation($2)}
@@ -9958,6 +10640,10 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability().IsDeferrable() {
+      return setErr(sqllex, pgerror.New(pgcode.FeatureNotSupported,
+        "CHECK constraints cannot be marked DEFERRABLE"))
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -9973,6 +10659,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -9996,11 +10683,55 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
     }
   }
-| EXCLUDE USING error
+| EXCLUDE opt_index_access_method '(' exclude_elem_list ')' opt_where_clause
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    $$.val = &tree.ExcludeConstraintTableDef{
+      Inverted: $2.bool(),
+      Elems: $4.excludeElems(),
+      Predicate: $6.expr(),
+    }
+  }
+
+exclude_elem_list:
+  exclude_elem
+  {
+    $$.val = tree.ExcludeElemList{$1.excludeElem()}
+  }
+| exclude_elem_list ',' exclude_elem
+  {
+    $$.val = append($1.excludeElems(), $3.excludeElem())
+  }
+
+exclude_elem:
+  index_elem WITH exclude_op
+  {
+    $$.val = tree.ExcludeElem{IndexElem: $1.idxElem(), Operator: $3.cmpOp()}
+  }
+| '(' name ',' name ')' WITH OVERLAPS
+  {
+    $$.val = tree.ExcludeElem{
+      IndexElem: tree.IndexElem{Column: tree.Name($2)},
+      PeriodEnd: tree.Name($4),
+      Operator: treecmp.MakeComparisonOperator(treecmp.Overlaps),
+    }
+  }
+
+// exclude_op lists the operators supported by EXCLUDE constraints.
+exclude_op:
+  '='
+  {
+    $$.val = treecmp.MakeComparisonOperator(treecmp.EQ)
+  }
+| AND_AND
+  {
+    $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps)
+  }
+| error
+  {
+    return unimplementedWithIssueDetail(sqllex, 46657, "exclude operator")
   }
 
 
@@ -10109,12 +10840,59 @@ create_as_col_qualification_elem:
   }
 
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| INITIALLY DEFERRED DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| INITIALLY IMMEDIATE DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+
+// constraint_attr is a DEFERRABLE-style clause on a column definition. Unlike
+// the table constraint forms, each keyword pair is a separate column
+// qualification so that NOT DEFERRABLE does not conflict with NOT NULL.
+constraint_attr:
+  DEFERRABLE
+  {
+    $$.val = tree.ConstraintAttrDeferrable
+  }
+| NOT DEFERRABLE
+  {
+    $$.val = tree.ConstraintAttrNotDeferrable
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintAttrInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintAttrInitiallyImmediate
+  }
 
 storing:
   COVERING
@@ -10487,20 +11265,92 @@ role_or_group_or_user:
     $$.val = false
   }
 
+// %Help: CREATE FOREIGN TABLE - create a table over files in external storage
+// %Category: DDL
+// %Text:
+// CREATE FOREIGN TABLE [IF NOT EXISTS] <tablename> ( <colname> <coltype> [, ...] )
+//   [SERVER <external_connection_name>]
+//   OPTIONS ( <option> '<value>' [, ...] )
+//
+// Options:
+//    location   the URI of the file, or its path within the SERVER connection
+//    format     one of csv, parquet or avro
+//    delimiter  the field delimiter of CSV files
+//    nullif     the string that represents NULL in CSV files
+//    skip       the number of header rows to skip in CSV files
+//
+// %SeeAlso: DROP FOREIGN TABLE, CREATE EXTERNAL CONNECTION
+create_foreign_table_stmt:
+  CREATE FOREIGN TABLE table_name '(' opt_table_elem_list ')' opt_foreign_server OPTIONS '(' foreign_table_option_list ')'
+  {
+    $$.val = &tree.CreateForeignTable{
+      Table: $4.unresolvedObjectName().ToTableName(),
+      IfNotExists: false,
+      Defs: $6.tblDefs(),
+      Server: tree.Name($8),
+      Options: $11.kvOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' opt_foreign_server OPTIONS '(' foreign_table_option_list ')'
+  {
+    $$.val = &tree.CreateForeignTable{
+      Table: $7.unresolvedObjectName().ToTableName(),
+      IfNotExists: true,
+      Defs: $9.tblDefs(),
+      Server: tree.Name($11),
+      Options: $14.kvOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE error // SHOW HELP: CREATE FOREIGN TABLE
+
+opt_foreign_server:
+  SERVER name
+  {
+    $$ = $2
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+foreign_table_option_list:
+  foreign_table_option
+  {
+    $$.val = []tree.KVOption{$1.kvOption()}
+  }
+| foreign_table_option_list ',' foreign_table_option
+  {
+    $$.val = append($1.kvOptions(), $3.kvOption())
+  }
+
+foreign_table_option:
+  name SCONST
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.NewStrVal($2)}
+  }
+
 // %Help: CREATE VIEW - create a new view
 // %Category: DDL
 // %Text:
 // CREATE [TEMPORARY | TEMP] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source>
+// CREATE [TEMPORARY | TEMP] RECURSIVE VIEW [IF NOT EXISTS] <viewname> ( <colnames...> ) AS <source>
 // CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source> [WITH [NO] DATA]
 // %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
   CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
   {
     name := $5.unresolvedObjectName().ToTableName()
+    source := $8.slct()
+    if $3.bool() {
+      var err error
+      if source, err = makeRecursiveViewSelect(name.ObjectName, $6.nameList(), source); err != nil {
+        return setErr(sqllex, err)
+      }
+    }
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $6.nameList(),
-      AsSource: $8.slct(),
+      AsSource: source,
       Persistence: $2.persistence(),
       IfNotExists: false,
       Replace: false,
@@ -10511,10 +11361,17 @@ create_view_stmt:
 | CREATE OR REPLACE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
   {
     name := $7.unresolvedObjectName().ToTableName()
+    source := $10.slct()
+    if $5.bool() {
+      var err error
+      if source, err = makeRecursiveViewSelect(name.ObjectName, $8.nameList(), source); err != nil {
+        return setErr(sqllex, err)
+      }
+    }
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $8.nameList(),
-      AsSource: $10.slct(),
+      AsSource: source,
       Persistence: $4.persistence(),
       IfNotExists: false,
       Replace: true,
@@ -10523,10 +11380,17 @@ create_view_stmt:
 | CREATE opt_temp opt_view_recursive VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt
   {
     name := $8.unresolvedObjectName().ToTableName()
+    source := $11.slct()
+    if $3.bool() {
+      var err error
+      if source, err = makeRecursiveViewSelect(name.ObjectName, $9.nameList(), source); err != nil {
+        return setErr(sqllex, err)
+      }
+    }
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $9.nameList(),
-      AsSource: $11.slct(),
+      AsSource: source,
       Persistence: $2.persistence(),
       IfNotExists: true,
       Replace: false,
@@ -10702,8 +11566,8 @@ valid_until_clause:
   }
 
 opt_view_recursive:
-  /* EMPTY */ { /* no error */ }
-| RECURSIVE { return unimplemented(sqllex, "create recursive view") }
+  /* EMPTY */ { $$.val = false }
+| RECURSIVE { $$.val = true }
 
 
 // %Help: CREATE TYPE - create a type
@@ -10753,8 +11617,32 @@ create_type_stmt:
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <domain_name> [AS] <type>
+//   [ DEFAULT <expr> ]
+//   [ [ CONSTRAINT <constraint_name> ] { NOT NULL | NULL | CHECK (<expr>) } ] [...]
+// %SeeAlso: ALTER DOMAIN, DROP DOMAIN
+create_domain_stmt:
+  CREATE DOMAIN type_name AS typename col_qual_list
+  {
+    d, err := tree.NewCreateDomain($3.unresolvedObjectName(), $5.typeReference(), $6.colQuals())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = d
+  }
+| CREATE DOMAIN type_name typename col_qual_list
+  {
+    d, err := tree.NewCreateDomain($3.unresolvedObjectName(), $4.typeReference(), $5.colQuals())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = d
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
 
 opt_enum_val_list:
   enum_val_list
@@ -12047,6 +12935,98 @@ upsert_stmt:
   }
 | opt_with_clause UPSERT error // SHOW HELP: UPSERT
 
+// %Help: MERGE - conditionally insert, update or delete rows in a table
+// %Category: DML
+// %Text:
+// MERGE INTO <tablename> [[AS] <name>]
+//       USING <source> ON <join_condition>
+//       WHEN MATCHED [AND <expr>] THEN { UPDATE SET ... | DELETE | DO NOTHING }
+//       WHEN NOT MATCHED [AND <expr>] THEN {
+//         INSERT [( <colnames...> )] { VALUES ( <exprs...> ) | DEFAULT VALUES } |
+//         DO NOTHING
+//       }
+//       [...]
+//       [RETURNING <exprs...>]
+// %SeeAlso: INSERT, UPSERT, UPDATE, DELETE
+merge_stmt:
+  opt_with_clause MERGE INTO table_expr_opt_alias_idx USING table_ref ON a_expr merge_when_list returning_clause
+  {
+    $$.val = &tree.Merge{
+      With: $1.with(),
+      Table: $4.tblExpr(),
+      Source: $6.tblExpr(),
+      On: $8.expr(),
+      Whens: $9.mergeWhens(),
+      Returning: $10.retClause(),
+    }
+  }
+| opt_with_clause MERGE error // SHOW HELP: MERGE
+
+merge_when_list:
+  merge_when_clause
+  {
+    $$.val = tree.MergeWhens{$1.mergeWhen()}
+  }
+| merge_when_list merge_when_clause
+  {
+    $$.val = append($1.mergeWhens(), $2.mergeWhen())
+  }
+
+merge_when_clause:
+  WHEN MATCHED opt_merge_when_cond THEN merge_matched_action
+  {
+    $$.val = $5.mergeWhen()
+    $$.val.(*tree.MergeWhen).Matched = true
+    $$.val.(*tree.MergeWhen).Cond = $3.expr()
+  }
+| WHEN NOT MATCHED opt_merge_when_cond THEN merge_not_matched_action
+  {
+    $$.val = $6.mergeWhen()
+    $$.val.(*tree.MergeWhen).Cond = $4.expr()
+  }
+
+opt_merge_when_cond:
+  AND a_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+merge_matched_action:
+  UPDATE SET set_clause_list
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeUpdate, Exprs: $3.updateExprs()}
+  }
+| DELETE
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeDelete}
+  }
+| DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeDoNothing}
+  }
+
+merge_not_matched_action:
+  INSERT VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeInsert, Values: $4.exprs()}
+  }
+| INSERT '(' insert_column_list ')' VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeInsert, Columns: $3.nameList(), Values: $7.exprs()}
+  }
+| INSERT DEFAULT VALUES
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeInsert}
+  }
+| DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeDoNothing}
+  }
+
 insert_target:
   table_name
   {
@@ -12864,9 +13844,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.SetsGroupingSet, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -13044,6 +14033,7 @@ opt_index_flags:
 //   <source> NATURAL [ <jointype> ] JOIN <source>
 //   <source> CROSS JOIN <source>
 //   <source> WITH ORDINALITY
+//   <tablename> [AS <alias>] TABLESAMPLE { BERNOULLI | SYSTEM } ( <percent> ) [REPEATABLE ( <seed> )]
 //   '[' EXPLAIN ... ']'
 //   '[' SHOW ... ']'
 //
@@ -13070,7 +14060,7 @@ table_ref:
         As:         $4.aliasClause(),
     }
   }
-| relation_expr opt_index_flags opt_ordinality opt_alias_clause
+| table_name opt_index_flags opt_ordinality opt_alias_clause opt_tablesample_clause
   {
     name := $1.unresolvedObjectName().ToTableName()
     $$.val = &tree.AliasedTableExpr{
@@ -13078,6 +14068,44 @@ table_ref:
       IndexFlags: $2.indexFlags(),
       Ordinality: $3.bool(),
       As:         $4.aliasClause(),
+      Sample:     $5.tableSample(),
+    }
+  }
+| table_name '*' opt_index_flags opt_ordinality opt_alias_clause opt_tablesample_clause
+  {
+    /* SKIP DOC */
+    name := $1.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{
+      Expr:       &name,
+      IndexFlags: $3.indexFlags(),
+      Ordinality: $4.bool(),
+      As:         $5.aliasClause(),
+      Sample:     $6.tableSample(),
+    }
+  }
+| ONLY table_name opt_index_flags opt_ordinality opt_alias_clause opt_tablesample_clause
+  {
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{
+      Expr:       &name,
+      IndexFlags: $3.indexFlags(),
+      Ordinality: $4.bool(),
+      As:         $5.aliasClause(),
+      Sample:     $6.tableSample(),
+      Only:       true,
+    }
+  }
+| ONLY '(' table_name ')' opt_index_flags opt_ordinality opt_alias_clause opt_tablesample_clause
+  {
+    /* SKIP DOC */
+    name := $3.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{
+      Expr:       &name,
+      IndexFlags: $5.indexFlags(),
+      Ordinality: $6.bool(),
+      As:         $7.aliasClause(),
+      Sample:     $8.tableSample(),
+      Only:       true,
     }
   }
 | select_with_parens opt_ordinality opt_alias_clause
@@ -13254,6 +14282,30 @@ opt_ordinality:
     $$.val = false
   }
 
+opt_tablesample_clause:
+  TABLESAMPLE name '(' a_expr ')' opt_repeatable_clause
+  {
+    method, err := tree.TableSampleMethodFromString($2)
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = &tree.TableSample{Method: method, Percent: $4.expr(), Seed: $6.expr()}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.TableSample)(nil)
+  }
+
+opt_repeatable_clause:
+  REPEATABLE '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
 // It may seem silly to separate joined_table from table_ref, but there is
 // method in SQL's madness: if you don't do it this way you get reduce- reduce
 // conflicts, because it's not clear to the parser generator whether to expect
@@ -13441,6 +14493,24 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
+// LISTEN
+listen_stmt:
+  LISTEN type_name
+  {
+    $$.val = &tree.Listen{ChannelName: $2.unresolvedObjectName()}
+  }
+
+// NOTIFY
+notify_stmt:
+  NOTIFY type_name
+  {
+    $$.val = &tree.Notify{ChannelName: $2.unresolvedObjectName()}
+  }
+| NOTIFY type_name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: $2.unresolvedObjectName(), Payload: tree.NewStrVal($4)}
+  }
+
 // UNLISTEN
 unlisten_stmt:
    UNLISTEN type_name
@@ -13666,8 +14736,6 @@ simple_typename:
   }
 | const_typename
 | interval_type
-| POINT error { return unimplementedWithIssueDetail(sqllex, 21286, "point") } // needed or else it generates a syntax error.
-| POLYGON error { return unimplementedWithIssueDetail(sqllex, 21286, "polygon") } // needed or else it generates a syntax error.
 
 geo_shape_type:
   POINT { $$.val = geopb.ShapeType_Point }
@@ -14318,6 +15386,10 @@ a_expr:
   {
     $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.JSONFetchTextPath), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr DISTANCE a_expr
+  {
+    $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.Distance), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr REMOVE_PATH a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("json_remove_path"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -14334,6 +15406,10 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr AT_QUESTION a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.JSONPathExists), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -14682,6 +15758,10 @@ b_expr:
   {
     $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.RShift), Left: $1.expr(), Right: $3.expr()}
   }
+| b_expr DISTANCE b_expr
+  {
+    $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.Distance), Left: $1.expr(), Right: $3.expr()}
+  }
 | b_expr LESS_EQUALS b_expr
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.LE), Left: $1.expr(), Right: $3.expr()}
@@ -14888,7 +15968,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingFuncExpr{Exprs: $3.exprs()}
+  }
 
 func_application:
   func_application_name '(' ')'
@@ -14899,8 +15982,14 @@ func_application:
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: $3.exprs(), OrderBy: $4.orderBy(), AggType: tree.GeneralAgg}
   }
-| func_application_name '(' VARIADIC a_expr opt_sort_clause ')' { return unimplemented(sqllex, "variadic") }
-| func_application_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause ')' { return unimplemented(sqllex, "variadic") }
+| func_application_name '(' VARIADIC a_expr opt_sort_clause ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: tree.Exprs{$4.expr()}, OrderBy: $5.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
+| func_application_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: append($3.exprs(), $6.expr()), OrderBy: $7.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
 | func_application_name '(' ALL expr_list opt_sort_clause ')'
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Type: tree.AllFuncType, Exprs: $4.exprs(), OrderBy: $5.orderBy(), AggType: tree.GeneralAgg}
@@ -15539,6 +16628,7 @@ all_op:
 | FETCHTEXT { $$.val = treebin.MakeBinaryOperator(treebin.JSONFetchText) }
 | FETCHVAL_PATH { $$.val = treebin.MakeBinaryOperator(treebin.JSONFetchValPath) }
 | FETCHTEXT_PATH { $$.val = treebin.MakeBinaryOperator(treebin.JSONFetchTextPath) }
+| DISTANCE { $$.val = treebin.MakeBinaryOperator(treebin.Distance) }
 | JSON_SOME_EXISTS { $$.val = treecmp.MakeComparisonOperator(treecmp.JSONSomeExists) }
 | JSON_ALL_EXISTS { $$.val = treecmp.MakeComparisonOperator(treecmp.JSONAllExists) }
 | NOT_REGMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegMatch) }
@@ -15546,6 +16636,7 @@ all_op:
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| AT_QUESTION { $$.val = treecmp.MakeComparisonOperator(treecmp.JSONPathExists) }
 | '~' { $$.val = tree.MakeUnaryOperator(tree.UnaryComplement) }
 | SQRT { $$.val = tree.MakeUnaryOperator(tree.UnarySqrt) }
 | CBRT { $$.val = tree.MakeUnaryOperator(tree.UnaryCbrt) }
@@ -16512,6 +17603,7 @@ unreserved_keyword:
 | CLUSTER
 | CLUSTERS
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -16588,6 +17680,7 @@ unreserved_keyword:
 | FAILURE
 | FILES
 | FILTER
+| FINALFUNC
 | FIRST
 | FOLLOWING
 | FORMAT
@@ -16632,7 +17725,9 @@ unreserved_keyword:
 | INCREMENTAL_LOCATION
 | INDEX
 | INDEXES
+| INHERIT
 | INHERITS
+| INITCOND
 | INJECT
 | INPUT
 | INSERT
@@ -16663,6 +17758,7 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGIN
@@ -16670,6 +17766,7 @@ unreserved_keyword:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -16701,6 +17798,7 @@ unreserved_keyword:
 | NO
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
@@ -16746,9 +17844,11 @@ unreserved_keyword:
 | PLACEMENT
 | PLAN
 | PLANS
+| POINT
 | POINTM
 | POINTZ
 | POINTZM
+| POLYGON
 | POLYGONM
 | POLYGONZ
 | POLYGONZM
@@ -16811,6 +17911,7 @@ unreserved_keyword:
 | SCROLL
 | SETTING
 | SETTINGS
+| SFUNC
 | STATUS
 | SAVEPOINT
 | SCANS
@@ -16861,6 +17962,7 @@ unreserved_keyword:
 | STORING
 | STREAM
 | STRICT
+| STYPE
 | SUBSCRIPTION
 | SUPER
 | SUPPORT
@@ -16995,6 +18097,7 @@ bare_label_keywords:
 | COLLATION
 | COLUMN
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -17093,6 +18196,7 @@ bare_label_keywords:
 | FALSE
 | FAMILY
 | FILES
+| FINALFUNC
 | FIRST
 | FLOAT
 | FOLLOWING
@@ -17152,7 +18256,9 @@ bare_label_keywords:
 | INDEX_AFTER_ORDER_BY_BEFORE_AT
 | INDEX_BEFORE_NAME_THEN_PAREN
 | INDEX_BEFORE_PAREN
+| INHERIT
 | INHERITS
+| INITCOND
 | INITIALLY
 | INJECT
 | INNER
@@ -17198,6 +18304,7 @@ bare_label_keywords:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCALITY
 | LOCALTIME
@@ -17207,6 +18314,7 @@ bare_label_keywords:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -17250,6 +18358,7 @@ bare_label_keywords:
 | NOT
 | NOTHING
 | NOTHING_AFTER_RETURNING
+| NOTIFY
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
@@ -17385,6 +18494,7 @@ bare_label_keywords:
 | SETS
 | SETTING
 | SETTINGS
+| SFUNC
 | SHARE
 | SHARED
 | SHOW
@@ -17419,6 +18529,7 @@ bare_label_keywords:
 | STORING
 | STREAM
 | STRICT
+| STYPE
 | STRING
 | SUBSCRIPTION
 | SUBSTRING
@@ -17431,6 +18542,7 @@ bare_label_keywords:
 | SYSTEM
 | TABLE
 | TABLES
+| TABLESAMPLE
 | TABLESPACE
 | TEMP
 | TEMPLATE
@@ -17551,8 +18663,6 @@ col_name_keyword:
 | NUMERIC
 | OUT
 | OVERLAY
-| POINT
-| POLYGON
 | POSITION
 | PRECISION
 | REAL
@@ -17611,6 +18721,7 @@ type_func_name_no_crdb_extra_keyword:
 | OVERLAPS
 | RIGHT
 | SIMILAR
+| TABLESAMPLE
 
 // CockroachDB-specific keywords that can be used in type/function
 // identifiers.
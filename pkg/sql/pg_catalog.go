@@ -21,6 +21,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -37,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -648,6 +650,7 @@ var (
 	relKindView             = tree.NewDString("v")
 	relKindMaterializedView = tree.NewDString("m")
 	relKindSequence         = tree.NewDString("S")
+	relKindForeignTable     = tree.NewDString("f")
 
 	relPersistencePermanent = tree.NewDString("p")
 	relPersistenceTemporary = tree.NewDString("t")
@@ -664,7 +667,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 		// The only difference between tables, views and sequences are the relkind and relam columns.
 		relKind := relKindTable
 		relAm := forwardIndexOid
-		if table.IsView() {
+		if table.IsForeignTable() {
+			relKind = relKindForeignTable
+			relAm = oidZero
+		} else if table.IsView() {
 			relKind = relKindView
 			if table.MaterializedView() {
 				relKind = relKindMaterializedView
@@ -694,6 +700,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 		}
 		implicitTypOID := typedesc.TableIDToImplicitTypeOID(table.GetID())
 		namespaceOid := schemaOid(sc.GetID())
+		relHasSubclass := tree.MakeDBool(tree.DBool(len(table.GetInheritedBy()) > 0))
 		if err := addRow(
 			tableOid(table.GetID()),        // oid
 			tree.NewDName(table.GetName()), // relname
@@ -719,7 +726,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			tree.MakeDBool(tree.DBool(table.IsPhysicalTable())), // relhaspkey
 			tree.DBoolFalse, // relhasrules
 			tree.DBoolFalse, // relhastriggers
-			tree.DBoolFalse, // relhassubclass
+			relHasSubclass,  // relhassubclass
 			zeroVal,         // relfrozenxid
 			tree.DNull,      // relacl
 			relOptions,      // reloptions
@@ -994,6 +1001,7 @@ func populateTableConstraints(
 			}
 			f.WriteString(strings.Join(colNames, ", "))
 			f.WriteByte(')')
+			f.FormatNode(constraintDeferrability(uwoi))
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1024,14 +1032,15 @@ func populateTableConstraints(
 			condef = tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", displayExpr, validity))
 		}
 
+		deferrability := constraintDeferrability(c)
 		if err := addRow(
 			conoid,                   // oid
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
-			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
+			tree.MakeDBool(tree.DBool(deferrability.IsDeferrable())),                      // condeferrable
+			tree.MakeDBool(tree.DBool(deferrability == tree.ConstraintInitiallyDeferred)), // condeferred
+			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())),                      // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
 			conindid,       // conindid
@@ -1735,14 +1744,32 @@ https://www.postgresql.org/docs/9.5/catalog-pg-foreign-server.html`,
 }
 
 var pgCatalogForeignTableTable = virtualSchemaTable{
-	comment: `foreign tables (empty  - feature does not exist)
+	comment: `foreign tables (incomplete - servers are external connections)
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-table.html`,
 	schema: vtable.PGCatalogForeignTable,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign tables are not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual,
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, desc catalog.TableDescriptor) error {
+				if !desc.IsForeignTable() {
+					return nil
+				}
+				opts, err := sanitizedForeignTableOptions(desc.TableDesc().ForeignTable)
+				if err != nil {
+					return err
+				}
+				options := tree.NewDArray(types.String)
+				for _, opt := range opts {
+					if err := options.Append(tree.NewDString(opt.Key + "=" + opt.Value)); err != nil {
+						return err
+					}
+				}
+				return addRow(
+					tableOid(desc.GetID()), // ftrelid
+					oidZero,                // ftserver
+					options,                // ftoptions
+				)
+			})
 	},
-	unimplemented: true,
 }
 
 func makeZeroedOidVector(size int) (tree.Datum, error) {
@@ -1942,14 +1969,24 @@ func indexDefFromDescriptor(
 }
 
 var pgCatalogInheritsTable = virtualSchemaTable{
-	comment: `table inheritance hierarchy (empty - feature does not exist)
+	comment: `table inheritance hierarchy
 https://www.postgresql.org/docs/9.5/catalog-pg-inherits.html`,
 	schema: vtable.PGCatalogInherits,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Table inheritance is not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual,
+			func(_ catalog.DatabaseDescriptor, _ catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				for i, parentID := range table.GetInherits() {
+					if err := addRow(
+						tableOid(table.GetID()),      // inhrelid
+						tableOid(parentID),           // inhparent
+						tree.NewDInt(tree.DInt(i+1)), // inhseqno
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogLanguageTable = virtualSchemaTable{
@@ -2425,17 +2462,45 @@ func addPgProcUDFRow(
 	addRow func(...tree.Datum) error,
 ) error {
 	isStrict := fnDesc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT
+	// proargtypes only contains the types of the input parameters, while
+	// proallargtypes contains the types of all of the parameters, and is only
+	// set if there are output parameters.
 	argTypes := tree.NewDArray(types.Oid)
+	allArgTypes := tree.NewDArray(types.Oid)
 	argModes := tree.NewDArray(types.String)
 	var argNames tree.Datum
 	argNamesArray := tree.NewDArray(types.String)
 	foundAnyArgNames := false
+	foundOutParams := false
+	variadicType := oidZero
 	for _, param := range fnDesc.GetParams() {
-		if err := argTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
+		var argMode string
+		switch param.Class {
+		case catpb.Function_Param_OUT:
+			argMode = "o"
+		case catpb.Function_Param_IN_OUT:
+			argMode = "b"
+		case catpb.Function_Param_VARIADIC:
+			argMode = "v"
+			variadicType = tree.NewDOid(param.Type.ArrayContents().Oid())
+		default:
+			argMode = "i"
+		}
+		switch param.Class {
+		case catpb.Function_Param_OUT:
+			foundOutParams = true
+		case catpb.Function_Param_IN_OUT:
+			foundOutParams = true
+			fallthrough
+		default:
+			if err := argTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
+				return err
+			}
+		}
+		if err := allArgTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
 			return err
 		}
-		// We only support IN arguments at the moment.
-		if err := argModes.Append(tree.NewDString("i")); err != nil {
+		if err := argModes.Append(tree.NewDString(argMode)); err != nil {
 			return err
 		}
 		if len(param.Name) > 0 {
@@ -2449,6 +2514,18 @@ func addPgProcUDFRow(
 	if foundAnyArgNames {
 		argNames = argNamesArray
 	}
+	var allArgTypesDatum tree.Datum = tree.DNull
+	if foundOutParams {
+		allArgTypesDatum = allArgTypes
+	}
+	kind := tree.NewDString("f")
+	if fnDesc.GetIsProcedure() {
+		kind = tree.NewDString("p")
+	}
+	isAggregate := fnDesc.GetAggregate() != nil
+	if isAggregate {
+		kind = tree.NewDString("a")
+	}
 
 	return addRow(
 		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())), // oid
@@ -2457,33 +2534,33 @@ func addPgProcUDFRow(
 		h.UserOid(fnDesc.GetPrivileges().Owner()),       // proowner
 		// In postgres oid of sql language is 14, need to add a mapping if
 		// we are going to support more languages.
-		tree.NewDOid(14), // prolang
-		tree.DNull,       // procost
-		tree.DNull,       // prorows
-		oidZero,          // provariadic
-		tree.DNull,       // protransform
-		tree.DBoolFalse,  // proisagg
-		tree.DBoolFalse,  // proiswindow
-		tree.DBoolFalse,  // prosecdef
+		tree.NewDOid(14),                        // prolang
+		tree.DNull,                              // procost
+		tree.DNull,                              // prorows
+		variadicType,                            // provariadic
+		tree.DNull,                              // protransform
+		tree.MakeDBool(tree.DBool(isAggregate)), // proisagg
+		tree.DBoolFalse,                         // proiswindow
+		tree.DBoolFalse,                         // prosecdef
 		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),            // proleakproof
 		tree.MakeDBool(tree.DBool(isStrict)),                         // proisstrict
 		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)), // proretset
 		tree.NewDString(funcVolatility(fnDesc.GetVolatility())),      // provolatile
-		tree.DNull, // proparallel
-		tree.NewDInt(tree.DInt(len(fnDesc.GetParams()))), // pronargs
-		tree.NewDInt(tree.DInt(0)),                       // pronargdefaults
-		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()),  // prorettype
-		tree.NewDOidVectorFromDArray(argTypes),           // proargtypes
-		tree.DNull,                                       // proallargtypes
-		argModes,                                         // proargmodes
-		argNames,                                         // proargnames
-		tree.DNull,                                       // proargdefaults
-		tree.DNull,                                       // protrftypes
-		tree.NewDString(fnDesc.GetFunctionBody()),        // prosrc
-		tree.DNull,                                       // probin
-		tree.DNull,                                       // proconfig
-		tree.DNull,                                       // proacl
-		tree.NewDString("f"),                             // prokind
+		tree.DNull,                                      // proparallel
+		tree.NewDInt(tree.DInt(argTypes.Len())),         // pronargs
+		tree.NewDInt(tree.DInt(0)),                      // pronargdefaults
+		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()), // prorettype
+		tree.NewDOidVectorFromDArray(argTypes),          // proargtypes
+		allArgTypesDatum,                                // proallargtypes
+		argModes,                                        // proargmodes
+		argNames,                                        // proargnames
+		tree.DNull,                                      // proargdefaults
+		tree.DNull,                                      // protrftypes
+		tree.NewDString(fnDesc.GetFunctionBody()),       // prosrc
+		tree.DNull,                                      // probin
+		tree.DNull,                                      // proconfig
+		tree.DNull,                                      // proacl
+		kind,                                            // prokind
 		// These columns were automatically created by pg_catalog_test's missing column generator.
 		tree.DNull, // prosupport
 	)
@@ -3001,7 +3078,6 @@ var (
 	typTypeRange     = tree.NewDString("r")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
 	_ = typTypeRange
 
@@ -3024,7 +3100,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
-	_ = typCategoryGeometric
 	_ = typCategoryRange
 	_ = typCategoryBitString
 
@@ -3129,23 +3204,40 @@ func addPGTypeRow(
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typOid := tree.NewDOid(typ.Oid())
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		// Domains have the representation of their base type, but no array type.
+		typOid = tree.NewDOid(typ.UserDefinedOID())
+		typType = typTypeDomain
+		typArray = oidZero
+		typBaseType = tree.NewDOid(typ.Oid())
+		if d := typ.TypeMeta.DomainData; d != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(d.NotNull))
+			if d.DefaultExpr != "" {
+				typDefault = tree.NewDString(d.DefaultExpr)
+			}
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
-		tree.NewDOid(typ.Oid()), // oid
-		tree.NewDName(typname),  // typname
-		nspOid,                  // typnamespace
-		owner,                   // typowner
-		typLen(typ),             // typlen
-		typByVal(typ),           // typbyval (is it fixedlen or not)
-		typType,                 // typtype
-		cat,                     // typcategory
-		tree.DBoolFalse,         // typispreferred
-		tree.DBoolTrue,          // typisdefined
-		typDelim,                // typdelim
-		oidZero,                 // typrelid
-		typElem,                 // typelem
-		typArray,                // typarray
+		typOid,                 // oid
+		tree.NewDName(typname), // typname
+		nspOid,                 // typnamespace
+		owner,                  // typowner
+		typLen(typ),            // typlen
+		typByVal(typ),          // typbyval (is it fixedlen or not)
+		typType,                // typtype
+		cat,                    // typcategory
+		tree.DBoolFalse,        // typispreferred
+		tree.DBoolTrue,         // typisdefined
+		typDelim,               // typdelim
+		oidZero,                // typrelid
+		typElem,                // typelem
+		typArray,               // typarray
 
 		// regproc references
 		h.RegProc(builtinPrefix+"in"),   // typinput
@@ -3158,13 +3250,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -3827,12 +3919,31 @@ var pgCatalogStatUserFunctionsTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTable = virtualSchemaTable{
-	comment: "pg_publication was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublication,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `publications for logical replication
+https://www.postgresql.org/docs/current/catalog-pg-publication.html`,
+	schema: vtable.PgCatalogPublication,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				for _, pub := range db.DatabaseDesc().Publications {
+					if err := addRow(
+						h.PublicationOid(db.GetID(), pub.Name),    // oid
+						tree.NewDName(pub.Name),                   // pubname
+						h.UserOid(pub.OwnerProto.Decode()),        // pubowner
+						tree.MakeDBool(tree.DBool(pub.AllTables)), // puballtables
+						tree.DBoolTrue,                            // pubinsert
+						tree.DBoolTrue,                            // pubupdate
+						tree.DBoolTrue,                            // pubdelete
+						tree.DBoolFalse,                           // pubtruncate
+						tree.DBoolFalse,                           // pubviaroot
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAmprocTable = virtualSchemaTable{
@@ -3987,12 +4098,28 @@ var pgCatalogStatXactUserTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTablesTable = virtualSchemaTable{
-	comment: "pg_publication_tables was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationTables,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables published by each publication
+https://www.postgresql.org/docs/current/view-pg-publication-tables.html`,
+	schema: vtable.PgCatalogPublicationTables,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual,
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				pubs := db.DatabaseDesc().Publications
+				for i := range pubs {
+					if !publicationIncludesTable(&pubs[i], table) {
+						continue
+					}
+					if err := addRow(
+						tree.NewDName(pubs[i].Name),    // pubname
+						tree.NewDName(sc.GetName()),    // schemaname
+						tree.NewDName(table.GetName()), // tablename
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatProgressClusterTable = virtualSchemaTable{
@@ -4023,12 +4150,57 @@ var pgCatalogLargeobjectMetadataTable = virtualSchemaTable{
 }
 
 var pgCatalogReplicationSlotsTable = virtualSchemaTable{
-	comment: "pg_replication_slots was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogReplicationSlots,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `logical replication slots (incomplete)
+https://www.postgresql.org/docs/current/view-pg-replication-slots.html`,
+	schema: vtable.PgCatalogReplicationSlots,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_ReplicationSlotsTable) {
+			return nil
+		}
+		rows, err := p.InternalSQLTxn().QueryBufferedEx(
+			ctx,
+			"select-replication-slots",
+			p.Txn(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT database_id, slot_name, plugin, confirmed_flush_lsn
+FROM system.replication_slots ORDER BY database_id, slot_name`,
+		)
+		if err != nil {
+			return err
+		}
+		slots := make(map[descpb.ID][]tree.Datums)
+		for _, row := range rows {
+			dbID := descpb.ID(tree.MustBeDInt(row[0]))
+			slots[dbID] = append(slots[dbID], row[1:])
+		}
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				for _, slot := range slots[db.GetID()] {
+					name := tree.NewDName(string(tree.MustBeDString(slot[0])))
+					plugin := tree.NewDName(string(tree.MustBeDString(slot[1])))
+					pos := tree.NewDString(lsn.LSN(tree.MustBeDInt(slot[2])).String())
+					if err := addRow(
+						name,                        // slot_name
+						plugin,                      // plugin
+						tree.NewDString("logical"),  // slot_type
+						dbOid(db.GetID()),           // datoid
+						tree.NewDName(db.GetName()), // database
+						tree.DBoolFalse,             // temporary
+						tree.DBoolFalse,             // active
+						tree.DNull,                  // active_pid
+						tree.DNull,                  // xmin
+						tree.DNull,                  // catalog_xmin
+						pos,                         // restart_lsn
+						pos,                         // confirmed_flush_lsn
+						tree.NewDString("reserved"), // wal_status
+						tree.DNull,                  // safe_wal_size
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogSubscriptionRelTable = virtualSchemaTable{
@@ -4299,12 +4471,30 @@ var pgCatalogStatReplicationTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationRelTable = virtualSchemaTable{
-	comment: "pg_publication_rel was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationRel,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables explicitly added to publications
+https://www.postgresql.org/docs/current/catalog-pg-publication-rel.html`,
+	schema: vtable.PgCatalogPublicationRel,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual,
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				pubs := db.DatabaseDesc().Publications
+				for i := range pubs {
+					if pubs[i].AllTables || !publicationIncludesTable(&pubs[i], table) {
+						continue
+					}
+					pubOid := h.PublicationOid(db.GetID(), pubs[i].Name)
+					if err := addRow(
+						h.PublicationRelOid(pubOid, table.GetID()), // oid
+						pubOid,                  // prpubid
+						tableOid(table.GetID()), // prrelid
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAvailableExtensionVersionsTable = virtualSchemaTable{
@@ -4368,6 +4558,7 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.GeographyFamily:   typCategoryUserDefined,
 	types.GeometryFamily:    typCategoryUserDefined,
 	types.JsonFamily:        typCategoryUserDefined,
+	types.JsonpathFamily:    typCategoryUserDefined,
 	types.DecimalFamily:     typCategoryNumeric,
 	types.StringFamily:      typCategoryString,
 	types.TimestampFamily:   typCategoryDateTime,
@@ -4382,6 +4573,11 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.INetFamily:        typCategoryNetworkAddr,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
+	types.PointFamily:       typCategoryGeometric,
+	types.BoxFamily:         typCategoryGeometric,
+	types.PathFamily:        typCategoryGeometric,
+	types.PolygonFamily:     typCategoryGeometric,
+	types.CircleFamily:      typCategoryGeometric,
 }
 
 func typCategory(typ *types.T) tree.Datum {
@@ -4404,7 +4600,7 @@ https://www.postgresql.org/docs/9.5/view-pg-views.html`,
 		// because it does not distinguish views in separate databases.
 		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /*virtual schemas do not have views*/
 			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, desc catalog.TableDescriptor) error {
-				if !desc.IsView() || desc.MaterializedView() {
+				if !desc.IsView() || desc.MaterializedView() || desc.IsForeignTable() {
 					return nil
 				}
 				owner, err := getOwnerName(ctx, p, desc)
@@ -4595,6 +4791,8 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	publicationTypeTag
+	publicationRelTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -4769,6 +4967,24 @@ func (h oidHasher) DBSchemaRoleOid(
 	return h.getOid()
 }
 
+// PublicationOid creates an OID for the publication with the given name in
+// the given database.
+func (h oidHasher) PublicationOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(publicationTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+// PublicationRelOid creates an OID for the membership of a table in a
+// publication.
+func (h oidHasher) PublicationRelOid(pubOid *tree.DOid, tableID descpb.ID) *tree.DOid {
+	h.writeTypeTag(publicationRelTypeTag)
+	h.writeOID(pubOid)
+	h.writeTable(tableID)
+	return h.getOid()
+}
+
 func tableOid(id descpb.ID) *tree.DOid {
 	return tree.NewDOid(oid.Oid(id))
 }
@@ -15,10 +15,32 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
 type LSN uint64
 
+// FromHLC returns the LSN of the changes committed at the given timestamp,
+// which is its wall time in nanoseconds. LSNs therefore order changes the same
+// way as their timestamps, but changes whose timestamps only differ by their
+// logical component share an LSN. Replication streams send all the changes
+// with the same LSN as a single transaction, so that each LSN identifies a
+// transaction.
+func FromHLC(ts hlc.Timestamp) LSN {
+	return LSN(ts.WallTime)
+}
+
+// HLC returns the lowest timestamp whose changes have the given LSN.
+func (lsn LSN) HLC() hlc.Timestamp {
+	return hlc.Timestamp{WallTime: int64(lsn)}
+}
+
+// EndHLC returns the highest timestamp whose changes have the given LSN. The
+// changes at or below it are the changes up to and including the LSN.
+func (lsn LSN) EndHLC() hlc.Timestamp {
+	return hlc.Timestamp{WallTime: int64(lsn) + 1}.Prev()
+}
+
 func (lsn LSN) String() string {
 	return fmt.Sprintf("%X/%X", uint32(lsn>>32), uint32(lsn))
 }
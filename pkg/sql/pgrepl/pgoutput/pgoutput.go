@@ -0,0 +1,247 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgoutput encodes the messages of the streaming replication protocol
+// and of the pgoutput logical decoding output plugin.
+// See: https://www.postgresql.org/docs/current/protocol-replication.html and
+// https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+package pgoutput
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/lib/pq/oid"
+)
+
+// PluginName is the name of the only output plugin we support.
+const PluginName = "pgoutput"
+
+// Messages of the streaming replication protocol, carried in CopyData
+// messages.
+const (
+	msgXLogData            = 'w'
+	msgPrimaryKeepalive    = 'k'
+	msgStandbyStatusUpdate = 'r'
+	msgHotStandbyFeedback  = 'h'
+
+	standbyStatusUpdateLen = 1 + 8 + 8 + 8 + 8 + 1
+	replyRequested         = 1
+)
+
+// Messages of the pgoutput plugin, carried in XLogData messages.
+const (
+	msgBegin    = 'B'
+	msgCommit   = 'C'
+	msgRelation = 'R'
+	msgInsert   = 'I'
+	msgUpdate   = 'U'
+	msgDelete   = 'D'
+
+	tupleNew  = 'N'
+	tupleKey  = 'K'
+	tupleOld  = 'O'
+	valueNull = 'n'
+	valueText = 't'
+
+	// replicaIdentityDefault means that the old values of the primary key
+	// columns are sent with updates and deletes.
+	replicaIdentityDefault = 'd'
+	columnFlagKey          = 1
+)
+
+// Column describes a column of a Relation.
+type Column struct {
+	Name    string
+	TypeOID oid.Oid
+	TypeMod int32
+	// Key is set if the column is part of the replica identity, i.e. the
+	// primary key.
+	Key bool
+}
+
+// Relation describes a published table. It is sent before the first change
+// to the table, and again whenever its schema changes.
+type Relation struct {
+	ID        uint32
+	Namespace string
+	Name      string
+	Columns   []Column
+}
+
+// Tuple holds the text representation of the values of a row, in the order
+// of the columns of its Relation. A nil value is NULL; an empty non-NULL value
+// must be an empty non-nil slice.
+type Tuple [][]byte
+
+// AppendXLogData appends the header of a message carrying replicated data
+// starting at start. The pgoutput message must be appended after it.
+func AppendXLogData(b []byte, start, end lsn.LSN, sendTime time.Time) []byte {
+	b = append(b, msgXLogData)
+	b = binary.BigEndian.AppendUint64(b, uint64(start))
+	b = binary.BigEndian.AppendUint64(b, uint64(end))
+	return appendTime(b, sendTime)
+}
+
+// AppendPrimaryKeepalive appends a keepalive message, which informs the client
+// of the current end of the stream.
+func AppendPrimaryKeepalive(b []byte, end lsn.LSN, sendTime time.Time, requestReply bool) []byte {
+	b = append(b, msgPrimaryKeepalive)
+	b = binary.BigEndian.AppendUint64(b, uint64(end))
+	b = appendTime(b, sendTime)
+	if requestReply {
+		return append(b, replyRequested)
+	}
+	return append(b, 0)
+}
+
+// AppendBegin appends the message that starts a transaction committed at
+// commitTime, whose changes end at finalLSN.
+func AppendBegin(b []byte, finalLSN lsn.LSN, commitTime time.Time, xid uint32) []byte {
+	b = append(b, msgBegin)
+	b = binary.BigEndian.AppendUint64(b, uint64(finalLSN))
+	b = appendTime(b, commitTime)
+	return binary.BigEndian.AppendUint32(b, xid)
+}
+
+// AppendCommit appends the message that ends a transaction.
+func AppendCommit(b []byte, commitLSN, endLSN lsn.LSN, commitTime time.Time) []byte {
+	b = append(b, msgCommit, 0 /* flags */)
+	b = binary.BigEndian.AppendUint64(b, uint64(commitLSN))
+	b = binary.BigEndian.AppendUint64(b, uint64(endLSN))
+	return appendTime(b, commitTime)
+}
+
+// AppendRelation appends the message describing a relation.
+func AppendRelation(b []byte, rel *Relation) []byte {
+	b = append(b, msgRelation)
+	b = binary.BigEndian.AppendUint32(b, rel.ID)
+	b = appendString(b, rel.Namespace)
+	b = appendString(b, rel.Name)
+	b = append(b, replicaIdentityDefault)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(rel.Columns)))
+	for i := range rel.Columns {
+		col := &rel.Columns[i]
+		if col.Key {
+			b = append(b, columnFlagKey)
+		} else {
+			b = append(b, 0)
+		}
+		b = appendString(b, col.Name)
+		b = binary.BigEndian.AppendUint32(b, uint32(col.TypeOID))
+		b = binary.BigEndian.AppendUint32(b, uint32(col.TypeMod))
+	}
+	return b
+}
+
+// AppendInsert appends the message for a row inserted in a relation.
+func AppendInsert(b []byte, relID uint32, newRow Tuple) []byte {
+	b = append(b, msgInsert)
+	b = binary.BigEndian.AppendUint32(b, relID)
+	b = append(b, tupleNew)
+	return appendTuple(b, newRow)
+}
+
+// AppendUpdate appends the message for a row updated in a relation. oldRow is
+// the previous version of the row, and can be nil if it is unknown.
+func AppendUpdate(b []byte, relID uint32, oldRow, newRow Tuple) []byte {
+	b = append(b, msgUpdate)
+	b = binary.BigEndian.AppendUint32(b, relID)
+	if oldRow != nil {
+		b = append(b, tupleOld)
+		b = appendTuple(b, oldRow)
+	}
+	b = append(b, tupleNew)
+	return appendTuple(b, newRow)
+}
+
+// AppendDelete appends the message for a row deleted from a relation. If
+// keyOnly is set, oldRow only has values for the key columns, and NULLs for
+// the others.
+func AppendDelete(b []byte, relID uint32, oldRow Tuple, keyOnly bool) []byte {
+	b = append(b, msgDelete)
+	b = binary.BigEndian.AppendUint32(b, relID)
+	if keyOnly {
+		b = append(b, tupleKey)
+	} else {
+		b = append(b, tupleOld)
+	}
+	return appendTuple(b, oldRow)
+}
+
+// StandbyStatus is the progress reported by a client in a standby status
+// update.
+type StandbyStatus struct {
+	Written lsn.LSN
+	Flushed lsn.LSN
+	Applied lsn.LSN
+	// ReplyRequested is set if the client asks the server to reply with a
+	// keepalive immediately.
+	ReplyRequested bool
+}
+
+// ParseClientMessage parses the payload of a CopyData message sent by the
+// client. It returns ok=false for hot standby feedback messages, which carry
+// nothing of interest for logical replication.
+func ParseClientMessage(data []byte) (_ StandbyStatus, ok bool, _ error) {
+	if len(data) == 0 {
+		return StandbyStatus{}, false, pgerror.New(pgcode.ProtocolViolation,
+			"unexpected empty message from the replication client")
+	}
+	switch data[0] {
+	case msgStandbyStatusUpdate:
+		if len(data) != standbyStatusUpdateLen {
+			return StandbyStatus{}, false, pgerror.Newf(pgcode.ProtocolViolation,
+				"invalid standby status update of %d bytes", len(data))
+		}
+		return StandbyStatus{
+			Written:        lsn.LSN(binary.BigEndian.Uint64(data[1:])),
+			Flushed:        lsn.LSN(binary.BigEndian.Uint64(data[9:])),
+			Applied:        lsn.LSN(binary.BigEndian.Uint64(data[17:])),
+			ReplyRequested: data[33] == replyRequested,
+		}, true, nil
+	case msgHotStandbyFeedback:
+		return StandbyStatus{}, false, nil
+	default:
+		return StandbyStatus{}, false, pgerror.Newf(pgcode.ProtocolViolation,
+			"unexpected message type %q from the replication client", data[0])
+	}
+}
+
+func appendTuple(b []byte, t Tuple) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(t)))
+	for _, v := range t {
+		if v == nil {
+			b = append(b, valueNull)
+			continue
+		}
+		b = append(b, valueText)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(v)))
+		b = append(b, v...)
+	}
+	return b
+}
+
+// appendTime appends t as the number of microseconds since the Postgres
+// epoch.
+func appendTime(b []byte, t time.Time) []byte {
+	return binary.BigEndian.AppendUint64(b, uint64(duration.DiffMicros(t, pgwirebase.PGEpochJDate)))
+}
+
+// appendString appends s as a null-terminated string.
+func appendString(b []byte, s string) []byte {
+	b = append(b, s...)
+	return append(b, 0)
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgoutput
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendMessages(t *testing.T) {
+	// One second after the Postgres epoch.
+	ts := time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC)
+
+	require.Equal(t, []byte{
+		'B',
+		0, 0, 0, 0, 0, 0, 0x01, 0x02, // final LSN
+		0, 0, 0, 0, 0, 0x0f, 0x42, 0x40, // commit time
+		0, 0, 0, 7, // xid
+	}, AppendBegin(nil, lsn.LSN(0x0102), ts, 7))
+
+	require.Equal(t, []byte{
+		'C', 0,
+		0, 0, 0, 0, 0, 0, 0x01, 0x02, // commit LSN
+		0, 0, 0, 0, 0, 0, 0x01, 0x03, // end LSN
+		0, 0, 0, 0, 0, 0x0f, 0x42, 0x40, // commit time
+	}, AppendCommit(nil, lsn.LSN(0x0102), lsn.LSN(0x0103), ts))
+
+	require.Equal(t, []byte{
+		'R',
+		0, 0, 0, 104, // relation ID
+		'p', 'u', 'b', 'l', 'i', 'c', 0,
+		't', 0,
+		'd',
+		0, 2, // number of columns
+		1, 'k', 0, 0, 0, 0, 20, 0xff, 0xff, 0xff, 0xff,
+		0, 'v', 0, 0, 0, 0, 25, 0xff, 0xff, 0xff, 0xff,
+	}, AppendRelation(nil, &Relation{
+		ID:        104,
+		Namespace: "public",
+		Name:      "t",
+		Columns: []Column{
+			{Name: "k", TypeOID: oid.T_int8, TypeMod: -1, Key: true},
+			{Name: "v", TypeOID: oid.T_text, TypeMod: -1},
+		},
+	}))
+
+	require.Equal(t, []byte{
+		'I', 0, 0, 0, 104, 'N',
+		0, 3, // number of columns
+		't', 0, 0, 0, 1, '1',
+		'n',
+		't', 0, 0, 0, 0,
+	}, AppendInsert(nil, 104, Tuple{[]byte("1"), nil, []byte{}}))
+
+	require.Equal(t, []byte{
+		'U', 0, 0, 0, 104,
+		'N', 0, 1, 't', 0, 0, 0, 1, '2',
+	}, AppendUpdate(nil, 104, nil /* oldRow */, Tuple{[]byte("2")}))
+
+	require.Equal(t, []byte{
+		'D', 0, 0, 0, 104,
+		'K', 0, 2, 't', 0, 0, 0, 1, '1', 'n',
+	}, AppendDelete(nil, 104, Tuple{[]byte("1"), nil}, true /* keyOnly */))
+
+	require.Equal(t, []byte{
+		'k',
+		0, 0, 0, 0, 0, 0, 0x01, 0x02, // end LSN
+		0, 0, 0, 0, 0, 0x0f, 0x42, 0x40, // send time
+		1,
+	}, AppendPrimaryKeepalive(nil, lsn.LSN(0x0102), ts, true /* requestReply */))
+}
+
+func TestParseClientMessage(t *testing.T) {
+	msg := []byte{
+		'r',
+		0, 0, 0, 0, 0, 0, 0, 3, // written
+		0, 0, 0, 0, 0, 0, 0, 2, // flushed
+		0, 0, 0, 0, 0, 0, 0, 1, // applied
+		0, 0, 0, 0, 0, 0, 0, 0, // client time
+		1,
+	}
+	status, ok, err := ParseClientMessage(msg)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, StandbyStatus{Written: 3, Flushed: 2, Applied: 1, ReplyRequested: true}, status)
+
+	_, _, err = ParseClientMessage(msg[:10])
+	require.Error(t, err)
+
+	_, ok, err = ParseClientMessage([]byte{'h'})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, _, err = ParseClientMessage([]byte{'x'})
+	require.Error(t, err)
+}
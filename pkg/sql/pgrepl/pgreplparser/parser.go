@@ -11,10 +11,45 @@
 package pgreplparser
 
 import (
+	"strings"
+	"unicode"
+
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/errors"
 )
 
+// replicationCommands are the keywords that start the commands of the
+// streaming replication protocol.
+var replicationCommands = []string{
+	"BASE_BACKUP",
+	"CREATE_REPLICATION_SLOT",
+	"DROP_REPLICATION_SLOT",
+	"IDENTIFY_SYSTEM",
+	"READ_REPLICATION_SLOT",
+	"START_REPLICATION",
+	"TIMELINE_HISTORY",
+}
+
+// IsReplicationProtocolCommand returns whether the given query is a command of
+// the streaming replication protocol, which must be parsed with Parse rather
+// than with the SQL parser. Like in the lexer, the keywords are case
+// sensitive.
+func IsReplicationProtocolCommand(q string) bool {
+	q = strings.TrimLeftFunc(q, unicode.IsSpace)
+	end := strings.IndexFunc(q, func(r rune) bool {
+		return !(r == '_' || unicode.IsLetter(r))
+	})
+	if end >= 0 {
+		q = q[:end]
+	}
+	for _, cmd := range replicationCommands {
+		if q == cmd {
+			return true
+		}
+	}
+	return false
+}
+
 func Parse(sql string) (pgrepltree.ReplicationStatement, error) {
 	lexer := newLexer(sql)
 	p := pgreplNewParser()
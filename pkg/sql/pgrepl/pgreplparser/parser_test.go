@@ -75,3 +75,24 @@ func TestParser(t *testing.T) {
 		})
 	})
 }
+
+func TestIsReplicationProtocolCommand(t *testing.T) {
+	for _, tc := range []struct {
+		q        string
+		expected bool
+	}{
+		{q: "IDENTIFY_SYSTEM", expected: true},
+		{q: "  START_REPLICATION SLOT s LOGICAL 0/0", expected: true},
+		{q: "CREATE_REPLICATION_SLOT s LOGICAL pgoutput", expected: true},
+		{q: "DROP_REPLICATION_SLOT s", expected: true},
+		{q: "TIMELINE_HISTORY 1", expected: true},
+		{q: "identify_system", expected: false},
+		{q: "IDENTIFY_SYSTEMS", expected: false},
+		{q: "SELECT 1", expected: false},
+		{q: "", expected: false},
+	} {
+		t.Run(tc.q, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsReplicationProtocolCommand(tc.q))
+		})
+	}
+}
@@ -32,7 +32,7 @@ func (drs *DropReplicationSlot) Format(ctx *tree.FmtCtx) {
 }
 
 func (drs *DropReplicationSlot) StatementReturnType() tree.StatementReturnType {
-	return tree.Replication
+	return tree.Ack
 }
 
 func (drs *DropReplicationSlot) StatementType() tree.StatementType {
@@ -26,7 +26,7 @@ func (i *IdentifySystem) Format(ctx *tree.FmtCtx) {
 }
 
 func (i *IdentifySystem) StatementReturnType() tree.StatementReturnType {
-	return tree.Replication
+	return tree.Rows
 }
 
 func (i *IdentifySystem) StatementType() tree.StatementType {
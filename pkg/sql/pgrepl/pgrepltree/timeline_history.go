@@ -28,7 +28,7 @@ func (th *TimelineHistory) Format(ctx *tree.FmtCtx) {
 }
 
 func (th *TimelineHistory) StatementReturnType() tree.StatementReturnType {
-	return tree.Replication
+	return tree.Rows
 }
 
 func (th *TimelineHistory) StatementType() tree.StatementType {
@@ -0,0 +1,159 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgrepl_test
+
+import (
+	"context"
+	"encoding/binary"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/tests"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogicalReplication(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	params, _ := tests.CreateTestServerParams()
+	s, db, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+	sqlDB.Exec(t, `CREATE PUBLICATION pub FOR TABLE t`)
+
+	pgURL, cleanup := sqlutils.PGUrl(t, s.ServingSQLAddr(), "pgrepl_test", url.User(username.RootUser))
+	defer cleanup()
+	pgURL.Path = "defaultdb"
+	cfg, err := pgconn.ParseConfig(pgURL.String())
+	require.NoError(t, err)
+	cfg.RuntimeParams["replication"] = "database"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+	defer cancel()
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	results, err := conn.Exec(ctx, `IDENTIFY_SYSTEM`).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Rows, 1)
+	require.Equal(t, "defaultdb", string(results[0].Rows[0][3]))
+
+	results, err = conn.Exec(ctx, `CREATE_REPLICATION_SLOT slot LOGICAL pgoutput`).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, "slot", string(results[0].Rows[0][0]))
+	require.Equal(t, "pgoutput", string(results[0].Rows[0][3]))
+	sqlDB.CheckQueryResults(t,
+		`SELECT slot_name, plugin, database FROM pg_replication_slots`,
+		[][]string{{"slot", "pgoutput", "defaultdb"}},
+	)
+	// The changes after the slot was created are protected from garbage
+	// collection.
+	const ptsQuery = `SELECT count(*) FROM system.protected_ts_records WHERE meta_type = 'replication_slots'`
+	sqlDB.CheckQueryResults(t, ptsQuery, [][]string{{"1"}})
+
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1, 'a')`)
+	sqlDB.Exec(t, `UPDATE t SET v = NULL WHERE k = 1`)
+	sqlDB.Exec(t, `DELETE FROM t WHERE k = 1`)
+
+	conn.Frontend().SendQuery(&pgproto3.Query{
+		String: `START_REPLICATION SLOT slot LOGICAL 0/0 (proto_version '1', publication_names 'pub')`,
+	})
+	require.NoError(t, conn.Frontend().Flush())
+	for started := false; !started; {
+		msg, err := conn.ReceiveMessage(ctx)
+		require.NoError(t, err)
+		switch msg := msg.(type) {
+		case *pgproto3.CopyBothResponse:
+			started = true
+		case *pgproto3.ErrorResponse:
+			t.Fatalf("unexpected error: %s", msg.Message)
+		}
+	}
+
+	// Collect the pgoutput messages, skipping keepalives, until the three
+	// transactions have been received.
+	var msgs [][]byte
+	for commits := 0; commits < 3; {
+		msg, err := conn.ReceiveMessage(ctx)
+		require.NoError(t, err)
+		data, ok := msg.(*pgproto3.CopyData)
+		require.True(t, ok, "unexpected message %T", msg)
+		if data.Data[0] != 'w' {
+			continue
+		}
+		// Skip the XLogData header.
+		m := append([]byte(nil), data.Data[25:]...)
+		if m[0] == 'C' {
+			commits++
+		}
+		msgs = append(msgs, m)
+	}
+	var types []byte
+	for _, m := range msgs {
+		types = append(types, m[0])
+	}
+	require.Equal(t, "BRICBUCBDC", string(types))
+	require.Contains(t, string(msgs[1]), "public\x00t\x00")
+	// The insert carries both values, the update a NULL value and the delete
+	// the key only.
+	require.Equal(t, []byte("N\x00\x02t\x00\x00\x00\x011t\x00\x00\x00\x01a"), msgs[2][5:])
+	require.Equal(t, []byte("N\x00\x02t\x00\x00\x00\x011n"), msgs[5][5:])
+	require.Equal(t, []byte("K\x00\x02t\x00\x00\x00\x011n"), msgs[8][5:])
+
+	// Confirm that the last transaction was flushed, then end the stream; the
+	// server persists the confirmed position in the slot, replies with CopyDone
+	// and completes the command.
+	flushed := binary.BigEndian.Uint64(msgs[9][2:])
+	status := []byte{'r'}
+	for i := 0; i < 3; i++ {
+		status = binary.BigEndian.AppendUint64(status, flushed)
+	}
+	status = binary.BigEndian.AppendUint64(status, 0 /* time */)
+	status = append(status, 0 /* replyRequested */)
+	conn.Frontend().Send(&pgproto3.CopyData{Data: status})
+	conn.Frontend().Send(&pgproto3.CopyDone{})
+	require.NoError(t, conn.Frontend().Flush())
+	for done := false; !done; {
+		msg, err := conn.ReceiveMessage(ctx)
+		require.NoError(t, err)
+		switch msg := msg.(type) {
+		case *pgproto3.ErrorResponse:
+			t.Fatalf("unexpected error: %s", msg.Message)
+		case *pgproto3.ReadyForQuery:
+			done = true
+		}
+	}
+
+	sqlDB.CheckQueryResults(t,
+		`SELECT confirmed_flush_lsn FROM pg_replication_slots`,
+		[][]string{{lsn.LSN(flushed).String()}},
+	)
+
+	_, err = conn.Exec(ctx, `DROP_REPLICATION_SLOT slot`).ReadAll()
+	require.NoError(t, err)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM pg_replication_slots`, [][]string{{"0"}})
+	sqlDB.CheckQueryResults(t, ptsQuery, [][]string{{"0"}})
+}
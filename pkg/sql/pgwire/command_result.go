@@ -59,6 +59,7 @@ type commandResult struct {
 	// buffer contains items that are sent before the connection is closed.
 	buffer struct {
 		notices            []pgnotice.Notice
+		notifications      []notification
 		paramStatusUpdates []paramStatusUpdate
 	}
 
@@ -116,6 +117,14 @@ type paramStatusUpdate struct {
 	val string
 }
 
+// notification is an asynchronous notification, produced by NOTIFY, to send
+// to the client.
+type notification struct {
+	senderPID int32
+	channel   string
+	payload   string
+}
+
 var _ sql.CommandResult = &commandResult{}
 
 // RevokePortalPausability is part of the sql.RestrictedCommandResult interface.
@@ -148,6 +157,12 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		}
 	}
 
+	for _, n := range r.buffer.notifications {
+		if err := r.conn.bufferNotification(n.senderPID, n.channel, n.payload); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err when sending notification"))
+		}
+	}
+
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
@@ -288,6 +303,14 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 	r.buffer.notices = append(r.buffer.notices, notice)
 }
 
+// BufferNotification is part of the sql.NotificationResult interface.
+func (r *commandResult) BufferNotification(senderPID int32, channel string, payload string) {
+	r.buffer.notifications = append(
+		r.buffer.notifications,
+		notification{senderPID: senderPID, channel: channel, payload: payload},
+	)
+}
+
 // SetColumns is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetColumns(ctx context.Context, cols colinfo.ResultColumns) {
 	r.assertNotReleased()
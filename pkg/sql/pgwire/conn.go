@@ -30,6 +30,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgreplparser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -279,6 +281,14 @@ func (c *conn) bufferNotice(ctx context.Context, noticeErr pgnotice.Notice) erro
 	return c.writeErrFields(ctx, noticeErr, &c.writerState.buf)
 }
 
+func (c *conn) bufferNotification(senderPID int32, channel string, payload string) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	c.msgBuilder.putInt32(senderPID)
+	c.msgBuilder.writeTerminatedString(channel)
+	c.msgBuilder.writeTerminatedString(payload)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) sendInitialConnData(
 	ctx context.Context,
 	sqlServer *sql.Server,
@@ -361,6 +371,11 @@ func (c *conn) handleSimpleQuery(
 		return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
 	}
 
+	if c.sessionArgs.ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DISABLED &&
+		pgreplparser.IsReplicationProtocolCommand(query) {
+		return c.handleReplicationCommand(ctx, query, timeReceived)
+	}
+
 	startParse := timeutil.Now()
 	stmts, err := c.parser.ParseWithInt(query, unqualifiedIntSize)
 	if err != nil {
@@ -471,6 +486,57 @@ func (c *conn) handleSimpleQuery(
 	return nil
 }
 
+// handleReplicationCommand handles a command of the streaming replication
+// protocol, which is received as a simple query on connections in a
+// replication mode.
+//
+// An error is returned iff the statement buffer has been closed. In that case,
+// the connection should be considered toast.
+func (c *conn) handleReplicationCommand(
+	ctx context.Context, query string, timeReceived time.Time,
+) error {
+	startParse := timeutil.Now()
+	stmt, err := pgreplparser.Parse(query)
+	if err != nil {
+		log.SqlExec.Infof(ctx, "could not parse replication command: %s", query)
+		return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
+	}
+	endParse := timeutil.Now()
+	parsed := statements.Statement[tree.Statement]{AST: stmt, SQL: query}
+
+	// Like COPY, START_REPLICATION takes control of the connection, so we block
+	// this network routine until control is passed back.
+	if sr, ok := stmt.(*pgrepltree.StartReplication); ok {
+		done := sync.WaitGroup{}
+		done.Add(1)
+		if err := c.stmtBuf.Push(
+			ctx,
+			sql.StartReplication{
+				ParsedStmt:   parsed,
+				Stmt:         sr,
+				Conn:         c,
+				Done:         &done,
+				TimeReceived: timeReceived,
+				ParseStart:   startParse,
+				ParseEnd:     endParse,
+			},
+		); err != nil {
+			return err
+		}
+		done.Wait()
+		return nil
+	}
+	return c.stmtBuf.Push(
+		ctx,
+		sql.ExecStmt{
+			Statement:    parsed,
+			TimeReceived: timeReceived,
+			ParseStart:   startParse,
+			ParseEnd:     endParse,
+			LastInBatch:  true,
+		})
+}
+
 // An error is returned iff the statement buffer has been closed. In that case,
 // the connection should be considered toast.
 func (c *conn) handleParse(
@@ -794,6 +860,27 @@ func (c *conn) BeginCopyIn(
 	return c.msgBuilder.finishMsg(c.conn)
 }
 
+// BeginCopyBoth is part of the pgwirebase.Conn interface.
+func (c *conn) BeginCopyBoth(ctx context.Context) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyBothResponse)
+	c.msgBuilder.writeByte(byte(pgwirebase.FormatText))
+	c.msgBuilder.putInt16(0)
+	return c.msgBuilder.finishMsg(c.conn)
+}
+
+// SendCopyData is part of the pgwirebase.Conn interface.
+func (c *conn) SendCopyData(ctx context.Context, data []byte) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDataCommand)
+	c.msgBuilder.write(data)
+	return c.msgBuilder.finishMsg(c.conn)
+}
+
+// SendCopyDone is part of the pgwirebase.Conn interface.
+func (c *conn) SendCopyDone(ctx context.Context) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDoneCommand)
+	return c.msgBuilder.finishMsg(c.conn)
+}
+
 // Rd is part of the pgwirebase.Conn interface.
 func (c *conn) Rd() pgwirebase.BufferedReader {
 	return &pgwireReader{conn: c}
@@ -846,7 +933,7 @@ func cookTag(
 		tag = strconv.AppendInt(tag, int64(rowsAffected), 10)
 
 	case tree.Rows:
-		if tagStr != "SHOW" {
+		if tagStr != "SHOW" && tagStr != "CALL" {
 			tag = append(tag, ' ')
 			tag = strconv.AppendUint(tag, uint64(rowsAffected), 10)
 		}
@@ -857,6 +944,9 @@ func cookTag(
 			tag = strconv.AppendInt(tag, int64(rowsAffected), 10)
 		}
 
+	case tree.Replication:
+		// Replication commands have no row count.
+
 	default:
 		panic(errors.AssertionFailedf("unexpected result type %v", stmtType))
 	}
@@ -1400,6 +1490,16 @@ func (c *conn) CreateCopyOutResult(cmd sql.CopyOut, pos sql.CmdPos) sql.CopyOutR
 	return res
 }
 
+// CreateStartReplicationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateStartReplicationResult(
+	cmd sql.StartReplication, pos sql.CmdPos,
+) sql.StartReplicationResult {
+	res := c.newMiscResult(pos, commandComplete)
+	res.stmtType = cmd.Stmt.StatementReturnType()
+	res.cmdCompleteTag = cmd.Stmt.StatementTag()
+	return res
+}
+
 // pgwireReader is an io.Reader that wraps a conn, maintaining its metrics as
 // it is consumed.
 type pgwireReader struct {
@@ -15,3 +15,10 @@ sed -E 's|^([A-Z0-9]{5})    .    ERRCODE_([A-Z_]+).*$|\2 = MakeCode("\1")|' |
 sed -E 's|// Section: Class 58 - System Error \(errors external to PostgreSQL itself\)|// Section: Class 58 - System Error|' |
 awk '{$1=tolower($1); print $0}' |
 perl -pe 's/(^|_)./uc($&)/ge;s/_//g' > errcodes.generated
+
+# The PL/pgSQL condition names are generated separately. The output must be
+# wrapped in the PLpgSQLConditionNameToCode map literal in
+# plpgsql_codenames.go.
+sed '/^\s*$/d' errcodes.txt |
+sed '/^#.*$/d' |
+awk '$2 == "E" && NF == 4 && !seen[$4]++ {printf "\t\"%s\": MakeCode(\"%s\"),\n", $4, $1}' > plpgsql_codenames.generated
@@ -0,0 +1,262 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgcode
+
+// PLpgSQLOthers is a special code used by PL/pgSQL exception handlers for the
+// OTHERS condition, which matches all errors except query_canceled and
+// assert_failure. It is not a valid SQLSTATE.
+var PLpgSQLOthers = MakeCode("OTHERS")
+
+// PLpgSQLConditionNameToCode maps the PL/pgSQL condition names that can be
+// used in RAISE statements and EXCEPTION handlers to their error codes. If a
+// condition name appears more than once in errcodes.txt, the first code is
+// used, which matches Postgres.
+//
+// This map is generated from errcodes.txt by generate.sh.
+var PLpgSQLConditionNameToCode = map[string]Code{
+	"sql_statement_not_yet_complete":                       MakeCode("03000"),
+	"connection_exception":                                 MakeCode("08000"),
+	"connection_does_not_exist":                            MakeCode("08003"),
+	"connection_failure":                                   MakeCode("08006"),
+	"sqlclient_unable_to_establish_sqlconnection":          MakeCode("08001"),
+	"sqlserver_rejected_establishment_of_sqlconnection":    MakeCode("08004"),
+	"transaction_resolution_unknown":                       MakeCode("08007"),
+	"protocol_violation":                                   MakeCode("08P01"),
+	"triggered_action_exception":                           MakeCode("09000"),
+	"feature_not_supported":                                MakeCode("0A000"),
+	"invalid_transaction_initiation":                       MakeCode("0B000"),
+	"locator_exception":                                    MakeCode("0F000"),
+	"invalid_locator_specification":                        MakeCode("0F001"),
+	"invalid_grantor":                                      MakeCode("0L000"),
+	"invalid_grant_operation":                              MakeCode("0LP01"),
+	"invalid_role_specification":                           MakeCode("0P000"),
+	"diagnostics_exception":                                MakeCode("0Z000"),
+	"stacked_diagnostics_accessed_without_active_handler":  MakeCode("0Z002"),
+	"case_not_found":                                       MakeCode("20000"),
+	"cardinality_violation":                                MakeCode("21000"),
+	"data_exception":                                       MakeCode("22000"),
+	"array_subscript_error":                                MakeCode("2202E"),
+	"character_not_in_repertoire":                          MakeCode("22021"),
+	"datetime_field_overflow":                              MakeCode("22008"),
+	"division_by_zero":                                     MakeCode("22012"),
+	"error_in_assignment":                                  MakeCode("22005"),
+	"escape_character_conflict":                            MakeCode("2200B"),
+	"indicator_overflow":                                   MakeCode("22022"),
+	"interval_field_overflow":                              MakeCode("22015"),
+	"invalid_argument_for_logarithm":                       MakeCode("2201E"),
+	"invalid_argument_for_ntile_function":                  MakeCode("22014"),
+	"invalid_argument_for_nth_value_function":              MakeCode("22016"),
+	"invalid_argument_for_power_function":                  MakeCode("2201F"),
+	"invalid_argument_for_width_bucket_function":           MakeCode("2201G"),
+	"invalid_character_value_for_cast":                     MakeCode("22018"),
+	"invalid_datetime_format":                              MakeCode("22007"),
+	"invalid_escape_character":                             MakeCode("22019"),
+	"invalid_escape_octet":                                 MakeCode("2200D"),
+	"invalid_escape_sequence":                              MakeCode("22025"),
+	"nonstandard_use_of_escape_character":                  MakeCode("22P06"),
+	"invalid_indicator_parameter_value":                    MakeCode("22010"),
+	"invalid_parameter_value":                              MakeCode("22023"),
+	"invalid_regular_expression":                           MakeCode("2201B"),
+	"invalid_row_count_in_limit_clause":                    MakeCode("2201W"),
+	"invalid_row_count_in_result_offset_clause":            MakeCode("2201X"),
+	"invalid_tablesample_argument":                         MakeCode("2202H"),
+	"invalid_tablesample_repeat":                           MakeCode("2202G"),
+	"invalid_time_zone_displacement_value":                 MakeCode("22009"),
+	"invalid_use_of_escape_character":                      MakeCode("2200C"),
+	"most_specific_type_mismatch":                          MakeCode("2200G"),
+	"null_value_not_allowed":                               MakeCode("22004"),
+	"null_value_no_indicator_parameter":                    MakeCode("22002"),
+	"numeric_value_out_of_range":                           MakeCode("22003"),
+	"string_data_length_mismatch":                          MakeCode("22026"),
+	"string_data_right_truncation":                         MakeCode("22001"),
+	"substring_error":                                      MakeCode("22011"),
+	"trim_error":                                           MakeCode("22027"),
+	"unterminated_c_string":                                MakeCode("22024"),
+	"zero_length_character_string":                         MakeCode("2200F"),
+	"floating_point_exception":                             MakeCode("22P01"),
+	"invalid_text_representation":                          MakeCode("22P02"),
+	"invalid_binary_representation":                        MakeCode("22P03"),
+	"bad_copy_file_format":                                 MakeCode("22P04"),
+	"untranslatable_character":                             MakeCode("22P05"),
+	"not_an_xml_document":                                  MakeCode("2200L"),
+	"invalid_xml_document":                                 MakeCode("2200M"),
+	"invalid_xml_content":                                  MakeCode("2200N"),
+	"invalid_xml_comment":                                  MakeCode("2200S"),
+	"invalid_xml_processing_instruction":                   MakeCode("2200T"),
+	"duplicate_json_object_key_value":                      MakeCode("22030"),
+	"invalid_argument_for_sql_json_datetime_function":      MakeCode("22031"),
+	"invalid_json_text":                                    MakeCode("22032"),
+	"invalid_sql_json_subscript":                           MakeCode("22033"),
+	"more_than_one_sql_json_item":                          MakeCode("22034"),
+	"no_sql_json_item":                                     MakeCode("22035"),
+	"non_numeric_sql_json_item":                            MakeCode("22036"),
+	"non_unique_keys_in_a_json_object":                     MakeCode("22037"),
+	"singleton_sql_json_item_required":                     MakeCode("22038"),
+	"sql_json_array_not_found":                             MakeCode("22039"),
+	"sql_json_member_not_found":                            MakeCode("2203A"),
+	"sql_json_number_not_found":                            MakeCode("2203B"),
+	"sql_json_object_not_found":                            MakeCode("2203C"),
+	"too_many_json_array_elements":                         MakeCode("2203D"),
+	"too_many_json_object_members":                         MakeCode("2203E"),
+	"sql_json_scalar_required":                             MakeCode("2203F"),
+	"integrity_constraint_violation":                       MakeCode("23000"),
+	"restrict_violation":                                   MakeCode("23001"),
+	"not_null_violation":                                   MakeCode("23502"),
+	"foreign_key_violation":                                MakeCode("23503"),
+	"unique_violation":                                     MakeCode("23505"),
+	"check_violation":                                      MakeCode("23514"),
+	"exclusion_violation":                                  MakeCode("23P01"),
+	"invalid_cursor_state":                                 MakeCode("24000"),
+	"invalid_transaction_state":                            MakeCode("25000"),
+	"active_sql_transaction":                               MakeCode("25001"),
+	"branch_transaction_already_active":                    MakeCode("25002"),
+	"held_cursor_requires_same_isolation_level":            MakeCode("25008"),
+	"inappropriate_access_mode_for_branch_transaction":     MakeCode("25003"),
+	"inappropriate_isolation_level_for_branch_transaction": MakeCode("25004"),
+	"no_active_sql_transaction_for_branch_transaction":     MakeCode("25005"),
+	"read_only_sql_transaction":                            MakeCode("25006"),
+	"schema_and_data_statement_mixing_not_supported":       MakeCode("25007"),
+	"no_active_sql_transaction":                            MakeCode("25P01"),
+	"in_failed_sql_transaction":                            MakeCode("25P02"),
+	"invalid_sql_statement_name":                           MakeCode("26000"),
+	"triggered_data_change_violation":                      MakeCode("27000"),
+	"invalid_authorization_specification":                  MakeCode("28000"),
+	"invalid_password":                                     MakeCode("28P01"),
+	"dependent_privilege_descriptors_still_exist":          MakeCode("2B000"),
+	"dependent_objects_still_exist":                        MakeCode("2BP01"),
+	"invalid_transaction_termination":                      MakeCode("2D000"),
+	"sql_routine_exception":                                MakeCode("2F000"),
+	"function_executed_no_return_statement":                MakeCode("2F005"),
+	"modifying_sql_data_not_permitted":                     MakeCode("2F002"),
+	"prohibited_sql_statement_attempted":                   MakeCode("2F003"),
+	"reading_sql_data_not_permitted":                       MakeCode("2F004"),
+	"invalid_cursor_name":                                  MakeCode("34000"),
+	"external_routine_exception":                           MakeCode("38000"),
+	"containing_sql_not_permitted":                         MakeCode("38001"),
+	"external_routine_invocation_exception":                MakeCode("39000"),
+	"invalid_sqlstate_returned":                            MakeCode("39001"),
+	"trigger_protocol_violated":                            MakeCode("39P01"),
+	"srf_protocol_violated":                                MakeCode("39P02"),
+	"event_trigger_protocol_violated":                      MakeCode("39P03"),
+	"savepoint_exception":                                  MakeCode("3B000"),
+	"invalid_savepoint_specification":                      MakeCode("3B001"),
+	"invalid_catalog_name":                                 MakeCode("3D000"),
+	"invalid_schema_name":                                  MakeCode("3F000"),
+	"transaction_rollback":                                 MakeCode("40000"),
+	"transaction_integrity_constraint_violation":           MakeCode("40002"),
+	"serialization_failure":                                MakeCode("40001"),
+	"statement_completion_unknown":                         MakeCode("40003"),
+	"deadlock_detected":                                    MakeCode("40P01"),
+	"syntax_error_or_access_rule_violation":                MakeCode("42000"),
+	"syntax_error":                                         MakeCode("42601"),
+	"insufficient_privilege":                               MakeCode("42501"),
+	"cannot_coerce":                                        MakeCode("42846"),
+	"grouping_error":                                       MakeCode("42803"),
+	"windowing_error":                                      MakeCode("42P20"),
+	"invalid_recursion":                                    MakeCode("42P19"),
+	"invalid_foreign_key":                                  MakeCode("42830"),
+	"invalid_name":                                         MakeCode("42602"),
+	"name_too_long":                                        MakeCode("42622"),
+	"reserved_name":                                        MakeCode("42939"),
+	"datatype_mismatch":                                    MakeCode("42804"),
+	"indeterminate_datatype":                               MakeCode("42P18"),
+	"collation_mismatch":                                   MakeCode("42P21"),
+	"indeterminate_collation":                              MakeCode("42P22"),
+	"wrong_object_type":                                    MakeCode("42809"),
+	"undefined_column":                                     MakeCode("42703"),
+	"undefined_function":                                   MakeCode("42883"),
+	"undefined_table":                                      MakeCode("42P01"),
+	"undefined_parameter":                                  MakeCode("42P02"),
+	"undefined_object":                                     MakeCode("42704"),
+	"duplicate_column":                                     MakeCode("42701"),
+	"duplicate_cursor":                                     MakeCode("42P03"),
+	"duplicate_database":                                   MakeCode("42P04"),
+	"duplicate_function":                                   MakeCode("42723"),
+	"duplicate_prepared_statement":                         MakeCode("42P05"),
+	"duplicate_schema":                                     MakeCode("42P06"),
+	"duplicate_table":                                      MakeCode("42P07"),
+	"duplicate_alias":                                      MakeCode("42712"),
+	"duplicate_object":                                     MakeCode("42710"),
+	"ambiguous_column":                                     MakeCode("42702"),
+	"ambiguous_function":                                   MakeCode("42725"),
+	"ambiguous_parameter":                                  MakeCode("42P08"),
+	"ambiguous_alias":                                      MakeCode("42P09"),
+	"invalid_column_reference":                             MakeCode("42P10"),
+	"invalid_column_definition":                            MakeCode("42611"),
+	"invalid_cursor_definition":                            MakeCode("42P11"),
+	"invalid_database_definition":                          MakeCode("42P12"),
+	"invalid_function_definition":                          MakeCode("42P13"),
+	"invalid_prepared_statement_definition":                MakeCode("42P14"),
+	"invalid_schema_definition":                            MakeCode("42P15"),
+	"invalid_table_definition":                             MakeCode("42P16"),
+	"invalid_object_definition":                            MakeCode("42P17"),
+	"with_check_option_violation":                          MakeCode("44000"),
+	"insufficient_resources":                               MakeCode("53000"),
+	"disk_full":                                            MakeCode("53100"),
+	"out_of_memory":                                        MakeCode("53200"),
+	"too_many_connections":                                 MakeCode("53300"),
+	"configuration_limit_exceeded":                         MakeCode("53400"),
+	"program_limit_exceeded":                               MakeCode("54000"),
+	"statement_too_complex":                                MakeCode("54001"),
+	"too_many_columns":                                     MakeCode("54011"),
+	"too_many_arguments":                                   MakeCode("54023"),
+	"object_not_in_prerequisite_state":                     MakeCode("55000"),
+	"object_in_use":                                        MakeCode("55006"),
+	"cant_change_runtime_param":                            MakeCode("55P02"),
+	"lock_not_available":                                   MakeCode("55P03"),
+	"operator_intervention":                                MakeCode("57000"),
+	"query_canceled":                                       MakeCode("57014"),
+	"admin_shutdown":                                       MakeCode("57P01"),
+	"crash_shutdown":                                       MakeCode("57P02"),
+	"cannot_connect_now":                                   MakeCode("57P03"),
+	"database_dropped":                                     MakeCode("57P04"),
+	"system_error":                                         MakeCode("58000"),
+	"io_error":                                             MakeCode("58030"),
+	"undefined_file":                                       MakeCode("58P01"),
+	"duplicate_file":                                       MakeCode("58P02"),
+	"config_file_error":                                    MakeCode("F0000"),
+	"lock_file_exists":                                     MakeCode("F0001"),
+	"fdw_error":                                            MakeCode("HV000"),
+	"fdw_column_name_not_found":                            MakeCode("HV005"),
+	"fdw_dynamic_parameter_value_needed":                   MakeCode("HV002"),
+	"fdw_function_sequence_error":                          MakeCode("HV010"),
+	"fdw_inconsistent_descriptor_information":              MakeCode("HV021"),
+	"fdw_invalid_attribute_value":                          MakeCode("HV024"),
+	"fdw_invalid_column_name":                              MakeCode("HV007"),
+	"fdw_invalid_column_number":                            MakeCode("HV008"),
+	"fdw_invalid_data_type":                                MakeCode("HV004"),
+	"fdw_invalid_data_type_descriptors":                    MakeCode("HV006"),
+	"fdw_invalid_descriptor_field_identifier":              MakeCode("HV091"),
+	"fdw_invalid_handle":                                   MakeCode("HV00B"),
+	"fdw_invalid_option_index":                             MakeCode("HV00C"),
+	"fdw_invalid_option_name":                              MakeCode("HV00D"),
+	"fdw_invalid_string_length_or_buffer_length":           MakeCode("HV090"),
+	"fdw_invalid_string_format":                            MakeCode("HV00A"),
+	"fdw_invalid_use_of_null_pointer":                      MakeCode("HV009"),
+	"fdw_too_many_handles":                                 MakeCode("HV014"),
+	"fdw_out_of_memory":                                    MakeCode("HV001"),
+	"fdw_no_schemas":                                       MakeCode("HV00P"),
+	"fdw_option_name_not_found":                            MakeCode("HV00J"),
+	"fdw_reply_handle":                                     MakeCode("HV00K"),
+	"fdw_schema_not_found":                                 MakeCode("HV00Q"),
+	"fdw_table_not_found":                                  MakeCode("HV00R"),
+	"fdw_unable_to_create_execution":                       MakeCode("HV00L"),
+	"fdw_unable_to_create_reply":                           MakeCode("HV00M"),
+	"fdw_unable_to_establish_connection":                   MakeCode("HV00N"),
+	"plpgsql_error":                                        MakeCode("P0000"),
+	"raise_exception":                                      MakeCode("P0001"),
+	"no_data_found":                                        MakeCode("P0002"),
+	"too_many_rows":                                        MakeCode("P0003"),
+	"assert_failure":                                       MakeCode("P0004"),
+	"internal_error":                                       MakeCode("XX000"),
+	"data_corrupted":                                       MakeCode("XX001"),
+	"index_corrupted":                                      MakeCode("XX002"),
+}
@@ -30,4 +30,17 @@ type Conn interface {
 	// subprotocol (COPY ... FROM STDIN). This message informs the client about
 	// the columns that are expected for the rows to be inserted.
 	BeginCopyIn(ctx context.Context, columns []colinfo.ResultColumn, format FormatCode) error
+
+	// BeginCopyBoth sends the server message initiating the Copy-both
+	// subprotocol, which is used to stream changes to a client that issued
+	// START_REPLICATION.
+	BeginCopyBoth(ctx context.Context) error
+
+	// SendCopyData sends a CopyData message carrying the given payload. The
+	// message is written to the network immediately.
+	SendCopyData(ctx context.Context, data []byte) error
+
+	// SendCopyDone sends a CopyDone message, which ends the data sent by the
+	// server in the Copy-both subprotocol.
+	SendCopyDone(ctx context.Context) error
 }
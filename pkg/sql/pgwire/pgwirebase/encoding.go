@@ -24,6 +24,7 @@ import (
 	"unsafe"
 
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
@@ -392,6 +393,21 @@ func DecodeDatum(
 				return nil, tree.MakeParseError(bs, typ, err)
 			}
 			return d, nil
+		case oidext.T_jsonpath:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(bs)
+		case oid.T_point:
+			return tree.ParseDPoint(bs)
+		case oid.T_box:
+			return tree.ParseDBox(bs)
+		case oid.T_path:
+			return tree.ParseDPath(bs)
+		case oid.T_polygon:
+			return tree.ParseDPolygon(bs)
+		case oid.T_circle:
+			return tree.ParseDCircle(bs)
 		case oid.T_void:
 			return tree.DVoidDatum, nil
 		case oid.T_numeric:
@@ -773,6 +789,49 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.NewDTSVector(ret), nil
+		case oidext.T_jsonpath:
+			if len(b) < 1 {
+				return nil, NewProtocolViolationErrorf("no data to decode")
+			}
+			if b[0] != 1 {
+				return nil, NewProtocolViolationErrorf("expected jsonpath version 1")
+			}
+			// Skip over the version number.
+			b = b[1:]
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(encoding.UnsafeConvertBytesToString(b))
+		case oid.T_point:
+			ret, err := geometric.DecodePointPGBinary(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPoint(ret), nil
+		case oid.T_box:
+			ret, err := geometric.DecodeBoxPGBinary(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDBox(ret), nil
+		case oid.T_path:
+			ret, err := geometric.DecodePathPGBinary(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPath(ret), nil
+		case oid.T_polygon:
+			ret, err := geometric.DecodePolygonPGBinary(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPolygon(ret), nil
+		case oid.T_circle:
+			ret, err := geometric.DecodeCirclePGBinary(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDCircle(ret), nil
 		case oidext.T_geometry:
 			ret, err := geo.ParseGeometryFromEWKB(b)
 			if err != nil {
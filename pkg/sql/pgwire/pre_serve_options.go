@@ -99,6 +99,9 @@ func parseClientProvidedSessionParameters(
 			if err := loadParameter(ctx, key, value, &args.SessionArgs); err != nil {
 				return args, pgerror.Wrapf(err, pgerror.GetPGCode(err), "replication parameter")
 			}
+			if args.ReplicationMode, err = sql.ParseReplicationMode(value); err != nil {
+				return args, pgerror.Wrapf(err, pgerror.GetPGCode(err), "replication parameter")
+			}
 
 		case "crdb:session_revival_token_base64":
 			token, err := base64.StdEncoding.DecodeString(value)
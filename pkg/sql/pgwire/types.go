@@ -22,6 +22,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
@@ -246,6 +247,24 @@ func writeTextDatumNotNull(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DJsonpath:
+		b.writeLengthPrefixedString(v.Jsonpath.String())
+
+	case *tree.DPoint:
+		b.writeLengthPrefixedString(v.Point.String())
+
+	case *tree.DBox:
+		b.writeLengthPrefixedString(v.Box.String())
+
+	case *tree.DPath:
+		b.writeLengthPrefixedString(v.Path.String())
+
+	case *tree.DPolygon:
+		b.writeLengthPrefixedString(v.Polygon.String())
+
+	case *tree.DCircle:
+		b.writeLengthPrefixedString(v.Circle.String())
+
 	case *tree.DTSQuery:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -747,6 +766,38 @@ func writeBinaryDatumNotNull(
 		b.putInt32(int32(len(v.EWKB())))
 		b.write(v.EWKB())
 
+	case *tree.DJsonpath:
+		s := v.Jsonpath.String()
+		b.putInt32(int32(len(s) + 1))
+		// Postgres version number, as of writing, `1` is the only valid value.
+		b.writeByte(1)
+		b.writeString(s)
+
+	case *tree.DPoint:
+		enc := geometric.EncodePointPGBinary(nil, v.Point)
+		b.putInt32(int32(len(enc)))
+		b.write(enc)
+
+	case *tree.DBox:
+		enc := geometric.EncodeBoxPGBinary(nil, v.Box)
+		b.putInt32(int32(len(enc)))
+		b.write(enc)
+
+	case *tree.DPath:
+		enc := geometric.EncodePathPGBinary(nil, v.Path)
+		b.putInt32(int32(len(enc)))
+		b.write(enc)
+
+	case *tree.DPolygon:
+		enc := geometric.EncodePolygonPGBinary(nil, v.Polygon)
+		b.putInt32(int32(len(enc)))
+		b.write(enc)
+
+	case *tree.DCircle:
+		enc := geometric.EncodeCirclePGBinary(nil, v.Circle)
+		b.putInt32(int32(len(enc)))
+		b.write(enc)
+
 	case *tree.DTSQuery:
 		initialLen := b.Len()
 		// Reserve bytes for writing length later.
@@ -155,11 +155,14 @@ var _ planNode = &alterTableNode{}
 var _ planNode = &alterTableOwnerNode{}
 var _ planNode = &alterTableSetSchemaNode{}
 var _ planNode = &alterTypeNode{}
+var _ planNode = &alterDomainNode{}
 var _ planNode = &bufferNode{}
+var _ planNode = &callNode{}
 var _ planNode = &cancelQueriesNode{}
 var _ planNode = &cancelSessionsNode{}
 var _ planNode = &changeDescriptorBackedPrivilegesNode{}
 var _ planNode = &completionsNode{}
+var _ planNode = &createAggregateNode{}
 var _ planNode = &createDatabaseNode{}
 var _ planNode = &createFunctionNode{}
 var _ planNode = &createIndexNode{}
@@ -167,6 +170,9 @@ var _ planNode = &createSequenceNode{}
 var _ planNode = &createStatsNode{}
 var _ planNode = &createTableNode{}
 var _ planNode = &createTypeNode{}
+var _ planNode = &createDomainNode{}
+var _ planNode = &createForeignTableNode{}
+var _ planNode = &createPublicationNode{}
 var _ planNode = &CreateRoleNode{}
 var _ planNode = &createViewNode{}
 var _ planNode = &delayedNode{}
@@ -175,6 +181,8 @@ var _ planNode = &deleteRangeNode{}
 var _ planNode = &distinctNode{}
 var _ planNode = &dropDatabaseNode{}
 var _ planNode = &dropIndexNode{}
+var _ planNode = &dropPublicationNode{}
+var _ planNode = &dropReplicationSlotNode{}
 var _ planNode = &dropSchemaNode{}
 var _ planNode = &dropSequenceNode{}
 var _ planNode = &dropTableNode{}
@@ -241,12 +249,16 @@ var _ planNodeReadingOwnWrites = &alterSchemaNode{}
 var _ planNodeReadingOwnWrites = &alterSequenceNode{}
 var _ planNodeReadingOwnWrites = &alterTableNode{}
 var _ planNodeReadingOwnWrites = &alterTypeNode{}
+var _ planNodeReadingOwnWrites = &alterDomainNode{}
+var _ planNodeReadingOwnWrites = &createAggregateNode{}
 var _ planNodeReadingOwnWrites = &createFunctionNode{}
 var _ planNodeReadingOwnWrites = &createIndexNode{}
 var _ planNodeReadingOwnWrites = &createSequenceNode{}
 var _ planNodeReadingOwnWrites = &createDatabaseNode{}
 var _ planNodeReadingOwnWrites = &createTableNode{}
 var _ planNodeReadingOwnWrites = &createTypeNode{}
+var _ planNodeReadingOwnWrites = &createDomainNode{}
+var _ planNodeReadingOwnWrites = &createForeignTableNode{}
 var _ planNodeReadingOwnWrites = &createViewNode{}
 var _ planNodeReadingOwnWrites = &changeDescriptorBackedPrivilegesNode{}
 var _ planNodeReadingOwnWrites = &dropSchemaNode{}
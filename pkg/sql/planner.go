@@ -113,6 +113,15 @@ type extendedEvalContext struct {
 
 	// validateDbZoneConfig should the DB zone config on commit.
 	validateDbZoneConfig *bool
+
+	// deferredConstraints refers to the deferred constraint checks in
+	// extraTxnState. It is nil if constraint checks cannot be deferred (e.g.
+	// for internal executors).
+	deferredConstraints *deferredConstraints
+
+	// notifications refers to the LISTEN/NOTIFY state of the session. It is nil
+	// for internal executors.
+	notifications *sessionNotifications
 }
 
 // copyFromExecCfg copies relevant fields from an ExecutorConfig.
@@ -225,6 +225,10 @@ func (l *lexer) ProcessForOpenCursor(nullCursorExplicitExpr bool) *plpgsqltree.P
 	openStmt.CursorOptions = plpgsqltree.PLpgSQLCursorOptFastPlan.Mask()
 
 	if nullCursorExplicitExpr {
+		if l.Peek().id == ';' {
+			// This statement opens a bound cursor, which already has a query.
+			return openStmt
+		}
 		if l.Peek().id == NO {
 			l.lastPos++
 			if l.Peek().id == SCROLL {
@@ -261,7 +265,12 @@ func (l *lexer) ProcessForOpenCursor(nullCursorExplicitExpr bool) *plpgsqltree.P
 				}
 			}
 		} else {
-			openStmt.Query = l.ReadSqlExpressionStr(';')
+			query, err := l.ParseStatement(l.ReadSqlExpressionStr(';'))
+			if err != nil {
+				l.setErr(err)
+				return nil
+			}
+			openStmt.Query = query
 		}
 	} else {
 		// read_cursor_args()
@@ -280,6 +289,19 @@ func (l *lexer) ReadSqlExpressionStr(terminator int) (sqlStr string) {
 	return sqlStr
 }
 
+// ReadOptionalSqlExpressionStr is like ReadSqlExpressionStr, but returns an
+// empty string instead of an error if the next token is the terminator.
+func (l *lexer) ReadOptionalSqlExpressionStr(terminator int) (sqlStr string) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be checked below.
+		l.PushBack(1)
+	}
+	if int(l.Peek().id) == terminator {
+		return ""
+	}
+	return l.ReadSqlExpressionStr(terminator)
+}
+
 func (l *lexer) ReadSqlExpressionStr2(
 	terminator1 int, terminator2 int,
 ) (sqlStr string, terminatorMet int) {
@@ -350,7 +372,12 @@ func (l *lexer) ProcessQueryForCursorWithoutExplicitExpr(openStmt *plpgsqltree.P
 			}
 		}
 	} else {
-		openStmt.Query = l.ReadSqlExpressionStr(';')
+		query, err := l.ParseStatement(l.ReadSqlExpressionStr(';'))
+		if err != nil {
+			l.setErr(err)
+			return
+		}
+		openStmt.Query = query
 	}
 }
 
@@ -433,3 +460,340 @@ func (l *lexer) GetTypeFromValidSQLSyntax(sqlStr string) (tree.ResolvableTypeRef
 func (l *lexer) ParseExpr(sqlStr string) (plpgsqltree.PLpgSQLExpr, error) {
 	return parser.ParseExpr(sqlStr)
 }
+
+// ParseStatement parses the given SQL string as a single SQL statement.
+func (l *lexer) ParseStatement(sqlStr string) (tree.Statement, error) {
+	stmt, err := parser.ParseOne(sqlStr)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.AST, nil
+}
+
+// MakeForLoopControl reads the section of a FOR loop between the IN and LOOP
+// keywords. It returns a PLpgSQLStmtForIntLoop if the section is an integer
+// range, and a PLpgSQLStmtForQuerySelectLoop if it is a query. The Label and
+// Body of the loop are filled in by the caller. It returns nil after setting
+// the lexer error if the section is malformed.
+func (l *lexer) MakeForLoopControl(loopVar string) plpgsqltree.PLpgSQLStatement {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it is read below.
+		l.PushBack(1)
+	}
+	reverse := false
+	if l.Peek().id == REVERSE {
+		reverse = true
+		l.lastPos++
+	}
+	if l.Peek().id == EXECUTE {
+		l.Unimplemented("for loop over dynamic query")
+		return nil
+	}
+	sqlStr, terminator := l.ReadSqlConstruct(DOT_DOT, LOOP)
+	if l.lastError != nil {
+		return nil
+	}
+	if terminator == LOOP {
+		// This is a FOR loop over the rows of a query.
+		if reverse {
+			l.setErr(errors.New("cannot specify REVERSE in query FOR loop"))
+			return nil
+		}
+		if tok := l.tokens[l.lastPos]; tok.id == IDENT && l.tokens[l.lastPos-1].id == IN {
+			// A single identifier names a bound cursor to loop over.
+			return &plpgsqltree.PLpgSQLStmtForQueryCursorLoop{
+				PLpgSQLStmtForQueryLoop: plpgsqltree.PLpgSQLStmtForQueryLoop{
+					Var: plpgsqltree.PLpgSQLVariable(loopVar),
+				},
+				CursorName: tok.str,
+			}
+		}
+		query, err := l.ParseStatement(sqlStr)
+		if err != nil {
+			l.setErr(err)
+			return nil
+		}
+		return &plpgsqltree.PLpgSQLStmtForQuerySelectLoop{
+			PLpgSQLStmtForQueryLoop: plpgsqltree.PLpgSQLStmtForQueryLoop{
+				Var: plpgsqltree.PLpgSQLVariable(loopVar),
+			},
+			Query: query,
+		}
+	}
+
+	// This is an integer FOR loop. Consume the ".." token and read the upper
+	// bound and optional step.
+	loop := &plpgsqltree.PLpgSQLStmtForIntLoop{
+		Var:     plpgsqltree.PLpgSQLVariable(loopVar),
+		Reverse: reverse,
+	}
+	var err error
+	if loop.Lower, err = l.ParseExpr(sqlStr); err != nil {
+		l.setErr(err)
+		return nil
+	}
+	l.lastPos++
+	sqlStr, terminator = l.ReadSqlConstruct(BY, LOOP)
+	if l.lastError != nil {
+		return nil
+	}
+	if loop.Upper, err = l.ParseExpr(sqlStr); err != nil {
+		l.setErr(err)
+		return nil
+	}
+	if terminator == BY {
+		l.lastPos++
+		sqlStr = l.ReadSqlExpressionStr(LOOP)
+		if l.lastError != nil {
+			return nil
+		}
+		if loop.Step, err = l.ParseExpr(sqlStr); err != nil {
+			l.setErr(err)
+			return nil
+		}
+	}
+	return loop
+}
+
+// MakeRaiseStmt reads a RAISE statement following the RAISE keyword, up to and
+// including the terminating semicolon. It returns nil after setting the lexer
+// error if the statement is malformed.
+func (l *lexer) MakeRaiseStmt() *plpgsqltree.PLpgSQLStmtRaise {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it is read below.
+		l.PushBack(1)
+	}
+	raise := &plpgsqltree.PLpgSQLStmtRaise{}
+	if l.Peek().id == ';' {
+		// A RAISE statement with no parameters re-raises the current error.
+		l.lastPos++
+		return raise
+	}
+
+	// Read the optional level, which defaults to EXCEPTION.
+	raise.LogLevel = "EXCEPTION"
+	switch l.Peek().id {
+	case EXCEPTION, WARNING, NOTICE, INFO, LOG, DEBUG:
+		raise.LogLevel = strings.ToUpper(l.Peek().str)
+		l.lastPos++
+	}
+
+	// Read the format string with its parameters, or the condition name or
+	// SQLSTATE that is raised.
+	if tok := l.Peek(); tok.id != USING {
+		l.lastPos++
+		switch tok.id {
+		case SCONST:
+			raise.Message = tok.str
+			for l.Peek().id == ',' {
+				l.lastPos++
+				sqlStr, _ := l.ReadSqlConstruct(',', ';', USING)
+				if l.lastError != nil {
+					return nil
+				}
+				param, err := l.ParseExpr(sqlStr)
+				if err != nil {
+					l.setErr(err)
+					return nil
+				}
+				raise.Params = append(raise.Params, param)
+			}
+			if err := checkRaiseFormatParams(raise.Message, len(raise.Params)); err != nil {
+				l.setErr(err)
+				return nil
+			}
+		case SQLSTATE:
+			code := l.Peek()
+			if code.id != SCONST || !isValidSQLState(code.str) {
+				l.setErr(errors.New("invalid SQLSTATE code"))
+				return nil
+			}
+			l.lastPos++
+			raise.Code = code.str
+		case ';':
+			l.setErr(errors.New("syntax error, expected a message or condition"))
+			return nil
+		default:
+			if _, ok := pgcode.PLpgSQLConditionNameToCode[tok.str]; !ok {
+				l.setErr(pgerror.Newf(
+					pgcode.UndefinedObject, "unrecognized exception condition \"%s\"", tok.str,
+				))
+				return nil
+			}
+			raise.CodeName = tok.str
+		}
+	}
+
+	// Read the USING options.
+	if l.Peek().id == USING {
+		l.lastPos++
+		for {
+			tok := l.Peek()
+			l.lastPos++
+			optType, ok := plpgsqltree.PLpgSQLRaiseOptionTypeFromString(tok.str)
+			if !ok {
+				l.setErr(errors.Newf("unrecognized RAISE statement option \"%s\"", tok.str))
+				return nil
+			}
+			if err := checkRaiseOptionNotSpecified(raise, optType); err != nil {
+				l.setErr(err)
+				return nil
+			}
+			if tok = l.Peek(); tok.id != '=' && tok.id != COLON_EQUALS {
+				l.setErr(errors.New("syntax error, expected \"=\""))
+				return nil
+			}
+			l.lastPos++
+			sqlStr, terminator := l.ReadSqlConstruct(',', ';')
+			if l.lastError != nil {
+				return nil
+			}
+			expr, err := l.ParseExpr(sqlStr)
+			if err != nil {
+				l.setErr(err)
+				return nil
+			}
+			raise.Options = append(raise.Options, plpgsqltree.PLpgSQLStmtRaiseOption{
+				OptType: optType,
+				Expr:    expr,
+			})
+			if terminator != ',' {
+				break
+			}
+			l.lastPos++
+		}
+	}
+
+	if l.Peek().id != ';' {
+		l.setErr(errors.New("syntax error, expected \";\""))
+		return nil
+	}
+	l.lastPos++
+	return raise
+}
+
+// checkRaiseFormatParams returns an error if the number of placeholders in the
+// format string of a RAISE statement does not match the number of parameters.
+// "%%" is an escaped percent sign, and is not a placeholder.
+func checkRaiseFormatParams(format string, numParams int) error {
+	numPlaceholders := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			i++
+			continue
+		}
+		numPlaceholders++
+	}
+	if numPlaceholders > numParams {
+		return pgerror.New(pgcode.Syntax, "too few parameters specified for RAISE")
+	}
+	if numPlaceholders < numParams {
+		return pgerror.New(pgcode.Syntax, "too many parameters specified for RAISE")
+	}
+	return nil
+}
+
+// checkRaiseOptionNotSpecified returns an error if the given RAISE option has
+// already been specified, either in the USING clause or in place of it.
+func checkRaiseOptionNotSpecified(
+	raise *plpgsqltree.PLpgSQLStmtRaise, optType plpgsqltree.PLpgSQLRaiseOptionType,
+) error {
+	alreadySpecified := false
+	switch optType {
+	case plpgsqltree.PLpgSQLRaiseOptionMessage:
+		alreadySpecified = raise.Message != ""
+	case plpgsqltree.PLpgSQLRaiseOptionErrCode:
+		alreadySpecified = raise.Code != "" || raise.CodeName != ""
+	}
+	for i := range raise.Options {
+		if raise.Options[i].OptType == optType {
+			alreadySpecified = true
+		}
+	}
+	if alreadySpecified {
+		return pgerror.Newf(pgcode.Syntax, "RAISE option already specified: %s", optType)
+	}
+	return nil
+}
+
+// MakeFetchOrMoveStmt reads a FETCH or MOVE statement following the FETCH or
+// MOVE keyword, up to and including the terminating semicolon. The direction
+// and cursor name are parsed by the SQL parser, since they have the same form
+// as in the SQL FETCH and MOVE statements. It returns nil after setting the
+// lexer error if the statement is malformed.
+func (l *lexer) MakeFetchOrMoveStmt(isMove bool) *plpgsqltree.PLpgSQLStmtFetch {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it is read below.
+		l.PushBack(1)
+	}
+	prefix := "FETCH "
+	if isMove {
+		prefix = "MOVE "
+	}
+	sqlStr, terminator := l.ReadSqlConstruct(INTO, ';')
+	if l.lastError != nil {
+		return nil
+	}
+	stmt, err := l.ParseStatement(prefix + sqlStr)
+	if err != nil {
+		l.setErr(err)
+		return nil
+	}
+	fetch := &plpgsqltree.PLpgSQLStmtFetch{IsMove: isMove}
+	switch t := stmt.(type) {
+	case *tree.FetchCursor:
+		fetch.Cursor = t.CursorStmt
+	case *tree.MoveCursor:
+		fetch.Cursor = t.CursorStmt
+	default:
+		l.setErr(errors.AssertionFailedf("unexpected statement %T", stmt))
+		return nil
+	}
+	l.lastPos++
+	if !isMove && terminator != INTO {
+		l.setErr(errors.New("FETCH statement requires an INTO clause"))
+		return nil
+	}
+	if terminator == INTO {
+		if isMove {
+			l.setErr(errors.New("MOVE statement cannot have an INTO clause"))
+			return nil
+		}
+		for {
+			tok := l.Peek()
+			if tok.id != IDENT {
+				l.setErr(errors.Newf("\"%s\" is not a known variable", tok.str))
+				return nil
+			}
+			l.lastPos++
+			fetch.Target = append(fetch.Target, plpgsqltree.PLpgSQLVariable(tok.str))
+			if l.Peek().id != ',' {
+				break
+			}
+			l.lastPos++
+		}
+		if l.Peek().id != ';' {
+			l.setErr(errors.New("syntax error, expected \";\""))
+			return nil
+		}
+		l.lastPos++
+	}
+	return fetch
+}
+
+// isValidSQLState returns true if the given string is a well-formed SQLSTATE
+// code, which consists of five digits or upper-case letters.
+func isValidSQLState(code string) bool {
+	if len(code) != 5 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if !(code[i] >= '0' && code[i] <= '9') && !(code[i] >= 'A' && code[i] <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,8 @@
 package parser
 
 import (
+  "github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+  "github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
   "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser/lexbase"
   "github.com/cockroachdb/cockroach/pkg/sql/scanner"
   "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -130,6 +132,10 @@ func (u *plpgsqlSymUnion) plpgsqlExpr() plpgsqltree.PLpgSQLExpr {
     return u.val.(plpgsqltree.PLpgSQLExpr)
 }
 
+func (u *plpgsqlSymUnion) statement() tree.Statement {
+    return u.val.(tree.Statement)
+}
+
 func (u *plpgsqlSymUnion) plpgsqlDecl() *plpgsqltree.PLpgSQLDecl {
     return u.val.(*plpgsqltree.PLpgSQLDecl)
 }
@@ -138,6 +144,33 @@ func (u *plpgsqlSymUnion) plpgsqlDecls() []plpgsqltree.PLpgSQLDecl {
     return u.val.([]plpgsqltree.PLpgSQLDecl)
 }
 
+func (u *plpgsqlSymUnion) int() int {
+    return u.val.(int)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlExceptionBlock() *plpgsqltree.PLpgSQLExceptionBlock {
+    if u.val == nil {
+      return nil
+    }
+    return u.val.(*plpgsqltree.PLpgSQLExceptionBlock)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlException() *plpgsqltree.PLpgSQLException {
+    return u.val.(*plpgsqltree.PLpgSQLException)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlExceptions() []*plpgsqltree.PLpgSQLException {
+    return u.val.([]*plpgsqltree.PLpgSQLException)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlCondition() *plpgsqltree.PLpgSQLCondition {
+    return u.val.(*plpgsqltree.PLpgSQLCondition)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlConditions() []plpgsqltree.PLpgSQLCondition {
+    return u.val.([]plpgsqltree.PLpgSQLCondition)
+}
+
 %}
 /*
  * Basic non-keyword token types.  These are hard-wired into the core lexer.
@@ -283,7 +316,8 @@ func (u *plpgsqlSymUnion) plpgsqlDecls() []plpgsqltree.PLpgSQLDecl {
 
 %type <str> decl_varname decl_defkey
 %type <bool>	decl_const decl_notnull
-%type <plpgsqltree.PLpgSQLExpr>	decl_defval decl_cursor_query
+%type <plpgsqltree.PLpgSQLExpr>	decl_defval
+%type <tree.Statement>	decl_cursor_query
 %type <tree.ResolvableTypeReference>	decl_datatype
 %type <str>		decl_collate
 %type <plpgsqltree.PLpgSQLDatum>	decl_cursor_args
@@ -293,11 +327,10 @@ func (u *plpgsqlSymUnion) plpgsqlDecls() []plpgsqltree.PLpgSQLDecl {
 %type <str>	expr_until_then expr_until_loop opt_expr_until_when
 %type <plpgsqltree.PLpgSQLExpr>	opt_exitcond
 
-%type <plpgsqltree.PLpgSQLScalarVar>		cursor_variable
-%type <plpgsqltree.PLpgSQLDatum>	decl_cursor_arg
-%type <forvariable>	for_variable
-%type <plpgsqltree.PLpgSQLExpr>	return_variable
-%type <*tree.NumVal>	foreach_slice
+%type <str>		cursor_variable
+%type <str>	for_variable
+%type <plpgsqltree.PLpgSQLExpr>	return_variable opt_return_variable
+%type <int>	foreach_slice
 %type <plpgsqltree.PLpgSQLStatement>	for_control
 
 %type <str>		any_identifier opt_block_label opt_loop_label opt_label query_options
@@ -318,8 +351,10 @@ func (u *plpgsqlSymUnion) plpgsqlDecls() []plpgsqltree.PLpgSQLDecl {
 %type <[]plpgsqltree.PLpgSQLDecl> decl_sect opt_decl_stmts decl_stmts
 
 %type <*plpgsqltree.PLpgSQLExceptionBlock> exception_sect
+%type <[]*plpgsqltree.PLpgSQLException>	proc_exceptions
 %type <*plpgsqltree.PLpgSQLException>	proc_exception
-%type <*plpgsqltree.PLpgSQLCondition>	proc_conditions proc_condition
+%type <[]plpgsqltree.PLpgSQLCondition>	proc_conditions
+%type <*plpgsqltree.PLpgSQLCondition>	proc_condition
 
 %type <*plpgsqltree.PLpgSQLStmtCaseWhenArm>	case_when
 %type <[]*plpgsqltree.PLpgSQLStmtCaseWhenArm>	case_when_list
@@ -332,8 +367,6 @@ func (u *plpgsqlSymUnion) plpgsqlDecls() []plpgsqltree.PLpgSQLDecl {
 
 %type <uint32>	opt_scrollable
 
-%type <*plpgsqltree.PLpgSQLStmtFetch>	opt_fetch_direction
-
 %type <*tree.NumVal>	opt_transaction_chain
 
 %type <str>	unreserved_keyword
@@ -355,6 +388,7 @@ pl_block: opt_block_label decl_sect BEGIN proc_sect exception_sect END opt_label
       Label: $1,
       Decls: $2.plpgsqlDecls(),
       Body: $4.plpgsqlStatements(),
+      Exceptions: $5.plpgsqlExceptionBlock(),
     }
   }
 ;
@@ -435,48 +469,44 @@ decl_statement: decl_varname decl_const decl_datatype decl_collate decl_notnull
   }
 | decl_varname opt_scrollable CURSOR decl_cursor_args decl_is_for decl_cursor_query ';'
   {
-    return unimplemented(plpgsqllex, "cursor")
+    $$.val = &plpgsqltree.PLpgSQLDecl{
+      Var: plpgsqltree.PLpgSQLVariable($1),
+      CursorOptions: $2.uint32(),
+      CursorQuery: $6.statement(),
+    }
   }
 ;
 
 opt_scrollable:
   {
-    return unimplemented(plpgsqllex, "cursor")
+    $$.val = uint32(0)
   }
 | NO_SCROLL SCROLL
   {
-    return unimplemented(plpgsqllex, "cursor")
+    $$.val = plpgsqltree.PLpgSQLCursorOptNoScroll.Mask()
   }
 | SCROLL
   {
-    return unimplemented(plpgsqllex, "cursor")
+    $$.val = plpgsqltree.PLpgSQLCursorOptScroll.Mask()
   }
 ;
 
 decl_cursor_query:
   {
-    plpgsqllex.(*lexer).ReadSqlExpressionStr(';')
+    stmt, err := plpgsqllex.(*lexer).ParseStatement(plpgsqllex.(*lexer).ReadSqlExpressionStr(';'))
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
 ;
 
 decl_cursor_args:
   {
   }
-| '(' decl_cursor_arglist ')'
-  {
-  }
-;
-
-decl_cursor_arglist: decl_cursor_arg
-  {
-  }
-| decl_cursor_arglist ',' decl_cursor_arg
-  {
-  }
-;
-
-decl_cursor_arg: decl_varname decl_datatype
+| '('
   {
+    return unimplemented(plpgsqllex, "cursor arguments")
   }
 ;
 
@@ -593,7 +623,9 @@ proc_sect:
 
 proc_stmt:pl_block ';'
   {
-    $$.val = $1.plpgsqlStmtBlock()
+    block := $1.plpgsqlStmtBlock()
+    block.Nested = true
+    $$.val = block
   }
 | stmt_assign
   {
@@ -608,13 +640,21 @@ proc_stmt:pl_block ';'
     $$.val = $1.plpgsqlStatement()
   }
 | stmt_loop
-  { }
+  {
+    $$.val = $1.plpgsqlStatement()
+  }
 | stmt_while
-  { }
+  {
+    $$.val = $1.plpgsqlStatement()
+  }
 | stmt_for
-  { }
+  {
+    $$.val = $1.plpgsqlStatement()
+  }
 | stmt_foreach_a
-  { }
+  {
+    $$.val = $1.plpgsqlStatement()
+  }
 | stmt_exit
   {
     $$.val = $1.plpgsqlStatement()
@@ -628,7 +668,9 @@ proc_stmt:pl_block ';'
     $$.val = $1.plpgsqlStatement()
   }
 | stmt_raise
-  { }
+  {
+    $$.val = $1.plpgsqlStatement()
+  }
 | stmt_assert
   {
     $$.val = $1.plpgsqlStatement()
@@ -650,11 +692,17 @@ proc_stmt:pl_block ';'
 | stmt_getdiag
   { }
 | stmt_open
-  { }
+  {
+    $$.val = $1.plpgsqlStatement()
+  }
 | stmt_fetch
-  { }
+  {
+    $$.val = $1.plpgsqlStatement()
+  }
 | stmt_move
-  { }
+  {
+    $$.val = $1.plpgsqlStatement()
+  }
 | stmt_close
   {
     $$.val = $1.plpgsqlStatement()
@@ -899,22 +947,45 @@ stmt_loop: opt_loop_label LOOP loop_body opt_label ';'
   }
 ;
 
-stmt_while: opt_loop_label WHILE expr_until_loop loop_body
+stmt_while: opt_loop_label WHILE expr_until_loop LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "while loop")
+    cond, err := plpgsqllex.(*lexer).ParseExpr($3)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.PLpgSQLStmtWhileLoop{
+      Label: $1,
+      Condition: cond,
+      Body: $5.plpgsqlStatements(),
+    }
   }
 ;
 
-stmt_for: opt_loop_label FOR for_control loop_body
+stmt_for: opt_loop_label FOR for_control LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for loop")
+    loop := $3.plpgsqlStatement()
+    switch t := loop.(type) {
+    case *plpgsqltree.PLpgSQLStmtForIntLoop:
+      t.Label = $1
+      t.Body = $5.plpgsqlStatements()
+    case *plpgsqltree.PLpgSQLStmtForQuerySelectLoop:
+      t.Label = $1
+      t.Body = $5.plpgsqlStatements()
+    case *plpgsqltree.PLpgSQLStmtForQueryCursorLoop:
+      t.Label = $1
+      t.Body = $5.plpgsqlStatements()
+    }
+    $$.val = loop
   }
 ;
 
 for_control: for_variable IN
-  // TODO need to parse the sql expression here.
   {
-    return unimplemented(plpgsqllex, "for loop")
+    loop := plpgsqllex.(*lexer).MakeForLoopControl($1)
+    if loop == nil {
+      return 1
+    }
+    $$.val = loop
   }
 ;
 
@@ -937,22 +1008,38 @@ for_control: for_variable IN
  * $$.row; see the for_control production.
  */
 for_variable: any_identifier
-  {
-    return unimplemented(plpgsqllex, "for loop")
-  }
 ;
 
-stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop loop_body
+stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for each loop")
+    expr, err := plpgsqllex.(*lexer).ParseExpr($7)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.PLpgSQLStmtForEachALoop{
+      Label: $1,
+      Var: plpgsqltree.PLpgSQLVariable($3),
+      Slice: $4.int(),
+      Expr: expr,
+      Body: $9.plpgsqlStatements(),
+    }
   }
 ;
 
 foreach_slice:
   {
+    $$.val = 0
   }
 | SLICE ICONST
   {
+    slice, err := $2.numVal().AsInt64()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    if slice < 0 {
+      return setErr(plpgsqllex, errors.New("SLICE must not be negative"))
+    }
+    $$.val = int(slice)
   }
 ;
 
@@ -980,7 +1067,7 @@ stmt_continue: CONTINUE opt_label opt_exitcond
   // 3. if no, expecting a sql expression "read_sql_expression"
   //    we can just read until a ';', then do the sql expression validation during compile time.
 
-stmt_return: RETURN return_variable ';'
+stmt_return: RETURN opt_return_variable ';'
   {
     $$.val = &plpgsqltree.PLpgSQLStmtReturn{
       Expr: $2.plpgsqlExpr(),
@@ -988,26 +1075,51 @@ stmt_return: RETURN return_variable ';'
   }
 | RETURN_NEXT NEXT return_variable ';'
   {
-    return unimplemented(plpgsqllex, "return next")
+    $$.val = &plpgsqltree.PLpgSQLStmtReturnNext{
+      Expr: $3.plpgsqlExpr(),
+    }
   }
 | RETURN_QUERY QUERY query_options ';'
- {
-   return unimplemented (plpgsqllex, "return query")
- }
+  {
+    query, err := plpgsqllex.(*lexer).ParseStatement($3)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.PLpgSQLStmtReturnQuery{
+      Query: query,
+    }
+  }
 ;
 
 
 query_options:
   {
-    _, terminator := plpgsqllex.(*lexer).ReadSqlExpressionStr2(EXECUTE, ';')
+    sqlStr, terminator := plpgsqllex.(*lexer).ReadSqlExpressionStr2(EXECUTE, ';')
     if terminator == EXECUTE {
       return unimplemented (plpgsqllex, "return dynamic sql query")
     }
-    plpgsqllex.(*lexer).ReadSqlExpressionStr(';')
+    $$ = sqlStr
   }
 ;
 
 
+// RETURN may omit the expression in a function that returns VOID or a set, or
+// in a procedure.
+opt_return_variable:
+  {
+    sqlStr := plpgsqllex.(*lexer).ReadOptionalSqlExpressionStr(';')
+    if sqlStr == "" {
+      $$.val = (plpgsqltree.PLpgSQLExpr)(nil)
+    } else {
+      expr, err := plpgsqllex.(*lexer).ParseExpr(sqlStr)
+      if err != nil {
+        return setErr(plpgsqllex, err)
+      }
+      $$.val = expr
+    }
+  }
+;
+
 return_variable: expr_until_semi
   {
     expr, err := plpgsqllex.(*lexer).ParseExpr($1)
@@ -1018,59 +1130,18 @@ return_variable: expr_until_semi
   }
 ;
 
-stmt_raise: RAISE error_level ';'
+// The RAISE statement is processed by the lexer, since the format string, its
+// parameters and the USING options are all comma-separated.
+stmt_raise: RAISE
   {
+    raise := plpgsqllex.(*lexer).MakeRaiseStmt()
+    if raise == nil {
+      return 1
+    }
+    $$.val = raise
   }
 ;
 
-error_level:
-  {
-    return unimplemented(plpgsqllex, "raise")
-  }
-| EXCEPTION raise_cond option_expr
-  {
-    return unimplemented(plpgsqllex, "raise exception")
-  }
-| DEBUG raise_cond option_expr
-  {
-    return unimplemented(plpgsqllex, "raise debug")
-  }
-| LOG raise_cond option_expr
-  {
-    return unimplemented(plpgsqllex, "raise log")
-  }
-| INFO raise_cond option_expr
-  {
-    return unimplemented(plpgsqllex, "raise info")
-  }
-| WARNING raise_cond option_expr
-  {
-    return unimplemented(plpgsqllex, "raise warning")
-  }
-;
-
-raise_cond:
-  {}
-| SCONST
-  {}
-| SQLSTATE
-  {}
-| IDENT
-  {}
-;
-
-
-option_expr:
-  {}
-| USING MESSAGE assign_operator expr_until_semi
-  {}
-| USING DETAIL assign_operator expr_until_semi
-  {}
-| USING HINT assign_operator expr_until_semi
-  {}
-| USING ERRCODE assign_operator expr_until_semi
-  {}
-
 stmt_assert: ASSERT assert_cond ';'
   {
     $$.val = &plpgsqltree.PLpgSQLStmtAssert{}
@@ -1120,31 +1191,39 @@ stmt_dynexecute: EXECUTE
 stmt_open: OPEN IDENT open_stmt_processor ';'
   {
     openCursorStmt := $3.pLpgSQLStmtOpen()
+    if openCursorStmt == nil {
+      return 1
+    }
     openCursorStmt.CursorName = $2
     $$.val = openCursorStmt
   }
 ;
 
-stmt_fetch: FETCH opt_fetch_direction IDENT INTO
+// FETCH and MOVE are processed by the lexer, since the fetch direction can
+// take many forms.
+stmt_fetch: FETCH
   {
-    return unimplemented(plpgsqllex, "fetch")
+    fetch := plpgsqllex.(*lexer).MakeFetchOrMoveStmt(false /* isMove */)
+    if fetch == nil {
+      return 1
+    }
+    $$.val = fetch
   }
 ;
 
-stmt_move: MOVE opt_fetch_direction IDENT ';'
+stmt_move: MOVE
   {
-    return unimplemented(plpgsqllex, "move")
+    move := plpgsqllex.(*lexer).MakeFetchOrMoveStmt(true /* isMove */)
+    if move == nil {
+      return 1
+    }
+    $$.val = move
   }
 ;
 
-opt_fetch_direction:
-  {
-      return unimplemented(plpgsqllex, "fetch direction")
-  }
-
 stmt_close: CLOSE cursor_variable ';'
   {
-    $$.val = &plpgsqltree.PLpgSQLStmtClose{}
+    $$.val = &plpgsqltree.PLpgSQLStmtClose{CursorName: $2}
   }
 ;
 
@@ -1176,48 +1255,70 @@ AND CHAIN
 
 cursor_variable: IDENT
   {
-    unimplemented(plpgsqllex, "cursor variable")
+    $$ = $1
   }
 ;
 
 exception_sect:
-  { }
-| EXCEPTION
   {
-    return unimplemented(plpgsqllex, "exception")
+    $$.val = (*plpgsqltree.PLpgSQLExceptionBlock)(nil)
   }
-  proc_exceptions
+| EXCEPTION proc_exceptions
   {
-    unimplemented(plpgsqllex, "exception")
+    $$.val = &plpgsqltree.PLpgSQLExceptionBlock{
+      ExecList: $2.plpgsqlExceptions(),
+    }
   }
 ;
 
 proc_exceptions: proc_exceptions proc_exception
   {
+    $$.val = append($1.plpgsqlExceptions(), $2.plpgsqlException())
   }
 | proc_exception
   {
+    $$.val = []*plpgsqltree.PLpgSQLException{$1.plpgsqlException()}
   }
 ;
 
 proc_exception: WHEN proc_conditions THEN proc_sect
   {
+    $$.val = &plpgsqltree.PLpgSQLException{
+      Conditions: $2.plpgsqlConditions(),
+      Action: $4.plpgsqlStatements(),
+    }
   }
 ;
 
 proc_conditions: proc_conditions OR proc_condition
   {
+    $$.val = append($1.plpgsqlConditions(), *$3.plpgsqlCondition())
   }
 | proc_condition
   {
+    $$.val = []plpgsqltree.PLpgSQLCondition{*$1.plpgsqlCondition()}
   }
 ;
 
 proc_condition: any_identifier
   {
+    // OTHERS is a special condition that matches all error codes except
+    // query_canceled and assert_failure.
+    if $1 != "others" {
+      if _, ok := pgcode.PLpgSQLConditionNameToCode[$1]; !ok {
+        return setErr(plpgsqllex, pgerror.Newf(
+          pgcode.UndefinedObject, "unrecognized exception condition \"%s\"", $1,
+        ))
+      }
+    }
+    $$.val = &plpgsqltree.PLpgSQLCondition{Name: $1}
   }
 | SQLSTATE SCONST
   {
+    if !isValidSQLState($2) {
+      return setErr(plpgsqllex, errors.New("invalid SQLSTATE code"))
+    }
+    $$.val = &plpgsqltree.PLpgSQLCondition{SqlErrState: $2}
   }
 ;
 
@@ -1240,7 +1341,7 @@ expr_until_then:
 
 expr_until_loop:
   {
-    return unimplemented(plpgsqllex, "loop expr")
+    $$ = plpgsqllex.(*lexer).ReadSqlExpressionStr(LOOP)
   }
 ;
 
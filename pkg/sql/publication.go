@@ -0,0 +1,227 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+// Publications are stored in the descriptor of their database, sorted by
+// name. They are only read by logical replication streams, see
+// replication_stream.go.
+
+// findPublication returns the index of the publication with the given name in
+// pubs, which is sorted by name, and whether it exists. If it does not exist,
+// the index is the one at which it would be inserted.
+func findPublication(pubs []descpb.DatabaseDescriptor_Publication, name string) (int, bool) {
+	i := sort.Search(len(pubs), func(i int) bool { return pubs[i].Name >= name })
+	return i, i < len(pubs) && pubs[i].Name == name
+}
+
+// publicationIncludesTable returns whether the changes to a table are
+// published by a publication.
+func publicationIncludesTable(
+	pub *descpb.DatabaseDescriptor_Publication, desc catalog.TableDescriptor,
+) bool {
+	if !desc.IsTable() || desc.IsVirtualTable() || desc.IsTemporary() || desc.Dropped() {
+		return false
+	}
+	if pub.AllTables {
+		return true
+	}
+	for _, id := range pub.TableIDs {
+		if id == desc.GetID() {
+			return true
+		}
+	}
+	return false
+}
+
+type createPublicationNode struct {
+	n        *tree.CreatePublication
+	dbDesc   *dbdesc.Mutable
+	tableIDs []descpb.ID
+}
+
+// CreatePublication creates a publication in the current database.
+// Privileges: CREATE on the database, CHANGEFEED on the published tables, and
+// the admin role for FOR ALL TABLES.
+func (p *planner) CreatePublication(
+	ctx context.Context, n *tree.CreatePublication,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+	if p.CurrentDatabase() == "" {
+		return nil, errNoDatabase
+	}
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if n.AllTables {
+		if err := p.RequireAdminRole(ctx, "CREATE PUBLICATION ... FOR ALL TABLES"); err != nil {
+			return nil, err
+		}
+	}
+
+	var tableIDs []descpb.ID
+	seen := make(map[descpb.ID]struct{}, len(n.Tables))
+	flags := tree.ObjectLookupFlags{
+		Required:             true,
+		DesiredObjectKind:    tree.TableObject,
+		DesiredTableDescKind: tree.ResolveRequireTableDesc,
+	}
+	for i := range n.Tables {
+		_, desc, err := resolver.ResolveExistingTableObject(ctx, p, &n.Tables[i], flags)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPublishableTable(dbDesc, desc); err != nil {
+			return nil, err
+		}
+		if err := p.CheckPrivilege(ctx, desc, privilege.CHANGEFEED); err != nil {
+			return nil, err
+		}
+		if _, ok := seen[desc.GetID()]; ok {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"table %q specified more than once", desc.GetName())
+		}
+		seen[desc.GetID()] = struct{}{}
+		tableIDs = append(tableIDs, desc.GetID())
+	}
+
+	return &createPublicationNode{n: n, dbDesc: dbDesc, tableIDs: tableIDs}, nil
+}
+
+// checkPublishableTable checks that the changes to a table can be published
+// by the given database.
+func checkPublishableTable(db catalog.DatabaseDescriptor, desc catalog.TableDescriptor) error {
+	switch {
+	case desc.GetParentID() != db.GetID():
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot add table %q from another database to a publication", desc.GetName())
+	case desc.IsVirtualTable() || desc.IsTemporary():
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"cannot add system or temporary table %q to a publication", desc.GetName())
+	case len(desc.GetFamilies()) > 1:
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot add table %q with multiple column families to a publication", desc.GetName())
+	}
+	return nil
+}
+
+func (n *createPublicationNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("publication"))
+	name := string(n.n.Name)
+	i, ok := findPublication(n.dbDesc.Publications, name)
+	if ok {
+		return pgerror.Newf(pgcode.DuplicateObject, "publication %q already exists", name)
+	}
+	pub := descpb.DatabaseDescriptor_Publication{
+		Name:       name,
+		OwnerProto: params.p.User().EncodeProto(),
+		AllTables:  n.n.AllTables,
+		TableIDs:   n.tableIDs,
+	}
+	pubs := append(n.dbDesc.Publications, descpb.DatabaseDescriptor_Publication{})
+	copy(pubs[i+1:], pubs[i:])
+	pubs[i] = pub
+	n.dbDesc.Publications = pubs
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (*createPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (*createPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createPublicationNode) Close(context.Context)        {}
+
+type dropPublicationNode struct {
+	n      *tree.DropPublication
+	dbDesc *dbdesc.Mutable
+}
+
+// DropPublication drops publications of the current database.
+// Privileges: ownership of the publications, or the admin role.
+func (p *planner) DropPublication(ctx context.Context, n *tree.DropPublication) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+	if p.CurrentDatabase() == "" {
+		return nil, errNoDatabase
+	}
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	return &dropPublicationNode{n: n, dbDesc: dbDesc}, nil
+}
+
+func (n *dropPublicationNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("publication"))
+	p := params.p
+	var dropped bool
+	for _, name := range n.n.Names {
+		i, ok := findPublication(n.dbDesc.Publications, string(name))
+		if !ok {
+			if n.n.IfExists {
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject, "publication %q does not exist", name)
+		}
+		if owner := n.dbDesc.Publications[i].OwnerProto.Decode(); owner != p.User() {
+			isAdmin, err := p.HasAdminRole(params.ctx)
+			if err != nil {
+				return err
+			}
+			if !isAdmin {
+				return pgerror.Newf(pgcode.InsufficientPrivilege,
+					"must be owner of publication %s", name)
+			}
+		}
+		n.dbDesc.Publications = append(n.dbDesc.Publications[:i], n.dbDesc.Publications[i+1:]...)
+		dropped = true
+	}
+	if !dropped {
+		return nil
+	}
+	return p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (*dropPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropPublicationNode) Close(context.Context)        {}
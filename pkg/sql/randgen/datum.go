@@ -21,6 +21,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/geo"
 	"github.com/cockroachdb/cockroach/pkg/geo/geogen"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -29,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -128,6 +130,22 @@ func RandDatumWithNullChance(
 		return tree.NewDBox2D(*b)
 	case types.PGLSNFamily:
 		return tree.NewDPGLSN(lsn.LSN(rng.Uint64()))
+	case types.PointFamily:
+		return tree.NewDPoint(randGeometricPoint(rng))
+	case types.BoxFamily:
+		return tree.NewDBox(geometric.MakeBox(randGeometricPoint(rng), randGeometricPoint(rng)))
+	case types.PathFamily:
+		return tree.NewDPath(geometric.Path{
+			Points: randGeometricPoints(rng),
+			Closed: rng.Intn(2) == 0,
+		})
+	case types.PolygonFamily:
+		return tree.NewDPolygon(geometric.Polygon{Points: randGeometricPoints(rng)})
+	case types.CircleFamily:
+		return tree.NewDCircle(geometric.Circle{
+			Center: randGeometricPoint(rng),
+			Radius: math.Abs(rng.NormFloat64()),
+		})
 	case types.GeographyFamily:
 		gm, err := typ.GeoMetadata()
 		if err != nil {
@@ -287,6 +305,8 @@ func RandDatumWithNullChance(
 		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TSQueryFamily:
 		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.JsonpathFamily:
+		return tree.NewDJsonpath(jsonpath.RandomJsonpath(rng))
 	default:
 		panic(errors.AssertionFailedf("invalid type %v", typ.DebugString()))
 	}
@@ -479,10 +499,24 @@ func RandDatumSimple(rng *rand.Rand, typ *types.T) tree.Datum {
 		datum = tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
 	case types.TSVectorFamily:
 		datum = tree.NewDTSVector(tsearch.RandomTSVector(rng))
+	case types.JsonpathFamily:
+		datum = tree.NewDJsonpath(jsonpath.RandomJsonpath(rng))
 	}
 	return datum
 }
 
+func randGeometricPoint(rng *rand.Rand) geometric.Point {
+	return geometric.Point{X: rng.NormFloat64(), Y: rng.NormFloat64()}
+}
+
+func randGeometricPoints(rng *rand.Rand) []geometric.Point {
+	pts := make([]geometric.Point, 1+rng.Intn(5))
+	for i := range pts {
+		pts[i] = randGeometricPoint(rng)
+	}
+	return pts
+}
+
 func randStringSimple(rng *rand.Rand) string {
 	return string(rune('A' + rng.Intn(simpleRange)))
 }
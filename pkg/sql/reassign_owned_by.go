@@ -300,19 +300,25 @@ func (n *reassignOwnedByNode) reassignTypeOwner(
 	if mutableTypDesc.Dropped() {
 		return nil
 	}
-	arrayDesc, err := params.p.Descriptors().MutableByID(params.p.txn).Type(params.ctx, typDesc.GetArrayTypeID())
-	if err != nil {
-		return err
+	// Domains have no array type.
+	var arrayDesc *typedesc.Mutable
+	var arrayTypeName tree.TypeName
+	if typDesc.GetArrayTypeID() != descpb.InvalidID {
+		arrayDesc, err = params.p.Descriptors().MutableByID(params.p.txn).Type(params.ctx, typDesc.GetArrayTypeID())
+		if err != nil {
+			return err
+		}
+		name, err := params.p.getQualifiedTypeName(params.ctx, arrayDesc)
+		if err != nil {
+			return err
+		}
+		arrayTypeName = *name
 	}
 
 	typeName, err := params.p.getQualifiedTypeName(params.ctx, mutableTypDesc.(*typedesc.Mutable))
 	if err != nil {
 		return err
 	}
-	arrayTypeName, err := params.p.getQualifiedTypeName(params.ctx, arrayDesc)
-	if err != nil {
-		return err
-	}
 
 	owner, err := decodeusername.FromRoleSpec(
 		params.p.SessionData(), username.PurposeValidation, n.n.NewRole,
@@ -322,7 +328,7 @@ func (n *reassignOwnedByNode) reassignTypeOwner(
 	}
 	if err := params.p.setNewTypeOwner(
 		params.ctx, mutableTypDesc.(*typedesc.Mutable), arrayDesc, *typeName,
-		*arrayTypeName, owner); err != nil {
+		arrayTypeName, owner); err != nil {
 		return err
 	}
 	if err := params.p.writeTypeSchemaChange(
@@ -330,6 +336,9 @@ func (n *reassignOwnedByNode) reassignTypeOwner(
 	); err != nil {
 		return err
 	}
+	if arrayDesc == nil {
+		return nil
+	}
 	if err := params.p.writeTypeSchemaChange(
 		params.ctx, arrayDesc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
 	); err != nil {
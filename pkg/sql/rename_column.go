@@ -160,6 +160,9 @@ func (p *planner) renameColumn(
 	if tableDesc.IsShardColumn(col) {
 		return false, pgerror.Newf(pgcode.ReservedName, "cannot rename shard column")
 	}
+	if err := p.checkColumnNotInherited(ctx, tableDesc, oldName, "rename"); err != nil {
+		return false, err
+	}
 	if err := tabledesc.RenameColumnInTable(tableDesc, col, newName, func(shardCol catalog.Column, newShardColName tree.Name) (bool, error) {
 		if c, err := p.findColumnToRename(ctx, tableDesc, shardCol.ColName(), newShardColName); err != nil || c == nil {
 			return false, err
@@ -0,0 +1,385 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptreconcile"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// Logical replication slots are stored in system.replication_slots, keyed by
+// the ID of their database and their name. A slot records the position up to which a client confirmed
+// that it flushed the changes, after which changes are streamed to clients
+// that do not request a later position. See replication_stream.go. The changes
+// after that position are protected from garbage collection by a protected
+// timestamp record, which is released when the slot is dropped.
+
+// ReplicationSlotProtectedTimestampMetaType is the meta type of the protected
+// timestamp records of replication slots.
+const ReplicationSlotProtectedTimestampMetaType = "replication_slots"
+
+// maxReplicationSlotNameLen is the maximum length of the name of a
+// replication slot, which is the same as in Postgres.
+const maxReplicationSlotNameLen = 63
+
+// replicationSlot is a row of system.replication_slots.
+type replicationSlot struct {
+	name   string
+	plugin string
+	// consistentPoint is the position at which the slot was created.
+	consistentPoint lsn.LSN
+	// confirmedFlush is the position up to which a client confirmed that it
+	// flushed the changes.
+	confirmedFlush lsn.LSN
+	// ptsRecordID is the ID of the protected timestamp record of the slot, or
+	// uuid.Nil.
+	ptsRecordID uuid.UUID
+}
+
+// checkReplicationSlotsVersion returns an error if system.replication_slots
+// may not exist yet.
+func checkReplicationSlotsVersion(ctx context.Context, st *cluster.Settings) error {
+	if !st.Version.IsActive(ctx, clusterversion.V23_2_ReplicationSlotsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"replication slots are not supported until upgrade to version %v is finalized",
+			clusterversion.ByKey(clusterversion.V23_2_ReplicationSlotsTable))
+	}
+	return nil
+}
+
+// getReplicationSlot reads a replication slot, and returns whether it exists.
+func getReplicationSlot(
+	ctx context.Context, txn isql.Txn, dbID descpb.ID, name string,
+) (replicationSlot, bool, error) {
+	row, err := txn.QueryRowEx(
+		ctx, "get-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT plugin, consistent_lsn, confirmed_flush_lsn, pts_record_id
+FROM system.replication_slots WHERE database_id = $1 AND slot_name = $2`,
+		tree.NewDInt(tree.DInt(dbID)), name,
+	)
+	if err != nil || row == nil {
+		return replicationSlot{}, false, err
+	}
+	slot := replicationSlot{
+		name:            name,
+		plugin:          string(tree.MustBeDString(row[0])),
+		consistentPoint: lsn.LSN(tree.MustBeDInt(row[1])),
+		confirmedFlush:  lsn.LSN(tree.MustBeDInt(row[2])),
+	}
+	if row[3] != tree.DNull {
+		slot.ptsRecordID = tree.MustBeDUuid(row[3]).UUID
+	}
+	return slot, true, nil
+}
+
+// encodeReplicationSlotMeta encodes the database ID and name of a replication
+// slot, which identify the slot in the meta of its protected timestamp record.
+// Slot names cannot contain slashes.
+func encodeReplicationSlotMeta(dbID descpb.ID, name string) []byte {
+	return []byte(fmt.Sprintf("%d/%s", dbID, name))
+}
+
+// decodeReplicationSlotMeta decodes the result of encodeReplicationSlotMeta.
+func decodeReplicationSlotMeta(meta []byte) (dbID descpb.ID, name string, _ error) {
+	id, name, ok := strings.Cut(string(meta), "/")
+	if !ok {
+		return 0, "", errors.Newf("failed to interpret meta %q as a replication slot", meta)
+	}
+	parsed, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0, "", errors.Wrapf(err, "failed to interpret meta %q as a replication slot", meta)
+	}
+	return descpb.ID(parsed), name, nil
+}
+
+// MakeReplicationSlotStatusFunc returns the function used by the protected
+// timestamp reconciler to remove the records of the replication slots which no
+// longer exist. The slots of dropped databases are deleted along with their
+// records.
+func MakeReplicationSlotStatusFunc(codec keys.SQLCodec) ptreconcile.StatusFunc {
+	return func(ctx context.Context, txn isql.Txn, meta []byte) (shouldRemove bool, _ error) {
+		dbID, name, err := decodeReplicationSlotMeta(meta)
+		if err != nil {
+			return false, err
+		}
+		kv, err := txn.KV().Get(ctx, catalogkeys.MakeDescMetadataKey(codec, dbID))
+		if err != nil {
+			return false, err
+		}
+		dropped := !kv.Exists()
+		if !dropped {
+			var desc descpb.Descriptor
+			if err := kv.ValueProto(&desc); err != nil {
+				return false, err
+			}
+			db := desc.GetDatabase()
+			dropped = db == nil || db.State == descpb.DescriptorState_DROP
+		}
+		if dropped {
+			_, err := txn.ExecEx(
+				ctx, "delete-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+				`DELETE FROM system.replication_slots WHERE database_id = $1 AND slot_name = $2`,
+				tree.NewDInt(tree.DInt(dbID)), name,
+			)
+			return err == nil, err
+		}
+		_, ok, err := getReplicationSlot(ctx, txn, dbID, name)
+		return !ok, err
+	}
+}
+
+// validateReplicationSlotName checks that a replication slot name only
+// contains lower case letters, numbers and underscores, like in Postgres.
+func validateReplicationSlotName(name string) error {
+	if name == "" {
+		return pgerror.New(pgcode.InvalidName, "replication slot name is too short")
+	}
+	if len(name) > maxReplicationSlotNameLen {
+		return pgerror.Newf(pgcode.NameTooLong, "replication slot name %q is too long", name)
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_') {
+			return pgerror.WithCandidateCode(
+				errors.WithHint(
+					errors.Newf("replication slot name %q contains invalid character", name),
+					"Replication slot names may only contain lower case letters, numbers, and the underscore character.",
+				),
+				pgcode.InvalidName,
+			)
+		}
+	}
+	return nil
+}
+
+// checkLogicalReplicationConnection checks that the session was opened with
+// replication=database, which is required to use logical replication slots.
+func (p *planner) checkLogicalReplicationConnection() error {
+	if p.SessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"logical decoding requires a database connection")
+	}
+	if p.CurrentDatabase() == "" {
+		return errNoDatabase
+	}
+	return nil
+}
+
+// replicationSlotDatabase returns the descriptor of the current database, to
+// which the replication slots of the session belong, after checking that the
+// user can modify them.
+func (p *planner) replicationSlotDatabase(
+	ctx context.Context,
+) (catalog.DatabaseDescriptor, error) {
+	if err := p.checkLogicalReplicationConnection(); err != nil {
+		return nil, err
+	}
+	if err := checkReplicationSlotsVersion(ctx, p.ExecCfg().Settings); err != nil {
+		return nil, err
+	}
+	dbDesc, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	return dbDesc, nil
+}
+
+var identifySystemColumns = colinfo.ResultColumns{
+	{Name: "systemid", Typ: types.String},
+	{Name: "timeline", Typ: types.Int4},
+	{Name: "xlogpos", Typ: types.String},
+	{Name: "dbname", Typ: types.String},
+}
+
+// IdentifySystem implements the IDENTIFY_SYSTEM replication command. The
+// current position in the log is the LSN of the current time.
+func (p *planner) IdentifySystem(
+	ctx context.Context, n *pgrepltree.IdentifySystem,
+) (planNode, error) {
+	return &delayedNode{
+		name:    n.StatementTag(),
+		columns: identifySystemColumns,
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			dbName := tree.DNull
+			if p.SessionData().ReplicationMode == sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+				dbName = tree.NewDString(p.CurrentDatabase())
+			}
+			v := p.newContainerValuesNode(identifySystemColumns, 1)
+			if _, err := v.rows.AddRow(ctx, tree.Datums{
+				tree.NewDString(p.ExecCfg().NodeInfo.LogicalClusterID().String()),
+				tree.NewDInt(1),
+				tree.NewDString(lsn.FromHLC(p.ExecCfg().Clock.Now()).String()),
+				dbName,
+			}); err != nil {
+				v.Close(ctx)
+				return nil, err
+			}
+			return v, nil
+		},
+	}, nil
+}
+
+var createReplicationSlotColumns = colinfo.ResultColumns{
+	{Name: "slot_name", Typ: types.String},
+	{Name: "consistent_point", Typ: types.String},
+	{Name: "snapshot_name", Typ: types.String},
+	{Name: "output_plugin", Typ: types.String},
+}
+
+// CreateReplicationSlot implements the CREATE_REPLICATION_SLOT replication
+// command. Only permanent logical slots using the pgoutput plugin are
+// supported. Snapshots are not exported, but clients can read the data as of
+// the consistent point with AS OF SYSTEM TIME. The consistent point is the end
+// of the LSN preceding the current time, so that the changes which share the
+// LSN of the current time are streamed.
+// Privileges: CREATE on the current database.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *pgrepltree.CreateReplicationSlot,
+) (planNode, error) {
+	if n.Kind != pgrepltree.LogicalReplication {
+		return nil, unimplemented.New("physical replication", "physical replication slots are not supported")
+	}
+	if n.Temporary {
+		return nil, unimplemented.New("temporary replication slot", "temporary replication slots are not supported")
+	}
+	if n.Plugin != pgoutput.PluginName {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"output plugin %q is not supported; use %q", n.Plugin, pgoutput.PluginName)
+	}
+	name := string(n.Slot)
+	if err := validateReplicationSlotName(name); err != nil {
+		return nil, err
+	}
+	dbDesc, err := p.replicationSlotDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &delayedNode{
+		name:    n.StatementTag(),
+		columns: createReplicationSlotColumns,
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			pos := lsn.FromHLC(p.txn.ReadTimestamp()).Sub(1)
+			ptsRecordID := uuid.MakeV4()
+			if inserted, err := p.InternalSQLTxn().ExecEx(
+				ctx, "create-replication-slot", p.txn, sessiondata.NodeUserSessionDataOverride,
+				`INSERT INTO system.replication_slots
+  (database_id, slot_name, plugin, consistent_lsn, confirmed_flush_lsn, pts_record_id)
+VALUES ($1, $2, $3, $4, $4, $5) ON CONFLICT DO NOTHING`,
+				tree.NewDInt(tree.DInt(dbDesc.GetID())), name, string(n.Plugin),
+				tree.NewDInt(tree.DInt(pos)), tree.NewDUuid(tree.DUuid{UUID: ptsRecordID}),
+			); err != nil {
+				return nil, err
+			} else if inserted == 0 {
+				return nil, pgerror.Newf(pgcode.DuplicateObject,
+					"replication slot %q already exists", name)
+			}
+			pts := p.ExecCfg().ProtectedTimestampProvider.WithTxn(p.InternalSQLTxn())
+			if err := pts.Protect(ctx, &ptpb.Record{
+				ID:        ptsRecordID.GetBytesMut(),
+				Timestamp: pos.EndHLC(),
+				Mode:      ptpb.PROTECT_AFTER,
+				MetaType:  ReplicationSlotProtectedTimestampMetaType,
+				Meta:      encodeReplicationSlotMeta(dbDesc.GetID(), name),
+				Target:    ptpb.MakeSchemaObjectsTarget(descpb.IDs{dbDesc.GetID()}),
+			}); err != nil {
+				return nil, err
+			}
+
+			v := p.newContainerValuesNode(createReplicationSlotColumns, 1)
+			if _, err := v.rows.AddRow(ctx, tree.Datums{
+				tree.NewDString(name),
+				tree.NewDString(pos.String()),
+				tree.DNull,
+				tree.NewDString(string(n.Plugin)),
+			}); err != nil {
+				v.Close(ctx)
+				return nil, err
+			}
+			return v, nil
+		},
+	}, nil
+}
+
+type dropReplicationSlotNode struct {
+	n    *pgrepltree.DropReplicationSlot
+	dbID descpb.ID
+}
+
+// DropReplicationSlot implements the DROP_REPLICATION_SLOT replication
+// command, which releases the protected timestamp of the slot. Since slots are
+// not marked as active while they are streamed, WAIT has no effect.
+// Privileges: CREATE on the current database.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *pgrepltree.DropReplicationSlot,
+) (planNode, error) {
+	dbDesc, err := p.replicationSlotDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dropReplicationSlotNode{n: n, dbID: dbDesc.GetID()}, nil
+}
+
+func (n *dropReplicationSlotNode) startExec(params runParams) error {
+	name := string(n.n.Slot)
+	row, err := params.p.InternalSQLTxn().QueryRowEx(
+		params.ctx, "drop-replication-slot", params.p.txn, sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.replication_slots WHERE database_id = $1 AND slot_name = $2
+RETURNING pts_record_id`,
+		tree.NewDInt(tree.DInt(n.dbID)), name,
+	)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return pgerror.Newf(pgcode.UndefinedObject, "replication slot %q does not exist", name)
+	}
+	if row[0] != tree.DNull {
+		pts := params.ExecCfg().ProtectedTimestampProvider.WithTxn(params.p.InternalSQLTxn())
+		id := tree.MustBeDUuid(row[0]).UUID
+		if err := pts.Release(params.ctx, id); err != nil && !errors.Is(err, protectedts.ErrNotExists) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropReplicationSlotNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropReplicationSlotNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropReplicationSlotNode) Close(context.Context)        {}
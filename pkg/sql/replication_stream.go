@@ -0,0 +1,915 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"container/heap"
+	"context"
+	"strings"
+	"time"
+	"unicode"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxlog"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// A logical replication stream sends the changes to the tables of a set of
+// publications to a client of the streaming replication protocol, encoded by
+// the pgoutput plugin. The changes are read with a rangefeed over the tables,
+// and are buffered until the frontier of the rangefeed passes all the
+// timestamps with their LSN, which is derived from their timestamp; see
+// lsn.FromHLC. All the changes with a given LSN are then sent as one
+// transaction, so that the LSN identifies the transaction. The memory used by
+// the buffered changes is accounted for, and the stream fails if it exceeds
+// sql.replication_slot.max_buffered_bytes.
+//
+// The position up to which the client confirmed that it flushed the changes
+// is periodically persisted in the slot, together with the protected
+// timestamp of the slot. Streaming resumes after the persisted position or the
+// position requested by the client, whichever is later. The changes confirmed
+// after the position was last persisted are sent again if the stream restarts,
+// so the delivery is at-least-once.
+
+// replicationKeepaliveInterval is the interval at which the current end of
+// the stream is sent to the client, when there are no changes to send.
+const replicationKeepaliveInterval = 10 * time.Second
+
+// replicationSlotPersistInterval is the minimum interval at which the position
+// confirmed by the client of a replication stream is persisted in its slot.
+var replicationSlotPersistInterval = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.replication_slot.persist_interval",
+	"minimum interval at which the position confirmed by a logical replication "+
+		"client is persisted in its replication slot",
+	time.Minute,
+	settings.NonNegativeDuration,
+)
+
+// replicationStreamMaxBufferedBytes limits the memory used by the changes
+// buffered by a replication stream until the rangefeed frontier passes them.
+var replicationStreamMaxBufferedBytes = settings.RegisterByteSizeSetting(
+	settings.TenantWritable,
+	"sql.replication_slot.max_buffered_bytes",
+	"maximum amount of memory used by a logical replication stream to buffer "+
+		"the changes which cannot be sent yet",
+	64<<20, /* 64 MiB */
+	settings.PositiveInt,
+)
+
+// execStartReplication executes START_REPLICATION, streaming changes to the
+// client until it ends the Copy-both subprotocol. cmd.Done is decremented once
+// the connection is handed back to the network routine.
+func (ex *connExecutor) execStartReplication(
+	ctx context.Context, cmd StartReplication,
+) (retErr error) {
+	// Until the client starts streaming, the network routine is only waiting
+	// for us.
+	handedBack := false
+	defer func() {
+		if !handedBack {
+			cmd.Done.Done()
+		}
+	}()
+
+	if _, isNoTxn := ex.machine.CurState().(stateNoTxn); !isNoTxn {
+		return pgerror.New(pgcode.ActiveSQLTransaction,
+			"START_REPLICATION cannot be executed inside a transaction")
+	}
+	if cmd.Stmt.Kind != pgrepltree.LogicalReplication {
+		return unimplemented.New("physical replication", "physical replication is not supported")
+	}
+	if ex.sessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"logical decoding requires a database connection")
+	}
+	if ex.sessionData().Database == "" {
+		return errNoDatabase
+	}
+
+	ex.incrementStartedStmtCounter(cmd.Stmt)
+	var cancelQuery context.CancelFunc
+	ctx, cancelQuery = ctxlog.WithCancel(ctx)
+	queryID := ex.server.cfg.GenerateID()
+	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
+	defer func() {
+		ex.removeActiveQuery(queryID, cmd.Stmt)
+		cancelQuery()
+		ex.metrics.EngineMetrics.SQLActiveStatements.Dec(1)
+		if retErr == nil {
+			ex.incrementExecutedStmtCounter(cmd.Stmt)
+		} else {
+			log.SqlExec.Errorf(ctx, "error executing %s: %+v", cmd, retErr)
+		}
+	}()
+
+	s, err := ex.newReplicationStream(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer s.close(ctx)
+
+	if err := cmd.Conn.BeginCopyBoth(ctx); err != nil {
+		return err
+	}
+	handedBack = true
+	clientCh := make(chan replicationClientEvent)
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		defer cmd.Done.Done()
+		readReplicationClientMessages(&ex.server.cfg.Settings.SV, cmd.Conn, clientCh, stopped)
+	}()
+	return s.run(ctx, clientCh)
+}
+
+// replicationClientEvent is a message from the client of a replication
+// stream, or the end of its messages.
+type replicationClientEvent struct {
+	status pgoutput.StandbyStatus
+	// done is set once the client has ended the Copy-both subprotocol.
+	done bool
+	err  error
+}
+
+// readReplicationClientMessages reads the messages sent by the client of a
+// replication stream until it ends the Copy-both subprotocol, and sends them
+// to ch. Once the stream has stopped, the remaining messages up to the end of
+// the subprotocol are discarded, so that the network routine only resumes
+// reading at a message boundary.
+func readReplicationClientMessages(
+	sv *settings.Values,
+	conn pgwirebase.Conn,
+	ch chan<- replicationClientEvent,
+	stopped <-chan struct{},
+) {
+	readBuf := pgwirebase.MakeReadBuffer(pgwirebase.ReadBufferOptionWithClusterSettings(sv))
+	send := func(ev replicationClientEvent) {
+		select {
+		case ch <- ev:
+		case <-stopped:
+		}
+	}
+	for {
+		typ, _, err := readBuf.ReadTypedMsg(conn.Rd())
+		if err != nil {
+			if pgwirebase.IsMessageTooBigError(err) {
+				if _, err := readBuf.SlurpBytes(conn.Rd(), pgwirebase.GetMessageTooBigSize(err)); err == nil {
+					continue
+				}
+			}
+			send(replicationClientEvent{err: err})
+			return
+		}
+		switch typ {
+		case pgwirebase.ClientMsgCopyData:
+			status, ok, err := pgoutput.ParseClientMessage(readBuf.Msg)
+			if err != nil {
+				send(replicationClientEvent{err: err})
+			} else if ok {
+				send(replicationClientEvent{status: status})
+			}
+		case pgwirebase.ClientMsgCopyDone:
+			send(replicationClientEvent{done: true})
+			return
+		case pgwirebase.ClientMsgCopyFail:
+			send(replicationClientEvent{err: pgerror.Newf(pgcode.QueryCanceled,
+				"replication stream canceled by the client: %s", readBuf.Msg)})
+			return
+		case pgwirebase.ClientMsgTerminate:
+			// The network routine will see that the client closed the
+			// connection.
+			send(replicationClientEvent{err: pgerror.New(pgcode.ConnectionFailure,
+				"client terminated the connection during replication")})
+			return
+		case pgwirebase.ClientMsgFlush, pgwirebase.ClientMsgSync:
+			// Ignore.
+		default:
+			send(replicationClientEvent{err: pgwirebase.NewProtocolViolationErrorf(
+				"unexpected message type %s during replication", typ)})
+		}
+	}
+}
+
+// replicationTable is a table published to a replication stream.
+type replicationTable struct {
+	schemaName string
+}
+
+// replicationFetcherKey identifies the version of a table for which a
+// row.Fetcher was initialized.
+type replicationFetcherKey struct {
+	id      descpb.ID
+	version descpb.DescriptorVersion
+}
+
+// replicationStream sends the changes to a set of tables to a client.
+type replicationStream struct {
+	execCfg  *ExecutorConfig
+	conn     pgwirebase.Conn
+	dbID     descpb.ID
+	slotName string
+	tables   map[descpb.ID]replicationTable
+	spans    []roachpb.Span
+	startTS  hlc.Timestamp
+
+	// frontier is the timestamp up to which changes have been sent. It is
+	// always the end of an LSN; see lsn.EndHLC.
+	frontier hlc.Timestamp
+	// flushed is the position up to which the client confirmed that it
+	// flushed the changes, and persisted is the position persisted in the slot.
+	flushed, persisted lsn.LSN
+	// persistedAt is the time at which the position was last persisted.
+	persistedAt time.Time
+	// pending holds the changes above the frontier, ordered by timestamp and
+	// key as they are received. Their memory is accounted for in pendingAcc.
+	pending    replicationChanges
+	pendingMon *mon.BytesMonitor
+	pendingAcc mon.BoundAccount
+	// batch holds the changes with the LSN being sent.
+	batch []*kvpb.RangeFeedValue
+	// sentRelations holds the version of the tables for which a Relation
+	// message was sent.
+	sentRelations map[descpb.ID]descpb.DescriptorVersion
+	fetchers      map[replicationFetcherKey]*row.Fetcher
+	alloc         tree.DatumAlloc
+	fmtCtx        *tree.FmtCtx
+	xid           uint32
+	buf           []byte
+}
+
+// newReplicationStream resolves the slot and publications of a
+// START_REPLICATION command.
+func (ex *connExecutor) newReplicationStream(
+	ctx context.Context, cmd StartReplication,
+) (*replicationStream, error) {
+	var pubNames []string
+	for _, opt := range cmd.Stmt.Options {
+		value := ""
+		if opt.Value != nil {
+			value = tree.AsStringWithFlags(opt.Value, tree.FmtBareStrings)
+		}
+		switch opt.Key {
+		case "proto_version":
+			if value != "1" && value != "2" {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"proto_version %q is not supported", value)
+			}
+		case "publication_names":
+			var err error
+			if pubNames, err = parsePublicationNames(value); err != nil {
+				return nil, err
+			}
+		case "binary":
+			if value != "" && value != "false" && value != "off" {
+				return nil, unimplemented.New("pgoutput binary", "binary pgoutput format is not supported")
+			}
+		case "messages", "streaming", "two_phase", "origin":
+			// Logical decoding messages, in-progress transactions and prepared
+			// transactions are never sent, and all changes are local.
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized pgoutput option %q", opt.Key)
+		}
+	}
+	if len(pubNames) == 0 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"publication_names parameter missing")
+	}
+
+	execCfg := ex.server.cfg
+	if err := checkReplicationSlotsVersion(ctx, execCfg.Settings); err != nil {
+		return nil, err
+	}
+	// Like changefeeds, replication streams are based on rangefeeds.
+	if !kvserver.RangefeedEnabled.Get(&execCfg.Settings.SV) {
+		return nil, pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication requires the kv.rangefeed.enabled setting")
+	}
+	sd := ex.sessionData()
+	s := &replicationStream{
+		execCfg:       execCfg,
+		conn:          cmd.Conn,
+		sentRelations: make(map[descpb.ID]descpb.DescriptorVersion),
+		fetchers:      make(map[replicationFetcherKey]*row.Fetcher),
+		fmtCtx: tree.NewFmtCtx(
+			tree.FmtPgwireText,
+			tree.FmtLocation(sd.GetLocation()),
+			tree.FmtDataConversionConfig(sd.DataConversionConfig),
+		),
+	}
+	if err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		p, cleanup := newInternalPlanner(
+			"start-replication", txn.KV(), sd.User(), &MemoryMetrics{}, execCfg, sd.Clone(),
+			WithDescCollection(txn.Descriptors()),
+		)
+		defer cleanup()
+		dbDesc, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, sd.Database)
+		if err != nil {
+			return err
+		}
+		slot, ok, err := getReplicationSlot(ctx, txn, dbDesc.GetID(), string(cmd.Stmt.Slot))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"replication slot %q does not exist", cmd.Stmt.Slot)
+		}
+		s.dbID = dbDesc.GetID()
+		s.slotName = slot.name
+		s.persisted = slot.confirmedFlush
+		s.startTS = slot.consistentPoint.EndHLC()
+		if ts := s.persisted.EndHLC(); s.startTS.Less(ts) {
+			s.startTS = ts
+		}
+
+		allPubs := dbDesc.DatabaseDesc().Publications
+		pubs := make([]*descpb.DatabaseDescriptor_Publication, 0, len(pubNames))
+		for _, name := range pubNames {
+			i, ok := findPublication(allPubs, name)
+			if !ok {
+				return pgerror.Newf(pgcode.UndefinedObject, "publication %q does not exist", name)
+			}
+			pubs = append(pubs, &allPubs[i])
+		}
+		s.tables = make(map[descpb.ID]replicationTable)
+		s.spans = s.spans[:0]
+		return forEachTableDesc(ctx, p, dbDesc, hideVirtual,
+			func(_ catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				for _, pub := range pubs {
+					if !publicationIncludesTable(pub, table) {
+						continue
+					}
+					if err := p.CheckPrivilege(ctx, table, privilege.CHANGEFEED); err != nil {
+						return err
+					}
+					s.tables[table.GetID()] = replicationTable{schemaName: sc.GetName()}
+					s.spans = append(s.spans, table.TableSpan(execCfg.Codec))
+					break
+				}
+				return nil
+			})
+	}); err != nil {
+		return nil, err
+	}
+
+	// The rangefeed starts after startTS, so the transaction at the requested
+	// LSN, which the client already flushed, is not sent again.
+	if ts := cmd.Stmt.LSN.EndHLC(); s.startTS.Less(ts) {
+		s.startTS = ts
+	}
+	s.frontier = s.startTS
+	s.flushed = s.persisted
+	s.persistedAt = timeutil.Now()
+	s.pendingMon = mon.NewMonitorInheritWithLimit(
+		"replication-stream", replicationStreamMaxBufferedBytes.Get(&execCfg.Settings.SV), ex.sessionMon,
+	)
+	s.pendingMon.StartNoReserved(ctx, ex.sessionMon)
+	s.pendingAcc = s.pendingMon.MakeBoundAccount()
+	return s, nil
+}
+
+// parsePublicationNames parses the list of publication names passed to
+// START_REPLICATION. Like SQL identifiers, the names are folded to lower case
+// unless they are double quoted.
+func parsePublicationNames(s string) ([]string, error) {
+	var names []string
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		var name string
+		if strings.HasPrefix(s, `"`) {
+			var b strings.Builder
+			i := 1
+			for ; i < len(s); i++ {
+				if s[i] == '"' {
+					if i+1 < len(s) && s[i+1] == '"' {
+						b.WriteByte('"')
+						i++
+						continue
+					}
+					break
+				}
+				b.WriteByte(s[i])
+			}
+			if i == len(s) {
+				return nil, pgerror.New(pgcode.InvalidParameterValue,
+					"unterminated quoted publication name")
+			}
+			name, s = b.String(), s[i+1:]
+		} else {
+			i := strings.IndexByte(s, ',')
+			if i < 0 {
+				i = len(s)
+			}
+			name, s = strings.ToLower(strings.TrimRightFunc(s[:i], unicode.IsSpace)), s[i:]
+		}
+		if name == "" {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "invalid publication_names syntax")
+		}
+		names = append(names, name)
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			return names, nil
+		}
+		if s[0] != ',' {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "invalid publication_names syntax")
+		}
+		s = s[1:]
+	}
+}
+
+// run streams the changes until the client ends the stream or an error
+// occurs.
+func (s *replicationStream) run(
+	ctx context.Context, clientCh <-chan replicationClientEvent,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	valueCh := make(chan *kvpb.RangeFeedValue)
+	frontierCh := make(chan hlc.Timestamp)
+	errCh := make(chan error, 1)
+	sendErr := func(err error) {
+		select {
+		case errCh <- err:
+		default:
+		}
+	}
+	// Changes that are not written as individual keys, such as SST ingestions
+	// and range deletions, cannot be replicated, so the stream fails rather
+	// than skipping them.
+	onSSTable := func(ctx context.Context, sst *kvpb.RangeFeedSSTable, _ roachpb.Span) {
+		sendErr(pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot replicate SST ingestion into span %s at %s", sst.Span, sst.WriteTS))
+	}
+	onDeleteRange := func(ctx context.Context, del *kvpb.RangeFeedDeleteRange) {
+		sendErr(pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot replicate range deletion of span %s at %s", del.Span, del.Timestamp))
+	}
+	rf, err := s.execCfg.RangeFeedFactory.RangeFeed(ctx, "logical-replication", s.spans, s.startTS,
+		func(ctx context.Context, value *kvpb.RangeFeedValue) {
+			select {
+			case valueCh <- value:
+			case <-ctx.Done():
+			}
+		},
+		rangefeed.WithDiff(true),
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+			select {
+			case frontierCh <- ts:
+			case <-ctx.Done():
+			}
+		}),
+		rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+			sendErr(err)
+		}),
+		rangefeed.WithOnSSTable(onSSTable),
+		rangefeed.WithOnDeleteRange(onDeleteRange),
+	)
+	if err != nil {
+		return err
+	}
+	defer rf.Close()
+
+	ticker := time.NewTicker(replicationKeepaliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case value := <-valueCh:
+			if err := s.addPending(ctx, value); err != nil {
+				return err
+			}
+		case ts := <-frontierCh:
+			if err := s.flush(ctx, ts); err != nil {
+				return err
+			}
+		case err := <-errCh:
+			return err
+		case ev := <-clientCh:
+			switch {
+			case ev.err != nil:
+				return ev.err
+			case ev.done:
+				if err := s.persistFlushed(ctx); err != nil {
+					return err
+				}
+				return s.conn.SendCopyDone(ctx)
+			}
+			if s.flushed < ev.status.Flushed {
+				s.flushed = ev.status.Flushed
+			}
+			if ev.status.ReplyRequested {
+				if err := s.sendKeepalive(ctx, false /* requestReply */); err != nil {
+					return err
+				}
+			}
+		case <-ticker.C:
+			if err := s.sendKeepalive(ctx, false /* requestReply */); err != nil {
+				return err
+			}
+			interval := replicationSlotPersistInterval.Get(&s.execCfg.Settings.SV)
+			if timeutil.Since(s.persistedAt) >= interval {
+				if err := s.persistFlushed(ctx); err != nil {
+					return err
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// replicationChanges is a min-heap of changes ordered by timestamp and key.
+type replicationChanges []*kvpb.RangeFeedValue
+
+var _ heap.Interface = (*replicationChanges)(nil)
+
+func (h replicationChanges) Len() int { return len(h) }
+func (h replicationChanges) Less(i, j int) bool {
+	if c := h[i].Value.Timestamp.Compare(h[j].Value.Timestamp); c != 0 {
+		return c < 0
+	}
+	return h[i].Key.Compare(h[j].Key) < 0
+}
+func (h replicationChanges) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *replicationChanges) Push(x interface{}) {
+	*h = append(*h, x.(*kvpb.RangeFeedValue))
+}
+func (h *replicationChanges) Pop() interface{} {
+	old := *h
+	n := len(old)
+	value := old[n-1]
+	old[n-1] = nil
+	*h = old[0 : n-1]
+	return value
+}
+
+// replicationChangeOverhead is the memory used by a buffered change, in
+// addition to its key and values.
+const replicationChangeOverhead = int64(unsafe.Sizeof(kvpb.RangeFeedValue{})) +
+	int64(unsafe.Sizeof((*kvpb.RangeFeedValue)(nil)))
+
+func replicationChangeSize(value *kvpb.RangeFeedValue) int64 {
+	return replicationChangeOverhead + int64(len(value.Key)+
+		len(value.Value.RawBytes)+len(value.PrevValue.RawBytes))
+}
+
+// addPending buffers a change until the frontier passes its LSN. Changes that
+// were already sent, which can be received again after the rangefeed
+// restarts, are ignored.
+func (s *replicationStream) addPending(ctx context.Context, value *kvpb.RangeFeedValue) error {
+	if value.Value.Timestamp.LessEq(s.frontier) {
+		return nil
+	}
+	if err := s.pendingAcc.Grow(ctx, replicationChangeSize(value)); err != nil {
+		return errors.WithHintf(err,
+			"The changes which cannot be sent yet are limited by the %s cluster setting.",
+			replicationStreamMaxBufferedBytes.Key())
+	}
+	heap.Push(&s.pending, value)
+	return nil
+}
+
+// flush sends the pending changes up to the new frontier. Only the LSNs whose
+// timestamps are all below the frontier are complete, so the changes with the
+// LSN of the frontier are held back.
+func (s *replicationStream) flush(ctx context.Context, frontier hlc.Timestamp) error {
+	frontier = lsn.FromHLC(frontier).Sub(1).EndHLC()
+	if frontier.LessEq(s.frontier) {
+		return nil
+	}
+	for len(s.pending) > 0 && s.pending[0].Value.Timestamp.LessEq(frontier) {
+		pos := lsn.FromHLC(s.pending[0].Value.Timestamp)
+		var size int64
+		s.batch = s.batch[:0]
+		for len(s.pending) > 0 && lsn.FromHLC(s.pending[0].Value.Timestamp) == pos {
+			value := heap.Pop(&s.pending).(*kvpb.RangeFeedValue)
+			size += replicationChangeSize(value)
+			s.batch = append(s.batch, value)
+		}
+		if err := s.sendTransaction(ctx, pos, s.batch); err != nil {
+			return err
+		}
+		for i := range s.batch {
+			s.batch[i] = nil
+		}
+		s.pendingAcc.Shrink(ctx, size)
+	}
+	s.frontier = frontier
+	return nil
+}
+
+// sendTransaction sends the changes with an LSN, sorted by timestamp and key,
+// as one transaction.
+func (s *replicationStream) sendTransaction(
+	ctx context.Context, pos lsn.LSN, values []*kvpb.RangeFeedValue,
+) error {
+	commitTime := values[len(values)-1].Value.Timestamp.GoTime()
+	began := false
+	for i, value := range values {
+		// The same change can be received more than once.
+		if i > 0 && value.Key.Equal(values[i-1].Key) &&
+			value.Value.Timestamp == values[i-1].Value.Timestamp {
+			continue
+		}
+		msgs, err := s.decodeChange(ctx, value.Value.Timestamp, value)
+		if err != nil {
+			return err
+		}
+		if len(msgs) == 0 {
+			continue
+		}
+		if !began {
+			s.xid++
+			if err := s.send(ctx, pos, func(b []byte) []byte {
+				return pgoutput.AppendBegin(b, pos, commitTime, s.xid)
+			}); err != nil {
+				return err
+			}
+			began = true
+		}
+		for _, msg := range msgs {
+			if err := s.send(ctx, pos, func(b []byte) []byte { return append(b, msg...) }); err != nil {
+				return err
+			}
+		}
+	}
+	if !began {
+		return nil
+	}
+	return s.send(ctx, pos, func(b []byte) []byte {
+		return pgoutput.AppendCommit(b, pos, pos, commitTime)
+	})
+}
+
+// decodeChange returns the pgoutput messages for a change to a row, which are
+// preceded by a Relation message if the schema of the table was not sent yet.
+// It returns no messages for changes that are not replicated.
+func (s *replicationStream) decodeChange(
+	ctx context.Context, ts hlc.Timestamp, value *kvpb.RangeFeedValue,
+) ([][]byte, error) {
+	deleted := !value.Value.IsPresent()
+	if deleted && !value.PrevValue.IsPresent() {
+		return nil, nil
+	}
+	key, err := s.execCfg.Codec.StripTenantPrefix(value.Key)
+	if err != nil {
+		return nil, err
+	}
+	_, tableID, indexID, err := rowenc.DecodePartialTableIDIndexID(key)
+	if err != nil {
+		return nil, err
+	}
+	table, ok := s.tables[tableID]
+	if !ok {
+		return nil, nil
+	}
+	desc, err := s.tableDesc(ctx, tableID, ts)
+	if err != nil {
+		if errors.Is(err, catalog.ErrDescriptorDropped) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// Changes to secondary indexes, and to the new primary index of a table
+	// whose primary key is being changed, are not replicated.
+	if indexID != desc.GetPrimaryIndexID() {
+		return nil, nil
+	}
+	if len(desc.GetFamilies()) > 1 {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot replicate table %q with multiple column families", desc.GetName())
+	}
+
+	var msgs [][]byte
+	relID := uint32(desc.GetID())
+	cols := desc.VisibleColumns()
+	keyCols := desc.GetPrimaryIndex().CollectKeyColumnIDs()
+	if v, ok := s.sentRelations[desc.GetID()]; !ok || v != desc.GetVersion() {
+		rel := pgoutput.Relation{
+			ID:        relID,
+			Namespace: table.schemaName,
+			Name:      desc.GetName(),
+			Columns:   make([]pgoutput.Column, len(cols)),
+		}
+		for i, col := range cols {
+			rel.Columns[i] = pgoutput.Column{
+				Name:    col.GetName(),
+				TypeOID: col.GetType().Oid(),
+				TypeMod: col.GetType().TypeModifier(),
+				Key:     keyCols.Contains(col.GetID()),
+			}
+		}
+		msgs = append(msgs, pgoutput.AppendRelation(nil, &rel))
+		s.sentRelations[desc.GetID()] = desc.GetVersion()
+	}
+
+	rf, err := s.fetcher(ctx, desc, cols)
+	if err != nil {
+		return nil, err
+	}
+	if err := rf.ConsumeKVProvider(ctx, &row.KVProvider{
+		KVs: []roachpb.KeyValue{{Key: value.Key, Value: value.Value}},
+	}); err != nil {
+		return nil, err
+	}
+	datums, err := rf.NextRowDecoded(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if datums == nil {
+		return nil, errors.AssertionFailedf("no row decoded from key %s", value.Key)
+	}
+	tuple := make(pgoutput.Tuple, len(datums))
+	for i, d := range datums {
+		if d == tree.DNull || (deleted && !keyCols.Contains(cols[i].GetID())) {
+			continue
+		}
+		s.fmtCtx.FormatNode(d)
+		tuple[i] = append([]byte{}, s.fmtCtx.Bytes()...)
+		s.fmtCtx.Reset()
+	}
+	switch {
+	case deleted:
+		msgs = append(msgs, pgoutput.AppendDelete(nil, relID, tuple, true /* keyOnly */))
+	case value.PrevValue.IsPresent():
+		msgs = append(msgs, pgoutput.AppendUpdate(nil, relID, nil /* oldRow */, tuple))
+	default:
+		msgs = append(msgs, pgoutput.AppendInsert(nil, relID, tuple))
+	}
+	return msgs, nil
+}
+
+// tableDesc returns the descriptor of a table at a timestamp.
+func (s *replicationStream) tableDesc(
+	ctx context.Context, id descpb.ID, ts hlc.Timestamp,
+) (catalog.TableDescriptor, error) {
+	leased, err := s.execCfg.LeaseManager.Acquire(ctx, ts, id)
+	if err != nil {
+		return nil, err
+	}
+	desc := leased.Underlying().(catalog.TableDescriptor)
+	// Immediately release the lease, since we only need it for the exact
+	// timestamp requested.
+	leased.Release(ctx)
+	if !catalog.MaybeRequiresHydration(desc) {
+		return desc, nil
+	}
+	if err := s.execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		desc, err = txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return desc, nil
+}
+
+// fetcher returns a row.Fetcher decoding the given columns of the primary
+// index of a table. Fetchers for tables using user-defined types are not
+// cached, since the types can change without a new version of the table.
+func (s *replicationStream) fetcher(
+	ctx context.Context, desc catalog.TableDescriptor, cols []catalog.Column,
+) (*row.Fetcher, error) {
+	key := replicationFetcherKey{id: desc.GetID(), version: desc.GetVersion()}
+	cacheable := !catalog.MaybeRequiresHydration(desc)
+	if rf, ok := s.fetchers[key]; ok && cacheable {
+		return rf, nil
+	}
+	colIDs := make([]descpb.ColumnID, len(cols))
+	for i, col := range cols {
+		colIDs[i] = col.GetID()
+	}
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(
+		&spec, s.execCfg.Codec, desc, desc.GetPrimaryIndex(), colIDs,
+	); err != nil {
+		return nil, err
+	}
+	rf := &row.Fetcher{}
+	if err := rf.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &s.alloc,
+		Spec:              &spec,
+	}); err != nil {
+		return nil, err
+	}
+	if old, ok := s.fetchers[key]; ok {
+		old.Close(ctx)
+	}
+	s.fetchers[key] = rf
+	return rf, nil
+}
+
+// persistFlushed persists the position up to which the client confirmed that
+// it flushed the changes in the slot, and advances the protected timestamp of
+// the slot accordingly.
+func (s *replicationStream) persistFlushed(ctx context.Context) error {
+	s.persistedAt = timeutil.Now()
+	// The client cannot have flushed changes that were not sent.
+	pos := s.flushed
+	if sent := lsn.FromHLC(s.frontier); sent < pos {
+		pos = sent
+	}
+	if pos <= s.persisted {
+		return nil
+	}
+	if err := s.execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		slot, ok, err := getReplicationSlot(ctx, txn, s.dbID, s.slotName)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"replication slot %q does not exist", s.slotName)
+		}
+		if pos <= slot.confirmedFlush {
+			return nil
+		}
+		if _, err := txn.ExecEx(
+			ctx, "persist-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+			`UPDATE system.replication_slots SET confirmed_flush_lsn = $3
+WHERE database_id = $1 AND slot_name = $2`,
+			tree.NewDInt(tree.DInt(s.dbID)), s.slotName, tree.NewDInt(tree.DInt(pos)),
+		); err != nil {
+			return err
+		}
+		if slot.ptsRecordID == uuid.Nil {
+			return nil
+		}
+		pts := s.execCfg.ProtectedTimestampProvider.WithTxn(txn)
+		return pts.UpdateTimestamp(ctx, slot.ptsRecordID, pos.EndHLC())
+	}); err != nil {
+		return err
+	}
+	s.persisted = pos
+	return nil
+}
+
+// sendKeepalive informs the client that the stream is complete up to the
+// frontier.
+func (s *replicationStream) sendKeepalive(ctx context.Context, requestReply bool) error {
+	s.buf = pgoutput.AppendPrimaryKeepalive(
+		s.buf[:0], lsn.FromHLC(s.frontier), timeutil.Now(), requestReply,
+	)
+	return s.conn.SendCopyData(ctx, s.buf)
+}
+
+// send sends a pgoutput message, appended to the buffer by appendMsg, in an
+// XLogData message at the given position.
+func (s *replicationStream) send(
+	ctx context.Context, pos lsn.LSN, appendMsg func([]byte) []byte,
+) error {
+	s.buf = pgoutput.AppendXLogData(s.buf[:0], pos, pos, timeutil.Now())
+	s.buf = appendMsg(s.buf)
+	return s.conn.SendCopyData(ctx, s.buf)
+}
+
+func (s *replicationStream) close(ctx context.Context) {
+	for _, rf := range s.fetchers {
+		rf.Close(ctx)
+	}
+	s.pending = nil
+	s.pendingAcc.Close(ctx)
+	s.pendingMon.Stop(ctx)
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplicationStreamPending checks that the changes buffered by a
+// replication stream are ordered by timestamp and key as they are added, that
+// changes which were already sent are ignored, and that the memory used by the
+// buffered changes is limited.
+func TestReplicationStreamPending(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	parent := mon.NewUnlimitedMonitor(
+		ctx, "test", mon.MemoryResource, nil /* curCount */, nil /* maxHist */, math.MaxInt64, st,
+	)
+	defer parent.Stop(ctx)
+	makeValue := func(key string, wallTime int64) *kvpb.RangeFeedValue {
+		v := &kvpb.RangeFeedValue{Key: roachpb.Key(key)}
+		v.Value.SetString("v")
+		v.Value.Timestamp = hlc.Timestamp{WallTime: wallTime}
+		return v
+	}
+	limit := 3 * replicationChangeSize(makeValue("a", 1))
+	s := &replicationStream{frontier: hlc.Timestamp{WallTime: 1}}
+	s.pendingMon = mon.NewMonitorWithLimit(
+		"test-stream", mon.MemoryResource, limit, nil /* curCount */, nil /* maxHist */,
+		1 /* increment */, math.MaxInt64, st,
+	)
+	s.pendingMon.StartNoReserved(ctx, parent)
+	s.pendingAcc = s.pendingMon.MakeBoundAccount()
+	defer s.close(ctx)
+
+	for _, v := range []*kvpb.RangeFeedValue{
+		makeValue("b", 3),
+		makeValue("a", 1), // already sent
+		makeValue("b", 2),
+		makeValue("a", 3),
+	} {
+		require.NoError(t, s.addPending(ctx, v))
+	}
+	require.Equal(t, 3, s.pending.Len())
+
+	// The buffer is full.
+	err := s.addPending(ctx, makeValue("c", 4))
+	require.Error(t, err)
+	require.Equal(t, pgcode.OutOfMemory, pgerror.GetPGCode(err))
+
+	var order []string
+	for s.pending.Len() > 0 {
+		v := heap.Pop(&s.pending).(*kvpb.RangeFeedValue)
+		s.pendingAcc.Shrink(ctx, replicationChangeSize(v))
+		order = append(order, string(v.Key)+"@"+v.Value.Timestamp.String())
+	}
+	require.Equal(t, []string{"b@0.000000002,0", "a@0.000000003,0", "b@0.000000003,0"}, order)
+	require.Zero(t, s.pendingAcc.Used())
+}
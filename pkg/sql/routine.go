@@ -13,8 +13,11 @@ package sql
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -151,6 +154,16 @@ func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
 		}()
 	}
 
+	// If the routine has an exception handler, create a savepoint before
+	// executing the body so that its changes can be rolled back if the handler
+	// catches an error.
+	var savepoint kv.SavepointToken
+	if g.expr.ExceptionHandler != nil {
+		if savepoint, err = txn.CreateSavepoint(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Execute each statement in the routine sequentially.
 	stmtIdx := 0
 	ef := newExecFactory(ctx, g.p)
@@ -186,6 +199,13 @@ func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
 
 		return nil
 	})
+	if g.expr.ExceptionHandler != nil {
+		if err != nil {
+			err = g.handleException(ctx, txn, savepoint, err)
+		} else {
+			err = txn.ReleaseSavepoint(ctx, savepoint)
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -194,6 +214,70 @@ func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
 	return nil
 }
 
+// handleException attempts to catch the given error, which was thrown by the
+// body of a routine with an exception handler. If one of the handler's codes
+// matches the error, the transaction is rolled back to the savepoint created
+// before the body was executed, and the result of the matching action replaces
+// the result of the body. Otherwise, the original error is returned.
+func (g *routineGenerator) handleException(
+	ctx context.Context, txn *kv.Txn, savepoint kv.SavepointToken, err error,
+) error {
+	if errors.Is(err, tree.ErrRoutineReRaise) {
+		// A re-raised error is not caught. It is replaced by the error that was
+		// caught by the handler that executed the RAISE statement.
+		return err
+	}
+	caughtCode := pgerror.GetPGCode(err)
+	switch caughtCode {
+	case pgcode.Uncategorized, pgcode.Internal, pgcode.SerializationFailure:
+		// It is not safe to catch internal errors or errors that require the
+		// transaction to be retried.
+		return err
+	}
+	handler := g.expr.ExceptionHandler
+	for i, code := range handler.Codes {
+		if !errorCodeMatches(caughtCode, code) {
+			continue
+		}
+		if rollbackErr := txn.RollbackToSavepoint(ctx, savepoint); rollbackErr != nil {
+			return errors.CombineErrors(err, rollbackErr)
+		}
+		res, actionErr := g.p.EvalRoutineExpr(ctx, handler.Actions[i], g.args)
+		if actionErr != nil {
+			if errors.Is(actionErr, tree.ErrRoutineReRaise) {
+				// The action re-raised the error that it is handling.
+				return err
+			}
+			return actionErr
+		}
+		// Discard any rows produced by the body before it failed.
+		if clearErr := g.rch.Clear(ctx); clearErr != nil {
+			return clearErr
+		}
+		return g.rch.AddRow(ctx, tree.Datums{res})
+	}
+	return err
+}
+
+// errorCodeMatches returns true if the given error code is matched by the
+// given exception handler code.
+func errorCodeMatches(code, handlerCode pgcode.Code) bool {
+	if code == handlerCode {
+		return true
+	}
+	if handlerCode == pgcode.PLpgSQLOthers {
+		// OTHERS matches any error code other than query_canceled and
+		// assert_failure, though they can still be caught explicitly.
+		return code != pgcode.QueryCanceled && code != pgcode.AssertFailure
+	}
+	if strings.HasSuffix(handlerCode.String(), "000") {
+		// A code ending in "000" matches every code in its class, which is
+		// determined by the first two characters.
+		return strings.HasPrefix(code.String(), handlerCode.String()[:2])
+	}
+	return false
+}
+
 // Next is part of the ValueGenerator interface.
 func (g *routineGenerator) Next(ctx context.Context) (bool, error) {
 	var err error
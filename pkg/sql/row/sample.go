@@ -0,0 +1,116 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package row
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangecache"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/errors"
+)
+
+// SampleSpans implements the range-level sampling of TABLESAMPLE SYSTEM. It
+// splits the given spans at the boundaries of the ranges they overlap and
+// returns only the pieces that belong to a random sample of those ranges, so
+// that the remaining ranges are never read. Each range is included with
+// probability percent / 100.
+//
+// Whether a range is included is a deterministic function of the seed and the
+// start key of the range, so the same seed produces the same sample as long as
+// the range boundaries don't change.
+func SampleSpans(
+	ctx context.Context,
+	rdc *rangecache.RangeCache,
+	spans roachpb.Spans,
+	percent float64,
+	seed int64,
+) (roachpb.Spans, error) {
+	if rdc == nil {
+		return nil, errors.AssertionFailedf("range cache is required for TABLESAMPLE SYSTEM")
+	}
+	return sampleSpans(ctx, func(ctx context.Context, key roachpb.RKey) (*roachpb.RangeDescriptor, error) {
+		entry, err := rdc.Lookup(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		return entry.Desc(), nil
+	}, spans, percent, seed)
+}
+
+// rangeLookupFn returns the descriptor of the range containing the given key.
+type rangeLookupFn func(ctx context.Context, key roachpb.RKey) (*roachpb.RangeDescriptor, error)
+
+func sampleSpans(
+	ctx context.Context, lookup rangeLookupFn, spans roachpb.Spans, percent float64, seed int64,
+) (roachpb.Spans, error) {
+	fraction := percent / 100
+	var sampled roachpb.Spans
+	// add appends the given piece of a span to the result, merging it with the
+	// previous piece if they are adjacent.
+	add := func(sp roachpb.Span) {
+		if n := len(sampled); n > 0 && sp.EndKey != nil && sampled[n-1].EndKey.Equal(sp.Key) {
+			sampled[n-1].EndKey = sp.EndKey
+			return
+		}
+		sampled = append(sampled, sp)
+	}
+	for _, sp := range spans {
+		rSpan, err := keys.SpanAddr(sp)
+		if err != nil {
+			return nil, err
+		}
+		if rSpan.EndKey == nil {
+			// This is a point lookup, which lies within a single range.
+			desc, err := lookup(ctx, rSpan.Key)
+			if err != nil {
+				return nil, err
+			}
+			if sampleRange(desc.StartKey, seed, fraction) {
+				add(sp)
+			}
+			continue
+		}
+		start := sp.Key
+		for key := rSpan.Key; key.Less(rSpan.EndKey); {
+			desc, err := lookup(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			end := sp.EndKey
+			if desc.EndKey.Less(rSpan.EndKey) {
+				end = desc.EndKey.AsRawKey()
+			}
+			if sampleRange(desc.StartKey, seed, fraction) {
+				add(roachpb.Span{Key: start, EndKey: end})
+			}
+			key = desc.EndKey
+			start = end
+		}
+	}
+	return sampled, nil
+}
+
+// sampleRange returns whether the range with the given start key is part of
+// the sample.
+func sampleRange(startKey roachpb.RKey, seed int64, fraction float64) bool {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(seed))
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write(startKey)
+	// Map the hash onto [0, 1) using its top 53 bits, which is the precision of
+	// a float64 mantissa.
+	return float64(h.Sum64()>>11)/(1<<53) < fraction
+}
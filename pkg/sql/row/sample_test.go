@@ -0,0 +1,68 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package row
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	// Ranges are split at "b", "d", "f" and "h".
+	splits := []roachpb.RKey{
+		roachpb.RKeyMin, roachpb.RKey("b"), roachpb.RKey("d"), roachpb.RKey("f"), roachpb.RKey("h"), roachpb.RKeyMax,
+	}
+	var lookups int
+	lookup := func(_ context.Context, key roachpb.RKey) (*roachpb.RangeDescriptor, error) {
+		lookups++
+		i := sort.Search(len(splits), func(i int) bool { return key.Less(splits[i]) })
+		return &roachpb.RangeDescriptor{StartKey: splits[i-1], EndKey: splits[i]}, nil
+	}
+	spans := roachpb.Spans{
+		{Key: roachpb.Key("a"), EndKey: roachpb.Key("g")},
+		{Key: roachpb.Key("g1")},
+	}
+
+	// A 100% sample reads everything, merging the pieces of each span back
+	// together.
+	res, err := sampleSpans(ctx, lookup, spans, 100 /* percent */, 1 /* seed */)
+	require.NoError(t, err)
+	require.Equal(t, spans, res)
+	require.Equal(t, 5, lookups)
+
+	// A 0% sample reads nothing.
+	res, err = sampleSpans(ctx, lookup, spans, 0 /* percent */, 1 /* seed */)
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	// A partial sample only returns pieces within single ranges, and is the same
+	// for the same seed.
+	for seed := int64(0); seed < 20; seed++ {
+		res, err = sampleSpans(ctx, lookup, spans, 50 /* percent */, seed)
+		require.NoError(t, err)
+		for _, sp := range res {
+			require.True(t, spans.ContainsKey(sp.Key))
+		}
+		again, err := sampleSpans(ctx, lookup, spans, 50 /* percent */, seed)
+		require.NoError(t, err)
+		require.Equal(t, res, again)
+	}
+}
@@ -38,6 +38,13 @@ func Decode(
 	if (dir != encoding.Ascending) && (dir != encoding.Descending) {
 		return nil, nil, errors.Errorf("invalid direction: %d", dir)
 	}
+	// Tuples are encoded as the concatenation of the encodings of their
+	// elements, so they must be decoded before checking for NULL, which would
+	// otherwise be confused with a NULL first element. As a consequence, a NULL
+	// tuple cannot be decoded.
+	if valType.Family() == types.TupleFamily {
+		return decodeTupleKey(a, valType, key, dir)
+	}
 	var isNull bool
 	if key, isNull = encoding.DecodeIfNull(key); isNull {
 		return tree.DNull, key, nil
@@ -157,6 +164,9 @@ func Decode(
 		return a.NewDBox2D(tree.DBox2D{
 			CartesianBoundingBox: geo.CartesianBoundingBox{BoundingBox: r},
 		}), rkey, err
+	case types.PointFamily, types.BoxFamily, types.PathFamily, types.PolygonFamily,
+		types.CircleFamily:
+		return decodeGeometricKey(valType, key, dir)
 	case types.GeographyFamily:
 		g := a.NewDGeographyEmpty()
 		so := g.Geography.SpatialObjectRef()
@@ -298,6 +308,22 @@ func Decode(
 	}
 }
 
+// decodeTupleKey decodes a tuple key generated by Encode.
+func decodeTupleKey(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	contents := t.TupleContents()
+	result := tree.NewDTupleWithLen(t, len(contents))
+	for i := range contents {
+		var err error
+		result.D[i], buf, err = Decode(a, contents[i], buf, dir)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return result, buf, nil
+}
+
 // Skip skips one value of in a key, returning the remainder of the key.
 func Skip(key []byte) (remainingKey []byte, _ error) {
 	skipLen, err := encoding.PeekLength(key)
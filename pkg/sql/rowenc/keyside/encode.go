@@ -86,6 +86,20 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 			return encoding.EncodeBox2DAscending(b, t.CartesianBoundingBox.BoundingBox)
 		}
 		return encoding.EncodeBox2DDescending(b, t.CartesianBoundingBox.BoundingBox)
+	case *tree.DPoint:
+		return encodeGeometric(b, 0, t.Point.Coords(nil), dir), nil
+	case *tree.DBox:
+		return encodeGeometric(b, 0, t.Box.Coords(nil), dir), nil
+	case *tree.DPath:
+		var flags uint64
+		if t.Path.Closed {
+			flags = pathClosedFlag
+		}
+		return encodeGeometric(b, flags, t.Path.Coords(nil), dir), nil
+	case *tree.DPolygon:
+		return encodeGeometric(b, 0, t.Polygon.Coords(nil), dir), nil
+	case *tree.DCircle:
+		return encodeGeometric(b, 0, t.Circle.Coords(nil), dir), nil
 	case *tree.DGeography:
 		so := t.Geography.SpatialObjectRef()
 		if dir == encoding.Ascending {
@@ -0,0 +1,68 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// pathClosedFlag is set in the flags of the key encoding of closed paths, so
+// that open paths sort before closed ones.
+const pathClosedFlag = 1
+
+// encodeGeometric encodes a value of one of the geometric types, given as its
+// flags and coordinates.
+func encodeGeometric(b []byte, flags uint64, coords []float64, dir encoding.Direction) []byte {
+	if dir == encoding.Ascending {
+		return encoding.EncodeGeometricAscending(b, flags, coords)
+	}
+	return encoding.EncodeGeometricDescending(b, flags, coords)
+}
+
+// decodeGeometricKey decodes a value of the geometric type typ encoded by
+// encodeGeometric.
+func decodeGeometricKey(
+	typ *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	var flags uint64
+	var coords []float64
+	var err error
+	if dir == encoding.Ascending {
+		key, flags, coords, err = encoding.DecodeGeometricAscending(key)
+	} else {
+		key, flags, coords, err = encoding.DecodeGeometricDescending(key)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	switch typ.Family() {
+	case types.PointFamily:
+		p, err := geometric.PointFromCoords(coords)
+		return tree.NewDPoint(p), key, err
+	case types.BoxFamily:
+		b, err := geometric.BoxFromCoords(coords)
+		return tree.NewDBox(b), key, err
+	case types.PathFamily:
+		p, err := geometric.PathFromCoords(coords, flags&pathClosedFlag != 0)
+		return tree.NewDPath(p), key, err
+	case types.PolygonFamily:
+		p, err := geometric.PolygonFromCoords(coords)
+		return tree.NewDPolygon(p), key, err
+	case types.CircleFamily:
+		c, err := geometric.CircleFromCoords(coords)
+		return tree.NewDCircle(c), key, err
+	}
+	return nil, nil, errors.AssertionFailedf("unexpected geometric type %s", typ)
+}
@@ -235,6 +235,9 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 		return encoding.IPAddr, nil
 	case types.JsonFamily:
 		return encoding.JSON, nil
+	case types.PointFamily, types.BoxFamily, types.PathFamily, types.PolygonFamily,
+		types.CircleFamily:
+		return encoding.Bytes, nil
 	case types.TupleFamily:
 		return encoding.Tuple, nil
 	default:
@@ -319,6 +322,9 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	case *tree.DTuple:
 		return encodeUntaggedTuple(t, b, encoding.NoColumnID, nil)
+	case *tree.DPoint, *tree.DBox, *tree.DPath, *tree.DPolygon, *tree.DCircle:
+		encoded, _ := encodeGeometric(nil, t)
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	case *tree.DTSQuery:
 		encoded := tsearch.EncodeTSQueryPGBinary(nil, t.TSQuery)
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
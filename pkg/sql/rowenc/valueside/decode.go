@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
@@ -196,6 +197,24 @@ func DecodeUntaggedDatum(
 			return nil, b, err
 		}
 		return a.NewDJSON(tree.DJSON{JSON: j}), b, nil
+	case types.JsonpathFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		p, err := jsonpath.Parse(string(data))
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDJsonpath(p), b, nil
+	case types.PointFamily, types.BoxFamily, types.PathFamily, types.PolygonFamily,
+		types.CircleFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		d, err := decodeGeometric(t, data)
+		return d, b, err
 	case types.TSQueryFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
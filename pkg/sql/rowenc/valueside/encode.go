@@ -83,6 +83,11 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 			return nil, err
 		}
 		return encoding.EncodeJSONValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DJsonpath:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), []byte(t.Jsonpath.String())), nil
+	case *tree.DPoint, *tree.DBox, *tree.DPath, *tree.DPolygon, *tree.DCircle:
+		encoded, _ := encodeGeometric(scratch, t)
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), encoded), nil
 	case *tree.DTSQuery:
 		encoded, err := tsearch.EncodeTSQuery(scratch, t.TSQuery)
 		if err != nil {
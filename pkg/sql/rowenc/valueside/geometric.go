@@ -0,0 +1,77 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// Values of the geometric types are stored as bytes holding their Postgres
+// binary encoding.
+
+// encodeGeometric appends the Postgres binary encoding of d to appendTo. It
+// returns false if d is not of one of the geometric types.
+func encodeGeometric(appendTo []byte, d tree.Datum) ([]byte, bool) {
+	switch t := d.(type) {
+	case *tree.DPoint:
+		return geometric.EncodePointPGBinary(appendTo, t.Point), true
+	case *tree.DBox:
+		return geometric.EncodeBoxPGBinary(appendTo, t.Box), true
+	case *tree.DPath:
+		return geometric.EncodePathPGBinary(appendTo, t.Path), true
+	case *tree.DPolygon:
+		return geometric.EncodePolygonPGBinary(appendTo, t.Polygon), true
+	case *tree.DCircle:
+		return geometric.EncodeCirclePGBinary(appendTo, t.Circle), true
+	}
+	return appendTo, false
+}
+
+// decodeGeometric decodes a value of the geometric type typ encoded by
+// encodeGeometric.
+func decodeGeometric(typ *types.T, data []byte) (tree.Datum, error) {
+	switch typ.Family() {
+	case types.PointFamily:
+		p, err := geometric.DecodePointPGBinary(data)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDPoint(p), nil
+	case types.BoxFamily:
+		b, err := geometric.DecodeBoxPGBinary(data)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDBox(b), nil
+	case types.PathFamily:
+		p, err := geometric.DecodePathPGBinary(data)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDPath(p), nil
+	case types.PolygonFamily:
+		p, err := geometric.DecodePolygonPGBinary(data)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDPolygon(p), nil
+	case types.CircleFamily:
+		c, err := geometric.DecodeCirclePGBinary(data)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDCircle(c), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected geometric type %s", typ)
+}
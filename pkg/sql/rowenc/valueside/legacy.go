@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -148,6 +149,17 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.JsonpathFamily:
+		if v, ok := val.(*tree.DJsonpath); ok {
+			r.SetBytes([]byte(v.Jsonpath.String()))
+			return r, nil
+		}
+	case types.PointFamily, types.BoxFamily, types.PathFamily, types.PolygonFamily,
+		types.CircleFamily:
+		if data, ok := encodeGeometric(nil, val); ok {
+			r.SetBytes(data)
+			return r, nil
+		}
 	case types.TSQueryFamily:
 		if v, ok := val.(*tree.DTSQuery); ok {
 			data := tsearch.EncodeTSQueryPGBinary(nil, v.TSQuery)
@@ -391,6 +403,23 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDJSON(jsonDatum), nil
+	case types.JsonpathFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		p, err := jsonpath.Parse(string(v))
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDJsonpath(p), nil
+	case types.PointFamily, types.BoxFamily, types.PathFamily, types.PolygonFamily,
+		types.CircleFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return decodeGeometric(typ, v)
 	case types.TSQueryFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -104,9 +104,6 @@ func newSampleAggregator(
 		if s.GenerateHistogram && s.HistogramMaxBuckets == 0 {
 			return nil, errors.Errorf("histogram max buckets not specified")
 		}
-		if s.GenerateHistogram && len(s.Columns) != 1 {
-			return nil, errors.Errorf("histograms require one column")
-		}
 	}
 
 	// Limit the memory use by creating a child monitor with a hard limit.
@@ -153,7 +150,9 @@ func newSampleAggregator(
 			numRows:  0,
 		}
 		if spec.Sketches[i].GenerateHistogram {
-			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
+			for _, col := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(col))
+			}
 		}
 	}
 
@@ -449,8 +448,18 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 		for _, si := range s.sketches {
 			var histogram *stats.HistogramData
 			if si.spec.GenerateHistogram {
-				colIdx := int(si.spec.Columns[0])
-				typ := s.inTypes[colIdx]
+				// A histogram on multiple columns is built on tuples of the values of
+				// the columns.
+				colIdxs := make([]int, len(si.spec.Columns))
+				colTypes := make([]*types.T, len(si.spec.Columns))
+				for i, c := range si.spec.Columns {
+					colIdxs[i] = int(c)
+					colTypes[i] = s.inTypes[c]
+				}
+				typ := colTypes[0]
+				if len(colTypes) > 1 {
+					typ = types.MakeTuple(colTypes)
+				}
 
 				var lowerBound tree.Datum
 				if si.spec.PrevLowerBound != "" {
@@ -472,7 +481,7 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 					ctx,
 					s.EvalCtx,
 					&s.sr,
-					colIdx,
+					colIdxs,
 					typ,
 					si.numRows-si.numNulls,
 					s.getDistinctCount(&si, false /* includeNulls */),
@@ -503,7 +512,7 @@ func (s *sampleAggregator) writeResults(ctx context.Context) error {
 					ctx,
 					s.EvalCtx,
 					invSr,
-					0, /* colIdx */
+					[]int{0}, /* colIdxs */
 					types.Bytes,
 					invSketch.numRows-invSketch.numNulls,
 					invDistinctCount,
@@ -624,15 +633,15 @@ func (s *sampleAggregator) getDistinctCount(si *sketchInfo, includeNulls bool) i
 	return distinctCount
 }
 
-// generateHistogram returns a histogram (on a given column) from a set of
-// samples.
+// generateHistogram returns a histogram (on the given columns) from a set of
+// samples. A histogram on multiple columns is built on tuples of type colType.
 // numRows is the total number of rows from which values were sampled
-// (excluding rows that have NULL values on the histogram column).
+// (excluding rows that have NULL values on all the histogram columns).
 func (s *sampleAggregator) generateHistogram(
 	ctx context.Context,
 	evalCtx *eval.Context,
 	sr *stats.SampleReservoir,
-	colIdx int,
+	colIdxs []int,
 	colType *types.T,
 	numRows int64,
 	distinctCount int64,
@@ -640,7 +649,13 @@ func (s *sampleAggregator) generateHistogram(
 	lowerBound tree.Datum,
 ) (stats.HistogramData, error) {
 	prevCapacity := sr.Cap()
-	values, err := sr.GetNonNullDatums(ctx, &s.tempMemAcc, colIdx)
+	var values tree.Datums
+	var err error
+	if len(colIdxs) == 1 {
+		values, err = sr.GetNonNullDatums(ctx, &s.tempMemAcc, colIdxs[0])
+	} else {
+		values, err = sr.GetNonNullTuples(ctx, &s.tempMemAcc, colIdxs, colType)
+	}
 	if err != nil {
 		return stats.HistogramData{}, err
 	}
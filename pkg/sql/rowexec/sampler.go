@@ -144,7 +144,9 @@ func newSamplerProcessor(
 			numRows:  0,
 		}
 		if spec.Sketches[i].GenerateHistogram {
-			sampleCols.Add(int(spec.Sketches[i].Columns[0]))
+			for _, col := range spec.Sketches[i].Columns {
+				sampleCols.Add(int(col))
+			}
 		}
 	}
 	for i := range spec.InvertedSketches {
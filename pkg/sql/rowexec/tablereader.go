@@ -50,6 +50,11 @@ type tableReader struct {
 
 	ignoreMisplannedRanges bool
 
+	// See TableReaderSpec.SampleSystem.
+	sampleSystem  bool
+	samplePercent float64
+	sampleSeed    int64
+
 	// fetcher wraps a row.Fetcher, allowing the tableReader to add a stat
 	// collection layer.
 	fetcher rowFetcher
@@ -107,6 +112,9 @@ func newTableReader(
 	tr.parallelize = spec.Parallelize
 	tr.batchBytesLimit = batchBytesLimit
 	tr.maxTimestampAge = time.Duration(spec.MaxTimestampAgeNanos)
+	tr.sampleSystem = spec.SampleSystem
+	tr.samplePercent = spec.SamplePercent
+	tr.sampleSeed = spec.SampleSeed
 
 	// Make sure the key column types are hydrated. The fetched column types
 	// will be hydrated in ProcessorBase.Init below.
@@ -213,6 +221,20 @@ func (tr *tableReader) startScan(ctx context.Context) error {
 		bytesLimit = tr.batchBytesLimit
 	}
 	log.VEventf(ctx, 1, "starting scan with limitBatches %t", limitBatches)
+	if tr.sampleSystem {
+		spans, err := row.SampleSpans(
+			ctx, tr.FlowCtx.Cfg.RangeCache, tr.Spans, tr.samplePercent, tr.sampleSeed,
+		)
+		if err != nil {
+			return err
+		}
+		tr.Spans = spans
+		if len(tr.Spans) == 0 {
+			// None of the ranges were sampled, so there is nothing to read.
+			tr.scanStarted = true
+			return nil
+		}
+	}
 	var err error
 	if tr.maxTimestampAge == 0 {
 		err = tr.fetcher.StartScan(
@@ -259,7 +281,7 @@ func (tr *tableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata
 	for tr.State == execinfra.StateRunning {
 		if !tr.scanStarted {
 			err := tr.startScan(tr.Ctx())
-			if err != nil {
+			if err != nil || len(tr.Spans) == 0 {
 				tr.MoveToDraining(err)
 				break
 			}
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -103,6 +104,10 @@ type scanNode struct {
 	// order for this optimization to work, the DistSQL planner must create a
 	// local plan.
 	localityOptimized bool
+
+	// sample, if sample.System is set, indicates that only a sample of the
+	// ranges overlapping the spans is read (see TABLESAMPLE SYSTEM).
+	sample opt.TableSample
 }
 
 // scanColumnsConfig controls the "schema" of a scan node.
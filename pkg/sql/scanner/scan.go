@@ -321,6 +321,12 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.CONTAINED_BY)
 			return
+		case '-':
+			if s.peekN(1) == '>' { // <->
+				s.pos += 2
+				lval.SetID(lexbase.DISTANCE)
+				return
+			}
 		}
 		return
 
@@ -405,6 +411,10 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.AT_AT)
 			return
+		case '?': // @?
+			s.pos++
+			lval.SetID(lexbase.AT_QUESTION)
+			return
 		}
 		return
 
@@ -921,6 +921,10 @@ func (b *builderState) ResolveUserDefinedTypeType(
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
 			"cannot modify table record type %q", typ.GetName()))
+	case descpb.TypeDescriptor_DOMAIN:
+		// Domains are dropped with DROP DOMAIN.
+		panic(errors.WithHint(pgerror.Newf(pgcode.WrongObjectType,
+			"%q is a domain", typ.GetName()), "use DROP DOMAIN instead"))
 	default:
 		panic(errors.AssertionFailedf("unknown type kind %s", typ.GetKind()))
 	}
@@ -1033,6 +1037,10 @@ func (b *builderState) ResolveView(
 	}
 	if rel := c.desc.(catalog.TableDescriptor); !rel.IsView() {
 		panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a view", rel.GetName()))
+	} else if rel.IsForeignTable() {
+		// Foreign tables have no element of their own yet, so leave them to the
+		// legacy schema changer.
+		panic(scerrors.NotImplementedErrorf(nil, "foreign table %q", rel.GetName()))
 	}
 	return b.QueryByID(c.desc.GetID())
 }
@@ -1305,6 +1313,12 @@ func (b *builderState) ensureDescriptor(id catid.DescID) {
 	}
 
 	c := b.newCachedDesc(id)
+	if tbl, ok := c.desc.(catalog.TableDescriptor); ok &&
+		(len(tbl.GetInherits()) > 0 || len(tbl.GetInheritedBy()) > 0) {
+		// Inheritance references have no element yet, so any schema change
+		// touching such a table is left to the legacy schema changer.
+		panic(scerrors.NotImplementedErrorf(nil, "table %q with inheritance", tbl.GetName()))
+	}
 	// Collect privileges
 	if !c.hasOwnership {
 		var err error
@@ -1436,6 +1450,12 @@ func (b *builderState) BuildUserPrivilegesFromDefaultPrivileges(
 	return ownerElem, upsElems
 }
 
+// HasFunctionComment implements the scbuildstmt.FunctionHelpers interface.
+func (b *builderState) HasFunctionComment(fnID descpb.ID) bool {
+	_, ok := b.commentGetter.GetFunctionComment(fnID)
+	return ok
+}
+
 func (b *builderState) WrapFunctionBody(
 	fnID descpb.ID,
 	bodyStr string,
@@ -123,6 +123,10 @@ func alterTableAddConstraintChecks(
 	mode sessiondatapb.NewSchemaChangerMode,
 	activeVersion clusterversion.ClusterVersion,
 ) bool {
+	// EXCLUDE constraints are only supported by the legacy schema changer.
+	if _, ok := t.ConstraintDef.(*tree.ExcludeConstraintTableDef); ok {
+		return false
+	}
 	// Start supporting ADD PRIMARY KEY from V22_2.
 	if d, ok := t.ConstraintDef.(*tree.UniqueConstraintTableDef); ok && d.PrimaryKey && t.ValidationBehavior == tree.ValidationDefault {
 		return isV222Active(t, mode, activeVersion)
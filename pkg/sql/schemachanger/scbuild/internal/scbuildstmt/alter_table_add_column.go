@@ -79,6 +79,9 @@ func alterTableAddColumn(
 	if d.GeneratedIdentity.IsGeneratedAsIdentity {
 		panic(scerrors.NotImplementedErrorf(d, "contains generated identity type"))
 	}
+	if d.Unique.Deferrability.IsDeferrable() {
+		panic(scerrors.NotImplementedErrorf(d, "contains deferrable unique constraint"))
+	}
 	// Unique without an index is unsupported.
 	if d.Unique.WithoutIndex {
 		// TODO(rytaft): add support for this in the future if we want to expose
@@ -156,7 +159,7 @@ func alterTableAddColumn(
 	_, _, tableNamespace := scpb.FindNamespace(b.QueryByID(tbl.TableID))
 	spec.colType.TypeT = b.ResolveTypeRef(d.Type)
 	if spec.colType.TypeT.Type.UserDefined() {
-		typeID := typedesc.UserDefinedTypeOIDToID(spec.colType.TypeT.Type.Oid())
+		typeID := typedesc.UserDefinedTypeOIDToID(spec.colType.TypeT.Type.UserDefinedOID())
 		maybeFailOnCrossDBTypeReference(b, typeID, tableNamespace.DatabaseID)
 	}
 	// Block unique indexes on unsupported types.
@@ -569,13 +572,14 @@ func addSecondaryIndexTargetsForAddColumn(
 ) {
 	var partitioning *catpb.PartitioningDescriptor
 	index := scpb.Index{
-		TableID:       tbl.TableID,
-		IndexID:       desc.ID,
-		IsUnique:      desc.Unique,
-		IsInverted:    desc.Type == descpb.IndexDescriptor_INVERTED,
-		SourceIndexID: newPrimaryIdx.IndexID,
-		IsNotVisible:  desc.NotVisible,
-		Invisibility:  desc.Invisibility,
+		TableID:            tbl.TableID,
+		IndexID:            desc.ID,
+		IsUnique:           desc.Unique,
+		IsInverted:         desc.Type == descpb.IndexDescriptor_INVERTED,
+		SourceIndexID:      newPrimaryIdx.IndexID,
+		IsNotVisible:       desc.NotVisible,
+		Invisibility:       desc.Invisibility,
+		ExclusionOperators: desc.ExclusionOperators,
 	}
 	tempIndexID := index.IndexID + 1 // this is enforced below
 	index.TemporaryIndexID = tempIndexID
@@ -41,6 +41,9 @@ func alterTableAddConstraint(
 ) {
 	switch d := t.ConstraintDef.(type) {
 	case *tree.UniqueConstraintTableDef:
+		if d.Deferrability.IsDeferrable() {
+			panic(scerrors.NotImplementedErrorf(t, "deferrable unique constraint"))
+		}
 		if d.PrimaryKey {
 			alterTableAddPrimaryKey(b, tn, tbl, t)
 		} else if d.WithoutIndex {
@@ -61,7 +64,12 @@ func alterTableAddConstraint(
 	case *tree.CheckConstraintTableDef:
 		alterTableAddCheck(b, tn, tbl, t)
 	case *tree.ForeignKeyConstraintTableDef:
+		if d.Deferrability.IsDeferrable() {
+			panic(scerrors.NotImplementedErrorf(t, "deferrable foreign key constraint"))
+		}
 		alterTableAddForeignKey(b, tn, tbl, t)
+	case *tree.ExcludeConstraintTableDef:
+		panic(scerrors.NotImplementedErrorf(t, "exclusion constraint"))
 	}
 }
 
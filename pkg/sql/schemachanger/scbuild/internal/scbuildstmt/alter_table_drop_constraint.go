@@ -25,6 +25,16 @@ import (
 func alterTableDropConstraint(
 	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, t *tree.AlterTableDropConstraint,
 ) {
+	// Exclusion constraints are backed by a non-unique secondary index, which
+	// has no constraint elements of its own: dropping one drops the index.
+	if isExclusionConstraintIndex(b, tbl.TableID, string(t.Constraint)) {
+		maybeDropIndex(b, &tree.TableIndexName{
+			Table: *tn,
+			Index: tree.UnrestrictedName(t.Constraint),
+		}, false /* ifExists */, t.DropBehavior)
+		return
+	}
+
 	constraintElems := b.ResolveConstraint(tbl.TableID, t.Constraint, ResolveParams{
 		IsExistenceOptional: t.IfExists,
 		RequiredPrivilege:   privilege.CREATE,
@@ -57,6 +67,23 @@ func alterTableDropConstraint(
 		constraintNameElem.Name, t.DropBehavior)
 }
 
+// isExclusionConstraintIndex returns true iff the table has a public secondary
+// index with the given name which backs an EXCLUDE constraint.
+func isExclusionConstraintIndex(b BuildCtx, tableID catid.DescID, name string) (ret bool) {
+	publicTableElts := b.QueryByID(tableID).Filter(publicTargetFilter)
+	scpb.ForEachIndexName(publicTableElts, func(_ scpb.Status, _ scpb.TargetStatus, n *scpb.IndexName) {
+		if n.Name != name {
+			return
+		}
+		scpb.ForEachSecondaryIndex(publicTableElts, func(_ scpb.Status, _ scpb.TargetStatus, e *scpb.SecondaryIndex) {
+			if e.IndexID == n.IndexID && len(e.ExclusionOperators) > 0 {
+				ret = true
+			}
+		})
+	})
+	return ret
+}
+
 func maybeDropAdditionallyForUniqueWithoutIndexConstraint(
 	b BuildCtx,
 	tableID catid.DescID,
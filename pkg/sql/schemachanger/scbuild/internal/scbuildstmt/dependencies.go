@@ -283,6 +283,9 @@ type TableHelpers interface {
 type FunctionHelpers interface {
 	BuildReferenceProvider(stmt tree.Statement) ReferenceProvider
 	WrapFunctionBody(fnID descpb.ID, bodyStr string, lang catpb.Function_Language, provider ReferenceProvider) *scpb.FunctionBody
+
+	// HasFunctionComment returns true if the function has a comment.
+	HasFunctionComment(fnID descpb.ID) bool
 }
 
 type SchemaHelpers interface {
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 )
 
 func DropFunction(b BuildCtx, n *tree.DropFunction) {
@@ -37,6 +38,24 @@ func DropFunction(b BuildCtx, n *tree.DropFunction) {
 		if fn == nil {
 			continue
 		}
+		if fn.IsProcedure != n.IsProcedure {
+			panic(sqlerrors.NewWrongRoutineKindError(&f.FuncName, fn.IsProcedure))
+		}
+		if fn.IsAggregate != n.IsAggregate {
+			panic(sqlerrors.NewWrongAggregateKindError(&f.FuncName, fn.IsAggregate))
+		}
+		// Aggregates and the support functions they reference are linked by
+		// back-references that are not modeled as elements yet, so they are
+		// dropped by the legacy schema changer.
+		if fn.IsAggregate || hasAggregateBackReferences(b, fn.FunctionID) {
+			panic(scerrors.NotImplementedErrorf(n, "dropping aggregates or their support functions"))
+		}
+		// Function comments are not modeled as elements either, so functions
+		// with a comment are dropped by the legacy schema changer, which also
+		// removes the comment.
+		if b.HasFunctionComment(fn.FunctionID) {
+			panic(scerrors.NotImplementedErrorf(n, "dropping functions with comments"))
+		}
 		f.FuncName.ObjectNamePrefix = b.NamePrefix(fn)
 		if dropRestrictDescriptor(b, fn.FunctionID) {
 			toCheckBackRefs = append(toCheckBackRefs, fn.FunctionID)
@@ -60,3 +79,14 @@ func DropFunction(b BuildCtx, n *tree.DropFunction) {
 		}
 	}
 }
+
+// hasAggregateBackReferences returns true if the function is a support
+// function of a user-defined aggregate.
+func hasAggregateBackReferences(b BuildCtx, fnID catid.DescID) (ret bool) {
+	b.BackReferences(fnID).ForEach(func(_ scpb.Status, _ scpb.TargetStatus, e scpb.Element) {
+		if fn, ok := e.(*scpb.Function); ok && fn.IsAggregate {
+			ret = true
+		}
+	})
+	return ret
+}
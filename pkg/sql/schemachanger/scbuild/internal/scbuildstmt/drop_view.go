@@ -24,6 +24,9 @@ import (
 
 // DropView implements DROP VIEW.
 func DropView(b BuildCtx, n *tree.DropView) {
+	if n.IsForeignTable {
+		panic(scerrors.NotImplementedErrorf(n, "DROP FOREIGN TABLE"))
+	}
 	var toCheckBackrefs []catid.DescID
 	for i := range n.Names {
 		name := &n.Names[i]
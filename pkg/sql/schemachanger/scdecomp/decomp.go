@@ -201,6 +201,15 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				LogicalRepresentation:  enum.GetMemberLogicalRepresentation(ord),
 			})
 		}
+	} else if domain := typ.AsDomainTypeDescriptor(); domain != nil {
+		// Domains have no elements of their own yet. They are represented like
+		// alias types of their base type, which is enough to drop them along
+		// with their schema or database.
+		typeT := newTypeT(domain.GetBaseType())
+		w.ev(descriptorStatus(typ), &scpb.AliasType{
+			TypeID: typ.GetID(),
+			TypeT:  *typeT,
+		})
 	} else if comp := typ.AsCompositeTypeDescriptor(); comp != nil {
 		w.ev(descriptorStatus(typ), &scpb.CompositeType{
 			TypeID:      comp.GetID(),
@@ -586,6 +595,7 @@ func (w *walkCtx) walkIndex(tbl catalog.TableDescriptor, idx catalog.Index) {
 			ConstraintID:        idx.GetConstraintID(),
 			IsNotVisible:        idx.GetInvisibility() != 0.0,
 			Invisibility:        idx.GetInvisibility(),
+			ExclusionOperators:  cpy.ExclusionOperators,
 		}
 		if geoConfig := idx.GetGeoConfig(); !geoConfig.IsEmpty() {
 			index.GeoConfig = protoutil.Clone(&geoConfig).(*geoindex.Config)
@@ -810,10 +820,12 @@ func (w *walkCtx) walkForeignKeyConstraint(
 func (w *walkCtx) walkFunction(fnDesc catalog.FunctionDescriptor) {
 	typeT := newTypeT(fnDesc.GetReturnType().Type)
 	fn := &scpb.Function{
-		FunctionID: fnDesc.GetID(),
-		ReturnSet:  fnDesc.GetReturnType().ReturnSet,
-		ReturnType: *typeT,
-		Params:     make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		FunctionID:  fnDesc.GetID(),
+		ReturnSet:   fnDesc.GetReturnType().ReturnSet,
+		ReturnType:  *typeT,
+		IsProcedure: fnDesc.GetIsProcedure(),
+		IsAggregate: fnDesc.GetAggregate() != nil,
+		Params:      make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
 	}
 	for i, param := range fnDesc.GetParams() {
 		typeT := newTypeT(param.Type)
@@ -44,6 +44,10 @@ type CommentGetter interface {
 	// GetConstraintComment returns comment for a constraint. `ok` returned
 	// indicates if the comment actually exists or not.
 	GetConstraintComment(tableID catid.DescID, constraintID catid.ConstraintID) (comment string, ok bool)
+
+	// GetFunctionComment returns comment for a function or procedure. `ok`
+	// returned indicates if the comment actually exists or not.
+	GetFunctionComment(fnID catid.DescID) (comment string, ok bool)
 }
 
 // ZoneConfigGetter supports reading raw zone config information
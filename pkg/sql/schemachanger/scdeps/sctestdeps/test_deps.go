@@ -1351,6 +1351,11 @@ func (s *TestState) GetConstraintComment(
 	return s.get(tableID, uint32(constraintID), catalogkeys.ConstraintCommentType)
 }
 
+// GetFunctionComment implements the scdecomp.CommentGetter interface.
+func (s *TestState) GetFunctionComment(fnID catid.DescID) (comment string, ok bool) {
+	return s.get(fnID, 0, catalogkeys.FunctionCommentType)
+}
+
 // getOrderedNameInfos retrieves all keys in nameInfos in sorted order.
 func getOrderedNameInfos(nameInfos map[descpb.NameInfo]descpb.ID) []descpb.NameInfo {
 	ret := make([]descpb.NameInfo, 0, len(nameInfos))
@@ -89,6 +89,7 @@ func addNewIndexMutation(
 		ConstraintID:                opIndex.ConstraintID,
 		UseDeletePreservingEncoding: isDeletePreserving,
 		StoreColumnNames:            []string{},
+		ExclusionOperators:          opIndex.ExclusionOperators,
 	}
 	if isSecondary && !isDeletePreserving {
 		idx.CreatedAtNanos = i.clock.ApproximateTime().UnixNano()
@@ -1284,6 +1284,7 @@ const sizeOfSTMakeLineAggregate = int64(unsafe.Sizeof(stMakeLineAgg{}))
 const sizeOfSTUnionAggregate = int64(unsafe.Sizeof(stUnionAgg{}))
 const sizeOfSTCollectAggregate = int64(unsafe.Sizeof(stCollectAgg{}))
 const sizeOfSTExtentAggregate = int64(unsafe.Sizeof(stExtentAgg{}))
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
 
 // singleDatumAggregateBase is a utility struct that helps aggregate builtins
 // that store a single datum internally track their memory usage related to
@@ -4923,3 +4924,130 @@ func (a *jsonObjectAggregate) Close(ctx context.Context) {
 func (a *jsonObjectAggregate) Size() int64 {
 	return sizeOfJSONObjectAggregate
 }
+
+// userDefinedAggregate evaluates an aggregate created with CREATE AGGREGATE.
+type userDefinedAggregate struct {
+	singleDatumAggregateBase
+
+	evalCtx *eval.Context
+	support *tree.AggregateSupportExprs
+	numArgs int
+
+	// state is the current state of the aggregation.
+	state tree.Datum
+	// row contains the values of the IndexedVars of the support function
+	// currently being evaluated.
+	row tree.Datums
+}
+
+var _ eval.IndexedVarContainer = &userDefinedAggregate{}
+
+// NewUserDefinedAggregate returns an aggregate function that evaluates the
+// support functions of a user-defined aggregate. See tree.AggregateSupportExprs
+// for the semantics of the support expressions. numArgs is the number of
+// arguments passed to Add.
+func NewUserDefinedAggregate(
+	evalCtx *eval.Context,
+	support *tree.AggregateSupportExprs,
+	numArgs int,
+) eval.AggregateFunc {
+	return &userDefinedAggregate{
+		singleDatumAggregateBase: makeSingleDatumAggregateBase(evalCtx),
+		evalCtx:                  evalCtx,
+		support:                  support,
+		numArgs:                  numArgs,
+		state:                    support.InitialState,
+		row:                      make(tree.Datums, 0, numArgs+1),
+	}
+}
+
+// Add implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	a.row = append(a.row[:0], a.state)
+	if a.numArgs > 0 {
+		a.row = append(a.row, firstArg)
+		a.row = append(a.row, otherArgs...)
+	}
+	if a.support.TransitionStrict {
+		// A strict transition function is not called for rows with a NULL
+		// argument. If the state is NULL, the first argument of the first
+		// row without NULL arguments becomes the state.
+		for _, d := range a.row[1:] {
+			if d == tree.DNull {
+				return nil
+			}
+		}
+		if a.state == tree.DNull {
+			if a.numArgs > 0 {
+				return a.setState(ctx, firstArg)
+			}
+			return nil
+		}
+	}
+	state, err := a.eval(ctx, a.support.Transition)
+	if err != nil {
+		return err
+	}
+	return a.setState(ctx, state)
+}
+
+func (a *userDefinedAggregate) setState(ctx context.Context, state tree.Datum) error {
+	a.state = state
+	return a.updateMemoryUsage(ctx, int64(state.Size()))
+}
+
+// eval evaluates the given support expression with the IndexedVars bound to
+// a.row.
+func (a *userDefinedAggregate) eval(ctx context.Context, expr tree.TypedExpr) (tree.Datum, error) {
+	a.evalCtx.PushIVarContainer(a)
+	defer a.evalCtx.PopIVarContainer()
+	return eval.Expr(ctx, a.evalCtx, expr)
+}
+
+// Result implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.support.Final == nil {
+		return a.state, nil
+	}
+	if a.support.FinalStrict && a.state == tree.DNull {
+		return tree.DNull, nil
+	}
+	a.row = append(a.row[:0], a.state)
+	// Result is not passed a context.
+	return a.eval(context.TODO(), a.support.Final)
+}
+
+// Reset implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.state = a.support.InitialState
+	a.reset(ctx)
+}
+
+// Close implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(ctx context.Context) {
+	a.close(ctx)
+}
+
+// Size implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
+
+// IndexedVarEval implements the eval.IndexedVarContainer interface.
+func (a *userDefinedAggregate) IndexedVarEval(
+	ctx context.Context, idx int, e tree.ExprEvaluator,
+) (tree.Datum, error) {
+	return a.row[idx].Eval(ctx, e)
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (a *userDefinedAggregate) IndexedVarResolvedType(idx int) *types.T {
+	return a.row[idx].ResolvedType()
+}
+
+// IndexedVarNodeFormatter implements the tree.IndexedVarContainer interface.
+func (a *userDefinedAggregate) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	return nil
+}
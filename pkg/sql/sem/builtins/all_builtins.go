@@ -59,7 +59,9 @@ func init() {
 			signature := name + fn.Signature(true)
 			overloads[i].Oid = signatureMustHaveHardcodedOID(signature)
 			tree.OidToBuiltinName[overloads[i].Oid] = name
-			if _, ok := CastBuiltinNames[name]; ok {
+			// Only single-argument overloads of cast builtins implement casts.
+			// Others, such as point(float, float), are constructors.
+			if _, ok := CastBuiltinNames[name]; ok && fn.Types.Length() == 1 {
 				retOid := fn.ReturnType(nil).Oid()
 				if _, ok := CastBuiltinOIDs[retOid]; !ok {
 					CastBuiltinOIDs[retOid] = make(map[types.Family]oid.Oid, len(overloads))
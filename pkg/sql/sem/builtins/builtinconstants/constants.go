@@ -45,6 +45,7 @@ const (
 	CategoryEnum                = "Enum"
 	CategoryFullTextSearch      = "Full Text Search"
 	CategoryGenerator           = "Set-returning"
+	CategoryGeometric           = "Geometric"
 	CategoryTrigram             = "Trigrams"
 	CategoryFuzzyStringMatching = "Fuzzy String Matching"
 	CategoryIDGeneration        = "ID generation"
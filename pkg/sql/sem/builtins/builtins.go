@@ -86,6 +86,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
@@ -3871,13 +3872,61 @@ value if you rely on the HLC for accuracy.`,
 	// The behavior of both the JSON and JSONB data types in CockroachDB is
 	// similar to the behavior of the JSONB data type in Postgres.
 
-	"jsonb_path_exists":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_exists_opr":  makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_match":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_match_opr":   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query_array": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query_first": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
+	"jsonb_path_exists": makeBuiltin(jsonProps(), makeJSONPathOverloads(
+		types.Bool,
+		"Returns whether the JSON path returns any item for the specified JSON value.",
+		jsonPathExists,
+	)...),
+
+	"jsonb_path_exists_opr": makeBuiltin(jsonProps(), makeJSONPathOprOverload(
+		"Returns whether the JSON path returns any item for the specified JSON value. "+
+			"This is the implementation of the @? operator.",
+		jsonPathExists,
+	)),
+
+	"jsonb_path_match": makeBuiltin(jsonProps(), makeJSONPathOverloads(
+		types.Bool,
+		"Returns the result of a JSON path predicate check for the specified JSON value. "+
+			"Only the first item of the result is taken into account. If the result is not "+
+			"Boolean, then NULL is returned.",
+		jsonPathMatch,
+	)...),
+
+	"jsonb_path_match_opr": makeBuiltin(jsonProps(), makeJSONPathOprOverload(
+		"Returns the result of a JSON path predicate check for the specified JSON value. "+
+			"This is the implementation of the @@ operator.",
+		jsonPathMatch,
+	)),
+
+	"jsonb_path_query_array": makeBuiltin(jsonProps(), makeJSONPathOverloads(
+		types.Jsonb,
+		"Returns all JSON items returned by the JSON path for the specified JSON value, "+
+			"as a JSON array.",
+		func(p *jsonpath.Jsonpath, target, vars json.JSON, silent bool) (tree.Datum, error) {
+			items, err := p.Query(target, vars, silent)
+			if err != nil {
+				return nil, err
+			}
+			b := json.NewArrayBuilder(len(items))
+			for _, item := range items {
+				b.Add(item)
+			}
+			return tree.NewDJSON(b.Build()), nil
+		},
+	)...),
+
+	"jsonb_path_query_first": makeBuiltin(jsonProps(), makeJSONPathOverloads(
+		types.Jsonb,
+		"Returns the first JSON item returned by the JSON path for the specified JSON value. "+
+			"Returns NULL if there are no results.",
+		func(p *jsonpath.Jsonpath, target, vars json.JSON, silent bool) (tree.Datum, error) {
+			items, err := p.Query(target, vars, silent)
+			if err != nil || len(items) == 0 {
+				return tree.DNull, err
+			}
+			return tree.NewDJSON(items[0]), nil
+		},
+	)...),
 
 	"json_remove_path": makeBuiltin(jsonProps(),
 		tree.Overload{
@@ -4216,6 +4265,52 @@ value if you rely on the HLC for accuracy.`,
 			CalledOnNullInput: true,
 		},
 	),
+	"crdb_internal.tablesample_bernoulli": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Info: "Returns whether the row identified by the given key should be " +
+				"included in a TABLESAMPLE BERNOULLI sample of the given percentage. " +
+				"If the seed is NULL, rows are selected at random; otherwise the " +
+				"selection is a deterministic function of the seed and the key.",
+			Types: tree.VariadicType{
+				FixedTypes: []*types.T{types.Float, types.Int},
+				VarType:    types.Any,
+			},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return tree.DBoolFalse, nil
+				}
+				fraction := float64(tree.MustBeDFloat(args[0])) / 100
+				if args[1] == tree.DNull {
+					return tree.MakeDBool(rand.Float64() < fraction), nil
+				}
+				h := fnv.New64a()
+				buf := encoding.EncodeVarintAscending(nil, int64(tree.MustBeDInt(args[1])))
+				for i, arg := range args[2:] {
+					var err error
+					buf, err = keyside.Encode(buf, arg, encoding.Ascending)
+					if err != nil {
+						return nil, pgerror.Newf(
+							pgcode.DatatypeMismatch,
+							"illegal argument %d of type %s",
+							i+2, arg.ResolvedType(),
+						)
+					}
+				}
+				_, _ = h.Write(buf)
+				// Map the hash onto [0, 1) using its top 53 bits, which is the
+				// precision of a float64 mantissa.
+				r := float64(h.Sum64()>>11) / (1 << 53)
+				return tree.MakeDBool(r < fraction), nil
+			},
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
 	"crdb_internal.merge_statement_stats": makeBuiltin(arrayProps(),
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "input", Typ: types.JSONBArray}},
@@ -5406,6 +5501,44 @@ SELECT
 		},
 	),
 
+	"crdb_internal.plpgsql_raise": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "severity", Typ: types.String},
+				{Name: "message", Typ: types.String},
+				{Name: "detail", Typ: types.String},
+				{Name: "hint", Typ: types.String},
+				{Name: "code", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				argStrings := make([]string, len(args))
+				for i := range args {
+					if args[i] == tree.DNull {
+						return nil, pgerror.New(
+							pgcode.NullValueNotAllowed, "RAISE statement option cannot be null",
+						)
+					}
+					s, ok := tree.AsDString(args[i])
+					if !ok {
+						return nil, errors.Newf("expected string value, got %T", args[i])
+					}
+					argStrings[i] = string(s)
+				}
+				return crdbInternalPLpgSQLRaise(
+					ctx, evalCtx, argStrings[0], argStrings[1], argStrings[2], argStrings[3], argStrings[4],
+				)
+			},
+			Info:              "This function is used internally to implement the PL/pgSQL RAISE statement.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+
 	"crdb_internal.force_assertion_error": makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategorySystemInfo,
@@ -9112,6 +9245,90 @@ var jsonArrayLengthImpl = tree.Overload{
 	Volatility: volatility.Immutable,
 }
 
+// jsonPathParamTypes returns the first numArgs parameters of the jsonb_path_*
+// builtins. The optional vars parameter supplies the values of the named
+// variables referenced by the path, and the optional silent parameter
+// suppresses errors caused by the structure of the target.
+func jsonPathParamTypes(numArgs int) tree.ParamTypes {
+	return tree.ParamTypes{
+		{Name: "target", Typ: types.Jsonb},
+		{Name: "path", Typ: types.Jsonpath},
+		{Name: "vars", Typ: types.Jsonb},
+		{Name: "silent", Typ: types.Bool},
+	}[:numArgs]
+}
+
+// jsonPathArgs unpacks the arguments of the jsonb_path_* builtins.
+func jsonPathArgs(
+	args tree.Datums,
+) (p *jsonpath.Jsonpath, target, vars json.JSON, silent bool) {
+	p = &tree.MustBeDJsonpath(args[1]).Jsonpath
+	target = tree.MustBeDJSON(args[0]).JSON
+	if len(args) > 2 {
+		vars = tree.MustBeDJSON(args[2]).JSON
+	}
+	if len(args) > 3 {
+		silent = bool(tree.MustBeDBool(args[3]))
+	}
+	return p, target, vars, silent
+}
+
+// makeJSONPathOprOverload returns the overload of a jsonb_path_*_opr builtin,
+// which implements a jsonpath operator. Like the operator, it suppresses
+// errors caused by the structure of the target.
+func makeJSONPathOprOverload(
+	info string, fn func(p *jsonpath.Jsonpath, target, vars json.JSON, silent bool) (tree.Datum, error),
+) tree.Overload {
+	return tree.Overload{
+		Types:      jsonPathParamTypes(2),
+		ReturnType: tree.FixedReturnType(types.Bool),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			p, target, _, _ := jsonPathArgs(args)
+			return fn(p, target, nil /* vars */, true /* silent */)
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+func jsonPathExists(p *jsonpath.Jsonpath, target, vars json.JSON, silent bool) (tree.Datum, error) {
+	res, ok, err := p.Exists(target, vars, silent)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
+func jsonPathMatch(p *jsonpath.Jsonpath, target, vars json.JSON, silent bool) (tree.Datum, error) {
+	res, ok, err := p.Match(target, vars, silent)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
+// makeJSONPathOverloads returns the overloads of a jsonb_path_* builtin, with
+// and without the optional vars and silent parameters.
+func makeJSONPathOverloads(
+	retType *types.T,
+	info string,
+	fn func(p *jsonpath.Jsonpath, target, vars json.JSON, silent bool) (tree.Datum, error),
+) []tree.Overload {
+	overloads := make([]tree.Overload, 0, 3)
+	for numArgs := 2; numArgs <= 4; numArgs++ {
+		overloads = append(overloads, tree.Overload{
+			Types:      jsonPathParamTypes(numArgs),
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(jsonPathArgs(args))
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
 func similarOverloads(calledOnNullInput bool) []tree.Overload {
 	return []tree.Overload{
 		{
@@ -10484,9 +10701,9 @@ func asJSONBuildObjectKey(
 		), nil
 	case *tree.DBitArray, *tree.DBool, *tree.DBox2D, *tree.DBytes, *tree.DDate,
 		*tree.DDecimal, *tree.DEnum, *tree.DFloat, *tree.DGeography,
-		*tree.DGeometry, *tree.DIPAddr, *tree.DInt, *tree.DInterval, *tree.DOid,
-		*tree.DOidWrapper, *tree.DPGLSN, *tree.DTime, *tree.DTimeTZ, *tree.DTimestamp,
-		*tree.DTSQuery, *tree.DTSVector, *tree.DUuid, *tree.DVoid:
+		*tree.DGeometry, *tree.DIPAddr, *tree.DInt, *tree.DInterval, *tree.DJsonpath,
+		*tree.DOid, *tree.DOidWrapper, *tree.DPGLSN, *tree.DTime, *tree.DTimeTZ,
+		*tree.DTimestamp, *tree.DTSQuery, *tree.DTSVector, *tree.DUuid, *tree.DVoid:
 		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	default:
 		return "", errors.AssertionFailedf("unexpected type %T for key value", d)
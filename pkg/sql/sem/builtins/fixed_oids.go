@@ -2427,6 +2427,132 @@ var builtinOidsArray = []string{
 	2454: `crdb_internal.sstable_metrics(node_id: int, store_id: int, start_key: bytes, end_key: bytes) -> tuple{int AS node_id,, int AS store_id, int AS level, int AS file_num, jsonb AS metrics}`,
 	2455: `crdb_internal.repair_catalog_corruption(descriptor_id: int, corruption: string) -> bool`,
 	2456: `crdb_internal.merge_aggregated_stmt_metadata(input: jsonb[]) -> jsonb`,
+	2457: `crdb_internal.plpgsql_raise(severity: string, message: string, detail: string, hint: string, code: string) -> int`,
+	2458: `pg_notify(channel: string, payload: string) -> void`,
+	2459: `pg_listening_channels() -> string`,
+	2460: `jsonpathsend(jsonpath: jsonpath) -> bytes`,
+	2461: `jsonpathrecv(input: anyelement) -> jsonpath`,
+	2462: `jsonpathout(jsonpath: jsonpath) -> bytes`,
+	2463: `jsonpathin(input: anyelement) -> jsonpath`,
+	2464: `jsonpath(string: string) -> jsonpath`,
+	2465: `jsonpath(jsonpath: jsonpath) -> jsonpath`,
+	2466: `varchar(jsonpath: jsonpath) -> varchar`,
+	2467: `text(jsonpath: jsonpath) -> string`,
+	2468: `bpchar(jsonpath: jsonpath) -> char`,
+	2469: `name(jsonpath: jsonpath) -> name`,
+	2470: `char(jsonpath: jsonpath) -> "char"`,
+	2471: `jsonb_path_exists(target: jsonb, path: jsonpath) -> bool`,
+	2472: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2473: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2474: `jsonb_path_match(target: jsonb, path: jsonpath) -> bool`,
+	2475: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2476: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2477: `jsonb_path_query(target: jsonb, path: jsonpath) -> jsonb`,
+	2478: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2479: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2480: `jsonb_path_query_array(target: jsonb, path: jsonpath) -> jsonb`,
+	2481: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2482: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2483: `jsonb_path_query_first(target: jsonb, path: jsonpath) -> jsonb`,
+	2484: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2485: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2486: `jsonb_path_exists_opr(target: jsonb, path: jsonpath) -> bool`,
+	2487: `jsonb_path_match_opr(target: jsonb, path: jsonpath) -> bool`,
+	2488: `crdb_internal.tablesample_bernoulli(float, int, anyelement...) -> bool`,
+	2489: `crdb_internal.read_foreign_table(table: regclass) -> tuple`,
+	2490: `pointsend(point: point) -> bytes`,
+	2491: `pointrecv(input: anyelement) -> point`,
+	2492: `pointout(point: point) -> bytes`,
+	2493: `pointin(input: anyelement) -> point`,
+	2494: `point(string: string) -> point`,
+	2495: `point(point: point) -> point`,
+	2496: `point(box: box) -> point`,
+	2497: `point(circle: circle) -> point`,
+	2498: `point(polygon: polygon) -> point`,
+	2499: `point(geometry: geometry) -> point`,
+	2500: `varchar(point: point) -> varchar`,
+	2501: `text(point: point) -> string`,
+	2502: `bpchar(point: point) -> char`,
+	2503: `name(point: point) -> name`,
+	2504: `char(point: point) -> "char"`,
+	2505: `boxsend(box: box) -> bytes`,
+	2506: `boxrecv(input: anyelement) -> box`,
+	2507: `boxout(box: box) -> bytes`,
+	2508: `boxin(input: anyelement) -> box`,
+	2509: `box(string: string) -> box`,
+	2510: `box(box: box) -> box`,
+	2511: `box(circle: circle) -> box`,
+	2512: `box(point: point) -> box`,
+	2513: `box(polygon: polygon) -> box`,
+	2514: `varchar(box: box) -> varchar`,
+	2515: `text(box: box) -> string`,
+	2516: `bpchar(box: box) -> char`,
+	2517: `name(box: box) -> name`,
+	2518: `char(box: box) -> "char"`,
+	2519: `pathsend(path: path) -> bytes`,
+	2520: `pathrecv(input: anyelement) -> path`,
+	2521: `pathout(path: path) -> bytes`,
+	2522: `pathin(input: anyelement) -> path`,
+	2523: `path(string: string) -> path`,
+	2524: `path(path: path) -> path`,
+	2525: `path(polygon: polygon) -> path`,
+	2526: `path(geometry: geometry) -> path`,
+	2527: `varchar(path: path) -> varchar`,
+	2528: `text(path: path) -> string`,
+	2529: `bpchar(path: path) -> char`,
+	2530: `name(path: path) -> name`,
+	2531: `char(path: path) -> "char"`,
+	2532: `polygonsend(polygon: polygon) -> bytes`,
+	2533: `polygonrecv(input: anyelement) -> polygon`,
+	2534: `polygonout(polygon: polygon) -> bytes`,
+	2535: `polygonin(input: anyelement) -> polygon`,
+	2536: `polygon(string: string) -> polygon`,
+	2537: `polygon(polygon: polygon) -> polygon`,
+	2538: `polygon(box: box) -> polygon`,
+	2539: `polygon(circle: circle) -> polygon`,
+	2540: `polygon(path: path) -> polygon`,
+	2541: `polygon(geometry: geometry) -> polygon`,
+	2542: `varchar(polygon: polygon) -> varchar`,
+	2543: `text(polygon: polygon) -> string`,
+	2544: `bpchar(polygon: polygon) -> char`,
+	2545: `name(polygon: polygon) -> name`,
+	2546: `char(polygon: polygon) -> "char"`,
+	2547: `circlesend(circle: circle) -> bytes`,
+	2548: `circlerecv(input: anyelement) -> circle`,
+	2549: `circleout(circle: circle) -> bytes`,
+	2550: `circlein(input: anyelement) -> circle`,
+	2551: `circle(string: string) -> circle`,
+	2552: `circle(circle: circle) -> circle`,
+	2553: `circle(box: box) -> circle`,
+	2554: `circle(polygon: polygon) -> circle`,
+	2555: `varchar(circle: circle) -> varchar`,
+	2556: `text(circle: circle) -> string`,
+	2557: `bpchar(circle: circle) -> char`,
+	2558: `name(circle: circle) -> name`,
+	2559: `char(circle: circle) -> "char"`,
+	2560: `geometry(point: point) -> geometry`,
+	2561: `geometry(path: path) -> geometry`,
+	2562: `geometry(polygon: polygon) -> geometry`,
+	2563: `point(x: float, y: float) -> point`,
+	2564: `box(a: point, b: point) -> box`,
+	2565: `circle(center: point, radius: float) -> circle`,
+	2566: `polygon(npts: int, circle: circle) -> polygon`,
+	2567: `area(box: box) -> float`,
+	2568: `area(circle: circle) -> float`,
+	2569: `area(path: path) -> float`,
+	2570: `bound_box(a: box, b: box) -> box`,
+	2571: `center(box: box) -> point`,
+	2572: `center(circle: circle) -> point`,
+	2573: `diameter(circle: circle) -> float`,
+	2574: `radius(circle: circle) -> float`,
+	2575: `width(box: box) -> float`,
+	2576: `height(box: box) -> float`,
+	2577: `npoints(path: path) -> int`,
+	2578: `npoints(polygon: polygon) -> int`,
+	2579: `isclosed(path: path) -> bool`,
+	2580: `isopen(path: path) -> bool`,
+	2581: `pclose(path: path) -> path`,
+	2582: `popen(path: path) -> path`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
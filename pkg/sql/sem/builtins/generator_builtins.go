@@ -46,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing/tracingpb"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // See the comments at the start of generators.go for details about
@@ -240,6 +241,21 @@ var generators = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+	"pg_listening_channels": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategoryGenerator,
+			DistsqlBlocklist: true,
+		},
+		// See https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-INFO-SESSION-TABLE
+		makeGeneratorOverload(
+			tree.ParamTypes{},
+			types.String,
+			makeListeningChannelsGenerator,
+			"Returns the names of the asynchronous notification channels on which "+
+				"the current session is listening.",
+			volatility.Volatile,
+		),
+	),
 	`pg_options_to_table`: makeBuiltin(
 		genProps(),
 		makeGeneratorOverload(
@@ -382,6 +398,7 @@ var generators = map[string]builtinDefinition{
 	"jsonb_each":                makeBuiltin(jsonGenPropsWithLabels(jsonEachGeneratorLabels), jsonEachImpl),
 	"json_each_text":            makeBuiltin(jsonGenPropsWithLabels(jsonEachGeneratorLabels), jsonEachTextImpl),
 	"jsonb_each_text":           makeBuiltin(jsonGenPropsWithLabels(jsonEachGeneratorLabels), jsonEachTextImpl),
+	"jsonb_path_query":          makeBuiltin(genProps(), jsonPathQueryImpls()...),
 	"json_populate_record": makeBuiltin(jsonPopulateProps, makeJSONPopulateImpl(makeJSONPopulateRecordGenerator,
 		"Expands the object in from_json to a row whose columns match the record type defined by base.",
 	)),
@@ -466,6 +483,27 @@ var generators = map[string]builtinDefinition{
 			volatility.Volatile,
 		),
 	),
+	"crdb_internal.read_foreign_table": makeBuiltin(
+		tree.FunctionProperties{
+			Category:          builtinconstants.CategorySystemInfo,
+			ReturnsRecordType: true,
+			// The rows are read through the planner of the gateway.
+			DistsqlBlocklist: true,
+		},
+		makeGeneratorOverload(
+			tree.ParamTypes{
+				{Name: "table", Typ: types.RegClass},
+			},
+			// NOTE: this type will never actually get used. It is replaced in the
+			// optimizer by looking at the most recent AS alias clause.
+			types.EmptyTuple,
+			makeForeignTableRowsGenerator,
+			"Reads the rows of the file in external storage backing the given "+
+				"foreign table. The columns to read are given by the column definition "+
+				"list of the AS clause. This is used to implement foreign tables.",
+			volatility.Volatile,
+		),
+	),
 	"crdb_internal.show_create_all_schemas": makeBuiltin(
 		tree.FunctionProperties{},
 		makeGeneratorOverload(
@@ -853,6 +891,22 @@ var keywordsValueGeneratorType = types.MakeLabeledTuple(
 	[]string{"word", "catcode", "catdesc"},
 )
 
+func makeListeningChannelsGenerator(
+	_ context.Context, evalCtx *eval.Context, _ tree.Datums,
+) (eval.ValueGenerator, error) {
+	channels, err := evalCtx.SessionAccessor.ListeningChannels()
+	if err != nil {
+		return nil, err
+	}
+	arr := tree.NewDArray(types.String)
+	for _, channel := range channels {
+		if err := arr.Append(tree.NewDString(channel)); err != nil {
+			return nil, err
+		}
+	}
+	return &arrayValueGenerator{array: arr}, nil
+}
+
 func makeKeywordsGenerator(
 	_ context.Context, _ *eval.Context, _ tree.Datums,
 ) (eval.ValueGenerator, error) {
@@ -1464,6 +1518,72 @@ func (g *jsonArrayGenerator) Values() (tree.Datums, error) {
 	return g.buf[:], nil
 }
 
+// jsonPathQueryImpls returns the overloads of jsonb_path_query, with and
+// without the optional vars and silent parameters.
+func jsonPathQueryImpls() []tree.Overload {
+	overloads := make([]tree.Overload, 0, 3)
+	for numArgs := 2; numArgs <= 4; numArgs++ {
+		overloads = append(overloads, makeGeneratorOverload(
+			jsonPathParamTypes(numArgs),
+			jsonPathQueryGeneratorType,
+			makeJSONPathQueryGenerator,
+			"Returns all JSON items returned by the JSON path for the specified JSON value.",
+			volatility.Immutable,
+		))
+	}
+	return overloads
+}
+
+var jsonPathQueryGeneratorType = types.Jsonb
+
+// jsonPathQueryGenerator is a generator of the items returned by a JSON path.
+type jsonPathQueryGenerator struct {
+	items     []json.JSON
+	nextIndex int
+	buf       [1]tree.Datum
+}
+
+func makeJSONPathQueryGenerator(
+	_ context.Context, _ *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	p, target, vars, silent := jsonPathArgs(args)
+	items, err := p.Query(target, vars, silent)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonPathQueryGenerator{items: items}, nil
+}
+
+// ResolvedType implements the tree.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) ResolvedType() *types.T {
+	return jsonPathQueryGeneratorType
+}
+
+// Start implements the tree.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	g.nextIndex = -1
+	g.buf[0] = nil
+	return nil
+}
+
+// Close implements the tree.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Close(_ context.Context) {}
+
+// Next implements the tree.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Next(_ context.Context) (bool, error) {
+	g.nextIndex++
+	if g.nextIndex >= len(g.items) {
+		return false, nil
+	}
+	g.buf[0] = tree.NewDJSON(g.items[g.nextIndex])
+	return true, nil
+}
+
+// Values implements the tree.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Values() (tree.Datums, error) {
+	return g.buf[:], nil
+}
+
 // jsonObjectKeysImpl is a key generator of a JSON object.
 var jsonObjectKeysImpl = makeGeneratorOverload(
 	tree.ParamTypes{{Name: "input", Typ: types.Jsonb}},
@@ -2559,6 +2679,78 @@ func (p *payloadsForTraceGenerator) Close(_ context.Context) {
 	}
 }
 
+// foreignTableRowsGenerator is a value generator that reads the rows of the
+// file backing a foreign table. It supports crdb_internal.read_foreign_table.
+type foreignTableRowsGenerator struct {
+	planner  eval.Planner
+	tableOID oid.Oid
+
+	types  []*types.T
+	labels []string
+	// it iterates over the rows of the file.
+	it eval.InternalRows
+}
+
+var _ eval.AliasAwareValueGenerator = &foreignTableRowsGenerator{}
+
+func makeForeignTableRowsGenerator(
+	ctx context.Context, evalCtx *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	return &foreignTableRowsGenerator{
+		planner:  evalCtx.Planner,
+		tableOID: tree.MustBeDOid(args[0]).Oid,
+	}, nil
+}
+
+// SetAlias implements the eval.AliasAwareValueGenerator interface.
+func (g *foreignTableRowsGenerator) SetAlias(types []*types.T, labels []string) error {
+	if len(types) != len(labels) {
+		return errors.AssertionFailedf(
+			"unexpected mismatched types/labels list in foreign table rows generator %v %v", types, labels)
+	}
+	g.types = types
+	g.labels = labels
+	return nil
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *foreignTableRowsGenerator) ResolvedType() *types.T {
+	return types.AnyTuple
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *foreignTableRowsGenerator) Start(ctx context.Context, _ *kv.Txn) error {
+	it, err := g.planner.ReadForeignTableRows(ctx, g.tableOID, g.types, g.labels)
+	if err != nil {
+		return err
+	}
+	g.it = it
+	return nil
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *foreignTableRowsGenerator) Next(ctx context.Context) (bool, error) {
+	if g.it == nil {
+		return false, errors.AssertionFailedf("Start must be called before Next")
+	}
+	return g.it.Next(ctx)
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *foreignTableRowsGenerator) Values() (tree.Datums, error) {
+	if g.it == nil {
+		return nil, errors.AssertionFailedf("Start must be called before Values")
+	}
+	return g.it.Cur(), nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *foreignTableRowsGenerator) Close(_ context.Context) {
+	if g.it != nil {
+		_ = g.it.Close()
+	}
+}
+
 var showCreateAllSchemasGeneratorType = types.String
 var showCreateAllTypesGeneratorType = types.String
 var showCreateAllTablesGeneratorType = types.String
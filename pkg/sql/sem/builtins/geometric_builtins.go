@@ -0,0 +1,219 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+func init() {
+	for k, v := range geometricBuiltins {
+		v.props.Category = builtinconstants.CategoryGeometric
+		v.props.AvailableOnPublicSchema = true
+		registerBuiltin(k, v)
+	}
+}
+
+// geometricConstructors are the overloads of the functions named after the
+// geometric types that build a value out of several arguments. They are
+// registered along with the single-argument overloads of these functions,
+// which are casts.
+var geometricConstructors = map[oid.Oid][]tree.Overload{
+	oid.T_point: {{
+		Types:      tree.ParamTypes{{Name: "x", Typ: types.Float}, {Name: "y", Typ: types.Float}},
+		ReturnType: tree.FixedReturnType(types.Point),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return tree.NewDPoint(geometric.Point{
+				X: float64(tree.MustBeDFloat(args[0])),
+				Y: float64(tree.MustBeDFloat(args[1])),
+			}), nil
+		},
+		Info:       "Returns the point with the given coordinates.",
+		Volatility: volatility.Immutable,
+	}},
+	oid.T_box: {{
+		Types:      tree.ParamTypes{{Name: "a", Typ: types.Point}, {Name: "b", Typ: types.Point}},
+		ReturnType: tree.FixedReturnType(types.Box),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return tree.NewDBox(geometric.MakeBox(
+				tree.MustBeDPoint(args[0]).Point, tree.MustBeDPoint(args[1]).Point,
+			)), nil
+		},
+		Info:       "Returns the box having the two given points as opposite corners.",
+		Volatility: volatility.Immutable,
+	}},
+	oid.T_circle: {{
+		Types:      tree.ParamTypes{{Name: "center", Typ: types.Point}, {Name: "radius", Typ: types.Float}},
+		ReturnType: tree.FixedReturnType(types.Circle),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			radius := float64(tree.MustBeDFloat(args[1]))
+			if radius < 0 {
+				return nil, pgerror.New(pgcode.InvalidParameterValue, "radius must not be negative")
+			}
+			return tree.NewDCircle(geometric.Circle{
+				Center: tree.MustBeDPoint(args[0]).Point,
+				Radius: radius,
+			}), nil
+		},
+		Info:       "Returns the circle with the given center and radius.",
+		Volatility: volatility.Immutable,
+	}},
+	oid.T_polygon: {{
+		Types:      tree.ParamTypes{{Name: "npts", Typ: types.Int}, {Name: "circle", Typ: types.Circle}},
+		ReturnType: tree.FixedReturnType(types.Polygon),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			p, err := tree.MustBeDCircle(args[1]).Circle.ToPolygon(int(tree.MustBeDInt(args[0])))
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPolygon(p), nil
+		},
+		Info:       "Returns the polygon with `npts` vertices approximating the circle.",
+		Volatility: volatility.Immutable,
+	}},
+}
+
+func geometricProps() tree.FunctionProperties {
+	return tree.FunctionProperties{Category: builtinconstants.CategoryGeometric}
+}
+
+// makeGeometricOverload returns an overload of a geometric function taking a
+// single argument of type typ.
+func makeGeometricOverload(
+	typ, retType *types.T, info string, fn func(tree.Datum) (tree.Datum, error),
+) tree.Overload {
+	return tree.Overload{
+		Types:      tree.ParamTypes{{Name: typ.Name(), Typ: typ}},
+		ReturnType: tree.FixedReturnType(retType),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return fn(args[0])
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+func makeDFloat(f float64) (tree.Datum, error) {
+	return tree.NewDFloat(tree.DFloat(f)), nil
+}
+
+var geometricBuiltins = map[string]builtinDefinition{
+	"area": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Box, types.Float, "Returns the area of the box.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return makeDFloat(tree.MustBeDBox(d).Box.Area())
+			}),
+		makeGeometricOverload(types.Circle, types.Float, "Returns the area of the circle.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return makeDFloat(tree.MustBeDCircle(d).Circle.Area())
+			}),
+		makeGeometricOverload(types.Path, types.Float,
+			"Returns the area enclosed by a closed path, or NULL for an open path.",
+			func(d tree.Datum) (tree.Datum, error) {
+				area, ok := tree.MustBeDPath(d).Path.Area()
+				if !ok {
+					return tree.DNull, nil
+				}
+				return makeDFloat(area)
+			}),
+	),
+	"bound_box": makeBuiltin(geometricProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "a", Typ: types.Box}, {Name: "b", Typ: types.Box}},
+			ReturnType: tree.FixedReturnType(types.Box),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDBox(tree.MustBeDBox(args[0]).Box.BoundBox(tree.MustBeDBox(args[1]).Box)), nil
+			},
+			Info:       "Returns the smallest box containing both boxes.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"center": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Box, types.Point, "Returns the center of the box.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return tree.NewDPoint(tree.MustBeDBox(d).Box.Center()), nil
+			}),
+		makeGeometricOverload(types.Circle, types.Point, "Returns the center of the circle.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return tree.NewDPoint(tree.MustBeDCircle(d).Circle.Center), nil
+			}),
+	),
+	"diameter": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Circle, types.Float, "Returns the diameter of the circle.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return makeDFloat(tree.MustBeDCircle(d).Circle.Diameter())
+			}),
+	),
+	"radius": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Circle, types.Float, "Returns the radius of the circle.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return makeDFloat(tree.MustBeDCircle(d).Circle.Radius)
+			}),
+	),
+	"width": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Box, types.Float, "Returns the horizontal size of the box.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return makeDFloat(tree.MustBeDBox(d).Box.Width())
+			}),
+	),
+	"height": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Box, types.Float, "Returns the vertical size of the box.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return makeDFloat(tree.MustBeDBox(d).Box.Height())
+			}),
+	),
+	"npoints": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Path, types.Int, "Returns the number of points of the path.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return tree.NewDInt(tree.DInt(len(tree.MustBeDPath(d).Path.Points))), nil
+			}),
+		makeGeometricOverload(types.Polygon, types.Int, "Returns the number of vertices of the polygon.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return tree.NewDInt(tree.DInt(len(tree.MustBeDPolygon(d).Polygon.Points))), nil
+			}),
+	),
+	"isclosed": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Path, types.Bool, "Returns whether the path is closed.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(tree.MustBeDPath(d).Path.Closed)), nil
+			}),
+	),
+	"isopen": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Path, types.Bool, "Returns whether the path is open.",
+			func(d tree.Datum) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(!tree.MustBeDPath(d).Path.Closed)), nil
+			}),
+	),
+	"pclose": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Path, types.Path, "Converts the path to a closed path.",
+			func(d tree.Datum) (tree.Datum, error) {
+				p := tree.MustBeDPath(d).Path
+				return tree.NewDPath(geometric.Path{Points: p.Points, Closed: true}), nil
+			}),
+	),
+	"popen": makeBuiltin(geometricProps(),
+		makeGeometricOverload(types.Path, types.Path, "Converts the path to an open path.",
+			func(d tree.Datum) (tree.Datum, error) {
+				p := tree.MustBeDPath(d).Path
+				return tree.NewDPath(geometric.Path{Points: p.Points}), nil
+			}),
+	),
+}
@@ -14,6 +14,8 @@ import (
 	"context"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -35,3 +37,66 @@ func crdbInternalSendNotice(
 	)
 	return tree.NewDInt(0), nil
 }
+
+// crdbInternalPLpgSQLRaise implements the PL/pgSQL RAISE statement. A RAISE
+// with the EXCEPTION level returns an error with the given code, which defaults
+// to raise_exception. All other levels send a notice to the client. The code
+// may be either a five-character SQLSTATE code or a condition name. If the
+// message is empty, the code is used as the message. An empty severity
+// indicates a RAISE statement without parameters, which re-raises the error
+// that is being handled by the enclosing exception handler.
+func crdbInternalPLpgSQLRaise(
+	ctx context.Context, evalCtx *eval.Context, severity, message, detail, hint, code string,
+) (tree.Datum, error) {
+	if severity == "" {
+		return nil, tree.ErrRoutineReRaise
+	}
+	severity = strings.ToUpper(severity)
+	if message == "" {
+		message = code
+	}
+	var err error
+	switch severity {
+	case "EXCEPTION":
+		errCode := pgcode.RaiseException
+		if code != "" {
+			if errCode, err = resolvePLpgSQLErrorCode(code); err != nil {
+				return nil, err
+			}
+		}
+		err = pgerror.New(errCode, message)
+	case "DEBUG":
+		// DEBUG is an alias for DEBUG1 in Postgres.
+		err = pgnotice.NewWithSeverityf("DEBUG1", "%s", message)
+	default:
+		err = pgnotice.NewWithSeverityf(severity, "%s", message)
+	}
+	if detail != "" {
+		err = errors.WithDetail(err, detail)
+	}
+	if hint != "" {
+		err = errors.WithHint(err, hint)
+	}
+	if severity == "EXCEPTION" {
+		return nil, err
+	}
+	if evalCtx.ClientNoticeSender == nil {
+		return nil, errors.AssertionFailedf("notice sender not set")
+	}
+	evalCtx.ClientNoticeSender.BufferClientNotice(ctx, pgnotice.Notice(err))
+	return tree.NewDInt(0), nil
+}
+
+// resolvePLpgSQLErrorCode returns the error code specified by either a
+// five-character SQLSTATE code or a PL/pgSQL condition name.
+func resolvePLpgSQLErrorCode(code string) (pgcode.Code, error) {
+	if len(code) == 5 && strings.ToUpper(code) == code {
+		return pgcode.MakeCode(code), nil
+	}
+	if c, ok := pgcode.PLpgSQLConditionNameToCode[strings.ToLower(code)]; ok {
+		return c, nil
+	}
+	return pgcode.Code{}, pgerror.Newf(
+		pgcode.UndefinedObject, "unrecognized exception condition \"%s\"", code,
+	)
+}
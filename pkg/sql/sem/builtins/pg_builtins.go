@@ -201,6 +201,7 @@ func init() {
 		)
 	}
 	for toOID, def := range castBuiltins {
+		def.overloads = append(def.overloads, geometricConstructors[toOID]...)
 		n := cast.CastTypeName(types.OidToType[toOID])
 		CastBuiltinNames[n] = struct{}{}
 		registerBuiltin(n, *def)
@@ -842,6 +843,37 @@ var pgBuiltins = map[string]builtinDefinition{
 	// pg_my_temp_schema returns the OID of session's temporary schema, or 0 if
 	// none.
 	// https://www.postgresql.org/docs/11/functions-info.html
+	"pg_notify": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategorySystemInfo,
+			DistsqlBlocklist: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "channel", Typ: types.String},
+				{Name: "payload", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Void),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				var channel, payload string
+				if args[0] != tree.DNull {
+					channel = string(tree.MustBeDString(args[0]))
+				}
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				if err := evalCtx.SessionAccessor.SendNotification(ctx, channel, payload); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info: "Sends a notification with the given payload to the sessions listening on " +
+				"the given channel, once the current transaction commits.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+
 	"pg_my_temp_schema": makeBuiltin(
 		tree.FunctionProperties{Category: builtinconstants.CategorySystemInfo},
 		tree.Overload{
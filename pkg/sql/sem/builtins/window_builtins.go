@@ -257,6 +257,18 @@ func NewAggregateWindowFunc(
 	}
 }
 
+// NewFramableAggregateWindowFunc creates a constructor of a window function
+// that computes the aggregate created by aggConstructor over the window frame
+// of each row. The aggregate is recreated for every row if the frame requires
+// it (see ShouldReset).
+func NewFramableAggregateWindowFunc(
+	aggConstructor func(*eval.Context, tree.Datums) eval.AggregateFunc,
+) func(*eval.Context) eval.WindowFunc {
+	return func(evalCtx *eval.Context) eval.WindowFunc {
+		return newFramableAggregateWindow(aggConstructor(evalCtx, nil /* arguments */), aggConstructor)
+	}
+}
+
 func (w *aggregateWindowFunc) Compute(
 	ctx context.Context, evalCtx *eval.Context, wfr *eval.WindowFrameRun,
 ) (tree.Datum, error) {
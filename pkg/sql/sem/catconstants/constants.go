@@ -97,6 +97,8 @@ const (
 	SpanStatsBuckets                       SystemTableName = "span_stats_buckets"
 	SpanStatsSamples                       SystemTableName = "span_stats_samples"
 	SpanStatsTenantBoundaries              SystemTableName = "span_stats_tenant_boundaries"
+	NotificationsTableName                 SystemTableName = "notifications"
+	ReplicationSlotsTableName              SystemTableName = "replication_slots"
 )
 
 // Oid for virtual database and table.
@@ -142,6 +142,12 @@ func (e *evaluator) EvalCompareBox2DOp(
 	return tree.MakeDBool(tree.DBool(op.Op(left, right))), nil
 }
 
+func (e *evaluator) EvalCompareGeometricOp(
+	ctx context.Context, op *tree.CompareGeometricOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(op.Op(left, right))), nil
+}
+
 func (e *evaluator) EvalCompareScalarOp(
 	ctx context.Context, op *tree.CompareScalarOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -475,6 +481,12 @@ func (e *evaluator) EvalFloorDivIntOp(
 	return tree.NewDInt(tree.MustBeDInt(left) / rInt), nil
 }
 
+func (e *evaluator) EvalGeometricOp(
+	ctx context.Context, op *tree.GeometricOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return op.Op(left, right), nil
+}
+
 func (e *evaluator) EvalInTupleOp(
 	ctx context.Context, _ *tree.InTupleOp, arg, values tree.Datum,
 ) (tree.Datum, error) {
@@ -574,6 +586,32 @@ func (e *evaluator) EvalJSONExistsOp(
 	return tree.DBoolFalse, nil
 }
 
+func (e *evaluator) EvalJSONPathExistsOp(
+	ctx context.Context, _ *tree.JSONPathExistsOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	// The operator suppresses evaluation errors, and returns NULL instead.
+	res, ok, err := tree.MustBeDJsonpath(right).Exists(
+		left.(*tree.DJSON).JSON, nil /* vars */, true, /* silent */
+	)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
+func (e *evaluator) EvalJSONPathMatchOp(
+	ctx context.Context, _ *tree.JSONPathMatchOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	// The operator suppresses evaluation errors, and returns NULL instead.
+	res, ok, err := tree.MustBeDJsonpath(right).Match(
+		left.(*tree.DJSON).JSON, nil /* vars */, true, /* silent */
+	)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
 func (e *evaluator) EvalJSONFetchTextIntOp(
 	ctx context.Context, _ *tree.JSONFetchTextIntOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -96,7 +97,15 @@ func performCast(
 	if err != nil {
 		return nil, err
 	}
-	return tree.AdjustValueToType(t, d)
+	if d, err = tree.AdjustValueToType(t, d); err != nil {
+		return nil, err
+	}
+	if t.IsDomain() {
+		if err := CheckDomainConstraints(ctx, evalCtx, d, t); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
 }
 
 var (
@@ -489,6 +498,8 @@ func performCastWithoutPrecisionTruncation(
 			s = t.String()
 		case *tree.DJSON:
 			s = t.JSON.String()
+		case *tree.DJsonpath:
+			s = t.Jsonpath.String()
 		case *tree.DTSQuery:
 			s = t.TSQuery.String()
 		case *tree.DTSVector:
@@ -597,6 +608,107 @@ func performCastWithoutPrecisionTruncation(
 			return d, nil
 		}
 
+	case types.PointFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDPoint(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDPoint(d.Contents)
+		case *tree.DPoint:
+			return d, nil
+		case *tree.DBox:
+			return tree.NewDPoint(d.Box.Center()), nil
+		case *tree.DPolygon:
+			return tree.NewDPoint(d.Polygon.Center()), nil
+		case *tree.DCircle:
+			return tree.NewDPoint(d.Circle.Center), nil
+		case *tree.DGeometry:
+			p, err := geometric.PointFromGeometry(d.Geometry)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPoint(p), nil
+		}
+
+	case types.BoxFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDBox(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDBox(d.Contents)
+		case *tree.DBox:
+			return d, nil
+		case *tree.DPoint:
+			return tree.NewDBox(geometric.MakeBox(d.Point, d.Point)), nil
+		case *tree.DPolygon:
+			return tree.NewDBox(d.Polygon.BoundingBox()), nil
+		case *tree.DCircle:
+			return tree.NewDBox(d.Circle.ToBox()), nil
+		}
+
+	case types.PathFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDPath(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDPath(d.Contents)
+		case *tree.DPath:
+			return d, nil
+		case *tree.DPolygon:
+			return tree.NewDPath(d.Polygon.ToPath()), nil
+		case *tree.DGeometry:
+			p, err := geometric.PathFromGeometry(d.Geometry)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPath(p), nil
+		}
+
+	case types.PolygonFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDPolygon(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDPolygon(d.Contents)
+		case *tree.DPolygon:
+			return d, nil
+		case *tree.DBox:
+			return tree.NewDPolygon(d.Box.ToPolygon()), nil
+		case *tree.DPath:
+			p, err := d.Path.ToPolygon()
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPolygon(p), nil
+		case *tree.DCircle:
+			// As in Postgres, the cast approximates the circle with 12 points.
+			p, err := d.Circle.ToPolygon(12 /* npts */)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPolygon(p), nil
+		case *tree.DGeometry:
+			p, err := geometric.PolygonFromGeometry(d.Geometry)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDPolygon(p), nil
+		}
+
+	case types.CircleFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			return tree.ParseDCircle(string(*d))
+		case *tree.DCollatedString:
+			return tree.ParseDCircle(d.Contents)
+		case *tree.DCircle:
+			return d, nil
+		case *tree.DBox:
+			return tree.NewDCircle(d.Box.ToCircle()), nil
+		case *tree.DPolygon:
+			return tree.NewDCircle(d.Polygon.ToCircle()), nil
+		}
+
 	case types.GeographyFamily:
 		switch d := d.(type) {
 		case *tree.DString:
@@ -692,6 +804,24 @@ func performCastWithoutPrecisionTruncation(
 				return nil, err
 			}
 			return &tree.DGeometry{Geometry: g}, nil
+		case *tree.DPoint:
+			g, err := d.Point.ToGeometry()
+			if err != nil {
+				return nil, err
+			}
+			return &tree.DGeometry{Geometry: g}, nil
+		case *tree.DPath:
+			g, err := d.Path.ToGeometry()
+			if err != nil {
+				return nil, err
+			}
+			return &tree.DGeometry{Geometry: g}, nil
+		case *tree.DPolygon:
+			g, err := d.Polygon.ToGeometry()
+			if err != nil {
+				return nil, err
+			}
+			return &tree.DGeometry{Geometry: g}, nil
 		case *tree.DBytes:
 			g, err := geo.ParseGeometryFromEWKB(geopb.EWKB(*d))
 			if err != nil {
@@ -876,6 +1006,16 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return tree.ParseDJSON(string(j))
 		}
+	case types.JsonpathFamily:
+		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_2) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use jsonpath",
+				clusterversion.ByKey(clusterversion.V23_2))
+		}
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDJsonpath(string(*v))
+		}
 	case types.TSQueryFamily:
 		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_1) {
 			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
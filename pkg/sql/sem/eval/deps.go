@@ -295,6 +295,17 @@ type Planner interface {
 	// ExternalReadFile reads the content from an external file URI.
 	ExternalReadFile(ctx context.Context, uri string) ([]byte, error)
 
+	// ReadForeignTableRows reads the rows of the file backing the foreign table
+	// with the given OID. The rows are decoded into datums of the given types,
+	// with the given column names used to match columns in self-describing
+	// formats.
+	ReadForeignTableRows(
+		ctx context.Context,
+		tableOID oid.Oid,
+		typs []*types.T,
+		colNames []string,
+	) (InternalRows, error)
+
 	// ExternalWriteFile writes the content to an external file URI.
 	ExternalWriteFile(ctx context.Context, uri string, content []byte) error
 
@@ -476,6 +487,14 @@ type SessionAccessor interface {
 	// HasViewActivityOrViewActivityRedactedRole returns true iff the current session user has the
 	// VIEWACTIVITY or VIEWACTIVITYREDACTED permission.
 	HasViewActivityOrViewActivityRedactedRole(ctx context.Context) (bool, error)
+
+	// SendNotification queues a notification on the given channel. It is delivered to
+	// the listening sessions when the current transaction commits.
+	SendNotification(ctx context.Context, channel, payload string) error
+
+	// ListeningChannels returns the channels on which the session is
+	// listening.
+	ListeningChannels() ([]string, error)
 }
 
 // PreparedStatementState is a limited interface that exposes metadata about
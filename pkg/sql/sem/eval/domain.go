@@ -0,0 +1,129 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// domainValueName is the name by which the CHECK constraints of a domain refer
+// to the value being checked.
+const domainValueName = "value"
+
+// ReplaceDomainValue returns a copy of the given domain CHECK constraint
+// expression, in which the references to VALUE are replaced with the given
+// expression.
+func ReplaceDomainValue(expr tree.Expr, value tree.Expr) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		if n, ok := expr.(*tree.UnresolvedName); ok && n.NumParts == 1 && !n.Star &&
+			n.Parts[0] == domainValueName {
+			return false, value, nil
+		}
+		return true, expr, nil
+	})
+}
+
+// domainValue is the IndexedVarContainer through which the CHECK constraint
+// expressions of a domain refer to the value being checked.
+type domainValue struct {
+	typ *types.T
+	d   tree.Datum
+}
+
+var _ IndexedVarContainer = &domainValue{}
+
+// IndexedVarEval is part of the IndexedVarContainer interface.
+func (v *domainValue) IndexedVarEval(
+	ctx context.Context, idx int, e tree.ExprEvaluator,
+) (tree.Datum, error) {
+	return v.d, nil
+}
+
+// IndexedVarResolvedType is part of the tree.IndexedVarContainer interface.
+func (v *domainValue) IndexedVarResolvedType(idx int) *types.T {
+	return v.typ
+}
+
+// IndexedVarNodeFormatter is part of the tree.IndexedVarContainer interface.
+func (v *domainValue) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	n := tree.Name(domainValueName)
+	return &n
+}
+
+// TypeCheckDomainChecks parses and type-checks the serialized CHECK constraint
+// expressions of a domain with the given base type. VALUE is replaced with a
+// reference to the value being checked, which CheckDomainConstraints binds
+// when it evaluates the returned expressions. Like when the domain is created,
+// the expressions are type-checked without any schema resolution.
+func TypeCheckDomainChecks(
+	ctx context.Context, baseType *types.T, checkExprs []string,
+) ([]tree.TypedExpr, error) {
+	semaCtx := tree.MakeSemaContext()
+	semaCtx.IVarContainer = &domainValue{typ: baseType}
+	typedExprs := make([]tree.TypedExpr, len(checkExprs))
+	for i, checkExpr := range checkExprs {
+		expr, err := parser.ParseExpr(checkExpr)
+		if err != nil {
+			return nil, err
+		}
+		if expr, err = ReplaceDomainValue(expr, tree.NewOrdinalReference(0)); err != nil {
+			return nil, err
+		}
+		if typedExprs[i], err = tree.TypeCheck(ctx, expr, &semaCtx, types.Bool); err != nil {
+			return nil, err
+		}
+	}
+	return typedExprs, nil
+}
+
+// CheckDomainConstraints returns an error if the given datum, which must be
+// of the base type of the domain type t, violates the NOT NULL or CHECK
+// constraints of the domain.
+func CheckDomainConstraints(
+	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T,
+) error {
+	data := t.TypeMeta.DomainData
+	if data == nil {
+		return errors.AssertionFailedf("domain type %s is not hydrated", t.SQLString())
+	}
+	if d == tree.DNull && data.NotNull {
+		return pgerror.Newf(pgcode.NotNullViolation,
+			"domain %s does not allow null values", t.Name())
+	}
+	if len(data.CheckExprs) == 0 {
+		return nil
+	}
+	if len(data.TypedCheckExprs) != len(data.CheckExprs) {
+		return errors.AssertionFailedf("checks of domain type %s are not type-checked", t.SQLString())
+	}
+	evalCtx.PushIVarContainer(&domainValue{typ: t.DomainBaseType(), d: d})
+	defer evalCtx.PopIVarContainer()
+	for i, typedExpr := range data.TypedCheckExprs {
+		res, err := Expr(ctx, evalCtx, typedExpr.(tree.TypedExpr))
+		if err != nil {
+			return err
+		}
+		// Like table CHECK constraints, a check that evaluates to NULL passes.
+		if res == tree.DBoolFalse {
+			return pgerror.WithConstraintName(pgerror.Newf(pgcode.CheckViolation,
+				"value for domain %s violates check constraint %q", t.Name(), data.CheckNames[i],
+			), data.CheckNames[i])
+		}
+	}
+	return nil
+}
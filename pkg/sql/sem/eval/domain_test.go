@@ -0,0 +1,82 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDomainConstraints(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	defer evalCtx.Stop(ctx)
+
+	checkExprs := []string{"VALUE > 0", "VALUE < 100"}
+	typedExprs, err := eval.TypeCheckDomainChecks(ctx, types.Int, checkExprs)
+	require.NoError(t, err)
+	typ := types.MakeDomain(types.Int, 100100)
+	typ.TypeMeta = types.UserDefinedTypeMetadata{
+		Name: &types.UserDefinedTypeName{Name: "d"},
+		DomainData: &types.DomainMetadata{
+			NotNull:         true,
+			CheckNames:      []string{"positive", "small"},
+			CheckExprs:      checkExprs,
+			TypedCheckExprs: []interface{}{typedExprs[0], typedExprs[1]},
+		},
+	}
+
+	for _, tc := range []struct {
+		d          tree.Datum
+		code       pgcode.Code
+		constraint string
+	}{
+		{d: tree.NewDInt(1)},
+		{d: tree.NewDInt(99)},
+		{d: tree.NewDInt(0), code: pgcode.CheckViolation, constraint: "positive"},
+		{d: tree.NewDInt(100), code: pgcode.CheckViolation, constraint: "small"},
+		{d: tree.DNull, code: pgcode.NotNullViolation},
+	} {
+		err := eval.CheckDomainConstraints(ctx, evalCtx, tc.d, typ)
+		if tc.code == (pgcode.Code{}) {
+			require.NoError(t, err)
+			continue
+		}
+		require.Error(t, err)
+		require.Equal(t, tc.code, pgerror.GetPGCode(err))
+		if tc.constraint != "" {
+			require.Contains(t, err.Error(), tc.constraint)
+		}
+	}
+	// The value of the domain is only bound while its checks are evaluated.
+	require.Nil(t, evalCtx.IVarContainer)
+
+	// Checks which evaluate to NULL pass.
+	typ.TypeMeta.DomainData.NotNull = false
+	require.NoError(t, eval.CheckDomainConstraints(ctx, evalCtx, tree.DNull, typ))
+
+	// Checks which don't type-check as booleans are rejected.
+	_, err = eval.TypeCheckDomainChecks(ctx, types.Int, []string{"VALUE + 1"})
+	require.Error(t, err)
+}
@@ -182,8 +182,9 @@ func (e *evaluator) EvalCastExpr(ctx context.Context, expr *tree.CastExpr) (tree
 		return nil, err
 	}
 
-	// NULL cast to anything is NULL.
-	if d == tree.DNull {
+	// NULL cast to anything is NULL, unless the target is a domain which may
+	// reject it.
+	if d == tree.DNull && !expr.ResolvedType().IsDomain() {
 		return d, nil
 	}
 	d = UnwrapDatum(ctx, e.ctx(), d)
@@ -10,12 +10,75 @@
 
 package plpgsqltree
 
-import "github.com/cockroachdb/errors"
+import (
+	"strings"
 
-// PLpgSQLRaiseOptionType represents the severity of the error in
-// a raise statement.
+	"github.com/cockroachdb/errors"
+)
+
+// PLpgSQLRaiseOptionType represents the type of an option in the USING
+// clause of a RAISE statement.
 type PLpgSQLRaiseOptionType int
 
+const (
+	// PLpgSQLRaiseOptionMessage sets the error or notice message.
+	PLpgSQLRaiseOptionMessage PLpgSQLRaiseOptionType = iota
+	// PLpgSQLRaiseOptionDetail sets the detail message.
+	PLpgSQLRaiseOptionDetail
+	// PLpgSQLRaiseOptionHint sets the hint message.
+	PLpgSQLRaiseOptionHint
+	// PLpgSQLRaiseOptionErrCode sets the error code, either as a condition name
+	// or as a five-character SQLSTATE code.
+	PLpgSQLRaiseOptionErrCode
+	// PLpgSQLRaiseOptionColumn sets the name of the related column.
+	PLpgSQLRaiseOptionColumn
+	// PLpgSQLRaiseOptionConstraint sets the name of the related constraint.
+	PLpgSQLRaiseOptionConstraint
+	// PLpgSQLRaiseOptionDatatype sets the name of the related data type.
+	PLpgSQLRaiseOptionDatatype
+	// PLpgSQLRaiseOptionTable sets the name of the related table.
+	PLpgSQLRaiseOptionTable
+	// PLpgSQLRaiseOptionSchema sets the name of the related schema.
+	PLpgSQLRaiseOptionSchema
+)
+
+// String implements the fmt.Stringer interface.
+func (t PLpgSQLRaiseOptionType) String() string {
+	switch t {
+	case PLpgSQLRaiseOptionMessage:
+		return "MESSAGE"
+	case PLpgSQLRaiseOptionDetail:
+		return "DETAIL"
+	case PLpgSQLRaiseOptionHint:
+		return "HINT"
+	case PLpgSQLRaiseOptionErrCode:
+		return "ERRCODE"
+	case PLpgSQLRaiseOptionColumn:
+		return "COLUMN"
+	case PLpgSQLRaiseOptionConstraint:
+		return "CONSTRAINT"
+	case PLpgSQLRaiseOptionDatatype:
+		return "DATATYPE"
+	case PLpgSQLRaiseOptionTable:
+		return "TABLE"
+	case PLpgSQLRaiseOptionSchema:
+		return "SCHEMA"
+	}
+	panic(errors.AssertionFailedf("unknown RAISE option type %d", t))
+}
+
+// PLpgSQLRaiseOptionTypeFromString returns the PLpgSQLRaiseOptionType with the
+// given name, which is not case-sensitive. It returns false if there is no
+// such option.
+func PLpgSQLRaiseOptionTypeFromString(name string) (PLpgSQLRaiseOptionType, bool) {
+	for t := PLpgSQLRaiseOptionMessage; t <= PLpgSQLRaiseOptionSchema; t++ {
+		if strings.EqualFold(name, t.String()) {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
 // PLpgSQLGetDiagKind represents the type of error diagnostic
 // item in stmt_getdiag.
 type PLpgSQLGetDiagKind int
@@ -87,10 +150,6 @@ func (k PLpgSQLGetDiagKind) String() string {
 
 }
 
-// PLpgSQLFetchDirection represents the direction clause passed into a
-// fetch statement.
-type PLpgSQLFetchDirection int
-
 // PLpgSQLCursorOpt represents a cursor option, which describes
 // how a cursor will behave.
 type PLpgSQLCursorOpt uint32
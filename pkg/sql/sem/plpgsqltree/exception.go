@@ -10,20 +10,57 @@
 
 package plpgsqltree
 
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+
+// PLpgSQLExceptionBlock represents the EXCEPTION section of a block.
 type PLpgSQLExceptionBlock struct {
 	SqlStateVarNo int
 	SqlErrmNo     int
 	ExecList      []*PLpgSQLException
 }
 
+func (s *PLpgSQLExceptionBlock) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("EXCEPTION\n")
+	for _, e := range s.ExecList {
+		e.Format(ctx)
+	}
+}
+
+// PLpgSQLException represents a single WHEN clause of an EXCEPTION section.
+// The statements in Action are executed if the error being handled matches
+// any of the conditions.
 type PLpgSQLException struct {
-	LineNo    int
-	Condition *PLpgSQLCondition
-	Action    []PLpgSQLStatement
+	LineNo     int
+	Conditions []PLpgSQLCondition
+	Action     []PLpgSQLStatement
+}
+
+func (s *PLpgSQLException) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("WHEN ")
+	for i, cond := range s.Conditions {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		if cond.SqlErrState != "" {
+			ctx.WriteString("SQLSTATE ")
+			formatStringQuotes(ctx, cond.SqlErrState)
+		} else {
+			ctx.WriteString(cond.Name)
+		}
+	}
+	ctx.WriteString(" THEN\n")
+	for _, stmt := range s.Action {
+		ctx.WriteString("\t")
+		stmt.Format(ctx)
+	}
 }
 
+// PLpgSQLCondition represents a condition in the WHEN clause of an exception
+// handler. Exactly one of SqlErrState and Name is set.
 type PLpgSQLCondition struct {
-	SqlErrState int
-	Name        string // TODO postgres says it's helpful for debugging.
-	Next        *PLpgSQLCondition
+	// SqlErrState is the five-character SQLSTATE code given with the SQLSTATE
+	// keyword.
+	SqlErrState string
+	// Name is the condition name, e.g. division_by_zero or OTHERS.
+	Name string
 }
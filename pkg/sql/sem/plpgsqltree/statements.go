@@ -60,10 +60,15 @@ type PLpgSQLStmtBlock struct {
 	Body       []PLpgSQLStatement
 	Exceptions *PLpgSQLExceptionBlock
 	Scope      VariableScope
+	// Nested is true if the block is a statement within another block, in
+	// which case it is terminated by a semicolon.
+	Nested bool
 }
 
-// TODO(drewk): format Label and Exceptions fields.
 func (s *PLpgSQLStmtBlock) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString(fmt.Sprintf("<<%s>>\n", s.Label))
+	}
 	if s.Decls != nil {
 		ctx.WriteString("DECLARE\n")
 		for _, dec := range s.Decls {
@@ -76,7 +81,17 @@ func (s *PLpgSQLStmtBlock) Format(ctx *tree.FmtCtx) {
 	for _, childStmt := range s.Body {
 		childStmt.Format(ctx)
 	}
-	ctx.WriteString("END\n")
+	if s.Exceptions != nil {
+		s.Exceptions.Format(ctx)
+	}
+	ctx.WriteString("END")
+	if s.Label != "" {
+		ctx.WriteString(fmt.Sprintf(" %s", s.Label))
+	}
+	if s.Nested {
+		ctx.WriteString(";")
+	}
+	ctx.WriteString("\n")
 }
 
 func (s *PLpgSQLStmtBlock) PlpgSQLStatementTag() string {
@@ -88,6 +103,13 @@ func (s *PLpgSQLStmtBlock) WalkStmt(visitor PLpgSQLStmtVisitor) {
 	for _, stmt := range s.Body {
 		stmt.WalkStmt(visitor)
 	}
+	if s.Exceptions != nil {
+		for _, e := range s.Exceptions.ExecList {
+			for _, stmt := range e.Action {
+				stmt.WalkStmt(visitor)
+			}
+		}
+	}
 }
 
 // decl_stmt
@@ -99,10 +121,27 @@ type PLpgSQLDecl struct {
 	Collate  string
 	NotNull  bool
 	Expr     PLpgSQLExpr
+	// CursorQuery is the query bound to a cursor declared with CURSOR FOR. It
+	// is nil for other declarations, in which case Typ is set instead.
+	CursorQuery tree.Statement
+	// CursorOptions holds the bitmask of the SCROLL options of a cursor
+	// declaration.
+	CursorOptions uint32
 }
 
 func (s *PLpgSQLDecl) Format(ctx *tree.FmtCtx) {
 	ctx.WriteString(string(s.Var))
+	if s.CursorQuery != nil {
+		for _, opt := range OptListFromBitField(s.CursorOptions) {
+			if opt.String() != "" {
+				ctx.WriteString(fmt.Sprintf(" %s", opt.String()))
+			}
+		}
+		ctx.WriteString(" CURSOR FOR ")
+		ctx.FormatNode(s.CursorQuery)
+		ctx.WriteString(";\n")
+		return
+	}
 	if s.Constant {
 		ctx.WriteString(" CONSTANT")
 	}
@@ -347,6 +386,13 @@ type PLpgSQLStmtWhileLoop struct {
 }
 
 func (s *PLpgSQLStmtWhileLoop) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString(fmt.Sprintf("<<%s>>\n", s.Label))
+	}
+	ctx.WriteString("WHILE ")
+	s.Condition.Format(ctx)
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *PLpgSQLStmtWhileLoop) PlpgSQLStatementTag() string {
@@ -368,11 +414,29 @@ type PLpgSQLStmtForIntLoop struct {
 	Lower   PLpgSQLExpr
 	Upper   PLpgSQLExpr
 	Step    PLpgSQLExpr
-	Reverse int
+	Reverse bool
 	Body    []PLpgSQLStatement
 }
 
 func (s *PLpgSQLStmtForIntLoop) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString(fmt.Sprintf("<<%s>>\n", s.Label))
+	}
+	ctx.WriteString("FOR ")
+	s.Var.Format(ctx)
+	ctx.WriteString(" IN ")
+	if s.Reverse {
+		ctx.WriteString("REVERSE ")
+	}
+	s.Lower.Format(ctx)
+	ctx.WriteString("..")
+	s.Upper.Format(ctx)
+	if s.Step != nil {
+		ctx.WriteString(" BY ")
+		s.Step.Format(ctx)
+	}
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *PLpgSQLStmtForIntLoop) PlpgSQLStatementTag() string {
@@ -394,6 +458,7 @@ type PLpgSQLStmtForQueryLoop struct {
 }
 
 func (s *PLpgSQLStmtForQueryLoop) Format(ctx *tree.FmtCtx) {
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *PLpgSQLStmtForQueryLoop) PlpgSQLStatementTag() string {
@@ -409,10 +474,19 @@ func (s *PLpgSQLStmtForQueryLoop) WalkStmt(visitor PLpgSQLStmtVisitor) {
 
 type PLpgSQLStmtForQuerySelectLoop struct {
 	PLpgSQLStmtForQueryLoop
-	Query PLpgSQLExpr
+	Query tree.Statement
 }
 
 func (s *PLpgSQLStmtForQuerySelectLoop) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString(fmt.Sprintf("<<%s>>\n", s.Label))
+	}
+	ctx.WriteString("FOR ")
+	s.Var.Format(ctx)
+	ctx.WriteString(" IN ")
+	ctx.FormatNode(s.Query)
+	ctx.WriteString(" LOOP\n")
+	s.PLpgSQLStmtForQueryLoop.Format(ctx)
 }
 
 func (s *PLpgSQLStmtForQuerySelectLoop) PlpgSQLStatementTag() string {
@@ -426,11 +500,19 @@ func (s *PLpgSQLStmtForQuerySelectLoop) WalkStmt(visitor PLpgSQLStmtVisitor) {
 
 type PLpgSQLStmtForQueryCursorLoop struct {
 	PLpgSQLStmtForQueryLoop
-	CurVar   int // TODO(drewk): is this CursorVariable?
-	ArgQuery PLpgSQLExpr
+	CurVar     int // TODO(drewk): is this CursorVariable?
+	CursorName string
+	ArgQuery   PLpgSQLExpr
 }
 
 func (s *PLpgSQLStmtForQueryCursorLoop) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString(fmt.Sprintf("<<%s>>\n", s.Label))
+	}
+	ctx.WriteString("FOR ")
+	s.Var.Format(ctx)
+	ctx.WriteString(fmt.Sprintf(" IN %s LOOP\n", s.CursorName))
+	s.PLpgSQLStmtForQueryLoop.Format(ctx)
 }
 
 func (s *PLpgSQLStmtForQueryCursorLoop) PlpgSQLStatementTag() string {
@@ -464,13 +546,28 @@ func (s *PLpgSQLStmtForDynamicLoop) WalkStmt(visitor PLpgSQLStmtVisitor) {
 type PLpgSQLStmtForEachALoop struct {
 	PLpgSQLStatementImpl
 	Label string
-	Var   *PLpgSQLVariable
-	Slice int // TODO(drewk): not sure what this is
+	Var   PLpgSQLVariable
+	// Slice is the number of array dimensions to iterate over at a time. It is
+	// zero if SLICE was not specified, in which case each element of the array
+	// is assigned to Var in turn.
+	Slice int
 	Expr  PLpgSQLExpr
 	Body  []PLpgSQLStatement
 }
 
 func (s *PLpgSQLStmtForEachALoop) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString(fmt.Sprintf("<<%s>>\n", s.Label))
+	}
+	ctx.WriteString("FOREACH ")
+	s.Var.Format(ctx)
+	if s.Slice != 0 {
+		ctx.WriteString(fmt.Sprintf(" SLICE %d", s.Slice))
+	}
+	ctx.WriteString(" IN ARRAY ")
+	s.Expr.Format(ctx)
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *PLpgSQLStmtForEachALoop) PlpgSQLStatementTag() string {
@@ -548,7 +645,12 @@ type PLpgSQLStmtReturn struct {
 }
 
 func (s *PLpgSQLStmtReturn) Format(ctx *tree.FmtCtx) {
-	ctx.WriteString("RETURN ")
+	ctx.WriteString("RETURN")
+	if s.Expr == nil && s.RetVar == "" {
+		ctx.WriteString(";\n")
+		return
+	}
+	ctx.WriteString(" ")
 	if s.Expr == nil {
 		s.RetVar.Format(ctx)
 	} else {
@@ -572,6 +674,9 @@ type PLpgSQLStmtReturnNext struct {
 }
 
 func (s *PLpgSQLStmtReturnNext) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN NEXT ")
+	s.Expr.Format(ctx)
+	ctx.WriteString(";\n")
 }
 
 func (s *PLpgSQLStmtReturnNext) PlpgSQLStatementTag() string {
@@ -584,12 +689,15 @@ func (s *PLpgSQLStmtReturnNext) WalkStmt(visitor PLpgSQLStmtVisitor) {
 
 type PLpgSQLStmtReturnQuery struct {
 	PLpgSQLStatementImpl
-	Query        PLpgSQLExpr
+	Query        tree.Statement
 	DynamicQuery PLpgSQLExpr
 	Params       []PLpgSQLExpr
 }
 
 func (s *PLpgSQLStmtReturnQuery) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN QUERY ")
+	ctx.FormatNode(s.Query)
+	ctx.WriteString(";\n")
 }
 
 func (s *PLpgSQLStmtReturnQuery) PlpgSQLStatementTag() string {
@@ -603,22 +711,64 @@ func (s *PLpgSQLStmtReturnQuery) WalkStmt(visitor PLpgSQLStmtVisitor) {
 // stmt_raise
 type PLpgSQLStmtRaise struct {
 	PLpgSQLStatementImpl
-	LogLevel int
+	// LogLevel is the severity of the RAISE, e.g. NOTICE or EXCEPTION. It is
+	// empty for a RAISE statement without parameters, which re-raises the error
+	// currently being handled.
+	LogLevel string
+	// CodeName is the condition name given in place of a format string, if
+	// any.
 	CodeName string
-	Message  string
-	Params   []PLpgSQLExpr
-	Options  []PLpgSQLStmtRaiseOption
+	// Code is the SQLSTATE given in place of a format string, if any.
+	Code    string
+	Message string
+	Params  []PLpgSQLExpr
+	Options []PLpgSQLStmtRaiseOption
 }
 
 func (s *PLpgSQLStmtRaise) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RAISE")
+	if s.LogLevel != "" {
+		ctx.WriteString(" ")
+		ctx.WriteString(s.LogLevel)
+	}
+	if s.CodeName != "" {
+		ctx.WriteString(" ")
+		ctx.WriteString(s.CodeName)
+	}
+	if s.Code != "" {
+		ctx.WriteString(" SQLSTATE ")
+		formatStringQuotes(ctx, s.Code)
+	}
+	if s.Message != "" {
+		ctx.WriteString(" ")
+		formatStringQuotes(ctx, s.Message)
+		for i := range s.Params {
+			ctx.WriteString(", ")
+			s.Params[i].Format(ctx)
+		}
+	}
+	for i := range s.Options {
+		if i == 0 {
+			ctx.WriteString(" USING ")
+		} else {
+			ctx.WriteString(", ")
+		}
+		s.Options[i].Format(ctx)
+	}
+	ctx.WriteString(";\n")
 }
 
+// PLpgSQLStmtRaiseOption is an option in the USING clause of a RAISE
+// statement.
 type PLpgSQLStmtRaiseOption struct {
 	OptType PLpgSQLRaiseOptionType
 	Expr    PLpgSQLExpr
 }
 
 func (s *PLpgSQLStmtRaiseOption) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString(s.OptType.String())
+	ctx.WriteString(" = ")
+	s.Expr.Format(ctx)
 }
 
 func (s *PLpgSQLStmtRaise) PlpgSQLStatementTag() string {
@@ -806,8 +956,9 @@ type PLpgSQLStmtOpen struct {
 	WithExplicitExpr bool
 	// TODO(jane): Should be PLpgSQLExpr
 	ArgQuery string
-	// TODO(jane): Should be PLpgSQLExpr
-	Query string
+	// Query is the query of an OPEN FOR statement. It is nil when opening a
+	// bound cursor, or when the query is dynamic.
+	Query tree.Statement
 	// TODO(jane): Should be PLpgSQLExpr
 	DynamicQuery string
 	// TODO(jane): Should be []PLpgSQLExpr
@@ -815,6 +966,11 @@ type PLpgSQLStmtOpen struct {
 }
 
 func (s *PLpgSQLStmtOpen) Format(ctx *tree.FmtCtx) {
+	if s.Query == nil && s.DynamicQuery == "" && !s.WithExplicitExpr {
+		// This statement opens a bound cursor.
+		ctx.WriteString(fmt.Sprintf("OPEN %s;\n", s.CursorName))
+		return
+	}
 	ctx.WriteString(
 		fmt.Sprintf(
 			"OPEN %s ",
@@ -837,12 +993,12 @@ func (s *PLpgSQLStmtOpen) Format(ctx *tree.FmtCtx) {
 				ctx.WriteString(fmt.Sprintf("USING %s", s.Params))
 			}
 		} else {
-			ctx.WriteString(s.Query)
+			ctx.FormatNode(s.Query)
 		}
 	} else {
 		ctx.WriteString(s.ArgQuery)
 	}
-	ctx.WriteString("\n")
+	ctx.WriteString(";\n")
 }
 
 func (s *PLpgSQLStmtOpen) PlpgSQLStatementTag() string {
@@ -857,16 +1013,30 @@ func (s *PLpgSQLStmtOpen) WalkStmt(visitor PLpgSQLStmtVisitor) {
 // stmt_move (where IsMove = true)
 type PLpgSQLStmtFetch struct {
 	PLpgSQLStatementImpl
-	Target           PLpgSQLVariable
-	CurVar           int // TODO(drewk): this could just a PLpgSQLVariable
-	Direction        PLpgSQLFetchDirection
-	HowMany          int64
-	Expr             PLpgSQLExpr
-	IsMove           bool
-	ReturnsMultiRows bool
+	Target []PLpgSQLVariable
+	// Cursor holds the name of the cursor along with the direction and count
+	// of the FETCH or MOVE.
+	Cursor tree.CursorStmt
+	IsMove bool
 }
 
 func (s *PLpgSQLStmtFetch) Format(ctx *tree.FmtCtx) {
+	if s.IsMove {
+		ctx.WriteString("MOVE ")
+	} else {
+		ctx.WriteString("FETCH ")
+	}
+	s.Cursor.Format(ctx)
+	if len(s.Target) > 0 {
+		ctx.WriteString(" INTO ")
+		for i := range s.Target {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			s.Target[i].Format(ctx)
+		}
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *PLpgSQLStmtFetch) PlpgSQLStatementTag() string {
@@ -883,13 +1053,12 @@ func (s *PLpgSQLStmtFetch) WalkStmt(visitor PLpgSQLStmtVisitor) {
 // stmt_close
 type PLpgSQLStmtClose struct {
 	PLpgSQLStatementImpl
-	CurVar int // TODO(drewk): this could just a PLpgSQLVariable
+	CurVar     int // TODO(drewk): this could just a PLpgSQLVariable
+	CursorName string
 }
 
 func (s *PLpgSQLStmtClose) Format(ctx *tree.FmtCtx) {
-	// TODO(drewk): Pretty- Print the cursor identifier
-	ctx.WriteString("CLOSE a cursor\n")
-
+	ctx.WriteString(fmt.Sprintf("CLOSE %s;\n", s.CursorName))
 }
 
 func (s *PLpgSQLStmtClose) PlpgSQLStatementTag() string {
@@ -940,7 +1109,7 @@ type PLpgSQLStmtNull struct {
 }
 
 func (s *PLpgSQLStmtNull) Format(ctx *tree.FmtCtx) {
-	ctx.WriteString("NULL\n")
+	ctx.WriteString("NULL;\n")
 }
 
 func (s *PLpgSQLStmtNull) PlpgSQLStatementTag() string {
@@ -950,3 +1119,21 @@ func (s *PLpgSQLStmtNull) PlpgSQLStatementTag() string {
 func (s *PLpgSQLStmtNull) WalkStmt(visitor PLpgSQLStmtVisitor) {
 	visitor.Visit(s)
 }
+
+// formatLoopBody formats the statements in the body of a loop, along with the
+// END LOOP clause.
+func formatLoopBody(ctx *tree.FmtCtx, label string, body []PLpgSQLStatement) {
+	for _, stmt := range body {
+		stmt.Format(ctx)
+	}
+	ctx.WriteString("END LOOP")
+	if label != "" {
+		ctx.WriteString(fmt.Sprintf(" %s", label))
+	}
+	ctx.WriteString(";\n")
+}
+
+// formatStringQuotes formats the given string as a SQL string literal.
+func formatStringQuotes(ctx *tree.FmtCtx, s string) {
+	ctx.FormatNode(tree.NewStrVal(s))
+}
@@ -52,6 +52,9 @@ func (v *isAggregateVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tre
 			v.Aggregated = true
 			return false, expr
 		}
+	case *tree.GroupingFuncExpr:
+		v.Aggregated = true
+		return false, expr
 	case *tree.Subquery:
 		return false, expr
 	}
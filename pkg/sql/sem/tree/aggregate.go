@@ -0,0 +1,163 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Replace bool
+	Name    FunctionName
+	// Params are the input parameters of the aggregate. They are empty for an
+	// aggregate declared with (*), which takes no arguments.
+	Params  FuncParams
+	Options *AggregateOptions
+}
+
+var _ Statement = &CreateAggregate{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.Name)
+	if len(node.Params) == 0 {
+		ctx.WriteString("(*)")
+	} else {
+		ctx.WriteString("(")
+		ctx.FormatNode(node.Params)
+		ctx.WriteString(")")
+	}
+	ctx.WriteString(" (")
+	ctx.FormatNode(node.Options)
+	ctx.WriteString(")")
+}
+
+// AggregateOptions contains the options of a CREATE AGGREGATE statement.
+type AggregateOptions struct {
+	// TransitionFunc is the state transition function (SFUNC). It is called
+	// with the current state and the arguments of the aggregate for each input
+	// row, and returns the new state.
+	TransitionFunc *FunctionName
+	// StateType is the type of the state of the aggregate (STYPE).
+	StateType ResolvableTypeReference
+	// FinalFunc is the function called with the final state to compute the
+	// result of the aggregate (FINALFUNC). If it is not set, the final state
+	// is the result.
+	FinalFunc *FunctionName
+	// CombineFunc is the function that combines two partial states
+	// (COMBINEFUNC). It is validated and recorded, but not used to compute
+	// the aggregate.
+	CombineFunc *FunctionName
+	// InitCond is the string representation of the initial state (INITCOND).
+	// If it is not set, the initial state is NULL.
+	InitCond *string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOptions) Format(ctx *FmtCtx) {
+	ctx.WriteString("SFUNC = ")
+	ctx.FormatNode(node.TransitionFunc)
+	ctx.WriteString(", STYPE = ")
+	ctx.FormatTypeReference(node.StateType)
+	if node.FinalFunc != nil {
+		ctx.WriteString(", FINALFUNC = ")
+		ctx.FormatNode(node.FinalFunc)
+	}
+	if node.CombineFunc != nil {
+		ctx.WriteString(", COMBINEFUNC = ")
+		ctx.FormatNode(node.CombineFunc)
+	}
+	if node.InitCond != nil {
+		ctx.WriteString(", INITCOND = ")
+		ctx.FormatNode(NewStrVal(*node.InitCond))
+	}
+}
+
+// CombineWith merges other options into node. An error is returned if the same
+// option is specified in both.
+func (node *AggregateOptions) CombineWith(other *AggregateOptions) error {
+	if node.TransitionFunc == nil {
+		node.TransitionFunc = other.TransitionFunc
+	} else if other.TransitionFunc != nil {
+		return errors.New("sfunc specified multiple times")
+	}
+
+	if node.StateType == nil {
+		node.StateType = other.StateType
+	} else if other.StateType != nil {
+		return errors.New("stype specified multiple times")
+	}
+
+	if node.FinalFunc == nil {
+		node.FinalFunc = other.FinalFunc
+	} else if other.FinalFunc != nil {
+		return errors.New("finalfunc specified multiple times")
+	}
+
+	if node.CombineFunc == nil {
+		node.CombineFunc = other.CombineFunc
+	} else if other.CombineFunc != nil {
+		return errors.New("combinefunc specified multiple times")
+	}
+
+	if node.InitCond == nil {
+		node.InitCond = other.InitCond
+	} else if other.InitCond != nil {
+		return errors.New("initcond specified multiple times")
+	}
+	return nil
+}
+
+// UserDefinedAggregate describes the support functions of an aggregate created
+// with CREATE AGGREGATE. It is set on the aggregate's Overload.
+type UserDefinedAggregate struct {
+	// TransitionFunc is the OID of the state transition function.
+	TransitionFunc oid.Oid
+	// FinalFunc is the OID of the final function, or zero if there is none.
+	FinalFunc oid.Oid
+	// StateType is the type of the aggregate's state.
+	StateType *types.T
+	// InitCond is the string representation of the initial state, or nil if
+	// the initial state is NULL.
+	InitCond *string
+}
+
+// AggregateSupportExprs contains the support functions of a user-defined
+// aggregate, as calls to routines which the execution engine evaluates like a
+// builtin aggregate.
+//
+// In Transition and Final, IndexedVar 0 refers to the current state and
+// IndexedVar i refers to the i-th argument of the aggregate (starting at 1).
+type AggregateSupportExprs struct {
+	// Transition computes the next state of the aggregate.
+	Transition TypedExpr
+	// TransitionStrict is true if the transition function is not called on
+	// NULL inputs.
+	TransitionStrict bool
+	// Final computes the result of the aggregate from its final state. It is
+	// nil if the aggregate has no final function.
+	Final TypedExpr
+	// FinalStrict is true if the final function is not called on a NULL
+	// state.
+	FinalStrict bool
+	// StateType is the type of the aggregate's state.
+	StateType *types.T
+	// InitialState is the initial state of the aggregate.
+	InitialState Datum
+}
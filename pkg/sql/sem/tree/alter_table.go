@@ -76,6 +76,8 @@ func (*AlterTablePartitionByTable) alterTableCmd()   {}
 func (*AlterTableInjectStats) alterTableCmd()        {}
 func (*AlterTableSetStorageParams) alterTableCmd()   {}
 func (*AlterTableResetStorageParams) alterTableCmd() {}
+func (*AlterTableInherit) alterTableCmd()            {}
+func (*AlterTableNoInherit) alterTableCmd()          {}
 
 var _ AlterTableCmd = &AlterTableAddColumn{}
 var _ AlterTableCmd = &AlterTableAddConstraint{}
@@ -96,6 +98,8 @@ var _ AlterTableCmd = &AlterTablePartitionByTable{}
 var _ AlterTableCmd = &AlterTableInjectStats{}
 var _ AlterTableCmd = &AlterTableSetStorageParams{}
 var _ AlterTableCmd = &AlterTableResetStorageParams{}
+var _ AlterTableCmd = &AlterTableInherit{}
+var _ AlterTableCmd = &AlterTableNoInherit{}
 
 // ColumnMutationCmd is the subset of AlterTableCmds that modify an
 // existing column.
@@ -169,12 +173,13 @@ func (node *AlterTable) HoistAddColumnConstraints(onHoistedFKConstraint func())
 					targetCol = append(targetCol, d.References.Col)
 				}
 				fk := &ForeignKeyConstraintTableDef{
-					Table:    *d.References.Table,
-					FromCols: NameList{d.Name},
-					ToCols:   targetCol,
-					Name:     d.References.ConstraintName,
-					Actions:  d.References.Actions,
-					Match:    d.References.Match,
+					Table:         *d.References.Table,
+					FromCols:      NameList{d.Name},
+					ToCols:        targetCol,
+					Name:          d.References.ConstraintName,
+					Actions:       d.References.Actions,
+					Match:         d.References.Match,
+					Deferrability: d.References.Deferrability,
 				}
 				constraint := &AlterTableAddConstraint{
 					ConstraintDef:      fk,
@@ -610,6 +615,38 @@ func (node *AlterTableInjectStats) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.Stats)
 }
 
+// AlterTableInherit represents an ALTER TABLE INHERIT command.
+type AlterTableInherit struct {
+	Parent TableName
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableInherit) TelemetryName() string {
+	return "inherit"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableInherit) Format(ctx *FmtCtx) {
+	ctx.WriteString(" INHERIT ")
+	ctx.FormatNode(&node.Parent)
+}
+
+// AlterTableNoInherit represents an ALTER TABLE NO INHERIT command.
+type AlterTableNoInherit struct {
+	Parent TableName
+}
+
+// TelemetryName implements the AlterTableCmd interface.
+func (node *AlterTableNoInherit) TelemetryName() string {
+	return "no_inherit"
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableNoInherit) Format(ctx *FmtCtx) {
+	ctx.WriteString(" NO INHERIT ")
+	ctx.FormatNode(&node.Parent)
+}
+
 // AlterTableSetStorageParams represents a ALTER TABLE SET command.
 type AlterTableSetStorageParams struct {
 	StorageParams StorageParams
@@ -0,0 +1,43 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CommentOnFunction represents a COMMENT ON FUNCTION or COMMENT ON PROCEDURE
+// statement.
+type CommentOnFunction struct {
+	Function    FuncObj
+	IsProcedure bool
+	Comment     *string
+}
+
+// Format implements the NodeFormatter interface.
+func (n *CommentOnFunction) Format(ctx *FmtCtx) {
+	if n.IsProcedure {
+		ctx.WriteString("COMMENT ON PROCEDURE ")
+	} else {
+		ctx.WriteString("COMMENT ON FUNCTION ")
+	}
+	ctx.FormatNode(&n.Function)
+	ctx.WriteString(" IS ")
+	if n.Comment != nil {
+		// TODO(knz): Replace all this with ctx.FormatNode
+		// when COMMENT supports expressions.
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteByte('_')
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, *n.Comment, ctx.flags.EncodeFlags())
+		}
+	} else {
+		ctx.WriteString("NULL")
+	}
+}
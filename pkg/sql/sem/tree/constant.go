@@ -542,6 +542,12 @@ var (
 		types.UUIDArray,
 		types.INet,
 		types.Jsonb,
+		types.Jsonpath,
+		types.Point,
+		types.Box,
+		types.Path,
+		types.Polygon,
+		types.Circle,
 		types.PGLSN,
 		types.PGLSNArray,
 		types.TSQuery,
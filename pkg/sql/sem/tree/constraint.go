@@ -127,3 +127,55 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrability specifies whether the checking of a constraint may
+// be postponed until the end of the transaction, and if so, whether it is
+// postponed by default. See
+// https://www.postgresql.org/docs/current/sql-set-constraints.html.
+type ConstraintDeferrability uint8
+
+// The values for ConstraintDeferrability.
+const (
+	ConstraintNotDeferrable ConstraintDeferrability = iota
+	ConstraintInitiallyImmediate
+	ConstraintInitiallyDeferred
+)
+
+// MakeConstraintDeferrability returns the ConstraintDeferrability matching
+// the deferrable and initially_deferred flags of a constraint descriptor.
+func MakeConstraintDeferrability(deferrable, initiallyDeferred bool) ConstraintDeferrability {
+	switch {
+	case !deferrable:
+		return ConstraintNotDeferrable
+	case initiallyDeferred:
+		return ConstraintInitiallyDeferred
+	default:
+		return ConstraintInitiallyImmediate
+	}
+}
+
+// IsDeferrable returns true if the constraint may be deferred.
+func (x ConstraintDeferrability) IsDeferrable() bool {
+	return x != ConstraintNotDeferrable
+}
+
+// String implements the fmt.Stringer interface. Non-deferrable constraints
+// are the default and are represented by the empty string.
+func (x ConstraintDeferrability) String() string {
+	switch x {
+	case ConstraintInitiallyImmediate:
+		return "DEFERRABLE"
+	case ConstraintInitiallyDeferred:
+		return "DEFERRABLE INITIALLY DEFERRED"
+	default:
+		return ""
+	}
+}
+
+// Format implements the NodeFormatter interface.
+func (x ConstraintDeferrability) Format(ctx *FmtCtx) {
+	if x.IsDeferrable() {
+		ctx.WriteByte(' ')
+		ctx.WriteString(x.String())
+	}
+}
@@ -62,11 +62,25 @@ type CopyOptions struct {
 	Escape      *StrVal
 	Header      bool
 	Quote       *StrVal
+	Encoding    *StrVal
+	Freeze      bool
+
+	// ForceQuote lists the columns whose non-NULL values are always quoted by
+	// COPY TO in CSV format. ForceQuoteAll is set for FORCE_QUOTE *.
+	ForceQuote    NameList
+	ForceQuoteAll bool
+	// ForceNotNull lists the columns whose values are never matched against
+	// the null string by COPY FROM in CSV format.
+	ForceNotNull NameList
+	// ForceNull lists the columns whose values are matched against the null
+	// string by COPY FROM in CSV format even when they are quoted.
+	ForceNull NameList
 
 	// Additional flags are needed to keep track of whether explicit default
 	// values were already set.
 	HasFormat bool
 	HasHeader bool
+	HasFreeze bool
 }
 
 var _ NodeFormatter = &CopyOptions{}
@@ -151,12 +165,62 @@ func (o *CopyOptions) Format(ctx *FmtCtx) {
 		ctx.WriteString("QUOTE ")
 		ctx.FormatNode(o.Quote)
 	}
+	if o.ForceQuoteAll {
+		maybeAddSep()
+		ctx.WriteString("FORCE_QUOTE *")
+	} else if o.ForceQuote != nil {
+		maybeAddSep()
+		ctx.WriteString("FORCE_QUOTE (")
+		ctx.FormatNode(&o.ForceQuote)
+		ctx.WriteString(")")
+	}
+	if o.ForceNotNull != nil {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NOT_NULL (")
+		ctx.FormatNode(&o.ForceNotNull)
+		ctx.WriteString(")")
+	}
+	if o.ForceNull != nil {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NULL (")
+		ctx.FormatNode(&o.ForceNull)
+		ctx.WriteString(")")
+	}
+	if o.Encoding != nil {
+		maybeAddSep()
+		ctx.WriteString("ENCODING ")
+		ctx.FormatNode(o.Encoding)
+	}
+	if o.HasFreeze {
+		maybeAddSep()
+		ctx.WriteString("FREEZE ")
+		if o.Freeze {
+			ctx.WriteString("true")
+		} else {
+			ctx.WriteString("false")
+		}
+	}
 	ctx.WriteString(")")
 }
 
 // IsDefault returns true if this struct has default value.
 func (o CopyOptions) IsDefault() bool {
-	return o == CopyOptions{}
+	return o.Destination == nil &&
+		o.CopyFormat == CopyFormatText &&
+		o.Delimiter == nil &&
+		o.Null == nil &&
+		o.Escape == nil &&
+		!o.Header &&
+		o.Quote == nil &&
+		o.Encoding == nil &&
+		!o.Freeze &&
+		o.ForceQuote == nil &&
+		!o.ForceQuoteAll &&
+		o.ForceNotNull == nil &&
+		o.ForceNull == nil &&
+		!o.HasFormat &&
+		!o.HasHeader &&
+		!o.HasFreeze
 }
 
 // CombineWith merges other options into this struct. An error is returned if
@@ -206,6 +270,38 @@ func (o *CopyOptions) CombineWith(other *CopyOptions) error {
 		}
 		o.Quote = other.Quote
 	}
+	if other.ForceQuote != nil || other.ForceQuoteAll {
+		if o.ForceQuote != nil || o.ForceQuoteAll {
+			return pgerror.Newf(pgcode.Syntax, "force_quote option specified multiple times")
+		}
+		o.ForceQuote = other.ForceQuote
+		o.ForceQuoteAll = other.ForceQuoteAll
+	}
+	if other.ForceNotNull != nil {
+		if o.ForceNotNull != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_not_null option specified multiple times")
+		}
+		o.ForceNotNull = other.ForceNotNull
+	}
+	if other.ForceNull != nil {
+		if o.ForceNull != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_null option specified multiple times")
+		}
+		o.ForceNull = other.ForceNull
+	}
+	if other.Encoding != nil {
+		if o.Encoding != nil {
+			return pgerror.Newf(pgcode.Syntax, "encoding option specified multiple times")
+		}
+		o.Encoding = other.Encoding
+	}
+	if other.HasFreeze {
+		if o.HasFreeze {
+			return pgerror.Newf(pgcode.Syntax, "freeze option specified multiple times")
+		}
+		o.Freeze = other.Freeze
+		o.HasFreeze = true
+	}
 	return nil
 }
 
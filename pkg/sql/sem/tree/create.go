@@ -28,8 +28,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
@@ -408,6 +410,106 @@ func (node *CreateType) String() string {
 	return AsString(node)
 }
 
+// CreateDomain represents a CREATE DOMAIN statement.
+type CreateDomain struct {
+	Name        *UnresolvedObjectName
+	Type        ResolvableTypeReference
+	Default     Expr
+	Constraints []DomainConstraint
+}
+
+// DomainConstraint is a NOT NULL, NULL or CHECK constraint of a domain.
+type DomainConstraint struct {
+	Name Name
+	// Check is the expression of a CHECK constraint. It is nil for NOT NULL and
+	// NULL constraints.
+	Check Expr
+	// Nullability is NotNull or Null for NOT NULL and NULL constraints, and
+	// SilentNull for CHECK constraints.
+	Nullability Nullability
+}
+
+var _ Statement = &CreateDomain{}
+
+// NewCreateDomain constructs a CREATE DOMAIN statement from the column
+// qualifications that follow the base type in the statement, which must
+// only consist of a default expression and NOT NULL, NULL and CHECK
+// constraints.
+func NewCreateDomain(
+	name *UnresolvedObjectName, typRef ResolvableTypeReference, quals []NamedColumnQualification,
+) (*CreateDomain, error) {
+	d := &CreateDomain{Name: name, Type: typRef}
+	nullability := SilentNull
+	for _, c := range quals {
+		switch t := c.Qualification.(type) {
+		case *ColumnDefault:
+			if d.Default != nil {
+				return nil, pgerror.New(pgcode.Syntax, "multiple default expressions")
+			}
+			d.Default = t.Expr
+		case NotNullConstraint, NullConstraint:
+			n := NotNull
+			if _, ok := t.(NullConstraint); ok {
+				n = Null
+			}
+			if nullability != SilentNull && nullability != n {
+				return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			nullability = n
+			d.Constraints = append(d.Constraints, DomainConstraint{Name: c.Name, Nullability: n})
+		case *ColumnCheckConstraint:
+			d.Constraints = append(d.Constraints, DomainConstraint{
+				Name: c.Name, Check: t.Expr, Nullability: SilentNull,
+			})
+		case ColumnCollation:
+			return nil, unimplemented.NewWithIssueDetail(27796, "collate", "COLLATE is not supported for domains")
+		default:
+			return nil, pgerror.New(pgcode.InvalidObjectDefinition,
+				"only NOT NULL, NULL and CHECK constraints are possible for domains")
+		}
+	}
+	return d, nil
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE DOMAIN ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.Type)
+	if node.Default != nil {
+		ctx.WriteString(" DEFAULT ")
+		ctx.FormatNode(node.Default)
+	}
+	for i := range node.Constraints {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Constraints[i])
+	}
+}
+
+func (node *CreateDomain) String() string {
+	return AsString(node)
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DomainConstraint) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	switch {
+	case node.Check != nil:
+		ctx.WriteString("CHECK (")
+		ctx.FormatNode(node.Check)
+		ctx.WriteByte(')')
+	case node.Nullability == NotNull:
+		ctx.WriteString("NOT NULL")
+	default:
+		ctx.WriteString("NULL")
+	}
+}
+
 // TableDef represents a column, index or constraint definition within a CREATE
 // TABLE statement.
 type TableDef interface {
@@ -422,6 +524,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExcludeConstraintTableDef) tableDef()    {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -495,6 +598,7 @@ type ColumnTableDef struct {
 		IsUnique       bool
 		WithoutIndex   bool
 		ConstraintName Name
+		Deferrability  ConstraintDeferrability
 	}
 	DefaultExpr struct {
 		Expr           Expr
@@ -511,6 +615,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrability  ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -572,7 +677,21 @@ func NewColumnTableDef(
 		IsSerial: isSerial,
 	}
 	d.Nullable.Nullability = SilentNull
+	// deferrability points at the deferrability of the most recent constraint
+	// that accepts constraint attributes, if the previous qualification was one.
+	var deferrability *ConstraintDeferrability
+	var deferrableSet, initiallySet bool
 	for _, c := range qualifications {
+		if attr, ok := c.Qualification.(ConstraintAttribute); ok {
+			if deferrability == nil {
+				return nil, pgerror.Newf(pgcode.Syntax, "misplaced %s clause", attr)
+			}
+			if err := attr.apply(deferrability, &deferrableSet, &initiallySet); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		deferrability, deferrableSet, initiallySet = nil, false, false
 		switch t := c.Qualification.(type) {
 		case ColumnCollation:
 			locale := string(t)
@@ -679,6 +798,7 @@ func NewColumnTableDef(
 			d.Unique.IsUnique = true
 			d.Unique.WithoutIndex = t.WithoutIndex
 			d.Unique.ConstraintName = c.Name
+			deferrability = &d.Unique.Deferrability
 		case *ColumnCheckConstraint:
 			d.CheckExprs = append(d.CheckExprs, ColumnTableDefCheckExpr{
 				Expr:           t.Expr,
@@ -694,6 +814,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			deferrability = &d.References.Deferrability
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -805,6 +926,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			if node.Unique.WithoutIndex {
 				ctx.WriteString(" WITHOUT INDEX")
 			}
+			ctx.FormatNode(node.Unique.Deferrability)
 		}
 	}
 	if node.HasDefaultExpr() {
@@ -864,6 +986,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(node.References.Deferrability)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -944,6 +1067,7 @@ func (*ColumnFKConstraint) columnQualification()         {}
 func (*ColumnFamilyConstraint) columnQualification()     {}
 func (*GeneratedAlwaysAsIdentity) columnQualification()  {}
 func (*GeneratedByDefAsIdentity) columnQualification()   {}
+func (ConstraintAttribute) columnQualification()         {}
 
 // ColumnCollation represents a COLLATE clause for a column.
 type ColumnCollation string
@@ -995,6 +1119,75 @@ type UniqueConstraint struct {
 	WithoutIndex bool
 }
 
+// ConstraintAttribute represents a DEFERRABLE, NOT DEFERRABLE, INITIALLY
+// DEFERRED or INITIALLY IMMEDIATE clause on a column. It applies to the
+// constraint that immediately precedes it.
+type ConstraintAttribute uint8
+
+// The values for ConstraintAttribute.
+const (
+	ConstraintAttrDeferrable ConstraintAttribute = iota
+	ConstraintAttrNotDeferrable
+	ConstraintAttrInitiallyDeferred
+	ConstraintAttrInitiallyImmediate
+)
+
+// String implements the fmt.Stringer interface.
+func (a ConstraintAttribute) String() string {
+	switch a {
+	case ConstraintAttrDeferrable:
+		return "DEFERRABLE"
+	case ConstraintAttrNotDeferrable:
+		return "NOT DEFERRABLE"
+	case ConstraintAttrInitiallyDeferred:
+		return "INITIALLY DEFERRED"
+	case ConstraintAttrInitiallyImmediate:
+		return "INITIALLY IMMEDIATE"
+	default:
+		return strconv.Itoa(int(a))
+	}
+}
+
+// apply updates d according to the attribute. deferrableSet and initiallySet
+// track whether a [NOT] DEFERRABLE or INITIALLY clause has already been seen
+// for the same constraint, mirroring Postgres' validation of conflicting or
+// repeated attributes.
+func (a ConstraintAttribute) apply(
+	d *ConstraintDeferrability, deferrableSet, initiallySet *bool,
+) error {
+	switch a {
+	case ConstraintAttrDeferrable, ConstraintAttrNotDeferrable:
+		if *deferrableSet {
+			return pgerror.New(pgcode.Syntax,
+				"multiple DEFERRABLE/NOT DEFERRABLE clauses not allowed")
+		}
+		*deferrableSet = true
+		if a == ConstraintAttrNotDeferrable {
+			if *d == ConstraintInitiallyDeferred {
+				return pgerror.New(pgcode.Syntax,
+					"constraint declared INITIALLY DEFERRED must be DEFERRABLE")
+			}
+			*d = ConstraintNotDeferrable
+		} else if *d == ConstraintNotDeferrable {
+			*d = ConstraintInitiallyImmediate
+		}
+	case ConstraintAttrInitiallyDeferred, ConstraintAttrInitiallyImmediate:
+		if *initiallySet {
+			return pgerror.New(pgcode.Syntax,
+				"multiple INITIALLY IMMEDIATE/DEFERRED clauses not allowed")
+		}
+		*initiallySet = true
+		if a == ConstraintAttrInitiallyDeferred {
+			if *deferrableSet && *d == ConstraintNotDeferrable {
+				return pgerror.New(pgcode.Syntax,
+					"constraint declared INITIALLY DEFERRED must be DEFERRABLE")
+			}
+			*d = ConstraintInitiallyDeferred
+		}
+	}
+	return nil
+}
+
 // ColumnCheckConstraint represents either a check on a column.
 type ColumnCheckConstraint struct {
 	Expr Expr
@@ -1097,14 +1290,16 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExcludeConstraintTableDef) constraintTableDef()    {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
 type UniqueConstraintTableDef struct {
 	IndexTableDef
-	PrimaryKey   bool
-	WithoutIndex bool
-	IfNotExists  bool
+	PrimaryKey    bool
+	WithoutIndex  bool
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // SetName implements the TableDef interface.
@@ -1149,6 +1344,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(node.Deferrability)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1170,13 +1366,14 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name        Name
-	Table       TableName
-	FromCols    NameList
-	ToCols      NameList
-	Actions     ReferenceActions
-	Match       CompositeKeyMatchMethod
-	IfNotExists bool
+	Name          Name
+	Table         TableName
+	FromCols      NameList
+	ToCols        NameList
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -1207,6 +1404,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(node.Deferrability)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1253,6 +1451,108 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExcludeElem represents a single element of an EXCLUDE constraint: an
+// index element along with the operator used to compare it.
+type ExcludeElem struct {
+	IndexElem
+	Operator treecmp.ComparisonOperator
+	// PeriodEnd is set if the element is a period, written as
+	// (start, end) WITH OVERLAPS. In that case IndexElem holds the start
+	// column, and the periods of two rows are compared with OVERLAPS.
+	PeriodEnd Name
+}
+
+// IsPeriod returns true if the element is a (start, end) period.
+func (node *ExcludeElem) IsPeriod() bool {
+	return node.PeriodEnd != ""
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeElem) Format(ctx *FmtCtx) {
+	if node.IsPeriod() {
+		ctx.WriteByte('(')
+		ctx.FormatNode(&node.Column)
+		ctx.WriteString(", ")
+		ctx.FormatNode(&node.PeriodEnd)
+		ctx.WriteString(") WITH OVERLAPS")
+		return
+	}
+	ctx.FormatNode(&node.IndexElem)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// ExcludeElemList is list of ExcludeElem.
+type ExcludeElemList []ExcludeElem
+
+// Format implements the NodeFormatter interface.
+func (l *ExcludeElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
+// ExcludeConstraintTableDef represents an EXCLUDE constraint within a CREATE
+// TABLE statement.
+type ExcludeConstraintTableDef struct {
+	Name Name
+	// Inverted is true if the constraint is backed by an inverted index, which
+	// is the case for USING GIST and USING GIN.
+	Inverted    bool
+	Elems       ExcludeElemList
+	Predicate   Expr
+	IfNotExists bool
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.Inverted {
+		ctx.WriteString("USING GIST ")
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
+}
+
+// Columns returns the index elements of the constraint, without their
+// operators. A period contributes both its start and end columns.
+func (node *ExcludeConstraintTableDef) Columns() IndexElemList {
+	cols := make(IndexElemList, 0, len(node.Elems))
+	for i := range node.Elems {
+		cols = append(cols, node.Elems[i].IndexElem)
+		if node.Elems[i].IsPeriod() {
+			cols = append(cols, IndexElem{Column: node.Elems[i].PeriodEnd})
+		}
+	}
+	return cols
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1498,7 +1798,12 @@ type CreateTable struct {
 	// these columns.
 	Defs     TableDefs
 	AsSource *Select
-	Locality *Locality
+	// AsWithNoData is true for CREATE TABLE ... AS ... WITH NO DATA, which
+	// creates the table without populating it with the rows of AsSource.
+	AsWithNoData bool
+	Locality     *Locality
+	// Inherits lists the parent tables of an INHERITS clause.
+	Inherits TableNames
 }
 
 // As returns true if this table represents a CREATE TABLE ... AS statement,
@@ -1555,10 +1860,18 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 		}
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(node.AsSource)
+		if node.AsWithNoData {
+			ctx.WriteString(" WITH NO DATA")
+		}
 	} else {
 		ctx.WriteString(" (")
 		ctx.FormatNode(&node.Defs)
 		ctx.WriteByte(')')
+		if len(node.Inherits) > 0 {
+			ctx.WriteString(" INHERITS (")
+			ctx.FormatNode(&node.Inherits)
+			ctx.WriteByte(')')
+		}
 		if node.PartitionByTable != nil {
 			ctx.FormatNode(node.PartitionByTable)
 		}
@@ -1619,12 +1932,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:         *col.References.Table,
+					FromCols:      NameList{col.Name},
+					ToCols:        targetCol,
+					Name:          col.References.ConstraintName,
+					Actions:       col.References.Actions,
+					Match:         col.References.Match,
+					Deferrability: col.References.Deferrability,
 				})
 				col.References.Table = nil
 			}
@@ -1855,6 +2169,10 @@ const (
 	LikeTableOptDefaults
 	LikeTableOptGenerated
 	LikeTableOptIndexes
+	LikeTableOptComments
+	LikeTableOptIdentity
+	LikeTableOptStatistics
+	LikeTableOptStorage
 
 	// Make sure this field stays last!
 	likeTableOptInvalid
@@ -1878,6 +2196,14 @@ func (o LikeTableOpt) String() string {
 		return "GENERATED"
 	case LikeTableOptIndexes:
 		return "INDEXES"
+	case LikeTableOptComments:
+		return "COMMENTS"
+	case LikeTableOptIdentity:
+		return "IDENTITY"
+	case LikeTableOptStatistics:
+		return "STATISTICS"
+	case LikeTableOptStorage:
+		return "STORAGE"
 	case LikeTableOptAll:
 		return "ALL"
 	default:
@@ -1989,6 +2315,47 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 	}
 }
 
+// CreateForeignTable represents a CREATE FOREIGN TABLE statement.
+type CreateForeignTable struct {
+	IfNotExists bool
+	Table       TableName
+	Defs        TableDefs
+	// Server is the name of the external connection the files backing the
+	// table are read from. It is empty if the location option is a full
+	// external storage URI.
+	Server  Name
+	Options KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE FOREIGN TABLE ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Defs)
+	ctx.WriteByte(')')
+	if node.Server != "" {
+		ctx.WriteString(" SERVER ")
+		ctx.FormatNode(&node.Server)
+	}
+	ctx.WriteString(" OPTIONS (")
+	for i := range node.Options {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		// Option keys never contain PII.
+		ctx.WithFlags(ctx.flags&^FmtMarkRedactionNode, func() {
+			ctx.FormatNode(&node.Options[i].Key)
+		})
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Options[i].Value)
+	}
+	ctx.WriteByte(')')
+}
+
 // RefreshMaterializedView represents a REFRESH MATERIALIZED VIEW statement.
 type RefreshMaterializedView struct {
 	Name              *UnresolvedObjectName
@@ -25,6 +25,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
@@ -38,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
@@ -3829,6 +3831,621 @@ func (d *DBox2D) Size() uintptr {
 	return unsafe.Sizeof(*d) + unsafe.Sizeof(d.CartesianBoundingBox)
 }
 
+// formatGeometric writes the text representation of a value of one of the
+// geometric types, quoting it unless bare strings are requested.
+func formatGeometric(ctx *FmtCtx, b []byte) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.Write(b)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// hasNegativeZero returns whether any of the given coordinates is -0, which
+// makes a value of one of the geometric types composite since -0 and 0 have
+// the same key encoding.
+func hasNegativeZero(coords []float64) bool {
+	for _, c := range coords {
+		if math.Float64bits(c) == 1<<63 {
+			return true
+		}
+	}
+	return false
+}
+
+// DPoint is the Datum representation of the point type.
+type DPoint struct {
+	geometric.Point
+}
+
+// NewDPoint returns a new Point Datum.
+func NewDPoint(v geometric.Point) *DPoint {
+	return &DPoint{Point: v}
+}
+
+// ParseDPoint attempts to parse `str` as a point.
+func ParseDPoint(str string) (*DPoint, error) {
+	v, err := geometric.ParsePoint(str)
+	if err != nil {
+		return nil, MakeParseError(str, types.Point, err)
+	}
+	return NewDPoint(v), nil
+}
+
+// AsDPoint attempts to retrieve a *DPoint from an Expr, returning a *DPoint and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DPoint wrapped by a
+// *DOidWrapper is possible.
+func AsDPoint(e Expr) (*DPoint, bool) {
+	switch t := e.(type) {
+	case *DPoint:
+		return t, true
+	case *DOidWrapper:
+		return AsDPoint(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDPoint attempts to retrieve a *DPoint from an Expr, panicking if the
+// assertion fails.
+func MustBeDPoint(e Expr) *DPoint {
+	v, ok := AsDPoint(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DPoint, found %T", e))
+	}
+	return v
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DPoint) ResolvedType() *types.T {
+	return types.Point
+}
+
+// Compare implements the Datum interface.
+func (d *DPoint) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DPoint) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DPoint)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Point.Compare(v.Point), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DPoint) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DPoint) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DPoint) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DPoint) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DPoint) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DPoint) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DPoint) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DPoint) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Point.AppendFormat(ctx.scratch[:0]))
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DPoint) IsComposite() bool {
+	return hasNegativeZero(d.Point.Coords(nil))
+}
+
+// Size implements the Datum interface.
+func (d *DPoint) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DBox is the Datum representation of the box type.
+type DBox struct {
+	geometric.Box
+}
+
+// NewDBox returns a new Box Datum.
+func NewDBox(v geometric.Box) *DBox {
+	return &DBox{Box: v}
+}
+
+// ParseDBox attempts to parse `str` as a box.
+func ParseDBox(str string) (*DBox, error) {
+	v, err := geometric.ParseBox(str)
+	if err != nil {
+		return nil, MakeParseError(str, types.Box, err)
+	}
+	return NewDBox(v), nil
+}
+
+// AsDBox attempts to retrieve a *DBox from an Expr, returning a *DBox and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DBox wrapped by a
+// *DOidWrapper is possible.
+func AsDBox(e Expr) (*DBox, bool) {
+	switch t := e.(type) {
+	case *DBox:
+		return t, true
+	case *DOidWrapper:
+		return AsDBox(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDBox attempts to retrieve a *DBox from an Expr, panicking if the
+// assertion fails.
+func MustBeDBox(e Expr) *DBox {
+	v, ok := AsDBox(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DBox, found %T", e))
+	}
+	return v
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DBox) ResolvedType() *types.T {
+	return types.Box
+}
+
+// Compare implements the Datum interface.
+func (d *DBox) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DBox) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DBox)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Box.Compare(v.Box), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DBox) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DBox) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DBox) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DBox) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DBox) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DBox) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DBox) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DBox) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Box.AppendFormat(ctx.scratch[:0]))
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DBox) IsComposite() bool {
+	return hasNegativeZero(d.Box.Coords(nil))
+}
+
+// Size implements the Datum interface.
+func (d *DBox) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
+// DPath is the Datum representation of the path type.
+type DPath struct {
+	geometric.Path
+}
+
+// NewDPath returns a new Path Datum.
+func NewDPath(v geometric.Path) *DPath {
+	return &DPath{Path: v}
+}
+
+// ParseDPath attempts to parse `str` as a path.
+func ParseDPath(str string) (*DPath, error) {
+	v, err := geometric.ParsePath(str)
+	if err != nil {
+		return nil, MakeParseError(str, types.Path, err)
+	}
+	return NewDPath(v), nil
+}
+
+// AsDPath attempts to retrieve a *DPath from an Expr, returning a *DPath and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DPath wrapped by a
+// *DOidWrapper is possible.
+func AsDPath(e Expr) (*DPath, bool) {
+	switch t := e.(type) {
+	case *DPath:
+		return t, true
+	case *DOidWrapper:
+		return AsDPath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDPath attempts to retrieve a *DPath from an Expr, panicking if the
+// assertion fails.
+func MustBeDPath(e Expr) *DPath {
+	v, ok := AsDPath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DPath, found %T", e))
+	}
+	return v
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DPath) ResolvedType() *types.T {
+	return types.Path
+}
+
+// Compare implements the Datum interface.
+func (d *DPath) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DPath) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DPath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Path.Compare(v.Path), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DPath) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DPath) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DPath) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DPath) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DPath) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DPath) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DPath) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DPath) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Path.AppendFormat(ctx.scratch[:0]))
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DPath) IsComposite() bool {
+	return hasNegativeZero(d.Path.Coords(nil))
+}
+
+// Size implements the Datum interface.
+func (d *DPath) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Path.Points))*unsafe.Sizeof(geometric.Point{})
+}
+
+// DPolygon is the Datum representation of the polygon type.
+type DPolygon struct {
+	geometric.Polygon
+}
+
+// NewDPolygon returns a new Polygon Datum.
+func NewDPolygon(v geometric.Polygon) *DPolygon {
+	return &DPolygon{Polygon: v}
+}
+
+// ParseDPolygon attempts to parse `str` as a polygon.
+func ParseDPolygon(str string) (*DPolygon, error) {
+	v, err := geometric.ParsePolygon(str)
+	if err != nil {
+		return nil, MakeParseError(str, types.Polygon, err)
+	}
+	return NewDPolygon(v), nil
+}
+
+// AsDPolygon attempts to retrieve a *DPolygon from an Expr, returning a *DPolygon and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DPolygon wrapped by a
+// *DOidWrapper is possible.
+func AsDPolygon(e Expr) (*DPolygon, bool) {
+	switch t := e.(type) {
+	case *DPolygon:
+		return t, true
+	case *DOidWrapper:
+		return AsDPolygon(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDPolygon attempts to retrieve a *DPolygon from an Expr, panicking if the
+// assertion fails.
+func MustBeDPolygon(e Expr) *DPolygon {
+	v, ok := AsDPolygon(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DPolygon, found %T", e))
+	}
+	return v
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DPolygon) ResolvedType() *types.T {
+	return types.Polygon
+}
+
+// Compare implements the Datum interface.
+func (d *DPolygon) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DPolygon) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DPolygon)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Polygon.Compare(v.Polygon), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DPolygon) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DPolygon) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DPolygon) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DPolygon) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DPolygon) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DPolygon) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DPolygon) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DPolygon) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Polygon.AppendFormat(ctx.scratch[:0]))
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DPolygon) IsComposite() bool {
+	return hasNegativeZero(d.Polygon.Coords(nil))
+}
+
+// Size implements the Datum interface.
+func (d *DPolygon) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Polygon.Points))*unsafe.Sizeof(geometric.Point{})
+}
+
+// DCircle is the Datum representation of the circle type.
+type DCircle struct {
+	geometric.Circle
+}
+
+// NewDCircle returns a new Circle Datum.
+func NewDCircle(v geometric.Circle) *DCircle {
+	return &DCircle{Circle: v}
+}
+
+// ParseDCircle attempts to parse `str` as a circle.
+func ParseDCircle(str string) (*DCircle, error) {
+	v, err := geometric.ParseCircle(str)
+	if err != nil {
+		return nil, MakeParseError(str, types.Circle, err)
+	}
+	return NewDCircle(v), nil
+}
+
+// AsDCircle attempts to retrieve a *DCircle from an Expr, returning a *DCircle and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DCircle wrapped by a
+// *DOidWrapper is possible.
+func AsDCircle(e Expr) (*DCircle, bool) {
+	switch t := e.(type) {
+	case *DCircle:
+		return t, true
+	case *DOidWrapper:
+		return AsDCircle(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDCircle attempts to retrieve a *DCircle from an Expr, panicking if the
+// assertion fails.
+func MustBeDCircle(e Expr) *DCircle {
+	v, ok := AsDCircle(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DCircle, found %T", e))
+	}
+	return v
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DCircle) ResolvedType() *types.T {
+	return types.Circle
+}
+
+// Compare implements the Datum interface.
+func (d *DCircle) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DCircle) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DCircle)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.Circle.Compare(v.Circle), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DCircle) Prev(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DCircle) Next(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DCircle) IsMax(ctx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DCircle) IsMin(ctx CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DCircle) Max(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DCircle) Min(ctx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DCircle) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DCircle) Format(ctx *FmtCtx) {
+	formatGeometric(ctx, d.Circle.AppendFormat(ctx.scratch[:0]))
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DCircle) IsComposite() bool {
+	return hasNegativeZero(d.Circle.Coords(nil))
+}
+
+// Size implements the Datum interface.
+func (d *DCircle) Size() uintptr {
+	return unsafe.Sizeof(*d)
+}
+
 // DJSON is the JSON Datum.
 type DJSON struct{ json.JSON }
 
@@ -3990,7 +4607,7 @@ func AsJSON(
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
-		*DTSVector, *DTSQuery, *DPGLSN:
+		*DTSVector, *DTSQuery, *DPGLSN, *DJsonpath, *DPoint, *DBox, *DPath, *DPolygon, *DCircle:
 		return json.FromString(
 			AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc), FmtLocation(loc)),
 		), nil
@@ -4103,6 +4720,137 @@ func (d *DJSON) Size() uintptr {
 	return unsafe.Sizeof(*d) + d.JSON.Size()
 }
 
+// DJsonpath is the jsonpath Datum.
+type DJsonpath struct {
+	jsonpath.Jsonpath
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DJsonpath) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	str := d.Jsonpath.String()
+	if !bareStrings {
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DJsonpath) ResolvedType() *types.T {
+	return types.Jsonpath
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DJsonpath) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DJsonpath) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DJsonpath) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DJsonpath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	l, r := d.String(), v.String()
+	if l < r {
+		return -1, nil
+	} else if l > r {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DJsonpath) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DJsonpath) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DJsonpath) IsMin(_ CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DJsonpath) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DJsonpath) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DJsonpath) Min(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DJsonpath) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Jsonpath.String()))
+}
+
+// AsDJsonpath attempts to retrieve a DJsonpath from an Expr, returning a
+// DJsonpath and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DJsonpath wrapped by a *DOidWrapper is possible.
+func AsDJsonpath(e Expr) (*DJsonpath, bool) {
+	switch t := e.(type) {
+	case *DJsonpath:
+		return t, true
+	case *DOidWrapper:
+		return AsDJsonpath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDJsonpath attempts to retrieve a DJsonpath from an Expr, panicking if
+// the assertion fails.
+func MustBeDJsonpath(e Expr) *DJsonpath {
+	v, ok := AsDJsonpath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DJsonpath, found %T", e))
+	}
+	return v
+}
+
+// NewDJsonpath is a helper routine to create a DJsonpath initialized from its
+// argument.
+func NewDJsonpath(p *jsonpath.Jsonpath) *DJsonpath {
+	return &DJsonpath{Jsonpath: *p}
+}
+
+// ParseDJsonpath takes a string of jsonpath and returns a DJsonpath value.
+func ParseDJsonpath(s string) (Datum, error) {
+	p, err := jsonpath.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDJsonpath(p), nil
+}
+
 // DTSQuery is the tsquery Datum.
 type DTSQuery struct {
 	tsearch.TSQuery
@@ -6077,6 +6825,12 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JsonFamily:           {unsafe.Sizeof(DJSON{}), variableSize},
+	types.JsonpathFamily:       {unsafe.Sizeof(DJsonpath{}), variableSize},
+	types.PointFamily:          {unsafe.Sizeof(DPoint{}), fixedSize},
+	types.BoxFamily:            {unsafe.Sizeof(DBox{}), fixedSize},
+	types.PathFamily:           {unsafe.Sizeof(DPath{}), variableSize},
+	types.PolygonFamily:        {unsafe.Sizeof(DPolygon{}), variableSize},
+	types.CircleFamily:         {unsafe.Sizeof(DCircle{}), fixedSize},
 	types.UuidFamily:           {unsafe.Sizeof(DUuid{}), fixedSize},
 	types.INetFamily:           {unsafe.Sizeof(DIPAddr{}), fixedSize},
 	types.OidFamily:            {unsafe.Sizeof(DOid{}.Oid), fixedSize},
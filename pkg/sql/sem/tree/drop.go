@@ -109,6 +109,9 @@ type DropView struct {
 	IfExists       bool
 	DropBehavior   DropBehavior
 	IsMaterialized bool
+	// IsForeignTable is set for DROP FOREIGN TABLE, since foreign tables are
+	// stored as views.
+	IsForeignTable bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -117,7 +120,11 @@ func (node *DropView) Format(ctx *FmtCtx) {
 	if node.IsMaterialized {
 		ctx.WriteString("MATERIALIZED ")
 	}
-	ctx.WriteString("VIEW ")
+	if node.IsForeignTable {
+		ctx.WriteString("FOREIGN TABLE ")
+	} else {
+		ctx.WriteString("VIEW ")
+	}
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
@@ -196,6 +203,33 @@ func (node *DropType) Format(ctx *FmtCtx) {
 	}
 }
 
+// DropDomain represents a DROP DOMAIN command.
+type DropDomain struct {
+	Names        []*UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropDomain{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP DOMAIN ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	for i := range node.Names {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(node.Names[i])
+	}
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
 // DropSchema represents a DROP SCHEMA command.
 type DropSchema struct {
 	Names        ObjectNamePrefixList
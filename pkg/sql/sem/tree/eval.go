@@ -779,6 +779,18 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &PlusPGLSNDecimalOp{},
 			Volatility: volatility.Immutable,
 		},
+		makeGeometricBinOp(types.Point, types.Point, types.Point, func(left, right Datum) Datum {
+			return NewDPoint(MustBeDPoint(left).Add(MustBeDPoint(right).Point))
+		}),
+		makeGeometricBinOp(types.Box, types.Point, types.Box, func(left, right Datum) Datum {
+			return NewDBox(MustBeDBox(left).Add(MustBeDPoint(right).Point))
+		}),
+		makeGeometricBinOp(types.Path, types.Point, types.Path, func(left, right Datum) Datum {
+			return NewDPath(MustBeDPath(left).Add(MustBeDPoint(right).Point))
+		}),
+		makeGeometricBinOp(types.Circle, types.Point, types.Circle, func(left, right Datum) Datum {
+			return NewDCircle(MustBeDCircle(left).Add(MustBeDPoint(right).Point))
+		}),
 	}},
 
 	treebin.Minus: {overloads: []*BinOp{
@@ -965,6 +977,18 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &MinusPGLSNOp{},
 			Volatility: volatility.Immutable,
 		},
+		makeGeometricBinOp(types.Point, types.Point, types.Point, func(left, right Datum) Datum {
+			return NewDPoint(MustBeDPoint(left).Sub(MustBeDPoint(right).Point))
+		}),
+		makeGeometricBinOp(types.Box, types.Point, types.Box, func(left, right Datum) Datum {
+			return NewDBox(MustBeDBox(left).Sub(MustBeDPoint(right).Point))
+		}),
+		makeGeometricBinOp(types.Path, types.Point, types.Path, func(left, right Datum) Datum {
+			return NewDPath(MustBeDPath(left).Sub(MustBeDPoint(right).Point))
+		}),
+		makeGeometricBinOp(types.Circle, types.Point, types.Circle, func(left, right Datum) Datum {
+			return NewDCircle(MustBeDCircle(left).Sub(MustBeDPoint(right).Point))
+		}),
 	}},
 
 	treebin.Mult: {overloads: []*BinOp{
@@ -1360,6 +1384,74 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			Volatility: volatility.Immutable,
 		},
 	}},
+
+	treebin.Distance: {overloads: []*BinOp{
+		makeGeometricDistanceOp(types.Point, types.Point, func(left, right Datum) float64 {
+			return MustBeDPoint(left).Distance(MustBeDPoint(right).Point)
+		}),
+		makeGeometricDistanceOp(types.Box, types.Box, func(left, right Datum) float64 {
+			return MustBeDBox(left).Distance(MustBeDBox(right).Box)
+		}),
+		makeGeometricDistanceOp(types.Path, types.Path, func(left, right Datum) float64 {
+			return MustBeDPath(left).Distance(MustBeDPath(right).Path)
+		}),
+		makeGeometricDistanceOp(types.Polygon, types.Polygon, func(left, right Datum) float64 {
+			return MustBeDPolygon(left).Distance(MustBeDPolygon(right).Polygon)
+		}),
+		makeGeometricDistanceOp(types.Circle, types.Circle, func(left, right Datum) float64 {
+			return MustBeDCircle(left).Distance(MustBeDCircle(right).Circle)
+		}),
+		makeGeometricDistanceOp(types.Point, types.Box, func(left, right Datum) float64 {
+			return MustBeDBox(right).DistanceToPoint(MustBeDPoint(left).Point)
+		}),
+		makeGeometricDistanceOp(types.Box, types.Point, func(left, right Datum) float64 {
+			return MustBeDBox(left).DistanceToPoint(MustBeDPoint(right).Point)
+		}),
+		makeGeometricDistanceOp(types.Point, types.Path, func(left, right Datum) float64 {
+			return MustBeDPath(right).DistanceToPoint(MustBeDPoint(left).Point)
+		}),
+		makeGeometricDistanceOp(types.Path, types.Point, func(left, right Datum) float64 {
+			return MustBeDPath(left).DistanceToPoint(MustBeDPoint(right).Point)
+		}),
+		makeGeometricDistanceOp(types.Point, types.Polygon, func(left, right Datum) float64 {
+			return MustBeDPolygon(right).DistanceToPoint(MustBeDPoint(left).Point)
+		}),
+		makeGeometricDistanceOp(types.Polygon, types.Point, func(left, right Datum) float64 {
+			return MustBeDPolygon(left).DistanceToPoint(MustBeDPoint(right).Point)
+		}),
+		makeGeometricDistanceOp(types.Point, types.Circle, func(left, right Datum) float64 {
+			return MustBeDCircle(right).DistanceToPoint(MustBeDPoint(left).Point)
+		}),
+		makeGeometricDistanceOp(types.Circle, types.Point, func(left, right Datum) float64 {
+			return MustBeDCircle(left).DistanceToPoint(MustBeDPoint(right).Point)
+		}),
+		makeGeometricDistanceOp(types.Polygon, types.Circle, func(left, right Datum) float64 {
+			return MustBeDCircle(right).DistanceToPolygon(MustBeDPolygon(left).Polygon)
+		}),
+		makeGeometricDistanceOp(types.Circle, types.Polygon, func(left, right Datum) float64 {
+			return MustBeDCircle(left).DistanceToPolygon(MustBeDPolygon(right).Polygon)
+		}),
+	}},
+}
+
+func makeGeometricBinOp(
+	left, right, ret *types.T, op func(left, right Datum) Datum,
+) *BinOp {
+	return &BinOp{
+		LeftType:   left,
+		RightType:  right,
+		ReturnType: ret,
+		EvalOp:     &GeometricOp{Op: op},
+		Volatility: volatility.Immutable,
+	}
+}
+
+func makeGeometricDistanceOp(
+	left, right *types.T, dist func(left, right Datum) float64,
+) *BinOp {
+	return makeGeometricBinOp(left, right, types.Float, func(left, right Datum) Datum {
+		return NewDFloat(DFloat(dist(left, right)))
+	})
 }
 
 // CmpOp is a comparison operator.
@@ -1534,6 +1626,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEqFn(types.AnyCollatedString, types.AnyCollatedString, volatility.Leakproof),
 		makeEqFn(types.Float, types.Float, volatility.Leakproof),
 		makeEqFn(types.Box2D, types.Box2D, volatility.Leakproof),
+		makeEqFn(types.Box, types.Box, volatility.Leakproof),
+		makeEqFn(types.Circle, types.Circle, volatility.Leakproof),
 		makeEqFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeEqFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeEqFn(types.INet, types.INet, volatility.Leakproof),
@@ -1542,6 +1636,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEqFn(types.Jsonb, types.Jsonb, volatility.Immutable),
 		makeEqFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeEqFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
+		makeEqFn(types.Path, types.Path, volatility.Leakproof),
+		makeEqFn(types.Point, types.Point, volatility.Leakproof),
+		makeEqFn(types.Polygon, types.Polygon, volatility.Leakproof),
 		makeEqFn(types.String, types.String, volatility.Leakproof),
 		makeEqFn(types.Time, types.Time, volatility.Leakproof),
 		makeEqFn(types.TimeTZ, types.TimeTZ, volatility.Leakproof),
@@ -1594,6 +1691,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLtFn(types.AnyCollatedString, types.AnyCollatedString, volatility.Leakproof),
 		makeLtFn(types.Float, types.Float, volatility.Leakproof),
 		makeLtFn(types.Box2D, types.Box2D, volatility.Leakproof),
+		makeLtFn(types.Box, types.Box, volatility.Leakproof),
+		makeLtFn(types.Circle, types.Circle, volatility.Leakproof),
 		makeLtFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeLtFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeLtFn(types.INet, types.INet, volatility.Leakproof),
@@ -1601,6 +1700,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLtFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeLtFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeLtFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
+		makeLtFn(types.Path, types.Path, volatility.Leakproof),
+		makeLtFn(types.Point, types.Point, volatility.Leakproof),
+		makeLtFn(types.Polygon, types.Polygon, volatility.Leakproof),
 		makeLtFn(types.String, types.String, volatility.Leakproof),
 		makeLtFn(types.Time, types.Time, volatility.Leakproof),
 		makeLtFn(types.TimeTZ, types.TimeTZ, volatility.Leakproof),
@@ -1652,6 +1754,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLeFn(types.AnyCollatedString, types.AnyCollatedString, volatility.Leakproof),
 		makeLeFn(types.Float, types.Float, volatility.Leakproof),
 		makeLeFn(types.Box2D, types.Box2D, volatility.Leakproof),
+		makeLeFn(types.Box, types.Box, volatility.Leakproof),
+		makeLeFn(types.Circle, types.Circle, volatility.Leakproof),
 		makeLeFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeLeFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeLeFn(types.INet, types.INet, volatility.Leakproof),
@@ -1659,6 +1763,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeLeFn(types.Interval, types.Interval, volatility.Leakproof),
 		makeLeFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeLeFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
+		makeLeFn(types.Path, types.Path, volatility.Leakproof),
+		makeLeFn(types.Point, types.Point, volatility.Leakproof),
+		makeLeFn(types.Polygon, types.Polygon, volatility.Leakproof),
 		makeLeFn(types.String, types.String, volatility.Leakproof),
 		makeLeFn(types.Time, types.Time, volatility.Leakproof),
 		makeLeFn(types.TimeTZ, types.TimeTZ, volatility.Leakproof),
@@ -1730,6 +1837,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeIsFn(types.AnyCollatedString, types.AnyCollatedString, volatility.Leakproof),
 		makeIsFn(types.Float, types.Float, volatility.Leakproof),
 		makeIsFn(types.Box2D, types.Box2D, volatility.Leakproof),
+		makeIsFn(types.Box, types.Box, volatility.Leakproof),
+		makeIsFn(types.Circle, types.Circle, volatility.Leakproof),
 		makeIsFn(types.Geography, types.Geography, volatility.Leakproof),
 		makeIsFn(types.Geometry, types.Geometry, volatility.Leakproof),
 		makeIsFn(types.INet, types.INet, volatility.Leakproof),
@@ -1738,6 +1847,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeIsFn(types.Jsonb, types.Jsonb, volatility.Immutable),
 		makeIsFn(types.Oid, types.Oid, volatility.Leakproof),
 		makeIsFn(types.PGLSN, types.PGLSN, volatility.Leakproof),
+		makeIsFn(types.Path, types.Path, volatility.Leakproof),
+		makeIsFn(types.Point, types.Point, volatility.Leakproof),
+		makeIsFn(types.Polygon, types.Polygon, volatility.Leakproof),
 		makeIsFn(types.String, types.String, volatility.Leakproof),
 		makeIsFn(types.Time, types.Time, volatility.Leakproof),
 		makeIsFn(types.TimeTZ, types.TimeTZ, volatility.Leakproof),
@@ -1797,6 +1909,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEvalTupleIn(types.AnyTuple, volatility.Leakproof),
 		makeEvalTupleIn(types.Float, volatility.Leakproof),
 		makeEvalTupleIn(types.Box2D, volatility.Leakproof),
+		makeEvalTupleIn(types.Box, volatility.Leakproof),
+		makeEvalTupleIn(types.Circle, volatility.Leakproof),
 		makeEvalTupleIn(types.Geography, volatility.Leakproof),
 		makeEvalTupleIn(types.Geometry, volatility.Leakproof),
 		makeEvalTupleIn(types.INet, volatility.Leakproof),
@@ -1805,6 +1919,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEvalTupleIn(types.Jsonb, volatility.Leakproof),
 		makeEvalTupleIn(types.Oid, volatility.Leakproof),
 		makeEvalTupleIn(types.PGLSN, volatility.Leakproof),
+		makeEvalTupleIn(types.Path, volatility.Leakproof),
+		makeEvalTupleIn(types.Point, volatility.Leakproof),
+		makeEvalTupleIn(types.Polygon, volatility.Leakproof),
 		makeEvalTupleIn(types.String, volatility.Leakproof),
 		makeEvalTupleIn(types.Time, volatility.Leakproof),
 		makeEvalTupleIn(types.TimeTZ, volatility.Leakproof),
@@ -1892,7 +2009,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
-	treecmp.Contains: {overloads: []*CmpOp{
+	treecmp.Contains: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1905,9 +2022,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainsJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, geometricContainsOperators...)},
 
-	treecmp.ContainedBy: {overloads: []*CmpOp{
+	treecmp.ContainedBy: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1920,7 +2037,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainedByJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeCommutedGeometricComparisonOperators(geometricContainsOperators)...)},
 	treecmp.Overlaps: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
@@ -1934,11 +2051,11 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &OverlapsINetOp{},
 			Volatility: volatility.Immutable,
 		},
-	}, makeBox2DComparisonOperators(
+	}, append(makeBox2DComparisonOperators(
 		func(lhs, rhs *geo.CartesianBoundingBox) bool {
 			return lhs.Intersects(rhs)
 		},
-	)...),
+	), geometricOverlapsOperators...)...),
 	},
 	treecmp.TSMatches: {overloads: []*CmpOp{
 		{
@@ -1953,6 +2070,20 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &TSMatchesVectorQueryOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathMatchOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
+	treecmp.JSONPathExists: {overloads: []*CmpOp{
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathExistsOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 })
 
@@ -2005,6 +2136,69 @@ func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bo
 	}
 }
 
+func makeGeometricComparisonOperator(
+	left, right *types.T, op func(left, right Datum) bool,
+) *CmpOp {
+	return &CmpOp{
+		LeftType:   left,
+		RightType:  right,
+		EvalOp:     &CompareGeometricOp{Op: op},
+		Volatility: volatility.Immutable,
+	}
+}
+
+// makeCommutedGeometricComparisonOperators returns the operators which
+// evaluate the given geometric comparison operators with their operands
+// swapped, such as <@ for @>.
+func makeCommutedGeometricComparisonOperators(ops []*CmpOp) []*CmpOp {
+	res := make([]*CmpOp, len(ops))
+	for i, o := range ops {
+		op := o.EvalOp.(*CompareGeometricOp).Op
+		res[i] = makeGeometricComparisonOperator(o.RightType, o.LeftType, func(left, right Datum) bool {
+			return op(right, left)
+		})
+	}
+	return res
+}
+
+// geometricContainsOperators are the overloads of @> for the geometric types.
+var geometricContainsOperators = []*CmpOp{
+	makeGeometricComparisonOperator(types.Box, types.Box, func(left, right Datum) bool {
+		return MustBeDBox(left).Contains(MustBeDBox(right).Box)
+	}),
+	makeGeometricComparisonOperator(types.Box, types.Point, func(left, right Datum) bool {
+		return MustBeDBox(left).ContainsPoint(MustBeDPoint(right).Point)
+	}),
+	makeGeometricComparisonOperator(types.Circle, types.Circle, func(left, right Datum) bool {
+		return MustBeDCircle(left).Contains(MustBeDCircle(right).Circle)
+	}),
+	makeGeometricComparisonOperator(types.Circle, types.Point, func(left, right Datum) bool {
+		return MustBeDCircle(left).ContainsPoint(MustBeDPoint(right).Point)
+	}),
+	makeGeometricComparisonOperator(types.Path, types.Point, func(left, right Datum) bool {
+		return MustBeDPath(left).ContainsPoint(MustBeDPoint(right).Point)
+	}),
+	makeGeometricComparisonOperator(types.Polygon, types.Point, func(left, right Datum) bool {
+		return MustBeDPolygon(left).ContainsPoint(MustBeDPoint(right).Point)
+	}),
+	makeGeometricComparisonOperator(types.Polygon, types.Polygon, func(left, right Datum) bool {
+		return MustBeDPolygon(left).Contains(MustBeDPolygon(right).Polygon)
+	}),
+}
+
+// geometricOverlapsOperators are the overloads of && for the geometric types.
+var geometricOverlapsOperators = []*CmpOp{
+	makeGeometricComparisonOperator(types.Box, types.Box, func(left, right Datum) bool {
+		return MustBeDBox(left).Overlaps(MustBeDBox(right).Box)
+	}),
+	makeGeometricComparisonOperator(types.Circle, types.Circle, func(left, right Datum) bool {
+		return MustBeDCircle(left).Overlaps(MustBeDCircle(right).Circle)
+	}),
+	makeGeometricComparisonOperator(types.Polygon, types.Polygon, func(left, right Datum) bool {
+		return MustBeDPolygon(left).Overlaps(MustBeDPolygon(right).Polygon)
+	}),
+}
+
 // This map contains the inverses for operators in the CmpOps map that have
 // inverses.
 var cmpOpsInverse map[treecmp.ComparisonOperatorSymbol]treecmp.ComparisonOperatorSymbol
@@ -32,6 +32,16 @@ type CompareBox2DOp struct {
 	Op func(left, right Datum) bool
 }
 
+// CompareGeometricOp is a BinaryEvalOp.
+type CompareGeometricOp struct {
+	Op func(left, right Datum) bool
+}
+
+// GeometricOp is a BinaryEvalOp.
+type GeometricOp struct {
+	Op func(left, right Datum) Datum
+}
+
 // InTupleOp is a BinaryEvalOp.
 type InTupleOp struct{}
 
@@ -364,6 +374,12 @@ type (
 // JSONExistsOp is a BinaryEvalOp.
 type JSONExistsOp struct{}
 
+// JSONPathExistsOp is a BinaryEvalOp.
+type JSONPathExistsOp struct{}
+
+// JSONPathMatchOp is a BinaryEvalOp.
+type JSONPathMatchOp struct{}
+
 // JSONSomeExistsOp is a BinaryEvalOp.
 type JSONSomeExistsOp struct{}
 
@@ -136,6 +136,11 @@ func (node *DBool) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DBox) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DBox2D) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -146,6 +151,11 @@ func (node *DBytes) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DCircle) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DCollatedString) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -206,6 +216,11 @@ func (node *DJSON) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DJsonpath) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DOid) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -221,6 +236,21 @@ func (node *DPGLSN) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DPath) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DPoint) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
+// Eval is part of the TypedExpr interface.
+func (node *DPolygon) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DString) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
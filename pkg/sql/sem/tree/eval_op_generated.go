@@ -65,6 +65,7 @@ type BinaryOpEvaluator interface {
 	EvalBitXorIntOp(context.Context, *BitXorIntOp, Datum, Datum) (Datum, error)
 	EvalBitXorVarBitOp(context.Context, *BitXorVarBitOp, Datum, Datum) (Datum, error)
 	EvalCompareBox2DOp(context.Context, *CompareBox2DOp, Datum, Datum) (Datum, error)
+	EvalCompareGeometricOp(context.Context, *CompareGeometricOp, Datum, Datum) (Datum, error)
 	EvalCompareScalarOp(context.Context, *CompareScalarOp, Datum, Datum) (Datum, error)
 	EvalCompareTupleOp(context.Context, *CompareTupleOp, Datum, Datum) (Datum, error)
 	EvalConcatArraysOp(context.Context, *ConcatArraysOp, Datum, Datum) (Datum, error)
@@ -89,6 +90,7 @@ type BinaryOpEvaluator interface {
 	EvalFloorDivFloatOp(context.Context, *FloorDivFloatOp, Datum, Datum) (Datum, error)
 	EvalFloorDivIntDecimalOp(context.Context, *FloorDivIntDecimalOp, Datum, Datum) (Datum, error)
 	EvalFloorDivIntOp(context.Context, *FloorDivIntOp, Datum, Datum) (Datum, error)
+	EvalGeometricOp(context.Context, *GeometricOp, Datum, Datum) (Datum, error)
 	EvalInTupleOp(context.Context, *InTupleOp, Datum, Datum) (Datum, error)
 	EvalJSONAllExistsOp(context.Context, *JSONAllExistsOp, Datum, Datum) (Datum, error)
 	EvalJSONExistsOp(context.Context, *JSONExistsOp, Datum, Datum) (Datum, error)
@@ -98,6 +100,8 @@ type BinaryOpEvaluator interface {
 	EvalJSONFetchValIntOp(context.Context, *JSONFetchValIntOp, Datum, Datum) (Datum, error)
 	EvalJSONFetchValPathOp(context.Context, *JSONFetchValPathOp, Datum, Datum) (Datum, error)
 	EvalJSONFetchValStringOp(context.Context, *JSONFetchValStringOp, Datum, Datum) (Datum, error)
+	EvalJSONPathExistsOp(context.Context, *JSONPathExistsOp, Datum, Datum) (Datum, error)
+	EvalJSONPathMatchOp(context.Context, *JSONPathMatchOp, Datum, Datum) (Datum, error)
 	EvalJSONSomeExistsOp(context.Context, *JSONSomeExistsOp, Datum, Datum) (Datum, error)
 	EvalLShiftINetOp(context.Context, *LShiftINetOp, Datum, Datum) (Datum, error)
 	EvalLShiftIntOp(context.Context, *LShiftIntOp, Datum, Datum) (Datum, error)
@@ -295,6 +299,11 @@ func (op *CompareBox2DOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (
 	return e.EvalCompareBox2DOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *CompareGeometricOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalCompareGeometricOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *CompareScalarOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalCompareScalarOp(ctx, op, a, b)
@@ -415,6 +424,11 @@ func (op *FloorDivIntOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (D
 	return e.EvalFloorDivIntOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *GeometricOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalGeometricOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *InTupleOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalInTupleOp(ctx, op, a, b)
@@ -460,6 +474,16 @@ func (op *JSONFetchValStringOp) Eval(ctx context.Context, e OpEvaluator, a, b Da
 	return e.EvalJSONFetchValStringOp(ctx, op, a, b)
 }
 
+// Eval is part of the BinaryEvalOp interface.
+func (op *JSONPathExistsOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalJSONPathExistsOp(ctx, op, a, b)
+}
+
+// Eval is part of the BinaryEvalOp interface.
+func (op *JSONPathMatchOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
+	return e.EvalJSONPathMatchOp(ctx, op, a, b)
+}
+
 // Eval is part of the BinaryEvalOp interface.
 func (op *JSONSomeExistsOp) Eval(ctx context.Context, e OpEvaluator, a, b Datum) (Datum, error) {
 	return e.EvalJSONSomeExistsOp(ctx, op, a, b)
@@ -1047,6 +1047,7 @@ var binaryOpPrio = [...]int{
 	treebin.Bitxor: 6,
 	treebin.Bitor:  7,
 	treebin.Concat: 8, treebin.JSONFetchVal: 8, treebin.JSONFetchText: 8, treebin.JSONFetchValPath: 8, treebin.JSONFetchTextPath: 8,
+	treebin.Distance: 8,
 }
 
 // binaryOpFullyAssoc indicates whether an operator is fully associative.
@@ -1060,6 +1061,7 @@ var binaryOpFullyAssoc = [...]bool{
 	treebin.Bitxor: true,
 	treebin.Bitor:  true,
 	treebin.Concat: true, treebin.JSONFetchVal: false, treebin.JSONFetchText: false, treebin.JSONFetchValPath: false, treebin.JSONFetchTextPath: false,
+	treebin.Distance: false,
 }
 
 // BinaryExpr represents a binary value expression.
@@ -1252,6 +1254,22 @@ func NewTypedUnaryExpr(op UnaryOperator, expr TypedExpr, typ *types.T) *UnaryExp
 	return node
 }
 
+// GroupingFuncExpr represents a GROUPING(...) expression, which returns a
+// bitmask indicating which of its arguments are not included in the grouping
+// set of the current output row. The optimizer replaces it with a reference to
+// a column computed by the aggregation, so it is never type checked or
+// evaluated directly.
+type GroupingFuncExpr struct {
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingFuncExpr) Format(ctx *FmtCtx) {
+	ctx.WriteString("GROUPING(")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // FuncExpr represents a function call.
 type FuncExpr struct {
 	Func  ResolvableFunctionReference
@@ -1266,6 +1284,10 @@ type FuncExpr struct {
 	// OrderBy is used for aggregations which specify an order. This same field
 	// is used for any type of aggregation.
 	OrderBy OrderBy
+	// Variadic is true if the last argument is marked VARIADIC, in which case
+	// it is an array that is passed directly to the VARIADIC parameter of the
+	// function: f(a, VARIADIC ARRAY[b, c]).
+	Variadic bool
 
 	typeAnnotation
 	fnProps *FunctionProperties
@@ -1376,7 +1398,18 @@ func (node *FuncExpr) Format(ctx *FmtCtx) {
 
 	ctx.WriteByte('(')
 	ctx.WriteString(typ)
-	ctx.FormatNode(&node.Exprs)
+	if node.Variadic && len(node.Exprs) > 0 {
+		last := len(node.Exprs) - 1
+		if last > 0 {
+			fixed := node.Exprs[:last]
+			ctx.FormatNode(&fixed)
+			ctx.WriteString(", ")
+		}
+		ctx.WriteString("VARIADIC ")
+		ctx.FormatNode(node.Exprs[last])
+	} else {
+		ctx.FormatNode(&node.Exprs)
+	}
 	if node.AggType == GeneralAgg && len(node.OrderBy) > 0 {
 		ctx.WriteByte(' ')
 		ctx.FormatNode(&node.OrderBy)
@@ -1727,6 +1760,11 @@ func (node *DDecimal) String() string         { return AsString(node) }
 func (node *DFloat) String() string           { return AsString(node) }
 func (node *DBox2D) String() string           { return AsString(node) }
 func (node *DPGLSN) String() string           { return AsString(node) }
+func (node *DPoint) String() string           { return AsString(node) }
+func (node *DBox) String() string             { return AsString(node) }
+func (node *DPath) String() string            { return AsString(node) }
+func (node *DPolygon) String() string         { return AsString(node) }
+func (node *DCircle) String() string          { return AsString(node) }
 func (node *DGeography) String() string       { return AsString(node) }
 func (node *DGeometry) String() string        { return AsString(node) }
 func (node *DInt) String() string             { return AsString(node) }
@@ -1746,6 +1784,8 @@ func (node *DVoid) String() string            { return AsString(node) }
 func (node *Exprs) String() string            { return AsString(node) }
 func (node *ArrayFlatten) String() string     { return AsString(node) }
 func (node *FuncExpr) String() string         { return AsString(node) }
+func (node *GroupingFuncExpr) String() string { return AsString(node) }
+func (node *GroupingSet) String() string      { return AsString(node) }
 func (node *IfExpr) String() string           { return AsString(node) }
 func (node *IfErrExpr) String() string        { return AsString(node) }
 func (node *IndexedVar) String() string       { return AsString(node) }
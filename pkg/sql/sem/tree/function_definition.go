@@ -258,7 +258,7 @@ func (fd *ResolvedFunctionDefinition) MatchOverload(
 ) (QualifiedOverload, error) {
 	matched := func(ol QualifiedOverload, schema string) bool {
 		if ol.IsUDF {
-			return schema == ol.Schema && (paramTypes == nil || ol.params().MatchIdentical(paramTypes))
+			return schema == ol.Schema && (paramTypes == nil || ol.RoutineParams().MatchIdentical(paramTypes))
 		}
 		return schema == ol.Schema && (paramTypes == nil || ol.params().Match(paramTypes))
 	}
@@ -0,0 +1,113 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// Merge represents a MERGE statement.
+type Merge struct {
+	With      *With
+	Table     TableExpr
+	Source    TableExpr
+	On        Expr
+	Whens     MergeWhens
+	Returning ReturningClause
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Merge) Format(ctx *FmtCtx) {
+	ctx.FormatNode(node.With)
+	ctx.WriteString("MERGE INTO ")
+	ctx.FormatNode(node.Table)
+	ctx.WriteString(" USING ")
+	ctx.FormatNode(node.Source)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.On)
+	for _, w := range node.Whens {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(w)
+	}
+	if HasReturningClause(node.Returning) {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Returning)
+	}
+}
+
+// MergeActionType is the type of action performed by a WHEN clause of a MERGE
+// statement.
+type MergeActionType int
+
+const (
+	// MergeDoNothing skips the row.
+	MergeDoNothing MergeActionType = iota
+	// MergeUpdate updates the matched target row.
+	MergeUpdate
+	// MergeDelete deletes the matched target row.
+	MergeDelete
+	// MergeInsert inserts a new target row.
+	MergeInsert
+)
+
+// MergeWhens represents the list of WHEN clauses of a MERGE statement.
+type MergeWhens []*MergeWhen
+
+// MergeWhen represents a `WHEN [NOT] MATCHED [AND cond] THEN action` clause of
+// a MERGE statement.
+type MergeWhen struct {
+	// Matched is true for WHEN MATCHED clauses and false for WHEN NOT MATCHED
+	// clauses.
+	Matched bool
+	// Cond is the optional additional condition of the clause.
+	Cond   Expr
+	Action MergeActionType
+	// Exprs are the SET expressions of an UPDATE action.
+	Exprs UpdateExprs
+	// Columns is the optional column list of an INSERT action.
+	Columns NameList
+	// Values are the values of an INSERT action. It is nil for INSERT DEFAULT
+	// VALUES.
+	Values Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *MergeWhen) Format(ctx *FmtCtx) {
+	if node.Matched {
+		ctx.WriteString("WHEN MATCHED")
+	} else {
+		ctx.WriteString("WHEN NOT MATCHED")
+	}
+	if node.Cond != nil {
+		ctx.WriteString(" AND ")
+		ctx.FormatNode(node.Cond)
+	}
+	ctx.WriteString(" THEN ")
+	switch node.Action {
+	case MergeDoNothing:
+		ctx.WriteString("DO NOTHING")
+	case MergeUpdate:
+		ctx.WriteString("UPDATE SET ")
+		ctx.FormatNode(&node.Exprs)
+	case MergeDelete:
+		ctx.WriteString("DELETE")
+	case MergeInsert:
+		ctx.WriteString("INSERT")
+		if node.Columns != nil {
+			ctx.WriteString(" (")
+			ctx.FormatNode(&node.Columns)
+			ctx.WriteByte(')')
+		}
+		if node.Values == nil {
+			ctx.WriteString(" DEFAULT VALUES")
+		} else {
+			ctx.WriteString(" VALUES (")
+			ctx.FormatNode(&node.Values)
+			ctx.WriteByte(')')
+		}
+	}
+}
@@ -211,6 +211,9 @@ type Overload struct {
 	// IsUDF is set to true when this is a user-defined function overload built
 	// using CREATE FUNCTION. Note: Body can be empty even if IsUDF is true.
 	IsUDF bool
+	// IsProcedure is set to true when this is a user-defined procedure overload
+	// built using CREATE PROCEDURE. Procedures can only be invoked with CALL.
+	IsProcedure bool
 	// Body is the SQL string body of a function. It can be set even if IsUDF is
 	// false if a builtin function is defined using a SQL string.
 	Body string
@@ -227,11 +230,53 @@ type Overload struct {
 	// Language is the function language that was used to define the UDF.
 	// This is currently either SQL or PL/pgSQL.
 	Language FunctionLanguage
+	// VariadicParams is only set for user-defined functions with a VARIADIC
+	// parameter. In that case, Types is a VariadicType used to match the
+	// arguments of calls, and VariadicParams contains the input parameters as
+	// they are declared, the last one being the VARIADIC array parameter.
+	VariadicParams ParamTypes
+	// OutParams is only set for user-defined routines with OUT or INOUT
+	// parameters. It contains them in the order they are declared.
+	OutParams []RoutineOutParam
+	// UserDefinedAggregate is only set for aggregates created with CREATE
+	// AGGREGATE. It describes the support functions of the aggregate.
+	UserDefinedAggregate *UserDefinedAggregate
 }
 
 // params implements the overloadImpl interface.
 func (b Overload) params() TypeList { return b.Types }
 
+// RoutineParams returns the input parameters of a user-defined function as
+// they are declared. The trailing arguments of a call to a variadic function
+// are collected into an array which is bound to its last parameter.
+func (b Overload) RoutineParams() ParamTypes {
+	if b.VariadicParams != nil {
+		return b.VariadicParams
+	}
+	params, _ := b.Types.(ParamTypes)
+	return params
+}
+
+// SetRoutineParams sets the input parameters of a user-defined function
+// overload. If variadic is true, the last parameter is the VARIADIC array
+// parameter of the function.
+func (b *Overload) SetRoutineParams(params ParamTypes, variadic bool) {
+	if !variadic {
+		b.Types = params
+		b.VariadicParams = nil
+		return
+	}
+	fixedTypes := make([]*types.T, len(params)-1)
+	for i := range fixedTypes {
+		fixedTypes[i] = params[i].Typ
+	}
+	b.Types = VariadicType{
+		FixedTypes: fixedTypes,
+		VarType:    params[len(params)-1].Typ.ArrayContents(),
+	}
+	b.VariadicParams = params
+}
+
 // returnType implements the overloadImpl interface.
 func (b Overload) returnType() ReturnTyper { return b.ReturnType }
 
@@ -531,7 +576,6 @@ func (v VariadicType) MatchAt(typ *types.T, i int) bool {
 }
 
 // MatchAtIdentical is part of the TypeList interface.
-// TODO(mgartner): This will be incorrect once we add support for variadic UDFs
 func (VariadicType) MatchAtIdentical(typ *types.T, i int) bool {
 	return true
 }
@@ -69,6 +69,16 @@ func ParseAndRequireString(
 		d, err = ParseDIntervalWithTypeMetadata(intervalStyle(ctx), s, itm)
 	case types.PGLSNFamily:
 		d, err = ParseDPGLSN(s)
+	case types.PointFamily:
+		d, err = ParseDPoint(s)
+	case types.BoxFamily:
+		d, err = ParseDBox(s)
+	case types.PathFamily:
+		d, err = ParseDPath(s)
+	case types.PolygonFamily:
+		d, err = ParseDPolygon(s)
+	case types.CircleFamily:
+		d, err = ParseDCircle(s)
 	case types.Box2DFamily:
 		d, err = ParseDBox2D(s)
 	case types.GeographyFamily:
@@ -77,6 +87,8 @@ func ParseAndRequireString(
 		d, err = ParseDGeometry(s)
 	case types.JsonFamily:
 		d, err = ParseDJSON(s)
+	case types.JsonpathFamily:
+		d, err = ParseDJsonpath(s)
 	case types.OidFamily:
 		if t.Oid() != oid.T_oid && s == ZeroOidValue {
 			d = WrapAsZeroOid(t)
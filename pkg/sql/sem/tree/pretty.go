@@ -694,6 +694,12 @@ func (node *Subquery) doc(p *PrettyCfg) pretty.Doc {
 
 func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 	d := p.Doc(node.Expr)
+	if node.Only {
+		d = pretty.Concat(
+			p.keywordWithText("", "ONLY", " "),
+			d,
+		)
+	}
 	if node.Lateral {
 		d = pretty.Concat(
 			p.keywordWithText("", "LATERAL", " "),
@@ -721,6 +727,29 @@ func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 			),
 		)
 	}
+	if node.Sample != nil {
+		d = p.nestUnder(d, p.Doc(node.Sample))
+	}
+	return d
+}
+
+func (node *TableSample) doc(p *PrettyCfg) pretty.Doc {
+	d := pretty.Concat(
+		p.keywordWithText("", "TABLESAMPLE", " "),
+		pretty.Concat(
+			pretty.Keyword(node.Method.String()),
+			p.bracket(" (", p.Doc(node.Percent), ")"),
+		),
+	)
+	if node.Seed != nil {
+		d = pretty.ConcatSpace(
+			d,
+			pretty.Concat(
+				pretty.Keyword("REPEATABLE"),
+				p.bracket(" (", p.Doc(node.Seed), ")"),
+			),
+		)
+	}
 	return d
 }
 
@@ -728,7 +757,21 @@ func (node *FuncExpr) doc(p *PrettyCfg) pretty.Doc {
 	d := p.Doc(&node.Func)
 
 	if len(node.Exprs) > 0 {
-		args := node.Exprs.doc(p)
+		var args pretty.Doc
+		if node.Variadic {
+			docs := make([]pretty.Doc, len(node.Exprs))
+			for i, e := range node.Exprs {
+				if p.Simplify {
+					e = StripParens(e)
+				}
+				docs[i] = p.Doc(e)
+			}
+			last := len(docs) - 1
+			docs[last] = pretty.ConcatSpace(pretty.Keyword("VARIADIC"), docs[last])
+			args = p.commaSeparated(docs...)
+		} else {
+			args = node.Exprs.doc(p)
+		}
 		if node.Type != 0 {
 			args = pretty.ConcatLine(
 				pretty.Text(funcTypeName[node.Type]),
@@ -1290,6 +1333,12 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	if node.As() {
 		clauses = append(clauses, p.Doc(node.AsSource))
 	}
+	if len(node.Inherits) > 0 {
+		clauses = append(clauses, pretty.ConcatSpace(
+			pretty.Keyword("INHERITS"),
+			p.bracket("(", p.Doc(&node.Inherits), ")"),
+		))
+	}
 	if node.PartitionByTable != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByTable))
 	}
@@ -1822,6 +1871,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionByIndex != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByIndex))
 	}
+	if node.Deferrability.IsDeferrable() {
+		clauses = append(clauses, pretty.Keyword(node.Deferrability.String()))
+	}
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
@@ -1887,6 +1939,10 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if node.Deferrability.IsDeferrable() {
+		clauses = append(clauses, pretty.Keyword(node.Deferrability.String()))
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
@@ -2026,6 +2082,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if node.Unique.WithoutIndex {
 			pkConstraint = pretty.ConcatSpace(pkConstraint, pretty.Keyword("WITHOUT INDEX"))
 		}
+		if node.Unique.Deferrability.IsDeferrable() {
+			pkConstraint = pretty.ConcatSpace(pkConstraint, pretty.Keyword(node.Unique.Deferrability.String()))
+		}
 	}
 	if pkConstraint != pretty.Nil {
 		clauses = append(clauses, p.maybePrependConstraintName(&node.Unique.ConstraintName, pkConstraint))
@@ -2073,6 +2132,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if ref := p.Doc(&node.References.Actions); ref != pretty.Nil {
 			fkDetails = append(fkDetails, ref)
 		}
+		if node.References.Deferrability.IsDeferrable() {
+			fkDetails = append(fkDetails, pretty.Keyword(node.References.Deferrability.String()))
+		}
 		fk := fkHead
 		if len(fkDetails) > 0 {
 			fk = p.nestUnder(fk, pretty.Group(pretty.Stack(fkDetails...)))
@@ -13,6 +13,8 @@ package tree
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -111,6 +113,11 @@ type RoutineExpr struct {
 
 	// Generator is true if the function may output a set of rows.
 	Generator bool
+
+	// ExceptionHandler holds the information needed to handle errors if an
+	// exception block was defined. It is nil if the routine has no exception
+	// block.
+	ExceptionHandler *RoutineExceptionHandler
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -123,6 +130,7 @@ func NewTypedRoutineExpr(
 	calledOnNullInput bool,
 	multiColOutput bool,
 	generator bool,
+	exceptionHandler *RoutineExceptionHandler,
 ) *RoutineExpr {
 	return &RoutineExpr{
 		Args:              args,
@@ -133,6 +141,7 @@ func NewTypedRoutineExpr(
 		CalledOnNullInput: calledOnNullInput,
 		MultiColOutput:    multiColOutput,
 		Generator:         generator,
+		ExceptionHandler:  exceptionHandler,
 	}
 }
 
@@ -160,3 +169,23 @@ func (node *RoutineExpr) Walk(v Visitor) Expr {
 	// Cannot walk into a routine, so this is a no-op.
 	return node
 }
+
+// ErrRoutineReRaise is returned by a PL/pgSQL RAISE statement without
+// parameters. The exception handler that is executing the statement replaces
+// it with the error that it caught.
+var ErrRoutineReRaise = pgerror.New(
+	pgcode.StackedDiagnosticsAccessedWithoutActiveHandler,
+	"RAISE without parameters cannot be used outside an exception handler",
+)
+
+// RoutineExceptionHandler encapsulates the information needed to match and
+// handle errors for the exception block of a routine defined with PL/pgSQL.
+type RoutineExceptionHandler struct {
+	// Codes is a list of pgcodes that the exception handler matches against.
+	// The i-th code is handled by the i-th routine in Actions.
+	Codes []pgcode.Code
+
+	// Actions contains a routine to handle each error code. Each action is
+	// invoked with the same arguments as the routine that threw the error.
+	Actions []*RoutineExpr
+}
@@ -21,6 +21,7 @@ package tree
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -573,6 +574,10 @@ type AliasedTableExpr struct {
 	Ordinality bool
 	Lateral    bool
 	As         AliasClause
+	Sample     *TableSample
+	// Only is set for FROM ONLY, which excludes the tables inheriting from
+	// the table in Expr.
+	Only bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -580,6 +585,9 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 	if node.Lateral {
 		ctx.WriteString("LATERAL ")
 	}
+	if node.Only {
+		ctx.WriteString("ONLY ")
+	}
 	ctx.FormatNode(node.Expr)
 	if node.IndexFlags != nil {
 		ctx.FormatNode(node.IndexFlags)
@@ -591,6 +599,65 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(&node.As)
 	}
+	if node.Sample != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Sample)
+	}
+}
+
+// TableSampleMethod is the sampling method used by a TABLESAMPLE clause.
+type TableSampleMethod int
+
+const (
+	// TableSampleBernoulli selects each row of the table independently with
+	// the given probability.
+	TableSampleBernoulli TableSampleMethod = iota
+	// TableSampleSystem selects whole ranges of the table with the given
+	// probability, and skips reading the remaining ones entirely.
+	TableSampleSystem
+)
+
+var tableSampleMethodName = [...]string{
+	TableSampleBernoulli: "BERNOULLI",
+	TableSampleSystem:    "SYSTEM",
+}
+
+func (m TableSampleMethod) String() string {
+	return tableSampleMethodName[m]
+}
+
+// TableSampleMethodFromString returns the TableSampleMethod with the given
+// (case-insensitive) name.
+func TableSampleMethodFromString(s string) (TableSampleMethod, error) {
+	for m, name := range tableSampleMethodName {
+		if strings.EqualFold(s, name) {
+			return TableSampleMethod(m), nil
+		}
+	}
+	return 0, pgerror.Newf(pgcode.UndefinedObject, "tablesample method %s does not exist", s)
+}
+
+// TableSample represents a TABLESAMPLE clause applied to a table.
+type TableSample struct {
+	Method TableSampleMethod
+	// Percent is the percentage of the table that is to be returned.
+	Percent Expr
+	// Seed is the argument of the REPEATABLE clause, or nil if there is none.
+	Seed Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TableSample) Format(ctx *FmtCtx) {
+	ctx.WriteString("TABLESAMPLE ")
+	ctx.WriteString(node.Method.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(node.Percent)
+	ctx.WriteByte(')')
+	if node.Seed != nil {
+		ctx.WriteString(" REPEATABLE (")
+		ctx.FormatNode(node.Seed)
+		ctx.WriteByte(')')
+	}
 }
 
 // ParenTableExpr represents a parenthesized TableExpr.
@@ -758,6 +825,46 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetType represents the kind of a GroupingSet.
+type GroupingSetType int
+
+const (
+	// RollupGroupingSet represents ROLLUP (...).
+	RollupGroupingSet GroupingSetType = iota
+	// CubeGroupingSet represents CUBE (...).
+	CubeGroupingSet
+	// SetsGroupingSet represents GROUPING SETS (...).
+	SetsGroupingSet
+)
+
+var groupingSetTypeName = [...]string{
+	RollupGroupingSet: "ROLLUP",
+	CubeGroupingSet:   "CUBE",
+	SetsGroupingSet:   "GROUPING SETS",
+}
+
+func (t GroupingSetType) String() string {
+	return groupingSetTypeName[t]
+}
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. For ROLLUP and CUBE, each element of Exprs is either a single
+// grouping expression or a Tuple of expressions that are treated as a unit.
+// For GROUPING SETS, each element is a grouping expression, a Tuple (the empty
+// Tuple denotes the empty grouping set), or a nested GroupingSet.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
@@ -123,6 +123,31 @@ func (node *SetSessionCharacteristics) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.Modes)
 }
 
+// SetConstraints represents a SET CONSTRAINTS statement.
+type SetConstraints struct {
+	// Names is the list of constraints to which the statement applies. It is
+	// empty when the statement applies to ALL deferrable constraints.
+	Names NameList
+	// Deferred is true for SET CONSTRAINTS ... DEFERRED and false for
+	// SET CONSTRAINTS ... IMMEDIATE.
+	Deferred bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *SetConstraints) Format(ctx *FmtCtx) {
+	ctx.WriteString("SET CONSTRAINTS ")
+	if len(node.Names) == 0 {
+		ctx.WriteString("ALL")
+	} else {
+		ctx.FormatNode(&node.Names)
+	}
+	if node.Deferred {
+		ctx.WriteString(" DEFERRED")
+	} else {
+		ctx.WriteString(" IMMEDIATE")
+	}
+}
+
 // SetTracing represents a SET TRACING statement.
 type SetTracing struct {
 	Values Exprs
@@ -167,6 +167,9 @@ func CanWriteData(stmt Statement) bool {
 	// Normal write operations.
 	case *Insert, *Delete, *Update, *Truncate:
 		return true
+	// Procedures may write data.
+	case *Call:
+		return true
 	// Import operations.
 	case *CopyFrom, *Import, *Restore:
 		return true
@@ -533,6 +536,17 @@ func (*AlterTenantService) StatementType() StatementType { return TypeDCL }
 // StatementTag returns a short string identifying the type of statement.
 func (*AlterTenantService) StatementTag() string { return "ALTER VIRTUAL CLUSTER SERVICE" }
 
+// StatementReturnType implements the Statement interface.
+func (*AlterDomain) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterDomain) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*AlterDomain) StatementTag() string { return "ALTER DOMAIN" }
+
+func (*AlterDomain) hiddenFromShowQueries() {}
+
 // StatementReturnType implements the Statement interface.
 func (*AlterType) StatementReturnType() StatementReturnType { return DDL }
 
@@ -730,6 +744,20 @@ func (*CommentOnConstraint) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*CommentOnConstraint) StatementTag() string { return "COMMENT ON CONSTRAINT" }
 
+// StatementReturnType implements the Statement interface.
+func (*CommentOnFunction) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CommentOnFunction) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (n *CommentOnFunction) StatementTag() string {
+	if n.IsProcedure {
+		return "COMMENT ON PROCEDURE"
+	}
+	return "COMMENT ON FUNCTION"
+}
+
 // StatementReturnType implements the Statement interface.
 func (*CommentOnDatabase) StatementReturnType() StatementReturnType { return DDL }
 
@@ -931,6 +959,17 @@ func (*CreateType) StatementTag() string { return "CREATE TYPE" }
 
 func (*CreateType) modifiesSchema() bool { return true }
 
+// StatementReturnType implements the Statement interface.
+func (*CreateDomain) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateDomain) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*CreateDomain) StatementTag() string { return "CREATE DOMAIN" }
+
+func (*CreateDomain) modifiesSchema() bool { return true }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateRole) StatementReturnType() StatementReturnType { return Ack }
 
@@ -951,6 +990,17 @@ func (*CreateView) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*CreateView) StatementTag() string { return "CREATE VIEW" }
 
+// StatementReturnType implements the Statement interface.
+func (*CreateForeignTable) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateForeignTable) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateForeignTable) StatementTag() string { return "CREATE FOREIGN TABLE" }
+
+func (*CreateForeignTable) modifiesSchema() bool { return true }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateSequence) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1051,7 +1101,12 @@ func (*DropView) StatementReturnType() StatementReturnType { return DDL }
 func (*DropView) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*DropView) StatementTag() string { return "DROP VIEW" }
+func (n *DropView) StatementTag() string {
+	if n.IsForeignTable {
+		return "DROP FOREIGN TABLE"
+	}
+	return "DROP VIEW"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*DropSequence) StatementReturnType() StatementReturnType { return DDL }
@@ -1082,6 +1137,15 @@ func (*DropType) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropType) StatementTag() string { return "DROP TYPE" }
 
+// StatementReturnType implements the Statement interface.
+func (*DropDomain) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropDomain) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropDomain) StatementTag() string { return "DROP DOMAIN" }
+
 // StatementReturnType implements the Statement interface.
 func (*DropSchema) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1194,6 +1258,24 @@ func (*Import) StatementTag() string { return ImportTag }
 
 func (*Import) cclOnlyStatement() {}
 
+// StatementReturnType implements the Statement interface.
+func (*Listen) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*Listen) StatementType() StatementType { return TypeTCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Listen) StatementTag() string { return "LISTEN" }
+
+// StatementReturnType implements the Statement interface.
+func (n *Merge) StatementReturnType() StatementReturnType { return n.Returning.statementReturnType() }
+
+// StatementType implements the Statement interface.
+func (*Merge) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Merge) StatementTag() string { return "MERGE" }
+
 // StatementReturnType implements the Statement interface.
 func (*LiteralValuesClause) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1203,6 +1285,15 @@ func (*LiteralValuesClause) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*LiteralValuesClause) StatementTag() string { return "VALUES" }
 
+// StatementReturnType implements the Statement interface.
+func (*Notify) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*Notify) StatementType() StatementType { return TypeTCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Notify) StatementTag() string { return "NOTIFY" }
+
 // StatementReturnType implements the Statement interface.
 func (*ParenSelect) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1455,6 +1546,15 @@ func (*SetClusterSetting) StatementType() StatementType { return TypeDCL }
 // StatementTag returns a short string identifying the type of statement.
 func (*SetClusterSetting) StatementTag() string { return "SET CLUSTER SETTING" }
 
+// StatementReturnType implements the Statement interface.
+func (*SetConstraints) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*SetConstraints) StatementType() StatementType { return TypeTCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*SetConstraints) StatementTag() string { return "SET CONSTRAINTS" }
+
 // StatementReturnType implements the Statement interface.
 func (*SetTransaction) StatementReturnType() StatementReturnType { return Ack }
 
@@ -2083,7 +2183,39 @@ func (*CreateFunction) StatementReturnType() StatementReturnType { return DDL }
 func (*CreateFunction) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*CreateFunction) StatementTag() string { return "CREATE FUNCTION" }
+func (n *CreateFunction) StatementTag() string {
+	if n.IsProcedure {
+		return "CREATE PROCEDURE"
+	}
+	return "CREATE FUNCTION"
+}
+
+// StatementReturnType implements the Statement interface.
+func (*CreateAggregate) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateAggregate) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateAggregate) StatementTag() string { return "CREATE AGGREGATE" }
+
+// StatementReturnType implements the Statement interface.
+func (*CreatePublication) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreatePublication) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreatePublication) StatementTag() string { return "CREATE PUBLICATION" }
+
+// StatementReturnType implements the Statement interface.
+func (*Call) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*Call) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Call) StatementTag() string { return "CALL" }
 
 // StatementReturnType implements the Statement interface.
 func (*RoutineReturn) StatementReturnType() StatementReturnType { return Rows }
@@ -2101,7 +2233,24 @@ func (*DropFunction) StatementReturnType() StatementReturnType { return DDL }
 func (*DropFunction) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*DropFunction) StatementTag() string { return "DROP FUNCTION" }
+func (n *DropFunction) StatementTag() string {
+	if n.IsProcedure {
+		return "DROP PROCEDURE"
+	}
+	if n.IsAggregate {
+		return "DROP AGGREGATE"
+	}
+	return "DROP FUNCTION"
+}
+
+// StatementReturnType implements the Statement interface.
+func (*DropPublication) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropPublication) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropPublication) StatementTag() string { return "DROP PUBLICATION" }
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionOptions) StatementReturnType() StatementReturnType { return DDL }
@@ -2119,7 +2268,9 @@ func (*AlterFunctionRename) StatementReturnType() StatementReturnType { return D
 func (*AlterFunctionRename) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*AlterFunctionRename) StatementTag() string { return "ALTER FUNCTION" }
+func (n *AlterFunctionRename) StatementTag() string {
+	return alterRoutineStatementTag(n.IsAggregate)
+}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionSetSchema) StatementReturnType() StatementReturnType { return DDL }
@@ -2128,7 +2279,9 @@ func (*AlterFunctionSetSchema) StatementReturnType() StatementReturnType { retur
 func (*AlterFunctionSetSchema) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*AlterFunctionSetSchema) StatementTag() string { return "ALTER FUNCTION" }
+func (n *AlterFunctionSetSchema) StatementTag() string {
+	return alterRoutineStatementTag(n.IsAggregate)
+}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionSetOwner) StatementReturnType() StatementReturnType { return DDL }
@@ -2137,7 +2290,16 @@ func (*AlterFunctionSetOwner) StatementReturnType() StatementReturnType { return
 func (*AlterFunctionSetOwner) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*AlterFunctionSetOwner) StatementTag() string { return "ALTER FUNCTION" }
+func (n *AlterFunctionSetOwner) StatementTag() string {
+	return alterRoutineStatementTag(n.IsAggregate)
+}
+
+func alterRoutineStatementTag(isAggregate bool) string {
+	if isAggregate {
+		return "ALTER AGGREGATE"
+	}
+	return "ALTER FUNCTION"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionDepExtension) StatementReturnType() StatementReturnType { return DDL }
@@ -2168,6 +2330,7 @@ func (n *AlterDatabaseSecondaryRegion) String() string        { return AsString(
 func (n *AlterDatabaseDropSecondaryRegion) String() string    { return AsString(n) }
 func (n *AlterDatabaseSetZoneConfigExtension) String() string { return AsString(n) }
 func (n *AlterDefaultPrivileges) String() string              { return AsString(n) }
+func (n *AlterDomain) String() string                         { return AsString(n) }
 func (n *AlterFunctionOptions) String() string                { return AsString(n) }
 func (n *AlterFunctionRename) String() string                 { return AsString(n) }
 func (n *AlterFunctionSetSchema) String() string              { return AsString(n) }
@@ -2205,12 +2368,14 @@ func (n *ControlJobs) String() string                         { return AsString(
 func (n *ControlSchedules) String() string                    { return AsString(n) }
 func (n *ControlJobsForSchedules) String() string             { return AsString(n) }
 func (n *ControlJobsOfType) String() string                   { return AsString(n) }
+func (n *Call) String() string                                { return AsString(n) }
 func (n *CancelQueries) String() string                       { return AsString(n) }
 func (n *CancelSessions) String() string                      { return AsString(n) }
 func (n *CannedOptPlan) String() string                       { return AsString(n) }
 func (n *CloseCursor) String() string                         { return AsString(n) }
 func (n *CommentOnColumn) String() string                     { return AsString(n) }
 func (n *CommentOnConstraint) String() string                 { return AsString(n) }
+func (n *CommentOnFunction) String() string                   { return AsString(n) }
 func (n *CommentOnDatabase) String() string                   { return AsString(n) }
 func (n *CommentOnSchema) String() string                     { return AsString(n) }
 func (n *CommentOnIndex) String() string                      { return AsString(n) }
@@ -2218,11 +2383,14 @@ func (n *CommentOnTable) String() string                      { return AsString(
 func (n *CommitTransaction) String() string                   { return AsString(n) }
 func (n *CopyFrom) String() string                            { return AsString(n) }
 func (n *CopyTo) String() string                              { return AsString(n) }
+func (n *CreateAggregate) String() string                     { return AsString(n) }
 func (n *CreateChangefeed) String() string                    { return AsString(n) }
 func (n *CreateDatabase) String() string                      { return AsString(n) }
 func (n *CreateExtension) String() string                     { return AsString(n) }
+func (n *CreateForeignTable) String() string                  { return AsString(n) }
 func (n *CreateFunction) String() string                      { return AsString(n) }
 func (n *CreateIndex) String() string                         { return AsString(n) }
+func (n *CreatePublication) String() string                   { return AsString(n) }
 func (n *CreateRole) String() string                          { return AsString(n) }
 func (n *CreateTable) String() string                         { return AsString(n) }
 func (n *CreateTenant) String() string                        { return AsString(n) }
@@ -2235,9 +2403,11 @@ func (n *Deallocate) String() string                          { return AsString(
 func (n *Delete) String() string                              { return AsString(n) }
 func (n *DeclareCursor) String() string                       { return AsString(n) }
 func (n *DropDatabase) String() string                        { return AsString(n) }
+func (n *DropDomain) String() string                          { return AsString(n) }
 func (n *DropFunction) String() string                        { return AsString(n) }
 func (n *DropIndex) String() string                           { return AsString(n) }
 func (n *DropOwnedBy) String() string                         { return AsString(n) }
+func (n *DropPublication) String() string                     { return AsString(n) }
 func (n *DropSchema) String() string                          { return AsString(n) }
 func (n *DropSequence) String() string                        { return AsString(n) }
 func (n *DropTable) String() string                           { return AsString(n) }
@@ -2258,6 +2428,7 @@ func (n *MoveCursor) String() string                          { return AsString(
 func (n *Insert) String() string                              { return AsString(n) }
 func (n *Import) String() string                              { return AsString(n) }
 func (n *LiteralValuesClause) String() string                 { return AsString(n) }
+func (n *Merge) String() string                               { return AsString(n) }
 func (n *ParenSelect) String() string                         { return AsString(n) }
 func (n *Prepare) String() string                             { return AsString(n) }
 func (n *ReassignOwnedBy) String() string                     { return AsString(n) }
@@ -2283,6 +2454,7 @@ func (n *Scrub) String() string                               { return AsString(
 func (n *Select) String() string                              { return AsString(n) }
 func (n *SelectClause) String() string                        { return AsString(n) }
 func (n *SetClusterSetting) String() string                   { return AsString(n) }
+func (n *SetConstraints) String() string                      { return AsString(n) }
 func (n *SetZoneConfig) String() string                       { return AsString(n) }
 func (n *SetSessionAuthorizationDefault) String() string      { return AsString(n) }
 func (n *SetSessionCharacteristics) String() string           { return AsString(n) }
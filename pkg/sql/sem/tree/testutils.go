@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
@@ -83,6 +84,16 @@ func SampleDatum(t *types.T) Datum {
 		return NewDOid(1009)
 	case types.PGLSNFamily:
 		return NewDPGLSN(0x1000000100)
+	case types.PointFamily:
+		return NewDPoint(geometric.Point{X: 1, Y: 2})
+	case types.BoxFamily:
+		return NewDBox(geometric.MakeBox(geometric.Point{X: 1, Y: 2}, geometric.Point{X: 3, Y: 4}))
+	case types.PathFamily:
+		return NewDPath(geometric.Path{Points: []geometric.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}})
+	case types.PolygonFamily:
+		return NewDPolygon(geometric.Polygon{Points: []geometric.Point{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 3, Y: 2}}})
+	case types.CircleFamily:
+		return NewDCircle(geometric.Circle{Center: geometric.Point{X: 1, Y: 2}, Radius: 3})
 	case types.Box2DFamily:
 		b := geo.NewCartesianBoundingBox().AddPoint(1, 2).AddPoint(3, 4)
 		return NewDBox2D(*b)
@@ -61,6 +61,7 @@ const (
 	JSONFetchValPath
 	JSONFetchTextPath
 	TSMatch
+	Distance
 
 	NumBinaryOperatorSymbols
 )
@@ -86,6 +87,7 @@ var binaryOpName = [...]string{
 	JSONFetchValPath:  "#>",
 	JSONFetchTextPath: "#>>",
 	TSMatch:           "@@",
+	Distance:          "<->",
 }
 
 // IsPadded returns whether the binary operator needs to be padded.
@@ -892,6 +892,8 @@ var (
 	errInvalidDefaultUsage = pgerror.New(pgcode.Syntax, "DEFAULT can only appear in a VALUES list within INSERT or on the right side of a SET")
 	errInvalidMaxUsage     = pgerror.New(pgcode.Syntax, "MAXVALUE can only appear within a range partition expression")
 	errInvalidMinUsage     = pgerror.New(pgcode.Syntax, "MINVALUE can only appear within a range partition expression")
+	errInvalidGroupingSet  = pgerror.New(pgcode.Syntax, "ROLLUP, CUBE and GROUPING SETS can only appear within a GROUP BY clause")
+	errInvalidGroupingFunc = pgerror.New(pgcode.Grouping, "GROUPING() can only appear in the SELECT list, HAVING or ORDER BY of a grouping query")
 	errPrivateFunction     = pgerror.New(pgcode.ReservedName, "function reserved for internal use")
 )
 
@@ -1074,8 +1076,12 @@ func (expr *FuncExpr) TypeCheck(
 		}
 	}
 
+	overloads := def.Overloads
+	if expr.Variadic {
+		overloads = variadicCallOverloads(overloads)
+	}
 	s := getOverloadTypeChecker(
-		(*qualifiedOverloads)(&def.Overloads), expr.Exprs...,
+		(*qualifiedOverloads)(&overloads), expr.Exprs...,
 	)
 	defer s.release()
 	if err := s.typeCheckOverloadedExprs(ctx, semaCtx, desired, false); err != nil {
@@ -1085,13 +1091,13 @@ func (expr *FuncExpr) TypeCheck(
 	var hasUDFOverload bool
 	var calledOnNullInputFns, notCalledOnNullInputFns intsets.Fast
 	for _, idx := range s.overloadIdxs {
-		if def.Overloads[idx].CalledOnNullInput {
+		if overloads[idx].CalledOnNullInput {
 			calledOnNullInputFns.Add(int(idx))
 		} else {
 			notCalledOnNullInputFns.Add(int(idx))
 		}
 		// TODO(harding): Check if this is a record-returning UDF instead.
-		if def.Overloads[idx].IsUDF {
+		if overloads[idx].IsUDF {
 			hasUDFOverload = true
 		}
 	}
@@ -1111,7 +1117,7 @@ func (expr *FuncExpr) TypeCheck(
 			if s.typedExprs[i].ResolvedType().Family() == types.UnknownFamily {
 				var filtered intsets.Fast
 				for j, ok := notCalledOnNullInputFns.Next(0); ok; j, ok = notCalledOnNullInputFns.Next(j + 1) {
-					if def.Overloads[j].params().GetAt(i).Equivalent(types.String) {
+					if overloads[j].params().GetAt(i).Equivalent(types.String) {
 						filtered.Add(j)
 					}
 				}
@@ -1157,11 +1163,11 @@ func (expr *FuncExpr) TypeCheck(
 		// If the function is resolved by OID, we know that there is always only one
 		// overload qualified. As long as it passes the argument type checks above,
 		// there is no need to worry about the search path.
-		favoredOverload = def.Overloads[0]
+		favoredOverload = overloads[0]
 	} else {
 		// Get overloads from the most significant schema in search path.
 		favoredOverload, err = getMostSignificantOverload(
-			def.Overloads, s.overloads, s.overloadIdxs, searchPath, expr, s.typedExprs,
+			overloads, s.overloads, s.overloadIdxs, searchPath, expr, s.typedExprs,
 			func() string { return getFuncSig(expr, s.typedExprs, desired) },
 		)
 		if err != nil {
@@ -1255,6 +1261,23 @@ func (expr *FuncExpr) TypeCheck(
 	return expr, nil
 }
 
+// variadicCallOverloads returns the overloads that can be called with a last
+// argument marked VARIADIC, which are the user-defined functions with a
+// VARIADIC parameter. The returned overloads match the last argument against
+// the VARIADIC array parameter itself, rather than against its element type.
+func variadicCallOverloads(overloads []QualifiedOverload) []QualifiedOverload {
+	var res []QualifiedOverload
+	for _, o := range overloads {
+		if o.VariadicParams == nil {
+			continue
+		}
+		ol := *o.Overload
+		ol.Types = o.VariadicParams
+		res = append(res, QualifiedOverload{Schema: o.Schema, Overload: &ol})
+	}
+	return res
+}
+
 // TypeCheck implements the Expr interface.
 func (expr *IfErrExpr) TypeCheck(
 	ctx context.Context, semaCtx *SemaContext, desired *types.T,
@@ -1584,6 +1607,20 @@ func (expr PartitionMaxVal) TypeCheck(
 	return nil, errInvalidMaxUsage
 }
 
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, errInvalidGroupingSet
+}
+
+// TypeCheck implements the Expr interface.
+func (expr *GroupingFuncExpr) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, errInvalidGroupingFunc
+}
+
 // TypeCheck implements the Expr interface.
 func (expr *NumVal) TypeCheck(
 	ctx context.Context, semaCtx *SemaContext, desired *types.T,
@@ -1870,6 +1907,36 @@ func (d *DPGLSN) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (Typed
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DPoint) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DBox) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DPath) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DPolygon) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DCircle) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DGeography) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -1888,6 +1955,12 @@ func (d *DJSON) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedE
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DJsonpath) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTSQuery) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -12,6 +12,7 @@ package tree
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -79,7 +80,7 @@ func (f *FunctionName) FQString() string {
 
 func (f *FunctionName) objectName() {}
 
-// CreateFunction represents a CREATE FUNCTION statement.
+// CreateFunction represents a CREATE FUNCTION or CREATE PROCEDURE statement.
 type CreateFunction struct {
 	IsProcedure bool
 	Replace     bool
@@ -105,17 +106,24 @@ func (node *CreateFunction) Format(ctx *FmtCtx) {
 	if node.Replace {
 		ctx.WriteString("OR REPLACE ")
 	}
-	ctx.WriteString("FUNCTION ")
+	if node.IsProcedure {
+		ctx.WriteString("PROCEDURE ")
+	} else {
+		ctx.WriteString("FUNCTION ")
+	}
 	ctx.FormatNode(&node.FuncName)
 	ctx.WriteString("(")
 	ctx.FormatNode(node.Params)
 	ctx.WriteString(")\n\t")
-	ctx.WriteString("RETURNS ")
-	if node.ReturnType.IsSet {
-		ctx.WriteString("SETOF ")
+	// Procedures do not have a return type.
+	if !node.IsProcedure {
+		ctx.WriteString("RETURNS ")
+		if node.ReturnType.IsSet {
+			ctx.WriteString("SETOF ")
+		}
+		ctx.FormatTypeReference(node.ReturnType.Type)
+		ctx.WriteString("\n\t")
 	}
-	ctx.FormatTypeReference(node.ReturnType.Type)
-	ctx.WriteString("\n\t")
 	var funcBody FunctionBodyStr
 	for _, option := range node.Options {
 		switch t := option.(type) {
@@ -377,14 +385,72 @@ const (
 	FunctionParamVariadic
 )
 
+// IsInParam returns true if parameters of the class are inputs of the
+// function. Only input parameters are part of the function's signature.
+func (c FuncParamClass) IsInParam() bool {
+	return c != FunctionParamOut
+}
+
+// IsOutParam returns true if parameters of the class are outputs of the
+// function. Output parameters determine the function's return type.
+func (c FuncParamClass) IsOutParam() bool {
+	return c == FunctionParamOut || c == FunctionParamInOut
+}
+
+// ReturnTypeFromOutParams returns the return type implied by the OUT and INOUT
+// parameters of a function: the type of the output parameter if there is only
+// one, and RECORD if there are several. It returns an error if the function
+// has no output parameters.
+func ReturnTypeFromOutParams(params FuncParams) (ResolvableTypeReference, error) {
+	var returnType ResolvableTypeReference
+	numOutParams := 0
+	for i := range params {
+		if params[i].Class.IsOutParam() {
+			returnType = params[i].Type
+			numOutParams++
+		}
+	}
+	switch numOutParams {
+	case 0:
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "function result type must be specified")
+	case 1:
+		return returnType, nil
+	default:
+		return types.AnyTuple, nil
+	}
+}
+
+// OutParamColumnName returns the name of the result column of an output
+// parameter, given its name and its ordinal among the output parameters.
+// Unnamed output parameters are named column1, column2, etc., like in
+// Postgres.
+func OutParamColumnName(name string, ord int) string {
+	if name != "" {
+		return name
+	}
+	return fmt.Sprintf("column%d", ord+1)
+}
+
+// RoutineOutParam describes an OUT or INOUT parameter of a user-defined
+// routine.
+type RoutineOutParam struct {
+	ParamType
+	// InputOrd is the ordinal of an INOUT parameter among the input parameters
+	// of the routine, or -1 for an OUT parameter.
+	InputOrd int
+}
+
 // FuncReturnType represent the return type of UDF.
 type FuncReturnType struct {
 	Type  ResolvableTypeReference
 	IsSet bool
 }
 
-// DropFunction represents a DROP FUNCTION statement.
+// DropFunction represents a DROP FUNCTION, DROP PROCEDURE or DROP AGGREGATE
+// statement.
 type DropFunction struct {
+	IsProcedure  bool
+	IsAggregate  bool
 	IfExists     bool
 	Functions    FuncObjs
 	DropBehavior DropBehavior
@@ -392,7 +458,13 @@ type DropFunction struct {
 
 // Format implements the NodeFormatter interface.
 func (node *DropFunction) Format(ctx *FmtCtx) {
-	ctx.WriteString("DROP FUNCTION ")
+	if node.IsProcedure {
+		ctx.WriteString("DROP PROCEDURE ")
+	} else if node.IsAggregate {
+		ctx.WriteString("DROP AGGREGATE ")
+	} else {
+		ctx.WriteString("DROP FUNCTION ")
+	}
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
@@ -434,23 +506,39 @@ func (node *FuncObj) Format(ctx *FmtCtx) {
 
 // ParamTypes returns a slice of parameter types of the function.
 func (node FuncObj) ParamTypes(ctx context.Context, res TypeReferenceResolver) ([]*types.T, error) {
-	// TODO(chengxiong): handle INOUT, OUT and VARIADIC argument classes when we
-	// support them. This is because only IN and INOUT arg types need to be
-	// considered to match a overload.
+	// Only input parameters need to be considered to match an overload, so OUT
+	// parameters are skipped.
 	var argTypes []*types.T
 	if node.Params != nil {
-		argTypes = make([]*types.T, len(node.Params))
-		for i, arg := range node.Params {
+		argTypes = make([]*types.T, 0, len(node.Params))
+		for _, arg := range node.Params {
+			if !arg.Class.IsInParam() {
+				continue
+			}
 			typ, err := ResolveType(ctx, arg.Type, res)
 			if err != nil {
 				return nil, err
 			}
-			argTypes[i] = typ
+			argTypes = append(argTypes, typ)
 		}
 	}
 	return argTypes, nil
 }
 
+// Call represents a CALL statement.
+type Call struct {
+	// Proc is the procedure being called, along with its arguments.
+	Proc *FuncExpr
+}
+
+var _ Statement = &Call{}
+
+// Format implements the NodeFormatter interface.
+func (node *Call) Format(ctx *FmtCtx) {
+	ctx.WriteString("CALL ")
+	ctx.FormatNode(node.Proc)
+}
+
 // AlterFunctionOptions represents a ALTER FUNCTION...action statement.
 type AlterFunctionOptions struct {
 	Function FuncObj
@@ -467,48 +555,62 @@ func (node *AlterFunctionOptions) Format(ctx *FmtCtx) {
 	}
 }
 
-// AlterFunctionRename represents a ALTER FUNCTION...RENAME statement.
+// AlterFunctionRename represents a ALTER FUNCTION...RENAME or ALTER
+// AGGREGATE...RENAME statement.
 type AlterFunctionRename struct {
-	Function FuncObj
-	NewName  Name
+	IsAggregate bool
+	Function    FuncObj
+	NewName     Name
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionRename) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	formatAlterRoutineKind(ctx, node.IsAggregate)
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" RENAME TO ")
 	ctx.WriteString(string(node.NewName))
 }
 
-// AlterFunctionSetSchema represents a ALTER FUNCTION...SET SCHEMA statement.
+// AlterFunctionSetSchema represents a ALTER FUNCTION...SET SCHEMA or ALTER
+// AGGREGATE...SET SCHEMA statement.
 type AlterFunctionSetSchema struct {
+	IsAggregate   bool
 	Function      FuncObj
 	NewSchemaName Name
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionSetSchema) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	formatAlterRoutineKind(ctx, node.IsAggregate)
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" SET SCHEMA ")
 	ctx.WriteString(string(node.NewSchemaName))
 }
 
-// AlterFunctionSetOwner represents the ALTER FUNCTION...OWNER TO statement.
+// AlterFunctionSetOwner represents the ALTER FUNCTION...OWNER TO or ALTER
+// AGGREGATE...OWNER TO statement.
 type AlterFunctionSetOwner struct {
-	Function FuncObj
-	NewOwner RoleSpec
+	IsAggregate bool
+	Function    FuncObj
+	NewOwner    RoleSpec
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionSetOwner) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	formatAlterRoutineKind(ctx, node.IsAggregate)
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" OWNER TO ")
 	ctx.FormatNode(&node.NewOwner)
 }
 
+func formatAlterRoutineKind(ctx *FmtCtx, isAggregate bool) {
+	if isAggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
+	} else {
+		ctx.WriteString("ALTER FUNCTION ")
+	}
+}
+
 // AlterFunctionDepExtension represents the ALTER FUNCTION...DEPENDS ON statement.
 type AlterFunctionDepExtension struct {
 	Function  FuncObj
@@ -569,6 +671,8 @@ const (
 	TTLExpirationExpr               SchemaExprContext = "TTL EXPIRATION EXPRESSION"
 	TTLDefaultExpr                  SchemaExprContext = "TTL DEFAULT"
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
+	DomainDefaultExpr               SchemaExprContext = "DEFAULT (in DOMAIN)"
+	DomainCheckExpr                 SchemaExprContext = "DOMAIN CHECK"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
@@ -186,6 +186,26 @@ func (expr *CoalesceExpr) Walk(v Visitor) Expr {
 	return ret
 }
 
+// Walk implements the Expr interface.
+func (expr *GroupingFuncExpr) Walk(v Visitor) Expr {
+	if exprs, changed := walkExprSlice(v, expr.Exprs); changed {
+		exprCopy := *expr
+		exprCopy.Exprs = exprs
+		return &exprCopy
+	}
+	return expr
+}
+
+// Walk implements the Expr interface.
+func (expr *GroupingSet) Walk(v Visitor) Expr {
+	if exprs, changed := walkExprSlice(v, expr.Exprs); changed {
+		exprCopy := *expr
+		exprCopy.Exprs = exprs
+		return &exprCopy
+	}
+	return expr
+}
+
 // Walk implements the Expr interface.
 func (expr *ComparisonExpr) Walk(v Visitor) Expr {
 	left, changedL := WalkExpr(v, expr.Left)
@@ -534,13 +554,30 @@ func (expr *Subquery) WalkTableExpr(v Visitor) TableExpr {
 
 // WalkTableExpr implements the TableExpr interface.
 func (expr *AliasedTableExpr) WalkTableExpr(v Visitor) TableExpr {
+	ret := expr
 	newExpr, changed := walkTableExpr(v, expr.Expr)
 	if changed {
 		exprCopy := *expr
 		exprCopy.Expr = newExpr
-		return &exprCopy
+		ret = &exprCopy
 	}
-	return expr
+	if expr.Sample != nil {
+		newSample := *expr.Sample
+		percent, changedPercent := WalkExpr(v, expr.Sample.Percent)
+		newSample.Percent = percent
+		var changedSeed bool
+		if expr.Sample.Seed != nil {
+			newSample.Seed, changedSeed = WalkExpr(v, expr.Sample.Seed)
+		}
+		if changedPercent || changedSeed {
+			if ret == expr {
+				exprCopy := *expr
+				ret = &exprCopy
+			}
+			ret.Sample = &newSample
+		}
+	}
+	return ret
 }
 
 // WalkTableExpr implements the TableExpr interface.
@@ -762,6 +799,21 @@ func (expr *DBox2D) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DPGLSN) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DPoint) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DBox) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DPath) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DPolygon) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DCircle) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DGeography) Walk(_ Visitor) Expr { return expr }
 
@@ -771,6 +823,9 @@ func (expr *DGeometry) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DJSON) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DJsonpath) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DTSQuery) Walk(_ Visitor) Expr { return expr }
 
@@ -1912,6 +1967,71 @@ func (stmt *Update) walkStmt(v Visitor) Statement {
 	return ret
 }
 
+// copyNode makes a copy of this Statement without recursing in any child Statements.
+func (stmt *Merge) copyNode() *Merge {
+	stmtCopy := *stmt
+	whens := make([]MergeWhen, len(stmt.Whens))
+	stmtCopy.Whens = make(MergeWhens, len(stmt.Whens))
+	for i, w := range stmt.Whens {
+		whens[i] = *w
+		whens[i].Exprs = make(UpdateExprs, len(w.Exprs))
+		for j, e := range w.Exprs {
+			eCopy := *e
+			whens[i].Exprs[j] = &eCopy
+		}
+		whens[i].Values = append(Exprs(nil), w.Values...)
+		stmtCopy.Whens[i] = &whens[i]
+	}
+	return &stmtCopy
+}
+
+// walkStmt is part of the walkableStmt interface.
+func (stmt *Merge) walkStmt(v Visitor) Statement {
+	ret := stmt
+	e, changed := WalkExpr(v, stmt.On)
+	if changed {
+		ret = stmt.copyNode()
+		ret.On = e
+	}
+	for i, w := range stmt.Whens {
+		if w.Cond != nil {
+			e, changed := WalkExpr(v, w.Cond)
+			if changed {
+				if ret == stmt {
+					ret = stmt.copyNode()
+				}
+				ret.Whens[i].Cond = e
+			}
+		}
+		for j, expr := range w.Exprs {
+			e, changed := WalkExpr(v, expr.Expr)
+			if changed {
+				if ret == stmt {
+					ret = stmt.copyNode()
+				}
+				ret.Whens[i].Exprs[j].Expr = e
+			}
+		}
+		for j, expr := range w.Values {
+			e, changed := WalkExpr(v, expr)
+			if changed {
+				if ret == stmt {
+					ret = stmt.copyNode()
+				}
+				ret.Whens[i].Values[j] = e
+			}
+		}
+	}
+	returning, changed := walkReturningClause(v, stmt.Returning)
+	if changed {
+		if ret == stmt {
+			ret = stmt.copyNode()
+		}
+		ret.Returning = returning
+	}
+	return ret
+}
+
 // walkStmt is part of the walkableStmt interface.
 func (stmt *ValuesClause) walkStmt(v Visitor) Statement {
 	ret := stmt
@@ -1965,6 +2085,7 @@ var _ walkableStmt = &DropTenant{}
 var _ walkableStmt = &Explain{}
 var _ walkableStmt = &Import{}
 var _ walkableStmt = &Insert{}
+var _ walkableStmt = &Merge{}
 var _ walkableStmt = &ParenSelect{}
 var _ walkableStmt = &Restore{}
 var _ walkableStmt = &SelectClause{}
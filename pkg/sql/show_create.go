@@ -180,6 +180,25 @@ func ShowCreateTable(
 		return "", err
 	}
 
+	if inherits := desc.GetInherits(); len(inherits) > 0 {
+		f.WriteString(" INHERITS (")
+		for i, id := range inherits {
+			if i > 0 {
+				f.WriteString(", ")
+			}
+			parent, err := lCtx.getTableByID(id)
+			if err != nil {
+				return "", err
+			}
+			parentName, err := getTableNameFromTableDescriptor(lCtx, parent, dbPrefix)
+			if err != nil {
+				return "", err
+			}
+			f.FormatNode(&parentName)
+		}
+		f.WriteString(")")
+	}
+
 	if err := ShowCreatePartitioning(
 		a, p.ExecCfg().Codec, desc, desc.GetPrimaryIndex(), desc.GetPrimaryIndex().GetPartitioning(),
 		&f.Buffer, 0 /* indent */, 0 /* colOffset */, displayOptions.RedactableValues,
@@ -236,6 +255,9 @@ func (p *planner) ShowCreate(
 	defer sp.Finish()
 
 	tn := tree.MakeUnqualifiedTableName(tree.Name(desc.GetName()))
+	if desc.IsForeignTable() {
+		return ShowCreateForeignTable(&tn, desc, displayOptions.RedactableValues)
+	}
 	if desc.IsView() {
 		return ShowCreateView(
 			ctx, &p.RunParams(ctx).p.semaCtx, p.RunParams(ctx).p.SessionData(), &tn, desc,
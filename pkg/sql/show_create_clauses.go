@@ -492,6 +492,10 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	if d := tree.MakeConstraintDeferrability(fk.Deferrable, fk.InitiallyDeferred); d.IsDeferrable() {
+		buf.WriteByte(' ')
+		buf.WriteString(d.String())
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -760,6 +764,9 @@ func showConstraintClause(
 		}
 		f.WriteString(strings.Join(colNames, ", "))
 		f.WriteString(")")
+		f.FormatNode(tree.MakeConstraintDeferrability(
+			c.UniqueWithoutIndexDesc().Deferrable, c.UniqueWithoutIndexDesc().InitiallyDeferred,
+		))
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -192,6 +193,16 @@ func NewUnsupportedUnvalidatedConstraintError(constraintType catconstants.Constr
 		"%v constraints cannot be marked NOT VALID", constraintType)
 }
 
+// NewDeferrableUniqueIndexError creates an error for a DEFERRABLE unique
+// constraint that would be enforced by a unique index.
+func NewDeferrableUniqueIndexError() error {
+	return errors.WithHint(
+		unimplemented.New("deferrable unique index",
+			"unique constraints backed by an index cannot be marked DEFERRABLE"),
+		"use UNIQUE WITHOUT INDEX for a deferrable unique constraint",
+	)
+}
+
 // WrapErrorWhileConstructingObjectAlreadyExistsErr is used to wrap an error
 // when an error occurs while trying to get the colliding object for an
 // ObjectAlreadyExistsErr.
@@ -237,6 +248,30 @@ func NewWrongObjectTypeError(name tree.NodeFormatter, desiredObjType string) err
 		tree.ErrString(name), desiredObjType)
 }
 
+// NewWrongRoutineKindError creates an error for a DROP FUNCTION statement that
+// targets a procedure, or a DROP PROCEDURE statement that targets a function.
+// isProcedure indicates the kind of the existing routine.
+func NewWrongRoutineKindError(name tree.NodeFormatter, isProcedure bool) error {
+	if isProcedure {
+		return errors.WithHint(NewWrongObjectTypeError(name, "function"),
+			"Use DROP PROCEDURE to drop procedures.")
+	}
+	return errors.WithHint(NewWrongObjectTypeError(name, "procedure"),
+		"Use DROP FUNCTION to drop functions.")
+}
+
+// NewWrongAggregateKindError creates an error for a statement on functions
+// that targets an aggregate, or an AGGREGATE statement that targets a
+// function which is not an aggregate. isAggregate indicates the kind of the
+// existing function.
+func NewWrongAggregateKindError(name tree.NodeFormatter, isAggregate bool) error {
+	if isAggregate {
+		return pgerror.Newf(pgcode.WrongObjectType, "%q is an aggregate function",
+			tree.ErrString(name))
+	}
+	return NewWrongObjectTypeError(name, "aggregate function")
+}
+
 // NewSyntaxErrorf creates a syntax error.
 func NewSyntaxErrorf(format string, args ...interface{}) error {
 	return pgerror.Newf(pgcode.Syntax, format, args...)
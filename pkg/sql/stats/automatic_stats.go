@@ -77,6 +77,15 @@ var MultiColumnStatisticsClusterMode = settings.RegisterBoolSetting(
 	true,
 ).WithPublic()
 
+// MultiColumnHistogramsClusterMode controls the cluster setting for enabling
+// collection of histograms on multi-column statistics.
+var MultiColumnHistogramsClusterMode = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.stats.multi_column_histogram_collection.enabled",
+	"multi-column histogram collection mode",
+	false,
+).WithPublic()
+
 // AutomaticStatisticsMaxIdleTime controls the maximum fraction of time that
 // the sampler processors will be idle when scanning large tables for automatic
 // statistics (in high load scenarios). This value can be tuned to trade off
@@ -40,11 +40,15 @@ type JSONStatistic struct {
 	// HistogramColumnType is the string representation of the column type for the
 	// histogram (or unset if there is no histogram). Parsable with
 	// tree.GetTypeFromValidSQLSyntax.
-	HistogramColumnType string            `json:"histo_col_type"`
-	HistogramBuckets    []JSONHistoBucket `json:"histo_buckets,omitempty"`
-	HistogramVersion    HistogramVersion  `json:"histo_version,omitempty"`
-	PartialPredicate    string            `json:"partial_predicate,omitempty"`
-	FullStatisticID     uint64            `json:"full_statistic_id,omitempty"`
+	HistogramColumnType string `json:"histo_col_type"`
+	// HistogramColumnTypes are the string representations of the column types
+	// of a histogram on multiple columns (or unset otherwise), since the tuple
+	// type of such a histogram is not parsable.
+	HistogramColumnTypes []string          `json:"histo_col_types,omitempty"`
+	HistogramBuckets     []JSONHistoBucket `json:"histo_buckets,omitempty"`
+	HistogramVersion     HistogramVersion  `json:"histo_version,omitempty"`
+	PartialPredicate     string            `json:"partial_predicate,omitempty"`
+	FullStatisticID      uint64            `json:"full_statistic_id,omitempty"`
 }
 
 // JSONHistoBucket is a struct used for JSON marshaling and unmarshaling of
@@ -67,6 +71,16 @@ func (js *JSONStatistic) SetHistogram(h *HistogramData) error {
 		return fmt.Errorf("histogram type is unset")
 	}
 	js.HistogramColumnType = typ.SQLString()
+	// Tuple values are formatted like in pgwire, which is the format parsed by
+	// ParseDatumStringAs for tuples.
+	fmtFlags := tree.FmtExport
+	if typ.Family() == types.TupleFamily {
+		js.HistogramColumnTypes = make([]string, len(typ.TupleContents()))
+		for i, t := range typ.TupleContents() {
+			js.HistogramColumnTypes[i] = t.SQLString()
+		}
+		fmtFlags = tree.FmtPgwireText
+	}
 	js.HistogramBuckets = make([]JSONHistoBucket, len(h.Buckets))
 	js.HistogramVersion = h.Version
 	var a tree.DatumAlloc
@@ -88,7 +102,7 @@ func (js *JSONStatistic) SetHistogram(h *HistogramData) error {
 			NumEq:         b.NumEq,
 			NumRange:      b.NumRange,
 			DistinctRange: b.DistinctRange,
-			UpperBound:    tree.AsStringWithFlags(datum, tree.FmtExport),
+			UpperBound:    tree.AsStringWithFlags(datum, fmtFlags),
 		}
 	}
 	return nil
@@ -137,13 +151,21 @@ func (js *JSONStatistic) GetHistogram(
 		return nil, nil
 	}
 	h := &HistogramData{}
-	colTypeRef, err := parser.GetTypeFromValidSQLSyntax(js.HistogramColumnType)
-	if err != nil {
-		return nil, err
-	}
-	colType, err := tree.ResolveType(ctx, colTypeRef, semaCtx.GetTypeResolver())
-	if err != nil {
-		return nil, err
+	var colType *types.T
+	if len(js.HistogramColumnTypes) > 0 {
+		contents := make([]*types.T, len(js.HistogramColumnTypes))
+		for i, t := range js.HistogramColumnTypes {
+			var err error
+			if contents[i], err = resolveJSONType(ctx, semaCtx, t); err != nil {
+				return nil, err
+			}
+		}
+		colType = types.MakeTuple(contents)
+	} else {
+		var err error
+		if colType, err = resolveJSONType(ctx, semaCtx, js.HistogramColumnType); err != nil {
+			return nil, err
+		}
 	}
 	h.ColumnType = colType
 	h.Version = js.HistogramVersion
@@ -165,6 +187,16 @@ func (js *JSONStatistic) GetHistogram(
 	return h, nil
 }
 
+// resolveJSONType resolves the string representation of a type in a
+// JSONStatistic.
+func resolveJSONType(ctx context.Context, semaCtx *tree.SemaContext, typ string) (*types.T, error) {
+	typRef, err := parser.GetTypeFromValidSQLSyntax(typ)
+	if err != nil {
+		return nil, err
+	}
+	return tree.ResolveType(ctx, typRef, semaCtx.GetTypeResolver())
+}
+
 // IsPartial returns true if this statistic was collected with a where clause.
 func (js *JSONStatistic) IsPartial() bool {
 	return js.PartialPredicate != ""
@@ -240,6 +240,42 @@ func (sr *SampleReservoir) GetNonNullDatums(
 	return
 }
 
+// GetNonNullTuples is like GetNonNullDatums, but for multiple columns. It
+// returns tuples of type typ with the values of the specified columns, for all
+// samples in which at least one of the columns is not NULL.
+func (sr *SampleReservoir) GetNonNullTuples(
+	ctx context.Context, memAcc *mon.BoundAccount, colIdxs []int, typ *types.T,
+) (values tree.Datums, err error) {
+	err = sr.retryMaybeResize(ctx, func() error {
+		// Account for the memory we'll use copying the samples into values.
+		if memAcc != nil {
+			size := memsize.DatumOverhead + memsize.DatumsOverhead + memsize.DatumOverhead*int64(len(colIdxs))
+			if err := memAcc.Grow(ctx, size*int64(len(sr.samples))); err != nil {
+				return err
+			}
+		}
+		values = make(tree.Datums, 0, len(sr.samples))
+		for _, sample := range sr.samples {
+			tuple := tree.NewDTupleWithLen(typ, len(colIdxs))
+			isNull := true
+			for i, colIdx := range colIdxs {
+				ed := &sample.Row[colIdx]
+				if ed.Datum == nil {
+					values = nil
+					return errors.AssertionFailedf("value in column %d not decoded", colIdx)
+				}
+				tuple.D[i] = ed.Datum
+				isNull = isNull && ed.IsNull()
+			}
+			if !isNull {
+				values = append(values, tuple)
+			}
+		}
+		return nil
+	})
+	return
+}
+
 func (sr *SampleReservoir) copyRow(
 	ctx context.Context, evalCtx *eval.Context, dst, src rowenc.EncDatumRow,
 ) error {
@@ -0,0 +1,510 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// Table inheritance is supported in a restricted form. A table inheriting from
+// other tables (its parents) starts with the columns and check constraints of
+// its parents, and queries on a parent also return the rows of the tables
+// inheriting from it, unless ONLY is specified. The relationship is stored in
+// the Inherits and InheritedBy fields of the table descriptors.
+//
+// Unlike in Postgres, schema changes are not propagated from the parents to
+// their children. Instead, the columns shared by a parent and its children
+// cannot be added, dropped, renamed or altered, and UPDATE and DELETE only
+// modify the rows of the table they target.
+
+// inheritTableDefs resolves the parents of a CREATE TABLE ... INHERITS
+// statement and returns the table definitions of the new table, which start
+// with the columns and check constraints inherited from the parents.
+func inheritTableDefs(
+	params runParams, n *tree.CreateTable, dbID descpb.ID,
+) ([]*tabledesc.Mutable, tree.TableDefs, error) {
+	parents := make([]*tabledesc.Mutable, 0, len(n.Inherits))
+	var defs tree.TableDefs
+	columns := make(map[tree.Name]*tree.ColumnTableDef)
+	checks := make(map[tree.Name]struct{})
+	for i := range n.Inherits {
+		tn := &n.Inherits[i]
+		_, parent, err := params.p.ResolveMutableTableDescriptor(
+			params.ctx, tn, true /* required */, tree.ResolveRequireTableDesc,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, p := range parents {
+			if p.ID == parent.ID {
+				return nil, nil, pgerror.Newf(pgcode.DuplicateRelation,
+					"relation %q would be inherited from more than once", parent.GetName())
+			}
+		}
+		if err := params.p.checkCanInheritFrom(params.ctx, parent, n.Persistence, dbID); err != nil {
+			return nil, nil, err
+		}
+		parents = append(parents, parent)
+
+		for j := range parent.Columns {
+			c := &parent.Columns[j]
+			if implicit, err := isImplicitlyCreatedBySystem(parent, c); err != nil {
+				return nil, nil, err
+			} else if implicit {
+				continue
+			}
+			def, err := inheritedColumnDef(c)
+			if err != nil {
+				return nil, nil, err
+			}
+			existing, ok := columns[def.Name]
+			if !ok {
+				columns[def.Name] = def
+				defs = append(defs, def)
+				continue
+			}
+			params.p.BufferClientNotice(params.ctx,
+				pgnotice.Newf("merging multiple inherited definitions of column %q", def.Name))
+			if typ := existing.Type.(*types.T); !typ.Identical(c.Type) {
+				return nil, nil, errors.WithDetailf(
+					pgerror.Newf(pgcode.DatatypeMismatch,
+						"inherited column %q has a type conflict", def.Name),
+					"%s versus %s", typ.SQLString(), c.Type.SQLString())
+			}
+			if def.Nullable.Nullability == tree.NotNull {
+				existing.Nullable.Nullability = tree.NotNull
+			}
+		}
+		for _, c := range parent.Checks {
+			if c.FromHashShardedColumn {
+				continue
+			}
+			if _, ok := checks[tree.Name(c.Name)]; ok {
+				continue
+			}
+			checks[tree.Name(c.Name)] = struct{}{}
+			def := &tree.CheckConstraintTableDef{Name: tree.Name(c.Name)}
+			if def.Expr, err = parser.ParseExpr(c.Expr); err != nil {
+				return nil, nil, err
+			}
+			defs = append(defs, def)
+		}
+	}
+
+	// Merge the columns defined by the statement with the inherited ones.
+	for _, def := range n.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			defs = append(defs, def)
+			continue
+		}
+		inherited, ok := columns[d.Name]
+		if !ok {
+			defs = append(defs, def)
+			continue
+		}
+		params.p.BufferClientNotice(params.ctx,
+			pgnotice.Newf("merging column %q with inherited definition", d.Name))
+		typ, err := tree.ResolveType(params.ctx, d.Type, params.p.semaCtx.GetTypeResolver())
+		if err != nil {
+			return nil, nil, err
+		}
+		if inheritedType := inherited.Type.(*types.T); !typ.Identical(inheritedType) {
+			return nil, nil, errors.WithDetailf(
+				pgerror.Newf(pgcode.DatatypeMismatch, "column %q has a type conflict", d.Name),
+				"%s versus %s", inheritedType.SQLString(), typ.SQLString())
+		}
+		// The definition of the statement replaces the inherited one, keeping
+		// the inherited NOT NULL constraint and expressions it does not
+		// override.
+		merged := *d
+		if inherited.Nullable.Nullability == tree.NotNull {
+			merged.Nullable.Nullability = tree.NotNull
+		}
+		if merged.DefaultExpr.Expr == nil && !merged.Computed.Computed {
+			merged.DefaultExpr = inherited.DefaultExpr
+			merged.Computed = inherited.Computed
+		}
+		if merged.OnUpdateExpr.Expr == nil {
+			merged.OnUpdateExpr = inherited.OnUpdateExpr
+		}
+		*inherited = merged
+	}
+	return parents, defs, nil
+}
+
+// inheritedColumnDef returns the definition of a column inherited from the
+// given column of a parent table.
+func inheritedColumnDef(c *descpb.ColumnDescriptor) (_ *tree.ColumnTableDef, err error) {
+	def := &tree.ColumnTableDef{
+		Name:   tree.Name(c.Name),
+		Type:   c.Type,
+		Hidden: c.Hidden,
+	}
+	if c.Nullable {
+		def.Nullable.Nullability = tree.Null
+	} else {
+		def.Nullable.Nullability = tree.NotNull
+	}
+	// The default expression of an identity column uses the sequence of the
+	// parent, which is not shared with its children.
+	if c.DefaultExpr != nil && c.GeneratedAsIdentityType == catpb.GeneratedAsIdentityType_NOT_IDENTITY_COLUMN {
+		if def.DefaultExpr.Expr, err = parser.ParseExpr(*c.DefaultExpr); err != nil {
+			return nil, err
+		}
+	}
+	if c.ComputeExpr != nil {
+		def.Computed.Computed = true
+		def.Computed.Virtual = c.Virtual
+		if def.Computed.Expr, err = parser.ParseExpr(*c.ComputeExpr); err != nil {
+			return nil, err
+		}
+	}
+	if c.OnUpdateExpr != nil {
+		if def.OnUpdateExpr.Expr, err = parser.ParseExpr(*c.OnUpdateExpr); err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
+
+// checkCanInheritFrom checks that a table with the given persistence in the
+// given database can inherit from parent.
+func (p *planner) checkCanInheritFrom(
+	ctx context.Context,
+	parent catalog.TableDescriptor,
+	persistence tree.Persistence,
+	dbID descpb.ID,
+) error {
+	if err := p.checkInheritanceOwnership(ctx, parent); err != nil {
+		return err
+	}
+	if parent.IsTemporary() && !persistence.IsTemporary() {
+		return pgerror.Newf(pgcode.WrongObjectType,
+			"cannot inherit from temporary relation %q", parent.GetName())
+	}
+	if parent.GetParentID() != dbID {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot inherit from relation %q in another database", parent.GetName())
+	}
+	return nil
+}
+
+// checkInheritanceOwnership checks that the current user owns a table whose
+// inheritance relationships are changed, like in Postgres.
+func (p *planner) checkInheritanceOwnership(
+	ctx context.Context, desc catalog.TableDescriptor,
+) error {
+	hasAdminRole, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if hasAdminRole {
+		return nil
+	}
+	hasOwnership, err := p.HasOwnership(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if !hasOwnership {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tree.Name(desc.GetName()))
+	}
+	return nil
+}
+
+// addInheritanceBackReferences adds the table to the tables inheriting from
+// each of its parents.
+func (p *planner) addInheritanceBackReferences(
+	ctx context.Context, desc *tabledesc.Mutable,
+) error {
+	for _, id := range desc.Inherits {
+		parent, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+		if err != nil {
+			return err
+		}
+		parent.InheritedBy = append(parent.InheritedBy, desc.ID)
+		if err := p.writeSchemaChange(
+			ctx, parent, descpb.InvalidMutationID,
+			fmt.Sprintf("updating inheritance parent %s(%d) for table %s(%d)",
+				parent.Name, parent.ID, desc.Name, desc.ID),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// alterTableInherit implements ALTER TABLE ... INHERIT. The table must already
+// have the columns and check constraints of the parent.
+// Privileges: ownership of the table and of the parent.
+func (p *planner) alterTableInherit(
+	ctx context.Context, desc *tabledesc.Mutable, parentName *tree.TableName,
+) error {
+	if err := p.checkInheritanceOwnership(ctx, desc); err != nil {
+		return err
+	}
+	_, parent, err := p.ResolveMutableTableDescriptor(
+		ctx, parentName, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return err
+	}
+	if parent.ID == desc.ID {
+		return pgerror.New(pgcode.DuplicateRelation, "circular inheritance not allowed")
+	}
+	for _, id := range desc.Inherits {
+		if id == parent.ID {
+			return pgerror.Newf(pgcode.DuplicateRelation,
+				"relation %q would be inherited from more than once", parent.GetName())
+		}
+	}
+	if isAncestor, err := p.isInheritanceAncestor(ctx, desc.ID, parent); err != nil {
+		return err
+	} else if isAncestor {
+		return errors.WithDetailf(
+			pgerror.New(pgcode.DuplicateRelation, "circular inheritance not allowed"),
+			"%q is already a child of %q.", parent.GetName(), desc.GetName())
+	}
+	persistence := tree.PersistencePermanent
+	if desc.IsTemporary() {
+		persistence = tree.PersistenceTemporary
+	}
+	if err := p.checkCanInheritFrom(ctx, parent, persistence, desc.GetParentID()); err != nil {
+		return err
+	}
+
+	for i := range parent.Columns {
+		c := &parent.Columns[i]
+		if implicit, err := isImplicitlyCreatedBySystem(parent, c); err != nil {
+			return err
+		} else if implicit {
+			continue
+		}
+		col := catalog.FindColumnByName(desc, c.Name)
+		if col == nil || !col.Public() {
+			return pgerror.Newf(pgcode.DatatypeMismatch, "child table is missing column %q", c.Name)
+		}
+		if !col.GetType().Identical(c.Type) {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"child table %q has different type for column %q", desc.GetName(), c.Name)
+		}
+		if !c.Nullable && col.IsNullable() {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"column %q in child table must be marked NOT NULL", c.Name)
+		}
+	}
+	for _, c := range parent.Checks {
+		if c.FromHashShardedColumn {
+			continue
+		}
+		var ck catalog.CheckConstraint
+		if constraint := catalog.FindConstraintByName(desc, c.Name); constraint != nil {
+			ck = constraint.AsCheck()
+		}
+		if ck == nil {
+			return pgerror.Newf(pgcode.DatatypeMismatch, "child table is missing constraint %q", c.Name)
+		}
+		if ck.GetExpr() != c.Expr {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"child table %q has different definition for check constraint %q", desc.GetName(), c.Name)
+		}
+	}
+
+	desc.Inherits = append(desc.Inherits, parent.ID)
+	parent.InheritedBy = append(parent.InheritedBy, desc.ID)
+	return p.writeSchemaChange(
+		ctx, parent, descpb.InvalidMutationID,
+		fmt.Sprintf("updating inheritance parent %s(%d) for table %s(%d)",
+			parent.Name, parent.ID, desc.Name, desc.ID),
+	)
+}
+
+// alterTableNoInherit implements ALTER TABLE ... NO INHERIT.
+// Privileges: ownership of the table.
+func (p *planner) alterTableNoInherit(
+	ctx context.Context, desc *tabledesc.Mutable, parentName *tree.TableName,
+) error {
+	if err := p.checkInheritanceOwnership(ctx, desc); err != nil {
+		return err
+	}
+	_, parent, err := p.ResolveMutableTableDescriptor(
+		ctx, parentName, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return err
+	}
+	var found bool
+	desc.Inherits, found = removeInheritanceID(desc.Inherits, parent.ID)
+	if !found {
+		return pgerror.Newf(pgcode.UndefinedTable,
+			"relation %q is not a parent of relation %q", parent.GetName(), desc.GetName())
+	}
+	parent.InheritedBy, _ = removeInheritanceID(parent.InheritedBy, desc.ID)
+	return p.writeSchemaChange(
+		ctx, parent, descpb.InvalidMutationID,
+		fmt.Sprintf("updating inheritance parent %s(%d) for table %s(%d)",
+			parent.Name, parent.ID, desc.Name, desc.ID),
+	)
+}
+
+// isInheritanceAncestor returns whether the table with the given ID is desc or
+// one of the tables it inherits from, directly or not.
+func (p *planner) isInheritanceAncestor(
+	ctx context.Context, id descpb.ID, desc catalog.TableDescriptor,
+) (bool, error) {
+	if desc.GetID() == id {
+		return true, nil
+	}
+	for _, parentID := range desc.GetInherits() {
+		parent, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Table(ctx, parentID)
+		if err != nil {
+			return false, err
+		}
+		if ok, err := p.isInheritanceAncestor(ctx, id, parent); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// removeInheritanceReferences removes the table from the tables inheriting from
+// its parents, and its parents from its children, when it is dropped.
+func (p *planner) removeInheritanceReferences(
+	ctx context.Context, desc *tabledesc.Mutable,
+) error {
+	for _, id := range desc.Inherits {
+		parent, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+		if err != nil {
+			return err
+		}
+		if parent.Dropped() {
+			continue
+		}
+		parent.InheritedBy, _ = removeInheritanceID(parent.InheritedBy, desc.ID)
+		if err := p.writeSchemaChange(
+			ctx, parent, descpb.InvalidMutationID,
+			fmt.Sprintf("updating inheritance parent %s(%d) after dropping table %s(%d)",
+				parent.Name, parent.ID, desc.Name, desc.ID),
+		); err != nil {
+			return err
+		}
+	}
+	desc.Inherits = nil
+	for _, id := range desc.InheritedBy {
+		child, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+		if err != nil {
+			return err
+		}
+		if child.Dropped() {
+			continue
+		}
+		child.Inherits, _ = removeInheritanceID(child.Inherits, desc.ID)
+		if err := p.writeSchemaChange(
+			ctx, child, descpb.InvalidMutationID,
+			fmt.Sprintf("updating inheriting table %s(%d) after dropping table %s(%d)",
+				child.Name, child.ID, desc.Name, desc.ID),
+		); err != nil {
+			return err
+		}
+	}
+	desc.InheritedBy = nil
+	return nil
+}
+
+// addInheritingTablesToDrop adds the tables inheriting from the tables in td,
+// directly or not, to the tables dropped by DROP TABLE ... CASCADE. Without
+// CASCADE, an error is returned if such tables are not already in td.
+func (p *planner) addInheritingTablesToDrop(
+	ctx context.Context, td map[descpb.ID]toDelete, behavior tree.DropBehavior,
+) error {
+	queue := make([]*tabledesc.Mutable, 0, len(td))
+	for _, toDel := range td {
+		queue = append(queue, toDel.desc)
+	}
+	for len(queue) > 0 {
+		desc := queue[0]
+		queue = queue[1:]
+		for _, id := range desc.InheritedBy {
+			if _, ok := td[id]; ok {
+				continue
+			}
+			if behavior != tree.DropCascade {
+				return errors.WithHint(
+					pgerror.Newf(pgcode.DependentObjectsStillExist,
+						"cannot drop table %s because other objects depend on it", desc.Name),
+					"use DROP ... CASCADE to drop the dependent objects too.",
+				)
+			}
+			child, err := p.Descriptors().MutableByID(p.txn).Table(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := p.canDropTable(ctx, child, true /* checkOwnership */); err != nil {
+				return err
+			}
+			tn, err := p.getQualifiedTableName(ctx, child)
+			if err != nil {
+				return err
+			}
+			td[id] = toDelete{tn, child}
+			queue = append(queue, child)
+		}
+	}
+	return nil
+}
+
+// checkColumnNotInherited returns an error if the column cannot be altered
+// because it is shared with the parents or children of the table, as the
+// columns of a table must match the ones of its parents.
+func (p *planner) checkColumnNotInherited(
+	ctx context.Context, desc catalog.TableDescriptor, colName tree.Name, op string,
+) error {
+	if len(desc.GetInheritedBy()) > 0 {
+		return pgerror.Newf(pgcode.InvalidTableDefinition,
+			"cannot %s column %q of table %q because other tables inherit from it",
+			op, colName, desc.GetName())
+	}
+	for _, id := range desc.GetInherits() {
+		parent, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Table(ctx, id)
+		if err != nil {
+			return err
+		}
+		if catalog.FindColumnByName(parent, string(colName)) != nil {
+			return pgerror.Newf(pgcode.InvalidTableDefinition,
+				"cannot %s inherited column %q", op, colName)
+		}
+	}
+	return nil
+}
+
+// removeInheritanceID removes id from ids, and returns whether it was found.
+func removeInheritanceID(ids []descpb.ID, id descpb.ID) ([]descpb.ID, bool) {
+	for i := range ids {
+		if ids[i] == id {
+			return append(ids[:i], ids[i+1:]...), true
+		}
+	}
+	return ids, false
+}
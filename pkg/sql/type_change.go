@@ -980,7 +980,7 @@ func findUsagesOfEnumValueInPartitioning(
 		if !typT.UserDefined() {
 			continue
 		}
-		id := typedesc.UserDefinedTypeOIDToID(typT.Oid())
+		id := typedesc.UserDefinedTypeOIDToID(typT.UserDefinedOID())
 		if id != typ.GetID() {
 			continue
 		}
@@ -62,8 +62,10 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_anyelement: Any,
 	oid.T_bit:        typeBit,
 	oid.T_bool:       Bool,
+	oid.T_box:        Box,
 	oid.T_bpchar:     typeBpChar,
 	oid.T_bytea:      Bytes,
+	oid.T_circle:     Circle,
 	oid.T_char:       QChar,
 	oid.T_date:       Date,
 	oid.T_float4:     Float4,
@@ -83,7 +85,10 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_numeric:      Decimal,
 	oid.T_oid:          Oid,
 	oid.T_oidvector:    OidVector,
+	oid.T_path:         Path,
 	oid.T_pg_lsn:       PGLSN,
+	oid.T_point:        Point,
+	oid.T_polygon:      Polygon,
 	oid.T_record:       AnyTuple,
 	oid.T_regclass:     RegClass,
 	oid.T_regnamespace: RegNamespace,
@@ -107,6 +112,7 @@ var OidToType = map[oid.Oid]*T{
 	oidext.T_geometry:  Geometry,
 	oidext.T_geography: Geography,
 	oidext.T_box2d:     Box2D,
+	oidext.T_jsonpath:  Jsonpath,
 }
 
 // oidToArrayOid maps scalar type Oids to their corresponding array type Oid.
@@ -114,9 +120,11 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_anyelement:   oid.T_anyarray,
 	oid.T_bit:          oid.T__bit,
 	oid.T_bool:         oid.T__bool,
+	oid.T_box:          oid.T__box,
 	oid.T_bpchar:       oid.T__bpchar,
 	oid.T_bytea:        oid.T__bytea,
 	oid.T_char:         oid.T__char,
+	oid.T_circle:       oid.T__circle,
 	oid.T_date:         oid.T__date,
 	oid.T_float4:       oid.T__float4,
 	oid.T_float8:       oid.T__float8,
@@ -131,7 +139,10 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_numeric:      oid.T__numeric,
 	oid.T_oid:          oid.T__oid,
 	oid.T_oidvector:    oid.T__oidvector,
+	oid.T_path:         oid.T__path,
 	oid.T_pg_lsn:       oid.T__pg_lsn,
+	oid.T_point:        oid.T__point,
+	oid.T_polygon:      oid.T__polygon,
 	oid.T_record:       oid.T__record,
 	oid.T_regclass:     oid.T__regclass,
 	oid.T_regnamespace: oid.T__regnamespace,
@@ -153,6 +164,7 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oidext.T_geometry:  oidext.T__geometry,
 	oidext.T_geography: oidext.T__geography,
 	oidext.T_box2d:     oidext.T__box2d,
+	oidext.T_jsonpath:  oidext.T__jsonpath,
 }
 
 // familyToOid maps each type family to a default OID value that is used when
@@ -184,10 +196,16 @@ var familyToOid = map[Family]oid.Oid{
 	TupleFamily:          oid.T_record,
 	BitFamily:            oid.T_bit,
 	AnyFamily:            oid.T_anyelement,
+	PointFamily:          oid.T_point,
+	BoxFamily:            oid.T_box,
+	PathFamily:           oid.T_path,
+	PolygonFamily:        oid.T_polygon,
+	CircleFamily:         oid.T_circle,
 
 	GeometryFamily:  oidext.T_geometry,
 	GeographyFamily: oidext.T_geography,
 	Box2DFamily:     oidext.T_box2d,
+	JsonpathFamily:  oidext.T_jsonpath,
 }
 
 // ArrayOids is a set of all oids which correspond to an array type.
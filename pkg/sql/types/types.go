@@ -203,6 +203,26 @@ type UserDefinedTypeMetadata struct {
 	// for a table. Note: this can be deleted if we migrate implicit record types
 	// to ordinary persisted composite types.
 	ImplicitRecordType bool
+
+	// DomainData is non-nil iff the metadata is for a domain type.
+	DomainData *DomainMetadata
+}
+
+// DomainMetadata is metadata about a domain needed for evaluation.
+type DomainMetadata struct {
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, if any.
+	DefaultExpr string
+	// CheckNames and CheckExprs are the names and serialized expressions of
+	// the CHECK constraints of the domain. The expressions refer to the value
+	// being checked as VALUE.
+	CheckNames []string
+	CheckExprs []string
+	// TypedCheckExprs contains the CHECK expressions, parsed and type-checked
+	// when the metadata is hydrated so that they are not reparsed for every
+	// checked value. Each is a tree.TypedExpr, which this package cannot refer to.
+	TypedCheckExprs []interface{}
 }
 
 // EnumMetadata is metadata about an ENUM needed for evaluation.
@@ -507,6 +527,61 @@ var (
 		},
 	}
 
+	// Jsonpath is the jsonpath type, which represents a SQL/JSON path
+	// expression.
+	Jsonpath = &T{
+		InternalType: InternalType{
+			Family: JsonpathFamily,
+			Oid:    oidext.T_jsonpath,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Point is the type of a point on a two-dimensional plane.
+	Point = &T{
+		InternalType: InternalType{
+			Family: PointFamily,
+			Oid:    oid.T_point,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Box is the type of a rectangular box on a two-dimensional plane.
+	Box = &T{
+		InternalType: InternalType{
+			Family: BoxFamily,
+			Oid:    oid.T_box,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Path is the type of an open or closed path on a two-dimensional plane.
+	Path = &T{
+		InternalType: InternalType{
+			Family: PathFamily,
+			Oid:    oid.T_path,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Polygon is the type of a polygon on a two-dimensional plane.
+	Polygon = &T{
+		InternalType: InternalType{
+			Family: PolygonFamily,
+			Oid:    oid.T_polygon,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Circle is the type of a circle on a two-dimensional plane.
+	Circle = &T{
+		InternalType: InternalType{
+			Family: CircleFamily,
+			Oid:    oid.T_circle,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -1139,6 +1214,20 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new domain type over the given base type, with the
+// given stable type ID. The domain has the same family, OID and other
+// properties as its base type, so that its values are represented and
+// encoded like those of the base type. Note that it does not hydrate cached
+// fields on the type.
+func MakeDomain(base *T, domainOID oid.Oid) *T {
+	typ := *base
+	typ.TypeMeta = UserDefinedTypeMetadata{}
+	typ.InternalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		DomainOID: domainOID,
+	}
+	return &typ
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1427,6 +1516,12 @@ func (t *T) UserDefinedArrayOID() oid.Oid {
 // be used when type is known to not be shared. If the input oid values are
 // 0 then the RemapUserDefinedTypeOIDs has no effect.
 func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
+	if t.IsDomain() {
+		if newOID != 0 {
+			t.InternalType.UDTMetadata.DomainOID = newOID
+		}
+		return
+	}
 	if newOID != 0 {
 		t.InternalType.Oid = newOID
 	}
@@ -1437,7 +1532,29 @@ func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
 
 // UserDefined returns whether or not t is a user defined type.
 func (t *T) UserDefined() bool {
-	return IsOIDUserDefinedType(t.Oid())
+	return t.IsDomain() || IsOIDUserDefinedType(t.Oid())
+}
+
+// IsDomain returns whether or not t is a domain type.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.DomainOID != 0
+}
+
+// UserDefinedOID returns the OID of the user defined type t. This is the same
+// as Oid, except for domains, whose Oid is that of their base type.
+func (t *T) UserDefinedOID() oid.Oid {
+	if t.IsDomain() {
+		return t.InternalType.UDTMetadata.DomainOID
+	}
+	return t.Oid()
+}
+
+// DomainBaseType returns the base type of the domain type t.
+func (t *T) DomainBaseType() *T {
+	base := *t
+	base.TypeMeta = UserDefinedTypeMetadata{}
+	base.InternalType.UDTMetadata = nil
+	return &base
 }
 
 // IsOIDUserDefinedType returns whether or not o corresponds to a user
@@ -1452,7 +1569,9 @@ var familyNames = map[Family]string{
 	BitFamily:            "bit",
 	BoolFamily:           "bool",
 	Box2DFamily:          "box2d",
+	BoxFamily:            "box",
 	BytesFamily:          "bytes",
+	CircleFamily:         "circle",
 	CollatedStringFamily: "collatedstring",
 	DateFamily:           "date",
 	DecimalFamily:        "decimal",
@@ -1464,8 +1583,12 @@ var familyNames = map[Family]string{
 	IntFamily:            "int",
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
+	JsonpathFamily:       "jsonpath",
 	OidFamily:            "oid",
+	PathFamily:           "path",
 	PGLSNFamily:          "pg_lsn",
+	PointFamily:          "point",
+	PolygonFamily:        "polygon",
 	StringFamily:         "string",
 	TimeFamily:           "time",
 	TimestampFamily:      "timestamp",
@@ -1499,6 +1622,9 @@ func (f Family) Name() string {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() {
+		return t.domainName()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1588,6 +1714,9 @@ func (t *T) Name() string {
 //	bytes        bytea
 //	int4[]       _int4
 func (t *T) PGName() string {
+	if t.IsDomain() {
+		return t.domainName()
+	}
 	name, ok := oidext.TypeName(t.Oid())
 	if ok {
 		return strings.ToLower(name)
@@ -1636,6 +1765,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() {
+		return t.domainName()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1714,6 +1846,18 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 	case JsonFamily:
 		// Only binary JSON is currently supported.
 		return "jsonb"
+	case JsonpathFamily:
+		return "jsonpath"
+	case PointFamily:
+		return "point"
+	case BoxFamily:
+		return "box"
+	case PathFamily:
+		return "path"
+	case PolygonFamily:
+		return "polygon"
+	case CircleFamily:
+		return "circle"
 	case OidFamily:
 		switch t.Oid() {
 		case oid.T_oid:
@@ -1836,6 +1980,14 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		// As with enums, return a less informative string rather than panic if
+		// the TypeMeta is not hydrated.
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.UserDefinedOID())
+		}
+		return t.TypeMeta.Name.FQName()
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -1941,6 +2093,15 @@ func (t *T) SQLString() string {
 	return strings.ToUpper(t.Name())
 }
 
+// domainName returns the name of the domain type t.
+func (t *T) domainName() string {
+	// This can be nil if the TypeMeta is not hydrated.
+	if t.TypeMeta.Name == nil {
+		return "unknown_domain"
+	}
+	return t.TypeMeta.Name.Basename()
+}
+
 // SQLStringForError returns a version of SQLString that will preserve safe
 // information during redaction. It is suitable for usage in error messages.
 func (t *T) SQLStringForError() redact.RedactableString {
@@ -1956,6 +2117,9 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		case ArrayFamily:
 			prefix = "ARRAY"
 		}
+		if t.IsDomain() {
+			prefix = "DOMAIN"
+		}
 		return redact.Sprintf("USER DEFINED %s: %s", redact.Safe(prefix), t.SQLString())
 	}
 	switch t.Family() {
@@ -1968,7 +2132,8 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
 		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily, PGLSNFamily:
+		TSVectorFamily, AnyFamily, PGLSNFamily, JsonpathFamily, PointFamily, BoxFamily, PathFamily,
+		PolygonFamily, CircleFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2192,6 +2357,9 @@ func (t *InternalType) Identical(other *InternalType) bool {
 		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID {
 			return false
 		}
+		if t.UDTMetadata.DomainOID != other.UDTMetadata.DomainOID {
+			return false
+		}
 	} else if t.UDTMetadata != nil {
 		return false
 	} else if other.UDTMetadata != nil {
@@ -2708,6 +2876,8 @@ func IsValidArrayElementType(t *T) (valid bool, issueNum int) {
 		return false, 90886
 	case TSVectorFamily:
 		return false, 90886
+	case JsonpathFamily:
+		return false, 22513
 	default:
 		return true, 0
 	}
@@ -2924,16 +3094,12 @@ var unreservedTypeTokens = map[string]*T{
 // github issues. It is also possible, but not necessary, to include
 // PostgreSQL types that are already implemented in CockroachDB.
 var postgresPredefinedTypeIssues = map[string]int{
-	"box":           21286,
 	"cidr":          18846,
-	"circle":        21286,
-	"jsonpath":      22513,
 	"line":          21286,
 	"lseg":          21286,
 	"macaddr":       45813,
 	"macaddr8":      45813,
 	"money":         41578,
-	"path":          21286,
 	"pg_lsn":        -1,
 	"txid_snapshot": -1,
 	"xml":           43355,
@@ -2960,6 +3126,10 @@ func (t *T) Delimiter() string {
 	switch t.Family() {
 	case Geometry.Family(), Geography.Family():
 		return ":"
+	case BoxFamily:
+		// As in Postgres, boxes use a semicolon since their text representation
+		// contains commas.
+		return ";"
 	default:
 		return ","
 	}
@@ -149,6 +149,9 @@ func (n *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) err
 		if n.run.tw.canaryOrdinal != -1 {
 			offset++
 		}
+		if n.run.tw.deleteOrdinal != -1 {
+			offset++
+		}
 		partialIndexVals := rowVals[offset:]
 		partialIndexPutVals := partialIndexVals[:numPartialIndexes]
 		partialIndexDelVals := partialIndexVals[numPartialIndexes : numPartialIndexes*2]
@@ -170,6 +173,9 @@ func (n *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) err
 		if n.run.tw.canaryOrdinal != -1 {
 			ord++
 		}
+		if n.run.tw.deleteOrdinal != -1 {
+			ord++
+		}
 		checkVals := rowVals[ord:]
 		if err := checkMutationInput(
 			params.ctx, &params.p.semaCtx, params.p.SessionData(), n.run.tw.tableDesc(), n.run.checkOrds, checkVals,
@@ -2772,22 +2772,11 @@ var varGen = map[string]sessionVar{
 		// It should only be set at connection time.
 		Hidden: true,
 		Set: func(_ context.Context, m sessionDataMutator, s string) error {
-			if strings.ToLower(s) == "database" {
-				m.SetReplicationMode(sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE)
-				return nil
-			}
-			b, err := paramparse.ParseBoolVar("replication", s)
+			mode, err := ParseReplicationMode(s)
 			if err != nil {
-				return pgerror.Newf(
-					pgcode.InvalidParameterValue,
-					`parameter "replication" requires a boolean value or "database"`,
-				)
-			}
-			if b {
-				m.SetReplicationMode(sessiondatapb.ReplicationMode_REPLICATION_MODE_ENABLED)
-			} else {
-				m.SetReplicationMode(sessiondatapb.ReplicationMode_REPLICATION_MODE_DISABLED)
+				return err
 			}
+			m.SetReplicationMode(mode)
 			return nil
 		},
 		Get: func(evalCtx *extendedEvalContext, _ *kv.Txn) (string, error) {
@@ -2805,6 +2794,25 @@ var varGen = map[string]sessionVar{
 	},
 }
 
+// ParseReplicationMode parses the value of the "replication" connection
+// parameter.
+func ParseReplicationMode(s string) (sessiondatapb.ReplicationMode, error) {
+	if strings.ToLower(s) == "database" {
+		return sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE, nil
+	}
+	b, err := paramparse.ParseBoolVar("replication", s)
+	if err != nil {
+		return 0, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			`parameter "replication" requires a boolean value or "database"`,
+		)
+	}
+	if b {
+		return sessiondatapb.ReplicationMode_REPLICATION_MODE_ENABLED, nil
+	}
+	return sessiondatapb.ReplicationMode_REPLICATION_MODE_DISABLED, nil
+}
+
 // We want test coverage for this on and off so make it metamorphic.
 var copyFastPathDefault bool = util.ConstantWithMetamorphicTestBool("copy-fast-path-enabled-default", true)
 
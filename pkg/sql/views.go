@@ -87,3 +87,20 @@ func checkViewMatchesMaterialized(
 	}
 	return nil
 }
+
+// checkViewMatchesForeignTable checks that a view descriptor is a foreign
+// table if and only if a FOREIGN TABLE statement is used on it.
+func checkViewMatchesForeignTable(desc catalog.TableDescriptor, wantForeignTable bool) error {
+	if !desc.IsView() {
+		return nil
+	}
+	isForeignTable := desc.IsForeignTable()
+	if isForeignTable && !wantForeignTable {
+		err := pgerror.Newf(pgcode.WrongObjectType, "%q is a foreign table", desc.GetName())
+		return errors.WithHint(err, "use the corresponding FOREIGN TABLE command")
+	}
+	if !isForeignTable && wantForeignTable {
+		return pgerror.Newf(pgcode.WrongObjectType, "%q is not a foreign table", desc.GetName())
+	}
+	return nil
+}
@@ -349,6 +349,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterDatabaseDropSecondaryRegion{}):        "alter database secondary region",
 	reflect.TypeOf(&alterDatabaseSetZoneConfigExtensionNode{}): "alter database configure zone extension",
 	reflect.TypeOf(&alterDefaultPrivilegesNode{}):              "alter default privileges",
+	reflect.TypeOf(&alterDomainNode{}):                         "alter domain",
 	reflect.TypeOf(&alterFunctionOptionsNode{}):                "alter function",
 	reflect.TypeOf(&alterFunctionRenameNode{}):                 "alter function rename",
 	reflect.TypeOf(&alterFunctionSetOwnerNode{}):               "alter function owner",
@@ -370,6 +371,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterRoleSetNode{}):                        "alter role set var",
 	reflect.TypeOf(&applyJoinNode{}):                           "apply join",
 	reflect.TypeOf(&bufferNode{}):                              "buffer",
+	reflect.TypeOf(&callNode{}):                                "call",
 	reflect.TypeOf(&cancelQueriesNode{}):                       "cancel queries",
 	reflect.TypeOf(&cancelSessionsNode{}):                      "cancel sessions",
 	reflect.TypeOf(&cdcValuesNode{}):                           "wrapped streaming node",
@@ -378,17 +380,22 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&commentOnColumnNode{}):                     "comment on column",
 	reflect.TypeOf(&commentOnConstraintNode{}):                 "comment on constraint",
 	reflect.TypeOf(&commentOnDatabaseNode{}):                   "comment on database",
+	reflect.TypeOf(&commentOnFunctionNode{}):                   "comment on function",
 	reflect.TypeOf(&commentOnIndexNode{}):                      "comment on index",
 	reflect.TypeOf(&commentOnTableNode{}):                      "comment on table",
 	reflect.TypeOf(&commentOnSchemaNode{}):                     "comment on schema",
 	reflect.TypeOf(&completionsNode{}):                         "show completions",
 	reflect.TypeOf(&controlJobsNode{}):                         "control jobs",
 	reflect.TypeOf(&controlSchedulesNode{}):                    "control schedules",
+	reflect.TypeOf(&createAggregateNode{}):                     "create aggregate",
 	reflect.TypeOf(&createDatabaseNode{}):                      "create database",
+	reflect.TypeOf(&createDomainNode{}):                        "create domain",
 	reflect.TypeOf(&createExtensionNode{}):                     "create extension",
 	reflect.TypeOf(&createExternalConectionNode{}):             "create external connection",
+	reflect.TypeOf(&createForeignTableNode{}):                  "create foreign table",
 	reflect.TypeOf(&createFunctionNode{}):                      "create function",
 	reflect.TypeOf(&createIndexNode{}):                         "create index",
+	reflect.TypeOf(&createPublicationNode{}):                   "create publication",
 	reflect.TypeOf(&createSequenceNode{}):                      "create sequence",
 	reflect.TypeOf(&createSchemaNode{}):                        "create schema",
 	reflect.TypeOf(&createStatsNode{}):                         "create statistics",
@@ -406,6 +413,8 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&dropExternalConnectionNode{}):              "drop external connection",
 	reflect.TypeOf(&dropFunctionNode{}):                        "drop function",
 	reflect.TypeOf(&dropIndexNode{}):                           "drop index",
+	reflect.TypeOf(&dropPublicationNode{}):                     "drop publication",
+	reflect.TypeOf(&dropReplicationSlotNode{}):                 "drop replication slot",
 	reflect.TypeOf(&dropSequenceNode{}):                        "drop sequence",
 	reflect.TypeOf(&dropSchemaNode{}):                          "drop schema",
 	reflect.TypeOf(&dropTableNode{}):                           "drop table",
@@ -430,8 +439,10 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&invertedJoinNode{}):                        "inverted join",
 	reflect.TypeOf(&joinNode{}):                                "join",
 	reflect.TypeOf(&limitNode{}):                               "limit",
+	reflect.TypeOf(&listenNode{}):                              "listen",
 	reflect.TypeOf(&lookupJoinNode{}):                          "lookup join",
 	reflect.TypeOf(&max1RowNode{}):                             "max1row",
+	reflect.TypeOf(&notifyNode{}):                              "notify",
 	reflect.TypeOf(&ordinalityNode{}):                          "ordinality",
 	reflect.TypeOf(&projectSetNode{}):                          "project set",
 	reflect.TypeOf(&reassignOwnedByNode{}):                     "reassign owned by",
@@ -458,6 +469,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&sequenceSelectNode{}):                      "sequence select",
 	reflect.TypeOf(&serializeNode{}):                           "run",
 	reflect.TypeOf(&setClusterSettingNode{}):                   "set cluster setting",
+	reflect.TypeOf(&setConstraintsNode{}):                      "set constraints",
 	reflect.TypeOf(&setSessionAuthorizationDefaultNode{}):      "set session authorization",
 	reflect.TypeOf(&setVarNode{}):                              "set",
 	reflect.TypeOf(&setZoneConfigNode{}):                       "configure zone",
@@ -68,6 +68,9 @@ var _ tree.VariableExpr = &windowFuncHolder{}
 type windowFuncHolder struct {
 	expr *tree.FuncExpr
 	args []tree.Expr
+	// userDefined contains the support expressions of a user-defined
+	// aggregate. It is nil for builtin functions.
+	userDefined *tree.AggregateSupportExprs
 
 	argsIdxs     []uint32 // indices of the columns that are arguments to the window function
 	filterColIdx int      // optional index of filtering column, -1 if no filter
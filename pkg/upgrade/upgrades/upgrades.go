@@ -317,6 +317,18 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.NoPrecondition,
 		NoTenantUpgradeFunc,
 	),
+	upgrade.NewTenantUpgrade(
+		"create system.notifications table",
+		toCV(clusterversion.V23_2_NotificationsTable),
+		upgrade.NoPrecondition,
+		createNotificationsTable,
+	),
+	upgrade.NewTenantUpgrade(
+		"create system.replication_slots table",
+		toCV(clusterversion.V23_2_ReplicationSlotsTable),
+		upgrade.NoPrecondition,
+		createReplicationSlotsTable,
+	),
 }
 
 var (
@@ -84,7 +84,17 @@ func (w *Writer) FinishRecord() (err error) {
 }
 
 // WriteField writes an individual field.
-func (w *Writer) WriteField(field *bytes.Buffer) (e error) {
+func (w *Writer) WriteField(field *bytes.Buffer) error {
+	return w.writeField(field, false /* forceQuotes */)
+}
+
+// WriteQuotedField writes an individual field, which is enclosed in quotes
+// even if it does not need to be.
+func (w *Writer) WriteQuotedField(field *bytes.Buffer) error {
+	return w.writeField(field, true /* forceQuotes */)
+}
+
+func (w *Writer) writeField(field *bytes.Buffer, forceQuotes bool) (e error) {
 	if w.midRow {
 		if _, err := w.w.WriteRune(w.Comma); err != nil {
 			return err
@@ -146,7 +156,7 @@ func (w *Writer) WriteField(field *bytes.Buffer) (e error) {
 	}
 
 	w.maybeTerminatorString = w.maybeTerminatorString && w.i == 2
-	w.currentRecordNeedsQuotes = w.currentRecordNeedsQuotes || w.maybeTerminatorString
+	w.currentRecordNeedsQuotes = w.currentRecordNeedsQuotes || w.maybeTerminatorString || forceQuotes
 
 	// By now we know whether or not the entire field needs to be quoted.
 	// Fields with a Comma, fields with a quote or newline, and
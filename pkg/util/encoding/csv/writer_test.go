@@ -106,3 +106,23 @@ func TestError(t *testing.T) {
 		t.Error("Error should not be nil")
 	}
 }
+
+func TestWriteQuotedField(t *testing.T) {
+	b := &bytes.Buffer{}
+	f := NewWriter(b)
+	for _, field := range []string{"abc", `a"b`, "c"} {
+		if err := f.WriteQuotedField(bytes.NewBufferString(field)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.WriteField(bytes.NewBufferString("d")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.FinishRecord(); err != nil {
+		t.Fatal(err)
+	}
+	f.Flush()
+	if out, want := b.String(), `"abc","a""b","c",d`+"\n"; out != want {
+		t.Errorf("out=%q want %q", out, want)
+	}
+}
@@ -135,6 +135,11 @@ const (
 	jsonArrayKeyDescendingMarker  = jsonTrueKeyDescendingMarker - 1
 	jsonObjectKeyDescendingMarker = jsonArrayKeyDescendingMarker - 1
 
+	// Markers for the key encoding of the geometric types (point, box, path,
+	// polygon and circle).
+	geometricMarker     = jsonNullKeyDescendingMarker + 1
+	geometricDescMarker = geometricMarker + 1
+
 	// Terminators for JSON Key encoding.
 	jsonKeyTerminator           byte = 0x00
 	jsonKeyDescendingTerminator byte = 0xFF
@@ -1319,6 +1324,79 @@ func DecodeBox2DDescending(b []byte) ([]byte, geopb.BoundingBox, error) {
 	return b, box, nil
 }
 
+// EncodeGeometricAscending encodes a value of one of the geometric types,
+// given as a set of flags and a list of coordinates, in ascending order. The
+// flags and the number of coordinates are encoded before the coordinates
+// themselves, so that values with different flags or with fewer coordinates
+// sort first.
+func EncodeGeometricAscending(b []byte, flags uint64, coords []float64) []byte {
+	b = append(b, geometricMarker)
+	b = EncodeUvarintAscending(b, flags)
+	b = EncodeUvarintAscending(b, uint64(len(coords)))
+	for _, c := range coords {
+		b = EncodeFloatAscending(b, c)
+	}
+	return b
+}
+
+// EncodeGeometricDescending is the descending version of
+// EncodeGeometricAscending.
+func EncodeGeometricDescending(b []byte, flags uint64, coords []float64) []byte {
+	b = append(b, geometricDescMarker)
+	b = EncodeUvarintDescending(b, flags)
+	b = EncodeUvarintDescending(b, uint64(len(coords)))
+	for _, c := range coords {
+		b = EncodeFloatDescending(b, c)
+	}
+	return b
+}
+
+// DecodeGeometricAscending decodes a value encoded by
+// EncodeGeometricAscending.
+func DecodeGeometricAscending(b []byte) (_ []byte, flags uint64, coords []float64, _ error) {
+	if PeekType(b) != Geometric {
+		return nil, 0, nil, errors.Errorf("did not find Geometric marker")
+	}
+	return decodeGeometric(b[1:], Ascending)
+}
+
+// DecodeGeometricDescending decodes a value encoded by
+// EncodeGeometricDescending.
+func DecodeGeometricDescending(b []byte) (_ []byte, flags uint64, coords []float64, _ error) {
+	if PeekType(b) != GeometricDesc {
+		return nil, 0, nil, errors.Errorf("did not find GeometricDesc marker")
+	}
+	return decodeGeometric(b[1:], Descending)
+}
+
+func decodeGeometric(
+	b []byte, dir Direction,
+) (_ []byte, flags uint64, coords []float64, err error) {
+	decodeUvarint, decodeFloat := DecodeUvarintAscending, DecodeFloatAscending
+	if dir == Descending {
+		decodeUvarint, decodeFloat = DecodeUvarintDescending, DecodeFloatDescending
+	}
+	b, flags, err = decodeUvarint(b)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	b, n, err := decodeUvarint(b)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	// Each coordinate takes at least one byte.
+	if n > uint64(len(b)) {
+		return nil, 0, nil, errors.Errorf("invalid number of coordinates: %d", n)
+	}
+	coords = make([]float64, n)
+	for i := range coords {
+		if b, coords[i], err = decodeFloat(b); err != nil {
+			return nil, 0, nil, err
+		}
+	}
+	return b, flags, coords, nil
+}
+
 // EncodeGeoAscending encodes a geopb.SpatialObject value in ascending order and
 // returns the new buffer.
 // It is sorted by the given curve index, followed by the bytes of the spatial object.
@@ -1752,6 +1830,8 @@ const (
 	JSONArrayDesc  Type = 39
 	JSONObject     Type = 40
 	JSONObjectDesc Type = 41
+	Geometric      Type = 42
+	GeometricDesc  Type = 43
 )
 
 // typMap maps an encoded type byte to a decoded Type. It's got 256 slots, one
@@ -1832,6 +1912,10 @@ func slowPeekType(b []byte) Type {
 			return Geo
 		case m == box2DMarker:
 			return Box2D
+		case m == geometricMarker:
+			return Geometric
+		case m == geometricDescMarker:
+			return GeometricDesc
 		case m == geoDescMarker:
 			return GeoDesc
 		case m == byte(Array):
@@ -1929,6 +2013,49 @@ func peekBox2DLength(b []byte) (int, error) {
 	return length, nil
 }
 
+// peekGeometricLength peeks to look at the length of a geometric encoding,
+// including its marker.
+func peekGeometricLength(b []byte) (int, error) {
+	decodeUvarint := DecodeUvarintAscending
+	if b[0] == geometricDescMarker {
+		decodeUvarint = DecodeUvarintDescending
+	}
+	curr := b[1:]
+	if len(curr) == 0 {
+		return 0, errors.Newf("slice too short for geometric value")
+	}
+	flagsLen, err := getVarintLen(curr)
+	if err != nil {
+		return 0, err
+	}
+	curr = curr[flagsLen:]
+	rest, n, err := decodeUvarint(curr)
+	if err != nil {
+		return 0, err
+	}
+	length := 1 + flagsLen + len(curr) - len(rest)
+	curr = rest
+	for i := uint64(0); i < n; i++ {
+		if len(curr) == 0 {
+			return 0, errors.Newf("slice too short for geometric value")
+		}
+		switch curr[0] {
+		case floatNaN, floatNaNDesc, floatZero:
+			length++
+			curr = curr[1:]
+		case floatNeg, floatPos:
+			if len(curr) < 9 {
+				return 0, errors.Newf("slice too short for geometric value")
+			}
+			length += 9
+			curr = curr[9:]
+		default:
+			return 0, errors.Newf("unexpected marker for geometric value: %x", curr[0])
+		}
+	}
+	return length, nil
+}
+
 // PeekLength returns the length of the encoded value at the start of b.  Note:
 // if this function succeeds, it's not a guarantee that decoding the value will
 // succeed. PeekLength is meant to be used on key encoded data only.
@@ -2010,6 +2137,8 @@ func PeekLength(b []byte) (int, error) {
 		return 1 + length, nil
 	case geoInvertedIndexMarker:
 		return getGeoInvertedIndexKeyLength(b)
+	case geometricMarker, geometricDescMarker:
+		return peekGeometricLength(b)
 	case geoMarker:
 		// Expect to reserve at least 8 bytes for int64.
 		if len(b) < 8 {
@@ -2342,6 +2471,26 @@ func prettyPrintFirstValue(dir Direction, b []byte) ([]byte, string, error) {
 			return b, "", err
 		}
 		return b, d.StringNanos(), nil
+	case Geometric, GeometricDesc:
+		var coords []float64
+		if typ == GeometricDesc {
+			b, _, coords, err = DecodeGeometricDescending(b)
+		} else {
+			b, _, coords, err = DecodeGeometricAscending(b)
+		}
+		if err != nil {
+			return b, "", err
+		}
+		var build strings.Builder
+		build.WriteString("(")
+		for i, c := range coords {
+			if i > 0 {
+				build.WriteString(",")
+			}
+			build.WriteString(strconv.FormatFloat(c, 'g', -1, 64))
+		}
+		build.WriteString(")")
+		return b, build.String(), nil
 	default:
 		if len(b) >= 1 {
 			switch b[0] {
@@ -1285,6 +1285,63 @@ func TestEncodeDecodeBox2D(t *testing.T) {
 	}
 }
 
+func TestEncodeDecodeGeometric(t *testing.T) {
+	type geometric struct {
+		flags  uint64
+		coords []float64
+	}
+	ordered := []geometric{
+		{flags: 0, coords: []float64{math.NaN(), 0}},
+		{flags: 0, coords: []float64{math.Inf(-1), 0}},
+		{flags: 0, coords: []float64{-1, 2}},
+		{flags: 0, coords: []float64{0, -1}},
+		{flags: 0, coords: []float64{0, 0}},
+		{flags: 0, coords: []float64{0, 0, 0}},
+		{flags: 0, coords: []float64{0, 0, 1, 1}},
+		{flags: 0, coords: []float64{1, 1, 0, 0}},
+		{flags: 1, coords: []float64{-1, -1}},
+		{flags: 1, coords: []float64{-1, -1, -1, -1}},
+	}
+	for _, dir := range []Direction{Ascending, Descending} {
+		t.Run(fmt.Sprintf("dir:%d", dir), func(t *testing.T) {
+			var lastEncoded []byte
+			for i, g := range ordered {
+				var b []byte
+				var flags uint64
+				var coords []float64
+				var err error
+				if dir == Ascending {
+					b = EncodeGeometricAscending(nil, g.flags, g.coords)
+					_, flags, coords, err = DecodeGeometricAscending(b)
+				} else {
+					b = EncodeGeometricDescending(nil, g.flags, g.coords)
+					_, flags, coords, err = DecodeGeometricDescending(b)
+				}
+				require.NoError(t, err)
+				require.Equal(t, g.flags, flags)
+				require.Equal(t, len(g.coords), len(coords))
+				for j := range coords {
+					if math.IsNaN(g.coords[j]) {
+						require.True(t, math.IsNaN(coords[j]))
+					} else {
+						require.Equal(t, g.coords[j], coords[j])
+					}
+				}
+				testPeekLength(t, b)
+
+				if i > 0 {
+					if dir == Ascending {
+						assert.Truef(t, bytes.Compare(b, lastEncoded) > 0, "expected %v > %v", g, ordered[i-1])
+					} else {
+						assert.Truef(t, bytes.Compare(b, lastEncoded) < 0, "expected %v < %v", g, ordered[i-1])
+					}
+				}
+				lastEncoded = b
+			}
+		})
+	}
+}
+
 func TestEncodeDecodeGeometry(t *testing.T) {
 	testCases := []struct {
 		orderedWKTs []string
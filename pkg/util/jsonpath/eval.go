@@ -0,0 +1,823 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// decimalCtx is the context for arithmetic on jsonpath numbers.
+var decimalCtx = &apd.Context{
+	Precision:   2000,
+	Rounding:    apd.RoundHalfUp,
+	MaxExponent: 2000,
+	MinExponent: -2000,
+	Traps:       apd.DefaultTraps,
+}
+
+// errFatal marks errors that are never suppressed, even in silent mode or
+// inside predicates.
+var errFatal = errors.New("fatal jsonpath error")
+
+// Query evaluates the path against the target JSON document, and returns the
+// resulting sequence of items. Named variables are looked up in vars, which must
+// be a JSON object or nil. If silent is true, errors that are caused by the
+// structure of the target, such as missing object keys or array elements, are
+// suppressed and an empty sequence is returned.
+func (p *Jsonpath) Query(target, vars json.JSON, silent bool) ([]json.JSON, error) {
+	e, err := newEvaluator(p, target, vars)
+	if err != nil {
+		return nil, err
+	}
+	res, err := e.eval(p.Expr, target)
+	if err != nil {
+		if silent && !errors.Is(err, errFatal) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// Exists returns whether the path selects any item of the target JSON document.
+// If silent is true and the evaluation fails, ok is false.
+func (p *Jsonpath) Exists(target, vars json.JSON, silent bool) (res, ok bool, err error) {
+	items, err := p.Query(target, vars, false /* silent */)
+	if err != nil {
+		if silent && !errors.Is(err, errFatal) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	return len(items) > 0, true, nil
+}
+
+// Match returns the result of a predicate check path. If the result is unknown,
+// or silent is true and the evaluation fails, ok is false.
+func (p *Jsonpath) Match(target, vars json.JSON, silent bool) (res, ok bool, err error) {
+	items, err := p.Query(target, vars, false /* silent */)
+	if err == nil && len(items) == 1 {
+		switch items[0].Type() {
+		case json.TrueJSONType:
+			return true, true, nil
+		case json.FalseJSONType:
+			return false, true, nil
+		case json.NullJSONType:
+			return false, false, nil
+		}
+	}
+	if err == nil {
+		err = pgerror.New(pgcode.SingletonSQLJSONItemRequired, "single boolean result is expected")
+	}
+	if silent && !errors.Is(err, errFatal) {
+		return false, false, nil
+	}
+	return false, false, err
+}
+
+// predResult is the result of a predicate, in three-valued logic.
+type predResult int
+
+const (
+	predFalse predResult = iota
+	predTrue
+	predUnknown
+)
+
+func (r predResult) toJSON() json.JSON {
+	switch r {
+	case predTrue:
+		return json.TrueJSONValue
+	case predFalse:
+		return json.FalseJSONValue
+	}
+	return json.NullJSONValue
+}
+
+type evaluator struct {
+	strict bool
+	root   json.JSON
+	vars   json.JSON
+	// last is the last index of the array being subscripted, or -1 outside of
+	// array subscripts.
+	last int
+}
+
+func newEvaluator(p *Jsonpath, target, vars json.JSON) (*evaluator, error) {
+	if vars != nil && vars.Type() != json.ObjectJSONType {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			`"vars" argument is not an object`)
+	}
+	return &evaluator{strict: p.Strict, root: target, vars: vars, last: -1}, nil
+}
+
+// structuralError returns an error caused by the structure of the target
+// document. Such errors are suppressed in lax mode.
+func (e *evaluator) structuralError(code pgcode.Code, format string, args ...interface{}) error {
+	if !e.strict {
+		return nil
+	}
+	return pgerror.Newf(code, format, args...)
+}
+
+// eval evaluates an expression, and returns the resulting sequence of items.
+// cur is the current item of the innermost filter expression.
+func (e *evaluator) eval(expr Expr, cur json.JSON) ([]json.JSON, error) {
+	switch t := expr.(type) {
+	case Root:
+		return []json.JSON{e.root}, nil
+
+	case Current:
+		return []json.JSON{cur}, nil
+
+	case Last:
+		if e.last < 0 {
+			return nil, errors.AssertionFailedf("last used outside of array subscript")
+		}
+		return []json.JSON{json.FromInt(e.last)}, nil
+
+	case *Variable:
+		var v json.JSON
+		if e.vars != nil {
+			var err error
+			if v, err = e.vars.FetchValKey(t.Name); err != nil {
+				return nil, err
+			}
+		}
+		if v == nil {
+			return nil, errors.Mark(pgerror.Newf(pgcode.UndefinedObject,
+				"could not find jsonpath variable %q", t.Name), errFatal)
+		}
+		return []json.JSON{v}, nil
+
+	case *Literal:
+		return []json.JSON{t.Value}, nil
+
+	case *Path:
+		items, err := e.eval(t.Start, cur)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range t.Accessors {
+			var next []json.JSON
+			for _, item := range items {
+				if next, err = e.evalAccessor(a, item, cur, next); err != nil {
+					return nil, err
+				}
+			}
+			items = next
+		}
+		return items, nil
+
+	case *Binary:
+		if isPredicate(t) {
+			break
+		}
+		return e.evalArithmetic(t, cur)
+
+	case *Unary:
+		if isPredicate(t) {
+			break
+		}
+		return e.evalUnaryArithmetic(t, cur)
+	}
+
+	// Predicates evaluate to a single boolean item, or null if unknown.
+	res, err := e.evalPredicate(expr, cur)
+	if err != nil {
+		return nil, err
+	}
+	return []json.JSON{res.toJSON()}, nil
+}
+
+// unwrap replaces the arrays of a sequence with their elements, in lax mode.
+func (e *evaluator) unwrap(items []json.JSON) []json.JSON {
+	if e.strict {
+		return items
+	}
+	var res []json.JSON
+	for _, item := range items {
+		if item.Type() != json.ArrayJSONType {
+			res = append(res, item)
+			continue
+		}
+		elems, _ := item.AsArray()
+		res = append(res, elems...)
+	}
+	return res
+}
+
+// evalAccessor applies an accessor to an item, and appends the selected items
+// to res.
+func (e *evaluator) evalAccessor(
+	a Accessor, item, cur json.JSON, res []json.JSON,
+) ([]json.JSON, error) {
+	switch t := a.(type) {
+	case *Key:
+		switch item.Type() {
+		case json.ObjectJSONType:
+			v, err := item.FetchValKey(t.Name)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				return res, e.structuralError(pgcode.SQLJSONMemberNotFound,
+					"JSON object does not contain key %q", t.Name)
+			}
+			return append(res, v), nil
+		case json.ArrayJSONType:
+			if !e.strict {
+				return e.evalOnElements(a, item, cur, res)
+			}
+		}
+		return res, e.structuralError(pgcode.SQLJSONMemberNotFound,
+			"jsonpath member accessor can only be applied to an object")
+
+	case AnyKey:
+		switch item.Type() {
+		case json.ObjectJSONType:
+			iter, err := item.ObjectIter()
+			if err != nil {
+				return nil, err
+			}
+			for iter.Next() {
+				res = append(res, iter.Value())
+			}
+			return res, nil
+		case json.ArrayJSONType:
+			if !e.strict {
+				return e.evalOnElements(a, item, cur, res)
+			}
+		}
+		return res, e.structuralError(pgcode.SQLJSONObjectNotFound,
+			"jsonpath wildcard member accessor can only be applied to an object")
+
+	case AnyIndex:
+		if item.Type() == json.ArrayJSONType {
+			elems, _ := item.AsArray()
+			return append(res, elems...), nil
+		}
+		if !e.strict {
+			return append(res, item), nil
+		}
+		return res, e.structuralError(pgcode.SQLJSONArrayNotFound,
+			"jsonpath wildcard array accessor can only be applied to an array")
+
+	case Subscripts:
+		return e.evalSubscripts(t, item, cur, res)
+
+	case *AnyPath:
+		return e.evalAnyPath(t, item, 0 /* level */, res)
+
+	case *Filter:
+		if item.Type() == json.ArrayJSONType && !e.strict {
+			return e.evalOnElements(a, item, cur, res)
+		}
+		r, err := e.evalPredicate(t.Pred, item)
+		if err != nil {
+			return nil, err
+		}
+		if r == predTrue {
+			res = append(res, item)
+		}
+		return res, nil
+
+	case *Method:
+		return e.evalMethod(t, item, cur, res)
+	}
+	return nil, errors.AssertionFailedf("unhandled jsonpath accessor %T", a)
+}
+
+// evalOnElements applies an accessor to each element of an array.
+func (e *evaluator) evalOnElements(
+	a Accessor, array, cur json.JSON, res []json.JSON,
+) ([]json.JSON, error) {
+	elems, _ := array.AsArray()
+	for _, elem := range elems {
+		var err error
+		if res, err = e.evalAccessor(a, elem, cur, res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (e *evaluator) evalSubscripts(
+	subs Subscripts, item, cur json.JSON, res []json.JSON,
+) ([]json.JSON, error) {
+	elems, isArray := item.AsArray()
+	if !isArray {
+		if e.strict {
+			return res, e.structuralError(pgcode.SQLJSONArrayNotFound,
+				"jsonpath array accessor can only be applied to an array")
+		}
+		// Treat the item as a single-element array in lax mode.
+		elems = []json.JSON{item}
+	}
+
+	saveLast := e.last
+	e.last = len(elems) - 1
+	defer func() { e.last = saveLast }()
+
+	for _, sub := range subs {
+		from, err := e.evalSubscript(sub.From, cur)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if sub.To != nil {
+			if to, err = e.evalSubscript(sub.To, cur); err != nil {
+				return nil, err
+			}
+		}
+		if from < 0 || from > to || to >= len(elems) {
+			if e.strict {
+				return nil, pgerror.New(pgcode.InvalidSQLJSONSubscript,
+					"jsonpath array subscript is out of bounds")
+			}
+			if from < 0 {
+				from = 0
+			}
+			if to >= len(elems) {
+				to = len(elems) - 1
+			}
+		}
+		for i := from; i <= to; i++ {
+			res = append(res, elems[i])
+		}
+	}
+	return res, nil
+}
+
+// evalSubscript evaluates an array subscript expression, which must return a
+// single number. The number is truncated to an integer.
+func (e *evaluator) evalSubscript(expr Expr, cur json.JSON) (int, error) {
+	items, err := e.eval(expr, cur)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) == 1 {
+		if d, ok := items[0].AsDecimal(); ok {
+			var i apd.Decimal
+			d.Modf(&i, nil /* frac */)
+			if v, err := i.Int64(); err == nil && v >= math.MinInt32 && v <= math.MaxInt32 {
+				return int(v), nil
+			}
+			return 0, pgerror.New(pgcode.InvalidSQLJSONSubscript,
+				"jsonpath array subscript is out of integer range")
+		}
+	}
+	return 0, pgerror.New(pgcode.InvalidSQLJSONSubscript,
+		"jsonpath array subscript is not a single numeric value")
+}
+
+// evalAnyPath appends the descendants of an item that are between the levels
+// of the accessor, where item is at the given level.
+func (e *evaluator) evalAnyPath(
+	a *AnyPath, item json.JSON, level int, res []json.JSON,
+) ([]json.JSON, error) {
+	if level >= a.First && a.First >= 0 {
+		res = append(res, item)
+	}
+	if a.Last >= 0 && level >= a.Last {
+		return res, nil
+	}
+	var children []json.JSON
+	switch item.Type() {
+	case json.ArrayJSONType:
+		children, _ = item.AsArray()
+	case json.ObjectJSONType:
+		iter, err := item.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		for iter.Next() {
+			children = append(children, iter.Value())
+		}
+	}
+	for _, child := range children {
+		var err error
+		if res, err = e.evalAnyPath(a, child, level+1, res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (e *evaluator) evalMethod(
+	m *Method, item, cur json.JSON, res []json.JSON,
+) ([]json.JSON, error) {
+	typ := item.Type()
+	if typ == json.ArrayJSONType && !e.strict && m.Kind != MethodType && m.Kind != MethodSize {
+		return e.evalOnElements(m, item, cur, res)
+	}
+	switch m.Kind {
+	case MethodType:
+		return append(res, json.FromString(typeName(item))), nil
+
+	case MethodSize:
+		if typ == json.ArrayJSONType {
+			return append(res, json.FromInt(item.Len())), nil
+		}
+		if !e.strict {
+			return append(res, json.FromInt(1)), nil
+		}
+		return res, e.structuralError(pgcode.SQLJSONArrayNotFound,
+			"jsonpath item method .%s() can only be applied to an array", m.Kind)
+
+	case MethodDouble:
+		switch typ {
+		case json.NumberJSONType:
+			d, _ := item.AsDecimal()
+			f, err := d.Float64()
+			if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+				return nil, pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+					"numeric argument of jsonpath item method .%s() is out of range for type double precision", m.Kind)
+			}
+			return append(res, item), nil
+		case json.StringJSONType:
+			s, err := item.AsText()
+			if err != nil {
+				return nil, err
+			}
+			f, err := strconv.ParseFloat(strings.TrimSpace(*s), 64)
+			if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+				return nil, pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+					"string argument of jsonpath item method .%s() is not a valid representation of a double precision number", m.Kind)
+			}
+			j, err := json.FromFloat64(f)
+			if err != nil {
+				return nil, err
+			}
+			return append(res, j), nil
+		}
+		return nil, pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+			"jsonpath item method .%s() can only be applied to a string or numeric value", m.Kind)
+
+	case MethodAbs, MethodFloor, MethodCeiling:
+		d, ok := item.AsDecimal()
+		if !ok {
+			return nil, pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a numeric value", m.Kind)
+		}
+		var r apd.Decimal
+		var err error
+		switch m.Kind {
+		case MethodAbs:
+			_, err = decimalCtx.Abs(&r, d)
+		case MethodFloor:
+			_, err = decimalCtx.Floor(&r, d)
+		case MethodCeiling:
+			_, err = decimalCtx.Ceil(&r, d)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return append(res, json.FromDecimal(r)), nil
+
+	case MethodKeyValue:
+		if typ != json.ObjectJSONType {
+			return nil, pgerror.Newf(pgcode.SQLJSONObjectNotFound,
+				"jsonpath item method .%s() can only be applied to an object", m.Kind)
+		}
+		iter, err := item.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		for iter.Next() {
+			b := json.NewObjectBuilder(3)
+			b.Add("id", json.FromInt(0))
+			b.Add("key", json.FromString(iter.Key()))
+			b.Add("value", iter.Value())
+			res = append(res, b.Build())
+		}
+		return res, nil
+	}
+	return nil, errors.AssertionFailedf("unhandled jsonpath method %s", m.Kind)
+}
+
+// typeName returns the name of the type of a JSON item, as returned by the
+// type() method.
+func typeName(j json.JSON) string {
+	switch j.Type() {
+	case json.NullJSONType:
+		return "null"
+	case json.TrueJSONType, json.FalseJSONType:
+		return "boolean"
+	case json.NumberJSONType:
+		return "number"
+	case json.StringJSONType:
+		return "string"
+	case json.ArrayJSONType:
+		return "array"
+	}
+	return "object"
+}
+
+// evalNumericOperand evaluates an operand of an arithmetic operator, which
+// must return a single number.
+func (e *evaluator) evalNumericOperand(
+	expr Expr, cur json.JSON, op Op, side string,
+) (*apd.Decimal, error) {
+	items, err := e.eval(expr, cur)
+	if err != nil {
+		return nil, err
+	}
+	items = e.unwrap(items)
+	if len(items) == 1 {
+		if d, ok := items[0].AsDecimal(); ok {
+			return d, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.SingletonSQLJSONItemRequired,
+		"%s operand of jsonpath operator %s is not a single numeric value", side, op)
+}
+
+func (e *evaluator) evalArithmetic(b *Binary, cur json.JSON) ([]json.JSON, error) {
+	left, err := e.evalNumericOperand(b.Left, cur, b.Op, "left")
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.evalNumericOperand(b.Right, cur, b.Op, "right")
+	if err != nil {
+		return nil, err
+	}
+	var r apd.Decimal
+	switch b.Op {
+	case OpAdd:
+		_, err = decimalCtx.Add(&r, left, right)
+	case OpSub:
+		_, err = decimalCtx.Sub(&r, left, right)
+	case OpMul:
+		_, err = decimalCtx.Mul(&r, left, right)
+	case OpDiv, OpMod:
+		if right.IsZero() {
+			return nil, pgerror.New(pgcode.DivisionByZero, "division by zero")
+		}
+		if b.Op == OpDiv {
+			_, err = decimalCtx.Quo(&r, left, right)
+			if err == nil {
+				_, _ = r.Reduce(&r)
+			}
+		} else {
+			_, err = decimalCtx.Rem(&r, left, right)
+		}
+	default:
+		return nil, errors.AssertionFailedf("unhandled jsonpath operator %s", b.Op)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []json.JSON{json.FromDecimal(r)}, nil
+}
+
+func (e *evaluator) evalUnaryArithmetic(u *Unary, cur json.JSON) ([]json.JSON, error) {
+	items, err := e.eval(u.Operand, cur)
+	if err != nil {
+		return nil, err
+	}
+	items = e.unwrap(items)
+	res := make([]json.JSON, 0, len(items))
+	for _, item := range items {
+		d, ok := item.AsDecimal()
+		if !ok {
+			return nil, pgerror.Newf(pgcode.NonNumericSQLJSONItem,
+				"operand of unary jsonpath operator %s is not a numeric value", u.Op)
+		}
+		if u.Op == OpMinus {
+			var r apd.Decimal
+			r.Neg(d)
+			item = json.FromDecimal(r)
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+// evalPredicate evaluates a predicate. Non-fatal errors make the result
+// unknown.
+func (e *evaluator) evalPredicate(expr Expr, cur json.JSON) (predResult, error) {
+	res, err := e.evalPredicateImpl(expr, cur)
+	if err != nil {
+		if errors.Is(err, errFatal) {
+			return predUnknown, err
+		}
+		return predUnknown, nil
+	}
+	return res, nil
+}
+
+func (e *evaluator) evalPredicateImpl(expr Expr, cur json.JSON) (predResult, error) {
+	switch t := expr.(type) {
+	case *Binary:
+		switch t.Op {
+		case OpAnd, OpOr:
+			left, err := e.evalPredicate(t.Left, cur)
+			if err != nil {
+				return predUnknown, err
+			}
+			if t.Op == OpAnd && left == predFalse || t.Op == OpOr && left == predTrue {
+				return left, nil
+			}
+			right, err := e.evalPredicate(t.Right, cur)
+			if err != nil {
+				return predUnknown, err
+			}
+			if left == predUnknown {
+				if t.Op == OpAnd && right == predFalse || t.Op == OpOr && right == predTrue {
+					return right, nil
+				}
+				return predUnknown, nil
+			}
+			return right, nil
+		case OpStartsWith:
+			return e.evalCompare(t, cur, func(l, r json.JSON) (predResult, error) {
+				ls, lok := asString(l)
+				rs, rok := asString(r)
+				if !lok || !rok {
+					return predUnknown, errors.New("starts with operands must be strings")
+				}
+				if strings.HasPrefix(ls, rs) {
+					return predTrue, nil
+				}
+				return predFalse, nil
+			})
+		}
+		return e.evalCompare(t, cur, func(l, r json.JSON) (predResult, error) {
+			return compareItems(t.Op, l, r), nil
+		})
+
+	case *Unary:
+		switch t.Op {
+		case OpNot:
+			r, err := e.evalPredicate(t.Operand, cur)
+			switch r {
+			case predTrue:
+				return predFalse, err
+			case predFalse:
+				return predTrue, err
+			}
+			return predUnknown, err
+		case OpIsUnknown:
+			r, err := e.evalPredicate(t.Operand, cur)
+			if r == predUnknown {
+				return predTrue, err
+			}
+			return predFalse, err
+		case OpExists:
+			items, err := e.eval(t.Operand, cur)
+			if err != nil {
+				return predUnknown, err
+			}
+			if len(items) > 0 {
+				return predTrue, nil
+			}
+			return predFalse, nil
+		}
+
+	case *LikeRegex:
+		items, err := e.eval(t.Expr, cur)
+		if err != nil {
+			return predUnknown, err
+		}
+		items = e.unwrap(items)
+		return e.anyItem(items, func(item json.JSON) predResult {
+			s, ok := asString(item)
+			if !ok {
+				return predUnknown
+			}
+			if t.re.MatchString(s) {
+				return predTrue
+			}
+			return predFalse
+		}), nil
+	}
+	return predUnknown, errors.AssertionFailedf("unhandled jsonpath predicate %T", expr)
+}
+
+// anyItem returns true if the predicate is true for any item. In strict mode,
+// the result is unknown if the predicate is unknown for any item. In lax mode,
+// the result is unknown if the predicate is unknown for some items, and not
+// true for any item.
+func (e *evaluator) anyItem(items []json.JSON, pred func(json.JSON) predResult) predResult {
+	found, unknown := false, false
+	for _, item := range items {
+		switch pred(item) {
+		case predTrue:
+			if !e.strict {
+				return predTrue
+			}
+			found = true
+		case predUnknown:
+			if e.strict {
+				return predUnknown
+			}
+			unknown = true
+		}
+	}
+	switch {
+	case found:
+		return predTrue
+	case unknown:
+		return predUnknown
+	}
+	return predFalse
+}
+
+// evalCompare evaluates a comparison predicate, which is true if the
+// comparison is true for any pair of items of the operands.
+func (e *evaluator) evalCompare(
+	b *Binary, cur json.JSON, cmp func(l, r json.JSON) (predResult, error),
+) (predResult, error) {
+	left, err := e.eval(b.Left, cur)
+	if err != nil {
+		return predUnknown, err
+	}
+	left = e.unwrap(left)
+	right, err := e.eval(b.Right, cur)
+	if err != nil {
+		return predUnknown, err
+	}
+	right = e.unwrap(right)
+	return e.anyItem(left, func(l json.JSON) predResult {
+		return e.anyItem(right, func(r json.JSON) predResult {
+			res, err := cmp(l, r)
+			if err != nil {
+				return predUnknown
+			}
+			return res
+		})
+	}), nil
+}
+
+func asString(j json.JSON) (string, bool) {
+	if j.Type() != json.StringJSONType {
+		return "", false
+	}
+	s, err := j.AsText()
+	if err != nil || s == nil {
+		return "", false
+	}
+	return *s, true
+}
+
+// compareItems compares two scalar items. Null is equal only to null, and
+// items of other different types, as well as arrays and objects, are not
+// comparable.
+func compareItems(op Op, l, r json.JSON) predResult {
+	lt, rt := l.Type(), r.Type()
+	isBool := func(t json.Type) bool { return t == json.TrueJSONType || t == json.FalseJSONType }
+	if lt != rt && !(isBool(lt) && isBool(rt)) {
+		if lt == json.NullJSONType || rt == json.NullJSONType {
+			if op == OpNe {
+				return predTrue
+			}
+			return predFalse
+		}
+		return predUnknown
+	}
+	if lt == json.ArrayJSONType || lt == json.ObjectJSONType {
+		return predUnknown
+	}
+	c, err := l.Compare(r)
+	if err != nil {
+		return predUnknown
+	}
+	var res bool
+	switch op {
+	case OpEq:
+		res = c == 0
+	case OpNe:
+		res = c != 0
+	case OpLt:
+		res = c < 0
+	case OpLe:
+		res = c <= 0
+	case OpGt:
+		res = c > 0
+	case OpGe:
+		res = c >= 0
+	default:
+		return predUnknown
+	}
+	if res {
+		return predTrue
+	}
+	return predFalse
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	const doc = `{"a": [1, 2, 3, {"b": "x"}], "c": {"d": {"e": 5}}, "s": "abc", "n": null}`
+	for _, tc := range []struct {
+		path     string
+		vars     string
+		expected []string
+	}{
+		{`$`, ``, []string{doc}},
+		{`$.c.d.e`, ``, []string{`5`}},
+		{`$.missing`, ``, nil},
+		{`$.a[0]`, ``, []string{`1`}},
+		{`$.a[last]`, ``, []string{`{"b": "x"}`}},
+		{`$.a[1 to 2]`, ``, []string{`2`, `3`}},
+		{`$.a[0, 2 to last]`, ``, []string{`1`, `3`, `{"b": "x"}`}},
+		{`$.a[10]`, ``, nil},
+		{`$.a[1.7]`, ``, []string{`2`}},
+		{`$.a[*]`, ``, []string{`1`, `2`, `3`, `{"b": "x"}`}},
+		{`$.a.b`, ``, []string{`"x"`}},
+		{`$.a ? (@ >= 2)`, ``, []string{`2`, `3`}},
+		{`$.a[*] ? (@ > $min)`, `{"min": 1}`, []string{`2`, `3`}},
+		{`$.c.**.e`, ``, []string{`5`}},
+		{`$.c.**{1}`, ``, []string{`{"e": 5}`}},
+		{`$.a.size()`, ``, []string{`4`}},
+		{`$.s.size()`, ``, []string{`1`}},
+		{`$.a[*].type()`, ``, []string{`"number"`, `"number"`, `"number"`, `"object"`}},
+		{`$.n.type()`, ``, []string{`"null"`}},
+		{`$.c.d.keyvalue()`, ``, []string{`{"id": 0, "key": "e", "value": 5}`}},
+		{`$.c.d.e + 1`, ``, []string{`6`}},
+		{`$.c.d.e * 2 - 1`, ``, []string{`9`}},
+		{`$.c.d.e / 2`, ``, []string{`2.5`}},
+		{`$.c.d.e % 2`, ``, []string{`1`}},
+		{`-$.a[0 to 2]`, ``, []string{`-1`, `-2`, `-3`}},
+		{`$.a[0 to 2].abs()`, ``, []string{`1`, `2`, `3`}},
+		{`$.c.d.e.double()`, ``, []string{`5`}},
+		{`"1.5".double().floor()`, ``, []string{`1`}},
+		{`"1.5".double().ceiling()`, ``, []string{`2`}},
+		{`$.c.d.e > 1`, ``, []string{`true`}},
+		{`$.s == 1`, ``, []string{`null`}},
+		{`$.n == null`, ``, []string{`true`}},
+		{`$.n != 1`, ``, []string{`true`}},
+		{`$.s starts with "ab"`, ``, []string{`true`}},
+		{`$.s like_regex "^A" flag "i"`, ``, []string{`true`}},
+		{`exists($.c.d)`, ``, []string{`true`}},
+		{`exists($.c.x)`, ``, []string{`false`}},
+		{`$.a[*] > 2 && $.s == "abc"`, ``, []string{`true`}},
+		{`!($.a[0 to 2] > 5)`, ``, []string{`true`}},
+		{`($.s == 1) is unknown`, ``, []string{`true`}},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			target, err := json.ParseJSON(doc)
+			require.NoError(t, err)
+			var vars json.JSON
+			if tc.vars != "" {
+				vars, err = json.ParseJSON(tc.vars)
+				require.NoError(t, err)
+			}
+			res, err := MustParse(tc.path).Query(target, vars, false /* silent */)
+			require.NoError(t, err)
+			var expected, actual []string
+			for _, e := range tc.expected {
+				j, err := json.ParseJSON(e)
+				require.NoError(t, err)
+				expected = append(expected, j.String())
+			}
+			for _, j := range res {
+				actual = append(actual, j.String())
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	const doc = `{"a": [1, 2], "s": "abc"}`
+	for _, tc := range []struct {
+		path   string
+		errMsg string
+		// silent is true if the error is suppressed in silent mode.
+		silent bool
+	}{
+		{`strict $.missing`, `JSON object does not contain key "missing"`, true},
+		{`strict $.a.b`, `jsonpath member accessor can only be applied to an object`, true},
+		{`strict $.a[5]`, `jsonpath array subscript is out of bounds`, true},
+		{`strict $.s[0]`, `jsonpath array accessor can only be applied to an array`, true},
+		{`strict $.s.size()`, `jsonpath item method .size() can only be applied to an array`, true},
+		{`$.s + 1`, `left operand of jsonpath operator + is not a single numeric value`, true},
+		{`$.a[0] / 0`, `division by zero`, true},
+		{`$.s.abs()`, `jsonpath item method .abs() can only be applied to a numeric value`, true},
+		{`$.a[$x]`, `could not find jsonpath variable "x"`, false},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			target, err := json.ParseJSON(doc)
+			require.NoError(t, err)
+			p := MustParse(tc.path)
+			_, err = p.Query(target, nil /* vars */, false /* silent */)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+
+			res, err := p.Query(target, nil /* vars */, true /* silent */)
+			if tc.silent {
+				require.NoError(t, err)
+				assert.Empty(t, res)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestExistsAndMatch(t *testing.T) {
+	target, err := json.ParseJSON(`{"a": [1, 2, 3], "s": "abc"}`)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		path    string
+		res, ok bool
+	}{
+		{`$.a`, true, true},
+		{`$.b`, false, true},
+		{`$.a ? (@ > 2)`, true, true},
+		{`$.a ? (@ > 3)`, false, true},
+		{`strict $.b`, false, false},
+	} {
+		res, ok, err := MustParse(tc.path).Exists(target, nil /* vars */, true /* silent */)
+		require.NoError(t, err)
+		assert.Equal(t, tc.res, res, tc.path)
+		assert.Equal(t, tc.ok, ok, tc.path)
+	}
+
+	for _, tc := range []struct {
+		path    string
+		res, ok bool
+	}{
+		{`$.a[*] > 2`, true, true},
+		{`$.a[*] > 3`, false, true},
+		{`$.s > 3`, false, false},
+		{`$.a`, false, false},
+	} {
+		res, ok, err := MustParse(tc.path).Match(target, nil /* vars */, true /* silent */)
+		require.NoError(t, err)
+		assert.Equal(t, tc.res, res, tc.path)
+		assert.Equal(t, tc.ok, ok, tc.path)
+	}
+
+	_, _, err = MustParse(`$.a`).Match(target, nil /* vars */, false /* silent */)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "single boolean result is expected")
+
+	vars, err := json.ParseJSON(`[1]`)
+	require.NoError(t, err)
+	_, err = MustParse(`$`).Query(target, vars, true /* silent */)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"vars" argument is not an object`)
+}
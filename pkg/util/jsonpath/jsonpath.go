@@ -0,0 +1,443 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package jsonpath implements the SQL/JSON path language, which is used to
+// query JSON documents. See section 9.16.2 of the Postgres documentation for
+// a description of the language.
+package jsonpath
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+// Jsonpath is a parsed SQL/JSON path expression.
+type Jsonpath struct {
+	// Strict is true if the path is evaluated in strict mode. In the default lax
+	// mode, structural errors are suppressed and arrays are automatically
+	// unwrapped.
+	Strict bool
+	// Expr is the root expression of the path.
+	Expr Expr
+}
+
+// String returns the canonical representation of the path.
+func (p *Jsonpath) String() string {
+	var buf strings.Builder
+	if p.Strict {
+		buf.WriteString("strict ")
+	}
+	p.Expr.format(&buf, true /* parens */)
+	return buf.String()
+}
+
+// IsPredicate returns true if the path is a predicate check expression, which
+// evaluates to a single boolean value, rather than a path that selects items.
+func (p *Jsonpath) IsPredicate() bool {
+	return isPredicate(p.Expr)
+}
+
+// Expr is a node of a jsonpath expression.
+type Expr interface {
+	// format writes the canonical representation of the node to buf. If parens
+	// is true, operators are enclosed in parentheses.
+	format(buf *strings.Builder, parens bool)
+}
+
+// Root is the `$` variable, which refers to the JSON document being queried.
+type Root struct{}
+
+// Current is the `@` variable, which refers to the current item of a filter
+// expression.
+type Current struct{}
+
+// Last is the `last` variable, which refers to the last index of the array
+// being subscripted.
+type Last struct{}
+
+// Variable is a named variable, such as `$x`. The values of variables are
+// supplied by a JSON object when the path is evaluated.
+type Variable struct {
+	Name string
+}
+
+// Literal is a string, numeric, boolean or null constant.
+type Literal struct {
+	Value json.JSON
+}
+
+// Path is an expression followed by a chain of accessors, such as
+// `$.a[*] ? (@ > 1)`.
+type Path struct {
+	Start     Expr
+	Accessors []Accessor
+}
+
+// Accessor is an element of a Path that selects items from the items produced
+// by the preceding part of the path.
+type Accessor interface {
+	Expr
+	accessor()
+}
+
+// Key is a member accessor, such as `.a` or `."a"`.
+type Key struct {
+	Name string
+}
+
+// AnyKey is the wildcard member accessor `.*`.
+type AnyKey struct{}
+
+// AnyIndex is the wildcard array element accessor `[*]`.
+type AnyIndex struct{}
+
+// Subscript is an element of an array element accessor. It refers to a single
+// index, or to a range of indexes if To is not nil.
+type Subscript struct {
+	From Expr
+	To   Expr
+}
+
+// Subscripts is an array element accessor, such as `[0]` or `[1 to last]`.
+type Subscripts []Subscript
+
+// AnyPath is the recursive wildcard member accessor `.**`, which selects all
+// the descendants of an item between the given levels of nesting. The item
+// itself is at level 0. A negative Last means that the level is unbounded.
+type AnyPath struct {
+	First, Last int
+}
+
+// Filter is a filter expression, such as `? (@ > 1)`, which selects the items
+// for which the predicate is true.
+type Filter struct {
+	Pred Expr
+}
+
+// MethodKind identifies an item method.
+type MethodKind int
+
+const (
+	// MethodType returns the type of the item.
+	MethodType MethodKind = iota
+	// MethodSize returns the number of elements of an array.
+	MethodSize
+	// MethodDouble converts a number or string to a number.
+	MethodDouble
+	// MethodAbs returns the absolute value of a number.
+	MethodAbs
+	// MethodFloor rounds a number down.
+	MethodFloor
+	// MethodCeiling rounds a number up.
+	MethodCeiling
+	// MethodKeyValue returns the key-value pairs of an object.
+	MethodKeyValue
+)
+
+var methodNames = [...]string{
+	MethodType:     "type",
+	MethodSize:     "size",
+	MethodDouble:   "double",
+	MethodAbs:      "abs",
+	MethodFloor:    "floor",
+	MethodCeiling:  "ceiling",
+	MethodKeyValue: "keyvalue",
+}
+
+// String returns the name of the method.
+func (m MethodKind) String() string {
+	return methodNames[m]
+}
+
+// Method is an item method, such as `.size()`.
+type Method struct {
+	Kind MethodKind
+}
+
+// Op is the operator of a Binary or Unary expression.
+type Op int
+
+const (
+	// OpAnd is the `&&` operator.
+	OpAnd Op = iota
+	// OpOr is the `||` operator.
+	OpOr
+	// OpNot is the `!` operator.
+	OpNot
+	// OpIsUnknown is the `is unknown` operator.
+	OpIsUnknown
+	// OpExists is the `exists` operator.
+	OpExists
+	// OpEq is the `==` operator.
+	OpEq
+	// OpNe is the `!=` operator.
+	OpNe
+	// OpLt is the `<` operator.
+	OpLt
+	// OpLe is the `<=` operator.
+	OpLe
+	// OpGt is the `>` operator.
+	OpGt
+	// OpGe is the `>=` operator.
+	OpGe
+	// OpStartsWith is the `starts with` operator.
+	OpStartsWith
+	// OpAdd is the binary `+` operator.
+	OpAdd
+	// OpSub is the binary `-` operator.
+	OpSub
+	// OpMul is the `*` operator.
+	OpMul
+	// OpDiv is the `/` operator.
+	OpDiv
+	// OpMod is the `%` operator.
+	OpMod
+	// OpPlus is the unary `+` operator.
+	OpPlus
+	// OpMinus is the unary `-` operator.
+	OpMinus
+)
+
+var opNames = [...]string{
+	OpAnd:        "&&",
+	OpOr:         "||",
+	OpNot:        "!",
+	OpIsUnknown:  "is unknown",
+	OpExists:     "exists",
+	OpEq:         "==",
+	OpNe:         "!=",
+	OpLt:         "<",
+	OpLe:         "<=",
+	OpGt:         ">",
+	OpGe:         ">=",
+	OpStartsWith: "starts with",
+	OpAdd:        "+",
+	OpSub:        "-",
+	OpMul:        "*",
+	OpDiv:        "/",
+	OpMod:        "%",
+	OpPlus:       "+",
+	OpMinus:      "-",
+}
+
+// String returns the representation of the operator.
+func (o Op) String() string {
+	return opNames[o]
+}
+
+// priority returns the binding strength of the operator, which is used to
+// determine where parentheses are needed when formatting.
+func (o Op) priority() int {
+	switch o {
+	case OpOr:
+		return 0
+	case OpAnd:
+		return 1
+	case OpEq, OpNe, OpLt, OpLe, OpGt, OpGe, OpStartsWith:
+		return 2
+	case OpAdd, OpSub:
+		return 3
+	case OpMul, OpDiv, OpMod:
+		return 4
+	case OpPlus, OpMinus:
+		return 5
+	}
+	return 6
+}
+
+// Binary is an expression with a binary operator, such as `@ > 1` or
+// `$.a + $.b`.
+type Binary struct {
+	Op          Op
+	Left, Right Expr
+}
+
+// Unary is an expression with a unary operator, such as `-$.a`,
+// `!(@ > 1)` or `exists (@.a)`.
+type Unary struct {
+	Op      Op
+	Operand Expr
+}
+
+// LikeRegex is a `like_regex` predicate, such as `@ like_regex "^a" flag "i"`.
+type LikeRegex struct {
+	Expr    Expr
+	Pattern string
+	Flags   string
+	re      *regexp.Regexp
+}
+
+func (Key) accessor()        {}
+func (AnyKey) accessor()     {}
+func (AnyIndex) accessor()   {}
+func (Subscripts) accessor() {}
+func (AnyPath) accessor()    {}
+func (Filter) accessor()     {}
+func (Method) accessor()     {}
+
+// priority returns the binding strength of the expression.
+func priority(e Expr) int {
+	switch t := e.(type) {
+	case *Binary:
+		return t.Op.priority()
+	case *Unary:
+		return t.Op.priority()
+	}
+	return 6
+}
+
+// isPredicate returns true if the expression evaluates to a boolean value with
+// three-valued logic.
+func isPredicate(e Expr) bool {
+	switch t := e.(type) {
+	case *Binary:
+		return t.Op < OpAdd
+	case *Unary:
+		return t.Op < OpPlus
+	case *LikeRegex:
+		return true
+	}
+	return false
+}
+
+func (Root) format(buf *strings.Builder, _ bool) { buf.WriteByte('$') }
+
+func (Current) format(buf *strings.Builder, _ bool) { buf.WriteByte('@') }
+
+func (Last) format(buf *strings.Builder, _ bool) { buf.WriteString("last") }
+
+func (v *Variable) format(buf *strings.Builder, _ bool) {
+	buf.WriteByte('$')
+	buf.WriteString(json.FromString(v.Name).String())
+}
+
+func (l *Literal) format(buf *strings.Builder, _ bool) {
+	buf.WriteString(l.Value.String())
+}
+
+func (p *Path) format(buf *strings.Builder, _ bool) {
+	p.Start.format(buf, true /* parens */)
+	for _, a := range p.Accessors {
+		a.format(buf, false /* parens */)
+	}
+}
+
+func (k *Key) format(buf *strings.Builder, _ bool) {
+	buf.WriteByte('.')
+	buf.WriteString(json.FromString(k.Name).String())
+}
+
+func (AnyKey) format(buf *strings.Builder, _ bool) { buf.WriteString(".*") }
+
+func (AnyIndex) format(buf *strings.Builder, _ bool) { buf.WriteString("[*]") }
+
+func (s Subscripts) format(buf *strings.Builder, _ bool) {
+	buf.WriteByte('[')
+	for i, sub := range s {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		sub.From.format(buf, false /* parens */)
+		if sub.To != nil {
+			buf.WriteString(" to ")
+			sub.To.format(buf, false /* parens */)
+		}
+	}
+	buf.WriteByte(']')
+}
+
+func (a *AnyPath) format(buf *strings.Builder, _ bool) {
+	buf.WriteString(".**")
+	formatLevel := func(level int) {
+		if level < 0 {
+			buf.WriteString("last")
+		} else {
+			buf.WriteString(json.FromInt(level).String())
+		}
+	}
+	switch {
+	case a.First == 0 && a.Last < 0:
+	case a.First == a.Last:
+		buf.WriteByte('{')
+		formatLevel(a.First)
+		buf.WriteByte('}')
+	default:
+		buf.WriteByte('{')
+		formatLevel(a.First)
+		buf.WriteString(" to ")
+		formatLevel(a.Last)
+		buf.WriteByte('}')
+	}
+}
+
+func (f *Filter) format(buf *strings.Builder, _ bool) {
+	buf.WriteString("?(")
+	f.Pred.format(buf, false /* parens */)
+	buf.WriteByte(')')
+}
+
+func (m *Method) format(buf *strings.Builder, _ bool) {
+	buf.WriteByte('.')
+	buf.WriteString(m.Kind.String())
+	buf.WriteString("()")
+}
+
+func (b *Binary) format(buf *strings.Builder, parens bool) {
+	if parens {
+		buf.WriteByte('(')
+	}
+	p := b.Op.priority()
+	b.Left.format(buf, priority(b.Left) <= p)
+	buf.WriteByte(' ')
+	buf.WriteString(b.Op.String())
+	buf.WriteByte(' ')
+	b.Right.format(buf, priority(b.Right) <= p)
+	if parens {
+		buf.WriteByte(')')
+	}
+}
+
+func (u *Unary) format(buf *strings.Builder, parens bool) {
+	switch u.Op {
+	case OpNot:
+		buf.WriteString("!(")
+		u.Operand.format(buf, false /* parens */)
+		buf.WriteByte(')')
+	case OpExists:
+		buf.WriteString("exists (")
+		u.Operand.format(buf, false /* parens */)
+		buf.WriteByte(')')
+	case OpIsUnknown:
+		buf.WriteByte('(')
+		u.Operand.format(buf, false /* parens */)
+		buf.WriteString(") is unknown")
+	default:
+		if parens {
+			buf.WriteByte('(')
+		}
+		buf.WriteString(u.Op.String())
+		u.Operand.format(buf, priority(u.Operand) <= u.Op.priority())
+		if parens {
+			buf.WriteByte(')')
+		}
+	}
+}
+
+func (l *LikeRegex) format(buf *strings.Builder, _ bool) {
+	l.Expr.format(buf, priority(l.Expr) <= OpEq.priority())
+	buf.WriteString(" like_regex ")
+	buf.WriteString(json.FromString(l.Pattern).String())
+	if l.Flags != "" {
+		buf.WriteString(" flag ")
+		buf.WriteString(json.FromString(l.Flags).String())
+	}
+}
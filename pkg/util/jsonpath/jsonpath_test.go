@@ -0,0 +1,117 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		input       string
+		expectedStr string
+	}{
+		{`$`, `$`},
+		{`lax $`, `$`},
+		{`strict $`, `strict $`},
+		{`$.a`, `$."a"`},
+		{`$."a b"`, `$."a b"`},
+		{`$.a.b.c`, `$."a"."b"."c"`},
+		{`$.*`, `$.*`},
+		{`$[*]`, `$[*]`},
+		{`$[0]`, `$[0]`},
+		{`$[1, 2 to 3]`, `$[1,2 to 3]`},
+		{`$[last]`, `$[last]`},
+		{`$[last - 1]`, `$[last - 1]`},
+		{`$.**`, `$.**`},
+		{`$.**{2}`, `$.**{2}`},
+		{`$.**{1 to last}`, `$.**{1 to last}`},
+		{`$.a.size()`, `$."a".size()`},
+		{`$.a.type()`, `$."a".type()`},
+		{`$.keyvalue()`, `$.keyvalue()`},
+		{`$.size`, `$."size"`},
+		{`$x`, `$"x"`},
+		{`$"x y"`, `$"x y"`},
+		{`1`, `1`},
+		{`-1`, `-1`},
+		{`1.5`, `1.5`},
+		{`"abc"`, `"abc"`},
+		{`"a\"b"`, `"a\"b"`},
+		{`true`, `true`},
+		{`null`, `null`},
+		{`$.a + 1`, `($."a" + 1)`},
+		{`$.a + 1 * 2`, `($."a" + 1 * 2)`},
+		{`($.a + 1) * 2`, `(($."a" + 1) * 2)`},
+		{`-$.a`, `(-$."a")`},
+		{`$.a == 1`, `($."a" == 1)`},
+		{`$.a <> 1`, `($."a" != 1)`},
+		{`$ ? (@ > 1)`, `$?(@ > 1)`},
+		{`$.a ? (@.b == "x" && @.c < 2)`, `$."a"?(@."b" == "x" && @."c" < 2)`},
+		{`$ ? (@ > 1 || @ < 0 && @ != -5)`, `$?(@ > 1 || @ < 0 && @ != -5)`},
+		{`$ ? ((@ > 1 || @ < 0) && @ != -5)`, `$?((@ > 1 || @ < 0) && @ != -5)`},
+		{`$ ? (!(@ > 1))`, `$?(!(@ > 1))`},
+		{`$ ? (exists (@.a))`, `$?(exists (@."a"))`},
+		{`$ ? ((@ > 1) is unknown)`, `$?((@ > 1) is unknown)`},
+		{`$ ? (@ starts with "a")`, `$?(@ starts with "a")`},
+		{`$ ? (@ like_regex "^a.*")`, `$?(@ like_regex "^a.*")`},
+		{`$ ? (@ like_regex "^a" flag "i")`, `$?(@ like_regex "^a" flag "i")`},
+		{`$.a[*] ? (@ > $min).b`, `$."a"[*]?(@ > $"min")."b"`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			p, err := Parse(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStr, p.String())
+
+			// The canonical representation must round trip.
+			p, err = Parse(p.String())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStr, p.String())
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		input  string
+		errMsg string
+	}{
+		{``, `syntax error at end of jsonpath input`},
+		{`$.`, `syntax error at end of jsonpath input`},
+		{`$[`, `syntax error at end of jsonpath input`},
+		{`$ $`, `syntax error at or near "$" of jsonpath input`},
+		{`$.a ==`, `syntax error at end of jsonpath input`},
+		{`@`, `@ is not allowed in root expressions`},
+		{`last`, `LAST is allowed only in array subscripts`},
+		{`$ ? (@)`, `syntax error at or near ")" of jsonpath input`},
+		{`$ ? (@ > 1) && 1`, `syntax error at or near "&&" of jsonpath input`},
+		{`1a`, `syntax error at or near "1a" of jsonpath input`},
+		{`"abc`, `unexpected end of quoted string in jsonpath input`},
+		{`$ ? (@ like_regex "a" flag "z")`, `unrecognized flag character 'z' in LIKE_REGEX predicate`},
+		{`$ ? (@ like_regex "(")`, `invalid regular expression`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestIsPredicate(t *testing.T) {
+	assert.False(t, MustParse(`$.a`).IsPredicate())
+	assert.False(t, MustParse(`$.a + 1`).IsPredicate())
+	assert.True(t, MustParse(`$.a > 1`).IsPredicate())
+	assert.True(t, MustParse(`exists($.a)`).IsPredicate())
+	assert.True(t, MustParse(`$.a like_regex "b"`).IsPredicate())
+}
@@ -0,0 +1,875 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	// tokIdent is an unquoted identifier or keyword.
+	tokIdent
+	// tokString is a double-quoted string.
+	tokString
+	// tokNumber is a numeric literal.
+	tokNumber
+	// tokVariable is a named variable, such as $x or $"x".
+	tokVariable
+	// tokPunct is an operator or punctuation.
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	// str is the text of identifiers, numbers and punctuation, and the unquoted
+	// value of strings and variables.
+	str string
+	// raw is the text of the token in the input.
+	raw string
+}
+
+// punctuation lists the multi-character operators before their prefixes.
+var punctuation = []string{
+	"==", "!=", "<>", "<=", ">=", "&&", "||", "**",
+	"$", "@", ".", ",", "[", "]", "(", ")", "{", "}", "?", "!", "<", ">",
+	"+", "-", "*", "/", "%",
+}
+
+// lexer splits a jsonpath string into tokens.
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) {
+		r, n := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.pos += n
+	}
+	if l.pos == len(l.input) {
+		return token{kind: tokEOF}, nil
+	}
+	start := l.pos
+	c := l.input[l.pos]
+	switch {
+	case c == '"':
+		s, err := l.scanString()
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokString, str: s, raw: l.input[start:l.pos]}, nil
+
+	case c == '$' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '"':
+		l.pos++
+		s, err := l.scanString()
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokVariable, str: s, raw: l.input[start:l.pos]}, nil
+
+	case c == '$' && l.pos+1 < len(l.input) && isIdentStart(l.input[l.pos+1:]):
+		l.pos++
+		name := l.scanIdent()
+		return token{kind: tokVariable, str: name, raw: l.input[start:l.pos]}, nil
+
+	case c >= '0' && c <= '9':
+		l.scanNumber()
+		num := l.input[start:l.pos]
+		if l.pos < len(l.input) && isIdentStart(l.input[l.pos:]) {
+			// Reject trailing junk after numeric literals, such as 1a.
+			return token{}, syntaxError(num + l.input[l.pos:l.pos+1])
+		}
+		return token{kind: tokNumber, str: num, raw: num}, nil
+
+	case isIdentStart(l.input[l.pos:]):
+		name := l.scanIdent()
+		return token{kind: tokIdent, str: name, raw: name}, nil
+	}
+	for _, p := range punctuation {
+		if strings.HasPrefix(l.input[l.pos:], p) {
+			l.pos += len(p)
+			return token{kind: tokPunct, str: p, raw: p}, nil
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[l.pos:])
+	return token{}, syntaxError(string(r))
+}
+
+func isIdentStart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func (l *lexer) scanIdent() string {
+	start := l.pos
+	for l.pos < len(l.input) {
+		r, n := utf8.DecodeRuneInString(l.input[l.pos:])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		l.pos += n
+	}
+	return l.input[start:l.pos]
+}
+
+func (l *lexer) scanDigits() {
+	for l.pos < len(l.input) && l.input[l.pos] >= '0' && l.input[l.pos] <= '9' {
+		l.pos++
+	}
+}
+
+func (l *lexer) scanNumber() {
+	l.scanDigits()
+	if l.pos+1 < len(l.input) && l.input[l.pos] == '.' &&
+		l.input[l.pos+1] >= '0' && l.input[l.pos+1] <= '9' {
+		l.pos++
+		l.scanDigits()
+	}
+	if l.pos < len(l.input) && (l.input[l.pos] == 'e' || l.input[l.pos] == 'E') {
+		save := l.pos
+		l.pos++
+		if l.pos < len(l.input) && (l.input[l.pos] == '+' || l.input[l.pos] == '-') {
+			l.pos++
+		}
+		if l.pos < len(l.input) && l.input[l.pos] >= '0' && l.input[l.pos] <= '9' {
+			l.scanDigits()
+		} else {
+			l.pos = save
+		}
+	}
+}
+
+// scanString scans a double-quoted string with JSON-style escapes, and returns
+// its unquoted value.
+func (l *lexer) scanString() (string, error) {
+	var buf strings.Builder
+	l.pos++
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return buf.String(), nil
+		case '\\':
+			l.pos++
+			if l.pos == len(l.input) {
+				break
+			}
+			e := l.input[l.pos]
+			l.pos++
+			switch e {
+			case 'b':
+				buf.WriteByte('\b')
+			case 'f':
+				buf.WriteByte('\f')
+			case 'n':
+				buf.WriteByte('\n')
+			case 'r':
+				buf.WriteByte('\r')
+			case 't':
+				buf.WriteByte('\t')
+			case 'v':
+				buf.WriteByte('\v')
+			case 'x':
+				if l.pos+2 > len(l.input) {
+					return "", invalidEscape()
+				}
+				v, err := strconv.ParseUint(l.input[l.pos:l.pos+2], 16, 8)
+				if err != nil {
+					return "", invalidEscape()
+				}
+				buf.WriteRune(rune(v))
+				l.pos += 2
+			case 'u':
+				if l.pos+4 > len(l.input) {
+					return "", invalidEscape()
+				}
+				v, err := strconv.ParseUint(l.input[l.pos:l.pos+4], 16, 16)
+				if err != nil {
+					return "", invalidEscape()
+				}
+				buf.WriteRune(rune(v))
+				l.pos += 4
+			default:
+				buf.WriteByte(e)
+			}
+		default:
+			buf.WriteByte(c)
+			l.pos++
+		}
+	}
+	return "", pgerror.New(pgcode.Syntax, "unexpected end of quoted string in jsonpath input")
+}
+
+func invalidEscape() error {
+	return pgerror.New(pgcode.Syntax, "invalid escape sequence in jsonpath input")
+}
+
+func syntaxError(near string) error {
+	if near == "" {
+		return pgerror.New(pgcode.Syntax, "syntax error at end of jsonpath input")
+	}
+	return pgerror.Newf(pgcode.Syntax, "syntax error at or near %q of jsonpath input", near)
+}
+
+// parser is a recursive descent parser for jsonpath expressions. The
+// operators, from the loosest to the tightest binding, are:
+//
+//	||
+//	&&
+//	== != <> < <= > >= like_regex starts with is unknown (non-associative)
+//	+ - (binary)
+//	* / %
+//	+ - (unary)
+//	accessors
+type parser struct {
+	lex lexer
+	tok token
+	// inFilter is the nesting depth of filter expressions, in which @ is
+	// allowed.
+	inFilter int
+	// inSubscript is the nesting depth of array subscripts, in which last is
+	// allowed.
+	inSubscript int
+}
+
+// Parse parses a jsonpath expression.
+func Parse(s string) (*Jsonpath, error) {
+	p := parser{lex: lexer{input: s}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var jp Jsonpath
+	if p.isIdent("strict") {
+		jp.Strict = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	} else if p.isIdent("lax") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, syntaxError(p.tok.raw)
+	}
+	jp.Expr = expr
+	return &jp, nil
+}
+
+func (p *parser) advance() (err error) {
+	p.tok, err = p.lex.next()
+	return err
+}
+
+func (p *parser) isIdent(s string) bool {
+	return p.tok.kind == tokIdent && p.tok.str == s
+}
+
+func (p *parser) isPunct(s string) bool {
+	return p.tok.kind == tokPunct && p.tok.str == s
+}
+
+func (p *parser) expectPunct(s string) error {
+	if !p.isPunct(s) {
+		return syntaxError(p.tok.raw)
+	}
+	return p.advance()
+}
+
+// expectPredicate returns an error if the expression is not a predicate.
+func (p *parser) expectPredicate(e Expr) error {
+	if !isPredicate(e) {
+		return syntaxError(p.tok.raw)
+	}
+	return nil
+}
+
+// expectValue returns an error if the expression is a predicate.
+func (p *parser) expectValue(e Expr) error {
+	if isPredicate(e) {
+		return syntaxError(p.tok.raw)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("||") {
+		if err := p.expectPredicate(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(right); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: OpOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("&&") {
+		if err := p.expectPredicate(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(right); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: OpAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+var comparisonOps = map[string]Op{
+	"==": OpEq, "!=": OpNe, "<>": OpNe, "<": OpLt, "<=": OpLe, ">": OpGt, ">=": OpGe,
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if op, ok := comparisonOps[p.tok.str]; ok && p.tok.kind == tokPunct {
+		if err := p.expectValue(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(right); err != nil {
+			return nil, err
+		}
+		return &Binary{Op: op, Left: left, Right: right}, nil
+	}
+	switch {
+	case p.isIdent("like_regex"):
+		if err := p.expectValue(left); err != nil {
+			return nil, err
+		}
+		return p.parseLikeRegex(left)
+
+	case p.isIdent("starts"):
+		if err := p.expectValue(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if !p.isIdent("with") {
+			return nil, syntaxError(p.tok.raw)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var right Expr
+		switch p.tok.kind {
+		case tokString:
+			right = &Literal{Value: json.FromString(p.tok.str)}
+		case tokVariable:
+			right = &Variable{Name: p.tok.str}
+		default:
+			return nil, syntaxError(p.tok.raw)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &Binary{Op: OpStartsWith, Left: left, Right: right}, nil
+
+	case p.isIdent("is"):
+		if err := p.expectPredicate(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if !p.isIdent("unknown") {
+			return nil, syntaxError(p.tok.raw)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &Unary{Op: OpIsUnknown, Operand: left}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseLikeRegex(left Expr) (Expr, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokString {
+		return nil, syntaxError(p.tok.raw)
+	}
+	lr := &LikeRegex{Expr: left, Pattern: p.tok.str}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.isIdent("flag") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokString {
+			return nil, syntaxError(p.tok.raw)
+		}
+		lr.Flags = p.tok.str
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	re, err := compileLikeRegex(lr.Pattern, lr.Flags)
+	if err != nil {
+		return nil, err
+	}
+	lr.re = re
+	return lr, nil
+}
+
+// compileLikeRegex compiles the pattern of a like_regex predicate. The flags
+// follow the XQuery fn:matches function: i (case-insensitive), s (dot matches
+// newline), m (multi-line) and q (literal pattern).
+func compileLikeRegex(pattern, flags string) (*regexp.Regexp, error) {
+	var prefix string
+	for _, f := range flags {
+		switch f {
+		case 'i', 's', 'm':
+			prefix += string(f)
+		case 'q':
+			pattern = regexp.QuoteMeta(pattern)
+		case 'x':
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				`XQuery "x" flag (expanded regular expressions) is not implemented`)
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"invalid input syntax for type jsonpath: unrecognized flag character %q in LIKE_REGEX predicate", f)
+		}
+	}
+	if prefix != "" {
+		pattern = "(?" + prefix + ")" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidRegularExpression, "invalid regular expression")
+	}
+	return re, nil
+}
+
+func (p *parser) parseAdditive() (Expr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("+") || p.isPunct("-") {
+		op := OpAdd
+		if p.tok.str == "-" {
+			op = OpSub
+		}
+		if err := p.expectValue(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(right); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("*") || p.isPunct("/") || p.isPunct("%") {
+		op := map[string]Op{"*": OpMul, "/": OpDiv, "%": OpMod}[p.tok.str]
+		if err := p.expectValue(left); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(right); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	switch {
+	case p.isPunct("+") || p.isPunct("-"):
+		op := OpPlus
+		if p.tok.str == "-" {
+			op = OpMinus
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(operand); err != nil {
+			return nil, err
+		}
+		// Fold signs into numeric literals, so that -1 is a constant.
+		if lit, ok := operand.(*Literal); ok {
+			if d, ok := lit.Value.AsDecimal(); ok {
+				if op == OpMinus {
+					var neg apd.Decimal
+					neg.Neg(d)
+					return &Literal{Value: json.FromDecimal(neg)}, nil
+				}
+				return lit, nil
+			}
+		}
+		return &Unary{Op: op, Operand: operand}, nil
+
+	case p.isPunct("!"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPredicate(operand); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return &Unary{Op: OpNot, Operand: operand}, nil
+
+	case p.isIdent("exists"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(operand); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return &Unary{Op: OpExists, Operand: operand}, nil
+	}
+	return p.parsePath()
+}
+
+func (p *parser) parsePath() (Expr, error) {
+	start, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	var accessors []Accessor
+	for {
+		var a Accessor
+		switch {
+		case p.isPunct("."):
+			if a, err = p.parseDotAccessor(); err != nil {
+				return nil, err
+			}
+		case p.isPunct("["):
+			if a, err = p.parseArrayAccessor(); err != nil {
+				return nil, err
+			}
+		case p.isPunct("?"):
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct("("); err != nil {
+				return nil, err
+			}
+			p.inFilter++
+			pred, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			p.inFilter--
+			if err := p.expectPredicate(pred); err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			a = &Filter{Pred: pred}
+		default:
+			if len(accessors) == 0 {
+				return start, nil
+			}
+			if isPredicate(start) {
+				return nil, syntaxError(p.tok.raw)
+			}
+			return &Path{Start: start, Accessors: accessors}, nil
+		}
+		accessors = append(accessors, a)
+	}
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	var e Expr
+	switch p.tok.kind {
+	case tokPunct:
+		switch p.tok.str {
+		case "$":
+			e = Root{}
+		case "@":
+			if p.inFilter == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "@ is not allowed in root expressions")
+			}
+			e = Current{}
+		case "(":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return inner, p.expectPunct(")")
+		default:
+			return nil, syntaxError(p.tok.raw)
+		}
+	case tokVariable:
+		e = &Variable{Name: p.tok.str}
+	case tokString:
+		e = &Literal{Value: json.FromString(p.tok.str)}
+	case tokNumber:
+		var d apd.Decimal
+		if _, _, err := d.SetString(p.tok.str); err != nil {
+			return nil, syntaxError(p.tok.raw)
+		}
+		e = &Literal{Value: json.FromDecimal(d)}
+	case tokIdent:
+		switch p.tok.str {
+		case "true":
+			e = &Literal{Value: json.TrueJSONValue}
+		case "false":
+			e = &Literal{Value: json.FalseJSONValue}
+		case "null":
+			e = &Literal{Value: json.NullJSONValue}
+		case "last":
+			if p.inSubscript == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "LAST is allowed only in array subscripts")
+			}
+			e = Last{}
+		default:
+			return nil, syntaxError(p.tok.raw)
+		}
+	default:
+		return nil, syntaxError(p.tok.raw)
+	}
+	return e, p.advance()
+}
+
+var methods = map[string]MethodKind{
+	"type":     MethodType,
+	"size":     MethodSize,
+	"double":   MethodDouble,
+	"abs":      MethodAbs,
+	"floor":    MethodFloor,
+	"ceiling":  MethodCeiling,
+	"keyvalue": MethodKeyValue,
+}
+
+// parseDotAccessor parses an accessor that starts with a dot: a member
+// accessor, a wildcard member accessor, or an item method.
+func (p *parser) parseDotAccessor() (Accessor, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.isPunct("*"):
+		return AnyKey{}, p.advance()
+	case p.isPunct("**"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return p.parseAnyPathLevels()
+	case p.tok.kind == tokString:
+		name := p.tok.str
+		return &Key{Name: name}, p.advance()
+	case p.tok.kind == tokIdent:
+		name := p.tok.str
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if kind, ok := methods[name]; ok && p.isPunct("(") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			return &Method{Kind: kind}, p.expectPunct(")")
+		}
+		return &Key{Name: name}, nil
+	}
+	return nil, syntaxError(p.tok.raw)
+}
+
+// parseAnyPathLevels parses the optional level range of a `.**` accessor, such
+// as `{2}` or `{1 to last}`.
+func (p *parser) parseAnyPathLevels() (Accessor, error) {
+	a := &AnyPath{First: 0, Last: -1}
+	if !p.isPunct("{") {
+		return a, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	parseLevel := func() (int, error) {
+		if p.isIdent("last") {
+			return -1, p.advance()
+		}
+		if p.tok.kind != tokNumber {
+			return 0, syntaxError(p.tok.raw)
+		}
+		level, err := strconv.Atoi(p.tok.str)
+		if err != nil {
+			return 0, syntaxError(p.tok.raw)
+		}
+		return level, p.advance()
+	}
+	var err error
+	if a.First, err = parseLevel(); err != nil {
+		return nil, err
+	}
+	a.Last = a.First
+	if p.isIdent("to") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if a.Last, err = parseLevel(); err != nil {
+			return nil, err
+		}
+	}
+	return a, p.expectPunct("}")
+}
+
+// parseArrayAccessor parses a wildcard array accessor or a list of array
+// subscripts.
+func (p *parser) parseArrayAccessor() (Accessor, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("*") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return AnyIndex{}, p.expectPunct("]")
+	}
+	p.inSubscript++
+	defer func() { p.inSubscript-- }()
+	var subs Subscripts
+	for {
+		from, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectValue(from); err != nil {
+			return nil, err
+		}
+		sub := Subscript{From: from}
+		if p.isIdent("to") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if sub.To, err = p.parseAdditive(); err != nil {
+				return nil, err
+			}
+			if err := p.expectValue(sub.To); err != nil {
+				return nil, err
+			}
+		}
+		subs = append(subs, sub)
+		if !p.isPunct(",") {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return subs, p.expectPunct("]")
+}
+
+// MustParse parses a jsonpath expression, and panics on error. It is intended
+// for tests.
+func MustParse(s string) *Jsonpath {
+	p, err := Parse(s)
+	if err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "parsing %q", s))
+	}
+	return p
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+var alphabet = "abcdefghijklmnopqrstuvwxyz"
+
+// RandomJsonpath returns a random Jsonpath for testing.
+func RandomJsonpath(rng *rand.Rand) *Jsonpath {
+	var sb strings.Builder
+	if rng.Intn(4) == 0 {
+		sb.WriteString("strict ")
+	}
+	sb.WriteString("$")
+	nAccessors := rng.Intn(5)
+	for i := 0; i < nAccessors; i++ {
+		switch rng.Intn(6) {
+		case 0:
+			sb.WriteString(".*")
+		case 1:
+			sb.WriteString("[*]")
+		case 2:
+			sb.WriteString("[")
+			sb.WriteString(strconv.Itoa(rng.Intn(10)))
+			sb.WriteString("]")
+		case 3:
+			sb.WriteString(" ? (@ > ")
+			sb.WriteString(strconv.Itoa(rng.Intn(100)))
+			sb.WriteString(")")
+		default:
+			l := make([]byte, 1+rng.Intn(5))
+			for i := range l {
+				l[i] = alphabet[rng.Intn(len(alphabet))]
+			}
+			sb.WriteString(".")
+			sb.Write(l)
+		}
+	}
+	return MustParse(sb.String())
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parquet
+
+import (
+	"github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// Reader reads the rows of a parquet file as datums. It reads one row group
+// at a time.
+//
+// Columns are matched by name, and each requested column must have the
+// physical layout that the Writer uses for its type. Tuple columns are not
+// supported.
+type Reader struct {
+	reader *file.Reader
+	typs   []*types.T
+	// physicalCols are the indexes of the physical columns in the file
+	// corresponding to the requested columns.
+	physicalCols []int
+	decoders     []decoder
+
+	rowGroup int
+	rows     [][]tree.Datum
+	rowIdx   int
+}
+
+// NewReader returns a Reader which reads the columns with the given names
+// from a parquet file, decoding them into datums of the given types.
+func NewReader(r parquet.ReaderAtSeeker, colNames []string, typs []*types.T) (*Reader, error) {
+	if len(colNames) != len(typs) {
+		return nil, errors.AssertionFailedf("the number of column names must match the number of column types")
+	}
+	for i, typ := range typs {
+		if typ.Family() == types.TupleFamily {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q: reading tuples from parquet files is not supported", colNames[i])
+		}
+	}
+	// The expected schema is the one the Writer would use for these columns.
+	// Since there are no tuples, each column has exactly one physical column.
+	expected, err := NewSchema(colNames, typs)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := file.NewParquetReader(r)
+	if err != nil {
+		return nil, err
+	}
+	sch := reader.MetaData().Schema
+	byName := make(map[string]int, sch.NumColumns())
+	for i := 0; i < sch.NumColumns(); i++ {
+		col := sch.Column(i)
+		// Skip the fields of tuples, which are nested in a group. See the
+		// comment above tupleFieldNonNilDefLevel.
+		if col.MaxDefinitionLevel() == 2 {
+			continue
+		}
+		byName[col.ColumnPath()[0]] = i
+	}
+
+	res := &Reader{
+		reader:       reader,
+		typs:         typs,
+		physicalCols: make([]int, len(colNames)),
+		decoders:     make([]decoder, len(colNames)),
+	}
+	for i, name := range colNames {
+		idx, ok := byName[name]
+		if !ok {
+			return nil, errors.CombineErrors(
+				pgerror.Newf(pgcode.UndefinedColumn, "column %q not found in parquet file", name),
+				reader.Close(),
+			)
+		}
+		got, want := sch.Column(idx), expected.schema.Column(i)
+		if got.PhysicalType() != want.PhysicalType() ||
+			got.MaxDefinitionLevel() != want.MaxDefinitionLevel() {
+			return nil, errors.CombineErrors(
+				pgerror.Newf(pgcode.DatatypeMismatch,
+					"column %q of type %s cannot be read from parquet column of type %s",
+					name, typs[i].SQLString(), got.PhysicalType()),
+				reader.Close(),
+			)
+		}
+		res.physicalCols[i] = idx
+		elemTyp := typs[i]
+		if elemTyp.Family() == types.ArrayFamily {
+			elemTyp = elemTyp.ArrayContents()
+		}
+		if res.decoders[i], err = decoderFromFamilyAndType(elemTyp.Oid(), elemTyp.Family()); err != nil {
+			return nil, errors.CombineErrors(err, reader.Close())
+		}
+	}
+	return res, nil
+}
+
+// Next returns the next row of the file, or nil if there are no more rows.
+// The returned row is safe to hold onto.
+func (r *Reader) Next() (tree.Datums, error) {
+	for r.rowIdx >= len(r.rows) {
+		if r.rowGroup >= r.reader.NumRowGroups() {
+			return nil, nil
+		}
+		if err := r.readRowGroup(); err != nil {
+			return nil, err
+		}
+	}
+	row := r.rows[r.rowIdx]
+	r.rows[r.rowIdx] = nil
+	r.rowIdx++
+	return row, nil
+}
+
+// readRowGroup decodes the next row group of the file into r.rows.
+func (r *Reader) readRowGroup() error {
+	rgr := r.reader.RowGroup(r.rowGroup)
+	r.rowGroup++
+	numRows := rgr.NumRows()
+	r.rows = make([][]tree.Datum, numRows)
+	r.rowIdx = 0
+	for i := range r.rows {
+		r.rows[i] = make([]tree.Datum, len(r.typs))
+	}
+	for i, idx := range r.physicalCols {
+		col, err := rgr.Column(idx)
+		if err != nil {
+			return err
+		}
+		isArray := r.typs[i].Family() == types.ArrayFamily
+		colDatums, err := readColInRowGroup(col, r.decoders[i], numRows, isArray, false /* isTuple */)
+		if err != nil {
+			return err
+		}
+		for rowIdx, d := range colDatums {
+			if arr, ok := d.(*tree.DArray); ok {
+				arr.ParamTyp = r.typs[i].ArrayContents()
+			}
+			r.rows[rowIdx][i] = d
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying file reader.
+func (r *Reader) Close() error {
+	return r.reader.Close()
+}